@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/megu/kaji-challenge/backend/internal/http/infra"
+)
+
+// runExport writes one team as a versioned JSON archive that runImport can
+// restore in another environment.
+func runExport(args []string, logger *log.Logger, runner opsRunner) int {
+	fs := flag.NewFlagSet("ops export", flag.ContinueOnError)
+	fs.SetOutput(logger.Writer())
+
+	teamID := fs.String("team-id", "", "team to export (required)")
+	out := fs.String("out", "-", "archive path, or - for stdout")
+
+	if err := fs.Parse(args); err != nil {
+		logger.Printf("failed to parse export flags: %v", err)
+		return exitFailure
+	}
+	id := strings.TrimSpace(*teamID)
+	if id == "" {
+		logger.Printf("missing --team-id")
+		return exitFailure
+	}
+
+	archive, err := runner.ExportTeam(context.Background(), id)
+	if err != nil {
+		logger.Printf("ops export failed: team_id=%s err=%v", id, err)
+		return exitFailure
+	}
+	body, err := json.MarshalIndent(archive, "", "  ")
+	if err != nil {
+		logger.Printf("failed to encode archive: %v", err)
+		return exitFailure
+	}
+	body = append(body, '\n')
+	// The archive alone goes to stdout so it can be piped into a file.
+	if *out == "-" {
+		if _, err := logger.Writer().Write(body); err != nil {
+			logger.Printf("failed to write archive: %v", err)
+			return exitFailure
+		}
+		return exitOK
+	}
+	if err := os.WriteFile(*out, body, 0o600); err != nil {
+		logger.Printf("failed to write archive: %v", err)
+		return exitFailure
+	}
+	logger.Printf(
+		"ops export finished: team_id=%s members=%d tasks=%d penalty_rules=%d monthly_summaries=%d out=%s",
+		id,
+		len(archive.Members),
+		len(archive.Tasks),
+		len(archive.PenaltyRules),
+		len(archive.MonthlySummaries),
+		*out,
+	)
+	return exitOK
+}
+
+// runImport restores an archive written by runExport under new IDs.
+func runImport(args []string, logger *log.Logger, runner opsRunner) int {
+	fs := flag.NewFlagSet("ops import", flag.ContinueOnError)
+	fs.SetOutput(logger.Writer())
+
+	in := fs.String("in", "", "archive path, or - for stdin (required)")
+	dryRun := fs.Bool("dry-run", false, "validate and import inside a rolled-back transaction")
+
+	if err := fs.Parse(args); err != nil {
+		logger.Printf("failed to parse import flags: %v", err)
+		return exitFailure
+	}
+	if strings.TrimSpace(*in) == "" {
+		logger.Printf("missing --in")
+		return exitFailure
+	}
+
+	archive, err := readArchive(*in)
+	if err != nil {
+		logger.Printf("failed to read archive: %v", err)
+		return exitFailure
+	}
+	res, err := runner.ImportTeam(context.Background(), archive, *dryRun)
+	if err != nil {
+		logger.Printf("ops import failed: source_team_id=%s err=%v", archive.Team.ID, err)
+		return exitFailure
+	}
+	logger.Printf(
+		"ops import finished: source_team_id=%s team_id=%s dry_run=%t members=%d tasks=%d task_categories=%d checklist_items=%d daily_completions=%d weekly_completions=%d one_off_completions=%d penalty_rules=%d monthly_summaries=%d close_runs=%d task_evaluations=%d close_run_history=%d absences=%d holidays=%d",
+		archive.Team.ID,
+		res.TeamID,
+		res.DryRun,
+		res.Members,
+		res.Tasks,
+		res.TaskCategories,
+		res.ChecklistItems,
+		res.DailyCompletions,
+		res.WeeklyCompletions,
+		res.OneOffCompletions,
+		res.PenaltyRules,
+		res.MonthlySummaries,
+		res.CloseRuns,
+		res.TaskEvaluations,
+		res.CloseRunHistory,
+		res.Absences,
+		res.Holidays,
+	)
+	return exitOK
+}
+
+// readArchive rejects unknown fields, so an archive from a newer layout fails
+// loudly instead of importing partially.
+func readArchive(path string) (infra.TeamArchive, error) {
+	var body []byte
+	var err error
+	if path == "-" {
+		body, err = io.ReadAll(os.Stdin)
+	} else {
+		body, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return infra.TeamArchive{}, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(body))
+	dec.DisallowUnknownFields()
+	var archive infra.TeamArchive
+	if err := dec.Decode(&archive); err != nil {
+		return infra.TeamArchive{}, fmt.Errorf("decode archive: %w", err)
+	}
+	return archive, nil
+}
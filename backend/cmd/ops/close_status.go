@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+type teamCloseStatus struct {
+	TeamID  string                 `json:"teamId"`
+	Lagging bool                   `json:"lagging"`
+	Status  []api.CloseScopeStatus `json:"status"`
+}
+
+// runCloseStatus lists the latest closed period per scope for each team and
+// flags teams that fall behind, e.g. after a failed or skipped job.
+func runCloseStatus(args []string, logger *log.Logger, runner opsRunner) int {
+	fs := flag.NewFlagSet("ops close-status", flag.ContinueOnError)
+	fs.SetOutput(logger.Writer())
+
+	teamID := fs.String("team-id", "", "target team id (default: all teams)")
+	laggingOnly := fs.Bool("lagging-only", false, "list only teams with an unclosed period")
+	format := fs.String("format", "text", "output format: text|json")
+
+	if err := fs.Parse(args); err != nil {
+		logger.Printf("failed to parse close-status flags: %v", err)
+		return exitFailure
+	}
+	if *format != "text" && *format != "json" {
+		logger.Printf("invalid --format %q (expected: text|json)", *format)
+		return exitFailure
+	}
+
+	ctx := context.Background()
+	targets := []string{}
+	if id := strings.TrimSpace(*teamID); id != "" {
+		targets = append(targets, id)
+	} else {
+		list, err := runner.ListClosableTeamIDs(ctx)
+		if err != nil {
+			logger.Printf("failed to list closable teams: %v", err)
+			return exitFailure
+		}
+		targets = list
+	}
+
+	results := make([]teamCloseStatus, 0, len(targets))
+	lagging := 0
+	for _, id := range targets {
+		status, err := runner.GetCloseStatusForTeam(ctx, id)
+		if err != nil {
+			logger.Printf("failed to load close status: team_id=%s err=%v", id, err)
+			return exitFailure
+		}
+		res := teamCloseStatus{TeamID: id, Status: status}
+		for _, scope := range status {
+			res.Lagging = res.Lagging || scope.Lagging
+		}
+		if res.Lagging {
+			lagging++
+		} else if *laggingOnly {
+			continue
+		}
+		results = append(results, res)
+	}
+
+	if *format == "json" {
+		body, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			logger.Printf("failed to encode close status: %v", err)
+			return exitFailure
+		}
+		_, _ = fmt.Fprintln(logger.Writer(), string(body))
+		return exitOK
+	}
+	for _, res := range results {
+		for _, scope := range res.Status {
+			logger.Printf(
+				"team_id=%s scope=%s latest=%s expected=%s lagging=%t%s",
+				res.TeamID,
+				scope.Scope,
+				formatStatusDate(scope.LatestTargetDate),
+				scope.ExpectedTargetDate.String(),
+				scope.Lagging,
+				formatLastRun(scope.LastRun),
+			)
+		}
+	}
+	logger.Printf("ops close-status finished: teams=%d lagging=%d", len(targets), lagging)
+	return exitOK
+}
+
+func formatStatusDate(d *openapi_types.Date) string {
+	if d == nil {
+		return "-"
+	}
+	return d.String()
+}
+
+func formatLastRun(run *api.CloseRun) string {
+	if run == nil {
+		return " last_run=-"
+	}
+	out := fmt.Sprintf(" last_run=%s trigger=%s", run.FinishedAt.Format("2006-01-02T15:04:05-07:00"), run.Trigger)
+	if run.ErrorClass != nil {
+		out += " error_class=" + *run.ErrorClass
+	}
+	return out
+}
@@ -0,0 +1,152 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/megu/kaji-challenge/backend/internal/http/infra"
+)
+
+const defaultGCBatchSize = 1000
+
+// runGC purges expired auth artifacts, stale sessions and soft-deleted rows
+// past their retention. Every target runs with its default retention unless
+// --retention overrides it; --only narrows the run to some targets.
+func runGC(args []string, logger *log.Logger, runner opsRunner) int {
+	fs := flag.NewFlagSet("ops gc", flag.ContinueOnError)
+	fs.SetOutput(logger.Writer())
+
+	dryRun := fs.Bool("dry-run", false, "count purgeable rows without deleting anything")
+	batchSize := fs.Int("batch-size", defaultGCBatchSize, "rows deleted per statement")
+	retention := fs.String("retention", "", "per-target retention overrides, e.g. sessions=14d,deleted_tasks=4320h")
+	only := fs.String("only", "", "comma-separated targets to run (default: all)")
+	format := fs.String("format", "text", "output format: text|json")
+
+	if err := fs.Parse(args); err != nil {
+		logger.Printf("failed to parse gc flags: %v", err)
+		return exitFailure
+	}
+	if *format != "text" && *format != "json" {
+		logger.Printf("invalid --format %q (expected: text|json)", *format)
+		return exitFailure
+	}
+	policies, err := gcPolicies(infra.DefaultGCPolicies(), *retention, *only)
+	if err != nil {
+		logger.Printf("invalid gc policy: %v", err)
+		return exitFailure
+	}
+
+	results, runErr := runner.RunGC(context.Background(), policies, *batchSize, *dryRun)
+	if *format == "json" {
+		body, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			logger.Printf("failed to encode gc report: %v", err)
+			return exitFailure
+		}
+		_, _ = fmt.Fprintln(logger.Writer(), string(body))
+	} else {
+		for _, res := range results {
+			logger.Printf(
+				"target=%s retention=%s cutoff=%s matched=%d deleted=%d batches=%d",
+				res.Target,
+				res.Retention,
+				res.Cutoff.Format(time.RFC3339),
+				res.Matched,
+				res.Deleted,
+				res.Batches,
+			)
+		}
+	}
+	if runErr != nil {
+		logger.Printf("ops gc failed: %v", runErr)
+		return exitFailure
+	}
+	if *format == "text" {
+		logger.Printf("ops gc finished: targets=%d dry_run=%t", len(results), *dryRun)
+	}
+	return exitOK
+}
+
+// gcPolicies applies the --retention overrides and --only filter to the
+// default policies, keeping their order.
+func gcPolicies(defaults []infra.GCPolicy, retention, only string) ([]infra.GCPolicy, error) {
+	known := map[string]bool{}
+	for _, p := range defaults {
+		known[p.Target] = true
+	}
+	overrides := map[string]time.Duration{}
+	for _, item := range splitList(retention) {
+		target, raw, ok := strings.Cut(item, "=")
+		if !ok {
+			return nil, fmt.Errorf("retention %q: expected target=duration", item)
+		}
+		target = strings.TrimSpace(target)
+		if !known[target] {
+			return nil, fmt.Errorf("unknown target %q", target)
+		}
+		d, err := parseRetention(raw)
+		if err != nil {
+			return nil, fmt.Errorf("retention for %s: %w", target, err)
+		}
+		overrides[target] = d
+	}
+	selected := map[string]bool{}
+	for _, target := range splitList(only) {
+		if !known[target] {
+			return nil, fmt.Errorf("unknown target %q", target)
+		}
+		selected[target] = true
+	}
+
+	policies := make([]infra.GCPolicy, 0, len(defaults))
+	for _, p := range defaults {
+		if len(selected) > 0 && !selected[p.Target] {
+			continue
+		}
+		if d, ok := overrides[p.Target]; ok {
+			p.Retention = d
+		}
+		policies = append(policies, p)
+	}
+	return policies, nil
+}
+
+// parseRetention accepts Go durations plus a day suffix, since retention is
+// usually thought of in days.
+func parseRetention(raw string) (time.Duration, error) {
+	raw = strings.TrimSpace(raw)
+	var d time.Duration
+	if days, ok := strings.CutSuffix(raw, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", raw)
+		}
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", raw)
+		}
+		d = parsed
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("must not be negative")
+	}
+	return d, nil
+}
+
+func splitList(raw string) []string {
+	items := []string{}
+	for _, item := range strings.Split(raw, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -2,50 +2,80 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"log"
 	"os"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/megu/kaji-challenge/backend/internal/http/infra"
 	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
 )
 
-type closeRunner interface {
+type opsRunner interface {
 	ListClosableTeamIDs(ctx context.Context) ([]string, error)
-	CloseDayForTeam(ctx context.Context, teamID string) (api.CloseResponse, error)
-	CloseWeekForTeam(ctx context.Context, teamID string) (api.CloseResponse, error)
-	CloseMonthForTeam(ctx context.Context, teamID string) (api.CloseResponse, error)
+	RunCloseForTeam(ctx context.Context, teamID, scope string) (infra.CloseReport, error)
+	PreviewCloseForTeam(ctx context.Context, teamID, scope string) (infra.CloseReport, error)
+	GetCloseStatusForTeam(ctx context.Context, teamID string) ([]api.CloseScopeStatus, error)
+	RunGC(ctx context.Context, policies []infra.GCPolicy, batchSize int, dryRun bool) ([]infra.GCResult, error)
+	ExportTeam(ctx context.Context, teamID string) (infra.TeamArchive, error)
+	ImportTeam(ctx context.Context, archive infra.TeamArchive, dryRun bool) (infra.TeamImportResult, error)
+	SetClock(clock infra.Clock)
 }
 
+const (
+	opsTimeZone        = "Asia/Tokyo"
+	defaultTeamTimeout = 10 * time.Minute
+	// maxConcurrency bounds the pool connections a run can hold: each team
+	// in flight keeps one for its lock and transactions.
+	maxConcurrency = 16
+)
+
 func main() {
 	logger := log.New(os.Stdout, "", log.LstdFlags)
 	store := infra.NewStore()
 	os.Exit(run(os.Args[1:], logger, store))
 }
 
-func run(args []string, logger *log.Logger, runner closeRunner) int {
+func run(args []string, logger *log.Logger, runner opsRunner) int {
 	if len(args) == 0 {
-		logger.Printf("missing subcommand (expected: close)")
+		logger.Printf("missing subcommand (expected: close|close-status|gc|export|import)")
 		return 1
 	}
 	switch args[0] {
 	case "close":
 		return runClose(args[1:], logger, runner)
+	case "close-status":
+		return runCloseStatus(args[1:], logger, runner)
+	case "gc":
+		return runGC(args[1:], logger, runner)
+	case "export":
+		return runExport(args[1:], logger, runner)
+	case "import":
+		return runImport(args[1:], logger, runner)
 	default:
-		logger.Printf("unsupported subcommand %q (expected: close)", args[0])
+		logger.Printf("unsupported subcommand %q (expected: close|close-status|gc|export|import)", args[0])
 		return 1
 	}
 }
 
-func runClose(args []string, logger *log.Logger, runner closeRunner) int {
+func runClose(args []string, logger *log.Logger, runner opsRunner) int {
 	fs := flag.NewFlagSet("ops close", flag.ContinueOnError)
 	fs.SetOutput(logger.Writer())
 
 	scope := fs.String("scope", "", "close scope: day|week|month")
 	allTeams := fs.Bool("all-teams", true, "run close for all teams")
 	teamID := fs.String("team-id", "", "target team id (optional)")
+	asOf := fs.String("as-of", "", "run as of this instant (RFC3339, or YYYY-MM-DD for 00:00 Asia/Tokyo); default now")
+	dryRun := fs.Bool("dry-run", false, "report what would be closed without writing anything")
+	format := fs.String("format", "text", "dry-run output format: text|json")
+	concurrency := fs.Int("concurrency", 1, "number of teams closed in parallel")
+	teamTimeout := fs.Duration("team-timeout", defaultTeamTimeout, "time limit for closing one team")
+	reportPath := fs.String("report", "", "write a JSON run report to this file, or - for stdout")
 
 	if err := fs.Parse(args); err != nil {
 		logger.Printf("failed to parse close flags: %v", err)
@@ -55,6 +85,42 @@ func runClose(args []string, logger *log.Logger, runner closeRunner) int {
 		logger.Printf("invalid --scope %q (expected: day|week|month)", *scope)
 		return 1
 	}
+	if *format != "text" && *format != "json" {
+		logger.Printf("invalid --format %q (expected: text|json)", *format)
+		return 1
+	}
+	if *concurrency < 1 || *concurrency > maxConcurrency {
+		logger.Printf("invalid --concurrency %d (expected: 1-%d)", *concurrency, maxConcurrency)
+		return 1
+	}
+	if *teamTimeout <= 0 {
+		logger.Printf("invalid --team-timeout %s (expected: positive duration)", *teamTimeout)
+		return 1
+	}
+	pool := teamPool{concurrency: *concurrency, timeout: *teamTimeout}
+	if *dryRun && strings.TrimSpace(*reportPath) != "" {
+		logger.Printf("--report cannot be combined with --dry-run (use --format=json)")
+		return 1
+	}
+	// JSON on stdout must stay a single parseable document, so progress logs
+	// are suppressed in that mode.
+	quiet := (*dryRun && *format == "json") || *reportPath == "-"
+	progress := logger
+	if quiet {
+		progress = log.New(io.Discard, "", 0)
+	}
+
+	report := runReport{Scope: *scope, StartedAt: time.Now(), Teams: []teamRunReport{}}
+	if strings.TrimSpace(*asOf) != "" {
+		at, err := parseAsOf(*asOf, time.Now())
+		if err != nil {
+			logger.Printf("invalid --as-of %q: %v", *asOf, err)
+			return 1
+		}
+		runner.SetClock(infra.FixedClock(at))
+		report.AsOf = &at
+		progress.Printf("ops close as-of: %s", at.Format(time.RFC3339))
+	}
 
 	targetTeamID := strings.TrimSpace(*teamID)
 	ctx := context.Background()
@@ -64,8 +130,9 @@ func runClose(args []string, logger *log.Logger, runner closeRunner) int {
 	} else if *allTeams {
 		list, err := runner.ListClosableTeamIDs(ctx)
 		if err != nil {
-			logger.Printf("failed to list closable teams: %v", err)
-			return 1
+			progress.Printf("failed to list closable teams: %v", err)
+			report.Error = fmt.Sprintf("list closable teams: %v", err)
+			return finishRun(*reportPath, logger, report)
 		}
 		targets = list
 	} else {
@@ -73,50 +140,173 @@ func runClose(args []string, logger *log.Logger, runner closeRunner) int {
 		return 1
 	}
 
-	logger.Printf("ops close started: scope=%s targets=%d", *scope, len(targets))
-	logger.Printf("ops close catch-up mode: pending periods are processed continuously")
-	processed := 0
-	succeeded := 0
-	failed := 0
-	for _, id := range targets {
-		processed++
-		res, err := runScope(ctx, runner, *scope, id)
+	if *dryRun {
+		return runCloseDryRun(ctx, logger, runner, pool, *scope, *format, targets)
+	}
+
+	progress.Printf("ops close started: scope=%s targets=%d concurrency=%d", *scope, len(targets), pool.concurrency)
+	progress.Printf("ops close catch-up mode: pending periods are processed continuously")
+	results := make([]teamRunReport, len(targets))
+	pool.run(ctx, targets, func(ctx context.Context, i int, id string) error {
+		startedAt := time.Now()
+		res, err := runner.RunCloseForTeam(ctx, id, *scope)
+		results[i] = newTeamRunReport(id, *scope, res, err, time.Since(startedAt))
 		if err != nil {
-			failed++
-			logger.Printf("ops close failed: scope=%s team_id=%s err=%v", *scope, id, err)
-			continue
+			progress.Printf("ops close failed: scope=%s team_id=%s err=%v", *scope, id, err)
+			return err
 		}
-		succeeded++
-		logger.Printf(
-			"ops close succeeded: scope=%s team_id=%s month=%s closed_at=%s",
+		progress.Printf(
+			"ops close succeeded: scope=%s team_id=%s month=%s closed_at=%s periods=%d penalty_added=%d",
 			*scope,
 			id,
 			res.Month,
-			res.ClosedAt.Format("2006-01-02T15:04:05-07:00"),
+			res.AsOf.Format("2006-01-02T15:04:05-07:00"),
+			len(res.Periods),
+			res.PenaltyAdded(),
+		)
+		return nil
+	})
+	report.Teams = results
+	return finishRun(*reportPath, logger, report)
+}
+
+// finishRun logs the summary, writes the --report file and returns the exit
+// code for the run.
+func finishRun(reportPath string, logger *log.Logger, report runReport) int {
+	report.finish(time.Now())
+	if reportPath != "-" {
+		logger.Printf(
+			"ops close finished: scope=%s processed=%d succeeded=%d failed=%d status=%s",
+			report.Scope,
+			report.Processed,
+			report.Succeeded,
+			report.Failed,
+			report.Status,
 		)
 	}
-	logger.Printf(
-		"ops close finished: scope=%s processed=%d succeeded=%d failed=%d",
-		*scope,
-		processed,
-		succeeded,
-		failed,
-	)
-	if failed > 0 {
-		return 1
+	if strings.TrimSpace(reportPath) != "" {
+		if err := writeRunReport(reportPath, logger, report); err != nil {
+			logger.Printf("failed to write run report: %v", err)
+			return exitFailure
+		}
 	}
-	return 0
+	return report.ExitCode
 }
 
-func runScope(ctx context.Context, runner closeRunner, scope, teamID string) (api.CloseResponse, error) {
-	switch scope {
-	case "day":
-		return runner.CloseDayForTeam(ctx, teamID)
-	case "week":
-		return runner.CloseWeekForTeam(ctx, teamID)
-	case "month":
-		return runner.CloseMonthForTeam(ctx, teamID)
-	default:
-		return api.CloseResponse{}, fmt.Errorf("unsupported scope: %s", scope)
+type dryRunResult struct {
+	TeamID string             `json:"teamId"`
+	Report *infra.CloseReport `json:"report,omitempty"`
+	Error  string             `json:"error,omitempty"`
+}
+
+func runCloseDryRun(ctx context.Context, logger *log.Logger, runner opsRunner, pool teamPool, scope, format string, targets []string) int {
+	results := make([]dryRunResult, len(targets))
+	errs := pool.run(ctx, targets, func(ctx context.Context, i int, id string) error {
+		report, err := runner.PreviewCloseForTeam(ctx, id, scope)
+		if err != nil {
+			results[i] = dryRunResult{TeamID: id, Error: err.Error()}
+			return err
+		}
+		results[i] = dryRunResult{TeamID: id, Report: &report}
+		return nil
+	})
+	failed := countErrors(errs)
+
+	if format == "json" {
+		body, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			logger.Printf("failed to encode dry-run report: %v", err)
+			return 1
+		}
+		_, _ = fmt.Fprintln(logger.Writer(), string(body))
+	} else {
+		logger.Printf("ops close dry-run: scope=%s targets=%d (nothing is written)", scope, len(targets))
+		for _, res := range results {
+			if res.Report == nil {
+				logger.Printf("ops close dry-run failed: scope=%s team_id=%s err=%s", scope, res.TeamID, res.Error)
+				continue
+			}
+			printCloseReport(logger, *res.Report)
+		}
+		logger.Printf("ops close dry-run finished: scope=%s processed=%d failed=%d", scope, len(targets), failed)
+	}
+	return exitCodeFor(len(targets), failed)
+}
+
+func printCloseReport(logger *log.Logger, report infra.CloseReport) {
+	logger.Printf("team_id=%s as_of=%s periods=%d", report.TeamID, report.AsOf.Format(time.RFC3339), len(report.Periods))
+	for _, p := range report.Periods {
+		if p.Scope == "month" {
+			logger.Printf("  close month %s: penalty_total=%d triggered_rules=%d", p.Target, p.PenaltyTotal, len(p.TriggeredRules))
+		} else {
+			logger.Printf("  close %s %s: month=%s penalty_total=%d penalized_tasks=%d", p.Scope, p.Target, p.Month, p.PenaltyTotal, len(p.Penalties))
+		}
+		for _, penalty := range p.Penalties {
+			logger.Printf("    task_id=%s title=%q points=%d", penalty.TaskID, penalty.Title, penalty.Points)
+		}
+		for _, rule := range p.TriggeredRules {
+			logger.Printf("    rule_id=%s name=%q threshold=%d", rule.RuleID, rule.Name, rule.Threshold)
+		}
+	}
+}
+
+// teamPool runs per-team work with bounded parallelism. Each team gets its
+// own deadline so one stuck team cannot hold up the rest of the run.
+type teamPool struct {
+	concurrency int
+	timeout     time.Duration
+}
+
+// run calls fn for every target and returns the errors in target order.
+// fn receives the target index so it can store results without locking.
+func (p teamPool) run(ctx context.Context, targets []string, fn func(ctx context.Context, i int, teamID string) error) []error {
+	errs := make([]error, len(targets))
+	sem := make(chan struct{}, p.concurrency)
+	var wg sync.WaitGroup
+	for i, id := range targets {
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int, id string) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			teamCtx, cancel := context.WithTimeout(ctx, p.timeout)
+			defer cancel()
+			errs[i] = fn(teamCtx, i, id)
+		}(i, id)
+	}
+	wg.Wait()
+	return errs
+}
+
+func countErrors(errs []error) int {
+	n := 0
+	for _, err := range errs {
+		if err != nil {
+			n++
+		}
+	}
+	return n
+}
+
+// parseAsOf rejects instants after now: closing a period that has not ended
+// yet would settle penalties for tasks that can still be completed.
+func parseAsOf(raw string, now time.Time) (time.Time, error) {
+	raw = strings.TrimSpace(raw)
+	at, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		loc, locErr := time.LoadLocation(opsTimeZone)
+		if locErr != nil {
+			loc = time.FixedZone("JST", 9*60*60)
+		}
+		at, err = time.ParseInLocation("2006-01-02", raw, loc)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("expected RFC3339 or YYYY-MM-DD")
+		}
+	}
+	if at.After(now) {
+		return time.Time{}, fmt.Errorf("must not be in the future")
 	}
+	return at, nil
 }
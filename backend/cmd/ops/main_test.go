@@ -3,16 +3,23 @@ package main
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"log"
+	"os"
+	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/megu/kaji-challenge/backend/internal/http/infra"
 	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
-type fakeCloseRunner struct {
+type fakeOpsRunner struct {
 	list []string
 
 	listErr error
@@ -21,49 +28,139 @@ type fakeCloseRunner struct {
 	weekErrByTeam  map[string]error
 	monthErrByTeam map[string]error
 
+	reportByTeam     map[string]infra.CloseReport
+	previewByTeam    map[string]infra.CloseReport
+	previewErrByTeam map[string]error
+	statusByTeam     map[string][]api.CloseScopeStatus
+
+	gcErr      error
+	gcPolicies []infra.GCPolicy
+	gcBatch    int
+	gcDryRun   bool
+
+	archiveByTeam map[string]infra.TeamArchive
+	imported      []infra.TeamArchive
+	importErr     error
+
+	// hangTeams block until the per-team context expires.
+	hangTeams map[string]bool
+	delay     time.Duration
+
+	mu          sync.Mutex
+	inFlight    int
+	maxInFlight int
 	closedTeams []string
+	clock       infra.Clock
+}
+
+func (f *fakeOpsRunner) begin(ctx context.Context, call, teamID string) error {
+	f.mu.Lock()
+	f.closedTeams = append(f.closedTeams, call+":"+teamID)
+	f.inFlight++
+	if f.inFlight > f.maxInFlight {
+		f.maxInFlight = f.inFlight
+	}
+	f.mu.Unlock()
+	defer func() {
+		f.mu.Lock()
+		f.inFlight--
+		f.mu.Unlock()
+	}()
+	if f.hangTeams[teamID] {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	time.Sleep(f.delay)
+	return nil
 }
 
-func (f *fakeCloseRunner) ListClosableTeamIDs(context.Context) ([]string, error) {
+func (f *fakeOpsRunner) SetClock(clock infra.Clock) {
+	f.clock = clock
+}
+
+func (f *fakeOpsRunner) ListClosableTeamIDs(context.Context) ([]string, error) {
 	if f.listErr != nil {
 		return nil, f.listErr
 	}
 	return append([]string{}, f.list...), nil
 }
 
-func (f *fakeCloseRunner) CloseDayForTeam(_ context.Context, teamID string) (api.CloseResponse, error) {
-	f.closedTeams = append(f.closedTeams, "day:"+teamID)
-	if err := f.dayErrByTeam[teamID]; err != nil {
-		return api.CloseResponse{}, err
+func (f *fakeOpsRunner) RunCloseForTeam(ctx context.Context, teamID, scope string) (infra.CloseReport, error) {
+	if err := f.begin(ctx, scope, teamID); err != nil {
+		return infra.CloseReport{}, err
+	}
+	errs := map[string]map[string]error{"day": f.dayErrByTeam, "week": f.weekErrByTeam, "month": f.monthErrByTeam}[scope]
+	if err := errs[teamID]; err != nil {
+		return infra.CloseReport{}, err
+	}
+	if report, ok := f.reportByTeam[teamID]; ok {
+		return report, nil
+	}
+	return okReport(teamID, scope), nil
+}
+
+func (f *fakeOpsRunner) PreviewCloseForTeam(ctx context.Context, teamID, scope string) (infra.CloseReport, error) {
+	if err := f.begin(ctx, "preview-"+scope, teamID); err != nil {
+		return infra.CloseReport{}, err
 	}
-	return okResp(), nil
+	if err := f.previewErrByTeam[teamID]; err != nil {
+		return infra.CloseReport{}, err
+	}
+	return f.previewByTeam[teamID], nil
+}
+
+func (f *fakeOpsRunner) GetCloseStatusForTeam(_ context.Context, teamID string) ([]api.CloseScopeStatus, error) {
+	status, ok := f.statusByTeam[teamID]
+	if !ok {
+		return nil, errors.New("team not found")
+	}
+	return status, nil
 }
 
-func (f *fakeCloseRunner) CloseWeekForTeam(_ context.Context, teamID string) (api.CloseResponse, error) {
-	f.closedTeams = append(f.closedTeams, "week:"+teamID)
-	if err := f.weekErrByTeam[teamID]; err != nil {
-		return api.CloseResponse{}, err
+func (f *fakeOpsRunner) RunGC(_ context.Context, policies []infra.GCPolicy, batchSize int, dryRun bool) ([]infra.GCResult, error) {
+	f.gcPolicies, f.gcBatch, f.gcDryRun = policies, batchSize, dryRun
+	results := []infra.GCResult{}
+	for _, p := range policies {
+		res := infra.GCResult{Target: p.Target, Retention: p.Retention.String(), Matched: 3}
+		if !dryRun {
+			res.Deleted, res.Batches = 3, 1
+		}
+		results = append(results, res)
+		if f.gcErr != nil {
+			return results, f.gcErr
+		}
 	}
-	return okResp(), nil
+	return results, nil
 }
 
-func (f *fakeCloseRunner) CloseMonthForTeam(_ context.Context, teamID string) (api.CloseResponse, error) {
-	f.closedTeams = append(f.closedTeams, "month:"+teamID)
-	if err := f.monthErrByTeam[teamID]; err != nil {
-		return api.CloseResponse{}, err
+func (f *fakeOpsRunner) ExportTeam(_ context.Context, teamID string) (infra.TeamArchive, error) {
+	archive, ok := f.archiveByTeam[teamID]
+	if !ok {
+		return infra.TeamArchive{}, errors.New("team not found")
 	}
-	return okResp(), nil
+	return archive, nil
 }
 
-func okResp() api.CloseResponse {
-	return api.CloseResponse{
-		Month:    "2026-02",
-		ClosedAt: time.Date(2026, 2, 21, 12, 0, 0, 0, time.UTC),
+func (f *fakeOpsRunner) ImportTeam(_ context.Context, archive infra.TeamArchive, dryRun bool) (infra.TeamImportResult, error) {
+	if f.importErr != nil {
+		return infra.TeamImportResult{}, f.importErr
+	}
+	f.imported = append(f.imported, archive)
+	return infra.TeamImportResult{TeamID: "team-new", DryRun: dryRun, Members: len(archive.Members), Tasks: len(archive.Tasks)}, nil
+}
+
+func okReport(teamID, scope string) infra.CloseReport {
+	return infra.CloseReport{
+		TeamID:  teamID,
+		Scope:   scope,
+		Month:   "2026-02",
+		AsOf:    time.Date(2026, 2, 21, 12, 0, 0, 0, time.UTC),
+		Periods: []infra.ClosePeriodReport{},
 	}
 }
 
 func TestRunRejectsInvalidScope(t *testing.T) {
-	runner := &fakeCloseRunner{}
+	runner := &fakeOpsRunner{}
 	var out bytes.Buffer
 	logger := log.New(&out, "", 0)
 
@@ -77,7 +174,7 @@ func TestRunRejectsInvalidScope(t *testing.T) {
 }
 
 func TestRunAllTeamsSuccess(t *testing.T) {
-	runner := &fakeCloseRunner{
+	runner := &fakeOpsRunner{
 		list:         []string{"team-1", "team-2"},
 		dayErrByTeam: map[string]error{},
 	}
@@ -97,7 +194,7 @@ func TestRunAllTeamsSuccess(t *testing.T) {
 }
 
 func TestRunAllTeamsContinuesOnFailure(t *testing.T) {
-	runner := &fakeCloseRunner{
+	runner := &fakeOpsRunner{
 		list:          []string{"team-1", "team-2"},
 		weekErrByTeam: map[string]error{"team-1": errors.New("boom")},
 	}
@@ -105,8 +202,8 @@ func TestRunAllTeamsContinuesOnFailure(t *testing.T) {
 	logger := log.New(&out, "", 0)
 
 	code := run([]string{"close", "--scope=week", "--all-teams"}, logger, runner)
-	if code != 1 {
-		t.Fatalf("expected exit code 1, got %d", code)
+	if code != exitPartialFailure {
+		t.Fatalf("expected partial failure exit code, got %d", code)
 	}
 	if got := strings.Join(runner.closedTeams, ","); got != "week:team-1,week:team-2" {
 		t.Fatalf("expected processing to continue after failure, got: %s", got)
@@ -117,7 +214,7 @@ func TestRunAllTeamsContinuesOnFailure(t *testing.T) {
 }
 
 func TestRunTeamIDOnly(t *testing.T) {
-	runner := &fakeCloseRunner{
+	runner := &fakeOpsRunner{
 		monthErrByTeam: map[string]error{},
 	}
 	var out bytes.Buffer
@@ -133,7 +230,7 @@ func TestRunTeamIDOnly(t *testing.T) {
 }
 
 func TestRunRejectsMissingSubcommand(t *testing.T) {
-	runner := &fakeCloseRunner{}
+	runner := &fakeOpsRunner{}
 	var out bytes.Buffer
 	logger := log.New(&out, "", 0)
 
@@ -147,7 +244,7 @@ func TestRunRejectsMissingSubcommand(t *testing.T) {
 }
 
 func TestRunRejectsUnsupportedSubcommand(t *testing.T) {
-	runner := &fakeCloseRunner{}
+	runner := &fakeOpsRunner{}
 	var out bytes.Buffer
 	logger := log.New(&out, "", 0)
 
@@ -159,3 +256,559 @@ func TestRunRejectsUnsupportedSubcommand(t *testing.T) {
 		t.Fatalf("expected unsupported subcommand log, got: %s", out.String())
 	}
 }
+
+func TestRunCloseAsOfSetsFixedClock(t *testing.T) {
+	runner := &fakeOpsRunner{list: []string{"team-1"}}
+	var out bytes.Buffer
+	logger := log.New(&out, "", 0)
+
+	code := run([]string{"close", "--scope=day", "--as-of=2026-03-01"}, logger, runner)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d: %s", code, out.String())
+	}
+	if runner.clock == nil {
+		t.Fatalf("expected --as-of to set a clock")
+	}
+	want := time.Date(2026, 3, 1, 0, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+	if got := runner.clock.Now(); !got.Equal(want) {
+		t.Fatalf("expected clock at %s, got %s", want, got)
+	}
+}
+
+func TestRunCloseRejectsInvalidAsOf(t *testing.T) {
+	for _, asOf := range []string{"yesterday", "2999-01-01"} {
+		runner := &fakeOpsRunner{list: []string{"team-1"}}
+		var out bytes.Buffer
+		logger := log.New(&out, "", 0)
+
+		code := run([]string{"close", "--scope=day", "--as-of=" + asOf}, logger, runner)
+		if code != 1 {
+			t.Fatalf("%s: expected exit code 1, got %d", asOf, code)
+		}
+		if len(runner.closedTeams) != 0 || runner.clock != nil {
+			t.Fatalf("%s: expected nothing to run", asOf)
+		}
+		if !strings.Contains(out.String(), "invalid --as-of") {
+			t.Fatalf("%s: expected invalid --as-of log, got: %s", asOf, out.String())
+		}
+	}
+}
+
+func dryRunFixture() *fakeOpsRunner {
+	return &fakeOpsRunner{
+		list: []string{"team-1", "team-2"},
+		previewByTeam: map[string]infra.CloseReport{
+			"team-1": {
+				TeamID: "team-1",
+				Scope:  "day",
+				AsOf:   time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC),
+				Periods: []infra.ClosePeriodReport{{
+					Scope:        "day",
+					Target:       "2026-03-01",
+					Month:        "2026-03",
+					Penalties:    []infra.ClosePenaltyReport{{TaskID: "task-1", Title: "皿洗い", Points: 2}},
+					PenaltyTotal: 2,
+				}},
+			},
+		},
+		previewErrByTeam: map[string]error{"team-2": errors.New("boom")},
+	}
+}
+
+func TestRunCloseDryRunTextDoesNotClose(t *testing.T) {
+	runner := dryRunFixture()
+	var out bytes.Buffer
+	logger := log.New(&out, "", 0)
+
+	code := run([]string{"close", "--scope=day", "--dry-run"}, logger, runner)
+	if code != exitPartialFailure {
+		t.Fatalf("expected partial failure exit code, got %d", code)
+	}
+	if got := strings.Join(runner.closedTeams, ","); got != "preview-day:team-1,preview-day:team-2" {
+		t.Fatalf("expected previews only, got: %s", got)
+	}
+	for _, want := range []string{
+		"close day 2026-03-01: month=2026-03 penalty_total=2 penalized_tasks=1",
+		`task_id=task-1 title="皿洗い" points=2`,
+		"ops close dry-run failed: scope=day team_id=team-2 err=boom",
+		"processed=2 failed=1",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected %q in output, got: %s", want, out.String())
+		}
+	}
+}
+
+func TestRunCloseDryRunJSON(t *testing.T) {
+	runner := dryRunFixture()
+	var out bytes.Buffer
+	logger := log.New(&out, "", 0)
+
+	code := run([]string{"close", "--scope=day", "--dry-run", "--format=json", "--as-of=2026-03-02"}, logger, runner)
+	if code != exitPartialFailure {
+		t.Fatalf("expected partial failure exit code, got %d", code)
+	}
+	var results []dryRunResult
+	if err := json.Unmarshal(out.Bytes(), &results); err != nil {
+		t.Fatalf("expected output to be a single JSON document: %v\n%s", err, out.String())
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 team results, got %d", len(results))
+	}
+	if results[0].Report == nil || results[0].Report.Periods[0].Penalties[0].Points != 2 {
+		t.Fatalf("unexpected report for team-1: %+v", results[0])
+	}
+	if results[1].Report != nil || results[1].Error != "boom" {
+		t.Fatalf("expected error for team-2, got %+v", results[1])
+	}
+}
+
+func TestRunCloseRejectsInvalidFormat(t *testing.T) {
+	runner := dryRunFixture()
+	var out bytes.Buffer
+	logger := log.New(&out, "", 0)
+
+	code := run([]string{"close", "--scope=day", "--dry-run", "--format=yaml"}, logger, runner)
+	if code != 1 {
+		t.Fatalf("expected exit code 1, got %d", code)
+	}
+	if len(runner.closedTeams) != 0 {
+		t.Fatalf("expected nothing to run, got %v", runner.closedTeams)
+	}
+}
+
+func TestRunCloseBoundsConcurrency(t *testing.T) {
+	runner := &fakeOpsRunner{
+		list:  []string{"team-1", "team-2", "team-3", "team-4", "team-5"},
+		delay: 20 * time.Millisecond,
+	}
+	var out bytes.Buffer
+	logger := log.New(&out, "", 0)
+
+	code := run([]string{"close", "--scope=day", "--concurrency=2"}, logger, runner)
+	if code != 0 {
+		t.Fatalf("expected exit code 0, got %d: %s", code, out.String())
+	}
+	if runner.maxInFlight != 2 {
+		t.Fatalf("expected 2 teams in flight at most, got %d", runner.maxInFlight)
+	}
+	got := append([]string{}, runner.closedTeams...)
+	sort.Strings(got)
+	if strings.Join(got, ",") != "day:team-1,day:team-2,day:team-3,day:team-4,day:team-5" {
+		t.Fatalf("expected every team closed once, got %v", got)
+	}
+	if !strings.Contains(out.String(), "processed=5 succeeded=5 failed=0") {
+		t.Fatalf("missing summary log: %s", out.String())
+	}
+}
+
+func TestRunCloseTeamTimeoutFailsOnlyThatTeam(t *testing.T) {
+	runner := &fakeOpsRunner{
+		list:      []string{"team-1", "team-2"},
+		hangTeams: map[string]bool{"team-1": true},
+	}
+	var out bytes.Buffer
+	logger := log.New(&out, "", 0)
+
+	code := run([]string{"close", "--scope=week", "--concurrency=2", "--team-timeout=50ms"}, logger, runner)
+	if code != exitPartialFailure {
+		t.Fatalf("expected partial failure exit code, got %d", code)
+	}
+	if !strings.Contains(out.String(), "team_id=team-1 err=context deadline exceeded") {
+		t.Fatalf("expected timeout for team-1, got: %s", out.String())
+	}
+	if !strings.Contains(out.String(), "processed=2 succeeded=1 failed=1") {
+		t.Fatalf("missing summary log: %s", out.String())
+	}
+}
+
+func TestRunCloseRejectsInvalidConcurrency(t *testing.T) {
+	for _, args := range [][]string{
+		{"close", "--scope=day", "--concurrency=0"},
+		{"close", "--scope=day", "--concurrency=99"},
+		{"close", "--scope=day", "--team-timeout=0s"},
+	} {
+		runner := &fakeOpsRunner{list: []string{"team-1"}}
+		var out bytes.Buffer
+		logger := log.New(&out, "", 0)
+
+		if code := run(args, logger, runner); code != 1 {
+			t.Fatalf("%v: expected exit code 1, got %d", args, code)
+		}
+		if len(runner.closedTeams) != 0 {
+			t.Fatalf("%v: expected nothing to run", args)
+		}
+	}
+}
+
+func TestRunCloseExitsWithFailureWhenEveryTeamFails(t *testing.T) {
+	runner := &fakeOpsRunner{
+		list:         []string{"team-1", "team-2"},
+		dayErrByTeam: map[string]error{"team-1": errors.New("boom"), "team-2": errors.New("boom")},
+	}
+	var out bytes.Buffer
+	logger := log.New(&out, "", 0)
+
+	if code := run([]string{"close", "--scope=day"}, logger, runner); code != exitFailure {
+		t.Fatalf("expected total failure exit code, got %d", code)
+	}
+	if !strings.Contains(out.String(), "status=failed") {
+		t.Fatalf("missing failed status: %s", out.String())
+	}
+}
+
+func TestRunCloseWritesJSONReportToStdout(t *testing.T) {
+	runner := &fakeOpsRunner{
+		list:           []string{"team-1", "team-2"},
+		monthErrByTeam: map[string]error{"team-2": context.DeadlineExceeded},
+		reportByTeam: map[string]infra.CloseReport{
+			"team-1": {
+				TeamID: "team-1",
+				Scope:  "month",
+				Month:  "2026-02",
+				Periods: []infra.ClosePeriodReport{{
+					Scope:          "month",
+					Target:         "2026-02",
+					Month:          "2026-02",
+					PenaltyTotal:   12,
+					TriggeredRules: []infra.CloseRuleReport{{RuleID: "rule-1", Name: "外食禁止", Threshold: 10}},
+				}},
+			},
+		},
+	}
+	var out bytes.Buffer
+	logger := log.New(&out, "", 0)
+
+	code := run([]string{"close", "--scope=month", "--report=-"}, logger, runner)
+	if code != exitPartialFailure {
+		t.Fatalf("expected partial failure exit code, got %d", code)
+	}
+	var report runReport
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("expected stdout to be the JSON report only: %v\n%s", err, out.String())
+	}
+	if report.Status != runStatusPartial || report.ExitCode != exitPartialFailure || report.Processed != 2 || report.Failed != 1 {
+		t.Fatalf("unexpected run summary: %+v", report)
+	}
+	first := report.Teams[0]
+	if first.TeamID != "team-1" || first.PeriodsProcessed != 1 || first.PenaltyAdded != 0 || len(first.RulesTriggered) != 1 {
+		t.Fatalf("unexpected team-1 report: %+v", first)
+	}
+	if second := report.Teams[1]; second.Status != runStatusFailed || second.ErrorClass != "timeout" {
+		t.Fatalf("unexpected team-2 report: %+v", second)
+	}
+}
+
+func TestRunCloseWritesReportFile(t *testing.T) {
+	runner := &fakeOpsRunner{list: []string{"team-1"}}
+	var out bytes.Buffer
+	logger := log.New(&out, "", 0)
+	path := filepath.Join(t.TempDir(), "report.json")
+
+	if code := run([]string{"close", "--scope=day", "--report=" + path}, logger, runner); code != exitOK {
+		t.Fatalf("expected exit code 0, got %d: %s", code, out.String())
+	}
+	body, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read report: %v", err)
+	}
+	var report runReport
+	if err := json.Unmarshal(body, &report); err != nil {
+		t.Fatalf("invalid report: %v", err)
+	}
+	if report.Status != runStatusOK || len(report.Teams) != 1 || report.Teams[0].Month != "2026-02" {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	if !strings.Contains(out.String(), "ops close finished") {
+		t.Fatalf("expected progress logs alongside a report file, got: %s", out.String())
+	}
+}
+
+func TestRunCloseReportsListFailure(t *testing.T) {
+	runner := &fakeOpsRunner{listErr: errors.New("db down")}
+	var out bytes.Buffer
+	logger := log.New(&out, "", 0)
+
+	if code := run([]string{"close", "--scope=day", "--report=-"}, logger, runner); code != exitFailure {
+		t.Fatalf("expected total failure exit code, got %d", code)
+	}
+	var report runReport
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("expected JSON report: %v\n%s", err, out.String())
+	}
+	if report.Status != runStatusFailed || !strings.Contains(report.Error, "db down") {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+}
+
+func closeStatusFixture() *fakeOpsRunner {
+	date := func(y int, m time.Month, d int) openapi_types.Date {
+		return openapi_types.Date{Time: time.Date(y, m, d, 0, 0, 0, 0, time.UTC)}
+	}
+	upToDate := date(2026, 3, 1)
+	behind := date(2026, 2, 20)
+	failed := "timeout"
+	return &fakeOpsRunner{
+		list: []string{"team-1", "team-2"},
+		statusByTeam: map[string][]api.CloseScopeStatus{
+			"team-1": {{Scope: "day", LatestTargetDate: &upToDate, ExpectedTargetDate: upToDate}},
+			"team-2": {{
+				Scope:              "day",
+				LatestTargetDate:   &behind,
+				ExpectedTargetDate: upToDate,
+				Lagging:            true,
+				LastRun: &api.CloseRun{
+					Scope:      "day",
+					Trigger:    "scheduler",
+					FinishedAt: time.Date(2026, 2, 21, 0, 1, 0, 0, time.UTC),
+					ErrorClass: &failed,
+				},
+			}},
+		},
+	}
+}
+
+func TestRunCloseStatusText(t *testing.T) {
+	runner := closeStatusFixture()
+	var out bytes.Buffer
+	logger := log.New(&out, "", 0)
+
+	if code := run([]string{"close-status"}, logger, runner); code != exitOK {
+		t.Fatalf("expected exit code 0, got %d: %s", code, out.String())
+	}
+	for _, want := range []string{
+		"team_id=team-1 scope=day latest=2026-03-01 expected=2026-03-01 lagging=false last_run=-",
+		"team_id=team-2 scope=day latest=2026-02-20 expected=2026-03-01 lagging=true last_run=2026-02-21T00:01:00+00:00 trigger=scheduler error_class=timeout",
+		"teams=2 lagging=1",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected %q in output, got: %s", want, out.String())
+		}
+	}
+}
+
+func TestRunCloseStatusLaggingOnlyJSON(t *testing.T) {
+	runner := closeStatusFixture()
+	var out bytes.Buffer
+	logger := log.New(&out, "", 0)
+
+	if code := run([]string{"close-status", "--lagging-only", "--format=json"}, logger, runner); code != exitOK {
+		t.Fatalf("expected exit code 0, got %d: %s", code, out.String())
+	}
+	var results []teamCloseStatus
+	if err := json.Unmarshal(out.Bytes(), &results); err != nil {
+		t.Fatalf("expected JSON output: %v\n%s", err, out.String())
+	}
+	if len(results) != 1 || results[0].TeamID != "team-2" || !results[0].Lagging {
+		t.Fatalf("expected only the lagging team, got %+v", results)
+	}
+}
+
+func TestRunCloseStatusFailsForUnknownTeam(t *testing.T) {
+	runner := closeStatusFixture()
+	var out bytes.Buffer
+	logger := log.New(&out, "", 0)
+
+	if code := run([]string{"close-status", "--team-id=team-9"}, logger, runner); code != exitFailure {
+		t.Fatalf("expected exit code 1, got %d", code)
+	}
+	if !strings.Contains(out.String(), "team_id=team-9 err=team not found") {
+		t.Fatalf("expected error log, got: %s", out.String())
+	}
+}
+
+func TestRunGCDryRunRunsEveryTargetWithDefaults(t *testing.T) {
+	runner := &fakeOpsRunner{}
+	var out bytes.Buffer
+	logger := log.New(&out, "", 0)
+
+	if code := run([]string{"gc", "--dry-run"}, logger, runner); code != exitOK {
+		t.Fatalf("expected exit code 0, got %d: %s", code, out.String())
+	}
+	if !runner.gcDryRun || runner.gcBatch != defaultGCBatchSize {
+		t.Fatalf("expected dry run with default batch size, got dry_run=%t batch=%d", runner.gcDryRun, runner.gcBatch)
+	}
+	defaults := infra.DefaultGCPolicies()
+	if len(runner.gcPolicies) != len(defaults) {
+		t.Fatalf("expected %d policies, got %+v", len(defaults), runner.gcPolicies)
+	}
+	for _, want := range []string{
+		"target=sessions retention=168h0m0s",
+		"matched=3 deleted=0 batches=0",
+		"targets=11 dry_run=true",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected %q in output, got: %s", want, out.String())
+		}
+	}
+}
+
+func TestRunGCAppliesRetentionOverridesAndOnly(t *testing.T) {
+	runner := &fakeOpsRunner{}
+	var out bytes.Buffer
+	logger := log.New(&out, "", 0)
+
+	args := []string{"gc", "--only=sessions,deleted_tasks", "--retention=sessions=14d,invite_codes=1h", "--batch-size=50", "--format=json"}
+	if code := run(args, logger, runner); code != exitOK {
+		t.Fatalf("expected exit code 0, got %d: %s", code, out.String())
+	}
+	want := []infra.GCPolicy{
+		{Target: "sessions", Retention: 14 * 24 * time.Hour},
+		{Target: "deleted_tasks", Retention: 180 * 24 * time.Hour},
+	}
+	if len(runner.gcPolicies) != len(want) || runner.gcPolicies[0] != want[0] || runner.gcPolicies[1] != want[1] {
+		t.Fatalf("expected policies %+v, got %+v", want, runner.gcPolicies)
+	}
+	if runner.gcBatch != 50 || runner.gcDryRun {
+		t.Fatalf("expected a real run with batch size 50, got dry_run=%t batch=%d", runner.gcDryRun, runner.gcBatch)
+	}
+	var results []infra.GCResult
+	if err := json.Unmarshal(out.Bytes(), &results); err != nil {
+		t.Fatalf("expected JSON output: %v\n%s", err, out.String())
+	}
+	if len(results) != 2 || results[0].Deleted != 3 {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+}
+
+func TestRunGCRejectsInvalidPolicy(t *testing.T) {
+	for _, args := range [][]string{
+		{"gc", "--only=users"},
+		{"gc", "--retention=sessions"},
+		{"gc", "--retention=sessions=-1h"},
+		{"gc", "--retention=sessions=soon"},
+	} {
+		runner := &fakeOpsRunner{}
+		var out bytes.Buffer
+		logger := log.New(&out, "", 0)
+
+		if code := run(args, logger, runner); code != exitFailure {
+			t.Fatalf("%v: expected exit code 1, got %d", args, code)
+		}
+		if runner.gcPolicies != nil {
+			t.Fatalf("%v: expected gc not to run", args)
+		}
+	}
+}
+
+func TestRunGCReportsPartialResultsOnFailure(t *testing.T) {
+	runner := &fakeOpsRunner{gcErr: errors.New("purge sessions: boom")}
+	var out bytes.Buffer
+	logger := log.New(&out, "", 0)
+
+	if code := run([]string{"gc", "--only=sessions"}, logger, runner); code != exitFailure {
+		t.Fatalf("expected exit code 1, got %d", code)
+	}
+	for _, want := range []string{"target=sessions", "ops gc failed: purge sessions: boom"} {
+		if !strings.Contains(out.String(), want) {
+			t.Fatalf("expected %q in output, got: %s", want, out.String())
+		}
+	}
+}
+
+func archiveFixture(t *testing.T) infra.TeamArchive {
+	t.Helper()
+	var archive infra.TeamArchive
+	body := `{
+		"format": "kaji-challenge.team-archive",
+		"version": 1,
+		"team": {"id": "team-1", "name": "Home"},
+		"members": [{"userId": "user-1", "email": "owner@example.com", "displayName": "Owner", "role": "owner"}],
+		"tasks": [{"id": "task-1", "title": "Dishes", "type": "daily", "requiredCompletionsPerWeek": 1}]
+	}`
+	if err := json.Unmarshal([]byte(body), &archive); err != nil {
+		t.Fatalf("failed to decode archive fixture: %v", err)
+	}
+	return archive
+}
+
+func TestRunExportThenImportRoundTrip(t *testing.T) {
+	runner := &fakeOpsRunner{archiveByTeam: map[string]infra.TeamArchive{"team-1": archiveFixture(t)}}
+	path := filepath.Join(t.TempDir(), "team.json")
+	var out bytes.Buffer
+	logger := log.New(&out, "", 0)
+
+	if code := run([]string{"export", "--team-id=team-1", "--out=" + path}, logger, runner); code != exitOK {
+		t.Fatalf("expected export exit code 0, got %d: %s", code, out.String())
+	}
+	if !strings.Contains(out.String(), "ops export finished: team_id=team-1 members=1 tasks=1") {
+		t.Fatalf("expected export summary, got: %s", out.String())
+	}
+	if code := run([]string{"import", "--in=" + path, "--dry-run"}, logger, runner); code != exitOK {
+		t.Fatalf("expected import exit code 0, got %d: %s", code, out.String())
+	}
+	if len(runner.imported) != 1 || runner.imported[0].Tasks[0].Title != "Dishes" {
+		t.Fatalf("expected the exported archive to be imported, got %+v", runner.imported)
+	}
+	if !strings.Contains(out.String(), "source_team_id=team-1 team_id=team-new dry_run=true members=1 tasks=1") {
+		t.Fatalf("expected import summary, got: %s", out.String())
+	}
+}
+
+func TestRunExportWritesOnlyTheArchiveToStdout(t *testing.T) {
+	runner := &fakeOpsRunner{archiveByTeam: map[string]infra.TeamArchive{"team-1": archiveFixture(t)}}
+	var out bytes.Buffer
+	logger := log.New(&out, "", 0)
+
+	if code := run([]string{"export", "--team-id=team-1"}, logger, runner); code != exitOK {
+		t.Fatalf("expected exit code 0, got %d: %s", code, out.String())
+	}
+	var archive infra.TeamArchive
+	if err := json.Unmarshal(out.Bytes(), &archive); err != nil {
+		t.Fatalf("expected a JSON archive on stdout: %v\n%s", err, out.String())
+	}
+	if archive.Team.ID != "team-1" {
+		t.Fatalf("unexpected archive: %+v", archive)
+	}
+}
+
+func TestRunExportRequiresKnownTeam(t *testing.T) {
+	runner := &fakeOpsRunner{}
+	var out bytes.Buffer
+	logger := log.New(&out, "", 0)
+
+	if code := run([]string{"export"}, logger, runner); code != exitFailure {
+		t.Fatalf("expected exit code 1 without --team-id, got %d", code)
+	}
+	if code := run([]string{"export", "--team-id=team-9"}, logger, runner); code != exitFailure {
+		t.Fatalf("expected exit code 1 for unknown team, got %d", code)
+	}
+	if !strings.Contains(out.String(), "team_id=team-9 err=team not found") {
+		t.Fatalf("expected error log, got: %s", out.String())
+	}
+}
+
+func TestRunImportRejectsUnknownFields(t *testing.T) {
+	runner := &fakeOpsRunner{}
+	path := filepath.Join(t.TempDir(), "team.json")
+	if err := os.WriteFile(path, []byte(`{"format":"kaji-challenge.team-archive","version":1,"extra":true}`), 0o600); err != nil {
+		t.Fatalf("failed to write archive: %v", err)
+	}
+	var out bytes.Buffer
+	logger := log.New(&out, "", 0)
+
+	if code := run([]string{"import", "--in=" + path}, logger, runner); code != exitFailure {
+		t.Fatalf("expected exit code 1, got %d", code)
+	}
+	if len(runner.imported) != 0 || !strings.Contains(out.String(), `unknown field "extra"`) {
+		t.Fatalf("expected the archive to be rejected, got: %s", out.String())
+	}
+}
+
+func TestRunImportReportsValidationFailure(t *testing.T) {
+	runner := &fakeOpsRunner{importErr: errors.New("invalid archive: users already exist: owner@example.com")}
+	path := filepath.Join(t.TempDir(), "team.json")
+	body, _ := json.Marshal(archiveFixture(t))
+	if err := os.WriteFile(path, body, 0o600); err != nil {
+		t.Fatalf("failed to write archive: %v", err)
+	}
+	var out bytes.Buffer
+	logger := log.New(&out, "", 0)
+
+	if code := run([]string{"import", "--in=" + path}, logger, runner); code != exitFailure {
+		t.Fatalf("expected exit code 1, got %d", code)
+	}
+	if !strings.Contains(out.String(), "users already exist: owner@example.com") {
+		t.Fatalf("expected validation error, got: %s", out.String())
+	}
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/megu/kaji-challenge/backend/internal/http/infra"
+)
+
+// Exit codes let monitoring tell a run that lost some teams from one that
+// achieved nothing. Usage errors share exitFailure.
+const (
+	exitOK             = 0
+	exitFailure        = 1
+	exitPartialFailure = 2
+)
+
+const (
+	runStatusOK      = "ok"
+	runStatusPartial = "partial"
+	runStatusFailed  = "failed"
+)
+
+// runReport is the machine-readable summary written by --report.
+type runReport struct {
+	Scope      string          `json:"scope"`
+	AsOf       *time.Time      `json:"asOf,omitempty"`
+	StartedAt  time.Time       `json:"startedAt"`
+	FinishedAt time.Time       `json:"finishedAt"`
+	DurationMs int64           `json:"durationMs"`
+	Status     string          `json:"status"`
+	ExitCode   int             `json:"exitCode"`
+	Processed  int             `json:"processed"`
+	Succeeded  int             `json:"succeeded"`
+	Failed     int             `json:"failed"`
+	Error      string          `json:"error,omitempty"`
+	Teams      []teamRunReport `json:"teams"`
+}
+
+type teamRunReport struct {
+	TeamID           string                  `json:"teamId"`
+	Scope            string                  `json:"scope"`
+	Status           string                  `json:"status"`
+	Month            string                  `json:"month,omitempty"`
+	PeriodsProcessed int                     `json:"periodsProcessed"`
+	PenaltyAdded     int                     `json:"penaltyAdded"`
+	RulesTriggered   []infra.CloseRuleReport `json:"rulesTriggered"`
+	ErrorClass       string                  `json:"errorClass,omitempty"`
+	Error            string                  `json:"error,omitempty"`
+	DurationMs       int64                   `json:"durationMs"`
+}
+
+func newTeamRunReport(teamID, scope string, report infra.CloseReport, err error, elapsed time.Duration) teamRunReport {
+	res := teamRunReport{
+		TeamID:           teamID,
+		Scope:            scope,
+		Status:           runStatusOK,
+		Month:            report.Month,
+		PeriodsProcessed: len(report.Periods),
+		PenaltyAdded:     report.PenaltyAdded(),
+		RulesTriggered:   report.TriggeredRules(),
+		DurationMs:       elapsed.Milliseconds(),
+	}
+	if err != nil {
+		res.Status = runStatusFailed
+		res.ErrorClass = infra.CloseErrorClass(err)
+		res.Error = err.Error()
+	}
+	return res
+}
+
+// finish fills the totals, status and exit code from the team results.
+func (r *runReport) finish(finishedAt time.Time) {
+	r.FinishedAt = finishedAt
+	r.DurationMs = finishedAt.Sub(r.StartedAt).Milliseconds()
+	r.Processed = len(r.Teams)
+	r.Succeeded, r.Failed = 0, 0
+	for _, team := range r.Teams {
+		if team.Status == runStatusOK {
+			r.Succeeded++
+		} else {
+			r.Failed++
+		}
+	}
+	r.ExitCode = exitCodeFor(r.Processed, r.Failed)
+	if r.Error != "" {
+		r.ExitCode = exitFailure
+	}
+	switch r.ExitCode {
+	case exitOK:
+		r.Status = runStatusOK
+	case exitPartialFailure:
+		r.Status = runStatusPartial
+	default:
+		r.Status = runStatusFailed
+	}
+}
+
+func exitCodeFor(processed, failed int) int {
+	switch {
+	case failed == 0:
+		return exitOK
+	case failed < processed:
+		return exitPartialFailure
+	default:
+		return exitFailure
+	}
+}
+
+// writeRunReport writes the report to path, or to the log output for "-".
+func writeRunReport(path string, logger *log.Logger, report runReport) error {
+	body, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	body = append(body, '\n')
+	if path == "-" {
+		_, err = logger.Writer().Write(body)
+		return err
+	}
+	if err := os.WriteFile(path, body, 0o644); err != nil {
+		return fmt.Errorf("write report: %w", err)
+	}
+	return nil
+}
@@ -10,23 +10,31 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/megu/kaji-challenge/backend/internal/tasktemplates"
 )
 
 const jst = "Asia/Tokyo"
 
+const defaultPack = "builtin-new-apartment-basics"
+
 type seedTask struct {
-	id       string
-	title    string
-	taskType string
-	penalty  int
-	required int
+	id        string
+	title     string
+	taskType  string
+	penalty   int
+	required  int
+	dueOn     *time.Time
+	checklist []string
+	completed bool
 }
 
 func main() {
 	var (
 		month = flag.String("month", "", "target month (YYYY-MM)")
 		email = flag.String("email", "", "user email to resolve target team")
+		pack  = flag.String("pack", defaultPack, "built-in task template to seed tasks from")
 	)
 	flag.Parse()
 
@@ -36,6 +44,10 @@ func main() {
 	if strings.TrimSpace(*email) == "" {
 		log.Fatal("--email is required")
 	}
+	template, ok := tasktemplates.BuiltinByID(strings.TrimSpace(*pack))
+	if !ok {
+		log.Fatalf("unknown --pack %q (available: %s)", *pack, strings.Join(builtinIDs(), ", "))
+	}
 
 	loc, err := time.LoadLocation(jst)
 	if err != nil || loc == nil {
@@ -63,7 +75,7 @@ func main() {
 	}
 	defer db.Close()
 
-	teamID, err := resolveTeamID(ctx, db, *email)
+	teamID, userID, err := resolveTeam(ctx, db, *email)
 	if err != nil {
 		log.Fatalf("failed to resolve team: %v", err)
 	}
@@ -79,35 +91,31 @@ func main() {
 	}()
 
 	seedKey := fmt.Sprintf("seed:%s:%s", teamID, *month)
-	tasks := []seedTask{
-		{
-			id:       taskID(seedKey, "daily-completed"),
-			title:    fmt.Sprintf("[SEED %s] Daily 完了タスク", *month),
-			taskType: "daily",
-			penalty:  2,
-			required: 1,
-		},
-		{
-			id:       taskID(seedKey, "daily-pending"),
-			title:    fmt.Sprintf("[SEED %s] Daily 未完了タスク", *month),
-			taskType: "daily",
-			penalty:  1,
-			required: 1,
-		},
-		{
-			id:       taskID(seedKey, "weekly-completed"),
-			title:    fmt.Sprintf("[SEED %s] Weekly 完了タスク", *month),
-			taskType: "weekly",
-			penalty:  3,
-			required: 2,
-		},
-		{
-			id:       taskID(seedKey, "weekly-pending"),
-			title:    fmt.Sprintf("[SEED %s] Weekly 未完了タスク", *month),
-			taskType: "weekly",
-			penalty:  2,
-			required: 2,
-		},
+	tasks := make([]seedTask, 0, len(template.Items))
+	for i, item := range template.Items {
+		item, err := item.Normalize()
+		if err != nil {
+			log.Fatalf("invalid pack %s: %v", template.ID, err)
+		}
+		t := seedTask{
+			id:        taskID(seedKey, fmt.Sprintf("%s:%d", template.ID, i)),
+			title:     fmt.Sprintf("[SEED %s] %s", *month, item.Title),
+			taskType:  item.Type,
+			penalty:   item.PenaltyPoints,
+			required:  item.RequiredCompletionsPerWeek,
+			checklist: item.Checklist,
+			// Every other task gets completions so the month has both done
+			// and missed work to close.
+			completed: i%2 == 0,
+		}
+		if item.Type == tasktemplates.TypeOneOff {
+			dueOn := monthStart.AddDate(0, 0, item.DueInDays)
+			if !dueOn.Before(monthEnd) {
+				dueOn = monthEnd.AddDate(0, 0, -1)
+			}
+			t.dueOn = &dueOn
+		}
+		tasks = append(tasks, t)
 	}
 
 	now := time.Now().In(loc)
@@ -115,76 +123,136 @@ func main() {
 		if _, err := tx.Exec(ctx, `
 INSERT INTO tasks (
   id, team_id, title, notes, type, penalty_points, assignee_user_id,
-  required_completions_per_week, created_at, updated_at, deleted_at
+  required_completions_per_week, due_on, created_at, updated_at, deleted_at
 )
-VALUES ($1, $2, $3, $4, $5, $6, NULL, $7, $8, $9, NULL)
+VALUES ($1, $2, $3, $4, $5, $6, NULL, $7, $8, $9, $10, NULL)
 ON CONFLICT (id) DO UPDATE SET
   title = EXCLUDED.title,
   notes = EXCLUDED.notes,
   type = EXCLUDED.type,
   penalty_points = EXCLUDED.penalty_points,
   required_completions_per_week = EXCLUDED.required_completions_per_week,
+  due_on = EXCLUDED.due_on,
   updated_at = EXCLUDED.updated_at,
   deleted_at = NULL
-`, t.id, teamID, t.title, "dummy data by seed-monthly-dummy", t.taskType, t.penalty, t.required, monthStart, now); err != nil {
+`, t.id, teamID, t.title, "dummy data by seed-monthly-dummy", t.taskType, t.penalty, t.required, t.dueOn, monthStart, now); err != nil {
 			log.Fatalf("failed to upsert task %s: %v", t.title, err)
 		}
+		if err := seedChecklist(ctx, tx, t, monthStart); err != nil {
+			log.Fatalf("failed to seed checklist of %s: %v", t.title, err)
+		}
+		if err := seedCompletions(ctx, tx, t, userID, monthStart, monthEnd, loc); err != nil {
+			log.Fatalf("failed to seed completions of %s: %v", t.title, err)
+		}
 	}
 
-	dailyCompletedID := tasks[0].id
-	if _, err := tx.Exec(ctx, `DELETE FROM task_completion_daily WHERE task_id = $1 AND target_date >= $2 AND target_date < $3`, dailyCompletedID, monthStart, monthEnd); err != nil {
-		log.Fatalf("failed to clear daily completions: %v", err)
+	if err := tx.Commit(ctx); err != nil {
+		log.Fatalf("failed to commit: %v", err)
 	}
-	for d := monthStart; d.Before(monthEnd); d = d.AddDate(0, 0, 1) {
-		if d.Day()%2 == 0 {
+
+	log.Printf("seed completed: month=%s team_id=%s email=%s pack=%s", *month, teamID, *email, template.ID)
+	log.Printf("tasks: %d from %s, every other one with completions", len(tasks), template.Name)
+	log.Printf("monthly summary is not seeded; run ops close(day/week/month) to aggregate")
+}
+
+// seedChecklist replaces the task's checklist with the pack's items.
+func seedChecklist(ctx context.Context, tx pgx.Tx, t seedTask, createdAt time.Time) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM task_checklist_items WHERE task_id = $1`, t.id); err != nil {
+		return err
+	}
+	for position, title := range t.checklist {
+		if _, err := tx.Exec(ctx, `
+INSERT INTO task_checklist_items (id, task_id, title, position, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $5)
+`, taskID(t.id, fmt.Sprintf("checklist:%d", position)), t.id, title, position, createdAt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// seedCompletions rewrites the month's completions of a completed task:
+// every other day for daily tasks, the full quota every week for weekly
+// tasks and the due date for one_off tasks.
+func seedCompletions(ctx context.Context, tx pgx.Tx, t seedTask, userID string, monthStart, monthEnd time.Time, loc *time.Location) error {
+	switch t.taskType {
+	case tasktemplates.TypeDaily:
+		if _, err := tx.Exec(ctx, `DELETE FROM task_completion_daily WHERE task_id = $1 AND target_date >= $2 AND target_date < $3`, t.id, monthStart, monthEnd); err != nil {
+			return err
+		}
+		if !t.completed {
+			return nil
+		}
+		for d := monthStart; d.Before(monthEnd); d = d.AddDate(0, 0, 1) {
+			if d.Day()%2 != 0 {
+				continue
+			}
 			if _, err := tx.Exec(ctx, `
-INSERT INTO task_completion_daily (task_id, target_date, created_at)
-VALUES ($1, $2, NOW())
+INSERT INTO task_completion_daily (task_id, target_date, completed_by_user_id, created_at)
+VALUES ($1, $2, $3, NOW())
 ON CONFLICT (task_id, target_date) DO NOTHING
-`, dailyCompletedID, d); err != nil {
-				log.Fatalf("failed to insert daily completion: %v", err)
+`, t.id, d, userID); err != nil {
+				return err
 			}
 		}
-	}
-
-	weeklyCompletedID := tasks[2].id
-	if _, err := tx.Exec(ctx, `DELETE FROM task_completion_weekly WHERE task_id = $1 AND week_start >= $2 AND week_start < $3`, weeklyCompletedID, monthStart, monthEnd); err != nil {
-		log.Fatalf("failed to clear weekly completions: %v", err)
-	}
-	for w := startOfWeek(monthStart, loc); w.Before(monthEnd); w = w.AddDate(0, 0, 7) {
+	case tasktemplates.TypeWeekly:
+		firstWeek := startOfWeek(monthStart, loc)
+		if _, err := tx.Exec(ctx, `DELETE FROM task_completion_weekly_entries WHERE task_id = $1 AND week_start >= $2 AND week_start < $3`, t.id, firstWeek, monthEnd); err != nil {
+			return err
+		}
+		if !t.completed {
+			return nil
+		}
+		for w := firstWeek; w.Before(monthEnd); w = w.AddDate(0, 0, 7) {
+			for i := 0; i < t.required; i++ {
+				if _, err := tx.Exec(ctx, `
+INSERT INTO task_completion_weekly_entries (id, task_id, week_start, completed_by_user_id, created_at)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (id) DO NOTHING
+`, taskID(t.id, fmt.Sprintf("%s:%d", w.Format("2006-01-02"), i)), t.id, w, userID, w.Add(time.Duration(i+1)*time.Hour)); err != nil {
+					return err
+				}
+			}
+		}
+	case tasktemplates.TypeOneOff:
+		if _, err := tx.Exec(ctx, `DELETE FROM task_completion_one_off WHERE task_id = $1`, t.id); err != nil {
+			return err
+		}
+		if !t.completed || t.dueOn == nil {
+			return nil
+		}
 		if _, err := tx.Exec(ctx, `
-INSERT INTO task_completion_weekly (task_id, week_start, completion_count, created_at, updated_at)
-VALUES ($1, $2, 2, NOW(), NOW())
-ON CONFLICT (task_id, week_start) DO UPDATE SET
-  completion_count = EXCLUDED.completion_count,
-  updated_at = NOW()
-`, weeklyCompletedID, w); err != nil {
-			log.Fatalf("failed to insert weekly completion: %v", err)
+INSERT INTO task_completion_one_off (task_id, completed_by_user_id, completed_at)
+VALUES ($1, $2, $3)
+`, t.id, userID, t.dueOn.Add(12*time.Hour)); err != nil {
+			return err
 		}
 	}
+	return nil
+}
 
-	if err := tx.Commit(ctx); err != nil {
-		log.Fatalf("failed to commit: %v", err)
+func builtinIDs() []string {
+	templates := tasktemplates.Builtin()
+	ids := make([]string, 0, len(templates))
+	for _, t := range templates {
+		ids = append(ids, t.ID)
 	}
-
-	log.Printf("seed completed: month=%s team_id=%s email=%s", *month, teamID, *email)
-	log.Printf("tasks: daily(complete/pending), weekly(complete/pending)")
-	log.Printf("monthly summary is not seeded; run ops close(day/week/month) to aggregate")
+	return ids
 }
 
-func resolveTeamID(ctx context.Context, db *pgxpool.Pool, email string) (string, error) {
-	var teamID string
+func resolveTeam(ctx context.Context, db *pgxpool.Pool, email string) (string, string, error) {
+	var teamID, userID string
 	err := db.QueryRow(ctx, `
-SELECT tm.team_id::text
+SELECT tm.team_id::text, u.id::text
 FROM users u
 JOIN team_members tm ON tm.user_id = u.id
 WHERE lower(u.email) = lower($1)
 LIMIT 1
-`, email).Scan(&teamID)
+`, email).Scan(&teamID, &userID)
 	if err != nil {
-		return "", err
+		return "", "", err
 	}
-	return teamID, nil
+	return teamID, userID, nil
 }
 
 func taskID(seedKey, suffix string) string {
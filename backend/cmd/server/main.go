@@ -1,14 +1,26 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
 
 	httpapi "github.com/megu/kaji-challenge/backend/internal/http"
+	"github.com/megu/kaji-challenge/backend/internal/http/infra"
 )
 
 func main() {
-	r := httpapi.NewRouter()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	s := infra.NewStore()
+	go s.RunOutboxDispatcher(ctx)
+	go s.RunWebhookWorker(ctx)
+	go s.RunPersonalDataExportWorker(ctx)
+	go s.RunCloseScheduler(ctx)
+	r := httpapi.NewRouterWithStore(infra.NewServices(s), s)
 
 	port := os.Getenv("PORT")
 	if port == "" {
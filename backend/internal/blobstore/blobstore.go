@@ -0,0 +1,79 @@
+// Package blobstore keeps binary objects such as completion photos outside
+// the database. The backend talks to the Store interface only; LoadFromEnv
+// picks the local filesystem or an S3-compatible bucket.
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+const (
+	DriverLocal = "local"
+	DriverS3    = "s3"
+
+	defaultLocalDir = "data/blobs"
+	defaultS3Region = "us-east-1"
+)
+
+// ErrNotFound is returned by Get when no object is stored under the key.
+var ErrNotFound = errors.New("blob not found")
+
+// Store saves objects under keys chosen by the caller. Keys are slash
+// separated paths made of letters, digits, '-', '_' and '.'.
+type Store interface {
+	Put(ctx context.Context, key, contentType string, data []byte) error
+	// Get returns the object body. The caller must close it.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the object. Deleting a missing key is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// LoadFromEnv builds the store selected by BLOB_STORE_DRIVER (default
+// "local").
+func LoadFromEnv() (Store, error) {
+	driver := strings.ToLower(strings.TrimSpace(os.Getenv("BLOB_STORE_DRIVER")))
+	switch driver {
+	case "", DriverLocal:
+		dir := strings.TrimSpace(os.Getenv("BLOB_LOCAL_DIR"))
+		if dir == "" {
+			dir = defaultLocalDir
+		}
+		return NewLocal(dir)
+	case DriverS3:
+		cfg := S3Config{
+			Endpoint:        strings.TrimSpace(os.Getenv("BLOB_S3_ENDPOINT")),
+			Region:          strings.TrimSpace(os.Getenv("BLOB_S3_REGION")),
+			Bucket:          strings.TrimSpace(os.Getenv("BLOB_S3_BUCKET")),
+			AccessKeyID:     strings.TrimSpace(os.Getenv("BLOB_S3_ACCESS_KEY_ID")),
+			SecretAccessKey: strings.TrimSpace(os.Getenv("BLOB_S3_SECRET_ACCESS_KEY")),
+		}
+		if cfg.Region == "" {
+			cfg.Region = defaultS3Region
+		}
+		return NewS3(cfg, nil)
+	default:
+		return nil, fmt.Errorf("BLOB_STORE_DRIVER must be %q or %q: %q", DriverLocal, DriverS3, driver)
+	}
+}
+
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.HasSuffix(key, "/") {
+		return fmt.Errorf("invalid blob key: %q", key)
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return fmt.Errorf("invalid blob key: %q", key)
+		}
+		for _, r := range part {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-' || r == '_' || r == '.') {
+				return fmt.Errorf("invalid blob key: %q", key)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	s3DefaultTimeout = 30 * time.Second
+	s3ErrorBodyLimit = 512
+	// emptyPayloadHash is the SHA-256 of an empty body.
+	emptyPayloadHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+)
+
+// S3Config points at an S3-compatible bucket. Objects are addressed
+// path-style ({endpoint}/{bucket}/{key}), which AWS S3, MinIO, Cloudflare R2
+// and Google Cloud Storage (with HMAC interoperability keys and the
+// https://storage.googleapis.com endpoint) all accept.
+type S3Config struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// S3 stores objects in a bucket, signing requests with AWS Signature V4.
+type S3 struct {
+	cfg      S3Config
+	endpoint *url.URL
+	client   *http.Client
+	now      func() time.Time
+}
+
+// NewS3 validates cfg. A nil client uses one with a 30 second timeout.
+func NewS3(cfg S3Config, client *http.Client) (*S3, error) {
+	for name, v := range map[string]string{
+		"BLOB_S3_ENDPOINT":          cfg.Endpoint,
+		"BLOB_S3_REGION":            cfg.Region,
+		"BLOB_S3_BUCKET":            cfg.Bucket,
+		"BLOB_S3_ACCESS_KEY_ID":     cfg.AccessKeyID,
+		"BLOB_S3_SECRET_ACCESS_KEY": cfg.SecretAccessKey,
+	} {
+		if v == "" {
+			return nil, fmt.Errorf("%s is required for the s3 blob store", name)
+		}
+	}
+	endpoint, err := url.Parse(strings.TrimRight(cfg.Endpoint, "/"))
+	if err != nil || (endpoint.Scheme != "https" && endpoint.Scheme != "http") || endpoint.Host == "" {
+		return nil, fmt.Errorf("BLOB_S3_ENDPOINT must be an http(s) URL: %q", cfg.Endpoint)
+	}
+	if client == nil {
+		client = &http.Client{Timeout: s3DefaultTimeout}
+	}
+	return &S3{cfg: cfg, endpoint: endpoint, client: client, now: time.Now}, nil
+}
+
+func (s *S3) objectURL(key string) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	return s.endpoint.String() + "/" + url.PathEscape(s.cfg.Bucket) + "/" + key, nil
+}
+
+func (s *S3) do(ctx context.Context, method, key, contentType string, body []byte) (*http.Response, error) {
+	target, err := s.objectURL(key)
+	if err != nil {
+		return nil, err
+	}
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, target, reader)
+	if err != nil {
+		return nil, err
+	}
+	payloadHash := emptyPayloadHash
+	if len(body) > 0 {
+		sum := sha256.Sum256(body)
+		payloadHash = hex.EncodeToString(sum[:])
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	signV4(req, payloadHash, s.cfg, s.now())
+	return s.client.Do(req)
+}
+
+func (s *S3) Put(ctx context.Context, key, contentType string, data []byte) error {
+	res, err := s.do(ctx, http.MethodPut, key, contentType, data)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode/100 != 2 {
+		return s3StatusError("put", key, res)
+	}
+	return nil
+}
+
+func (s *S3) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	res, err := s.do(ctx, http.MethodGet, key, "", nil)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode == http.StatusNotFound {
+		res.Body.Close()
+		return nil, ErrNotFound
+	}
+	if res.StatusCode/100 != 2 {
+		defer res.Body.Close()
+		return nil, s3StatusError("get", key, res)
+	}
+	return res.Body, nil
+}
+
+func (s *S3) Delete(ctx context.Context, key string) error {
+	res, err := s.do(ctx, http.MethodDelete, key, "", nil)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode/100 != 2 && res.StatusCode != http.StatusNotFound {
+		return s3StatusError("delete", key, res)
+	}
+	return nil
+}
+
+func s3StatusError(op, key string, res *http.Response) error {
+	msg, _ := io.ReadAll(io.LimitReader(res.Body, s3ErrorBodyLimit))
+	return fmt.Errorf("blob %s %s: status %d: %s", op, key, res.StatusCode, strings.TrimSpace(string(msg)))
+}
+
+// signV4 adds the x-amz-date, x-amz-content-sha256 and Authorization
+// headers. Every header already set on req is signed along with host.
+func signV4(req *http.Request, payloadHash string, cfg S3Config, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format("20060102T150405Z")
+	day := now.Format("20060102")
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalURI := req.URL.EscapedPath()
+	if canonicalURI == "" {
+		canonicalURI = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI,
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := day + "/" + cfg.Region + "/s3/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+cfg.SecretAccessKey), day)
+	key = hmacSHA256(key, cfg.Region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+cfg.AccessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,66 @@
+-- name: CreateTeamAbsence :exec
+INSERT INTO team_absences (id, team_id, user_id, starts_on, ends_on, reason, created_by_user_id, created_at, revision)
+VALUES (
+  sqlc.arg(id),
+  sqlc.arg(team_id),
+  NULLIF(sqlc.arg(user_id), '')::uuid,
+  sqlc.arg(starts_on),
+  sqlc.arg(ends_on),
+  sqlc.narg(reason),
+  sqlc.arg(created_by_user_id),
+  sqlc.arg(created_at),
+  sqlc.arg(revision)
+);
+
+-- name: GetTeamAbsenceForUpdate :one
+SELECT
+  a.id,
+  a.team_id,
+  COALESCE(a.user_id::text, ''::text) AS user_id,
+  a.starts_on,
+  a.ends_on,
+  a.reason,
+  COALESCE(a.created_by_user_id::text, ''::text) AS created_by_user_id,
+  a.created_at,
+  a.revision
+FROM team_absences a
+WHERE a.id = $1
+FOR UPDATE;
+
+-- name: ListTeamAbsencesOverlapping :many
+SELECT
+  a.id,
+  a.team_id,
+  COALESCE(a.user_id::text, ''::text) AS user_id,
+  a.starts_on,
+  a.ends_on,
+  a.reason,
+  COALESCE(a.created_by_user_id::text, ''::text) AS created_by_user_id,
+  a.created_at,
+  a.revision
+FROM team_absences a
+WHERE a.team_id = sqlc.arg(team_id)
+  AND a.ends_on >= sqlc.arg(range_start)
+  AND a.starts_on <= sqlc.arg(range_end)
+ORDER BY a.starts_on, a.created_at, a.id;
+
+-- name: ListTeamAbsencesEndingFrom :many
+SELECT
+  a.id,
+  a.team_id,
+  COALESCE(a.user_id::text, ''::text) AS user_id,
+  a.starts_on,
+  a.ends_on,
+  a.reason,
+  COALESCE(a.created_by_user_id::text, ''::text) AS created_by_user_id,
+  a.created_at,
+  a.revision
+FROM team_absences a
+WHERE a.team_id = sqlc.arg(team_id)
+  AND a.ends_on >= sqlc.arg(ends_from)
+ORDER BY a.starts_on, a.created_at, a.id;
+
+-- name: DeleteTeamAbsence :execrows
+DELETE FROM team_absences
+WHERE id = sqlc.arg(id)
+  AND team_id = sqlc.arg(team_id);
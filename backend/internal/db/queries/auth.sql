@@ -42,8 +42,17 @@ SELECT s.token, s.user_id, s.created_at, s.expires_at
 FROM sessions AS s
 INNER JOIN users AS u ON u.id = s.user_id
 WHERE s.token = $1
-  AND (s.expires_at IS NULL OR s.expires_at > NOW());
+  AND u.deleted_at IS NULL
+  AND (s.expires_at IS NULL OR s.expires_at > sqlc.arg(now));
 
 -- name: DeleteSession :exec
 DELETE FROM sessions
 WHERE token = $1;
+
+-- name: DeleteSessionsByUserID :exec
+DELETE FROM sessions
+WHERE user_id = $1;
+
+-- name: DeleteExchangeCodesByUserID :exec
+DELETE FROM oauth_exchange_codes
+WHERE user_id = $1;
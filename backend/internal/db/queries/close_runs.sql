@@ -8,3 +8,25 @@ SELECT MAX(target_date)::date AS target_date
 FROM close_runs
 WHERE team_id = $1
   AND scope = $2;
+
+-- name: InsertCloseRunHistory :exec
+INSERT INTO close_run_history (
+  id, team_id, scope, trigger, as_of, started_at, finished_at,
+  periods_processed, penalty_added, penalized_tasks, error_class, error_message
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12);
+
+-- name: ListCloseRunHistoryByTeamID :many
+SELECT *
+FROM close_run_history
+WHERE team_id = $1
+ORDER BY started_at DESC, id DESC
+LIMIT $2;
+
+-- name: GetLatestCloseRunHistory :one
+SELECT *
+FROM close_run_history
+WHERE team_id = $1
+  AND scope = $2
+ORDER BY started_at DESC, id DESC
+LIMIT 1;
@@ -0,0 +1,130 @@
+-- name: CreateCompletionAttachment :exec
+INSERT INTO completion_attachments (
+  id,
+  team_id,
+  task_id,
+  blob_key,
+  thumbnail_key,
+  content_type,
+  size_bytes,
+  width,
+  height,
+  uploaded_by_user_id,
+  created_at
+)
+VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9,
+  NULLIF(sqlc.arg(uploaded_by_user_id), '')::uuid,
+  sqlc.arg(created_at)
+);
+
+-- name: GetCompletionAttachmentByID :one
+SELECT
+  id,
+  COALESCE(team_id::text, ''::text) AS team_id,
+  blob_key,
+  thumbnail_key,
+  content_type,
+  size_bytes,
+  width,
+  height,
+  created_at
+FROM completion_attachments
+WHERE id = $1;
+
+-- name: GetTaskCompletionDailyEvidence :one
+SELECT
+  note,
+  COALESCE(attachment_id::text, ''::text) AS attachment_id
+FROM task_completion_daily
+WHERE task_id = $1 AND target_date = $2
+FOR UPDATE;
+
+-- name: SetTaskCompletionDailyEvidence :exec
+UPDATE task_completion_daily
+SET note = sqlc.narg(note),
+    attachment_id = NULLIF(sqlc.arg(attachment_id)::text, '')::uuid
+WHERE task_id = sqlc.arg(task_id) AND target_date = sqlc.arg(target_date);
+
+-- name: GetTaskCompletionWeeklyEntryEvidence :one
+SELECT
+  e.id,
+  e.slot,
+  e.note,
+  COALESCE(e.attachment_id::text, ''::text) AS attachment_id
+FROM (
+  SELECT
+    w.id,
+    w.note,
+    w.attachment_id,
+    ROW_NUMBER() OVER (ORDER BY w.created_at ASC, w.id ASC)::integer AS slot,
+    COUNT(*) OVER ()::integer AS total
+  FROM task_completion_weekly_entries w
+  WHERE w.task_id = sqlc.arg(task_id) AND w.week_start = sqlc.arg(week_start)
+) e
+WHERE e.slot = CASE WHEN sqlc.arg(slot)::integer > 0 THEN sqlc.arg(slot)::integer ELSE e.total END;
+
+-- name: SetTaskCompletionWeeklyEntryEvidence :exec
+UPDATE task_completion_weekly_entries
+SET note = sqlc.narg(note),
+    attachment_id = NULLIF(sqlc.arg(attachment_id)::text, '')::uuid
+WHERE id = sqlc.arg(id);
+
+-- name: GetTaskCompletionOneOffEvidence :one
+SELECT
+  note,
+  COALESCE(attachment_id::text, ''::text) AS attachment_id
+FROM task_completion_one_off
+WHERE task_id = $1
+FOR UPDATE;
+
+-- name: SetTaskCompletionOneOffEvidence :exec
+UPDATE task_completion_one_off
+SET note = sqlc.narg(note),
+    attachment_id = NULLIF(sqlc.arg(attachment_id)::text, '')::uuid
+WHERE task_id = sqlc.arg(task_id);
+
+-- name: ListCompletionEvidenceByTeam :many
+WITH evidence AS (
+  SELECT 'daily'::text AS kind, d.task_id, 1::integer AS slot, d.note, d.attachment_id
+  FROM task_completion_daily d
+  JOIN tasks t ON t.id = d.task_id
+  WHERE t.team_id = sqlc.arg(team_id)
+    AND t.type = 'daily'
+    AND d.target_date = sqlc.arg(target_date)
+  UNION ALL
+  SELECT 'weekly'::text, w.task_id, w.slot, w.note, w.attachment_id
+  FROM (
+    SELECT
+      e.task_id,
+      e.note,
+      e.attachment_id,
+      ROW_NUMBER() OVER (PARTITION BY e.task_id ORDER BY e.created_at ASC, e.id ASC)::integer AS slot
+    FROM task_completion_weekly_entries e
+    JOIN tasks t ON t.id = e.task_id
+    WHERE t.team_id = sqlc.arg(team_id)
+      AND t.type = 'weekly'
+      AND e.week_start = sqlc.arg(week_start)
+  ) w
+  UNION ALL
+  SELECT 'one_off'::text, o.task_id, 1::integer, o.note, o.attachment_id
+  FROM task_completion_one_off o
+  JOIN tasks t ON t.id = o.task_id
+  WHERE t.team_id = sqlc.arg(team_id)
+    AND t.type = 'one_off'
+)
+SELECT
+  ev.kind::text AS kind,
+  ev.task_id,
+  ev.slot::integer AS slot,
+  ev.note,
+  COALESCE(a.id::text, ''::text) AS attachment_id,
+  COALESCE(a.content_type, ''::text) AS content_type,
+  COALESCE(a.size_bytes, 0)::integer AS size_bytes,
+  COALESCE(a.width, 0)::integer AS width,
+  COALESCE(a.height, 0)::integer AS height,
+  a.created_at AS attachment_created_at
+FROM evidence ev
+LEFT JOIN completion_attachments a ON a.id = ev.attachment_id
+WHERE ev.note IS NOT NULL OR ev.attachment_id IS NOT NULL
+ORDER BY ev.task_id, ev.slot;
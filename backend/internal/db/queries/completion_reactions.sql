@@ -0,0 +1,83 @@
+-- name: CreateCompletionReaction :execrows
+INSERT INTO completion_reactions (
+  id,
+  task_id,
+  target_date,
+  weekly_entry_id,
+  one_off_task_id,
+  user_id,
+  emoji,
+  created_at
+)
+VALUES (
+  sqlc.arg(id),
+  sqlc.arg(task_id),
+  sqlc.narg(target_date),
+  NULLIF(sqlc.arg(weekly_entry_id)::text, '')::uuid,
+  NULLIF(sqlc.arg(one_off_task_id)::text, '')::uuid,
+  sqlc.arg(user_id),
+  sqlc.arg(emoji),
+  sqlc.arg(created_at)
+)
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteCompletionReaction :execrows
+DELETE FROM completion_reactions
+WHERE task_id = sqlc.arg(task_id)
+  AND target_date IS NOT DISTINCT FROM sqlc.narg(target_date)::date
+  AND weekly_entry_id IS NOT DISTINCT FROM NULLIF(sqlc.arg(weekly_entry_id)::text, '')::uuid
+  AND one_off_task_id IS NOT DISTINCT FROM NULLIF(sqlc.arg(one_off_task_id)::text, '')::uuid
+  AND user_id = sqlc.arg(user_id)
+  AND emoji = sqlc.arg(emoji);
+
+-- name: ListCompletionReactionsByTarget :many
+SELECT emoji, user_id::text AS user_id
+FROM completion_reactions
+WHERE task_id = sqlc.arg(task_id)
+  AND target_date IS NOT DISTINCT FROM sqlc.narg(target_date)::date
+  AND weekly_entry_id IS NOT DISTINCT FROM NULLIF(sqlc.arg(weekly_entry_id)::text, '')::uuid
+  AND one_off_task_id IS NOT DISTINCT FROM NULLIF(sqlc.arg(one_off_task_id)::text, '')::uuid
+ORDER BY created_at, id;
+
+-- name: ListCompletionReactionsByTeam :many
+WITH targets AS (
+  SELECT d.task_id, 1::integer AS slot, d.target_date, NULL::uuid AS weekly_entry_id, NULL::uuid AS one_off_task_id
+  FROM task_completion_daily d
+  JOIN tasks t ON t.id = d.task_id
+  WHERE t.team_id = sqlc.arg(team_id)
+    AND t.type = 'daily'
+    AND d.target_date = sqlc.arg(target_date)
+  UNION ALL
+  SELECT w.task_id, w.slot, NULL::date, w.id, NULL::uuid
+  FROM (
+    SELECT
+      e.id,
+      e.task_id,
+      ROW_NUMBER() OVER (PARTITION BY e.task_id ORDER BY e.created_at ASC, e.id ASC)::integer AS slot
+    FROM task_completion_weekly_entries e
+    JOIN tasks t ON t.id = e.task_id
+    WHERE t.team_id = sqlc.arg(team_id)
+      AND t.type = 'weekly'
+      AND e.week_start = sqlc.arg(week_start)
+  ) w
+  UNION ALL
+  SELECT o.task_id, 1::integer, NULL::date, NULL::uuid, o.task_id
+  FROM task_completion_one_off o
+  JOIN tasks t ON t.id = o.task_id
+  WHERE t.team_id = sqlc.arg(team_id)
+    AND t.type = 'one_off'
+)
+SELECT
+  tg.task_id,
+  tg.slot::integer AS slot,
+  r.emoji,
+  r.user_id::text AS user_id
+FROM targets tg
+JOIN completion_reactions r
+  ON r.task_id = tg.task_id
+  AND (
+    r.target_date = tg.target_date
+    OR r.weekly_entry_id = tg.weekly_entry_id
+    OR r.one_off_task_id = tg.one_off_task_id
+  )
+ORDER BY tg.task_id, tg.slot, r.created_at, r.id;
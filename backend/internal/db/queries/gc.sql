@@ -0,0 +1,209 @@
+-- name: CountExpiredAuthRequests :one
+SELECT COUNT(*)::bigint
+FROM oauth_auth_requests
+WHERE expires_at < sqlc.arg(cutoff);
+
+-- name: DeleteExpiredAuthRequestsBatch :execrows
+DELETE FROM oauth_auth_requests
+WHERE state IN (
+  SELECT a.state
+  FROM oauth_auth_requests a
+  WHERE a.expires_at < sqlc.arg(cutoff)
+  LIMIT sqlc.arg(batch_size)
+);
+
+-- name: CountStaleExchangeCodes :one
+SELECT COUNT(*)::bigint
+FROM oauth_exchange_codes
+WHERE LEAST(expires_at, COALESCE(used_at, expires_at)) < sqlc.arg(cutoff);
+
+-- name: DeleteStaleExchangeCodesBatch :execrows
+DELETE FROM oauth_exchange_codes
+WHERE code IN (
+  SELECT e.code
+  FROM oauth_exchange_codes e
+  WHERE LEAST(e.expires_at, COALESCE(e.used_at, e.expires_at)) < sqlc.arg(cutoff)
+  LIMIT sqlc.arg(batch_size)
+);
+
+-- name: CountStaleSessions :one
+SELECT COUNT(*)::bigint
+FROM sessions
+WHERE (expires_at IS NOT NULL AND expires_at < sqlc.arg(cutoff))
+   OR (expires_at IS NULL AND created_at < sqlc.arg(created_before));
+
+-- name: DeleteStaleSessionsBatch :execrows
+DELETE FROM sessions
+WHERE token IN (
+  SELECT s.token
+  FROM sessions s
+  WHERE (s.expires_at IS NOT NULL AND s.expires_at < sqlc.arg(cutoff))
+     OR (s.expires_at IS NULL AND s.created_at < sqlc.arg(created_before))
+  LIMIT sqlc.arg(batch_size)
+);
+
+-- name: CountExpiredInviteCodes :one
+SELECT COUNT(*)::bigint
+FROM invite_codes
+WHERE expires_at < sqlc.arg(cutoff);
+
+-- name: DeleteExpiredInviteCodesBatch :execrows
+DELETE FROM invite_codes
+WHERE code IN (
+  SELECT i.code
+  FROM invite_codes i
+  WHERE i.expires_at < sqlc.arg(cutoff)
+  LIMIT sqlc.arg(batch_size)
+);
+
+-- name: CountPurgeableDeletedTasks :one
+SELECT COUNT(*)::bigint
+FROM tasks t
+WHERE t.deleted_at IS NOT NULL
+  AND t.deleted_at < sqlc.arg(cutoff)
+  AND EXISTS (
+    SELECT 1
+    FROM monthly_penalty_summaries m
+    WHERE m.team_id = t.team_id
+      AND m.is_closed
+      AND m.month_start > date_trunc('month', t.deleted_at AT TIME ZONE sqlc.arg(time_zone)::text)::date
+  );
+
+-- name: DeletePurgeableDeletedTasksBatch :execrows
+DELETE FROM tasks
+WHERE id IN (
+  SELECT t.id
+  FROM tasks t
+  WHERE t.deleted_at IS NOT NULL
+    AND t.deleted_at < sqlc.arg(cutoff)
+    AND EXISTS (
+      SELECT 1
+      FROM monthly_penalty_summaries m
+      WHERE m.team_id = t.team_id
+        AND m.is_closed
+        AND m.month_start > date_trunc('month', t.deleted_at AT TIME ZONE sqlc.arg(time_zone)::text)::date
+    )
+  LIMIT sqlc.arg(batch_size)
+);
+
+-- name: CountPurgeableDeletedPenaltyRules :one
+SELECT COUNT(*)::bigint
+FROM penalty_rules r
+WHERE r.deleted_at IS NOT NULL
+  AND r.deleted_at < sqlc.arg(cutoff)
+  AND NOT EXISTS (
+    SELECT 1
+    FROM monthly_penalty_summary_triggered_rules tr
+    WHERE tr.rule_id = r.id
+  )
+  AND EXISTS (
+    SELECT 1
+    FROM monthly_penalty_summaries m
+    WHERE m.team_id = r.team_id
+      AND m.is_closed
+      AND m.month_start > date_trunc('month', r.deleted_at AT TIME ZONE sqlc.arg(time_zone)::text)::date
+  );
+
+-- name: DeletePurgeableDeletedPenaltyRulesBatch :execrows
+DELETE FROM penalty_rules
+WHERE id IN (
+  SELECT r.id
+  FROM penalty_rules r
+  WHERE r.deleted_at IS NOT NULL
+    AND r.deleted_at < sqlc.arg(cutoff)
+    AND NOT EXISTS (
+      SELECT 1
+      FROM monthly_penalty_summary_triggered_rules tr
+      WHERE tr.rule_id = r.id
+    )
+    AND EXISTS (
+      SELECT 1
+      FROM monthly_penalty_summaries m
+      WHERE m.team_id = r.team_id
+        AND m.is_closed
+        AND m.month_start > date_trunc('month', r.deleted_at AT TIME ZONE sqlc.arg(time_zone)::text)::date
+    )
+  LIMIT sqlc.arg(batch_size)
+);
+
+-- name: CountOldCloseRunHistory :one
+SELECT COUNT(*)::bigint
+FROM close_run_history
+WHERE started_at < sqlc.arg(cutoff);
+
+-- name: DeleteOldCloseRunHistoryBatch :execrows
+DELETE FROM close_run_history
+WHERE id IN (
+  SELECT h.id
+  FROM close_run_history h
+  WHERE h.started_at < sqlc.arg(cutoff)
+  LIMIT sqlc.arg(batch_size)
+);
+
+-- name: CountFinishedWebhookDeliveries :one
+SELECT COUNT(*)::bigint
+FROM team_webhook_deliveries
+WHERE status <> 'pending'
+  AND created_at < sqlc.arg(cutoff);
+
+-- name: DeleteFinishedWebhookDeliveriesBatch :execrows
+DELETE FROM team_webhook_deliveries
+WHERE id IN (
+  SELECT d.id
+  FROM team_webhook_deliveries d
+  WHERE d.status <> 'pending'
+    AND d.created_at < sqlc.arg(cutoff)
+  LIMIT sqlc.arg(batch_size)
+);
+
+-- name: CountExpiredPersonalDataExports :one
+SELECT COUNT(*)::bigint
+FROM personal_data_exports
+WHERE (status = 'ready' AND expires_at < sqlc.arg(cutoff))
+   OR (status = 'failed' AND requested_at < sqlc.arg(cutoff));
+
+-- name: DeleteExpiredPersonalDataExportsBatch :execrows
+DELETE FROM personal_data_exports
+WHERE id IN (
+  SELECT p.id
+  FROM personal_data_exports p
+  WHERE (p.status = 'ready' AND p.expires_at < sqlc.arg(cutoff))
+     OR (p.status = 'failed' AND p.requested_at < sqlc.arg(cutoff))
+  LIMIT sqlc.arg(batch_size)
+);
+
+-- name: CountOrphanedCompletionAttachments :one
+SELECT COUNT(*)::bigint
+FROM completion_attachments a
+WHERE a.created_at < sqlc.arg(cutoff)
+  AND NOT EXISTS (SELECT 1 FROM task_completion_daily d WHERE d.attachment_id = a.id)
+  AND NOT EXISTS (SELECT 1 FROM task_completion_weekly_entries e WHERE e.attachment_id = a.id)
+  AND NOT EXISTS (SELECT 1 FROM task_completion_one_off o WHERE o.attachment_id = a.id);
+
+-- name: ListOrphanedCompletionAttachmentsBatch :many
+SELECT a.id, a.blob_key, a.thumbnail_key
+FROM completion_attachments a
+WHERE a.created_at < sqlc.arg(cutoff)
+  AND NOT EXISTS (SELECT 1 FROM task_completion_daily d WHERE d.attachment_id = a.id)
+  AND NOT EXISTS (SELECT 1 FROM task_completion_weekly_entries e WHERE e.attachment_id = a.id)
+  AND NOT EXISTS (SELECT 1 FROM task_completion_one_off o WHERE o.attachment_id = a.id)
+ORDER BY a.created_at, a.id
+LIMIT sqlc.arg(batch_size);
+
+-- name: DeleteCompletionAttachmentsByIDs :execrows
+DELETE FROM completion_attachments
+WHERE id = ANY(sqlc.arg(ids)::uuid[]);
+
+-- name: CountOldTeamAuditEntries :one
+SELECT COUNT(*)::bigint
+FROM team_audit_log
+WHERE created_at < sqlc.arg(cutoff);
+
+-- name: DeleteOldTeamAuditEntriesBatch :execrows
+DELETE FROM team_audit_log
+WHERE id IN (
+  SELECT a.id
+  FROM team_audit_log a
+  WHERE a.created_at < sqlc.arg(cutoff)
+  LIMIT sqlc.arg(batch_size)
+);
@@ -0,0 +1,44 @@
+-- name: UpsertTeamHoliday :exec
+INSERT INTO team_holidays (team_id, holiday_date, name, source, created_by_user_id, created_at, revision)
+VALUES (
+  sqlc.arg(team_id),
+  sqlc.arg(holiday_date),
+  sqlc.arg(name),
+  sqlc.arg(source),
+  sqlc.arg(created_by_user_id),
+  sqlc.arg(created_at),
+  sqlc.arg(revision)
+)
+ON CONFLICT (team_id, holiday_date) DO UPDATE
+SET name = EXCLUDED.name,
+    source = EXCLUDED.source,
+    created_by_user_id = EXCLUDED.created_by_user_id,
+    created_at = EXCLUDED.created_at,
+    revision = EXCLUDED.revision;
+
+-- name: GetTeamHolidayForUpdate :one
+SELECT holiday_date, revision
+FROM team_holidays
+WHERE team_id = sqlc.arg(team_id)
+  AND holiday_date = sqlc.arg(holiday_date)
+FOR UPDATE;
+
+-- name: ListTeamHolidaysBetween :many
+SELECT
+  h.team_id,
+  h.holiday_date,
+  h.name,
+  h.source,
+  COALESCE(h.created_by_user_id::text, ''::text) AS created_by_user_id,
+  h.created_at,
+  h.revision
+FROM team_holidays h
+WHERE h.team_id = sqlc.arg(team_id)
+  AND h.holiday_date >= sqlc.arg(range_start)
+  AND h.holiday_date <= sqlc.arg(range_end)
+ORDER BY h.holiday_date;
+
+-- name: DeleteTeamHoliday :execrows
+DELETE FROM team_holidays
+WHERE team_id = sqlc.arg(team_id)
+  AND holiday_date = sqlc.arg(holiday_date);
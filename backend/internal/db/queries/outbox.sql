@@ -0,0 +1,34 @@
+-- name: InsertTeamEventOutbox :exec
+INSERT INTO team_event_outbox (team_id, entity, revision, hints, changed_at)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: ClaimPendingTeamEventOutbox :many
+SELECT id, team_id, entity, revision, hints, changed_at, attempt_count
+FROM team_event_outbox
+WHERE dispatched_at IS NULL
+ORDER BY id ASC
+LIMIT $1
+FOR UPDATE SKIP LOCKED;
+
+-- name: MarkTeamEventOutboxDispatched :exec
+UPDATE team_event_outbox
+SET dispatched_at = sqlc.arg(dispatched_at),
+    attempt_count = attempt_count + 1,
+    last_error = sqlc.narg(last_error)
+WHERE id = sqlc.arg(id);
+
+-- name: MarkTeamEventOutboxAttemptFailed :exec
+UPDATE team_event_outbox
+SET attempt_count = attempt_count + 1,
+    last_error = sqlc.arg(last_error)
+WHERE id = sqlc.arg(id);
+
+-- name: CountPendingTeamEventOutbox :one
+SELECT COUNT(*)::bigint
+FROM team_event_outbox
+WHERE dispatched_at IS NULL;
+
+-- name: DeleteDispatchedTeamEventOutboxBefore :execrows
+DELETE FROM team_event_outbox
+WHERE dispatched_at IS NOT NULL
+  AND dispatched_at < $1;
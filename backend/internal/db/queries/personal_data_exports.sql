@@ -0,0 +1,102 @@
+-- name: InsertPersonalDataExport :execrows
+INSERT INTO personal_data_exports (id, user_id, status, payload, requested_at, completed_at, expires_at)
+VALUES (
+  sqlc.arg(id), sqlc.arg(user_id), sqlc.arg(status), sqlc.arg(payload),
+  sqlc.arg(requested_at), sqlc.narg(completed_at), sqlc.narg(expires_at)
+)
+ON CONFLICT DO NOTHING;
+
+-- name: GetLatestPersonalDataExport :one
+SELECT id, user_id, status, attempt_count, last_error, requested_at, completed_at, expires_at
+FROM personal_data_exports
+WHERE user_id = $1
+ORDER BY requested_at DESC, id DESC
+LIMIT 1;
+
+-- name: GetPersonalDataExportPayload :one
+SELECT payload
+FROM personal_data_exports
+WHERE id = $1
+  AND status = 'ready';
+
+-- name: ClaimDuePersonalDataExports :many
+UPDATE personal_data_exports e
+SET next_attempt_at = sqlc.arg(lease_until)
+WHERE e.id IN (
+  SELECT due.id
+  FROM personal_data_exports due
+  WHERE due.status = 'pending'
+    AND due.next_attempt_at <= sqlc.arg(due_before)
+  ORDER BY due.next_attempt_at ASC
+  LIMIT sqlc.arg(batch_size)
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING e.id, e.user_id, e.attempt_count;
+
+-- name: MarkPersonalDataExportReady :exec
+UPDATE personal_data_exports
+SET status = 'ready',
+    payload = sqlc.arg(payload),
+    attempt_count = attempt_count + 1,
+    last_error = NULL,
+    completed_at = sqlc.arg(completed_at),
+    expires_at = sqlc.arg(expires_at)
+WHERE id = sqlc.arg(id);
+
+-- name: RecordPersonalDataExportFailure :exec
+UPDATE personal_data_exports
+SET status = CASE WHEN sqlc.arg(give_up)::boolean THEN 'failed' ELSE 'pending' END,
+    attempt_count = attempt_count + 1,
+    last_error = sqlc.arg(last_error),
+    next_attempt_at = sqlc.arg(next_attempt_at)
+WHERE id = sqlc.arg(id);
+
+-- name: CountPersonalCompletions :one
+SELECT (
+  (SELECT COUNT(*) FROM task_completion_daily WHERE completed_by_user_id = sqlc.arg(user_id)::uuid)
+  + (SELECT COUNT(*) FROM task_completion_weekly_entries WHERE completed_by_user_id = sqlc.arg(user_id)::uuid)
+  + (SELECT COUNT(*) FROM task_completion_one_off WHERE completed_by_user_id = sqlc.arg(user_id)::uuid)
+)::bigint;
+
+-- name: GetPersonalDataProfile :one
+SELECT id, email, display_name, nickname, color_hex, oidc_issuer, oidc_linked_at, created_at
+FROM users
+WHERE id = $1;
+
+-- name: ListPersonalDataMemberships :many
+SELECT tm.team_id, t.name AS team_name, tm.role, tm.created_at AS joined_at
+FROM team_members tm
+JOIN teams t ON t.id = tm.team_id
+WHERE tm.user_id = $1
+ORDER BY tm.created_at;
+
+-- name: ListPersonalDailyCompletions :many
+SELECT d.task_id, t.title AS task_title, t.team_id, d.target_date, d.created_at
+FROM task_completion_daily d
+JOIN tasks t ON t.id = d.task_id
+WHERE d.completed_by_user_id = $1
+ORDER BY d.target_date, d.task_id;
+
+-- name: ListPersonalWeeklyCompletions :many
+SELECT e.task_id, t.title AS task_title, t.team_id, e.week_start, e.created_at
+FROM task_completion_weekly_entries e
+JOIN tasks t ON t.id = e.task_id
+WHERE e.completed_by_user_id = $1
+ORDER BY e.week_start, e.task_id, e.created_at;
+
+-- name: ListPersonalOneOffCompletions :many
+SELECT o.task_id, t.title AS task_title, t.team_id, t.due_on, o.completed_at
+FROM task_completion_one_off o
+JOIN tasks t ON t.id = o.task_id
+WHERE o.completed_by_user_id = $1
+ORDER BY o.completed_at, o.task_id;
+
+-- name: ListPersonalSessions :many
+SELECT created_at, expires_at
+FROM sessions
+WHERE user_id = $1
+ORDER BY created_at;
+
+-- name: DeletePersonalDataExportsByUserID :exec
+DELETE FROM personal_data_exports
+WHERE user_id = $1;
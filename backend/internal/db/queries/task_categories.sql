@@ -0,0 +1,90 @@
+-- name: CreateTaskCategory :exec
+INSERT INTO task_categories (id, team_id, name, color_hex, icon, created_at, updated_at, revision)
+VALUES (
+  sqlc.arg(id),
+  sqlc.arg(team_id),
+  sqlc.arg(name),
+  sqlc.narg(color_hex),
+  sqlc.narg(icon),
+  sqlc.arg(created_at),
+  sqlc.arg(updated_at),
+  sqlc.arg(revision)
+);
+
+-- name: GetTaskCategoryByID :one
+SELECT id, team_id, name, color_hex, icon, created_at, updated_at, revision
+FROM task_categories
+WHERE id = $1;
+
+-- name: ListTaskCategoriesByTeamID :many
+SELECT id, team_id, name, color_hex, icon, created_at, updated_at, revision
+FROM task_categories
+WHERE team_id = $1
+ORDER BY lower(name), id;
+
+-- name: UpdateTaskCategory :exec
+UPDATE task_categories
+SET name = sqlc.arg(name),
+    color_hex = sqlc.narg(color_hex),
+    icon = sqlc.narg(icon),
+    updated_at = sqlc.arg(updated_at),
+    revision = sqlc.arg(revision)
+WHERE id = sqlc.arg(id);
+
+-- name: DeleteTaskCategory :execrows
+DELETE FROM task_categories
+WHERE id = sqlc.arg(id)
+  AND team_id = sqlc.arg(team_id);
+
+-- name: ClearTaskCategoryFromTasks :exec
+UPDATE tasks
+SET category_id = NULL,
+    revision = sqlc.arg(revision)
+WHERE category_id = sqlc.arg(category_id);
+
+-- name: ListMonthlyPenaltiesByCategory :many
+SELECT
+  COALESCE(e.category_id::text, ''::text) AS category_id,
+  COALESCE((array_agg(e.category_name ORDER BY e.target_date DESC, e.created_at DESC))[1], ''::text)::text AS category_name,
+  COUNT(*)::integer AS missed_count,
+  COALESCE(SUM(e.penalty_points), 0)::integer AS penalty_total
+FROM task_evaluation_dedupes e
+WHERE e.team_id = sqlc.arg(team_id)
+  AND (
+    (e.scope = 'penalty_day'
+      AND e.target_date >= sqlc.arg(month_start)::date
+      AND e.target_date < sqlc.arg(month_end)::date)
+    OR (e.scope = 'penalty_week'
+      AND e.target_date + 6 >= sqlc.arg(month_start)::date
+      AND e.target_date + 6 < sqlc.arg(month_end)::date)
+  )
+GROUP BY e.category_id
+ORDER BY lower(COALESCE((array_agg(e.category_name ORDER BY e.target_date DESC, e.created_at DESC))[1], ''::text)), e.category_id;
+
+-- name: ListMonthlyCompletionsByCategory :many
+SELECT
+  COALESCE(c.category_id::text, ''::text) AS category_id,
+  COUNT(*)::integer AS completed_count
+FROM (
+  SELECT t.category_id
+  FROM task_completion_daily d
+  JOIN tasks t ON t.id = d.task_id
+  WHERE t.team_id = sqlc.arg(team_id)
+    AND d.target_date >= sqlc.arg(month_start)::date
+    AND d.target_date < sqlc.arg(month_end)::date
+  UNION ALL
+  SELECT t.category_id
+  FROM task_completion_weekly_entries w
+  JOIN tasks t ON t.id = w.task_id
+  WHERE t.team_id = sqlc.arg(team_id)
+    AND w.week_start + 6 >= sqlc.arg(month_start)::date
+    AND w.week_start + 6 < sqlc.arg(month_end)::date
+  UNION ALL
+  SELECT t.category_id
+  FROM task_completion_one_off o
+  JOIN tasks t ON t.id = o.task_id
+  WHERE t.team_id = sqlc.arg(team_id)
+    AND t.due_on >= sqlc.arg(month_start)::date
+    AND t.due_on < sqlc.arg(month_end)::date
+) c
+GROUP BY c.category_id;
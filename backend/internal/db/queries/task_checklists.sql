@@ -0,0 +1,83 @@
+-- name: ListTaskChecklistItemsByTaskID :many
+SELECT id, task_id, title, position, created_at, updated_at
+FROM task_checklist_items
+WHERE task_id = $1
+ORDER BY position, created_at, id;
+
+-- name: GetTaskChecklistItemByID :one
+SELECT id, task_id, title, position, created_at, updated_at
+FROM task_checklist_items
+WHERE id = $1;
+
+-- name: CreateTaskChecklistItem :exec
+INSERT INTO task_checklist_items (id, task_id, title, position, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: UpdateTaskChecklistItem :exec
+UPDATE task_checklist_items
+SET title = $2,
+    updated_at = $3
+WHERE id = $1;
+
+-- name: SetTaskChecklistItemPosition :exec
+UPDATE task_checklist_items
+SET position = $2
+WHERE id = $1;
+
+-- name: DeleteTaskChecklistItem :exec
+DELETE FROM task_checklist_items
+WHERE id = $1;
+
+-- name: HasTaskChecklistCheck :one
+SELECT EXISTS (
+  SELECT 1
+  FROM task_checklist_checks
+  WHERE item_id = $1 AND period_start = $2
+);
+
+-- name: CreateTaskChecklistCheck :exec
+INSERT INTO task_checklist_checks (item_id, period_start, checked_by_user_id, checked_at)
+VALUES (
+  sqlc.arg(item_id),
+  sqlc.arg(period_start),
+  NULLIF(sqlc.arg(checked_by_user_id), '')::uuid,
+  sqlc.arg(checked_at)
+);
+
+-- name: DeleteTaskChecklistCheck :exec
+DELETE FROM task_checklist_checks
+WHERE item_id = $1 AND period_start = $2;
+
+-- name: CountUncheckedTaskChecklistItems :one
+SELECT COUNT(*)::integer
+FROM task_checklist_items i
+WHERE i.task_id = $1
+  AND NOT EXISTS (
+    SELECT 1
+    FROM task_checklist_checks c
+    WHERE c.item_id = i.id AND c.period_start = $2
+  );
+
+-- name: ListTaskChecklistStatesByTeam :many
+SELECT
+  i.id,
+  i.task_id,
+  i.title,
+  i.position,
+  (c.item_id IS NOT NULL)::boolean AS checked,
+  COALESCE(c.checked_by_user_id::text, ''::text) AS checked_by_user_id,
+  COALESCE(NULLIF(u.nickname, ''), u.display_name, ''::text) AS checked_by_effective_name,
+  u.color_hex AS checked_by_color_hex
+FROM task_checklist_items i
+JOIN tasks t ON t.id = i.task_id
+LEFT JOIN task_checklist_checks c
+  ON c.item_id = i.id
+  AND (
+    (t.type = 'daily' AND c.period_start = sqlc.arg(today)::date)
+    OR (t.type = 'weekly' AND c.period_start = sqlc.arg(week_start)::date)
+    OR t.type = 'one_off'
+  )
+LEFT JOIN users u ON u.id = c.checked_by_user_id
+WHERE t.team_id = sqlc.arg(team_id)
+  AND t.deleted_at IS NULL
+ORDER BY i.task_id, i.position, i.created_at, i.id;
@@ -0,0 +1,74 @@
+-- name: CreateTaskComment :exec
+INSERT INTO task_comments (id, task_id, author_user_id, body, created_at, revision)
+VALUES (
+  sqlc.arg(id),
+  sqlc.arg(task_id),
+  NULLIF(sqlc.arg(author_user_id)::text, '')::uuid,
+  sqlc.arg(body),
+  sqlc.arg(created_at),
+  sqlc.arg(revision)
+);
+
+-- name: GetTaskCommentForUpdate :one
+SELECT
+  id,
+  task_id,
+  COALESCE(author_user_id::text, ''::text) AS author_user_id,
+  revision
+FROM task_comments
+WHERE id = $1
+FOR UPDATE;
+
+-- name: UpdateTaskCommentBody :exec
+UPDATE task_comments
+SET body = sqlc.arg(body),
+    edited_at = sqlc.arg(edited_at),
+    revision = sqlc.arg(revision)
+WHERE id = sqlc.arg(id);
+
+-- name: DeleteTaskComment :exec
+DELETE FROM task_comments
+WHERE id = $1;
+
+-- name: GetTaskCommentByID :one
+SELECT
+  c.id,
+  c.task_id,
+  c.body,
+  c.created_at,
+  c.edited_at,
+  c.revision,
+  COALESCE(c.author_user_id::text, ''::text) AS author_user_id,
+  COALESCE(NULLIF(u.nickname, ''), u.display_name, ''::text) AS author_effective_name,
+  u.color_hex AS author_color_hex
+FROM task_comments c
+LEFT JOIN users u ON u.id = c.author_user_id
+WHERE c.id = $1;
+
+-- name: ListTaskComments :many
+SELECT
+  c.id,
+  c.task_id,
+  c.body,
+  c.created_at,
+  c.edited_at,
+  c.revision,
+  COALESCE(c.author_user_id::text, ''::text) AS author_user_id,
+  COALESCE(NULLIF(u.nickname, ''), u.display_name, ''::text) AS author_effective_name,
+  u.color_hex AS author_color_hex
+FROM task_comments c
+LEFT JOIN users u ON u.id = c.author_user_id
+WHERE c.task_id = sqlc.arg(task_id)
+  AND (
+    sqlc.narg(before_created_at)::timestamptz IS NULL
+    OR (c.created_at, c.id) < (sqlc.narg(before_created_at)::timestamptz, NULLIF(sqlc.arg(before_id)::text, '')::uuid)
+  )
+ORDER BY c.created_at DESC, c.id DESC
+LIMIT sqlc.arg(row_limit);
+
+-- name: ListPersonalTaskComments :many
+SELECT c.id, c.task_id, t.title AS task_title, t.team_id, c.body, c.created_at, c.edited_at
+FROM task_comments c
+JOIN tasks t ON t.id = c.task_id
+WHERE c.author_user_id = $1
+ORDER BY c.created_at, c.id;
@@ -126,3 +126,34 @@ WHERE t.team_id = $1
   AND e.week_start >= $2
   AND e.week_start < $3
 ORDER BY e.week_start, e.task_id, slot;
+
+-- name: GetTaskCompletionOneOff :one
+SELECT
+  task_id,
+  COALESCE(completed_by_user_id::text, ''::text) AS completed_by_user_id,
+  completed_at
+FROM task_completion_one_off
+WHERE task_id = $1;
+
+-- name: CreateTaskCompletionOneOff :exec
+INSERT INTO task_completion_one_off (task_id, completed_by_user_id, completed_at)
+VALUES ($1, NULLIF(sqlc.arg(completed_by_user_id), '')::uuid, sqlc.arg(completed_at))
+ON CONFLICT (task_id) DO NOTHING;
+
+-- name: DeleteTaskCompletionOneOff :exec
+DELETE FROM task_completion_one_off
+WHERE task_id = $1;
+
+-- name: ListTaskCompletionOneOffByTeam :many
+SELECT
+  o.task_id,
+  COALESCE(o.completed_by_user_id::text, ''::text) AS completed_by_user_id,
+  COALESCE(NULLIF(u.nickname, ''), u.display_name, ''::text) AS completed_by_effective_name,
+  u.color_hex AS completed_by_color_hex,
+  o.completed_at
+FROM task_completion_one_off o
+JOIN tasks t ON t.id = o.task_id
+LEFT JOIN users u ON u.id = o.completed_by_user_id
+WHERE t.team_id = $1
+  AND t.type = 'one_off'
+ORDER BY o.task_id;
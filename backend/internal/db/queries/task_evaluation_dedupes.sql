@@ -3,10 +3,11 @@ INSERT INTO task_evaluation_dedupes (team_id, scope, target_date, task_id, creat
 VALUES ($1, $2, $3, $4, NOW())
 ON CONFLICT (team_id, scope, target_date, task_id) DO NOTHING;
 
--- name: SumDailyPenaltyForClose :one
+-- name: RecordDailyPenaltiesForClose :many
 WITH candidates AS (
-  SELECT t.id AS task_id, t.penalty_points
+  SELECT t.id AS task_id, t.title, t.penalty_points, t.category_id, cat.name AS category_name
   FROM tasks t
+  LEFT JOIN task_categories cat ON cat.id = t.category_id
   LEFT JOIN task_completion_daily d
     ON d.task_id = t.id
    AND d.target_date = $2
@@ -14,23 +15,40 @@ WITH candidates AS (
     AND t.type = 'daily'
     AND t.created_at < $3
     AND (t.deleted_at IS NULL OR t.deleted_at >= $3)
+    AND (t.starts_on IS NULL OR t.starts_on <= $2)
+    AND (t.ends_on IS NULL OR t.ends_on >= $2)
     AND d.task_id IS NULL
+    AND NOT EXISTS (
+      SELECT 1
+      FROM team_absences a
+      WHERE a.team_id = t.team_id
+        AND (a.user_id IS NULL OR a.user_id = t.assignee_user_id)
+        AND $2 BETWEEN a.starts_on AND a.ends_on
+    )
+    AND NOT EXISTS (
+      SELECT 1
+      FROM team_holidays h
+      WHERE h.team_id = t.team_id
+        AND h.holiday_date = $2
+    )
 ),
 deduped AS (
-  INSERT INTO task_evaluation_dedupes (team_id, scope, target_date, task_id, created_at)
-  SELECT $1, 'penalty_day', $2, c.task_id, NOW()
+  INSERT INTO task_evaluation_dedupes (team_id, scope, target_date, task_id, penalty_points, category_id, category_name, created_at)
+  SELECT $1, 'penalty_day', $2, c.task_id, c.penalty_points, c.category_id, c.category_name, NOW()
   FROM candidates c
   ON CONFLICT (team_id, scope, target_date, task_id) DO NOTHING
   RETURNING task_id
 )
-SELECT COALESCE(SUM(c.penalty_points), 0)::bigint AS total_penalty
+SELECT c.task_id, c.title, c.penalty_points
 FROM candidates c
-JOIN deduped d ON d.task_id = c.task_id;
+JOIN deduped d ON d.task_id = c.task_id
+ORDER BY c.title, c.task_id;
 
--- name: SumWeeklyPenaltyForClose :one
+-- name: RecordWeeklyPenaltiesForClose :many
 WITH candidates AS (
-  SELECT t.id AS task_id, t.penalty_points
+  SELECT t.id AS task_id, t.title, t.penalty_points, t.category_id, cat.name AS category_name
   FROM tasks t
+  LEFT JOIN task_categories cat ON cat.id = t.category_id
   LEFT JOIN (
     SELECT task_id, week_start, COUNT(*)::integer AS completion_count
     FROM task_completion_weekly_entries
@@ -39,19 +57,79 @@ WITH candidates AS (
   ) w
     ON w.task_id = t.id
    AND w.week_start = $2
+  CROSS JOIN LATERAL (
+    SELECT COUNT(*)::integer AS paused_days
+    FROM generate_series(0, 6) AS g(day_offset)
+    WHERE EXISTS (
+      SELECT 1
+      FROM team_absences a
+      WHERE a.team_id = t.team_id
+        AND (a.user_id IS NULL OR a.user_id = t.assignee_user_id)
+        AND $2::date + g.day_offset BETWEEN a.starts_on AND a.ends_on
+    ) OR EXISTS (
+      SELECT 1
+      FROM team_holidays h
+      WHERE h.team_id = t.team_id
+        AND h.holiday_date = $2::date + g.day_offset
+    ) OR $2::date + g.day_offset < t.starts_on
+      OR $2::date + g.day_offset > t.ends_on
+  ) p
   WHERE t.team_id = $1
     AND t.type = 'weekly'
     AND t.created_at < $3
     AND (t.deleted_at IS NULL OR t.deleted_at >= $3)
-    AND COALESCE(w.completion_count, 0) < t.required_completions_per_week
+    AND (t.starts_on IS NULL OR t.starts_on <= $2::date + 6)
+    AND (t.ends_on IS NULL OR t.ends_on >= $2)
+    AND COALESCE(w.completion_count, 0) < (t.required_completions_per_week * (7 - p.paused_days) + 6) / 7
 ),
 deduped AS (
-  INSERT INTO task_evaluation_dedupes (team_id, scope, target_date, task_id, created_at)
-  SELECT $1, 'penalty_week', $2, c.task_id, NOW()
+  INSERT INTO task_evaluation_dedupes (team_id, scope, target_date, task_id, penalty_points, category_id, category_name, created_at)
+  SELECT $1, 'penalty_week', $2, c.task_id, c.penalty_points, c.category_id, c.category_name, NOW()
   FROM candidates c
   ON CONFLICT (team_id, scope, target_date, task_id) DO NOTHING
   RETURNING task_id
 )
-SELECT COALESCE(SUM(c.penalty_points), 0)::bigint AS total_penalty
+SELECT c.task_id, c.title, c.penalty_points
 FROM candidates c
-JOIN deduped d ON d.task_id = c.task_id;
+JOIN deduped d ON d.task_id = c.task_id
+ORDER BY c.title, c.task_id;
+
+-- name: RecordOneOffPenaltiesForClose :many
+WITH candidates AS (
+  SELECT t.id AS task_id, t.title, t.penalty_points, t.category_id, cat.name AS category_name
+  FROM tasks t
+  LEFT JOIN task_categories cat ON cat.id = t.category_id
+  LEFT JOIN task_completion_one_off o
+    ON o.task_id = t.id
+   AND o.completed_at < sqlc.arg(cutoff)::timestamptz
+  WHERE t.team_id = sqlc.arg(team_id)::uuid
+    AND t.type = 'one_off'
+    AND t.due_on = sqlc.arg(target_date)::date
+    AND t.created_at < sqlc.arg(cutoff)::timestamptz
+    AND (t.deleted_at IS NULL OR t.deleted_at >= sqlc.arg(cutoff)::timestamptz)
+    AND o.task_id IS NULL
+    AND NOT EXISTS (
+      SELECT 1
+      FROM team_absences a
+      WHERE a.team_id = t.team_id
+        AND (a.user_id IS NULL OR a.user_id = t.assignee_user_id)
+        AND sqlc.arg(target_date)::date BETWEEN a.starts_on AND a.ends_on
+    )
+    AND NOT EXISTS (
+      SELECT 1
+      FROM team_holidays h
+      WHERE h.team_id = t.team_id
+        AND h.holiday_date = sqlc.arg(target_date)::date
+    )
+),
+deduped AS (
+  INSERT INTO task_evaluation_dedupes (team_id, scope, target_date, task_id, penalty_points, category_id, category_name, created_at)
+  SELECT sqlc.arg(team_id)::uuid, 'penalty_day', sqlc.arg(target_date)::date, c.task_id, c.penalty_points, c.category_id, c.category_name, NOW()
+  FROM candidates c
+  ON CONFLICT (team_id, scope, target_date, task_id) DO NOTHING
+  RETURNING task_id
+)
+SELECT c.task_id, c.title, c.penalty_points
+FROM candidates c
+JOIN deduped d ON d.task_id = c.task_id
+ORDER BY c.title, c.task_id;
@@ -0,0 +1,66 @@
+-- name: CreateTaskTemplate :exec
+INSERT INTO task_templates (id, team_id, name, description, created_by_user_id, created_at, updated_at)
+VALUES (
+  sqlc.arg(id),
+  sqlc.arg(team_id),
+  sqlc.arg(name),
+  sqlc.arg(description),
+  NULLIF(sqlc.arg(created_by_user_id)::text, '')::uuid,
+  sqlc.arg(created_at),
+  sqlc.arg(updated_at)
+);
+
+-- name: CreateTaskTemplateItem :exec
+INSERT INTO task_template_items (
+  id, template_id, position, title, notes, type, penalty_points,
+  required_completions_per_week, due_in_days, checklist
+)
+VALUES (
+  sqlc.arg(id),
+  sqlc.arg(template_id),
+  sqlc.arg(position),
+  sqlc.arg(title),
+  sqlc.arg(notes),
+  sqlc.arg(type),
+  sqlc.arg(penalty_points),
+  sqlc.arg(required_completions_per_week),
+  sqlc.narg(due_in_days),
+  sqlc.arg(checklist)::text[]
+);
+
+-- name: CountTaskTemplatesByTeamID :one
+SELECT COUNT(*)::integer
+FROM task_templates
+WHERE team_id = $1;
+
+-- name: GetTaskTemplateByID :one
+SELECT id, team_id, name, description, created_at, updated_at
+FROM task_templates
+WHERE id = sqlc.arg(id)
+  AND team_id = sqlc.arg(team_id);
+
+-- name: ListTaskTemplatesByTeamID :many
+SELECT id, team_id, name, description, created_at, updated_at
+FROM task_templates
+WHERE team_id = $1
+ORDER BY lower(name), id;
+
+-- name: ListTaskTemplateItemsByTeamID :many
+SELECT i.id, i.template_id, i.position, i.title, i.notes, i.type, i.penalty_points,
+  i.required_completions_per_week, i.due_in_days, i.checklist
+FROM task_template_items i
+JOIN task_templates t ON t.id = i.template_id
+WHERE t.team_id = $1
+ORDER BY i.template_id, i.position, i.id;
+
+-- name: ListTaskTemplateItemsByTemplateID :many
+SELECT id, template_id, position, title, notes, type, penalty_points,
+  required_completions_per_week, due_in_days, checklist
+FROM task_template_items
+WHERE template_id = $1
+ORDER BY position, id;
+
+-- name: DeleteTaskTemplate :execrows
+DELETE FROM task_templates
+WHERE id = sqlc.arg(id)
+  AND team_id = sqlc.arg(team_id);
@@ -1,12 +1,12 @@
 -- name: ListTasksByTeamID :many
-SELECT id, team_id, title, notes, type, penalty_points, COALESCE(assignee_user_id::text, '') AS assignee_user_id, required_completions_per_week, created_at, updated_at, deleted_at
+SELECT id, team_id, title, notes, type, penalty_points, COALESCE(assignee_user_id::text, '') AS assignee_user_id, required_completions_per_week, due_on, starts_on, ends_on, COALESCE(category_id::text, '') AS category_id, created_at, updated_at, deleted_at, revision
 FROM tasks
 WHERE team_id = $1
   AND deleted_at IS NULL
 ORDER BY created_at;
 
 -- name: ListUndeletedTasksByTeamID :many
-SELECT id, team_id, title, notes, type, penalty_points, COALESCE(assignee_user_id::text, '') AS assignee_user_id, required_completions_per_week, created_at, updated_at, deleted_at
+SELECT id, team_id, title, notes, type, penalty_points, COALESCE(assignee_user_id::text, '') AS assignee_user_id, required_completions_per_week, due_on, starts_on, ends_on, COALESCE(category_id::text, '') AS category_id, created_at, updated_at, deleted_at, revision
 FROM tasks
 WHERE team_id = $1
   AND deleted_at IS NULL
@@ -18,7 +18,7 @@ FROM tasks
 WHERE team_id = $1;
 
 -- name: ListTasksEffectiveForCloseByTeamAndType :many
-SELECT id, team_id, title, notes, type, penalty_points, COALESCE(assignee_user_id::text, '') AS assignee_user_id, required_completions_per_week, created_at, updated_at, deleted_at
+SELECT id, team_id, title, notes, type, penalty_points, COALESCE(assignee_user_id::text, '') AS assignee_user_id, required_completions_per_week, due_on, starts_on, ends_on, COALESCE(category_id::text, '') AS category_id, created_at, updated_at, deleted_at, revision
 FROM tasks
 WHERE team_id = $1
   AND type = $2
@@ -27,13 +27,13 @@ WHERE team_id = $1
 ORDER BY created_at;
 
 -- name: GetTaskByID :one
-SELECT id, team_id, title, notes, type, penalty_points, COALESCE(assignee_user_id::text, '') AS assignee_user_id, required_completions_per_week, created_at, updated_at, deleted_at
+SELECT id, team_id, title, notes, type, penalty_points, COALESCE(assignee_user_id::text, '') AS assignee_user_id, required_completions_per_week, due_on, starts_on, ends_on, COALESCE(category_id::text, '') AS category_id, created_at, updated_at, deleted_at, revision
 FROM tasks
 WHERE id = $1;
 
 -- name: CreateTask :exec
-INSERT INTO tasks (id, team_id, title, notes, type, penalty_points, assignee_user_id, required_completions_per_week, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, '')::uuid, $8, $9, $10);
+INSERT INTO tasks (id, team_id, title, notes, type, penalty_points, assignee_user_id, required_completions_per_week, created_at, updated_at, revision, due_on, starts_on, ends_on, category_id)
+VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, '')::uuid, $8, $9, $10, $11, $12, $13, $14, NULLIF($15, '')::uuid);
 
 -- name: UpdateTask :exec
 UPDATE tasks
@@ -42,13 +42,23 @@ SET title = $2,
     penalty_points = $4,
     assignee_user_id = NULLIF($5, '')::uuid,
     required_completions_per_week = $6,
-    updated_at = $7
+    updated_at = $7,
+    revision = $8,
+    due_on = $9,
+    starts_on = $10,
+    ends_on = $11,
+    category_id = NULLIF($12, '')::uuid
+WHERE id = $1;
+
+-- name: UpdateTaskRevision :exec
+UPDATE tasks
+SET revision = $2
 WHERE id = $1;
 
 -- name: DeleteTask :exec
 UPDATE tasks
-SET deleted_at = NOW(),
-    updated_at = NOW()
+SET deleted_at = $2,
+    updated_at = $2
 WHERE id = $1;
 
 -- name: ClearTaskAssigneeByTeamAndUser :exec
@@ -59,13 +69,13 @@ WHERE team_id = $1
   AND deleted_at IS NULL;
 
 -- name: ListTasksForMonthlyStatusByTeam :many
-SELECT id, title, notes, type, penalty_points, required_completions_per_week, created_at, deleted_at
+SELECT id, title, notes, type, penalty_points, required_completions_per_week, due_on, starts_on, ends_on, created_at, deleted_at
 FROM tasks t
 WHERE t.team_id = $1
   AND t.created_at < $3
   AND t.deleted_at IS NULL
 UNION ALL
-SELECT id, title, notes, type, penalty_points, required_completions_per_week, created_at, deleted_at
+SELECT id, title, notes, type, penalty_points, required_completions_per_week, due_on, starts_on, ends_on, created_at, deleted_at
 FROM tasks t
 WHERE t.team_id = $1
   AND t.created_at < $3
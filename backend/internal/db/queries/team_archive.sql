@@ -0,0 +1,241 @@
+-- name: GetArchiveTeam :one
+SELECT id, name, created_at
+FROM teams
+WHERE id = $1;
+
+-- name: ListArchiveMembersByTeamID :many
+SELECT
+  u.id AS user_id,
+  u.email,
+  u.display_name,
+  u.nickname,
+  u.color_hex,
+  u.created_at AS user_created_at,
+  tm.role,
+  tm.created_at AS joined_at
+FROM team_members tm
+JOIN users u ON u.id = tm.user_id
+WHERE tm.team_id = $1
+ORDER BY tm.created_at, u.id;
+
+-- name: ListArchiveTeamAbsencesByTeamID :many
+SELECT
+  id,
+  COALESCE(user_id::text, ''::text) AS user_id,
+  starts_on,
+  ends_on,
+  reason,
+  COALESCE(created_by_user_id::text, ''::text) AS created_by_user_id,
+  created_at
+FROM team_absences
+WHERE team_id = $1
+ORDER BY starts_on, created_at, id;
+
+-- name: ListArchiveTeamHolidaysByTeamID :many
+SELECT holiday_date, name, source, COALESCE(created_by_user_id::text, ''::text) AS created_by_user_id, created_at
+FROM team_holidays
+WHERE team_id = $1
+ORDER BY holiday_date;
+
+-- name: ListArchiveTasksByTeamID :many
+SELECT
+  id,
+  title,
+  notes,
+  type,
+  penalty_points,
+  COALESCE(assignee_user_id::text, ''::text) AS assignee_user_id,
+  required_completions_per_week,
+  due_on,
+  starts_on,
+  ends_on,
+  COALESCE(category_id::text, ''::text) AS category_id,
+  created_at,
+  updated_at,
+  deleted_at
+FROM tasks
+WHERE team_id = $1
+ORDER BY created_at, id;
+
+-- name: ListArchiveDailyCompletionsByTeamID :many
+SELECT
+  d.task_id,
+  d.target_date,
+  COALESCE(d.completed_by_user_id::text, ''::text) AS completed_by_user_id,
+  d.created_at
+FROM task_completion_daily d
+JOIN tasks t ON t.id = d.task_id
+WHERE t.team_id = $1
+ORDER BY d.target_date, d.task_id;
+
+-- name: ListArchiveWeeklyEntriesByTeamID :many
+SELECT
+  e.task_id,
+  e.week_start,
+  COALESCE(e.completed_by_user_id::text, ''::text) AS completed_by_user_id,
+  e.created_at
+FROM task_completion_weekly_entries e
+JOIN tasks t ON t.id = e.task_id
+WHERE t.team_id = $1
+ORDER BY e.week_start, e.task_id, e.created_at, e.id;
+
+-- name: ListArchiveOneOffCompletionsByTeamID :many
+SELECT
+  o.task_id,
+  COALESCE(o.completed_by_user_id::text, ''::text) AS completed_by_user_id,
+  o.completed_at
+FROM task_completion_one_off o
+JOIN tasks t ON t.id = o.task_id
+WHERE t.team_id = $1
+ORDER BY o.completed_at, o.task_id;
+
+-- name: ListArchiveTaskCategoriesByTeamID :many
+SELECT id, name, color_hex, icon, created_at, updated_at
+FROM task_categories
+WHERE team_id = $1
+ORDER BY created_at, id;
+
+-- name: ListArchiveTaskChecklistItemsByTeamID :many
+SELECT i.id, i.task_id, i.title, i.position, i.created_at, i.updated_at
+FROM task_checklist_items i
+JOIN tasks t ON t.id = i.task_id
+WHERE t.team_id = $1
+ORDER BY i.task_id, i.position, i.created_at, i.id;
+
+-- name: ListArchiveTaskTemplatesByTeamID :many
+SELECT id, name, description, COALESCE(created_by_user_id::text, ''::text) AS created_by_user_id, created_at, updated_at
+FROM task_templates
+WHERE team_id = $1
+ORDER BY created_at, id;
+
+-- name: ListArchivePenaltyRulesByTeamID :many
+SELECT id, threshold, name, description, created_at, updated_at, deleted_at
+FROM penalty_rules
+WHERE team_id = $1
+ORDER BY created_at, id;
+
+-- name: ListArchiveMonthlySummariesByTeamID :many
+SELECT month_start, daily_penalty_total, weekly_penalty_total, is_closed
+FROM monthly_penalty_summaries
+WHERE team_id = $1
+ORDER BY month_start;
+
+-- name: ListArchiveTriggeredRulesByTeamID :many
+SELECT month_start, rule_id, created_at
+FROM monthly_penalty_summary_triggered_rules
+WHERE team_id = $1
+ORDER BY month_start, rule_id;
+
+-- name: ListArchiveCloseRunsByTeamID :many
+SELECT scope, target_date, created_at
+FROM close_runs
+WHERE team_id = $1
+ORDER BY scope, target_date;
+
+-- name: ListArchiveTaskEvaluationDedupesByTeamID :many
+SELECT scope, target_date, task_id, penalty_points, COALESCE(category_id::text, ''::text) AS category_id, category_name, created_at
+FROM task_evaluation_dedupes
+WHERE team_id = $1
+ORDER BY scope, target_date, task_id;
+
+-- name: ListArchiveCloseRunHistoryByTeamID :many
+SELECT *
+FROM close_run_history
+WHERE team_id = $1
+ORDER BY started_at, id;
+
+-- name: ListExistingUserEmails :many
+SELECT email
+FROM users
+WHERE LOWER(email) = ANY(sqlc.arg(emails)::text[])
+ORDER BY email;
+
+-- name: ImportUser :exec
+INSERT INTO users (id, email, display_name, nickname, color_hex, created_at)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: ImportTeamAbsence :exec
+INSERT INTO team_absences (id, team_id, user_id, starts_on, ends_on, reason, created_by_user_id, created_at)
+VALUES (
+  sqlc.arg(id), sqlc.arg(team_id), NULLIF(sqlc.arg(user_id)::text, '')::uuid, sqlc.arg(starts_on), sqlc.arg(ends_on),
+  sqlc.narg(reason), NULLIF(sqlc.arg(created_by_user_id)::text, '')::uuid, sqlc.arg(created_at)
+);
+
+-- name: ImportTeamHoliday :exec
+INSERT INTO team_holidays (team_id, holiday_date, name, source, created_by_user_id, created_at)
+VALUES (
+  sqlc.arg(team_id), sqlc.arg(holiday_date), sqlc.arg(name), sqlc.arg(source),
+  NULLIF(sqlc.arg(created_by_user_id)::text, '')::uuid, sqlc.arg(created_at)
+);
+
+-- name: ImportTask :exec
+INSERT INTO tasks (
+  id, team_id, title, notes, type, penalty_points, assignee_user_id,
+  required_completions_per_week, due_on, starts_on, ends_on, category_id, created_at, updated_at, deleted_at
+)
+VALUES (
+  sqlc.arg(id), sqlc.arg(team_id), sqlc.arg(title), sqlc.arg(notes), sqlc.arg(type), sqlc.arg(penalty_points),
+  NULLIF(sqlc.arg(assignee_user_id), '')::uuid,
+  sqlc.arg(required_completions_per_week), sqlc.narg(due_on), sqlc.narg(starts_on), sqlc.narg(ends_on),
+  NULLIF(sqlc.arg(category_id), '')::uuid, sqlc.arg(created_at), sqlc.arg(updated_at), sqlc.arg(deleted_at)
+);
+
+-- name: ImportTaskCompletionDaily :exec
+INSERT INTO task_completion_daily (task_id, target_date, completed_by_user_id, created_at)
+VALUES (sqlc.arg(task_id), sqlc.arg(target_date), NULLIF(sqlc.arg(completed_by_user_id), '')::uuid, sqlc.arg(created_at));
+
+-- name: ImportTaskCompletionWeeklyEntry :exec
+INSERT INTO task_completion_weekly_entries (id, task_id, week_start, completed_by_user_id, created_at)
+VALUES (sqlc.arg(id), sqlc.arg(task_id), sqlc.arg(week_start), NULLIF(sqlc.arg(completed_by_user_id), '')::uuid, sqlc.arg(created_at));
+
+-- name: ImportTaskCompletionOneOff :exec
+INSERT INTO task_completion_one_off (task_id, completed_by_user_id, completed_at)
+VALUES (sqlc.arg(task_id), NULLIF(sqlc.arg(completed_by_user_id), '')::uuid, sqlc.arg(completed_at));
+
+-- name: ImportTaskCategory :exec
+INSERT INTO task_categories (id, team_id, name, color_hex, icon, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7);
+
+-- name: ImportTaskChecklistItem :exec
+INSERT INTO task_checklist_items (id, task_id, title, position, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: ImportTaskTemplate :exec
+INSERT INTO task_templates (id, team_id, name, description, created_by_user_id, created_at, updated_at)
+VALUES (
+  sqlc.arg(id), sqlc.arg(team_id), sqlc.arg(name), sqlc.arg(description),
+  NULLIF(sqlc.arg(created_by_user_id)::text, '')::uuid, sqlc.arg(created_at), sqlc.arg(updated_at)
+);
+
+-- name: ImportTaskTemplateItem :exec
+INSERT INTO task_template_items (
+  id, template_id, position, title, notes, type, penalty_points,
+  required_completions_per_week, due_in_days, checklist
+)
+VALUES (
+  sqlc.arg(id), sqlc.arg(template_id), sqlc.arg(position), sqlc.arg(title), sqlc.arg(notes), sqlc.arg(type),
+  sqlc.arg(penalty_points), sqlc.arg(required_completions_per_week), sqlc.narg(due_in_days), sqlc.arg(checklist)::text[]
+);
+
+-- name: ImportPenaltyRule :exec
+INSERT INTO penalty_rules (id, team_id, threshold, name, description, created_at, updated_at, deleted_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: ImportMonthlyPenaltySummary :exec
+INSERT INTO monthly_penalty_summaries (team_id, month_start, daily_penalty_total, weekly_penalty_total, is_closed)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: ImportTriggeredRule :exec
+INSERT INTO monthly_penalty_summary_triggered_rules (team_id, month_start, rule_id, created_at)
+VALUES ($1, $2, $3, $4);
+
+-- name: ImportCloseRun :exec
+INSERT INTO close_runs (team_id, scope, target_date, created_at)
+VALUES ($1, $2, $3, $4);
+
+-- name: ImportTaskEvaluationDedupe :exec
+INSERT INTO task_evaluation_dedupes (team_id, scope, target_date, task_id, penalty_points, category_id, category_name, created_at)
+VALUES (
+  sqlc.arg(team_id), sqlc.arg(scope), sqlc.arg(target_date), sqlc.arg(task_id), sqlc.arg(penalty_points),
+  NULLIF(sqlc.arg(category_id)::text, '')::uuid, sqlc.narg(category_name), sqlc.arg(created_at)
+);
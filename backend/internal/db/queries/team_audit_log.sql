@@ -0,0 +1,37 @@
+-- name: CreateTeamAuditEntry :exec
+INSERT INTO team_audit_log (id, team_id, actor_user_id, entity, entity_id, action, before, after, revision, created_at)
+VALUES (
+  sqlc.arg(id),
+  sqlc.arg(team_id),
+  NULLIF(sqlc.arg(actor_user_id)::text, '')::uuid,
+  sqlc.arg(entity),
+  sqlc.narg(entity_id),
+  sqlc.arg(action),
+  sqlc.narg(before),
+  sqlc.narg(after),
+  sqlc.arg(revision),
+  sqlc.arg(created_at)
+);
+
+-- name: ListTeamAuditEntries :many
+SELECT
+  a.id,
+  a.entity,
+  a.entity_id,
+  a.action,
+  a.before,
+  a.after,
+  a.revision,
+  a.created_at,
+  COALESCE(a.actor_user_id::text, ''::text) AS actor_user_id,
+  COALESCE(NULLIF(u.nickname, ''), u.display_name, ''::text) AS actor_effective_name,
+  u.color_hex AS actor_color_hex
+FROM team_audit_log a
+LEFT JOIN users u ON u.id = a.actor_user_id
+WHERE a.team_id = sqlc.arg(team_id)
+  AND (
+    sqlc.narg(before_created_at)::timestamptz IS NULL
+    OR (a.created_at, a.id) < (sqlc.narg(before_created_at)::timestamptz, NULLIF(sqlc.arg(before_id)::text, '')::uuid)
+  )
+ORDER BY a.created_at DESC, a.id DESC
+LIMIT sqlc.arg(row_limit);
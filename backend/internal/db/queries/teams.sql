@@ -8,7 +8,13 @@ WHERE id = $1;
 
 -- name: UpdateTeamName :exec
 UPDATE teams
-SET name = $2
+SET name = $2,
+    settings_revision = $3
+WHERE id = $1;
+
+-- name: GetTeamSettings :one
+SELECT name, settings_revision
+FROM teams
 WHERE id = $1;
 
 -- name: AddTeamMember :exec
@@ -70,6 +76,12 @@ SELECT state_revision
 FROM teams
 WHERE id = $1;
 
+-- name: GetTeamStateRevisionForUpdate :one
+SELECT state_revision
+FROM teams
+WHERE id = $1
+FOR UPDATE;
+
 -- name: UpdateTeamStateRevisionIfMatch :one
 UPDATE teams
 SET state_revision = state_revision + 1
@@ -46,3 +46,16 @@ SET oidc_issuer = NULLIF($2, ''),
     oidc_subject = NULLIF($3, ''),
     oidc_linked_at = $4
 WHERE id = $1;
+
+-- name: AnonymizeUser :execrows
+UPDATE users
+SET email = sqlc.arg(email),
+    display_name = sqlc.arg(display_name),
+    nickname = NULL,
+    color_hex = NULL,
+    oidc_issuer = NULL,
+    oidc_subject = NULL,
+    oidc_linked_at = NULL,
+    deleted_at = sqlc.arg(deleted_at)
+WHERE id = sqlc.arg(id)
+  AND deleted_at IS NULL;
@@ -0,0 +1,83 @@
+-- name: CreateTeamWebhook :exec
+INSERT INTO team_webhooks (id, team_id, url, secret, event_types, is_active, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8);
+
+-- name: GetTeamWebhookByID :one
+SELECT id, team_id, url, secret, event_types, is_active, created_at, updated_at
+FROM team_webhooks
+WHERE id = $1;
+
+-- name: ListTeamWebhooksByTeamID :many
+SELECT id, team_id, url, secret, event_types, is_active, created_at, updated_at
+FROM team_webhooks
+WHERE team_id = $1
+ORDER BY created_at ASC, id ASC;
+
+-- name: ListActiveTeamWebhooksForEvent :many
+SELECT id, team_id, url, secret, event_types, is_active, created_at, updated_at
+FROM team_webhooks
+WHERE team_id = sqlc.arg(team_id)
+  AND is_active = TRUE
+  AND (cardinality(event_types) = 0 OR sqlc.arg(event_type)::text = ANY(event_types))
+ORDER BY created_at ASC, id ASC;
+
+-- name: UpdateTeamWebhook :exec
+UPDATE team_webhooks
+SET url = $2,
+    secret = $3,
+    event_types = $4,
+    is_active = $5,
+    updated_at = $6
+WHERE id = $1;
+
+-- name: DeleteTeamWebhook :execrows
+DELETE FROM team_webhooks
+WHERE id = $1
+  AND team_id = $2;
+
+-- name: CreateTeamWebhookDelivery :exec
+INSERT INTO team_webhook_deliveries (id, webhook_id, team_id, event_type, payload, next_attempt_at, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $6);
+
+-- name: ClaimDueTeamWebhookDeliveries :many
+UPDATE team_webhook_deliveries d
+SET next_attempt_at = sqlc.arg(lease_until)
+FROM team_webhooks w
+WHERE w.id = d.webhook_id
+  AND d.id IN (
+    SELECT due.id
+    FROM team_webhook_deliveries due
+    WHERE due.status = 'pending'
+      AND due.next_attempt_at <= sqlc.arg(due_before)
+    ORDER BY due.next_attempt_at ASC
+    LIMIT sqlc.arg(batch_size)
+    FOR UPDATE SKIP LOCKED
+  )
+RETURNING d.id, d.webhook_id, d.team_id, d.event_type, d.payload, d.attempt_count, w.url, w.secret, w.is_active;
+
+-- name: MarkTeamWebhookDeliverySucceeded :exec
+UPDATE team_webhook_deliveries
+SET status = 'succeeded',
+    attempt_count = attempt_count + 1,
+    last_attempt_at = sqlc.arg(attempted_at),
+    last_response_status = sqlc.arg(response_status),
+    last_error = NULL,
+    delivered_at = sqlc.arg(attempted_at)
+WHERE id = sqlc.arg(id);
+
+-- name: MarkTeamWebhookDeliveryAttemptFailed :exec
+UPDATE team_webhook_deliveries
+SET status = sqlc.arg(status),
+    attempt_count = attempt_count + 1,
+    last_attempt_at = sqlc.arg(attempted_at),
+    next_attempt_at = sqlc.arg(next_attempt_at),
+    last_response_status = sqlc.narg(response_status),
+    last_error = sqlc.arg(last_error)
+WHERE id = sqlc.arg(id);
+
+-- name: ListTeamWebhookDeliveriesByWebhookID :many
+SELECT id, webhook_id, team_id, event_type, payload, status, attempt_count, next_attempt_at, last_attempt_at, last_response_status, last_error, delivered_at, created_at
+FROM team_webhook_deliveries
+WHERE webhook_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2;
@@ -0,0 +1,248 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: absences.sql
+
+package dbsqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createTeamAbsence = `-- name: CreateTeamAbsence :exec
+INSERT INTO team_absences (id, team_id, user_id, starts_on, ends_on, reason, created_by_user_id, created_at, revision)
+VALUES (
+  $1,
+  $2,
+  NULLIF($3, '')::uuid,
+  $4,
+  $5,
+  $6,
+  $7,
+  $8,
+  $9
+)
+`
+
+type CreateTeamAbsenceParams struct {
+	ID              string             `json:"id"`
+	TeamID          string             `json:"team_id"`
+	UserID          interface{}        `json:"user_id"`
+	StartsOn        pgtype.Date        `json:"starts_on"`
+	EndsOn          pgtype.Date        `json:"ends_on"`
+	Reason          pgtype.Text        `json:"reason"`
+	CreatedByUserID string             `json:"created_by_user_id"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	Revision        int64              `json:"revision"`
+}
+
+func (q *Queries) CreateTeamAbsence(ctx context.Context, arg CreateTeamAbsenceParams) error {
+	_, err := q.db.Exec(ctx, createTeamAbsence,
+		arg.ID,
+		arg.TeamID,
+		arg.UserID,
+		arg.StartsOn,
+		arg.EndsOn,
+		arg.Reason,
+		arg.CreatedByUserID,
+		arg.CreatedAt,
+		arg.Revision,
+	)
+	return err
+}
+
+const deleteTeamAbsence = `-- name: DeleteTeamAbsence :execrows
+DELETE FROM team_absences
+WHERE id = $1
+  AND team_id = $2
+`
+
+type DeleteTeamAbsenceParams struct {
+	ID     string `json:"id"`
+	TeamID string `json:"team_id"`
+}
+
+func (q *Queries) DeleteTeamAbsence(ctx context.Context, arg DeleteTeamAbsenceParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteTeamAbsence, arg.ID, arg.TeamID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getTeamAbsenceForUpdate = `-- name: GetTeamAbsenceForUpdate :one
+SELECT
+  a.id,
+  a.team_id,
+  COALESCE(a.user_id::text, ''::text) AS user_id,
+  a.starts_on,
+  a.ends_on,
+  a.reason,
+  COALESCE(a.created_by_user_id::text, ''::text) AS created_by_user_id,
+  a.created_at,
+  a.revision
+FROM team_absences a
+WHERE a.id = $1
+FOR UPDATE
+`
+
+type GetTeamAbsenceForUpdateRow struct {
+	ID              string             `json:"id"`
+	TeamID          string             `json:"team_id"`
+	UserID          interface{}        `json:"user_id"`
+	StartsOn        pgtype.Date        `json:"starts_on"`
+	EndsOn          pgtype.Date        `json:"ends_on"`
+	Reason          pgtype.Text        `json:"reason"`
+	CreatedByUserID interface{}        `json:"created_by_user_id"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	Revision        int64              `json:"revision"`
+}
+
+func (q *Queries) GetTeamAbsenceForUpdate(ctx context.Context, id string) (GetTeamAbsenceForUpdateRow, error) {
+	row := q.db.QueryRow(ctx, getTeamAbsenceForUpdate, id)
+	var i GetTeamAbsenceForUpdateRow
+	err := row.Scan(
+		&i.ID,
+		&i.TeamID,
+		&i.UserID,
+		&i.StartsOn,
+		&i.EndsOn,
+		&i.Reason,
+		&i.CreatedByUserID,
+		&i.CreatedAt,
+		&i.Revision,
+	)
+	return i, err
+}
+
+const listTeamAbsencesEndingFrom = `-- name: ListTeamAbsencesEndingFrom :many
+SELECT
+  a.id,
+  a.team_id,
+  COALESCE(a.user_id::text, ''::text) AS user_id,
+  a.starts_on,
+  a.ends_on,
+  a.reason,
+  COALESCE(a.created_by_user_id::text, ''::text) AS created_by_user_id,
+  a.created_at,
+  a.revision
+FROM team_absences a
+WHERE a.team_id = $1
+  AND a.ends_on >= $2
+ORDER BY a.starts_on, a.created_at, a.id
+`
+
+type ListTeamAbsencesEndingFromParams struct {
+	TeamID   string      `json:"team_id"`
+	EndsFrom pgtype.Date `json:"ends_from"`
+}
+
+type ListTeamAbsencesEndingFromRow struct {
+	ID              string             `json:"id"`
+	TeamID          string             `json:"team_id"`
+	UserID          interface{}        `json:"user_id"`
+	StartsOn        pgtype.Date        `json:"starts_on"`
+	EndsOn          pgtype.Date        `json:"ends_on"`
+	Reason          pgtype.Text        `json:"reason"`
+	CreatedByUserID interface{}        `json:"created_by_user_id"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	Revision        int64              `json:"revision"`
+}
+
+func (q *Queries) ListTeamAbsencesEndingFrom(ctx context.Context, arg ListTeamAbsencesEndingFromParams) ([]ListTeamAbsencesEndingFromRow, error) {
+	rows, err := q.db.Query(ctx, listTeamAbsencesEndingFrom, arg.TeamID, arg.EndsFrom)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTeamAbsencesEndingFromRow
+	for rows.Next() {
+		var i ListTeamAbsencesEndingFromRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.TeamID,
+			&i.UserID,
+			&i.StartsOn,
+			&i.EndsOn,
+			&i.Reason,
+			&i.CreatedByUserID,
+			&i.CreatedAt,
+			&i.Revision,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTeamAbsencesOverlapping = `-- name: ListTeamAbsencesOverlapping :many
+SELECT
+  a.id,
+  a.team_id,
+  COALESCE(a.user_id::text, ''::text) AS user_id,
+  a.starts_on,
+  a.ends_on,
+  a.reason,
+  COALESCE(a.created_by_user_id::text, ''::text) AS created_by_user_id,
+  a.created_at,
+  a.revision
+FROM team_absences a
+WHERE a.team_id = $1
+  AND a.ends_on >= $2
+  AND a.starts_on <= $3
+ORDER BY a.starts_on, a.created_at, a.id
+`
+
+type ListTeamAbsencesOverlappingParams struct {
+	TeamID     string      `json:"team_id"`
+	RangeStart pgtype.Date `json:"range_start"`
+	RangeEnd   pgtype.Date `json:"range_end"`
+}
+
+type ListTeamAbsencesOverlappingRow struct {
+	ID              string             `json:"id"`
+	TeamID          string             `json:"team_id"`
+	UserID          interface{}        `json:"user_id"`
+	StartsOn        pgtype.Date        `json:"starts_on"`
+	EndsOn          pgtype.Date        `json:"ends_on"`
+	Reason          pgtype.Text        `json:"reason"`
+	CreatedByUserID interface{}        `json:"created_by_user_id"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	Revision        int64              `json:"revision"`
+}
+
+func (q *Queries) ListTeamAbsencesOverlapping(ctx context.Context, arg ListTeamAbsencesOverlappingParams) ([]ListTeamAbsencesOverlappingRow, error) {
+	rows, err := q.db.Query(ctx, listTeamAbsencesOverlapping, arg.TeamID, arg.RangeStart, arg.RangeEnd)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTeamAbsencesOverlappingRow
+	for rows.Next() {
+		var i ListTeamAbsencesOverlappingRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.TeamID,
+			&i.UserID,
+			&i.StartsOn,
+			&i.EndsOn,
+			&i.Reason,
+			&i.CreatedByUserID,
+			&i.CreatedAt,
+			&i.Revision,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
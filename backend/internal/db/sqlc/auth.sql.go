@@ -47,6 +47,16 @@ func (q *Queries) DeleteAuthRequest(ctx context.Context, state string) error {
 	return err
 }
 
+const deleteExchangeCodesByUserID = `-- name: DeleteExchangeCodesByUserID :exec
+DELETE FROM oauth_exchange_codes
+WHERE user_id = $1
+`
+
+func (q *Queries) DeleteExchangeCodesByUserID(ctx context.Context, userID string) error {
+	_, err := q.db.Exec(ctx, deleteExchangeCodesByUserID, userID)
+	return err
+}
+
 const deleteSession = `-- name: DeleteSession :exec
 DELETE FROM sessions
 WHERE token = $1
@@ -57,6 +67,16 @@ func (q *Queries) DeleteSession(ctx context.Context, token string) error {
 	return err
 }
 
+const deleteSessionsByUserID = `-- name: DeleteSessionsByUserID :exec
+DELETE FROM sessions
+WHERE user_id = $1
+`
+
+func (q *Queries) DeleteSessionsByUserID(ctx context.Context, userID string) error {
+	_, err := q.db.Exec(ctx, deleteSessionsByUserID, userID)
+	return err
+}
+
 const getAuthRequest = `-- name: GetAuthRequest :one
 SELECT state, nonce, code_verifier, expires_at, created_at
 FROM oauth_auth_requests
@@ -100,11 +120,17 @@ SELECT s.token, s.user_id, s.created_at, s.expires_at
 FROM sessions AS s
 INNER JOIN users AS u ON u.id = s.user_id
 WHERE s.token = $1
-  AND (s.expires_at IS NULL OR s.expires_at > NOW())
+  AND u.deleted_at IS NULL
+  AND (s.expires_at IS NULL OR s.expires_at > $2)
 `
 
-func (q *Queries) GetSessionByToken(ctx context.Context, token string) (Session, error) {
-	row := q.db.QueryRow(ctx, getSessionByToken, token)
+type GetSessionByTokenParams struct {
+	Token string             `json:"token"`
+	Now   pgtype.Timestamptz `json:"now"`
+}
+
+func (q *Queries) GetSessionByToken(ctx context.Context, arg GetSessionByTokenParams) (Session, error) {
+	row := q.db.QueryRow(ctx, getSessionByToken, arg.Token, arg.Now)
 	var i Session
 	err := row.Scan(
 		&i.Token,
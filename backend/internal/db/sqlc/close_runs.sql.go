@@ -11,6 +11,40 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const getLatestCloseRunHistory = `-- name: GetLatestCloseRunHistory :one
+SELECT id, team_id, scope, trigger, as_of, started_at, finished_at, periods_processed, penalty_added, penalized_tasks, error_class, error_message
+FROM close_run_history
+WHERE team_id = $1
+  AND scope = $2
+ORDER BY started_at DESC, id DESC
+LIMIT 1
+`
+
+type GetLatestCloseRunHistoryParams struct {
+	TeamID string `json:"team_id"`
+	Scope  string `json:"scope"`
+}
+
+func (q *Queries) GetLatestCloseRunHistory(ctx context.Context, arg GetLatestCloseRunHistoryParams) (CloseRunHistory, error) {
+	row := q.db.QueryRow(ctx, getLatestCloseRunHistory, arg.TeamID, arg.Scope)
+	var i CloseRunHistory
+	err := row.Scan(
+		&i.ID,
+		&i.TeamID,
+		&i.Scope,
+		&i.Trigger,
+		&i.AsOf,
+		&i.StartedAt,
+		&i.FinishedAt,
+		&i.PeriodsProcessed,
+		&i.PenaltyAdded,
+		&i.PenalizedTasks,
+		&i.ErrorClass,
+		&i.ErrorMessage,
+	)
+	return i, err
+}
+
 const getLatestCloseRunTargetDate = `-- name: GetLatestCloseRunTargetDate :one
 SELECT MAX(target_date)::date AS target_date
 FROM close_runs
@@ -49,3 +83,90 @@ func (q *Queries) InsertCloseRun(ctx context.Context, arg InsertCloseRunParams)
 	}
 	return result.RowsAffected(), nil
 }
+
+const insertCloseRunHistory = `-- name: InsertCloseRunHistory :exec
+INSERT INTO close_run_history (
+  id, team_id, scope, trigger, as_of, started_at, finished_at,
+  periods_processed, penalty_added, penalized_tasks, error_class, error_message
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+`
+
+type InsertCloseRunHistoryParams struct {
+	ID               string             `json:"id"`
+	TeamID           string             `json:"team_id"`
+	Scope            string             `json:"scope"`
+	Trigger          string             `json:"trigger"`
+	AsOf             pgtype.Timestamptz `json:"as_of"`
+	StartedAt        pgtype.Timestamptz `json:"started_at"`
+	FinishedAt       pgtype.Timestamptz `json:"finished_at"`
+	PeriodsProcessed int32              `json:"periods_processed"`
+	PenaltyAdded     int32              `json:"penalty_added"`
+	PenalizedTasks   int32              `json:"penalized_tasks"`
+	ErrorClass       pgtype.Text        `json:"error_class"`
+	ErrorMessage     pgtype.Text        `json:"error_message"`
+}
+
+func (q *Queries) InsertCloseRunHistory(ctx context.Context, arg InsertCloseRunHistoryParams) error {
+	_, err := q.db.Exec(ctx, insertCloseRunHistory,
+		arg.ID,
+		arg.TeamID,
+		arg.Scope,
+		arg.Trigger,
+		arg.AsOf,
+		arg.StartedAt,
+		arg.FinishedAt,
+		arg.PeriodsProcessed,
+		arg.PenaltyAdded,
+		arg.PenalizedTasks,
+		arg.ErrorClass,
+		arg.ErrorMessage,
+	)
+	return err
+}
+
+const listCloseRunHistoryByTeamID = `-- name: ListCloseRunHistoryByTeamID :many
+SELECT id, team_id, scope, trigger, as_of, started_at, finished_at, periods_processed, penalty_added, penalized_tasks, error_class, error_message
+FROM close_run_history
+WHERE team_id = $1
+ORDER BY started_at DESC, id DESC
+LIMIT $2
+`
+
+type ListCloseRunHistoryByTeamIDParams struct {
+	TeamID string `json:"team_id"`
+	Limit  int32  `json:"limit"`
+}
+
+func (q *Queries) ListCloseRunHistoryByTeamID(ctx context.Context, arg ListCloseRunHistoryByTeamIDParams) ([]CloseRunHistory, error) {
+	rows, err := q.db.Query(ctx, listCloseRunHistoryByTeamID, arg.TeamID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CloseRunHistory
+	for rows.Next() {
+		var i CloseRunHistory
+		if err := rows.Scan(
+			&i.ID,
+			&i.TeamID,
+			&i.Scope,
+			&i.Trigger,
+			&i.AsOf,
+			&i.StartedAt,
+			&i.FinishedAt,
+			&i.PeriodsProcessed,
+			&i.PenaltyAdded,
+			&i.PenalizedTasks,
+			&i.ErrorClass,
+			&i.ErrorMessage,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,355 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: completion_evidence.sql
+
+package dbsqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createCompletionAttachment = `-- name: CreateCompletionAttachment :exec
+INSERT INTO completion_attachments (
+  id,
+  team_id,
+  task_id,
+  blob_key,
+  thumbnail_key,
+  content_type,
+  size_bytes,
+  width,
+  height,
+  uploaded_by_user_id,
+  created_at
+)
+VALUES (
+  $1, $2, $3, $4, $5, $6, $7, $8, $9,
+  NULLIF($10, '')::uuid,
+  $11
+)
+`
+
+type CreateCompletionAttachmentParams struct {
+	ID               string             `json:"id"`
+	TeamID           string             `json:"team_id"`
+	TaskID           string             `json:"task_id"`
+	BlobKey          string             `json:"blob_key"`
+	ThumbnailKey     string             `json:"thumbnail_key"`
+	ContentType      string             `json:"content_type"`
+	SizeBytes        int32              `json:"size_bytes"`
+	Width            int32              `json:"width"`
+	Height           int32              `json:"height"`
+	UploadedByUserID interface{}        `json:"uploaded_by_user_id"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+}
+
+func (q *Queries) CreateCompletionAttachment(ctx context.Context, arg CreateCompletionAttachmentParams) error {
+	_, err := q.db.Exec(ctx, createCompletionAttachment,
+		arg.ID,
+		arg.TeamID,
+		arg.TaskID,
+		arg.BlobKey,
+		arg.ThumbnailKey,
+		arg.ContentType,
+		arg.SizeBytes,
+		arg.Width,
+		arg.Height,
+		arg.UploadedByUserID,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const getCompletionAttachmentByID = `-- name: GetCompletionAttachmentByID :one
+SELECT
+  id,
+  COALESCE(team_id::text, ''::text) AS team_id,
+  blob_key,
+  thumbnail_key,
+  content_type,
+  size_bytes,
+  width,
+  height,
+  created_at
+FROM completion_attachments
+WHERE id = $1
+`
+
+type GetCompletionAttachmentByIDRow struct {
+	ID           string             `json:"id"`
+	TeamID       interface{}        `json:"team_id"`
+	BlobKey      string             `json:"blob_key"`
+	ThumbnailKey string             `json:"thumbnail_key"`
+	ContentType  string             `json:"content_type"`
+	SizeBytes    int32              `json:"size_bytes"`
+	Width        int32              `json:"width"`
+	Height       int32              `json:"height"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
+
+func (q *Queries) GetCompletionAttachmentByID(ctx context.Context, id string) (GetCompletionAttachmentByIDRow, error) {
+	row := q.db.QueryRow(ctx, getCompletionAttachmentByID, id)
+	var i GetCompletionAttachmentByIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.TeamID,
+		&i.BlobKey,
+		&i.ThumbnailKey,
+		&i.ContentType,
+		&i.SizeBytes,
+		&i.Width,
+		&i.Height,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const getTaskCompletionDailyEvidence = `-- name: GetTaskCompletionDailyEvidence :one
+SELECT
+  note,
+  COALESCE(attachment_id::text, ''::text) AS attachment_id
+FROM task_completion_daily
+WHERE task_id = $1 AND target_date = $2
+FOR UPDATE
+`
+
+type GetTaskCompletionDailyEvidenceParams struct {
+	TaskID     string      `json:"task_id"`
+	TargetDate pgtype.Date `json:"target_date"`
+}
+
+type GetTaskCompletionDailyEvidenceRow struct {
+	Note         pgtype.Text `json:"note"`
+	AttachmentID interface{} `json:"attachment_id"`
+}
+
+func (q *Queries) GetTaskCompletionDailyEvidence(ctx context.Context, arg GetTaskCompletionDailyEvidenceParams) (GetTaskCompletionDailyEvidenceRow, error) {
+	row := q.db.QueryRow(ctx, getTaskCompletionDailyEvidence, arg.TaskID, arg.TargetDate)
+	var i GetTaskCompletionDailyEvidenceRow
+	err := row.Scan(&i.Note, &i.AttachmentID)
+	return i, err
+}
+
+const getTaskCompletionOneOffEvidence = `-- name: GetTaskCompletionOneOffEvidence :one
+SELECT
+  note,
+  COALESCE(attachment_id::text, ''::text) AS attachment_id
+FROM task_completion_one_off
+WHERE task_id = $1
+FOR UPDATE
+`
+
+type GetTaskCompletionOneOffEvidenceRow struct {
+	Note         pgtype.Text `json:"note"`
+	AttachmentID interface{} `json:"attachment_id"`
+}
+
+func (q *Queries) GetTaskCompletionOneOffEvidence(ctx context.Context, taskID string) (GetTaskCompletionOneOffEvidenceRow, error) {
+	row := q.db.QueryRow(ctx, getTaskCompletionOneOffEvidence, taskID)
+	var i GetTaskCompletionOneOffEvidenceRow
+	err := row.Scan(&i.Note, &i.AttachmentID)
+	return i, err
+}
+
+const getTaskCompletionWeeklyEntryEvidence = `-- name: GetTaskCompletionWeeklyEntryEvidence :one
+SELECT
+  e.id,
+  e.slot,
+  e.note,
+  COALESCE(e.attachment_id::text, ''::text) AS attachment_id
+FROM (
+  SELECT
+    w.id,
+    w.note,
+    w.attachment_id,
+    ROW_NUMBER() OVER (ORDER BY w.created_at ASC, w.id ASC)::integer AS slot,
+    COUNT(*) OVER ()::integer AS total
+  FROM task_completion_weekly_entries w
+  WHERE w.task_id = $1 AND w.week_start = $2
+) e
+WHERE e.slot = CASE WHEN $3::integer > 0 THEN $3::integer ELSE e.total END
+`
+
+type GetTaskCompletionWeeklyEntryEvidenceParams struct {
+	TaskID    string      `json:"task_id"`
+	WeekStart pgtype.Date `json:"week_start"`
+	Slot      int32       `json:"slot"`
+}
+
+type GetTaskCompletionWeeklyEntryEvidenceRow struct {
+	ID           string      `json:"id"`
+	Slot         int32       `json:"slot"`
+	Note         pgtype.Text `json:"note"`
+	AttachmentID interface{} `json:"attachment_id"`
+}
+
+func (q *Queries) GetTaskCompletionWeeklyEntryEvidence(ctx context.Context, arg GetTaskCompletionWeeklyEntryEvidenceParams) (GetTaskCompletionWeeklyEntryEvidenceRow, error) {
+	row := q.db.QueryRow(ctx, getTaskCompletionWeeklyEntryEvidence, arg.TaskID, arg.WeekStart, arg.Slot)
+	var i GetTaskCompletionWeeklyEntryEvidenceRow
+	err := row.Scan(
+		&i.ID,
+		&i.Slot,
+		&i.Note,
+		&i.AttachmentID,
+	)
+	return i, err
+}
+
+const listCompletionEvidenceByTeam = `-- name: ListCompletionEvidenceByTeam :many
+WITH evidence AS (
+  SELECT 'daily'::text AS kind, d.task_id, 1::integer AS slot, d.note, d.attachment_id
+  FROM task_completion_daily d
+  JOIN tasks t ON t.id = d.task_id
+  WHERE t.team_id = $1
+    AND t.type = 'daily'
+    AND d.target_date = $2
+  UNION ALL
+  SELECT 'weekly'::text, w.task_id, w.slot, w.note, w.attachment_id
+  FROM (
+    SELECT
+      e.task_id,
+      e.note,
+      e.attachment_id,
+      ROW_NUMBER() OVER (PARTITION BY e.task_id ORDER BY e.created_at ASC, e.id ASC)::integer AS slot
+    FROM task_completion_weekly_entries e
+    JOIN tasks t ON t.id = e.task_id
+    WHERE t.team_id = $1
+      AND t.type = 'weekly'
+      AND e.week_start = $3
+  ) w
+  UNION ALL
+  SELECT 'one_off'::text, o.task_id, 1::integer, o.note, o.attachment_id
+  FROM task_completion_one_off o
+  JOIN tasks t ON t.id = o.task_id
+  WHERE t.team_id = $1
+    AND t.type = 'one_off'
+)
+SELECT
+  ev.kind::text AS kind,
+  ev.task_id,
+  ev.slot::integer AS slot,
+  ev.note,
+  COALESCE(a.id::text, ''::text) AS attachment_id,
+  COALESCE(a.content_type, ''::text) AS content_type,
+  COALESCE(a.size_bytes, 0)::integer AS size_bytes,
+  COALESCE(a.width, 0)::integer AS width,
+  COALESCE(a.height, 0)::integer AS height,
+  a.created_at AS attachment_created_at
+FROM evidence ev
+LEFT JOIN completion_attachments a ON a.id = ev.attachment_id
+WHERE ev.note IS NOT NULL OR ev.attachment_id IS NOT NULL
+ORDER BY ev.task_id, ev.slot
+`
+
+type ListCompletionEvidenceByTeamParams struct {
+	TeamID     string      `json:"team_id"`
+	TargetDate pgtype.Date `json:"target_date"`
+	WeekStart  pgtype.Date `json:"week_start"`
+}
+
+type ListCompletionEvidenceByTeamRow struct {
+	Kind                string             `json:"kind"`
+	TaskID              string             `json:"task_id"`
+	Slot                int32              `json:"slot"`
+	Note                pgtype.Text        `json:"note"`
+	AttachmentID        interface{}        `json:"attachment_id"`
+	ContentType         string             `json:"content_type"`
+	SizeBytes           int32              `json:"size_bytes"`
+	Width               int32              `json:"width"`
+	Height              int32              `json:"height"`
+	AttachmentCreatedAt pgtype.Timestamptz `json:"attachment_created_at"`
+}
+
+func (q *Queries) ListCompletionEvidenceByTeam(ctx context.Context, arg ListCompletionEvidenceByTeamParams) ([]ListCompletionEvidenceByTeamRow, error) {
+	rows, err := q.db.Query(ctx, listCompletionEvidenceByTeam, arg.TeamID, arg.TargetDate, arg.WeekStart)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCompletionEvidenceByTeamRow
+	for rows.Next() {
+		var i ListCompletionEvidenceByTeamRow
+		if err := rows.Scan(
+			&i.Kind,
+			&i.TaskID,
+			&i.Slot,
+			&i.Note,
+			&i.AttachmentID,
+			&i.ContentType,
+			&i.SizeBytes,
+			&i.Width,
+			&i.Height,
+			&i.AttachmentCreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setTaskCompletionDailyEvidence = `-- name: SetTaskCompletionDailyEvidence :exec
+UPDATE task_completion_daily
+SET note = $1,
+    attachment_id = NULLIF($2::text, '')::uuid
+WHERE task_id = $3 AND target_date = $4
+`
+
+type SetTaskCompletionDailyEvidenceParams struct {
+	Note         pgtype.Text `json:"note"`
+	AttachmentID string      `json:"attachment_id"`
+	TaskID       string      `json:"task_id"`
+	TargetDate   pgtype.Date `json:"target_date"`
+}
+
+func (q *Queries) SetTaskCompletionDailyEvidence(ctx context.Context, arg SetTaskCompletionDailyEvidenceParams) error {
+	_, err := q.db.Exec(ctx, setTaskCompletionDailyEvidence,
+		arg.Note,
+		arg.AttachmentID,
+		arg.TaskID,
+		arg.TargetDate,
+	)
+	return err
+}
+
+const setTaskCompletionOneOffEvidence = `-- name: SetTaskCompletionOneOffEvidence :exec
+UPDATE task_completion_one_off
+SET note = $1,
+    attachment_id = NULLIF($2::text, '')::uuid
+WHERE task_id = $3
+`
+
+type SetTaskCompletionOneOffEvidenceParams struct {
+	Note         pgtype.Text `json:"note"`
+	AttachmentID string      `json:"attachment_id"`
+	TaskID       string      `json:"task_id"`
+}
+
+func (q *Queries) SetTaskCompletionOneOffEvidence(ctx context.Context, arg SetTaskCompletionOneOffEvidenceParams) error {
+	_, err := q.db.Exec(ctx, setTaskCompletionOneOffEvidence, arg.Note, arg.AttachmentID, arg.TaskID)
+	return err
+}
+
+const setTaskCompletionWeeklyEntryEvidence = `-- name: SetTaskCompletionWeeklyEntryEvidence :exec
+UPDATE task_completion_weekly_entries
+SET note = $1,
+    attachment_id = NULLIF($2::text, '')::uuid
+WHERE id = $3
+`
+
+type SetTaskCompletionWeeklyEntryEvidenceParams struct {
+	Note         pgtype.Text `json:"note"`
+	AttachmentID string      `json:"attachment_id"`
+	ID           string      `json:"id"`
+}
+
+func (q *Queries) SetTaskCompletionWeeklyEntryEvidence(ctx context.Context, arg SetTaskCompletionWeeklyEntryEvidenceParams) error {
+	_, err := q.db.Exec(ctx, setTaskCompletionWeeklyEntryEvidence, arg.Note, arg.AttachmentID, arg.ID)
+	return err
+}
@@ -0,0 +1,227 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: completion_reactions.sql
+
+package dbsqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createCompletionReaction = `-- name: CreateCompletionReaction :execrows
+INSERT INTO completion_reactions (
+  id,
+  task_id,
+  target_date,
+  weekly_entry_id,
+  one_off_task_id,
+  user_id,
+  emoji,
+  created_at
+)
+VALUES (
+  $1,
+  $2,
+  $3,
+  NULLIF($4::text, '')::uuid,
+  NULLIF($5::text, '')::uuid,
+  $6,
+  $7,
+  $8
+)
+ON CONFLICT DO NOTHING
+`
+
+type CreateCompletionReactionParams struct {
+	ID            string             `json:"id"`
+	TaskID        string             `json:"task_id"`
+	TargetDate    pgtype.Date        `json:"target_date"`
+	WeeklyEntryID string             `json:"weekly_entry_id"`
+	OneOffTaskID  string             `json:"one_off_task_id"`
+	UserID        string             `json:"user_id"`
+	Emoji         string             `json:"emoji"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+}
+
+func (q *Queries) CreateCompletionReaction(ctx context.Context, arg CreateCompletionReactionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, createCompletionReaction,
+		arg.ID,
+		arg.TaskID,
+		arg.TargetDate,
+		arg.WeeklyEntryID,
+		arg.OneOffTaskID,
+		arg.UserID,
+		arg.Emoji,
+		arg.CreatedAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteCompletionReaction = `-- name: DeleteCompletionReaction :execrows
+DELETE FROM completion_reactions
+WHERE task_id = $1
+  AND target_date IS NOT DISTINCT FROM $2::date
+  AND weekly_entry_id IS NOT DISTINCT FROM NULLIF($3::text, '')::uuid
+  AND one_off_task_id IS NOT DISTINCT FROM NULLIF($4::text, '')::uuid
+  AND user_id = $5
+  AND emoji = $6
+`
+
+type DeleteCompletionReactionParams struct {
+	TaskID        string      `json:"task_id"`
+	TargetDate    pgtype.Date `json:"target_date"`
+	WeeklyEntryID string      `json:"weekly_entry_id"`
+	OneOffTaskID  string      `json:"one_off_task_id"`
+	UserID        string      `json:"user_id"`
+	Emoji         string      `json:"emoji"`
+}
+
+func (q *Queries) DeleteCompletionReaction(ctx context.Context, arg DeleteCompletionReactionParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteCompletionReaction,
+		arg.TaskID,
+		arg.TargetDate,
+		arg.WeeklyEntryID,
+		arg.OneOffTaskID,
+		arg.UserID,
+		arg.Emoji,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const listCompletionReactionsByTarget = `-- name: ListCompletionReactionsByTarget :many
+SELECT emoji, user_id::text AS user_id
+FROM completion_reactions
+WHERE task_id = $1
+  AND target_date IS NOT DISTINCT FROM $2::date
+  AND weekly_entry_id IS NOT DISTINCT FROM NULLIF($3::text, '')::uuid
+  AND one_off_task_id IS NOT DISTINCT FROM NULLIF($4::text, '')::uuid
+ORDER BY created_at, id
+`
+
+type ListCompletionReactionsByTargetParams struct {
+	TaskID        string      `json:"task_id"`
+	TargetDate    pgtype.Date `json:"target_date"`
+	WeeklyEntryID string      `json:"weekly_entry_id"`
+	OneOffTaskID  string      `json:"one_off_task_id"`
+}
+
+type ListCompletionReactionsByTargetRow struct {
+	Emoji  string `json:"emoji"`
+	UserID string `json:"user_id"`
+}
+
+func (q *Queries) ListCompletionReactionsByTarget(ctx context.Context, arg ListCompletionReactionsByTargetParams) ([]ListCompletionReactionsByTargetRow, error) {
+	rows, err := q.db.Query(ctx, listCompletionReactionsByTarget,
+		arg.TaskID,
+		arg.TargetDate,
+		arg.WeeklyEntryID,
+		arg.OneOffTaskID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCompletionReactionsByTargetRow
+	for rows.Next() {
+		var i ListCompletionReactionsByTargetRow
+		if err := rows.Scan(&i.Emoji, &i.UserID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCompletionReactionsByTeam = `-- name: ListCompletionReactionsByTeam :many
+WITH targets AS (
+  SELECT d.task_id, 1::integer AS slot, d.target_date, NULL::uuid AS weekly_entry_id, NULL::uuid AS one_off_task_id
+  FROM task_completion_daily d
+  JOIN tasks t ON t.id = d.task_id
+  WHERE t.team_id = $1
+    AND t.type = 'daily'
+    AND d.target_date = $2
+  UNION ALL
+  SELECT w.task_id, w.slot, NULL::date, w.id, NULL::uuid
+  FROM (
+    SELECT
+      e.id,
+      e.task_id,
+      ROW_NUMBER() OVER (PARTITION BY e.task_id ORDER BY e.created_at ASC, e.id ASC)::integer AS slot
+    FROM task_completion_weekly_entries e
+    JOIN tasks t ON t.id = e.task_id
+    WHERE t.team_id = $1
+      AND t.type = 'weekly'
+      AND e.week_start = $3
+  ) w
+  UNION ALL
+  SELECT o.task_id, 1::integer, NULL::date, NULL::uuid, o.task_id
+  FROM task_completion_one_off o
+  JOIN tasks t ON t.id = o.task_id
+  WHERE t.team_id = $1
+    AND t.type = 'one_off'
+)
+SELECT
+  tg.task_id,
+  tg.slot::integer AS slot,
+  r.emoji,
+  r.user_id::text AS user_id
+FROM targets tg
+JOIN completion_reactions r
+  ON r.task_id = tg.task_id
+  AND (
+    r.target_date = tg.target_date
+    OR r.weekly_entry_id = tg.weekly_entry_id
+    OR r.one_off_task_id = tg.one_off_task_id
+  )
+ORDER BY tg.task_id, tg.slot, r.created_at, r.id
+`
+
+type ListCompletionReactionsByTeamParams struct {
+	TeamID     string      `json:"team_id"`
+	TargetDate pgtype.Date `json:"target_date"`
+	WeekStart  pgtype.Date `json:"week_start"`
+}
+
+type ListCompletionReactionsByTeamRow struct {
+	TaskID string `json:"task_id"`
+	Slot   int32  `json:"slot"`
+	Emoji  string `json:"emoji"`
+	UserID string `json:"user_id"`
+}
+
+func (q *Queries) ListCompletionReactionsByTeam(ctx context.Context, arg ListCompletionReactionsByTeamParams) ([]ListCompletionReactionsByTeamRow, error) {
+	rows, err := q.db.Query(ctx, listCompletionReactionsByTeam, arg.TeamID, arg.TargetDate, arg.WeekStart)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCompletionReactionsByTeamRow
+	for rows.Next() {
+		var i ListCompletionReactionsByTeamRow
+		if err := rows.Scan(
+			&i.TaskID,
+			&i.Slot,
+			&i.Emoji,
+			&i.UserID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
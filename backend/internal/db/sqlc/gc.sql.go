@@ -0,0 +1,509 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: gc.sql
+
+package dbsqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const countExpiredAuthRequests = `-- name: CountExpiredAuthRequests :one
+SELECT COUNT(*)::bigint
+FROM oauth_auth_requests
+WHERE expires_at < $1
+`
+
+func (q *Queries) CountExpiredAuthRequests(ctx context.Context, cutoff pgtype.Timestamptz) (int64, error) {
+	row := q.db.QueryRow(ctx, countExpiredAuthRequests, cutoff)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const countExpiredInviteCodes = `-- name: CountExpiredInviteCodes :one
+SELECT COUNT(*)::bigint
+FROM invite_codes
+WHERE expires_at < $1
+`
+
+func (q *Queries) CountExpiredInviteCodes(ctx context.Context, cutoff pgtype.Timestamptz) (int64, error) {
+	row := q.db.QueryRow(ctx, countExpiredInviteCodes, cutoff)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const countExpiredPersonalDataExports = `-- name: CountExpiredPersonalDataExports :one
+SELECT COUNT(*)::bigint
+FROM personal_data_exports
+WHERE (status = 'ready' AND expires_at < $1)
+   OR (status = 'failed' AND requested_at < $1)
+`
+
+func (q *Queries) CountExpiredPersonalDataExports(ctx context.Context, cutoff pgtype.Timestamptz) (int64, error) {
+	row := q.db.QueryRow(ctx, countExpiredPersonalDataExports, cutoff)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const countFinishedWebhookDeliveries = `-- name: CountFinishedWebhookDeliveries :one
+SELECT COUNT(*)::bigint
+FROM team_webhook_deliveries
+WHERE status <> 'pending'
+  AND created_at < $1
+`
+
+func (q *Queries) CountFinishedWebhookDeliveries(ctx context.Context, cutoff pgtype.Timestamptz) (int64, error) {
+	row := q.db.QueryRow(ctx, countFinishedWebhookDeliveries, cutoff)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const countOldCloseRunHistory = `-- name: CountOldCloseRunHistory :one
+SELECT COUNT(*)::bigint
+FROM close_run_history
+WHERE started_at < $1
+`
+
+func (q *Queries) CountOldCloseRunHistory(ctx context.Context, cutoff pgtype.Timestamptz) (int64, error) {
+	row := q.db.QueryRow(ctx, countOldCloseRunHistory, cutoff)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const countOldTeamAuditEntries = `-- name: CountOldTeamAuditEntries :one
+SELECT COUNT(*)::bigint
+FROM team_audit_log
+WHERE created_at < $1
+`
+
+func (q *Queries) CountOldTeamAuditEntries(ctx context.Context, cutoff pgtype.Timestamptz) (int64, error) {
+	row := q.db.QueryRow(ctx, countOldTeamAuditEntries, cutoff)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const countOrphanedCompletionAttachments = `-- name: CountOrphanedCompletionAttachments :one
+SELECT COUNT(*)::bigint
+FROM completion_attachments a
+WHERE a.created_at < $1
+  AND NOT EXISTS (SELECT 1 FROM task_completion_daily d WHERE d.attachment_id = a.id)
+  AND NOT EXISTS (SELECT 1 FROM task_completion_weekly_entries e WHERE e.attachment_id = a.id)
+  AND NOT EXISTS (SELECT 1 FROM task_completion_one_off o WHERE o.attachment_id = a.id)
+`
+
+func (q *Queries) CountOrphanedCompletionAttachments(ctx context.Context, cutoff pgtype.Timestamptz) (int64, error) {
+	row := q.db.QueryRow(ctx, countOrphanedCompletionAttachments, cutoff)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const countPurgeableDeletedPenaltyRules = `-- name: CountPurgeableDeletedPenaltyRules :one
+SELECT COUNT(*)::bigint
+FROM penalty_rules r
+WHERE r.deleted_at IS NOT NULL
+  AND r.deleted_at < $1
+  AND NOT EXISTS (
+    SELECT 1
+    FROM monthly_penalty_summary_triggered_rules tr
+    WHERE tr.rule_id = r.id
+  )
+  AND EXISTS (
+    SELECT 1
+    FROM monthly_penalty_summaries m
+    WHERE m.team_id = r.team_id
+      AND m.is_closed
+      AND m.month_start > date_trunc('month', r.deleted_at AT TIME ZONE $2::text)::date
+  )
+`
+
+type CountPurgeableDeletedPenaltyRulesParams struct {
+	Cutoff   pgtype.Timestamptz `json:"cutoff"`
+	TimeZone string             `json:"time_zone"`
+}
+
+func (q *Queries) CountPurgeableDeletedPenaltyRules(ctx context.Context, arg CountPurgeableDeletedPenaltyRulesParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countPurgeableDeletedPenaltyRules, arg.Cutoff, arg.TimeZone)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const countPurgeableDeletedTasks = `-- name: CountPurgeableDeletedTasks :one
+SELECT COUNT(*)::bigint
+FROM tasks t
+WHERE t.deleted_at IS NOT NULL
+  AND t.deleted_at < $1
+  AND EXISTS (
+    SELECT 1
+    FROM monthly_penalty_summaries m
+    WHERE m.team_id = t.team_id
+      AND m.is_closed
+      AND m.month_start > date_trunc('month', t.deleted_at AT TIME ZONE $2::text)::date
+  )
+`
+
+type CountPurgeableDeletedTasksParams struct {
+	Cutoff   pgtype.Timestamptz `json:"cutoff"`
+	TimeZone string             `json:"time_zone"`
+}
+
+func (q *Queries) CountPurgeableDeletedTasks(ctx context.Context, arg CountPurgeableDeletedTasksParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countPurgeableDeletedTasks, arg.Cutoff, arg.TimeZone)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const countStaleExchangeCodes = `-- name: CountStaleExchangeCodes :one
+SELECT COUNT(*)::bigint
+FROM oauth_exchange_codes
+WHERE LEAST(expires_at, COALESCE(used_at, expires_at)) < $1
+`
+
+func (q *Queries) CountStaleExchangeCodes(ctx context.Context, cutoff pgtype.Timestamptz) (int64, error) {
+	row := q.db.QueryRow(ctx, countStaleExchangeCodes, cutoff)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const countStaleSessions = `-- name: CountStaleSessions :one
+SELECT COUNT(*)::bigint
+FROM sessions
+WHERE (expires_at IS NOT NULL AND expires_at < $1)
+   OR (expires_at IS NULL AND created_at < $2)
+`
+
+type CountStaleSessionsParams struct {
+	Cutoff        pgtype.Timestamptz `json:"cutoff"`
+	CreatedBefore pgtype.Timestamptz `json:"created_before"`
+}
+
+func (q *Queries) CountStaleSessions(ctx context.Context, arg CountStaleSessionsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countStaleSessions, arg.Cutoff, arg.CreatedBefore)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const deleteCompletionAttachmentsByIDs = `-- name: DeleteCompletionAttachmentsByIDs :execrows
+DELETE FROM completion_attachments
+WHERE id = ANY($1::uuid[])
+`
+
+func (q *Queries) DeleteCompletionAttachmentsByIDs(ctx context.Context, ids []string) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteCompletionAttachmentsByIDs, ids)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteExpiredAuthRequestsBatch = `-- name: DeleteExpiredAuthRequestsBatch :execrows
+DELETE FROM oauth_auth_requests
+WHERE state IN (
+  SELECT a.state
+  FROM oauth_auth_requests a
+  WHERE a.expires_at < $1
+  LIMIT $2
+)
+`
+
+type DeleteExpiredAuthRequestsBatchParams struct {
+	Cutoff    pgtype.Timestamptz `json:"cutoff"`
+	BatchSize int32              `json:"batch_size"`
+}
+
+func (q *Queries) DeleteExpiredAuthRequestsBatch(ctx context.Context, arg DeleteExpiredAuthRequestsBatchParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredAuthRequestsBatch, arg.Cutoff, arg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteExpiredInviteCodesBatch = `-- name: DeleteExpiredInviteCodesBatch :execrows
+DELETE FROM invite_codes
+WHERE code IN (
+  SELECT i.code
+  FROM invite_codes i
+  WHERE i.expires_at < $1
+  LIMIT $2
+)
+`
+
+type DeleteExpiredInviteCodesBatchParams struct {
+	Cutoff    pgtype.Timestamptz `json:"cutoff"`
+	BatchSize int32              `json:"batch_size"`
+}
+
+func (q *Queries) DeleteExpiredInviteCodesBatch(ctx context.Context, arg DeleteExpiredInviteCodesBatchParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredInviteCodesBatch, arg.Cutoff, arg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteExpiredPersonalDataExportsBatch = `-- name: DeleteExpiredPersonalDataExportsBatch :execrows
+DELETE FROM personal_data_exports
+WHERE id IN (
+  SELECT p.id
+  FROM personal_data_exports p
+  WHERE (p.status = 'ready' AND p.expires_at < $1)
+     OR (p.status = 'failed' AND p.requested_at < $1)
+  LIMIT $2
+)
+`
+
+type DeleteExpiredPersonalDataExportsBatchParams struct {
+	Cutoff    pgtype.Timestamptz `json:"cutoff"`
+	BatchSize int32              `json:"batch_size"`
+}
+
+func (q *Queries) DeleteExpiredPersonalDataExportsBatch(ctx context.Context, arg DeleteExpiredPersonalDataExportsBatchParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteExpiredPersonalDataExportsBatch, arg.Cutoff, arg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteFinishedWebhookDeliveriesBatch = `-- name: DeleteFinishedWebhookDeliveriesBatch :execrows
+DELETE FROM team_webhook_deliveries
+WHERE id IN (
+  SELECT d.id
+  FROM team_webhook_deliveries d
+  WHERE d.status <> 'pending'
+    AND d.created_at < $1
+  LIMIT $2
+)
+`
+
+type DeleteFinishedWebhookDeliveriesBatchParams struct {
+	Cutoff    pgtype.Timestamptz `json:"cutoff"`
+	BatchSize int32              `json:"batch_size"`
+}
+
+func (q *Queries) DeleteFinishedWebhookDeliveriesBatch(ctx context.Context, arg DeleteFinishedWebhookDeliveriesBatchParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteFinishedWebhookDeliveriesBatch, arg.Cutoff, arg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteOldCloseRunHistoryBatch = `-- name: DeleteOldCloseRunHistoryBatch :execrows
+DELETE FROM close_run_history
+WHERE id IN (
+  SELECT h.id
+  FROM close_run_history h
+  WHERE h.started_at < $1
+  LIMIT $2
+)
+`
+
+type DeleteOldCloseRunHistoryBatchParams struct {
+	Cutoff    pgtype.Timestamptz `json:"cutoff"`
+	BatchSize int32              `json:"batch_size"`
+}
+
+func (q *Queries) DeleteOldCloseRunHistoryBatch(ctx context.Context, arg DeleteOldCloseRunHistoryBatchParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteOldCloseRunHistoryBatch, arg.Cutoff, arg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteOldTeamAuditEntriesBatch = `-- name: DeleteOldTeamAuditEntriesBatch :execrows
+DELETE FROM team_audit_log
+WHERE id IN (
+  SELECT a.id
+  FROM team_audit_log a
+  WHERE a.created_at < $1
+  LIMIT $2
+)
+`
+
+type DeleteOldTeamAuditEntriesBatchParams struct {
+	Cutoff    pgtype.Timestamptz `json:"cutoff"`
+	BatchSize int32              `json:"batch_size"`
+}
+
+func (q *Queries) DeleteOldTeamAuditEntriesBatch(ctx context.Context, arg DeleteOldTeamAuditEntriesBatchParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteOldTeamAuditEntriesBatch, arg.Cutoff, arg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deletePurgeableDeletedPenaltyRulesBatch = `-- name: DeletePurgeableDeletedPenaltyRulesBatch :execrows
+DELETE FROM penalty_rules
+WHERE id IN (
+  SELECT r.id
+  FROM penalty_rules r
+  WHERE r.deleted_at IS NOT NULL
+    AND r.deleted_at < $1
+    AND NOT EXISTS (
+      SELECT 1
+      FROM monthly_penalty_summary_triggered_rules tr
+      WHERE tr.rule_id = r.id
+    )
+    AND EXISTS (
+      SELECT 1
+      FROM monthly_penalty_summaries m
+      WHERE m.team_id = r.team_id
+        AND m.is_closed
+        AND m.month_start > date_trunc('month', r.deleted_at AT TIME ZONE $2::text)::date
+    )
+  LIMIT $3
+)
+`
+
+type DeletePurgeableDeletedPenaltyRulesBatchParams struct {
+	Cutoff    pgtype.Timestamptz `json:"cutoff"`
+	TimeZone  string             `json:"time_zone"`
+	BatchSize int32              `json:"batch_size"`
+}
+
+func (q *Queries) DeletePurgeableDeletedPenaltyRulesBatch(ctx context.Context, arg DeletePurgeableDeletedPenaltyRulesBatchParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deletePurgeableDeletedPenaltyRulesBatch, arg.Cutoff, arg.TimeZone, arg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deletePurgeableDeletedTasksBatch = `-- name: DeletePurgeableDeletedTasksBatch :execrows
+DELETE FROM tasks
+WHERE id IN (
+  SELECT t.id
+  FROM tasks t
+  WHERE t.deleted_at IS NOT NULL
+    AND t.deleted_at < $1
+    AND EXISTS (
+      SELECT 1
+      FROM monthly_penalty_summaries m
+      WHERE m.team_id = t.team_id
+        AND m.is_closed
+        AND m.month_start > date_trunc('month', t.deleted_at AT TIME ZONE $2::text)::date
+    )
+  LIMIT $3
+)
+`
+
+type DeletePurgeableDeletedTasksBatchParams struct {
+	Cutoff    pgtype.Timestamptz `json:"cutoff"`
+	TimeZone  string             `json:"time_zone"`
+	BatchSize int32              `json:"batch_size"`
+}
+
+func (q *Queries) DeletePurgeableDeletedTasksBatch(ctx context.Context, arg DeletePurgeableDeletedTasksBatchParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deletePurgeableDeletedTasksBatch, arg.Cutoff, arg.TimeZone, arg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteStaleExchangeCodesBatch = `-- name: DeleteStaleExchangeCodesBatch :execrows
+DELETE FROM oauth_exchange_codes
+WHERE code IN (
+  SELECT e.code
+  FROM oauth_exchange_codes e
+  WHERE LEAST(e.expires_at, COALESCE(e.used_at, e.expires_at)) < $1
+  LIMIT $2
+)
+`
+
+type DeleteStaleExchangeCodesBatchParams struct {
+	Cutoff    pgtype.Timestamptz `json:"cutoff"`
+	BatchSize int32              `json:"batch_size"`
+}
+
+func (q *Queries) DeleteStaleExchangeCodesBatch(ctx context.Context, arg DeleteStaleExchangeCodesBatchParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteStaleExchangeCodesBatch, arg.Cutoff, arg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteStaleSessionsBatch = `-- name: DeleteStaleSessionsBatch :execrows
+DELETE FROM sessions
+WHERE token IN (
+  SELECT s.token
+  FROM sessions s
+  WHERE (s.expires_at IS NOT NULL AND s.expires_at < $1)
+     OR (s.expires_at IS NULL AND s.created_at < $2)
+  LIMIT $3
+)
+`
+
+type DeleteStaleSessionsBatchParams struct {
+	Cutoff        pgtype.Timestamptz `json:"cutoff"`
+	CreatedBefore pgtype.Timestamptz `json:"created_before"`
+	BatchSize     int32              `json:"batch_size"`
+}
+
+func (q *Queries) DeleteStaleSessionsBatch(ctx context.Context, arg DeleteStaleSessionsBatchParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteStaleSessionsBatch, arg.Cutoff, arg.CreatedBefore, arg.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const listOrphanedCompletionAttachmentsBatch = `-- name: ListOrphanedCompletionAttachmentsBatch :many
+SELECT a.id, a.blob_key, a.thumbnail_key
+FROM completion_attachments a
+WHERE a.created_at < $1
+  AND NOT EXISTS (SELECT 1 FROM task_completion_daily d WHERE d.attachment_id = a.id)
+  AND NOT EXISTS (SELECT 1 FROM task_completion_weekly_entries e WHERE e.attachment_id = a.id)
+  AND NOT EXISTS (SELECT 1 FROM task_completion_one_off o WHERE o.attachment_id = a.id)
+ORDER BY a.created_at, a.id
+LIMIT $2
+`
+
+type ListOrphanedCompletionAttachmentsBatchParams struct {
+	Cutoff    pgtype.Timestamptz `json:"cutoff"`
+	BatchSize int32              `json:"batch_size"`
+}
+
+type ListOrphanedCompletionAttachmentsBatchRow struct {
+	ID           string `json:"id"`
+	BlobKey      string `json:"blob_key"`
+	ThumbnailKey string `json:"thumbnail_key"`
+}
+
+func (q *Queries) ListOrphanedCompletionAttachmentsBatch(ctx context.Context, arg ListOrphanedCompletionAttachmentsBatchParams) ([]ListOrphanedCompletionAttachmentsBatchRow, error) {
+	rows, err := q.db.Query(ctx, listOrphanedCompletionAttachmentsBatch, arg.Cutoff, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListOrphanedCompletionAttachmentsBatchRow
+	for rows.Next() {
+		var i ListOrphanedCompletionAttachmentsBatchRow
+		if err := rows.Scan(&i.ID, &i.BlobKey, &i.ThumbnailKey); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,158 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: holidays.sql
+
+package dbsqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const deleteTeamHoliday = `-- name: DeleteTeamHoliday :execrows
+DELETE FROM team_holidays
+WHERE team_id = $1
+  AND holiday_date = $2
+`
+
+type DeleteTeamHolidayParams struct {
+	TeamID      string      `json:"team_id"`
+	HolidayDate pgtype.Date `json:"holiday_date"`
+}
+
+func (q *Queries) DeleteTeamHoliday(ctx context.Context, arg DeleteTeamHolidayParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteTeamHoliday, arg.TeamID, arg.HolidayDate)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getTeamHolidayForUpdate = `-- name: GetTeamHolidayForUpdate :one
+SELECT holiday_date, revision
+FROM team_holidays
+WHERE team_id = $1
+  AND holiday_date = $2
+FOR UPDATE
+`
+
+type GetTeamHolidayForUpdateParams struct {
+	TeamID      string      `json:"team_id"`
+	HolidayDate pgtype.Date `json:"holiday_date"`
+}
+
+type GetTeamHolidayForUpdateRow struct {
+	HolidayDate pgtype.Date `json:"holiday_date"`
+	Revision    int64       `json:"revision"`
+}
+
+func (q *Queries) GetTeamHolidayForUpdate(ctx context.Context, arg GetTeamHolidayForUpdateParams) (GetTeamHolidayForUpdateRow, error) {
+	row := q.db.QueryRow(ctx, getTeamHolidayForUpdate, arg.TeamID, arg.HolidayDate)
+	var i GetTeamHolidayForUpdateRow
+	err := row.Scan(&i.HolidayDate, &i.Revision)
+	return i, err
+}
+
+const listTeamHolidaysBetween = `-- name: ListTeamHolidaysBetween :many
+SELECT
+  h.team_id,
+  h.holiday_date,
+  h.name,
+  h.source,
+  COALESCE(h.created_by_user_id::text, ''::text) AS created_by_user_id,
+  h.created_at,
+  h.revision
+FROM team_holidays h
+WHERE h.team_id = $1
+  AND h.holiday_date >= $2
+  AND h.holiday_date <= $3
+ORDER BY h.holiday_date
+`
+
+type ListTeamHolidaysBetweenParams struct {
+	TeamID     string      `json:"team_id"`
+	RangeStart pgtype.Date `json:"range_start"`
+	RangeEnd   pgtype.Date `json:"range_end"`
+}
+
+type ListTeamHolidaysBetweenRow struct {
+	TeamID          string             `json:"team_id"`
+	HolidayDate     pgtype.Date        `json:"holiday_date"`
+	Name            string             `json:"name"`
+	Source          string             `json:"source"`
+	CreatedByUserID interface{}        `json:"created_by_user_id"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	Revision        int64              `json:"revision"`
+}
+
+func (q *Queries) ListTeamHolidaysBetween(ctx context.Context, arg ListTeamHolidaysBetweenParams) ([]ListTeamHolidaysBetweenRow, error) {
+	rows, err := q.db.Query(ctx, listTeamHolidaysBetween, arg.TeamID, arg.RangeStart, arg.RangeEnd)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTeamHolidaysBetweenRow
+	for rows.Next() {
+		var i ListTeamHolidaysBetweenRow
+		if err := rows.Scan(
+			&i.TeamID,
+			&i.HolidayDate,
+			&i.Name,
+			&i.Source,
+			&i.CreatedByUserID,
+			&i.CreatedAt,
+			&i.Revision,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const upsertTeamHoliday = `-- name: UpsertTeamHoliday :exec
+INSERT INTO team_holidays (team_id, holiday_date, name, source, created_by_user_id, created_at, revision)
+VALUES (
+  $1,
+  $2,
+  $3,
+  $4,
+  $5,
+  $6,
+  $7
+)
+ON CONFLICT (team_id, holiday_date) DO UPDATE
+SET name = EXCLUDED.name,
+    source = EXCLUDED.source,
+    created_by_user_id = EXCLUDED.created_by_user_id,
+    created_at = EXCLUDED.created_at,
+    revision = EXCLUDED.revision
+`
+
+type UpsertTeamHolidayParams struct {
+	TeamID          string             `json:"team_id"`
+	HolidayDate     pgtype.Date        `json:"holiday_date"`
+	Name            string             `json:"name"`
+	Source          string             `json:"source"`
+	CreatedByUserID string             `json:"created_by_user_id"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	Revision        int64              `json:"revision"`
+}
+
+func (q *Queries) UpsertTeamHoliday(ctx context.Context, arg UpsertTeamHolidayParams) error {
+	_, err := q.db.Exec(ctx, upsertTeamHoliday,
+		arg.TeamID,
+		arg.HolidayDate,
+		arg.Name,
+		arg.Source,
+		arg.CreatedByUserID,
+		arg.CreatedAt,
+		arg.Revision,
+	)
+	return err
+}
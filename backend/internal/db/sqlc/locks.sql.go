@@ -0,0 +1,41 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: locks.sql
+
+package dbsqlc
+
+import (
+	"context"
+)
+
+const advisoryLock = `-- name: AdvisoryLock :exec
+SELECT pg_advisory_lock($1::bigint)
+`
+
+func (q *Queries) AdvisoryLock(ctx context.Context, key int64) error {
+	_, err := q.db.Exec(ctx, advisoryLock, key)
+	return err
+}
+
+const releaseAdvisoryLock = `-- name: ReleaseAdvisoryLock :one
+SELECT pg_advisory_unlock($1::bigint)::boolean AS released
+`
+
+func (q *Queries) ReleaseAdvisoryLock(ctx context.Context, key int64) (bool, error) {
+	row := q.db.QueryRow(ctx, releaseAdvisoryLock, key)
+	var released bool
+	err := row.Scan(&released)
+	return released, err
+}
+
+const tryAdvisoryLock = `-- name: TryAdvisoryLock :one
+SELECT pg_try_advisory_lock($1::bigint)::boolean AS locked
+`
+
+func (q *Queries) TryAdvisoryLock(ctx context.Context, key int64) (bool, error) {
+	row := q.db.QueryRow(ctx, tryAdvisoryLock, key)
+	var locked bool
+	err := row.Scan(&locked)
+	return locked, err
+}
@@ -15,6 +15,46 @@ type CloseRun struct {
 	CreatedAt  pgtype.Timestamptz `json:"created_at"`
 }
 
+type CloseRunHistory struct {
+	ID               string             `json:"id"`
+	TeamID           string             `json:"team_id"`
+	Scope            string             `json:"scope"`
+	Trigger          string             `json:"trigger"`
+	AsOf             pgtype.Timestamptz `json:"as_of"`
+	StartedAt        pgtype.Timestamptz `json:"started_at"`
+	FinishedAt       pgtype.Timestamptz `json:"finished_at"`
+	PeriodsProcessed int32              `json:"periods_processed"`
+	PenaltyAdded     int32              `json:"penalty_added"`
+	PenalizedTasks   int32              `json:"penalized_tasks"`
+	ErrorClass       pgtype.Text        `json:"error_class"`
+	ErrorMessage     pgtype.Text        `json:"error_message"`
+}
+
+type CompletionAttachment struct {
+	ID               string             `json:"id"`
+	TeamID           string             `json:"team_id"`
+	TaskID           string             `json:"task_id"`
+	BlobKey          string             `json:"blob_key"`
+	ThumbnailKey     string             `json:"thumbnail_key"`
+	ContentType      string             `json:"content_type"`
+	SizeBytes        int32              `json:"size_bytes"`
+	Width            int32              `json:"width"`
+	Height           int32              `json:"height"`
+	UploadedByUserID string             `json:"uploaded_by_user_id"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+}
+
+type CompletionReaction struct {
+	ID            string             `json:"id"`
+	TaskID        string             `json:"task_id"`
+	TargetDate    pgtype.Date        `json:"target_date"`
+	WeeklyEntryID string             `json:"weekly_entry_id"`
+	OneOffTaskID  string             `json:"one_off_task_id"`
+	UserID        string             `json:"user_id"`
+	Emoji         string             `json:"emoji"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+}
+
 type InviteCode struct {
 	Code      string             `json:"code"`
 	TeamID    string             `json:"team_id"`
@@ -62,6 +102,20 @@ type PenaltyRule struct {
 	DeletedAt   pgtype.Timestamptz `json:"deleted_at"`
 	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	Revision    int64              `json:"revision"`
+}
+
+type PersonalDataExport struct {
+	ID            string             `json:"id"`
+	UserID        string             `json:"user_id"`
+	Status        string             `json:"status"`
+	Payload       []byte             `json:"payload"`
+	AttemptCount  int32              `json:"attempt_count"`
+	NextAttemptAt pgtype.Timestamptz `json:"next_attempt_at"`
+	LastError     pgtype.Text        `json:"last_error"`
+	RequestedAt   pgtype.Timestamptz `json:"requested_at"`
+	CompletedAt   pgtype.Timestamptz `json:"completed_at"`
+	ExpiresAt     pgtype.Timestamptz `json:"expires_at"`
 }
 
 type Session struct {
@@ -83,6 +137,48 @@ type Task struct {
 	CreatedAt                  pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt                  pgtype.Timestamptz `json:"updated_at"`
 	DeletedAt                  pgtype.Timestamptz `json:"deleted_at"`
+	Revision                   int64              `json:"revision"`
+	DueOn                      pgtype.Date        `json:"due_on"`
+	StartsOn                   pgtype.Date        `json:"starts_on"`
+	EndsOn                     pgtype.Date        `json:"ends_on"`
+	CategoryID                 string             `json:"category_id"`
+}
+
+type TaskCategory struct {
+	ID        string             `json:"id"`
+	TeamID    string             `json:"team_id"`
+	Name      string             `json:"name"`
+	ColorHex  pgtype.Text        `json:"color_hex"`
+	Icon      pgtype.Text        `json:"icon"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	Revision  int64              `json:"revision"`
+}
+
+type TaskChecklistCheck struct {
+	ItemID          string             `json:"item_id"`
+	PeriodStart     pgtype.Date        `json:"period_start"`
+	CheckedByUserID string             `json:"checked_by_user_id"`
+	CheckedAt       pgtype.Timestamptz `json:"checked_at"`
+}
+
+type TaskChecklistItem struct {
+	ID        string             `json:"id"`
+	TaskID    string             `json:"task_id"`
+	Title     string             `json:"title"`
+	Position  int32              `json:"position"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+type TaskComment struct {
+	ID           string             `json:"id"`
+	TaskID       string             `json:"task_id"`
+	AuthorUserID string             `json:"author_user_id"`
+	Body         string             `json:"body"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	EditedAt     pgtype.Timestamptz `json:"edited_at"`
+	Revision     int64              `json:"revision"`
 }
 
 type TaskCompletionDaily struct {
@@ -90,6 +186,16 @@ type TaskCompletionDaily struct {
 	TargetDate        pgtype.Date        `json:"target_date"`
 	CreatedAt         pgtype.Timestamptz `json:"created_at"`
 	CompletedByUserID string             `json:"completed_by_user_id"`
+	Note              pgtype.Text        `json:"note"`
+	AttachmentID      string             `json:"attachment_id"`
+}
+
+type TaskCompletionOneOff struct {
+	TaskID            string             `json:"task_id"`
+	CompletedByUserID string             `json:"completed_by_user_id"`
+	CompletedAt       pgtype.Timestamptz `json:"completed_at"`
+	Note              pgtype.Text        `json:"note"`
+	AttachmentID      string             `json:"attachment_id"`
 }
 
 type TaskCompletionWeeklyEntry struct {
@@ -98,21 +204,98 @@ type TaskCompletionWeeklyEntry struct {
 	WeekStart         pgtype.Date        `json:"week_start"`
 	CompletedByUserID string             `json:"completed_by_user_id"`
 	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+	Note              pgtype.Text        `json:"note"`
+	AttachmentID      string             `json:"attachment_id"`
 }
 
 type TaskEvaluationDedupe struct {
-	TeamID     string             `json:"team_id"`
-	Scope      string             `json:"scope"`
-	TargetDate pgtype.Date        `json:"target_date"`
-	TaskID     string             `json:"task_id"`
-	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	TeamID        string             `json:"team_id"`
+	Scope         string             `json:"scope"`
+	TargetDate    pgtype.Date        `json:"target_date"`
+	TaskID        string             `json:"task_id"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	PenaltyPoints int32              `json:"penalty_points"`
+	CategoryID    string             `json:"category_id"`
+	CategoryName  pgtype.Text        `json:"category_name"`
+}
+
+type TaskTemplate struct {
+	ID              string             `json:"id"`
+	TeamID          string             `json:"team_id"`
+	Name            string             `json:"name"`
+	Description     string             `json:"description"`
+	CreatedByUserID string             `json:"created_by_user_id"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+}
+
+type TaskTemplateItem struct {
+	ID                         string      `json:"id"`
+	TemplateID                 string      `json:"template_id"`
+	Position                   int32       `json:"position"`
+	Title                      string      `json:"title"`
+	Notes                      string      `json:"notes"`
+	Type                       string      `json:"type"`
+	PenaltyPoints              int32       `json:"penalty_points"`
+	RequiredCompletionsPerWeek int32       `json:"required_completions_per_week"`
+	DueInDays                  pgtype.Int4 `json:"due_in_days"`
+	Checklist                  []string    `json:"checklist"`
 }
 
 type Team struct {
-	ID            string             `json:"id"`
-	CreatedAt     pgtype.Timestamptz `json:"created_at"`
-	Name          string             `json:"name"`
-	StateRevision int64              `json:"state_revision"`
+	ID               string             `json:"id"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	Name             string             `json:"name"`
+	StateRevision    int64              `json:"state_revision"`
+	SettingsRevision int64              `json:"settings_revision"`
+}
+
+type TeamAbsence struct {
+	ID              string             `json:"id"`
+	TeamID          string             `json:"team_id"`
+	UserID          string             `json:"user_id"`
+	StartsOn        pgtype.Date        `json:"starts_on"`
+	EndsOn          pgtype.Date        `json:"ends_on"`
+	Reason          pgtype.Text        `json:"reason"`
+	CreatedByUserID string             `json:"created_by_user_id"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	Revision        int64              `json:"revision"`
+}
+
+type TeamAuditLog struct {
+	ID          string             `json:"id"`
+	TeamID      string             `json:"team_id"`
+	ActorUserID string             `json:"actor_user_id"`
+	Entity      string             `json:"entity"`
+	EntityID    pgtype.Text        `json:"entity_id"`
+	Action      string             `json:"action"`
+	Before      []byte             `json:"before"`
+	After       []byte             `json:"after"`
+	Revision    int64              `json:"revision"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+type TeamEventOutbox struct {
+	ID           int64              `json:"id"`
+	TeamID       string             `json:"team_id"`
+	Entity       string             `json:"entity"`
+	Revision     int64              `json:"revision"`
+	Hints        []byte             `json:"hints"`
+	ChangedAt    pgtype.Timestamptz `json:"changed_at"`
+	AttemptCount int32              `json:"attempt_count"`
+	LastError    pgtype.Text        `json:"last_error"`
+	DispatchedAt pgtype.Timestamptz `json:"dispatched_at"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
+
+type TeamHoliday struct {
+	TeamID          string             `json:"team_id"`
+	HolidayDate     pgtype.Date        `json:"holiday_date"`
+	Name            string             `json:"name"`
+	Source          string             `json:"source"`
+	CreatedByUserID string             `json:"created_by_user_id"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	Revision        int64              `json:"revision"`
 }
 
 type TeamMember struct {
@@ -122,6 +305,33 @@ type TeamMember struct {
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
 }
 
+type TeamWebhook struct {
+	ID         string             `json:"id"`
+	TeamID     string             `json:"team_id"`
+	Url        string             `json:"url"`
+	Secret     string             `json:"secret"`
+	EventTypes []string           `json:"event_types"`
+	IsActive   bool               `json:"is_active"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+}
+
+type TeamWebhookDelivery struct {
+	ID                 string             `json:"id"`
+	WebhookID          string             `json:"webhook_id"`
+	TeamID             string             `json:"team_id"`
+	EventType          string             `json:"event_type"`
+	Payload            []byte             `json:"payload"`
+	Status             string             `json:"status"`
+	AttemptCount       int32              `json:"attempt_count"`
+	NextAttemptAt      pgtype.Timestamptz `json:"next_attempt_at"`
+	LastAttemptAt      pgtype.Timestamptz `json:"last_attempt_at"`
+	LastResponseStatus pgtype.Int4        `json:"last_response_status"`
+	LastError          pgtype.Text        `json:"last_error"`
+	DeliveredAt        pgtype.Timestamptz `json:"delivered_at"`
+	CreatedAt          pgtype.Timestamptz `json:"created_at"`
+}
+
 type User struct {
 	ID           string             `json:"id"`
 	Email        string             `json:"email"`
@@ -132,4 +342,5 @@ type User struct {
 	OidcIssuer   pgtype.Text        `json:"oidc_issuer"`
 	OidcSubject  pgtype.Text        `json:"oidc_subject"`
 	OidcLinkedAt pgtype.Timestamptz `json:"oidc_linked_at"`
+	DeletedAt    pgtype.Timestamptz `json:"deleted_at"`
 }
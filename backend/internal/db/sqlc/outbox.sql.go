@@ -0,0 +1,146 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: outbox.sql
+
+package dbsqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const claimPendingTeamEventOutbox = `-- name: ClaimPendingTeamEventOutbox :many
+SELECT id, team_id, entity, revision, hints, changed_at, attempt_count
+FROM team_event_outbox
+WHERE dispatched_at IS NULL
+ORDER BY id ASC
+LIMIT $1
+FOR UPDATE SKIP LOCKED
+`
+
+type ClaimPendingTeamEventOutboxRow struct {
+	ID           int64              `json:"id"`
+	TeamID       string             `json:"team_id"`
+	Entity       string             `json:"entity"`
+	Revision     int64              `json:"revision"`
+	Hints        []byte             `json:"hints"`
+	ChangedAt    pgtype.Timestamptz `json:"changed_at"`
+	AttemptCount int32              `json:"attempt_count"`
+}
+
+func (q *Queries) ClaimPendingTeamEventOutbox(ctx context.Context, limit int32) ([]ClaimPendingTeamEventOutboxRow, error) {
+	rows, err := q.db.Query(ctx, claimPendingTeamEventOutbox, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ClaimPendingTeamEventOutboxRow
+	for rows.Next() {
+		var i ClaimPendingTeamEventOutboxRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.TeamID,
+			&i.Entity,
+			&i.Revision,
+			&i.Hints,
+			&i.ChangedAt,
+			&i.AttemptCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countPendingTeamEventOutbox = `-- name: CountPendingTeamEventOutbox :one
+SELECT COUNT(*)::bigint
+FROM team_event_outbox
+WHERE dispatched_at IS NULL
+`
+
+func (q *Queries) CountPendingTeamEventOutbox(ctx context.Context) (int64, error) {
+	row := q.db.QueryRow(ctx, countPendingTeamEventOutbox)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const deleteDispatchedTeamEventOutboxBefore = `-- name: DeleteDispatchedTeamEventOutboxBefore :execrows
+DELETE FROM team_event_outbox
+WHERE dispatched_at IS NOT NULL
+  AND dispatched_at < $1
+`
+
+func (q *Queries) DeleteDispatchedTeamEventOutboxBefore(ctx context.Context, dispatchedAt pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteDispatchedTeamEventOutboxBefore, dispatchedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const insertTeamEventOutbox = `-- name: InsertTeamEventOutbox :exec
+INSERT INTO team_event_outbox (team_id, entity, revision, hints, changed_at)
+VALUES ($1, $2, $3, $4, $5)
+`
+
+type InsertTeamEventOutboxParams struct {
+	TeamID    string             `json:"team_id"`
+	Entity    string             `json:"entity"`
+	Revision  int64              `json:"revision"`
+	Hints     []byte             `json:"hints"`
+	ChangedAt pgtype.Timestamptz `json:"changed_at"`
+}
+
+func (q *Queries) InsertTeamEventOutbox(ctx context.Context, arg InsertTeamEventOutboxParams) error {
+	_, err := q.db.Exec(ctx, insertTeamEventOutbox,
+		arg.TeamID,
+		arg.Entity,
+		arg.Revision,
+		arg.Hints,
+		arg.ChangedAt,
+	)
+	return err
+}
+
+const markTeamEventOutboxAttemptFailed = `-- name: MarkTeamEventOutboxAttemptFailed :exec
+UPDATE team_event_outbox
+SET attempt_count = attempt_count + 1,
+    last_error = $1
+WHERE id = $2
+`
+
+type MarkTeamEventOutboxAttemptFailedParams struct {
+	LastError pgtype.Text `json:"last_error"`
+	ID        int64       `json:"id"`
+}
+
+func (q *Queries) MarkTeamEventOutboxAttemptFailed(ctx context.Context, arg MarkTeamEventOutboxAttemptFailedParams) error {
+	_, err := q.db.Exec(ctx, markTeamEventOutboxAttemptFailed, arg.LastError, arg.ID)
+	return err
+}
+
+const markTeamEventOutboxDispatched = `-- name: MarkTeamEventOutboxDispatched :exec
+UPDATE team_event_outbox
+SET dispatched_at = $1,
+    attempt_count = attempt_count + 1,
+    last_error = $2
+WHERE id = $3
+`
+
+type MarkTeamEventOutboxDispatchedParams struct {
+	DispatchedAt pgtype.Timestamptz `json:"dispatched_at"`
+	LastError    pgtype.Text        `json:"last_error"`
+	ID           int64              `json:"id"`
+}
+
+func (q *Queries) MarkTeamEventOutboxDispatched(ctx context.Context, arg MarkTeamEventOutboxDispatchedParams) error {
+	_, err := q.db.Exec(ctx, markTeamEventOutboxDispatched, arg.DispatchedAt, arg.LastError, arg.ID)
+	return err
+}
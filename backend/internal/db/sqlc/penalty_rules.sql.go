@@ -12,8 +12,8 @@ import (
 )
 
 const createPenaltyRule = `-- name: CreatePenaltyRule :exec
-INSERT INTO penalty_rules (id, team_id, threshold, name, description, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
+INSERT INTO penalty_rules (id, team_id, threshold, name, description, created_at, updated_at, revision)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
 `
 
 type CreatePenaltyRuleParams struct {
@@ -24,6 +24,7 @@ type CreatePenaltyRuleParams struct {
 	Description pgtype.Text        `json:"description"`
 	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	Revision    int64              `json:"revision"`
 }
 
 func (q *Queries) CreatePenaltyRule(ctx context.Context, arg CreatePenaltyRuleParams) error {
@@ -35,12 +36,13 @@ func (q *Queries) CreatePenaltyRule(ctx context.Context, arg CreatePenaltyRulePa
 		arg.Description,
 		arg.CreatedAt,
 		arg.UpdatedAt,
+		arg.Revision,
 	)
 	return err
 }
 
 const getPenaltyRuleByID = `-- name: GetPenaltyRuleByID :one
-SELECT id, team_id, threshold, name, description, deleted_at, created_at, updated_at
+SELECT id, team_id, threshold, name, description, deleted_at, created_at, updated_at, revision
 FROM penalty_rules
 WHERE id = $1
 `
@@ -57,12 +59,13 @@ func (q *Queries) GetPenaltyRuleByID(ctx context.Context, id string) (PenaltyRul
 		&i.DeletedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Revision,
 	)
 	return i, err
 }
 
 const getUndeletedPenaltyRuleByID = `-- name: GetUndeletedPenaltyRuleByID :one
-SELECT id, team_id, threshold, name, description, deleted_at, created_at, updated_at
+SELECT id, team_id, threshold, name, description, deleted_at, created_at, updated_at, revision
 FROM penalty_rules
 WHERE id = $1 AND deleted_at IS NULL
 `
@@ -79,12 +82,13 @@ func (q *Queries) GetUndeletedPenaltyRuleByID(ctx context.Context, id string) (P
 		&i.DeletedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Revision,
 	)
 	return i, err
 }
 
 const listPenaltyRulesByTeamID = `-- name: ListPenaltyRulesByTeamID :many
-SELECT id, team_id, threshold, name, description, deleted_at, created_at, updated_at
+SELECT id, team_id, threshold, name, description, deleted_at, created_at, updated_at, revision
 FROM penalty_rules
 WHERE team_id = $1
 ORDER BY threshold
@@ -108,6 +112,7 @@ func (q *Queries) ListPenaltyRulesByTeamID(ctx context.Context, teamID string) (
 			&i.DeletedAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Revision,
 		); err != nil {
 			return nil, err
 		}
@@ -120,7 +125,7 @@ func (q *Queries) ListPenaltyRulesByTeamID(ctx context.Context, teamID string) (
 }
 
 const listPenaltyRulesEffectiveAtByTeamID = `-- name: ListPenaltyRulesEffectiveAtByTeamID :many
-SELECT id, team_id, threshold, name, description, deleted_at, created_at, updated_at
+SELECT id, team_id, threshold, name, description, deleted_at, created_at, updated_at, revision
 FROM penalty_rules
 WHERE team_id = $1
   AND created_at < $2
@@ -151,6 +156,7 @@ func (q *Queries) ListPenaltyRulesEffectiveAtByTeamID(ctx context.Context, arg L
 			&i.DeletedAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Revision,
 		); err != nil {
 			return nil, err
 		}
@@ -163,7 +169,7 @@ func (q *Queries) ListPenaltyRulesEffectiveAtByTeamID(ctx context.Context, arg L
 }
 
 const listUndeletedPenaltyRulesByTeamID = `-- name: ListUndeletedPenaltyRulesByTeamID :many
-SELECT id, team_id, threshold, name, description, deleted_at, created_at, updated_at
+SELECT id, team_id, threshold, name, description, deleted_at, created_at, updated_at, revision
 FROM penalty_rules
 WHERE team_id = $1 AND deleted_at IS NULL
 ORDER BY threshold
@@ -187,6 +193,7 @@ func (q *Queries) ListUndeletedPenaltyRulesByTeamID(ctx context.Context, teamID
 			&i.DeletedAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Revision,
 		); err != nil {
 			return nil, err
 		}
@@ -201,17 +208,19 @@ func (q *Queries) ListUndeletedPenaltyRulesByTeamID(ctx context.Context, teamID
 const softDeletePenaltyRule = `-- name: SoftDeletePenaltyRule :execrows
 UPDATE penalty_rules
 SET deleted_at = $2,
-    updated_at = $2
+    updated_at = $2,
+    revision = $3
 WHERE id = $1 AND deleted_at IS NULL
 `
 
 type SoftDeletePenaltyRuleParams struct {
 	ID        string             `json:"id"`
 	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
+	Revision  int64              `json:"revision"`
 }
 
 func (q *Queries) SoftDeletePenaltyRule(ctx context.Context, arg SoftDeletePenaltyRuleParams) (int64, error) {
-	result, err := q.db.Exec(ctx, softDeletePenaltyRule, arg.ID, arg.DeletedAt)
+	result, err := q.db.Exec(ctx, softDeletePenaltyRule, arg.ID, arg.DeletedAt, arg.Revision)
 	if err != nil {
 		return 0, err
 	}
@@ -223,7 +232,8 @@ UPDATE penalty_rules
 SET threshold = $2,
     name = $3,
     description = $4,
-    updated_at = $5
+    updated_at = $5,
+    revision = $6
 WHERE id = $1 AND deleted_at IS NULL
 `
 
@@ -233,6 +243,7 @@ type UpdatePenaltyRuleParams struct {
 	Name        string             `json:"name"`
 	Description pgtype.Text        `json:"description"`
 	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	Revision    int64              `json:"revision"`
 }
 
 func (q *Queries) UpdatePenaltyRule(ctx context.Context, arg UpdatePenaltyRuleParams) error {
@@ -242,6 +253,7 @@ func (q *Queries) UpdatePenaltyRule(ctx context.Context, arg UpdatePenaltyRulePa
 		arg.Name,
 		arg.Description,
 		arg.UpdatedAt,
+		arg.Revision,
 	)
 	return err
 }
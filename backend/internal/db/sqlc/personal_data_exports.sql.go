@@ -0,0 +1,453 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: personal_data_exports.sql
+
+package dbsqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const claimDuePersonalDataExports = `-- name: ClaimDuePersonalDataExports :many
+UPDATE personal_data_exports e
+SET next_attempt_at = $1
+WHERE e.id IN (
+  SELECT due.id
+  FROM personal_data_exports due
+  WHERE due.status = 'pending'
+    AND due.next_attempt_at <= $2
+  ORDER BY due.next_attempt_at ASC
+  LIMIT $3
+  FOR UPDATE SKIP LOCKED
+)
+RETURNING e.id, e.user_id, e.attempt_count
+`
+
+type ClaimDuePersonalDataExportsParams struct {
+	LeaseUntil pgtype.Timestamptz `json:"lease_until"`
+	DueBefore  pgtype.Timestamptz `json:"due_before"`
+	BatchSize  int32              `json:"batch_size"`
+}
+
+type ClaimDuePersonalDataExportsRow struct {
+	ID           string `json:"id"`
+	UserID       string `json:"user_id"`
+	AttemptCount int32  `json:"attempt_count"`
+}
+
+func (q *Queries) ClaimDuePersonalDataExports(ctx context.Context, arg ClaimDuePersonalDataExportsParams) ([]ClaimDuePersonalDataExportsRow, error) {
+	rows, err := q.db.Query(ctx, claimDuePersonalDataExports, arg.LeaseUntil, arg.DueBefore, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ClaimDuePersonalDataExportsRow
+	for rows.Next() {
+		var i ClaimDuePersonalDataExportsRow
+		if err := rows.Scan(&i.ID, &i.UserID, &i.AttemptCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const countPersonalCompletions = `-- name: CountPersonalCompletions :one
+SELECT (
+  (SELECT COUNT(*) FROM task_completion_daily WHERE completed_by_user_id = $1::uuid)
+  + (SELECT COUNT(*) FROM task_completion_weekly_entries WHERE completed_by_user_id = $1::uuid)
+  + (SELECT COUNT(*) FROM task_completion_one_off WHERE completed_by_user_id = $1::uuid)
+)::bigint
+`
+
+func (q *Queries) CountPersonalCompletions(ctx context.Context, userID string) (int64, error) {
+	row := q.db.QueryRow(ctx, countPersonalCompletions, userID)
+	var column_1 int64
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const deletePersonalDataExportsByUserID = `-- name: DeletePersonalDataExportsByUserID :exec
+DELETE FROM personal_data_exports
+WHERE user_id = $1
+`
+
+func (q *Queries) DeletePersonalDataExportsByUserID(ctx context.Context, userID string) error {
+	_, err := q.db.Exec(ctx, deletePersonalDataExportsByUserID, userID)
+	return err
+}
+
+const getLatestPersonalDataExport = `-- name: GetLatestPersonalDataExport :one
+SELECT id, user_id, status, attempt_count, last_error, requested_at, completed_at, expires_at
+FROM personal_data_exports
+WHERE user_id = $1
+ORDER BY requested_at DESC, id DESC
+LIMIT 1
+`
+
+type GetLatestPersonalDataExportRow struct {
+	ID           string             `json:"id"`
+	UserID       string             `json:"user_id"`
+	Status       string             `json:"status"`
+	AttemptCount int32              `json:"attempt_count"`
+	LastError    pgtype.Text        `json:"last_error"`
+	RequestedAt  pgtype.Timestamptz `json:"requested_at"`
+	CompletedAt  pgtype.Timestamptz `json:"completed_at"`
+	ExpiresAt    pgtype.Timestamptz `json:"expires_at"`
+}
+
+func (q *Queries) GetLatestPersonalDataExport(ctx context.Context, userID string) (GetLatestPersonalDataExportRow, error) {
+	row := q.db.QueryRow(ctx, getLatestPersonalDataExport, userID)
+	var i GetLatestPersonalDataExportRow
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Status,
+		&i.AttemptCount,
+		&i.LastError,
+		&i.RequestedAt,
+		&i.CompletedAt,
+		&i.ExpiresAt,
+	)
+	return i, err
+}
+
+const getPersonalDataExportPayload = `-- name: GetPersonalDataExportPayload :one
+SELECT payload
+FROM personal_data_exports
+WHERE id = $1
+  AND status = 'ready'
+`
+
+func (q *Queries) GetPersonalDataExportPayload(ctx context.Context, id string) ([]byte, error) {
+	row := q.db.QueryRow(ctx, getPersonalDataExportPayload, id)
+	var payload []byte
+	err := row.Scan(&payload)
+	return payload, err
+}
+
+const getPersonalDataProfile = `-- name: GetPersonalDataProfile :one
+SELECT id, email, display_name, nickname, color_hex, oidc_issuer, oidc_linked_at, created_at
+FROM users
+WHERE id = $1
+`
+
+type GetPersonalDataProfileRow struct {
+	ID           string             `json:"id"`
+	Email        string             `json:"email"`
+	DisplayName  string             `json:"display_name"`
+	Nickname     pgtype.Text        `json:"nickname"`
+	ColorHex     pgtype.Text        `json:"color_hex"`
+	OidcIssuer   pgtype.Text        `json:"oidc_issuer"`
+	OidcLinkedAt pgtype.Timestamptz `json:"oidc_linked_at"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
+
+func (q *Queries) GetPersonalDataProfile(ctx context.Context, id string) (GetPersonalDataProfileRow, error) {
+	row := q.db.QueryRow(ctx, getPersonalDataProfile, id)
+	var i GetPersonalDataProfileRow
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.DisplayName,
+		&i.Nickname,
+		&i.ColorHex,
+		&i.OidcIssuer,
+		&i.OidcLinkedAt,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const insertPersonalDataExport = `-- name: InsertPersonalDataExport :execrows
+INSERT INTO personal_data_exports (id, user_id, status, payload, requested_at, completed_at, expires_at)
+VALUES (
+  $1, $2, $3, $4,
+  $5, $6, $7
+)
+ON CONFLICT DO NOTHING
+`
+
+type InsertPersonalDataExportParams struct {
+	ID          string             `json:"id"`
+	UserID      string             `json:"user_id"`
+	Status      string             `json:"status"`
+	Payload     []byte             `json:"payload"`
+	RequestedAt pgtype.Timestamptz `json:"requested_at"`
+	CompletedAt pgtype.Timestamptz `json:"completed_at"`
+	ExpiresAt   pgtype.Timestamptz `json:"expires_at"`
+}
+
+func (q *Queries) InsertPersonalDataExport(ctx context.Context, arg InsertPersonalDataExportParams) (int64, error) {
+	result, err := q.db.Exec(ctx, insertPersonalDataExport,
+		arg.ID,
+		arg.UserID,
+		arg.Status,
+		arg.Payload,
+		arg.RequestedAt,
+		arg.CompletedAt,
+		arg.ExpiresAt,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const listPersonalDailyCompletions = `-- name: ListPersonalDailyCompletions :many
+SELECT d.task_id, t.title AS task_title, t.team_id, d.target_date, d.created_at
+FROM task_completion_daily d
+JOIN tasks t ON t.id = d.task_id
+WHERE d.completed_by_user_id = $1
+ORDER BY d.target_date, d.task_id
+`
+
+type ListPersonalDailyCompletionsRow struct {
+	TaskID     string             `json:"task_id"`
+	TaskTitle  string             `json:"task_title"`
+	TeamID     string             `json:"team_id"`
+	TargetDate pgtype.Date        `json:"target_date"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+func (q *Queries) ListPersonalDailyCompletions(ctx context.Context, completedByUserID string) ([]ListPersonalDailyCompletionsRow, error) {
+	rows, err := q.db.Query(ctx, listPersonalDailyCompletions, completedByUserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListPersonalDailyCompletionsRow
+	for rows.Next() {
+		var i ListPersonalDailyCompletionsRow
+		if err := rows.Scan(
+			&i.TaskID,
+			&i.TaskTitle,
+			&i.TeamID,
+			&i.TargetDate,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPersonalDataMemberships = `-- name: ListPersonalDataMemberships :many
+SELECT tm.team_id, t.name AS team_name, tm.role, tm.created_at AS joined_at
+FROM team_members tm
+JOIN teams t ON t.id = tm.team_id
+WHERE tm.user_id = $1
+ORDER BY tm.created_at
+`
+
+type ListPersonalDataMembershipsRow struct {
+	TeamID   string             `json:"team_id"`
+	TeamName string             `json:"team_name"`
+	Role     string             `json:"role"`
+	JoinedAt pgtype.Timestamptz `json:"joined_at"`
+}
+
+func (q *Queries) ListPersonalDataMemberships(ctx context.Context, userID string) ([]ListPersonalDataMembershipsRow, error) {
+	rows, err := q.db.Query(ctx, listPersonalDataMemberships, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListPersonalDataMembershipsRow
+	for rows.Next() {
+		var i ListPersonalDataMembershipsRow
+		if err := rows.Scan(
+			&i.TeamID,
+			&i.TeamName,
+			&i.Role,
+			&i.JoinedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPersonalOneOffCompletions = `-- name: ListPersonalOneOffCompletions :many
+SELECT o.task_id, t.title AS task_title, t.team_id, t.due_on, o.completed_at
+FROM task_completion_one_off o
+JOIN tasks t ON t.id = o.task_id
+WHERE o.completed_by_user_id = $1
+ORDER BY o.completed_at, o.task_id
+`
+
+type ListPersonalOneOffCompletionsRow struct {
+	TaskID      string             `json:"task_id"`
+	TaskTitle   string             `json:"task_title"`
+	TeamID      string             `json:"team_id"`
+	DueOn       pgtype.Date        `json:"due_on"`
+	CompletedAt pgtype.Timestamptz `json:"completed_at"`
+}
+
+func (q *Queries) ListPersonalOneOffCompletions(ctx context.Context, completedByUserID string) ([]ListPersonalOneOffCompletionsRow, error) {
+	rows, err := q.db.Query(ctx, listPersonalOneOffCompletions, completedByUserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListPersonalOneOffCompletionsRow
+	for rows.Next() {
+		var i ListPersonalOneOffCompletionsRow
+		if err := rows.Scan(
+			&i.TaskID,
+			&i.TaskTitle,
+			&i.TeamID,
+			&i.DueOn,
+			&i.CompletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPersonalSessions = `-- name: ListPersonalSessions :many
+SELECT created_at, expires_at
+FROM sessions
+WHERE user_id = $1
+ORDER BY created_at
+`
+
+type ListPersonalSessionsRow struct {
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+}
+
+func (q *Queries) ListPersonalSessions(ctx context.Context, userID string) ([]ListPersonalSessionsRow, error) {
+	rows, err := q.db.Query(ctx, listPersonalSessions, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListPersonalSessionsRow
+	for rows.Next() {
+		var i ListPersonalSessionsRow
+		if err := rows.Scan(&i.CreatedAt, &i.ExpiresAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listPersonalWeeklyCompletions = `-- name: ListPersonalWeeklyCompletions :many
+SELECT e.task_id, t.title AS task_title, t.team_id, e.week_start, e.created_at
+FROM task_completion_weekly_entries e
+JOIN tasks t ON t.id = e.task_id
+WHERE e.completed_by_user_id = $1
+ORDER BY e.week_start, e.task_id, e.created_at
+`
+
+type ListPersonalWeeklyCompletionsRow struct {
+	TaskID    string             `json:"task_id"`
+	TaskTitle string             `json:"task_title"`
+	TeamID    string             `json:"team_id"`
+	WeekStart pgtype.Date        `json:"week_start"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+func (q *Queries) ListPersonalWeeklyCompletions(ctx context.Context, completedByUserID string) ([]ListPersonalWeeklyCompletionsRow, error) {
+	rows, err := q.db.Query(ctx, listPersonalWeeklyCompletions, completedByUserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListPersonalWeeklyCompletionsRow
+	for rows.Next() {
+		var i ListPersonalWeeklyCompletionsRow
+		if err := rows.Scan(
+			&i.TaskID,
+			&i.TaskTitle,
+			&i.TeamID,
+			&i.WeekStart,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markPersonalDataExportReady = `-- name: MarkPersonalDataExportReady :exec
+UPDATE personal_data_exports
+SET status = 'ready',
+    payload = $1,
+    attempt_count = attempt_count + 1,
+    last_error = NULL,
+    completed_at = $2,
+    expires_at = $3
+WHERE id = $4
+`
+
+type MarkPersonalDataExportReadyParams struct {
+	Payload     []byte             `json:"payload"`
+	CompletedAt pgtype.Timestamptz `json:"completed_at"`
+	ExpiresAt   pgtype.Timestamptz `json:"expires_at"`
+	ID          string             `json:"id"`
+}
+
+func (q *Queries) MarkPersonalDataExportReady(ctx context.Context, arg MarkPersonalDataExportReadyParams) error {
+	_, err := q.db.Exec(ctx, markPersonalDataExportReady,
+		arg.Payload,
+		arg.CompletedAt,
+		arg.ExpiresAt,
+		arg.ID,
+	)
+	return err
+}
+
+const recordPersonalDataExportFailure = `-- name: RecordPersonalDataExportFailure :exec
+UPDATE personal_data_exports
+SET status = CASE WHEN $1::boolean THEN 'failed' ELSE 'pending' END,
+    attempt_count = attempt_count + 1,
+    last_error = $2,
+    next_attempt_at = $3
+WHERE id = $4
+`
+
+type RecordPersonalDataExportFailureParams struct {
+	GiveUp        bool               `json:"give_up"`
+	LastError     pgtype.Text        `json:"last_error"`
+	NextAttemptAt pgtype.Timestamptz `json:"next_attempt_at"`
+	ID            string             `json:"id"`
+}
+
+func (q *Queries) RecordPersonalDataExportFailure(ctx context.Context, arg RecordPersonalDataExportFailureParams) error {
+	_, err := q.db.Exec(ctx, recordPersonalDataExportFailure,
+		arg.GiveUp,
+		arg.LastError,
+		arg.NextAttemptAt,
+		arg.ID,
+	)
+	return err
+}
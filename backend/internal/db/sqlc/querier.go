@@ -13,84 +13,247 @@ import (
 type Querier interface {
 	AddTeamMember(ctx context.Context, arg AddTeamMemberParams) error
 	AddTriggeredRuleForMonth(ctx context.Context, arg AddTriggeredRuleForMonthParams) error
+	AdvisoryLock(ctx context.Context, key int64) error
+	AnonymizeUser(ctx context.Context, arg AnonymizeUserParams) (int64, error)
+	ClaimDuePersonalDataExports(ctx context.Context, arg ClaimDuePersonalDataExportsParams) ([]ClaimDuePersonalDataExportsRow, error)
+	ClaimDueTeamWebhookDeliveries(ctx context.Context, arg ClaimDueTeamWebhookDeliveriesParams) ([]ClaimDueTeamWebhookDeliveriesRow, error)
+	ClaimPendingTeamEventOutbox(ctx context.Context, limit int32) ([]ClaimPendingTeamEventOutboxRow, error)
 	ClearTaskAssigneeByTeamAndUser(ctx context.Context, arg ClearTaskAssigneeByTeamAndUserParams) error
+	ClearTaskCategoryFromTasks(ctx context.Context, arg ClearTaskCategoryFromTasksParams) error
 	CloseMonthlyPenaltySummary(ctx context.Context, arg CloseMonthlyPenaltySummaryParams) error
 	ConsumeExchangeCode(ctx context.Context, code string) error
+	CountExpiredAuthRequests(ctx context.Context, cutoff pgtype.Timestamptz) (int64, error)
+	CountExpiredInviteCodes(ctx context.Context, cutoff pgtype.Timestamptz) (int64, error)
+	CountExpiredPersonalDataExports(ctx context.Context, cutoff pgtype.Timestamptz) (int64, error)
+	CountFinishedWebhookDeliveries(ctx context.Context, cutoff pgtype.Timestamptz) (int64, error)
+	CountOldCloseRunHistory(ctx context.Context, cutoff pgtype.Timestamptz) (int64, error)
+	CountOldTeamAuditEntries(ctx context.Context, cutoff pgtype.Timestamptz) (int64, error)
+	CountOrphanedCompletionAttachments(ctx context.Context, cutoff pgtype.Timestamptz) (int64, error)
+	CountPendingTeamEventOutbox(ctx context.Context) (int64, error)
+	CountPersonalCompletions(ctx context.Context, userID string) (int64, error)
+	CountPurgeableDeletedPenaltyRules(ctx context.Context, arg CountPurgeableDeletedPenaltyRulesParams) (int64, error)
+	CountPurgeableDeletedTasks(ctx context.Context, arg CountPurgeableDeletedTasksParams) (int64, error)
+	CountStaleExchangeCodes(ctx context.Context, cutoff pgtype.Timestamptz) (int64, error)
+	CountStaleSessions(ctx context.Context, arg CountStaleSessionsParams) (int64, error)
+	CountTaskTemplatesByTeamID(ctx context.Context, teamID string) (int32, error)
+	CountUncheckedTaskChecklistItems(ctx context.Context, arg CountUncheckedTaskChecklistItemsParams) (int32, error)
+	CreateCompletionAttachment(ctx context.Context, arg CreateCompletionAttachmentParams) error
+	CreateCompletionReaction(ctx context.Context, arg CreateCompletionReactionParams) (int64, error)
 	CreateInviteCode(ctx context.Context, arg CreateInviteCodeParams) error
 	CreatePenaltyRule(ctx context.Context, arg CreatePenaltyRuleParams) error
 	CreateSession(ctx context.Context, arg CreateSessionParams) error
 	CreateTask(ctx context.Context, arg CreateTaskParams) error
+	CreateTaskCategory(ctx context.Context, arg CreateTaskCategoryParams) error
+	CreateTaskChecklistCheck(ctx context.Context, arg CreateTaskChecklistCheckParams) error
+	CreateTaskChecklistItem(ctx context.Context, arg CreateTaskChecklistItemParams) error
+	CreateTaskComment(ctx context.Context, arg CreateTaskCommentParams) error
 	CreateTaskCompletionDaily(ctx context.Context, arg CreateTaskCompletionDailyParams) error
+	CreateTaskCompletionOneOff(ctx context.Context, arg CreateTaskCompletionOneOffParams) error
+	CreateTaskTemplate(ctx context.Context, arg CreateTaskTemplateParams) error
+	CreateTaskTemplateItem(ctx context.Context, arg CreateTaskTemplateItemParams) error
 	CreateTeam(ctx context.Context, arg CreateTeamParams) error
+	CreateTeamAbsence(ctx context.Context, arg CreateTeamAbsenceParams) error
+	CreateTeamAuditEntry(ctx context.Context, arg CreateTeamAuditEntryParams) error
+	CreateTeamWebhook(ctx context.Context, arg CreateTeamWebhookParams) error
+	CreateTeamWebhookDelivery(ctx context.Context, arg CreateTeamWebhookDeliveryParams) error
 	CreateUser(ctx context.Context, arg CreateUserParams) error
 	DeleteAuthRequest(ctx context.Context, state string) error
+	DeleteCompletionAttachmentsByIDs(ctx context.Context, ids []string) (int64, error)
+	DeleteCompletionReaction(ctx context.Context, arg DeleteCompletionReactionParams) (int64, error)
+	DeleteDispatchedTeamEventOutboxBefore(ctx context.Context, dispatchedAt pgtype.Timestamptz) (int64, error)
+	DeleteExchangeCodesByUserID(ctx context.Context, userID string) error
+	DeleteExpiredAuthRequestsBatch(ctx context.Context, arg DeleteExpiredAuthRequestsBatchParams) (int64, error)
+	DeleteExpiredInviteCodesBatch(ctx context.Context, arg DeleteExpiredInviteCodesBatchParams) (int64, error)
+	DeleteExpiredPersonalDataExportsBatch(ctx context.Context, arg DeleteExpiredPersonalDataExportsBatchParams) (int64, error)
+	DeleteFinishedWebhookDeliveriesBatch(ctx context.Context, arg DeleteFinishedWebhookDeliveriesBatchParams) (int64, error)
 	DeleteInviteCode(ctx context.Context, code string) (int64, error)
 	DeleteInviteCodesByTeamID(ctx context.Context, teamID string) error
 	DeleteLatestTaskCompletionWeeklyEntry(ctx context.Context, arg DeleteLatestTaskCompletionWeeklyEntryParams) (int64, error)
+	DeleteOldCloseRunHistoryBatch(ctx context.Context, arg DeleteOldCloseRunHistoryBatchParams) (int64, error)
+	DeleteOldTeamAuditEntriesBatch(ctx context.Context, arg DeleteOldTeamAuditEntriesBatchParams) (int64, error)
+	DeletePersonalDataExportsByUserID(ctx context.Context, userID string) error
+	DeletePurgeableDeletedPenaltyRulesBatch(ctx context.Context, arg DeletePurgeableDeletedPenaltyRulesBatchParams) (int64, error)
+	DeletePurgeableDeletedTasksBatch(ctx context.Context, arg DeletePurgeableDeletedTasksBatchParams) (int64, error)
 	DeleteSession(ctx context.Context, token string) error
-	DeleteTask(ctx context.Context, id string) error
+	DeleteSessionsByUserID(ctx context.Context, userID string) error
+	DeleteStaleExchangeCodesBatch(ctx context.Context, arg DeleteStaleExchangeCodesBatchParams) (int64, error)
+	DeleteStaleSessionsBatch(ctx context.Context, arg DeleteStaleSessionsBatchParams) (int64, error)
+	DeleteTask(ctx context.Context, arg DeleteTaskParams) error
+	DeleteTaskCategory(ctx context.Context, arg DeleteTaskCategoryParams) (int64, error)
+	DeleteTaskChecklistCheck(ctx context.Context, arg DeleteTaskChecklistCheckParams) error
+	DeleteTaskChecklistItem(ctx context.Context, id string) error
+	DeleteTaskComment(ctx context.Context, id string) error
 	DeleteTaskCompletionDaily(ctx context.Context, arg DeleteTaskCompletionDailyParams) error
 	DeleteTaskCompletionDailyByTaskID(ctx context.Context, taskID string) error
+	DeleteTaskCompletionOneOff(ctx context.Context, taskID string) error
 	DeleteTaskCompletionWeeklyEntriesByTaskID(ctx context.Context, taskID string) error
+	DeleteTaskTemplate(ctx context.Context, arg DeleteTaskTemplateParams) (int64, error)
 	DeleteTeam(ctx context.Context, id string) error
+	DeleteTeamAbsence(ctx context.Context, arg DeleteTeamAbsenceParams) (int64, error)
+	DeleteTeamHoliday(ctx context.Context, arg DeleteTeamHolidayParams) (int64, error)
 	DeleteTeamMember(ctx context.Context, arg DeleteTeamMemberParams) error
+	DeleteTeamWebhook(ctx context.Context, arg DeleteTeamWebhookParams) (int64, error)
 	DeleteTriggeredRulesByMonth(ctx context.Context, arg DeleteTriggeredRulesByMonthParams) error
+	GetArchiveTeam(ctx context.Context, id string) (GetArchiveTeamRow, error)
 	GetAuthRequest(ctx context.Context, state string) (OauthAuthRequest, error)
+	GetCompletionAttachmentByID(ctx context.Context, id string) (GetCompletionAttachmentByIDRow, error)
 	GetEarliestTaskCreatedAtByTeam(ctx context.Context, teamID string) (pgtype.Timestamptz, error)
 	GetExchangeCode(ctx context.Context, code string) (OauthExchangeCode, error)
 	GetInviteCode(ctx context.Context, code string) (InviteCode, error)
+	GetLatestCloseRunHistory(ctx context.Context, arg GetLatestCloseRunHistoryParams) (CloseRunHistory, error)
 	GetLatestCloseRunTargetDate(ctx context.Context, arg GetLatestCloseRunTargetDateParams) (pgtype.Date, error)
 	GetLatestInviteCodeByTeamID(ctx context.Context, teamID string) (InviteCode, error)
+	GetLatestPersonalDataExport(ctx context.Context, userID string) (GetLatestPersonalDataExportRow, error)
 	GetMonthlyPenaltySummary(ctx context.Context, arg GetMonthlyPenaltySummaryParams) (MonthlyPenaltySummary, error)
 	GetOldestOtherTeamMember(ctx context.Context, arg GetOldestOtherTeamMemberParams) (string, error)
 	GetPenaltyRuleByID(ctx context.Context, id string) (PenaltyRule, error)
-	GetSessionByToken(ctx context.Context, token string) (Session, error)
+	GetPersonalDataExportPayload(ctx context.Context, id string) ([]byte, error)
+	GetPersonalDataProfile(ctx context.Context, id string) (GetPersonalDataProfileRow, error)
+	GetSessionByToken(ctx context.Context, arg GetSessionByTokenParams) (Session, error)
 	GetTaskByID(ctx context.Context, id string) (GetTaskByIDRow, error)
+	GetTaskCategoryByID(ctx context.Context, id string) (TaskCategory, error)
+	GetTaskChecklistItemByID(ctx context.Context, id string) (TaskChecklistItem, error)
+	GetTaskCommentByID(ctx context.Context, id string) (GetTaskCommentByIDRow, error)
+	GetTaskCommentForUpdate(ctx context.Context, id string) (GetTaskCommentForUpdateRow, error)
+	GetTaskCompletionDailyEvidence(ctx context.Context, arg GetTaskCompletionDailyEvidenceParams) (GetTaskCompletionDailyEvidenceRow, error)
+	GetTaskCompletionOneOff(ctx context.Context, taskID string) (GetTaskCompletionOneOffRow, error)
+	GetTaskCompletionOneOffEvidence(ctx context.Context, taskID string) (GetTaskCompletionOneOffEvidenceRow, error)
 	GetTaskCompletionWeeklyEntryCount(ctx context.Context, arg GetTaskCompletionWeeklyEntryCountParams) (int64, error)
+	GetTaskCompletionWeeklyEntryEvidence(ctx context.Context, arg GetTaskCompletionWeeklyEntryEvidenceParams) (GetTaskCompletionWeeklyEntryEvidenceRow, error)
+	GetTaskTemplateByID(ctx context.Context, arg GetTaskTemplateByIDParams) (GetTaskTemplateByIDRow, error)
+	GetTeamAbsenceForUpdate(ctx context.Context, id string) (GetTeamAbsenceForUpdateRow, error)
+	GetTeamHolidayForUpdate(ctx context.Context, arg GetTeamHolidayForUpdateParams) (GetTeamHolidayForUpdateRow, error)
+	GetTeamSettings(ctx context.Context, id string) (GetTeamSettingsRow, error)
 	GetTeamStateRevision(ctx context.Context, id string) (int64, error)
+	GetTeamStateRevisionForUpdate(ctx context.Context, id string) (int64, error)
+	GetTeamWebhookByID(ctx context.Context, id string) (TeamWebhook, error)
 	GetUndeletedPenaltyRuleByID(ctx context.Context, id string) (PenaltyRule, error)
 	GetUserAuthIdentityByID(ctx context.Context, id string) (GetUserAuthIdentityByIDRow, error)
 	GetUserByEmail(ctx context.Context, lower string) (GetUserByEmailRow, error)
 	GetUserByID(ctx context.Context, id string) (GetUserByIDRow, error)
 	GetUserByOIDC(ctx context.Context, arg GetUserByOIDCParams) (GetUserByOIDCRow, error)
+	HasTaskChecklistCheck(ctx context.Context, arg HasTaskChecklistCheckParams) (bool, error)
 	HasTaskCompletionDaily(ctx context.Context, arg HasTaskCompletionDailyParams) (bool, error)
+	ImportCloseRun(ctx context.Context, arg ImportCloseRunParams) error
+	ImportMonthlyPenaltySummary(ctx context.Context, arg ImportMonthlyPenaltySummaryParams) error
+	ImportPenaltyRule(ctx context.Context, arg ImportPenaltyRuleParams) error
+	ImportTask(ctx context.Context, arg ImportTaskParams) error
+	ImportTaskCategory(ctx context.Context, arg ImportTaskCategoryParams) error
+	ImportTaskChecklistItem(ctx context.Context, arg ImportTaskChecklistItemParams) error
+	ImportTaskCompletionDaily(ctx context.Context, arg ImportTaskCompletionDailyParams) error
+	ImportTaskCompletionOneOff(ctx context.Context, arg ImportTaskCompletionOneOffParams) error
+	ImportTaskCompletionWeeklyEntry(ctx context.Context, arg ImportTaskCompletionWeeklyEntryParams) error
+	ImportTaskEvaluationDedupe(ctx context.Context, arg ImportTaskEvaluationDedupeParams) error
+	ImportTaskTemplate(ctx context.Context, arg ImportTaskTemplateParams) error
+	ImportTaskTemplateItem(ctx context.Context, arg ImportTaskTemplateItemParams) error
+	ImportTeamAbsence(ctx context.Context, arg ImportTeamAbsenceParams) error
+	ImportTeamHoliday(ctx context.Context, arg ImportTeamHolidayParams) error
+	ImportTriggeredRule(ctx context.Context, arg ImportTriggeredRuleParams) error
+	ImportUser(ctx context.Context, arg ImportUserParams) error
 	IncrementDailyPenalty(ctx context.Context, arg IncrementDailyPenaltyParams) error
 	IncrementWeeklyPenalty(ctx context.Context, arg IncrementWeeklyPenaltyParams) error
 	InsertAuthRequest(ctx context.Context, arg InsertAuthRequestParams) error
 	InsertCloseRun(ctx context.Context, arg InsertCloseRunParams) (int64, error)
+	InsertCloseRunHistory(ctx context.Context, arg InsertCloseRunHistoryParams) error
 	InsertExchangeCode(ctx context.Context, arg InsertExchangeCodeParams) error
+	InsertPersonalDataExport(ctx context.Context, arg InsertPersonalDataExportParams) (int64, error)
 	InsertTaskCompletionWeeklyEntry(ctx context.Context, arg InsertTaskCompletionWeeklyEntryParams) error
 	InsertTaskEvaluationDedupe(ctx context.Context, arg InsertTaskEvaluationDedupeParams) (int64, error)
+	InsertTeamEventOutbox(ctx context.Context, arg InsertTeamEventOutboxParams) error
+	ListActiveTeamWebhooksForEvent(ctx context.Context, arg ListActiveTeamWebhooksForEventParams) ([]TeamWebhook, error)
+	ListArchiveCloseRunHistoryByTeamID(ctx context.Context, teamID string) ([]CloseRunHistory, error)
+	ListArchiveCloseRunsByTeamID(ctx context.Context, teamID string) ([]ListArchiveCloseRunsByTeamIDRow, error)
+	ListArchiveDailyCompletionsByTeamID(ctx context.Context, teamID string) ([]ListArchiveDailyCompletionsByTeamIDRow, error)
+	ListArchiveMembersByTeamID(ctx context.Context, teamID string) ([]ListArchiveMembersByTeamIDRow, error)
+	ListArchiveMonthlySummariesByTeamID(ctx context.Context, teamID string) ([]ListArchiveMonthlySummariesByTeamIDRow, error)
+	ListArchiveOneOffCompletionsByTeamID(ctx context.Context, teamID string) ([]ListArchiveOneOffCompletionsByTeamIDRow, error)
+	ListArchivePenaltyRulesByTeamID(ctx context.Context, teamID string) ([]ListArchivePenaltyRulesByTeamIDRow, error)
+	ListArchiveTaskCategoriesByTeamID(ctx context.Context, teamID string) ([]ListArchiveTaskCategoriesByTeamIDRow, error)
+	ListArchiveTaskChecklistItemsByTeamID(ctx context.Context, teamID string) ([]TaskChecklistItem, error)
+	ListArchiveTaskEvaluationDedupesByTeamID(ctx context.Context, teamID string) ([]ListArchiveTaskEvaluationDedupesByTeamIDRow, error)
+	ListArchiveTaskTemplatesByTeamID(ctx context.Context, teamID string) ([]ListArchiveTaskTemplatesByTeamIDRow, error)
+	ListArchiveTasksByTeamID(ctx context.Context, teamID string) ([]ListArchiveTasksByTeamIDRow, error)
+	ListArchiveTeamAbsencesByTeamID(ctx context.Context, teamID string) ([]ListArchiveTeamAbsencesByTeamIDRow, error)
+	ListArchiveTeamHolidaysByTeamID(ctx context.Context, teamID string) ([]ListArchiveTeamHolidaysByTeamIDRow, error)
+	ListArchiveTriggeredRulesByTeamID(ctx context.Context, teamID string) ([]ListArchiveTriggeredRulesByTeamIDRow, error)
+	ListArchiveWeeklyEntriesByTeamID(ctx context.Context, teamID string) ([]ListArchiveWeeklyEntriesByTeamIDRow, error)
+	ListCloseRunHistoryByTeamID(ctx context.Context, arg ListCloseRunHistoryByTeamIDParams) ([]CloseRunHistory, error)
+	ListCompletionEvidenceByTeam(ctx context.Context, arg ListCompletionEvidenceByTeamParams) ([]ListCompletionEvidenceByTeamRow, error)
+	ListCompletionReactionsByTarget(ctx context.Context, arg ListCompletionReactionsByTargetParams) ([]ListCompletionReactionsByTargetRow, error)
+	ListCompletionReactionsByTeam(ctx context.Context, arg ListCompletionReactionsByTeamParams) ([]ListCompletionReactionsByTeamRow, error)
+	ListExistingUserEmails(ctx context.Context, emails []string) ([]string, error)
 	ListMembershipsByUserID(ctx context.Context, userID string) ([]ListMembershipsByUserIDRow, error)
+	ListMonthlyCompletionsByCategory(ctx context.Context, arg ListMonthlyCompletionsByCategoryParams) ([]ListMonthlyCompletionsByCategoryRow, error)
+	ListMonthlyPenaltiesByCategory(ctx context.Context, arg ListMonthlyPenaltiesByCategoryParams) ([]ListMonthlyPenaltiesByCategoryRow, error)
+	ListOrphanedCompletionAttachmentsBatch(ctx context.Context, arg ListOrphanedCompletionAttachmentsBatchParams) ([]ListOrphanedCompletionAttachmentsBatchRow, error)
 	ListPenaltyRulesByTeamID(ctx context.Context, teamID string) ([]PenaltyRule, error)
 	ListPenaltyRulesEffectiveAtByTeamID(ctx context.Context, arg ListPenaltyRulesEffectiveAtByTeamIDParams) ([]PenaltyRule, error)
+	ListPersonalDailyCompletions(ctx context.Context, completedByUserID string) ([]ListPersonalDailyCompletionsRow, error)
+	ListPersonalDataMemberships(ctx context.Context, userID string) ([]ListPersonalDataMembershipsRow, error)
+	ListPersonalOneOffCompletions(ctx context.Context, completedByUserID string) ([]ListPersonalOneOffCompletionsRow, error)
+	ListPersonalSessions(ctx context.Context, userID string) ([]ListPersonalSessionsRow, error)
+	ListPersonalTaskComments(ctx context.Context, authorUserID string) ([]ListPersonalTaskCommentsRow, error)
+	ListPersonalWeeklyCompletions(ctx context.Context, completedByUserID string) ([]ListPersonalWeeklyCompletionsRow, error)
+	ListTaskCategoriesByTeamID(ctx context.Context, teamID string) ([]TaskCategory, error)
+	ListTaskChecklistItemsByTaskID(ctx context.Context, taskID string) ([]TaskChecklistItem, error)
+	ListTaskChecklistStatesByTeam(ctx context.Context, arg ListTaskChecklistStatesByTeamParams) ([]ListTaskChecklistStatesByTeamRow, error)
+	ListTaskComments(ctx context.Context, arg ListTaskCommentsParams) ([]ListTaskCommentsRow, error)
 	ListTaskCompletionDailyByMonthAndTeam(ctx context.Context, arg ListTaskCompletionDailyByMonthAndTeamParams) ([]ListTaskCompletionDailyByMonthAndTeamRow, error)
 	ListTaskCompletionDailyByTeamAndDate(ctx context.Context, arg ListTaskCompletionDailyByTeamAndDateParams) ([]ListTaskCompletionDailyByTeamAndDateRow, error)
+	ListTaskCompletionOneOffByTeam(ctx context.Context, teamID string) ([]ListTaskCompletionOneOffByTeamRow, error)
 	ListTaskCompletionWeeklyByMonthAndTeam(ctx context.Context, arg ListTaskCompletionWeeklyByMonthAndTeamParams) ([]ListTaskCompletionWeeklyByMonthAndTeamRow, error)
 	ListTaskCompletionWeeklyCountsByTeamAndWeek(ctx context.Context, arg ListTaskCompletionWeeklyCountsByTeamAndWeekParams) ([]ListTaskCompletionWeeklyCountsByTeamAndWeekRow, error)
 	ListTaskCompletionWeeklySlotsByMonthAndTeam(ctx context.Context, arg ListTaskCompletionWeeklySlotsByMonthAndTeamParams) ([]ListTaskCompletionWeeklySlotsByMonthAndTeamRow, error)
 	ListTaskCompletionWeeklySlotsByTeamAndWeek(ctx context.Context, arg ListTaskCompletionWeeklySlotsByTeamAndWeekParams) ([]ListTaskCompletionWeeklySlotsByTeamAndWeekRow, error)
+	ListTaskTemplateItemsByTeamID(ctx context.Context, teamID string) ([]TaskTemplateItem, error)
+	ListTaskTemplateItemsByTemplateID(ctx context.Context, templateID string) ([]TaskTemplateItem, error)
+	ListTaskTemplatesByTeamID(ctx context.Context, teamID string) ([]ListTaskTemplatesByTeamIDRow, error)
 	ListTasksByTeamID(ctx context.Context, teamID string) ([]ListTasksByTeamIDRow, error)
 	ListTasksEffectiveForCloseByTeamAndType(ctx context.Context, arg ListTasksEffectiveForCloseByTeamAndTypeParams) ([]ListTasksEffectiveForCloseByTeamAndTypeRow, error)
 	ListTasksForMonthlyStatusByTeam(ctx context.Context, arg ListTasksForMonthlyStatusByTeamParams) ([]ListTasksForMonthlyStatusByTeamRow, error)
+	ListTeamAbsencesEndingFrom(ctx context.Context, arg ListTeamAbsencesEndingFromParams) ([]ListTeamAbsencesEndingFromRow, error)
+	ListTeamAbsencesOverlapping(ctx context.Context, arg ListTeamAbsencesOverlappingParams) ([]ListTeamAbsencesOverlappingRow, error)
+	ListTeamAuditEntries(ctx context.Context, arg ListTeamAuditEntriesParams) ([]ListTeamAuditEntriesRow, error)
+	ListTeamHolidaysBetween(ctx context.Context, arg ListTeamHolidaysBetweenParams) ([]ListTeamHolidaysBetweenRow, error)
 	ListTeamIDsForClose(ctx context.Context) ([]string, error)
 	ListTeamMembersByTeamID(ctx context.Context, teamID string) ([]ListTeamMembersByTeamIDRow, error)
+	ListTeamWebhookDeliveriesByWebhookID(ctx context.Context, arg ListTeamWebhookDeliveriesByWebhookIDParams) ([]TeamWebhookDelivery, error)
+	ListTeamWebhooksByTeamID(ctx context.Context, teamID string) ([]TeamWebhook, error)
 	ListTriggeredRuleIDsByMonth(ctx context.Context, arg ListTriggeredRuleIDsByMonthParams) ([]string, error)
 	ListUndeletedPenaltyRulesByTeamID(ctx context.Context, teamID string) ([]PenaltyRule, error)
 	ListUndeletedTasksByTeamID(ctx context.Context, teamID string) ([]ListUndeletedTasksByTeamIDRow, error)
+	MarkPersonalDataExportReady(ctx context.Context, arg MarkPersonalDataExportReadyParams) error
+	MarkTeamEventOutboxAttemptFailed(ctx context.Context, arg MarkTeamEventOutboxAttemptFailedParams) error
+	MarkTeamEventOutboxDispatched(ctx context.Context, arg MarkTeamEventOutboxDispatchedParams) error
+	MarkTeamWebhookDeliveryAttemptFailed(ctx context.Context, arg MarkTeamWebhookDeliveryAttemptFailedParams) error
+	MarkTeamWebhookDeliverySucceeded(ctx context.Context, arg MarkTeamWebhookDeliverySucceededParams) error
+	RecordDailyPenaltiesForClose(ctx context.Context, arg RecordDailyPenaltiesForCloseParams) ([]RecordDailyPenaltiesForCloseRow, error)
+	RecordOneOffPenaltiesForClose(ctx context.Context, arg RecordOneOffPenaltiesForCloseParams) ([]RecordOneOffPenaltiesForCloseRow, error)
+	RecordPersonalDataExportFailure(ctx context.Context, arg RecordPersonalDataExportFailureParams) error
+	RecordWeeklyPenaltiesForClose(ctx context.Context, arg RecordWeeklyPenaltiesForCloseParams) ([]RecordWeeklyPenaltiesForCloseRow, error)
+	ReleaseAdvisoryLock(ctx context.Context, key int64) (bool, error)
+	SetTaskChecklistItemPosition(ctx context.Context, arg SetTaskChecklistItemPositionParams) error
+	SetTaskCompletionDailyEvidence(ctx context.Context, arg SetTaskCompletionDailyEvidenceParams) error
+	SetTaskCompletionOneOffEvidence(ctx context.Context, arg SetTaskCompletionOneOffEvidenceParams) error
+	SetTaskCompletionWeeklyEntryEvidence(ctx context.Context, arg SetTaskCompletionWeeklyEntryEvidenceParams) error
 	SoftDeletePenaltyRule(ctx context.Context, arg SoftDeletePenaltyRuleParams) (int64, error)
-	SumDailyPenaltyForClose(ctx context.Context, arg SumDailyPenaltyForCloseParams) (int64, error)
-	SumWeeklyPenaltyForClose(ctx context.Context, arg SumWeeklyPenaltyForCloseParams) (int64, error)
+	TryAdvisoryLock(ctx context.Context, key int64) (bool, error)
 	UpdatePenaltyRule(ctx context.Context, arg UpdatePenaltyRuleParams) error
 	UpdateTask(ctx context.Context, arg UpdateTaskParams) error
+	UpdateTaskCategory(ctx context.Context, arg UpdateTaskCategoryParams) error
+	UpdateTaskChecklistItem(ctx context.Context, arg UpdateTaskChecklistItemParams) error
+	UpdateTaskCommentBody(ctx context.Context, arg UpdateTaskCommentBodyParams) error
+	UpdateTaskRevision(ctx context.Context, arg UpdateTaskRevisionParams) error
 	UpdateTeamMemberRole(ctx context.Context, arg UpdateTeamMemberRoleParams) error
 	UpdateTeamName(ctx context.Context, arg UpdateTeamNameParams) error
 	UpdateTeamStateRevisionIfMatch(ctx context.Context, arg UpdateTeamStateRevisionIfMatchParams) (int64, error)
+	UpdateTeamWebhook(ctx context.Context, arg UpdateTeamWebhookParams) error
 	UpdateUserColorHex(ctx context.Context, arg UpdateUserColorHexParams) error
 	UpdateUserDisplayName(ctx context.Context, arg UpdateUserDisplayNameParams) error
 	UpdateUserNickname(ctx context.Context, arg UpdateUserNicknameParams) error
 	UpdateUserOIDCByID(ctx context.Context, arg UpdateUserOIDCByIDParams) error
 	UpsertMonthlyPenaltySummary(ctx context.Context, arg UpsertMonthlyPenaltySummaryParams) error
+	UpsertTeamHoliday(ctx context.Context, arg UpsertTeamHolidayParams) error
 }
 
 var _ Querier = (*Queries)(nil)
@@ -0,0 +1,294 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: task_categories.sql
+
+package dbsqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const clearTaskCategoryFromTasks = `-- name: ClearTaskCategoryFromTasks :exec
+UPDATE tasks
+SET category_id = NULL,
+    revision = $1
+WHERE category_id = $2
+`
+
+type ClearTaskCategoryFromTasksParams struct {
+	Revision   int64  `json:"revision"`
+	CategoryID string `json:"category_id"`
+}
+
+func (q *Queries) ClearTaskCategoryFromTasks(ctx context.Context, arg ClearTaskCategoryFromTasksParams) error {
+	_, err := q.db.Exec(ctx, clearTaskCategoryFromTasks, arg.Revision, arg.CategoryID)
+	return err
+}
+
+const createTaskCategory = `-- name: CreateTaskCategory :exec
+INSERT INTO task_categories (id, team_id, name, color_hex, icon, created_at, updated_at, revision)
+VALUES (
+  $1,
+  $2,
+  $3,
+  $4,
+  $5,
+  $6,
+  $7,
+  $8
+)
+`
+
+type CreateTaskCategoryParams struct {
+	ID        string             `json:"id"`
+	TeamID    string             `json:"team_id"`
+	Name      string             `json:"name"`
+	ColorHex  pgtype.Text        `json:"color_hex"`
+	Icon      pgtype.Text        `json:"icon"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	Revision  int64              `json:"revision"`
+}
+
+func (q *Queries) CreateTaskCategory(ctx context.Context, arg CreateTaskCategoryParams) error {
+	_, err := q.db.Exec(ctx, createTaskCategory,
+		arg.ID,
+		arg.TeamID,
+		arg.Name,
+		arg.ColorHex,
+		arg.Icon,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+		arg.Revision,
+	)
+	return err
+}
+
+const deleteTaskCategory = `-- name: DeleteTaskCategory :execrows
+DELETE FROM task_categories
+WHERE id = $1
+  AND team_id = $2
+`
+
+type DeleteTaskCategoryParams struct {
+	ID     string `json:"id"`
+	TeamID string `json:"team_id"`
+}
+
+func (q *Queries) DeleteTaskCategory(ctx context.Context, arg DeleteTaskCategoryParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteTaskCategory, arg.ID, arg.TeamID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getTaskCategoryByID = `-- name: GetTaskCategoryByID :one
+SELECT id, team_id, name, color_hex, icon, created_at, updated_at, revision
+FROM task_categories
+WHERE id = $1
+`
+
+func (q *Queries) GetTaskCategoryByID(ctx context.Context, id string) (TaskCategory, error) {
+	row := q.db.QueryRow(ctx, getTaskCategoryByID, id)
+	var i TaskCategory
+	err := row.Scan(
+		&i.ID,
+		&i.TeamID,
+		&i.Name,
+		&i.ColorHex,
+		&i.Icon,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Revision,
+	)
+	return i, err
+}
+
+const listMonthlyCompletionsByCategory = `-- name: ListMonthlyCompletionsByCategory :many
+SELECT
+  COALESCE(c.category_id::text, ''::text) AS category_id,
+  COUNT(*)::integer AS completed_count
+FROM (
+  SELECT t.category_id
+  FROM task_completion_daily d
+  JOIN tasks t ON t.id = d.task_id
+  WHERE t.team_id = $1
+    AND d.target_date >= $2::date
+    AND d.target_date < $3::date
+  UNION ALL
+  SELECT t.category_id
+  FROM task_completion_weekly_entries w
+  JOIN tasks t ON t.id = w.task_id
+  WHERE t.team_id = $1
+    AND w.week_start + 6 >= $2::date
+    AND w.week_start + 6 < $3::date
+  UNION ALL
+  SELECT t.category_id
+  FROM task_completion_one_off o
+  JOIN tasks t ON t.id = o.task_id
+  WHERE t.team_id = $1
+    AND t.due_on >= $2::date
+    AND t.due_on < $3::date
+) c
+GROUP BY c.category_id
+`
+
+type ListMonthlyCompletionsByCategoryParams struct {
+	TeamID     string      `json:"team_id"`
+	MonthStart pgtype.Date `json:"month_start"`
+	MonthEnd   pgtype.Date `json:"month_end"`
+}
+
+type ListMonthlyCompletionsByCategoryRow struct {
+	CategoryID     interface{} `json:"category_id"`
+	CompletedCount int32       `json:"completed_count"`
+}
+
+func (q *Queries) ListMonthlyCompletionsByCategory(ctx context.Context, arg ListMonthlyCompletionsByCategoryParams) ([]ListMonthlyCompletionsByCategoryRow, error) {
+	rows, err := q.db.Query(ctx, listMonthlyCompletionsByCategory, arg.TeamID, arg.MonthStart, arg.MonthEnd)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListMonthlyCompletionsByCategoryRow
+	for rows.Next() {
+		var i ListMonthlyCompletionsByCategoryRow
+		if err := rows.Scan(&i.CategoryID, &i.CompletedCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMonthlyPenaltiesByCategory = `-- name: ListMonthlyPenaltiesByCategory :many
+SELECT
+  COALESCE(e.category_id::text, ''::text) AS category_id,
+  COALESCE((array_agg(e.category_name ORDER BY e.target_date DESC, e.created_at DESC))[1], ''::text)::text AS category_name,
+  COUNT(*)::integer AS missed_count,
+  COALESCE(SUM(e.penalty_points), 0)::integer AS penalty_total
+FROM task_evaluation_dedupes e
+WHERE e.team_id = $1
+  AND (
+    (e.scope = 'penalty_day'
+      AND e.target_date >= $2::date
+      AND e.target_date < $3::date)
+    OR (e.scope = 'penalty_week'
+      AND e.target_date + 6 >= $2::date
+      AND e.target_date + 6 < $3::date)
+  )
+GROUP BY e.category_id
+ORDER BY lower(COALESCE((array_agg(e.category_name ORDER BY e.target_date DESC, e.created_at DESC))[1], ''::text)), e.category_id
+`
+
+type ListMonthlyPenaltiesByCategoryParams struct {
+	TeamID     string      `json:"team_id"`
+	MonthStart pgtype.Date `json:"month_start"`
+	MonthEnd   pgtype.Date `json:"month_end"`
+}
+
+type ListMonthlyPenaltiesByCategoryRow struct {
+	CategoryID   interface{} `json:"category_id"`
+	CategoryName string      `json:"category_name"`
+	MissedCount  int32       `json:"missed_count"`
+	PenaltyTotal int32       `json:"penalty_total"`
+}
+
+func (q *Queries) ListMonthlyPenaltiesByCategory(ctx context.Context, arg ListMonthlyPenaltiesByCategoryParams) ([]ListMonthlyPenaltiesByCategoryRow, error) {
+	rows, err := q.db.Query(ctx, listMonthlyPenaltiesByCategory, arg.TeamID, arg.MonthStart, arg.MonthEnd)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListMonthlyPenaltiesByCategoryRow
+	for rows.Next() {
+		var i ListMonthlyPenaltiesByCategoryRow
+		if err := rows.Scan(
+			&i.CategoryID,
+			&i.CategoryName,
+			&i.MissedCount,
+			&i.PenaltyTotal,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTaskCategoriesByTeamID = `-- name: ListTaskCategoriesByTeamID :many
+SELECT id, team_id, name, color_hex, icon, created_at, updated_at, revision
+FROM task_categories
+WHERE team_id = $1
+ORDER BY lower(name), id
+`
+
+func (q *Queries) ListTaskCategoriesByTeamID(ctx context.Context, teamID string) ([]TaskCategory, error) {
+	rows, err := q.db.Query(ctx, listTaskCategoriesByTeamID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TaskCategory
+	for rows.Next() {
+		var i TaskCategory
+		if err := rows.Scan(
+			&i.ID,
+			&i.TeamID,
+			&i.Name,
+			&i.ColorHex,
+			&i.Icon,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Revision,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateTaskCategory = `-- name: UpdateTaskCategory :exec
+UPDATE task_categories
+SET name = $1,
+    color_hex = $2,
+    icon = $3,
+    updated_at = $4,
+    revision = $5
+WHERE id = $6
+`
+
+type UpdateTaskCategoryParams struct {
+	Name      string             `json:"name"`
+	ColorHex  pgtype.Text        `json:"color_hex"`
+	Icon      pgtype.Text        `json:"icon"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	Revision  int64              `json:"revision"`
+	ID        string             `json:"id"`
+}
+
+func (q *Queries) UpdateTaskCategory(ctx context.Context, arg UpdateTaskCategoryParams) error {
+	_, err := q.db.Exec(ctx, updateTaskCategory,
+		arg.Name,
+		arg.ColorHex,
+		arg.Icon,
+		arg.UpdatedAt,
+		arg.Revision,
+		arg.ID,
+	)
+	return err
+}
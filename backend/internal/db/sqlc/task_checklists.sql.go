@@ -0,0 +1,292 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: task_checklists.sql
+
+package dbsqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const countUncheckedTaskChecklistItems = `-- name: CountUncheckedTaskChecklistItems :one
+SELECT COUNT(*)::integer
+FROM task_checklist_items i
+WHERE i.task_id = $1
+  AND NOT EXISTS (
+    SELECT 1
+    FROM task_checklist_checks c
+    WHERE c.item_id = i.id AND c.period_start = $2
+  )
+`
+
+type CountUncheckedTaskChecklistItemsParams struct {
+	TaskID      string      `json:"task_id"`
+	PeriodStart pgtype.Date `json:"period_start"`
+}
+
+func (q *Queries) CountUncheckedTaskChecklistItems(ctx context.Context, arg CountUncheckedTaskChecklistItemsParams) (int32, error) {
+	row := q.db.QueryRow(ctx, countUncheckedTaskChecklistItems, arg.TaskID, arg.PeriodStart)
+	var column_1 int32
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const createTaskChecklistCheck = `-- name: CreateTaskChecklistCheck :exec
+INSERT INTO task_checklist_checks (item_id, period_start, checked_by_user_id, checked_at)
+VALUES (
+  $1,
+  $2,
+  NULLIF($3, '')::uuid,
+  $4
+)
+`
+
+type CreateTaskChecklistCheckParams struct {
+	ItemID          string             `json:"item_id"`
+	PeriodStart     pgtype.Date        `json:"period_start"`
+	CheckedByUserID interface{}        `json:"checked_by_user_id"`
+	CheckedAt       pgtype.Timestamptz `json:"checked_at"`
+}
+
+func (q *Queries) CreateTaskChecklistCheck(ctx context.Context, arg CreateTaskChecklistCheckParams) error {
+	_, err := q.db.Exec(ctx, createTaskChecklistCheck,
+		arg.ItemID,
+		arg.PeriodStart,
+		arg.CheckedByUserID,
+		arg.CheckedAt,
+	)
+	return err
+}
+
+const createTaskChecklistItem = `-- name: CreateTaskChecklistItem :exec
+INSERT INTO task_checklist_items (id, task_id, title, position, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+type CreateTaskChecklistItemParams struct {
+	ID        string             `json:"id"`
+	TaskID    string             `json:"task_id"`
+	Title     string             `json:"title"`
+	Position  int32              `json:"position"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+func (q *Queries) CreateTaskChecklistItem(ctx context.Context, arg CreateTaskChecklistItemParams) error {
+	_, err := q.db.Exec(ctx, createTaskChecklistItem,
+		arg.ID,
+		arg.TaskID,
+		arg.Title,
+		arg.Position,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
+const deleteTaskChecklistCheck = `-- name: DeleteTaskChecklistCheck :exec
+DELETE FROM task_checklist_checks
+WHERE item_id = $1 AND period_start = $2
+`
+
+type DeleteTaskChecklistCheckParams struct {
+	ItemID      string      `json:"item_id"`
+	PeriodStart pgtype.Date `json:"period_start"`
+}
+
+func (q *Queries) DeleteTaskChecklistCheck(ctx context.Context, arg DeleteTaskChecklistCheckParams) error {
+	_, err := q.db.Exec(ctx, deleteTaskChecklistCheck, arg.ItemID, arg.PeriodStart)
+	return err
+}
+
+const deleteTaskChecklistItem = `-- name: DeleteTaskChecklistItem :exec
+DELETE FROM task_checklist_items
+WHERE id = $1
+`
+
+func (q *Queries) DeleteTaskChecklistItem(ctx context.Context, id string) error {
+	_, err := q.db.Exec(ctx, deleteTaskChecklistItem, id)
+	return err
+}
+
+const getTaskChecklistItemByID = `-- name: GetTaskChecklistItemByID :one
+SELECT id, task_id, title, position, created_at, updated_at
+FROM task_checklist_items
+WHERE id = $1
+`
+
+func (q *Queries) GetTaskChecklistItemByID(ctx context.Context, id string) (TaskChecklistItem, error) {
+	row := q.db.QueryRow(ctx, getTaskChecklistItemByID, id)
+	var i TaskChecklistItem
+	err := row.Scan(
+		&i.ID,
+		&i.TaskID,
+		&i.Title,
+		&i.Position,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const hasTaskChecklistCheck = `-- name: HasTaskChecklistCheck :one
+SELECT EXISTS (
+  SELECT 1
+  FROM task_checklist_checks
+  WHERE item_id = $1 AND period_start = $2
+)
+`
+
+type HasTaskChecklistCheckParams struct {
+	ItemID      string      `json:"item_id"`
+	PeriodStart pgtype.Date `json:"period_start"`
+}
+
+func (q *Queries) HasTaskChecklistCheck(ctx context.Context, arg HasTaskChecklistCheckParams) (bool, error) {
+	row := q.db.QueryRow(ctx, hasTaskChecklistCheck, arg.ItemID, arg.PeriodStart)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const listTaskChecklistItemsByTaskID = `-- name: ListTaskChecklistItemsByTaskID :many
+SELECT id, task_id, title, position, created_at, updated_at
+FROM task_checklist_items
+WHERE task_id = $1
+ORDER BY position, created_at, id
+`
+
+func (q *Queries) ListTaskChecklistItemsByTaskID(ctx context.Context, taskID string) ([]TaskChecklistItem, error) {
+	rows, err := q.db.Query(ctx, listTaskChecklistItemsByTaskID, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TaskChecklistItem
+	for rows.Next() {
+		var i TaskChecklistItem
+		if err := rows.Scan(
+			&i.ID,
+			&i.TaskID,
+			&i.Title,
+			&i.Position,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTaskChecklistStatesByTeam = `-- name: ListTaskChecklistStatesByTeam :many
+SELECT
+  i.id,
+  i.task_id,
+  i.title,
+  i.position,
+  (c.item_id IS NOT NULL)::boolean AS checked,
+  COALESCE(c.checked_by_user_id::text, ''::text) AS checked_by_user_id,
+  COALESCE(NULLIF(u.nickname, ''), u.display_name, ''::text) AS checked_by_effective_name,
+  u.color_hex AS checked_by_color_hex
+FROM task_checklist_items i
+JOIN tasks t ON t.id = i.task_id
+LEFT JOIN task_checklist_checks c
+  ON c.item_id = i.id
+  AND (
+    (t.type = 'daily' AND c.period_start = $1::date)
+    OR (t.type = 'weekly' AND c.period_start = $2::date)
+    OR t.type = 'one_off'
+  )
+LEFT JOIN users u ON u.id = c.checked_by_user_id
+WHERE t.team_id = $3
+  AND t.deleted_at IS NULL
+ORDER BY i.task_id, i.position, i.created_at, i.id
+`
+
+type ListTaskChecklistStatesByTeamParams struct {
+	Today     pgtype.Date `json:"today"`
+	WeekStart pgtype.Date `json:"week_start"`
+	TeamID    string      `json:"team_id"`
+}
+
+type ListTaskChecklistStatesByTeamRow struct {
+	ID                     string      `json:"id"`
+	TaskID                 string      `json:"task_id"`
+	Title                  string      `json:"title"`
+	Position               int32       `json:"position"`
+	Checked                bool        `json:"checked"`
+	CheckedByUserID        interface{} `json:"checked_by_user_id"`
+	CheckedByEffectiveName string      `json:"checked_by_effective_name"`
+	CheckedByColorHex      pgtype.Text `json:"checked_by_color_hex"`
+}
+
+func (q *Queries) ListTaskChecklistStatesByTeam(ctx context.Context, arg ListTaskChecklistStatesByTeamParams) ([]ListTaskChecklistStatesByTeamRow, error) {
+	rows, err := q.db.Query(ctx, listTaskChecklistStatesByTeam, arg.Today, arg.WeekStart, arg.TeamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTaskChecklistStatesByTeamRow
+	for rows.Next() {
+		var i ListTaskChecklistStatesByTeamRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.TaskID,
+			&i.Title,
+			&i.Position,
+			&i.Checked,
+			&i.CheckedByUserID,
+			&i.CheckedByEffectiveName,
+			&i.CheckedByColorHex,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const setTaskChecklistItemPosition = `-- name: SetTaskChecklistItemPosition :exec
+UPDATE task_checklist_items
+SET position = $2
+WHERE id = $1
+`
+
+type SetTaskChecklistItemPositionParams struct {
+	ID       string `json:"id"`
+	Position int32  `json:"position"`
+}
+
+func (q *Queries) SetTaskChecklistItemPosition(ctx context.Context, arg SetTaskChecklistItemPositionParams) error {
+	_, err := q.db.Exec(ctx, setTaskChecklistItemPosition, arg.ID, arg.Position)
+	return err
+}
+
+const updateTaskChecklistItem = `-- name: UpdateTaskChecklistItem :exec
+UPDATE task_checklist_items
+SET title = $2,
+    updated_at = $3
+WHERE id = $1
+`
+
+type UpdateTaskChecklistItemParams struct {
+	ID        string             `json:"id"`
+	Title     string             `json:"title"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+func (q *Queries) UpdateTaskChecklistItem(ctx context.Context, arg UpdateTaskChecklistItemParams) error {
+	_, err := q.db.Exec(ctx, updateTaskChecklistItem, arg.ID, arg.Title, arg.UpdatedAt)
+	return err
+}
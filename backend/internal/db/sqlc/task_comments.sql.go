@@ -0,0 +1,277 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: task_comments.sql
+
+package dbsqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createTaskComment = `-- name: CreateTaskComment :exec
+INSERT INTO task_comments (id, task_id, author_user_id, body, created_at, revision)
+VALUES (
+  $1,
+  $2,
+  NULLIF($3::text, '')::uuid,
+  $4,
+  $5,
+  $6
+)
+`
+
+type CreateTaskCommentParams struct {
+	ID           string             `json:"id"`
+	TaskID       string             `json:"task_id"`
+	AuthorUserID string             `json:"author_user_id"`
+	Body         string             `json:"body"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	Revision     int64              `json:"revision"`
+}
+
+func (q *Queries) CreateTaskComment(ctx context.Context, arg CreateTaskCommentParams) error {
+	_, err := q.db.Exec(ctx, createTaskComment,
+		arg.ID,
+		arg.TaskID,
+		arg.AuthorUserID,
+		arg.Body,
+		arg.CreatedAt,
+		arg.Revision,
+	)
+	return err
+}
+
+const deleteTaskComment = `-- name: DeleteTaskComment :exec
+DELETE FROM task_comments
+WHERE id = $1
+`
+
+func (q *Queries) DeleteTaskComment(ctx context.Context, id string) error {
+	_, err := q.db.Exec(ctx, deleteTaskComment, id)
+	return err
+}
+
+const getTaskCommentByID = `-- name: GetTaskCommentByID :one
+SELECT
+  c.id,
+  c.task_id,
+  c.body,
+  c.created_at,
+  c.edited_at,
+  c.revision,
+  COALESCE(c.author_user_id::text, ''::text) AS author_user_id,
+  COALESCE(NULLIF(u.nickname, ''), u.display_name, ''::text) AS author_effective_name,
+  u.color_hex AS author_color_hex
+FROM task_comments c
+LEFT JOIN users u ON u.id = c.author_user_id
+WHERE c.id = $1
+`
+
+type GetTaskCommentByIDRow struct {
+	ID                  string             `json:"id"`
+	TaskID              string             `json:"task_id"`
+	Body                string             `json:"body"`
+	CreatedAt           pgtype.Timestamptz `json:"created_at"`
+	EditedAt            pgtype.Timestamptz `json:"edited_at"`
+	Revision            int64              `json:"revision"`
+	AuthorUserID        interface{}        `json:"author_user_id"`
+	AuthorEffectiveName string             `json:"author_effective_name"`
+	AuthorColorHex      pgtype.Text        `json:"author_color_hex"`
+}
+
+func (q *Queries) GetTaskCommentByID(ctx context.Context, id string) (GetTaskCommentByIDRow, error) {
+	row := q.db.QueryRow(ctx, getTaskCommentByID, id)
+	var i GetTaskCommentByIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.TaskID,
+		&i.Body,
+		&i.CreatedAt,
+		&i.EditedAt,
+		&i.Revision,
+		&i.AuthorUserID,
+		&i.AuthorEffectiveName,
+		&i.AuthorColorHex,
+	)
+	return i, err
+}
+
+const getTaskCommentForUpdate = `-- name: GetTaskCommentForUpdate :one
+SELECT
+  id,
+  task_id,
+  COALESCE(author_user_id::text, ''::text) AS author_user_id,
+  revision
+FROM task_comments
+WHERE id = $1
+FOR UPDATE
+`
+
+type GetTaskCommentForUpdateRow struct {
+	ID           string      `json:"id"`
+	TaskID       string      `json:"task_id"`
+	AuthorUserID interface{} `json:"author_user_id"`
+	Revision     int64       `json:"revision"`
+}
+
+func (q *Queries) GetTaskCommentForUpdate(ctx context.Context, id string) (GetTaskCommentForUpdateRow, error) {
+	row := q.db.QueryRow(ctx, getTaskCommentForUpdate, id)
+	var i GetTaskCommentForUpdateRow
+	err := row.Scan(
+		&i.ID,
+		&i.TaskID,
+		&i.AuthorUserID,
+		&i.Revision,
+	)
+	return i, err
+}
+
+const listPersonalTaskComments = `-- name: ListPersonalTaskComments :many
+SELECT c.id, c.task_id, t.title AS task_title, t.team_id, c.body, c.created_at, c.edited_at
+FROM task_comments c
+JOIN tasks t ON t.id = c.task_id
+WHERE c.author_user_id = $1
+ORDER BY c.created_at, c.id
+`
+
+type ListPersonalTaskCommentsRow struct {
+	ID        string             `json:"id"`
+	TaskID    string             `json:"task_id"`
+	TaskTitle string             `json:"task_title"`
+	TeamID    string             `json:"team_id"`
+	Body      string             `json:"body"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	EditedAt  pgtype.Timestamptz `json:"edited_at"`
+}
+
+func (q *Queries) ListPersonalTaskComments(ctx context.Context, authorUserID string) ([]ListPersonalTaskCommentsRow, error) {
+	rows, err := q.db.Query(ctx, listPersonalTaskComments, authorUserID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListPersonalTaskCommentsRow
+	for rows.Next() {
+		var i ListPersonalTaskCommentsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.TaskID,
+			&i.TaskTitle,
+			&i.TeamID,
+			&i.Body,
+			&i.CreatedAt,
+			&i.EditedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTaskComments = `-- name: ListTaskComments :many
+SELECT
+  c.id,
+  c.task_id,
+  c.body,
+  c.created_at,
+  c.edited_at,
+  c.revision,
+  COALESCE(c.author_user_id::text, ''::text) AS author_user_id,
+  COALESCE(NULLIF(u.nickname, ''), u.display_name, ''::text) AS author_effective_name,
+  u.color_hex AS author_color_hex
+FROM task_comments c
+LEFT JOIN users u ON u.id = c.author_user_id
+WHERE c.task_id = $1
+  AND (
+    $2::timestamptz IS NULL
+    OR (c.created_at, c.id) < ($2::timestamptz, NULLIF($3::text, '')::uuid)
+  )
+ORDER BY c.created_at DESC, c.id DESC
+LIMIT $4
+`
+
+type ListTaskCommentsParams struct {
+	TaskID          string             `json:"task_id"`
+	BeforeCreatedAt pgtype.Timestamptz `json:"before_created_at"`
+	BeforeID        string             `json:"before_id"`
+	RowLimit        int32              `json:"row_limit"`
+}
+
+type ListTaskCommentsRow struct {
+	ID                  string             `json:"id"`
+	TaskID              string             `json:"task_id"`
+	Body                string             `json:"body"`
+	CreatedAt           pgtype.Timestamptz `json:"created_at"`
+	EditedAt            pgtype.Timestamptz `json:"edited_at"`
+	Revision            int64              `json:"revision"`
+	AuthorUserID        interface{}        `json:"author_user_id"`
+	AuthorEffectiveName string             `json:"author_effective_name"`
+	AuthorColorHex      pgtype.Text        `json:"author_color_hex"`
+}
+
+func (q *Queries) ListTaskComments(ctx context.Context, arg ListTaskCommentsParams) ([]ListTaskCommentsRow, error) {
+	rows, err := q.db.Query(ctx, listTaskComments,
+		arg.TaskID,
+		arg.BeforeCreatedAt,
+		arg.BeforeID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTaskCommentsRow
+	for rows.Next() {
+		var i ListTaskCommentsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.TaskID,
+			&i.Body,
+			&i.CreatedAt,
+			&i.EditedAt,
+			&i.Revision,
+			&i.AuthorUserID,
+			&i.AuthorEffectiveName,
+			&i.AuthorColorHex,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const updateTaskCommentBody = `-- name: UpdateTaskCommentBody :exec
+UPDATE task_comments
+SET body = $1,
+    edited_at = $2,
+    revision = $3
+WHERE id = $4
+`
+
+type UpdateTaskCommentBodyParams struct {
+	Body     string             `json:"body"`
+	EditedAt pgtype.Timestamptz `json:"edited_at"`
+	Revision int64              `json:"revision"`
+	ID       string             `json:"id"`
+}
+
+func (q *Queries) UpdateTaskCommentBody(ctx context.Context, arg UpdateTaskCommentBodyParams) error {
+	_, err := q.db.Exec(ctx, updateTaskCommentBody,
+		arg.Body,
+		arg.EditedAt,
+		arg.Revision,
+		arg.ID,
+	)
+	return err
+}
@@ -28,6 +28,23 @@ func (q *Queries) CreateTaskCompletionDaily(ctx context.Context, arg CreateTaskC
 	return err
 }
 
+const createTaskCompletionOneOff = `-- name: CreateTaskCompletionOneOff :exec
+INSERT INTO task_completion_one_off (task_id, completed_by_user_id, completed_at)
+VALUES ($1, NULLIF($2, '')::uuid, $3)
+ON CONFLICT (task_id) DO NOTHING
+`
+
+type CreateTaskCompletionOneOffParams struct {
+	TaskID            string             `json:"task_id"`
+	CompletedByUserID interface{}        `json:"completed_by_user_id"`
+	CompletedAt       pgtype.Timestamptz `json:"completed_at"`
+}
+
+func (q *Queries) CreateTaskCompletionOneOff(ctx context.Context, arg CreateTaskCompletionOneOffParams) error {
+	_, err := q.db.Exec(ctx, createTaskCompletionOneOff, arg.TaskID, arg.CompletedByUserID, arg.CompletedAt)
+	return err
+}
+
 const deleteLatestTaskCompletionWeeklyEntry = `-- name: DeleteLatestTaskCompletionWeeklyEntry :execrows
 WITH latest AS (
   SELECT id
@@ -80,6 +97,16 @@ func (q *Queries) DeleteTaskCompletionDailyByTaskID(ctx context.Context, taskID
 	return err
 }
 
+const deleteTaskCompletionOneOff = `-- name: DeleteTaskCompletionOneOff :exec
+DELETE FROM task_completion_one_off
+WHERE task_id = $1
+`
+
+func (q *Queries) DeleteTaskCompletionOneOff(ctx context.Context, taskID string) error {
+	_, err := q.db.Exec(ctx, deleteTaskCompletionOneOff, taskID)
+	return err
+}
+
 const deleteTaskCompletionWeeklyEntriesByTaskID = `-- name: DeleteTaskCompletionWeeklyEntriesByTaskID :exec
 DELETE FROM task_completion_weekly_entries
 WHERE task_id = $1
@@ -90,6 +117,28 @@ func (q *Queries) DeleteTaskCompletionWeeklyEntriesByTaskID(ctx context.Context,
 	return err
 }
 
+const getTaskCompletionOneOff = `-- name: GetTaskCompletionOneOff :one
+SELECT
+  task_id,
+  COALESCE(completed_by_user_id::text, ''::text) AS completed_by_user_id,
+  completed_at
+FROM task_completion_one_off
+WHERE task_id = $1
+`
+
+type GetTaskCompletionOneOffRow struct {
+	TaskID            string             `json:"task_id"`
+	CompletedByUserID interface{}        `json:"completed_by_user_id"`
+	CompletedAt       pgtype.Timestamptz `json:"completed_at"`
+}
+
+func (q *Queries) GetTaskCompletionOneOff(ctx context.Context, taskID string) (GetTaskCompletionOneOffRow, error) {
+	row := q.db.QueryRow(ctx, getTaskCompletionOneOff, taskID)
+	var i GetTaskCompletionOneOffRow
+	err := row.Scan(&i.TaskID, &i.CompletedByUserID, &i.CompletedAt)
+	return i, err
+}
+
 const getTaskCompletionWeeklyEntryCount = `-- name: GetTaskCompletionWeeklyEntryCount :one
 SELECT COALESCE((
   SELECT COUNT(*)::integer
@@ -260,6 +309,55 @@ func (q *Queries) ListTaskCompletionDailyByTeamAndDate(ctx context.Context, arg
 	return items, nil
 }
 
+const listTaskCompletionOneOffByTeam = `-- name: ListTaskCompletionOneOffByTeam :many
+SELECT
+  o.task_id,
+  COALESCE(o.completed_by_user_id::text, ''::text) AS completed_by_user_id,
+  COALESCE(NULLIF(u.nickname, ''), u.display_name, ''::text) AS completed_by_effective_name,
+  u.color_hex AS completed_by_color_hex,
+  o.completed_at
+FROM task_completion_one_off o
+JOIN tasks t ON t.id = o.task_id
+LEFT JOIN users u ON u.id = o.completed_by_user_id
+WHERE t.team_id = $1
+  AND t.type = 'one_off'
+ORDER BY o.task_id
+`
+
+type ListTaskCompletionOneOffByTeamRow struct {
+	TaskID                   string             `json:"task_id"`
+	CompletedByUserID        interface{}        `json:"completed_by_user_id"`
+	CompletedByEffectiveName string             `json:"completed_by_effective_name"`
+	CompletedByColorHex      pgtype.Text        `json:"completed_by_color_hex"`
+	CompletedAt              pgtype.Timestamptz `json:"completed_at"`
+}
+
+func (q *Queries) ListTaskCompletionOneOffByTeam(ctx context.Context, teamID string) ([]ListTaskCompletionOneOffByTeamRow, error) {
+	rows, err := q.db.Query(ctx, listTaskCompletionOneOffByTeam, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTaskCompletionOneOffByTeamRow
+	for rows.Next() {
+		var i ListTaskCompletionOneOffByTeamRow
+		if err := rows.Scan(
+			&i.TaskID,
+			&i.CompletedByUserID,
+			&i.CompletedByEffectiveName,
+			&i.CompletedByColorHex,
+			&i.CompletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listTaskCompletionWeeklyByMonthAndTeam = `-- name: ListTaskCompletionWeeklyByMonthAndTeam :many
 SELECT e.task_id, e.week_start, COUNT(*)::integer AS completion_count
 FROM task_completion_weekly_entries e
@@ -37,10 +37,11 @@ func (q *Queries) InsertTaskEvaluationDedupe(ctx context.Context, arg InsertTask
 	return result.RowsAffected(), nil
 }
 
-const sumDailyPenaltyForClose = `-- name: SumDailyPenaltyForClose :one
+const recordDailyPenaltiesForClose = `-- name: RecordDailyPenaltiesForClose :many
 WITH candidates AS (
-  SELECT t.id AS task_id, t.penalty_points
+  SELECT t.id AS task_id, t.title, t.penalty_points, t.category_id, cat.name AS category_name
   FROM tasks t
+  LEFT JOIN task_categories cat ON cat.id = t.category_id
   LEFT JOIN task_completion_daily d
     ON d.task_id = t.id
    AND d.target_date = $2
@@ -48,37 +49,146 @@ WITH candidates AS (
     AND t.type = 'daily'
     AND t.created_at < $3
     AND (t.deleted_at IS NULL OR t.deleted_at >= $3)
+    AND (t.starts_on IS NULL OR t.starts_on <= $2)
+    AND (t.ends_on IS NULL OR t.ends_on >= $2)
     AND d.task_id IS NULL
+    AND NOT EXISTS (
+      SELECT 1
+      FROM team_absences a
+      WHERE a.team_id = t.team_id
+        AND (a.user_id IS NULL OR a.user_id = t.assignee_user_id)
+        AND $2 BETWEEN a.starts_on AND a.ends_on
+    )
+    AND NOT EXISTS (
+      SELECT 1
+      FROM team_holidays h
+      WHERE h.team_id = t.team_id
+        AND h.holiday_date = $2
+    )
 ),
 deduped AS (
-  INSERT INTO task_evaluation_dedupes (team_id, scope, target_date, task_id, created_at)
-  SELECT $1, 'penalty_day', $2, c.task_id, NOW()
+  INSERT INTO task_evaluation_dedupes (team_id, scope, target_date, task_id, penalty_points, category_id, category_name, created_at)
+  SELECT $1, 'penalty_day', $2, c.task_id, c.penalty_points, c.category_id, c.category_name, NOW()
   FROM candidates c
   ON CONFLICT (team_id, scope, target_date, task_id) DO NOTHING
   RETURNING task_id
 )
-SELECT COALESCE(SUM(c.penalty_points), 0)::bigint AS total_penalty
+SELECT c.task_id, c.title, c.penalty_points
 FROM candidates c
 JOIN deduped d ON d.task_id = c.task_id
+ORDER BY c.title, c.task_id
 `
 
-type SumDailyPenaltyForCloseParams struct {
+type RecordDailyPenaltiesForCloseParams struct {
 	TeamID     string             `json:"team_id"`
 	TargetDate pgtype.Date        `json:"target_date"`
 	CreatedAt  pgtype.Timestamptz `json:"created_at"`
 }
 
-func (q *Queries) SumDailyPenaltyForClose(ctx context.Context, arg SumDailyPenaltyForCloseParams) (int64, error) {
-	row := q.db.QueryRow(ctx, sumDailyPenaltyForClose, arg.TeamID, arg.TargetDate, arg.CreatedAt)
-	var total_penalty int64
-	err := row.Scan(&total_penalty)
-	return total_penalty, err
+type RecordDailyPenaltiesForCloseRow struct {
+	TaskID        string `json:"task_id"`
+	Title         string `json:"title"`
+	PenaltyPoints int32  `json:"penalty_points"`
 }
 
-const sumWeeklyPenaltyForClose = `-- name: SumWeeklyPenaltyForClose :one
+func (q *Queries) RecordDailyPenaltiesForClose(ctx context.Context, arg RecordDailyPenaltiesForCloseParams) ([]RecordDailyPenaltiesForCloseRow, error) {
+	rows, err := q.db.Query(ctx, recordDailyPenaltiesForClose, arg.TeamID, arg.TargetDate, arg.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RecordDailyPenaltiesForCloseRow
+	for rows.Next() {
+		var i RecordDailyPenaltiesForCloseRow
+		if err := rows.Scan(&i.TaskID, &i.Title, &i.PenaltyPoints); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const recordOneOffPenaltiesForClose = `-- name: RecordOneOffPenaltiesForClose :many
+WITH candidates AS (
+  SELECT t.id AS task_id, t.title, t.penalty_points, t.category_id, cat.name AS category_name
+  FROM tasks t
+  LEFT JOIN task_categories cat ON cat.id = t.category_id
+  LEFT JOIN task_completion_one_off o
+    ON o.task_id = t.id
+   AND o.completed_at < $1::timestamptz
+  WHERE t.team_id = $2::uuid
+    AND t.type = 'one_off'
+    AND t.due_on = $3::date
+    AND t.created_at < $1::timestamptz
+    AND (t.deleted_at IS NULL OR t.deleted_at >= $1::timestamptz)
+    AND o.task_id IS NULL
+    AND NOT EXISTS (
+      SELECT 1
+      FROM team_absences a
+      WHERE a.team_id = t.team_id
+        AND (a.user_id IS NULL OR a.user_id = t.assignee_user_id)
+        AND $3::date BETWEEN a.starts_on AND a.ends_on
+    )
+    AND NOT EXISTS (
+      SELECT 1
+      FROM team_holidays h
+      WHERE h.team_id = t.team_id
+        AND h.holiday_date = $3::date
+    )
+),
+deduped AS (
+  INSERT INTO task_evaluation_dedupes (team_id, scope, target_date, task_id, penalty_points, category_id, category_name, created_at)
+  SELECT $2::uuid, 'penalty_day', $3::date, c.task_id, c.penalty_points, c.category_id, c.category_name, NOW()
+  FROM candidates c
+  ON CONFLICT (team_id, scope, target_date, task_id) DO NOTHING
+  RETURNING task_id
+)
+SELECT c.task_id, c.title, c.penalty_points
+FROM candidates c
+JOIN deduped d ON d.task_id = c.task_id
+ORDER BY c.title, c.task_id
+`
+
+type RecordOneOffPenaltiesForCloseParams struct {
+	Cutoff     pgtype.Timestamptz `json:"cutoff"`
+	TeamID     string             `json:"team_id"`
+	TargetDate pgtype.Date        `json:"target_date"`
+}
+
+type RecordOneOffPenaltiesForCloseRow struct {
+	TaskID        string `json:"task_id"`
+	Title         string `json:"title"`
+	PenaltyPoints int32  `json:"penalty_points"`
+}
+
+func (q *Queries) RecordOneOffPenaltiesForClose(ctx context.Context, arg RecordOneOffPenaltiesForCloseParams) ([]RecordOneOffPenaltiesForCloseRow, error) {
+	rows, err := q.db.Query(ctx, recordOneOffPenaltiesForClose, arg.Cutoff, arg.TeamID, arg.TargetDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RecordOneOffPenaltiesForCloseRow
+	for rows.Next() {
+		var i RecordOneOffPenaltiesForCloseRow
+		if err := rows.Scan(&i.TaskID, &i.Title, &i.PenaltyPoints); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const recordWeeklyPenaltiesForClose = `-- name: RecordWeeklyPenaltiesForClose :many
 WITH candidates AS (
-  SELECT t.id AS task_id, t.penalty_points
+  SELECT t.id AS task_id, t.title, t.penalty_points, t.category_id, cat.name AS category_name
   FROM tasks t
+  LEFT JOIN task_categories cat ON cat.id = t.category_id
   LEFT JOIN (
     SELECT task_id, week_start, COUNT(*)::integer AS completion_count
     FROM task_completion_weekly_entries
@@ -87,33 +197,72 @@ WITH candidates AS (
   ) w
     ON w.task_id = t.id
    AND w.week_start = $2
+  CROSS JOIN LATERAL (
+    SELECT COUNT(*)::integer AS paused_days
+    FROM generate_series(0, 6) AS g(day_offset)
+    WHERE EXISTS (
+      SELECT 1
+      FROM team_absences a
+      WHERE a.team_id = t.team_id
+        AND (a.user_id IS NULL OR a.user_id = t.assignee_user_id)
+        AND $2::date + g.day_offset BETWEEN a.starts_on AND a.ends_on
+    ) OR EXISTS (
+      SELECT 1
+      FROM team_holidays h
+      WHERE h.team_id = t.team_id
+        AND h.holiday_date = $2::date + g.day_offset
+    ) OR $2::date + g.day_offset < t.starts_on
+      OR $2::date + g.day_offset > t.ends_on
+  ) p
   WHERE t.team_id = $1
     AND t.type = 'weekly'
     AND t.created_at < $3
     AND (t.deleted_at IS NULL OR t.deleted_at >= $3)
-    AND COALESCE(w.completion_count, 0) < t.required_completions_per_week
+    AND (t.starts_on IS NULL OR t.starts_on <= $2::date + 6)
+    AND (t.ends_on IS NULL OR t.ends_on >= $2)
+    AND COALESCE(w.completion_count, 0) < (t.required_completions_per_week * (7 - p.paused_days) + 6) / 7
 ),
 deduped AS (
-  INSERT INTO task_evaluation_dedupes (team_id, scope, target_date, task_id, created_at)
-  SELECT $1, 'penalty_week', $2, c.task_id, NOW()
+  INSERT INTO task_evaluation_dedupes (team_id, scope, target_date, task_id, penalty_points, category_id, category_name, created_at)
+  SELECT $1, 'penalty_week', $2, c.task_id, c.penalty_points, c.category_id, c.category_name, NOW()
   FROM candidates c
   ON CONFLICT (team_id, scope, target_date, task_id) DO NOTHING
   RETURNING task_id
 )
-SELECT COALESCE(SUM(c.penalty_points), 0)::bigint AS total_penalty
+SELECT c.task_id, c.title, c.penalty_points
 FROM candidates c
 JOIN deduped d ON d.task_id = c.task_id
+ORDER BY c.title, c.task_id
 `
 
-type SumWeeklyPenaltyForCloseParams struct {
+type RecordWeeklyPenaltiesForCloseParams struct {
 	TeamID    string             `json:"team_id"`
 	WeekStart pgtype.Date        `json:"week_start"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
 }
 
-func (q *Queries) SumWeeklyPenaltyForClose(ctx context.Context, arg SumWeeklyPenaltyForCloseParams) (int64, error) {
-	row := q.db.QueryRow(ctx, sumWeeklyPenaltyForClose, arg.TeamID, arg.WeekStart, arg.CreatedAt)
-	var total_penalty int64
-	err := row.Scan(&total_penalty)
-	return total_penalty, err
+type RecordWeeklyPenaltiesForCloseRow struct {
+	TaskID        string `json:"task_id"`
+	Title         string `json:"title"`
+	PenaltyPoints int32  `json:"penalty_points"`
+}
+
+func (q *Queries) RecordWeeklyPenaltiesForClose(ctx context.Context, arg RecordWeeklyPenaltiesForCloseParams) ([]RecordWeeklyPenaltiesForCloseRow, error) {
+	rows, err := q.db.Query(ctx, recordWeeklyPenaltiesForClose, arg.TeamID, arg.WeekStart, arg.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []RecordWeeklyPenaltiesForCloseRow
+	for rows.Next() {
+		var i RecordWeeklyPenaltiesForCloseRow
+		if err := rows.Scan(&i.TaskID, &i.Title, &i.PenaltyPoints); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
@@ -0,0 +1,285 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: task_templates.sql
+
+package dbsqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const countTaskTemplatesByTeamID = `-- name: CountTaskTemplatesByTeamID :one
+SELECT COUNT(*)::integer
+FROM task_templates
+WHERE team_id = $1
+`
+
+func (q *Queries) CountTaskTemplatesByTeamID(ctx context.Context, teamID string) (int32, error) {
+	row := q.db.QueryRow(ctx, countTaskTemplatesByTeamID, teamID)
+	var column_1 int32
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
+const createTaskTemplate = `-- name: CreateTaskTemplate :exec
+INSERT INTO task_templates (id, team_id, name, description, created_by_user_id, created_at, updated_at)
+VALUES (
+  $1,
+  $2,
+  $3,
+  $4,
+  NULLIF($5::text, '')::uuid,
+  $6,
+  $7
+)
+`
+
+type CreateTaskTemplateParams struct {
+	ID              string             `json:"id"`
+	TeamID          string             `json:"team_id"`
+	Name            string             `json:"name"`
+	Description     string             `json:"description"`
+	CreatedByUserID string             `json:"created_by_user_id"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+}
+
+func (q *Queries) CreateTaskTemplate(ctx context.Context, arg CreateTaskTemplateParams) error {
+	_, err := q.db.Exec(ctx, createTaskTemplate,
+		arg.ID,
+		arg.TeamID,
+		arg.Name,
+		arg.Description,
+		arg.CreatedByUserID,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
+const createTaskTemplateItem = `-- name: CreateTaskTemplateItem :exec
+INSERT INTO task_template_items (
+  id, template_id, position, title, notes, type, penalty_points,
+  required_completions_per_week, due_in_days, checklist
+)
+VALUES (
+  $1,
+  $2,
+  $3,
+  $4,
+  $5,
+  $6,
+  $7,
+  $8,
+  $9,
+  $10::text[]
+)
+`
+
+type CreateTaskTemplateItemParams struct {
+	ID                         string      `json:"id"`
+	TemplateID                 string      `json:"template_id"`
+	Position                   int32       `json:"position"`
+	Title                      string      `json:"title"`
+	Notes                      string      `json:"notes"`
+	Type                       string      `json:"type"`
+	PenaltyPoints              int32       `json:"penalty_points"`
+	RequiredCompletionsPerWeek int32       `json:"required_completions_per_week"`
+	DueInDays                  pgtype.Int4 `json:"due_in_days"`
+	Checklist                  []string    `json:"checklist"`
+}
+
+func (q *Queries) CreateTaskTemplateItem(ctx context.Context, arg CreateTaskTemplateItemParams) error {
+	_, err := q.db.Exec(ctx, createTaskTemplateItem,
+		arg.ID,
+		arg.TemplateID,
+		arg.Position,
+		arg.Title,
+		arg.Notes,
+		arg.Type,
+		arg.PenaltyPoints,
+		arg.RequiredCompletionsPerWeek,
+		arg.DueInDays,
+		arg.Checklist,
+	)
+	return err
+}
+
+const deleteTaskTemplate = `-- name: DeleteTaskTemplate :execrows
+DELETE FROM task_templates
+WHERE id = $1
+  AND team_id = $2
+`
+
+type DeleteTaskTemplateParams struct {
+	ID     string `json:"id"`
+	TeamID string `json:"team_id"`
+}
+
+func (q *Queries) DeleteTaskTemplate(ctx context.Context, arg DeleteTaskTemplateParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteTaskTemplate, arg.ID, arg.TeamID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getTaskTemplateByID = `-- name: GetTaskTemplateByID :one
+SELECT id, team_id, name, description, created_at, updated_at
+FROM task_templates
+WHERE id = $1
+  AND team_id = $2
+`
+
+type GetTaskTemplateByIDParams struct {
+	ID     string `json:"id"`
+	TeamID string `json:"team_id"`
+}
+
+type GetTaskTemplateByIDRow struct {
+	ID          string             `json:"id"`
+	TeamID      string             `json:"team_id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+}
+
+func (q *Queries) GetTaskTemplateByID(ctx context.Context, arg GetTaskTemplateByIDParams) (GetTaskTemplateByIDRow, error) {
+	row := q.db.QueryRow(ctx, getTaskTemplateByID, arg.ID, arg.TeamID)
+	var i GetTaskTemplateByIDRow
+	err := row.Scan(
+		&i.ID,
+		&i.TeamID,
+		&i.Name,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listTaskTemplateItemsByTeamID = `-- name: ListTaskTemplateItemsByTeamID :many
+SELECT i.id, i.template_id, i.position, i.title, i.notes, i.type, i.penalty_points,
+  i.required_completions_per_week, i.due_in_days, i.checklist
+FROM task_template_items i
+JOIN task_templates t ON t.id = i.template_id
+WHERE t.team_id = $1
+ORDER BY i.template_id, i.position, i.id
+`
+
+func (q *Queries) ListTaskTemplateItemsByTeamID(ctx context.Context, teamID string) ([]TaskTemplateItem, error) {
+	rows, err := q.db.Query(ctx, listTaskTemplateItemsByTeamID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TaskTemplateItem
+	for rows.Next() {
+		var i TaskTemplateItem
+		if err := rows.Scan(
+			&i.ID,
+			&i.TemplateID,
+			&i.Position,
+			&i.Title,
+			&i.Notes,
+			&i.Type,
+			&i.PenaltyPoints,
+			&i.RequiredCompletionsPerWeek,
+			&i.DueInDays,
+			&i.Checklist,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTaskTemplateItemsByTemplateID = `-- name: ListTaskTemplateItemsByTemplateID :many
+SELECT id, template_id, position, title, notes, type, penalty_points,
+  required_completions_per_week, due_in_days, checklist
+FROM task_template_items
+WHERE template_id = $1
+ORDER BY position, id
+`
+
+func (q *Queries) ListTaskTemplateItemsByTemplateID(ctx context.Context, templateID string) ([]TaskTemplateItem, error) {
+	rows, err := q.db.Query(ctx, listTaskTemplateItemsByTemplateID, templateID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TaskTemplateItem
+	for rows.Next() {
+		var i TaskTemplateItem
+		if err := rows.Scan(
+			&i.ID,
+			&i.TemplateID,
+			&i.Position,
+			&i.Title,
+			&i.Notes,
+			&i.Type,
+			&i.PenaltyPoints,
+			&i.RequiredCompletionsPerWeek,
+			&i.DueInDays,
+			&i.Checklist,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTaskTemplatesByTeamID = `-- name: ListTaskTemplatesByTeamID :many
+SELECT id, team_id, name, description, created_at, updated_at
+FROM task_templates
+WHERE team_id = $1
+ORDER BY lower(name), id
+`
+
+type ListTaskTemplatesByTeamIDRow struct {
+	ID          string             `json:"id"`
+	TeamID      string             `json:"team_id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+}
+
+func (q *Queries) ListTaskTemplatesByTeamID(ctx context.Context, teamID string) ([]ListTaskTemplatesByTeamIDRow, error) {
+	rows, err := q.db.Query(ctx, listTaskTemplatesByTeamID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTaskTemplatesByTeamIDRow
+	for rows.Next() {
+		var i ListTaskTemplatesByTeamIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.TeamID,
+			&i.Name,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
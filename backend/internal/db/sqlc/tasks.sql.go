@@ -30,8 +30,8 @@ func (q *Queries) ClearTaskAssigneeByTeamAndUser(ctx context.Context, arg ClearT
 }
 
 const createTask = `-- name: CreateTask :exec
-INSERT INTO tasks (id, team_id, title, notes, type, penalty_points, assignee_user_id, required_completions_per_week, created_at, updated_at)
-VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, '')::uuid, $8, $9, $10)
+INSERT INTO tasks (id, team_id, title, notes, type, penalty_points, assignee_user_id, required_completions_per_week, created_at, updated_at, revision, due_on, starts_on, ends_on, category_id)
+VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, '')::uuid, $8, $9, $10, $11, $12, $13, $14, NULLIF($15, '')::uuid)
 `
 
 type CreateTaskParams struct {
@@ -45,6 +45,11 @@ type CreateTaskParams struct {
 	RequiredCompletionsPerWeek int32              `json:"required_completions_per_week"`
 	CreatedAt                  pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt                  pgtype.Timestamptz `json:"updated_at"`
+	Revision                   int64              `json:"revision"`
+	DueOn                      pgtype.Date        `json:"due_on"`
+	StartsOn                   pgtype.Date        `json:"starts_on"`
+	EndsOn                     pgtype.Date        `json:"ends_on"`
+	Column15                   interface{}        `json:"column_15"`
 }
 
 func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) error {
@@ -59,19 +64,29 @@ func (q *Queries) CreateTask(ctx context.Context, arg CreateTaskParams) error {
 		arg.RequiredCompletionsPerWeek,
 		arg.CreatedAt,
 		arg.UpdatedAt,
+		arg.Revision,
+		arg.DueOn,
+		arg.StartsOn,
+		arg.EndsOn,
+		arg.Column15,
 	)
 	return err
 }
 
 const deleteTask = `-- name: DeleteTask :exec
 UPDATE tasks
-SET deleted_at = NOW(),
-    updated_at = NOW()
+SET deleted_at = $2,
+    updated_at = $2
 WHERE id = $1
 `
 
-func (q *Queries) DeleteTask(ctx context.Context, id string) error {
-	_, err := q.db.Exec(ctx, deleteTask, id)
+type DeleteTaskParams struct {
+	ID        string             `json:"id"`
+	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
+}
+
+func (q *Queries) DeleteTask(ctx context.Context, arg DeleteTaskParams) error {
+	_, err := q.db.Exec(ctx, deleteTask, arg.ID, arg.DeletedAt)
 	return err
 }
 
@@ -89,7 +104,7 @@ func (q *Queries) GetEarliestTaskCreatedAtByTeam(ctx context.Context, teamID str
 }
 
 const getTaskByID = `-- name: GetTaskByID :one
-SELECT id, team_id, title, notes, type, penalty_points, COALESCE(assignee_user_id::text, '') AS assignee_user_id, required_completions_per_week, created_at, updated_at, deleted_at
+SELECT id, team_id, title, notes, type, penalty_points, COALESCE(assignee_user_id::text, '') AS assignee_user_id, required_completions_per_week, due_on, starts_on, ends_on, COALESCE(category_id::text, '') AS category_id, created_at, updated_at, deleted_at, revision
 FROM tasks
 WHERE id = $1
 `
@@ -103,9 +118,14 @@ type GetTaskByIDRow struct {
 	PenaltyPoints              int32              `json:"penalty_points"`
 	AssigneeUserID             interface{}        `json:"assignee_user_id"`
 	RequiredCompletionsPerWeek int32              `json:"required_completions_per_week"`
+	DueOn                      pgtype.Date        `json:"due_on"`
+	StartsOn                   pgtype.Date        `json:"starts_on"`
+	EndsOn                     pgtype.Date        `json:"ends_on"`
+	CategoryID                 interface{}        `json:"category_id"`
 	CreatedAt                  pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt                  pgtype.Timestamptz `json:"updated_at"`
 	DeletedAt                  pgtype.Timestamptz `json:"deleted_at"`
+	Revision                   int64              `json:"revision"`
 }
 
 func (q *Queries) GetTaskByID(ctx context.Context, id string) (GetTaskByIDRow, error) {
@@ -120,15 +140,20 @@ func (q *Queries) GetTaskByID(ctx context.Context, id string) (GetTaskByIDRow, e
 		&i.PenaltyPoints,
 		&i.AssigneeUserID,
 		&i.RequiredCompletionsPerWeek,
+		&i.DueOn,
+		&i.StartsOn,
+		&i.EndsOn,
+		&i.CategoryID,
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Revision,
 	)
 	return i, err
 }
 
 const listTasksByTeamID = `-- name: ListTasksByTeamID :many
-SELECT id, team_id, title, notes, type, penalty_points, COALESCE(assignee_user_id::text, '') AS assignee_user_id, required_completions_per_week, created_at, updated_at, deleted_at
+SELECT id, team_id, title, notes, type, penalty_points, COALESCE(assignee_user_id::text, '') AS assignee_user_id, required_completions_per_week, due_on, starts_on, ends_on, COALESCE(category_id::text, '') AS category_id, created_at, updated_at, deleted_at, revision
 FROM tasks
 WHERE team_id = $1
   AND deleted_at IS NULL
@@ -144,9 +169,14 @@ type ListTasksByTeamIDRow struct {
 	PenaltyPoints              int32              `json:"penalty_points"`
 	AssigneeUserID             interface{}        `json:"assignee_user_id"`
 	RequiredCompletionsPerWeek int32              `json:"required_completions_per_week"`
+	DueOn                      pgtype.Date        `json:"due_on"`
+	StartsOn                   pgtype.Date        `json:"starts_on"`
+	EndsOn                     pgtype.Date        `json:"ends_on"`
+	CategoryID                 interface{}        `json:"category_id"`
 	CreatedAt                  pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt                  pgtype.Timestamptz `json:"updated_at"`
 	DeletedAt                  pgtype.Timestamptz `json:"deleted_at"`
+	Revision                   int64              `json:"revision"`
 }
 
 func (q *Queries) ListTasksByTeamID(ctx context.Context, teamID string) ([]ListTasksByTeamIDRow, error) {
@@ -167,9 +197,14 @@ func (q *Queries) ListTasksByTeamID(ctx context.Context, teamID string) ([]ListT
 			&i.PenaltyPoints,
 			&i.AssigneeUserID,
 			&i.RequiredCompletionsPerWeek,
+			&i.DueOn,
+			&i.StartsOn,
+			&i.EndsOn,
+			&i.CategoryID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.Revision,
 		); err != nil {
 			return nil, err
 		}
@@ -182,7 +217,7 @@ func (q *Queries) ListTasksByTeamID(ctx context.Context, teamID string) ([]ListT
 }
 
 const listTasksEffectiveForCloseByTeamAndType = `-- name: ListTasksEffectiveForCloseByTeamAndType :many
-SELECT id, team_id, title, notes, type, penalty_points, COALESCE(assignee_user_id::text, '') AS assignee_user_id, required_completions_per_week, created_at, updated_at, deleted_at
+SELECT id, team_id, title, notes, type, penalty_points, COALESCE(assignee_user_id::text, '') AS assignee_user_id, required_completions_per_week, due_on, starts_on, ends_on, COALESCE(category_id::text, '') AS category_id, created_at, updated_at, deleted_at, revision
 FROM tasks
 WHERE team_id = $1
   AND type = $2
@@ -206,9 +241,14 @@ type ListTasksEffectiveForCloseByTeamAndTypeRow struct {
 	PenaltyPoints              int32              `json:"penalty_points"`
 	AssigneeUserID             interface{}        `json:"assignee_user_id"`
 	RequiredCompletionsPerWeek int32              `json:"required_completions_per_week"`
+	DueOn                      pgtype.Date        `json:"due_on"`
+	StartsOn                   pgtype.Date        `json:"starts_on"`
+	EndsOn                     pgtype.Date        `json:"ends_on"`
+	CategoryID                 interface{}        `json:"category_id"`
 	CreatedAt                  pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt                  pgtype.Timestamptz `json:"updated_at"`
 	DeletedAt                  pgtype.Timestamptz `json:"deleted_at"`
+	Revision                   int64              `json:"revision"`
 }
 
 func (q *Queries) ListTasksEffectiveForCloseByTeamAndType(ctx context.Context, arg ListTasksEffectiveForCloseByTeamAndTypeParams) ([]ListTasksEffectiveForCloseByTeamAndTypeRow, error) {
@@ -229,9 +269,14 @@ func (q *Queries) ListTasksEffectiveForCloseByTeamAndType(ctx context.Context, a
 			&i.PenaltyPoints,
 			&i.AssigneeUserID,
 			&i.RequiredCompletionsPerWeek,
+			&i.DueOn,
+			&i.StartsOn,
+			&i.EndsOn,
+			&i.CategoryID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.Revision,
 		); err != nil {
 			return nil, err
 		}
@@ -244,13 +289,13 @@ func (q *Queries) ListTasksEffectiveForCloseByTeamAndType(ctx context.Context, a
 }
 
 const listTasksForMonthlyStatusByTeam = `-- name: ListTasksForMonthlyStatusByTeam :many
-SELECT id, title, notes, type, penalty_points, required_completions_per_week, created_at, deleted_at
+SELECT id, title, notes, type, penalty_points, required_completions_per_week, due_on, starts_on, ends_on, created_at, deleted_at
 FROM tasks t
 WHERE t.team_id = $1
   AND t.created_at < $3
   AND t.deleted_at IS NULL
 UNION ALL
-SELECT id, title, notes, type, penalty_points, required_completions_per_week, created_at, deleted_at
+SELECT id, title, notes, type, penalty_points, required_completions_per_week, due_on, starts_on, ends_on, created_at, deleted_at
 FROM tasks t
 WHERE t.team_id = $1
   AND t.created_at < $3
@@ -271,6 +316,9 @@ type ListTasksForMonthlyStatusByTeamRow struct {
 	Type                       string             `json:"type"`
 	PenaltyPoints              int32              `json:"penalty_points"`
 	RequiredCompletionsPerWeek int32              `json:"required_completions_per_week"`
+	DueOn                      pgtype.Date        `json:"due_on"`
+	StartsOn                   pgtype.Date        `json:"starts_on"`
+	EndsOn                     pgtype.Date        `json:"ends_on"`
 	CreatedAt                  pgtype.Timestamptz `json:"created_at"`
 	DeletedAt                  pgtype.Timestamptz `json:"deleted_at"`
 }
@@ -291,6 +339,9 @@ func (q *Queries) ListTasksForMonthlyStatusByTeam(ctx context.Context, arg ListT
 			&i.Type,
 			&i.PenaltyPoints,
 			&i.RequiredCompletionsPerWeek,
+			&i.DueOn,
+			&i.StartsOn,
+			&i.EndsOn,
 			&i.CreatedAt,
 			&i.DeletedAt,
 		); err != nil {
@@ -305,7 +356,7 @@ func (q *Queries) ListTasksForMonthlyStatusByTeam(ctx context.Context, arg ListT
 }
 
 const listUndeletedTasksByTeamID = `-- name: ListUndeletedTasksByTeamID :many
-SELECT id, team_id, title, notes, type, penalty_points, COALESCE(assignee_user_id::text, '') AS assignee_user_id, required_completions_per_week, created_at, updated_at, deleted_at
+SELECT id, team_id, title, notes, type, penalty_points, COALESCE(assignee_user_id::text, '') AS assignee_user_id, required_completions_per_week, due_on, starts_on, ends_on, COALESCE(category_id::text, '') AS category_id, created_at, updated_at, deleted_at, revision
 FROM tasks
 WHERE team_id = $1
   AND deleted_at IS NULL
@@ -321,9 +372,14 @@ type ListUndeletedTasksByTeamIDRow struct {
 	PenaltyPoints              int32              `json:"penalty_points"`
 	AssigneeUserID             interface{}        `json:"assignee_user_id"`
 	RequiredCompletionsPerWeek int32              `json:"required_completions_per_week"`
+	DueOn                      pgtype.Date        `json:"due_on"`
+	StartsOn                   pgtype.Date        `json:"starts_on"`
+	EndsOn                     pgtype.Date        `json:"ends_on"`
+	CategoryID                 interface{}        `json:"category_id"`
 	CreatedAt                  pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt                  pgtype.Timestamptz `json:"updated_at"`
 	DeletedAt                  pgtype.Timestamptz `json:"deleted_at"`
+	Revision                   int64              `json:"revision"`
 }
 
 func (q *Queries) ListUndeletedTasksByTeamID(ctx context.Context, teamID string) ([]ListUndeletedTasksByTeamIDRow, error) {
@@ -344,9 +400,14 @@ func (q *Queries) ListUndeletedTasksByTeamID(ctx context.Context, teamID string)
 			&i.PenaltyPoints,
 			&i.AssigneeUserID,
 			&i.RequiredCompletionsPerWeek,
+			&i.DueOn,
+			&i.StartsOn,
+			&i.EndsOn,
+			&i.CategoryID,
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.Revision,
 		); err != nil {
 			return nil, err
 		}
@@ -365,7 +426,12 @@ SET title = $2,
     penalty_points = $4,
     assignee_user_id = NULLIF($5, '')::uuid,
     required_completions_per_week = $6,
-    updated_at = $7
+    updated_at = $7,
+    revision = $8,
+    due_on = $9,
+    starts_on = $10,
+    ends_on = $11,
+    category_id = NULLIF($12, '')::uuid
 WHERE id = $1
 `
 
@@ -377,6 +443,11 @@ type UpdateTaskParams struct {
 	Column5                    interface{}        `json:"column_5"`
 	RequiredCompletionsPerWeek int32              `json:"required_completions_per_week"`
 	UpdatedAt                  pgtype.Timestamptz `json:"updated_at"`
+	Revision                   int64              `json:"revision"`
+	DueOn                      pgtype.Date        `json:"due_on"`
+	StartsOn                   pgtype.Date        `json:"starts_on"`
+	EndsOn                     pgtype.Date        `json:"ends_on"`
+	Column12                   interface{}        `json:"column_12"`
 }
 
 func (q *Queries) UpdateTask(ctx context.Context, arg UpdateTaskParams) error {
@@ -388,6 +459,27 @@ func (q *Queries) UpdateTask(ctx context.Context, arg UpdateTaskParams) error {
 		arg.Column5,
 		arg.RequiredCompletionsPerWeek,
 		arg.UpdatedAt,
+		arg.Revision,
+		arg.DueOn,
+		arg.StartsOn,
+		arg.EndsOn,
+		arg.Column12,
 	)
 	return err
 }
+
+const updateTaskRevision = `-- name: UpdateTaskRevision :exec
+UPDATE tasks
+SET revision = $2
+WHERE id = $1
+`
+
+type UpdateTaskRevisionParams struct {
+	ID       string `json:"id"`
+	Revision int64  `json:"revision"`
+}
+
+func (q *Queries) UpdateTaskRevision(ctx context.Context, arg UpdateTaskRevisionParams) error {
+	_, err := q.db.Exec(ctx, updateTaskRevision, arg.ID, arg.Revision)
+	return err
+}
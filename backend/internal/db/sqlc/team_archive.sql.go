@@ -0,0 +1,1219 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: team_archive.sql
+
+package dbsqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const getArchiveTeam = `-- name: GetArchiveTeam :one
+SELECT id, name, created_at
+FROM teams
+WHERE id = $1
+`
+
+type GetArchiveTeamRow struct {
+	ID        string             `json:"id"`
+	Name      string             `json:"name"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+func (q *Queries) GetArchiveTeam(ctx context.Context, id string) (GetArchiveTeamRow, error) {
+	row := q.db.QueryRow(ctx, getArchiveTeam, id)
+	var i GetArchiveTeamRow
+	err := row.Scan(&i.ID, &i.Name, &i.CreatedAt)
+	return i, err
+}
+
+const importCloseRun = `-- name: ImportCloseRun :exec
+INSERT INTO close_runs (team_id, scope, target_date, created_at)
+VALUES ($1, $2, $3, $4)
+`
+
+type ImportCloseRunParams struct {
+	TeamID     string             `json:"team_id"`
+	Scope      string             `json:"scope"`
+	TargetDate pgtype.Date        `json:"target_date"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+func (q *Queries) ImportCloseRun(ctx context.Context, arg ImportCloseRunParams) error {
+	_, err := q.db.Exec(ctx, importCloseRun,
+		arg.TeamID,
+		arg.Scope,
+		arg.TargetDate,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const importMonthlyPenaltySummary = `-- name: ImportMonthlyPenaltySummary :exec
+INSERT INTO monthly_penalty_summaries (team_id, month_start, daily_penalty_total, weekly_penalty_total, is_closed)
+VALUES ($1, $2, $3, $4, $5)
+`
+
+type ImportMonthlyPenaltySummaryParams struct {
+	TeamID             string      `json:"team_id"`
+	MonthStart         pgtype.Date `json:"month_start"`
+	DailyPenaltyTotal  int32       `json:"daily_penalty_total"`
+	WeeklyPenaltyTotal int32       `json:"weekly_penalty_total"`
+	IsClosed           bool        `json:"is_closed"`
+}
+
+func (q *Queries) ImportMonthlyPenaltySummary(ctx context.Context, arg ImportMonthlyPenaltySummaryParams) error {
+	_, err := q.db.Exec(ctx, importMonthlyPenaltySummary,
+		arg.TeamID,
+		arg.MonthStart,
+		arg.DailyPenaltyTotal,
+		arg.WeeklyPenaltyTotal,
+		arg.IsClosed,
+	)
+	return err
+}
+
+const importPenaltyRule = `-- name: ImportPenaltyRule :exec
+INSERT INTO penalty_rules (id, team_id, threshold, name, description, created_at, updated_at, deleted_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+`
+
+type ImportPenaltyRuleParams struct {
+	ID          string             `json:"id"`
+	TeamID      string             `json:"team_id"`
+	Threshold   int32              `json:"threshold"`
+	Name        string             `json:"name"`
+	Description pgtype.Text        `json:"description"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt   pgtype.Timestamptz `json:"deleted_at"`
+}
+
+func (q *Queries) ImportPenaltyRule(ctx context.Context, arg ImportPenaltyRuleParams) error {
+	_, err := q.db.Exec(ctx, importPenaltyRule,
+		arg.ID,
+		arg.TeamID,
+		arg.Threshold,
+		arg.Name,
+		arg.Description,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+		arg.DeletedAt,
+	)
+	return err
+}
+
+const importTask = `-- name: ImportTask :exec
+INSERT INTO tasks (
+  id, team_id, title, notes, type, penalty_points, assignee_user_id,
+  required_completions_per_week, due_on, starts_on, ends_on, category_id, created_at, updated_at, deleted_at
+)
+VALUES (
+  $1, $2, $3, $4, $5, $6,
+  NULLIF($7, '')::uuid,
+  $8, $9, $10, $11,
+  NULLIF($12, '')::uuid, $13, $14, $15
+)
+`
+
+type ImportTaskParams struct {
+	ID                         string             `json:"id"`
+	TeamID                     string             `json:"team_id"`
+	Title                      string             `json:"title"`
+	Notes                      pgtype.Text        `json:"notes"`
+	Type                       string             `json:"type"`
+	PenaltyPoints              int32              `json:"penalty_points"`
+	AssigneeUserID             interface{}        `json:"assignee_user_id"`
+	RequiredCompletionsPerWeek int32              `json:"required_completions_per_week"`
+	DueOn                      pgtype.Date        `json:"due_on"`
+	StartsOn                   pgtype.Date        `json:"starts_on"`
+	EndsOn                     pgtype.Date        `json:"ends_on"`
+	CategoryID                 interface{}        `json:"category_id"`
+	CreatedAt                  pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt                  pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt                  pgtype.Timestamptz `json:"deleted_at"`
+}
+
+func (q *Queries) ImportTask(ctx context.Context, arg ImportTaskParams) error {
+	_, err := q.db.Exec(ctx, importTask,
+		arg.ID,
+		arg.TeamID,
+		arg.Title,
+		arg.Notes,
+		arg.Type,
+		arg.PenaltyPoints,
+		arg.AssigneeUserID,
+		arg.RequiredCompletionsPerWeek,
+		arg.DueOn,
+		arg.StartsOn,
+		arg.EndsOn,
+		arg.CategoryID,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+		arg.DeletedAt,
+	)
+	return err
+}
+
+const importTaskCategory = `-- name: ImportTaskCategory :exec
+INSERT INTO task_categories (id, team_id, name, color_hex, icon, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+`
+
+type ImportTaskCategoryParams struct {
+	ID        string             `json:"id"`
+	TeamID    string             `json:"team_id"`
+	Name      string             `json:"name"`
+	ColorHex  pgtype.Text        `json:"color_hex"`
+	Icon      pgtype.Text        `json:"icon"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+func (q *Queries) ImportTaskCategory(ctx context.Context, arg ImportTaskCategoryParams) error {
+	_, err := q.db.Exec(ctx, importTaskCategory,
+		arg.ID,
+		arg.TeamID,
+		arg.Name,
+		arg.ColorHex,
+		arg.Icon,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
+const importTaskChecklistItem = `-- name: ImportTaskChecklistItem :exec
+INSERT INTO task_checklist_items (id, task_id, title, position, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+type ImportTaskChecklistItemParams struct {
+	ID        string             `json:"id"`
+	TaskID    string             `json:"task_id"`
+	Title     string             `json:"title"`
+	Position  int32              `json:"position"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+func (q *Queries) ImportTaskChecklistItem(ctx context.Context, arg ImportTaskChecklistItemParams) error {
+	_, err := q.db.Exec(ctx, importTaskChecklistItem,
+		arg.ID,
+		arg.TaskID,
+		arg.Title,
+		arg.Position,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
+const importTaskCompletionDaily = `-- name: ImportTaskCompletionDaily :exec
+INSERT INTO task_completion_daily (task_id, target_date, completed_by_user_id, created_at)
+VALUES ($1, $2, NULLIF($3, '')::uuid, $4)
+`
+
+type ImportTaskCompletionDailyParams struct {
+	TaskID            string             `json:"task_id"`
+	TargetDate        pgtype.Date        `json:"target_date"`
+	CompletedByUserID interface{}        `json:"completed_by_user_id"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+}
+
+func (q *Queries) ImportTaskCompletionDaily(ctx context.Context, arg ImportTaskCompletionDailyParams) error {
+	_, err := q.db.Exec(ctx, importTaskCompletionDaily,
+		arg.TaskID,
+		arg.TargetDate,
+		arg.CompletedByUserID,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const importTaskCompletionOneOff = `-- name: ImportTaskCompletionOneOff :exec
+INSERT INTO task_completion_one_off (task_id, completed_by_user_id, completed_at)
+VALUES ($1, NULLIF($2, '')::uuid, $3)
+`
+
+type ImportTaskCompletionOneOffParams struct {
+	TaskID            string             `json:"task_id"`
+	CompletedByUserID interface{}        `json:"completed_by_user_id"`
+	CompletedAt       pgtype.Timestamptz `json:"completed_at"`
+}
+
+func (q *Queries) ImportTaskCompletionOneOff(ctx context.Context, arg ImportTaskCompletionOneOffParams) error {
+	_, err := q.db.Exec(ctx, importTaskCompletionOneOff, arg.TaskID, arg.CompletedByUserID, arg.CompletedAt)
+	return err
+}
+
+const importTaskCompletionWeeklyEntry = `-- name: ImportTaskCompletionWeeklyEntry :exec
+INSERT INTO task_completion_weekly_entries (id, task_id, week_start, completed_by_user_id, created_at)
+VALUES ($1, $2, $3, NULLIF($4, '')::uuid, $5)
+`
+
+type ImportTaskCompletionWeeklyEntryParams struct {
+	ID                string             `json:"id"`
+	TaskID            string             `json:"task_id"`
+	WeekStart         pgtype.Date        `json:"week_start"`
+	CompletedByUserID interface{}        `json:"completed_by_user_id"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+}
+
+func (q *Queries) ImportTaskCompletionWeeklyEntry(ctx context.Context, arg ImportTaskCompletionWeeklyEntryParams) error {
+	_, err := q.db.Exec(ctx, importTaskCompletionWeeklyEntry,
+		arg.ID,
+		arg.TaskID,
+		arg.WeekStart,
+		arg.CompletedByUserID,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const importTaskEvaluationDedupe = `-- name: ImportTaskEvaluationDedupe :exec
+INSERT INTO task_evaluation_dedupes (team_id, scope, target_date, task_id, penalty_points, category_id, category_name, created_at)
+VALUES (
+  $1, $2, $3, $4, $5,
+  NULLIF($6::text, '')::uuid, $7, $8
+)
+`
+
+type ImportTaskEvaluationDedupeParams struct {
+	TeamID        string             `json:"team_id"`
+	Scope         string             `json:"scope"`
+	TargetDate    pgtype.Date        `json:"target_date"`
+	TaskID        string             `json:"task_id"`
+	PenaltyPoints int32              `json:"penalty_points"`
+	CategoryID    string             `json:"category_id"`
+	CategoryName  pgtype.Text        `json:"category_name"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+}
+
+func (q *Queries) ImportTaskEvaluationDedupe(ctx context.Context, arg ImportTaskEvaluationDedupeParams) error {
+	_, err := q.db.Exec(ctx, importTaskEvaluationDedupe,
+		arg.TeamID,
+		arg.Scope,
+		arg.TargetDate,
+		arg.TaskID,
+		arg.PenaltyPoints,
+		arg.CategoryID,
+		arg.CategoryName,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const importTaskTemplate = `-- name: ImportTaskTemplate :exec
+INSERT INTO task_templates (id, team_id, name, description, created_by_user_id, created_at, updated_at)
+VALUES (
+  $1, $2, $3, $4,
+  NULLIF($5::text, '')::uuid, $6, $7
+)
+`
+
+type ImportTaskTemplateParams struct {
+	ID              string             `json:"id"`
+	TeamID          string             `json:"team_id"`
+	Name            string             `json:"name"`
+	Description     string             `json:"description"`
+	CreatedByUserID string             `json:"created_by_user_id"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+}
+
+func (q *Queries) ImportTaskTemplate(ctx context.Context, arg ImportTaskTemplateParams) error {
+	_, err := q.db.Exec(ctx, importTaskTemplate,
+		arg.ID,
+		arg.TeamID,
+		arg.Name,
+		arg.Description,
+		arg.CreatedByUserID,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
+const importTaskTemplateItem = `-- name: ImportTaskTemplateItem :exec
+INSERT INTO task_template_items (
+  id, template_id, position, title, notes, type, penalty_points,
+  required_completions_per_week, due_in_days, checklist
+)
+VALUES (
+  $1, $2, $3, $4, $5, $6,
+  $7, $8, $9, $10::text[]
+)
+`
+
+type ImportTaskTemplateItemParams struct {
+	ID                         string      `json:"id"`
+	TemplateID                 string      `json:"template_id"`
+	Position                   int32       `json:"position"`
+	Title                      string      `json:"title"`
+	Notes                      string      `json:"notes"`
+	Type                       string      `json:"type"`
+	PenaltyPoints              int32       `json:"penalty_points"`
+	RequiredCompletionsPerWeek int32       `json:"required_completions_per_week"`
+	DueInDays                  pgtype.Int4 `json:"due_in_days"`
+	Checklist                  []string    `json:"checklist"`
+}
+
+func (q *Queries) ImportTaskTemplateItem(ctx context.Context, arg ImportTaskTemplateItemParams) error {
+	_, err := q.db.Exec(ctx, importTaskTemplateItem,
+		arg.ID,
+		arg.TemplateID,
+		arg.Position,
+		arg.Title,
+		arg.Notes,
+		arg.Type,
+		arg.PenaltyPoints,
+		arg.RequiredCompletionsPerWeek,
+		arg.DueInDays,
+		arg.Checklist,
+	)
+	return err
+}
+
+const importTeamAbsence = `-- name: ImportTeamAbsence :exec
+INSERT INTO team_absences (id, team_id, user_id, starts_on, ends_on, reason, created_by_user_id, created_at)
+VALUES (
+  $1, $2, NULLIF($3::text, '')::uuid, $4, $5,
+  $6, NULLIF($7::text, '')::uuid, $8
+)
+`
+
+type ImportTeamAbsenceParams struct {
+	ID              string             `json:"id"`
+	TeamID          string             `json:"team_id"`
+	UserID          string             `json:"user_id"`
+	StartsOn        pgtype.Date        `json:"starts_on"`
+	EndsOn          pgtype.Date        `json:"ends_on"`
+	Reason          pgtype.Text        `json:"reason"`
+	CreatedByUserID string             `json:"created_by_user_id"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+}
+
+func (q *Queries) ImportTeamAbsence(ctx context.Context, arg ImportTeamAbsenceParams) error {
+	_, err := q.db.Exec(ctx, importTeamAbsence,
+		arg.ID,
+		arg.TeamID,
+		arg.UserID,
+		arg.StartsOn,
+		arg.EndsOn,
+		arg.Reason,
+		arg.CreatedByUserID,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const importTeamHoliday = `-- name: ImportTeamHoliday :exec
+INSERT INTO team_holidays (team_id, holiday_date, name, source, created_by_user_id, created_at)
+VALUES (
+  $1, $2, $3, $4,
+  NULLIF($5::text, '')::uuid, $6
+)
+`
+
+type ImportTeamHolidayParams struct {
+	TeamID          string             `json:"team_id"`
+	HolidayDate     pgtype.Date        `json:"holiday_date"`
+	Name            string             `json:"name"`
+	Source          string             `json:"source"`
+	CreatedByUserID string             `json:"created_by_user_id"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+}
+
+func (q *Queries) ImportTeamHoliday(ctx context.Context, arg ImportTeamHolidayParams) error {
+	_, err := q.db.Exec(ctx, importTeamHoliday,
+		arg.TeamID,
+		arg.HolidayDate,
+		arg.Name,
+		arg.Source,
+		arg.CreatedByUserID,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const importTriggeredRule = `-- name: ImportTriggeredRule :exec
+INSERT INTO monthly_penalty_summary_triggered_rules (team_id, month_start, rule_id, created_at)
+VALUES ($1, $2, $3, $4)
+`
+
+type ImportTriggeredRuleParams struct {
+	TeamID     string             `json:"team_id"`
+	MonthStart pgtype.Date        `json:"month_start"`
+	RuleID     string             `json:"rule_id"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+func (q *Queries) ImportTriggeredRule(ctx context.Context, arg ImportTriggeredRuleParams) error {
+	_, err := q.db.Exec(ctx, importTriggeredRule,
+		arg.TeamID,
+		arg.MonthStart,
+		arg.RuleID,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const importUser = `-- name: ImportUser :exec
+INSERT INTO users (id, email, display_name, nickname, color_hex, created_at)
+VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+type ImportUserParams struct {
+	ID          string             `json:"id"`
+	Email       string             `json:"email"`
+	DisplayName string             `json:"display_name"`
+	Nickname    pgtype.Text        `json:"nickname"`
+	ColorHex    pgtype.Text        `json:"color_hex"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+func (q *Queries) ImportUser(ctx context.Context, arg ImportUserParams) error {
+	_, err := q.db.Exec(ctx, importUser,
+		arg.ID,
+		arg.Email,
+		arg.DisplayName,
+		arg.Nickname,
+		arg.ColorHex,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const listArchiveCloseRunHistoryByTeamID = `-- name: ListArchiveCloseRunHistoryByTeamID :many
+SELECT id, team_id, scope, trigger, as_of, started_at, finished_at, periods_processed, penalty_added, penalized_tasks, error_class, error_message
+FROM close_run_history
+WHERE team_id = $1
+ORDER BY started_at, id
+`
+
+func (q *Queries) ListArchiveCloseRunHistoryByTeamID(ctx context.Context, teamID string) ([]CloseRunHistory, error) {
+	rows, err := q.db.Query(ctx, listArchiveCloseRunHistoryByTeamID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CloseRunHistory
+	for rows.Next() {
+		var i CloseRunHistory
+		if err := rows.Scan(
+			&i.ID,
+			&i.TeamID,
+			&i.Scope,
+			&i.Trigger,
+			&i.AsOf,
+			&i.StartedAt,
+			&i.FinishedAt,
+			&i.PeriodsProcessed,
+			&i.PenaltyAdded,
+			&i.PenalizedTasks,
+			&i.ErrorClass,
+			&i.ErrorMessage,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listArchiveCloseRunsByTeamID = `-- name: ListArchiveCloseRunsByTeamID :many
+SELECT scope, target_date, created_at
+FROM close_runs
+WHERE team_id = $1
+ORDER BY scope, target_date
+`
+
+type ListArchiveCloseRunsByTeamIDRow struct {
+	Scope      string             `json:"scope"`
+	TargetDate pgtype.Date        `json:"target_date"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+func (q *Queries) ListArchiveCloseRunsByTeamID(ctx context.Context, teamID string) ([]ListArchiveCloseRunsByTeamIDRow, error) {
+	rows, err := q.db.Query(ctx, listArchiveCloseRunsByTeamID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListArchiveCloseRunsByTeamIDRow
+	for rows.Next() {
+		var i ListArchiveCloseRunsByTeamIDRow
+		if err := rows.Scan(&i.Scope, &i.TargetDate, &i.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listArchiveDailyCompletionsByTeamID = `-- name: ListArchiveDailyCompletionsByTeamID :many
+SELECT
+  d.task_id,
+  d.target_date,
+  COALESCE(d.completed_by_user_id::text, ''::text) AS completed_by_user_id,
+  d.created_at
+FROM task_completion_daily d
+JOIN tasks t ON t.id = d.task_id
+WHERE t.team_id = $1
+ORDER BY d.target_date, d.task_id
+`
+
+type ListArchiveDailyCompletionsByTeamIDRow struct {
+	TaskID            string             `json:"task_id"`
+	TargetDate        pgtype.Date        `json:"target_date"`
+	CompletedByUserID interface{}        `json:"completed_by_user_id"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+}
+
+func (q *Queries) ListArchiveDailyCompletionsByTeamID(ctx context.Context, teamID string) ([]ListArchiveDailyCompletionsByTeamIDRow, error) {
+	rows, err := q.db.Query(ctx, listArchiveDailyCompletionsByTeamID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListArchiveDailyCompletionsByTeamIDRow
+	for rows.Next() {
+		var i ListArchiveDailyCompletionsByTeamIDRow
+		if err := rows.Scan(
+			&i.TaskID,
+			&i.TargetDate,
+			&i.CompletedByUserID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listArchiveMembersByTeamID = `-- name: ListArchiveMembersByTeamID :many
+SELECT
+  u.id AS user_id,
+  u.email,
+  u.display_name,
+  u.nickname,
+  u.color_hex,
+  u.created_at AS user_created_at,
+  tm.role,
+  tm.created_at AS joined_at
+FROM team_members tm
+JOIN users u ON u.id = tm.user_id
+WHERE tm.team_id = $1
+ORDER BY tm.created_at, u.id
+`
+
+type ListArchiveMembersByTeamIDRow struct {
+	UserID        string             `json:"user_id"`
+	Email         string             `json:"email"`
+	DisplayName   string             `json:"display_name"`
+	Nickname      pgtype.Text        `json:"nickname"`
+	ColorHex      pgtype.Text        `json:"color_hex"`
+	UserCreatedAt pgtype.Timestamptz `json:"user_created_at"`
+	Role          string             `json:"role"`
+	JoinedAt      pgtype.Timestamptz `json:"joined_at"`
+}
+
+func (q *Queries) ListArchiveMembersByTeamID(ctx context.Context, teamID string) ([]ListArchiveMembersByTeamIDRow, error) {
+	rows, err := q.db.Query(ctx, listArchiveMembersByTeamID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListArchiveMembersByTeamIDRow
+	for rows.Next() {
+		var i ListArchiveMembersByTeamIDRow
+		if err := rows.Scan(
+			&i.UserID,
+			&i.Email,
+			&i.DisplayName,
+			&i.Nickname,
+			&i.ColorHex,
+			&i.UserCreatedAt,
+			&i.Role,
+			&i.JoinedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listArchiveMonthlySummariesByTeamID = `-- name: ListArchiveMonthlySummariesByTeamID :many
+SELECT month_start, daily_penalty_total, weekly_penalty_total, is_closed
+FROM monthly_penalty_summaries
+WHERE team_id = $1
+ORDER BY month_start
+`
+
+type ListArchiveMonthlySummariesByTeamIDRow struct {
+	MonthStart         pgtype.Date `json:"month_start"`
+	DailyPenaltyTotal  int32       `json:"daily_penalty_total"`
+	WeeklyPenaltyTotal int32       `json:"weekly_penalty_total"`
+	IsClosed           bool        `json:"is_closed"`
+}
+
+func (q *Queries) ListArchiveMonthlySummariesByTeamID(ctx context.Context, teamID string) ([]ListArchiveMonthlySummariesByTeamIDRow, error) {
+	rows, err := q.db.Query(ctx, listArchiveMonthlySummariesByTeamID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListArchiveMonthlySummariesByTeamIDRow
+	for rows.Next() {
+		var i ListArchiveMonthlySummariesByTeamIDRow
+		if err := rows.Scan(
+			&i.MonthStart,
+			&i.DailyPenaltyTotal,
+			&i.WeeklyPenaltyTotal,
+			&i.IsClosed,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listArchiveOneOffCompletionsByTeamID = `-- name: ListArchiveOneOffCompletionsByTeamID :many
+SELECT
+  o.task_id,
+  COALESCE(o.completed_by_user_id::text, ''::text) AS completed_by_user_id,
+  o.completed_at
+FROM task_completion_one_off o
+JOIN tasks t ON t.id = o.task_id
+WHERE t.team_id = $1
+ORDER BY o.completed_at, o.task_id
+`
+
+type ListArchiveOneOffCompletionsByTeamIDRow struct {
+	TaskID            string             `json:"task_id"`
+	CompletedByUserID interface{}        `json:"completed_by_user_id"`
+	CompletedAt       pgtype.Timestamptz `json:"completed_at"`
+}
+
+func (q *Queries) ListArchiveOneOffCompletionsByTeamID(ctx context.Context, teamID string) ([]ListArchiveOneOffCompletionsByTeamIDRow, error) {
+	rows, err := q.db.Query(ctx, listArchiveOneOffCompletionsByTeamID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListArchiveOneOffCompletionsByTeamIDRow
+	for rows.Next() {
+		var i ListArchiveOneOffCompletionsByTeamIDRow
+		if err := rows.Scan(&i.TaskID, &i.CompletedByUserID, &i.CompletedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listArchivePenaltyRulesByTeamID = `-- name: ListArchivePenaltyRulesByTeamID :many
+SELECT id, threshold, name, description, created_at, updated_at, deleted_at
+FROM penalty_rules
+WHERE team_id = $1
+ORDER BY created_at, id
+`
+
+type ListArchivePenaltyRulesByTeamIDRow struct {
+	ID          string             `json:"id"`
+	Threshold   int32              `json:"threshold"`
+	Name        string             `json:"name"`
+	Description pgtype.Text        `json:"description"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt   pgtype.Timestamptz `json:"deleted_at"`
+}
+
+func (q *Queries) ListArchivePenaltyRulesByTeamID(ctx context.Context, teamID string) ([]ListArchivePenaltyRulesByTeamIDRow, error) {
+	rows, err := q.db.Query(ctx, listArchivePenaltyRulesByTeamID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListArchivePenaltyRulesByTeamIDRow
+	for rows.Next() {
+		var i ListArchivePenaltyRulesByTeamIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Threshold,
+			&i.Name,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listArchiveTaskCategoriesByTeamID = `-- name: ListArchiveTaskCategoriesByTeamID :many
+SELECT id, name, color_hex, icon, created_at, updated_at
+FROM task_categories
+WHERE team_id = $1
+ORDER BY created_at, id
+`
+
+type ListArchiveTaskCategoriesByTeamIDRow struct {
+	ID        string             `json:"id"`
+	Name      string             `json:"name"`
+	ColorHex  pgtype.Text        `json:"color_hex"`
+	Icon      pgtype.Text        `json:"icon"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+func (q *Queries) ListArchiveTaskCategoriesByTeamID(ctx context.Context, teamID string) ([]ListArchiveTaskCategoriesByTeamIDRow, error) {
+	rows, err := q.db.Query(ctx, listArchiveTaskCategoriesByTeamID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListArchiveTaskCategoriesByTeamIDRow
+	for rows.Next() {
+		var i ListArchiveTaskCategoriesByTeamIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.ColorHex,
+			&i.Icon,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listArchiveTaskChecklistItemsByTeamID = `-- name: ListArchiveTaskChecklistItemsByTeamID :many
+SELECT i.id, i.task_id, i.title, i.position, i.created_at, i.updated_at
+FROM task_checklist_items i
+JOIN tasks t ON t.id = i.task_id
+WHERE t.team_id = $1
+ORDER BY i.task_id, i.position, i.created_at, i.id
+`
+
+func (q *Queries) ListArchiveTaskChecklistItemsByTeamID(ctx context.Context, teamID string) ([]TaskChecklistItem, error) {
+	rows, err := q.db.Query(ctx, listArchiveTaskChecklistItemsByTeamID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TaskChecklistItem
+	for rows.Next() {
+		var i TaskChecklistItem
+		if err := rows.Scan(
+			&i.ID,
+			&i.TaskID,
+			&i.Title,
+			&i.Position,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listArchiveTaskEvaluationDedupesByTeamID = `-- name: ListArchiveTaskEvaluationDedupesByTeamID :many
+SELECT scope, target_date, task_id, penalty_points, COALESCE(category_id::text, ''::text) AS category_id, category_name, created_at
+FROM task_evaluation_dedupes
+WHERE team_id = $1
+ORDER BY scope, target_date, task_id
+`
+
+type ListArchiveTaskEvaluationDedupesByTeamIDRow struct {
+	Scope         string             `json:"scope"`
+	TargetDate    pgtype.Date        `json:"target_date"`
+	TaskID        string             `json:"task_id"`
+	PenaltyPoints int32              `json:"penalty_points"`
+	CategoryID    interface{}        `json:"category_id"`
+	CategoryName  pgtype.Text        `json:"category_name"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+}
+
+func (q *Queries) ListArchiveTaskEvaluationDedupesByTeamID(ctx context.Context, teamID string) ([]ListArchiveTaskEvaluationDedupesByTeamIDRow, error) {
+	rows, err := q.db.Query(ctx, listArchiveTaskEvaluationDedupesByTeamID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListArchiveTaskEvaluationDedupesByTeamIDRow
+	for rows.Next() {
+		var i ListArchiveTaskEvaluationDedupesByTeamIDRow
+		if err := rows.Scan(
+			&i.Scope,
+			&i.TargetDate,
+			&i.TaskID,
+			&i.PenaltyPoints,
+			&i.CategoryID,
+			&i.CategoryName,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listArchiveTaskTemplatesByTeamID = `-- name: ListArchiveTaskTemplatesByTeamID :many
+SELECT id, name, description, COALESCE(created_by_user_id::text, ''::text) AS created_by_user_id, created_at, updated_at
+FROM task_templates
+WHERE team_id = $1
+ORDER BY created_at, id
+`
+
+type ListArchiveTaskTemplatesByTeamIDRow struct {
+	ID              string             `json:"id"`
+	Name            string             `json:"name"`
+	Description     string             `json:"description"`
+	CreatedByUserID interface{}        `json:"created_by_user_id"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt       pgtype.Timestamptz `json:"updated_at"`
+}
+
+func (q *Queries) ListArchiveTaskTemplatesByTeamID(ctx context.Context, teamID string) ([]ListArchiveTaskTemplatesByTeamIDRow, error) {
+	rows, err := q.db.Query(ctx, listArchiveTaskTemplatesByTeamID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListArchiveTaskTemplatesByTeamIDRow
+	for rows.Next() {
+		var i ListArchiveTaskTemplatesByTeamIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Description,
+			&i.CreatedByUserID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listArchiveTasksByTeamID = `-- name: ListArchiveTasksByTeamID :many
+SELECT
+  id,
+  title,
+  notes,
+  type,
+  penalty_points,
+  COALESCE(assignee_user_id::text, ''::text) AS assignee_user_id,
+  required_completions_per_week,
+  due_on,
+  starts_on,
+  ends_on,
+  COALESCE(category_id::text, ''::text) AS category_id,
+  created_at,
+  updated_at,
+  deleted_at
+FROM tasks
+WHERE team_id = $1
+ORDER BY created_at, id
+`
+
+type ListArchiveTasksByTeamIDRow struct {
+	ID                         string             `json:"id"`
+	Title                      string             `json:"title"`
+	Notes                      pgtype.Text        `json:"notes"`
+	Type                       string             `json:"type"`
+	PenaltyPoints              int32              `json:"penalty_points"`
+	AssigneeUserID             interface{}        `json:"assignee_user_id"`
+	RequiredCompletionsPerWeek int32              `json:"required_completions_per_week"`
+	DueOn                      pgtype.Date        `json:"due_on"`
+	StartsOn                   pgtype.Date        `json:"starts_on"`
+	EndsOn                     pgtype.Date        `json:"ends_on"`
+	CategoryID                 interface{}        `json:"category_id"`
+	CreatedAt                  pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt                  pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt                  pgtype.Timestamptz `json:"deleted_at"`
+}
+
+func (q *Queries) ListArchiveTasksByTeamID(ctx context.Context, teamID string) ([]ListArchiveTasksByTeamIDRow, error) {
+	rows, err := q.db.Query(ctx, listArchiveTasksByTeamID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListArchiveTasksByTeamIDRow
+	for rows.Next() {
+		var i ListArchiveTasksByTeamIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Title,
+			&i.Notes,
+			&i.Type,
+			&i.PenaltyPoints,
+			&i.AssigneeUserID,
+			&i.RequiredCompletionsPerWeek,
+			&i.DueOn,
+			&i.StartsOn,
+			&i.EndsOn,
+			&i.CategoryID,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listArchiveTeamAbsencesByTeamID = `-- name: ListArchiveTeamAbsencesByTeamID :many
+SELECT
+  id,
+  COALESCE(user_id::text, ''::text) AS user_id,
+  starts_on,
+  ends_on,
+  reason,
+  COALESCE(created_by_user_id::text, ''::text) AS created_by_user_id,
+  created_at
+FROM team_absences
+WHERE team_id = $1
+ORDER BY starts_on, created_at, id
+`
+
+type ListArchiveTeamAbsencesByTeamIDRow struct {
+	ID              string             `json:"id"`
+	UserID          interface{}        `json:"user_id"`
+	StartsOn        pgtype.Date        `json:"starts_on"`
+	EndsOn          pgtype.Date        `json:"ends_on"`
+	Reason          pgtype.Text        `json:"reason"`
+	CreatedByUserID interface{}        `json:"created_by_user_id"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+}
+
+func (q *Queries) ListArchiveTeamAbsencesByTeamID(ctx context.Context, teamID string) ([]ListArchiveTeamAbsencesByTeamIDRow, error) {
+	rows, err := q.db.Query(ctx, listArchiveTeamAbsencesByTeamID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListArchiveTeamAbsencesByTeamIDRow
+	for rows.Next() {
+		var i ListArchiveTeamAbsencesByTeamIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.StartsOn,
+			&i.EndsOn,
+			&i.Reason,
+			&i.CreatedByUserID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listArchiveTeamHolidaysByTeamID = `-- name: ListArchiveTeamHolidaysByTeamID :many
+SELECT holiday_date, name, source, COALESCE(created_by_user_id::text, ''::text) AS created_by_user_id, created_at
+FROM team_holidays
+WHERE team_id = $1
+ORDER BY holiday_date
+`
+
+type ListArchiveTeamHolidaysByTeamIDRow struct {
+	HolidayDate     pgtype.Date        `json:"holiday_date"`
+	Name            string             `json:"name"`
+	Source          string             `json:"source"`
+	CreatedByUserID interface{}        `json:"created_by_user_id"`
+	CreatedAt       pgtype.Timestamptz `json:"created_at"`
+}
+
+func (q *Queries) ListArchiveTeamHolidaysByTeamID(ctx context.Context, teamID string) ([]ListArchiveTeamHolidaysByTeamIDRow, error) {
+	rows, err := q.db.Query(ctx, listArchiveTeamHolidaysByTeamID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListArchiveTeamHolidaysByTeamIDRow
+	for rows.Next() {
+		var i ListArchiveTeamHolidaysByTeamIDRow
+		if err := rows.Scan(
+			&i.HolidayDate,
+			&i.Name,
+			&i.Source,
+			&i.CreatedByUserID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listArchiveTriggeredRulesByTeamID = `-- name: ListArchiveTriggeredRulesByTeamID :many
+SELECT month_start, rule_id, created_at
+FROM monthly_penalty_summary_triggered_rules
+WHERE team_id = $1
+ORDER BY month_start, rule_id
+`
+
+type ListArchiveTriggeredRulesByTeamIDRow struct {
+	MonthStart pgtype.Date        `json:"month_start"`
+	RuleID     string             `json:"rule_id"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+func (q *Queries) ListArchiveTriggeredRulesByTeamID(ctx context.Context, teamID string) ([]ListArchiveTriggeredRulesByTeamIDRow, error) {
+	rows, err := q.db.Query(ctx, listArchiveTriggeredRulesByTeamID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListArchiveTriggeredRulesByTeamIDRow
+	for rows.Next() {
+		var i ListArchiveTriggeredRulesByTeamIDRow
+		if err := rows.Scan(&i.MonthStart, &i.RuleID, &i.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listArchiveWeeklyEntriesByTeamID = `-- name: ListArchiveWeeklyEntriesByTeamID :many
+SELECT
+  e.task_id,
+  e.week_start,
+  COALESCE(e.completed_by_user_id::text, ''::text) AS completed_by_user_id,
+  e.created_at
+FROM task_completion_weekly_entries e
+JOIN tasks t ON t.id = e.task_id
+WHERE t.team_id = $1
+ORDER BY e.week_start, e.task_id, e.created_at, e.id
+`
+
+type ListArchiveWeeklyEntriesByTeamIDRow struct {
+	TaskID            string             `json:"task_id"`
+	WeekStart         pgtype.Date        `json:"week_start"`
+	CompletedByUserID interface{}        `json:"completed_by_user_id"`
+	CreatedAt         pgtype.Timestamptz `json:"created_at"`
+}
+
+func (q *Queries) ListArchiveWeeklyEntriesByTeamID(ctx context.Context, teamID string) ([]ListArchiveWeeklyEntriesByTeamIDRow, error) {
+	rows, err := q.db.Query(ctx, listArchiveWeeklyEntriesByTeamID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListArchiveWeeklyEntriesByTeamIDRow
+	for rows.Next() {
+		var i ListArchiveWeeklyEntriesByTeamIDRow
+		if err := rows.Scan(
+			&i.TaskID,
+			&i.WeekStart,
+			&i.CompletedByUserID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listExistingUserEmails = `-- name: ListExistingUserEmails :many
+SELECT email
+FROM users
+WHERE LOWER(email) = ANY($1::text[])
+ORDER BY email
+`
+
+func (q *Queries) ListExistingUserEmails(ctx context.Context, emails []string) ([]string, error) {
+	rows, err := q.db.Query(ctx, listExistingUserEmails, emails)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var email string
+		if err := rows.Scan(&email); err != nil {
+			return nil, err
+		}
+		items = append(items, email)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
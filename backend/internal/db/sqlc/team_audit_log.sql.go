@@ -0,0 +1,139 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: team_audit_log.sql
+
+package dbsqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const createTeamAuditEntry = `-- name: CreateTeamAuditEntry :exec
+INSERT INTO team_audit_log (id, team_id, actor_user_id, entity, entity_id, action, before, after, revision, created_at)
+VALUES (
+  $1,
+  $2,
+  NULLIF($3::text, '')::uuid,
+  $4,
+  $5,
+  $6,
+  $7,
+  $8,
+  $9,
+  $10
+)
+`
+
+type CreateTeamAuditEntryParams struct {
+	ID          string             `json:"id"`
+	TeamID      string             `json:"team_id"`
+	ActorUserID string             `json:"actor_user_id"`
+	Entity      string             `json:"entity"`
+	EntityID    pgtype.Text        `json:"entity_id"`
+	Action      string             `json:"action"`
+	Before      []byte             `json:"before"`
+	After       []byte             `json:"after"`
+	Revision    int64              `json:"revision"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+func (q *Queries) CreateTeamAuditEntry(ctx context.Context, arg CreateTeamAuditEntryParams) error {
+	_, err := q.db.Exec(ctx, createTeamAuditEntry,
+		arg.ID,
+		arg.TeamID,
+		arg.ActorUserID,
+		arg.Entity,
+		arg.EntityID,
+		arg.Action,
+		arg.Before,
+		arg.After,
+		arg.Revision,
+		arg.CreatedAt,
+	)
+	return err
+}
+
+const listTeamAuditEntries = `-- name: ListTeamAuditEntries :many
+SELECT
+  a.id,
+  a.entity,
+  a.entity_id,
+  a.action,
+  a.before,
+  a.after,
+  a.revision,
+  a.created_at,
+  COALESCE(a.actor_user_id::text, ''::text) AS actor_user_id,
+  COALESCE(NULLIF(u.nickname, ''), u.display_name, ''::text) AS actor_effective_name,
+  u.color_hex AS actor_color_hex
+FROM team_audit_log a
+LEFT JOIN users u ON u.id = a.actor_user_id
+WHERE a.team_id = $1
+  AND (
+    $2::timestamptz IS NULL
+    OR (a.created_at, a.id) < ($2::timestamptz, NULLIF($3::text, '')::uuid)
+  )
+ORDER BY a.created_at DESC, a.id DESC
+LIMIT $4
+`
+
+type ListTeamAuditEntriesParams struct {
+	TeamID          string             `json:"team_id"`
+	BeforeCreatedAt pgtype.Timestamptz `json:"before_created_at"`
+	BeforeID        string             `json:"before_id"`
+	RowLimit        int32              `json:"row_limit"`
+}
+
+type ListTeamAuditEntriesRow struct {
+	ID                 string             `json:"id"`
+	Entity             string             `json:"entity"`
+	EntityID           pgtype.Text        `json:"entity_id"`
+	Action             string             `json:"action"`
+	Before             []byte             `json:"before"`
+	After              []byte             `json:"after"`
+	Revision           int64              `json:"revision"`
+	CreatedAt          pgtype.Timestamptz `json:"created_at"`
+	ActorUserID        interface{}        `json:"actor_user_id"`
+	ActorEffectiveName string             `json:"actor_effective_name"`
+	ActorColorHex      pgtype.Text        `json:"actor_color_hex"`
+}
+
+func (q *Queries) ListTeamAuditEntries(ctx context.Context, arg ListTeamAuditEntriesParams) ([]ListTeamAuditEntriesRow, error) {
+	rows, err := q.db.Query(ctx, listTeamAuditEntries,
+		arg.TeamID,
+		arg.BeforeCreatedAt,
+		arg.BeforeID,
+		arg.RowLimit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTeamAuditEntriesRow
+	for rows.Next() {
+		var i ListTeamAuditEntriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Entity,
+			&i.EntityID,
+			&i.Action,
+			&i.Before,
+			&i.After,
+			&i.Revision,
+			&i.CreatedAt,
+			&i.ActorUserID,
+			&i.ActorEffectiveName,
+			&i.ActorColorHex,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -97,6 +97,24 @@ func (q *Queries) GetOldestOtherTeamMember(ctx context.Context, arg GetOldestOth
 	return user_id, err
 }
 
+const getTeamSettings = `-- name: GetTeamSettings :one
+SELECT name, settings_revision
+FROM teams
+WHERE id = $1
+`
+
+type GetTeamSettingsRow struct {
+	Name             string `json:"name"`
+	SettingsRevision int64  `json:"settings_revision"`
+}
+
+func (q *Queries) GetTeamSettings(ctx context.Context, id string) (GetTeamSettingsRow, error) {
+	row := q.db.QueryRow(ctx, getTeamSettings, id)
+	var i GetTeamSettingsRow
+	err := row.Scan(&i.Name, &i.SettingsRevision)
+	return i, err
+}
+
 const getTeamStateRevision = `-- name: GetTeamStateRevision :one
 SELECT state_revision
 FROM teams
@@ -110,6 +128,20 @@ func (q *Queries) GetTeamStateRevision(ctx context.Context, id string) (int64, e
 	return state_revision, err
 }
 
+const getTeamStateRevisionForUpdate = `-- name: GetTeamStateRevisionForUpdate :one
+SELECT state_revision
+FROM teams
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) GetTeamStateRevisionForUpdate(ctx context.Context, id string) (int64, error) {
+	row := q.db.QueryRow(ctx, getTeamStateRevisionForUpdate, id)
+	var state_revision int64
+	err := row.Scan(&state_revision)
+	return state_revision, err
+}
+
 const listMembershipsByUserID = `-- name: ListMembershipsByUserID :many
 SELECT tm.team_id, tm.role, t.name AS team_name
 FROM team_members tm
@@ -247,17 +279,19 @@ func (q *Queries) UpdateTeamMemberRole(ctx context.Context, arg UpdateTeamMember
 
 const updateTeamName = `-- name: UpdateTeamName :exec
 UPDATE teams
-SET name = $2
+SET name = $2,
+    settings_revision = $3
 WHERE id = $1
 `
 
 type UpdateTeamNameParams struct {
-	ID   string `json:"id"`
-	Name string `json:"name"`
+	ID               string `json:"id"`
+	Name             string `json:"name"`
+	SettingsRevision int64  `json:"settings_revision"`
 }
 
 func (q *Queries) UpdateTeamName(ctx context.Context, arg UpdateTeamNameParams) error {
-	_, err := q.db.Exec(ctx, updateTeamName, arg.ID, arg.Name)
+	_, err := q.db.Exec(ctx, updateTeamName, arg.ID, arg.Name, arg.SettingsRevision)
 	return err
 }
 
@@ -11,6 +11,40 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const anonymizeUser = `-- name: AnonymizeUser :execrows
+UPDATE users
+SET email = $1,
+    display_name = $2,
+    nickname = NULL,
+    color_hex = NULL,
+    oidc_issuer = NULL,
+    oidc_subject = NULL,
+    oidc_linked_at = NULL,
+    deleted_at = $3
+WHERE id = $4
+  AND deleted_at IS NULL
+`
+
+type AnonymizeUserParams struct {
+	Email       string             `json:"email"`
+	DisplayName string             `json:"display_name"`
+	DeletedAt   pgtype.Timestamptz `json:"deleted_at"`
+	ID          string             `json:"id"`
+}
+
+func (q *Queries) AnonymizeUser(ctx context.Context, arg AnonymizeUserParams) (int64, error) {
+	result, err := q.db.Exec(ctx, anonymizeUser,
+		arg.Email,
+		arg.DisplayName,
+		arg.DeletedAt,
+		arg.ID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const createUser = `-- name: CreateUser :exec
 INSERT INTO users (id, email, display_name, created_at)
 VALUES ($1, $2, $3, $4)
@@ -0,0 +1,385 @@
+// Code generated by sqlc. DO NOT EDIT.
+// versions:
+//   sqlc v1.27.0
+// source: webhooks.sql
+
+package dbsqlc
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const claimDueTeamWebhookDeliveries = `-- name: ClaimDueTeamWebhookDeliveries :many
+UPDATE team_webhook_deliveries d
+SET next_attempt_at = $1
+FROM team_webhooks w
+WHERE w.id = d.webhook_id
+  AND d.id IN (
+    SELECT due.id
+    FROM team_webhook_deliveries due
+    WHERE due.status = 'pending'
+      AND due.next_attempt_at <= $2
+    ORDER BY due.next_attempt_at ASC
+    LIMIT $3
+    FOR UPDATE SKIP LOCKED
+  )
+RETURNING d.id, d.webhook_id, d.team_id, d.event_type, d.payload, d.attempt_count, w.url, w.secret, w.is_active
+`
+
+type ClaimDueTeamWebhookDeliveriesParams struct {
+	LeaseUntil pgtype.Timestamptz `json:"lease_until"`
+	DueBefore  pgtype.Timestamptz `json:"due_before"`
+	BatchSize  int32              `json:"batch_size"`
+}
+
+type ClaimDueTeamWebhookDeliveriesRow struct {
+	ID           string `json:"id"`
+	WebhookID    string `json:"webhook_id"`
+	TeamID       string `json:"team_id"`
+	EventType    string `json:"event_type"`
+	Payload      []byte `json:"payload"`
+	AttemptCount int32  `json:"attempt_count"`
+	Url          string `json:"url"`
+	Secret       string `json:"secret"`
+	IsActive     bool   `json:"is_active"`
+}
+
+func (q *Queries) ClaimDueTeamWebhookDeliveries(ctx context.Context, arg ClaimDueTeamWebhookDeliveriesParams) ([]ClaimDueTeamWebhookDeliveriesRow, error) {
+	rows, err := q.db.Query(ctx, claimDueTeamWebhookDeliveries, arg.LeaseUntil, arg.DueBefore, arg.BatchSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ClaimDueTeamWebhookDeliveriesRow
+	for rows.Next() {
+		var i ClaimDueTeamWebhookDeliveriesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.WebhookID,
+			&i.TeamID,
+			&i.EventType,
+			&i.Payload,
+			&i.AttemptCount,
+			&i.Url,
+			&i.Secret,
+			&i.IsActive,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const createTeamWebhook = `-- name: CreateTeamWebhook :exec
+INSERT INTO team_webhooks (id, team_id, url, secret, event_types, is_active, created_at, updated_at)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+`
+
+type CreateTeamWebhookParams struct {
+	ID         string             `json:"id"`
+	TeamID     string             `json:"team_id"`
+	Url        string             `json:"url"`
+	Secret     string             `json:"secret"`
+	EventTypes []string           `json:"event_types"`
+	IsActive   bool               `json:"is_active"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+}
+
+func (q *Queries) CreateTeamWebhook(ctx context.Context, arg CreateTeamWebhookParams) error {
+	_, err := q.db.Exec(ctx, createTeamWebhook,
+		arg.ID,
+		arg.TeamID,
+		arg.Url,
+		arg.Secret,
+		arg.EventTypes,
+		arg.IsActive,
+		arg.CreatedAt,
+		arg.UpdatedAt,
+	)
+	return err
+}
+
+const createTeamWebhookDelivery = `-- name: CreateTeamWebhookDelivery :exec
+INSERT INTO team_webhook_deliveries (id, webhook_id, team_id, event_type, payload, next_attempt_at, created_at)
+VALUES ($1, $2, $3, $4, $5, $6, $6)
+`
+
+type CreateTeamWebhookDeliveryParams struct {
+	ID            string             `json:"id"`
+	WebhookID     string             `json:"webhook_id"`
+	TeamID        string             `json:"team_id"`
+	EventType     string             `json:"event_type"`
+	Payload       []byte             `json:"payload"`
+	NextAttemptAt pgtype.Timestamptz `json:"next_attempt_at"`
+}
+
+func (q *Queries) CreateTeamWebhookDelivery(ctx context.Context, arg CreateTeamWebhookDeliveryParams) error {
+	_, err := q.db.Exec(ctx, createTeamWebhookDelivery,
+		arg.ID,
+		arg.WebhookID,
+		arg.TeamID,
+		arg.EventType,
+		arg.Payload,
+		arg.NextAttemptAt,
+	)
+	return err
+}
+
+const deleteTeamWebhook = `-- name: DeleteTeamWebhook :execrows
+DELETE FROM team_webhooks
+WHERE id = $1
+  AND team_id = $2
+`
+
+type DeleteTeamWebhookParams struct {
+	ID     string `json:"id"`
+	TeamID string `json:"team_id"`
+}
+
+func (q *Queries) DeleteTeamWebhook(ctx context.Context, arg DeleteTeamWebhookParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteTeamWebhook, arg.ID, arg.TeamID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const getTeamWebhookByID = `-- name: GetTeamWebhookByID :one
+SELECT id, team_id, url, secret, event_types, is_active, created_at, updated_at
+FROM team_webhooks
+WHERE id = $1
+`
+
+func (q *Queries) GetTeamWebhookByID(ctx context.Context, id string) (TeamWebhook, error) {
+	row := q.db.QueryRow(ctx, getTeamWebhookByID, id)
+	var i TeamWebhook
+	err := row.Scan(
+		&i.ID,
+		&i.TeamID,
+		&i.Url,
+		&i.Secret,
+		&i.EventTypes,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const listActiveTeamWebhooksForEvent = `-- name: ListActiveTeamWebhooksForEvent :many
+SELECT id, team_id, url, secret, event_types, is_active, created_at, updated_at
+FROM team_webhooks
+WHERE team_id = $1
+  AND is_active = TRUE
+  AND (cardinality(event_types) = 0 OR $2::text = ANY(event_types))
+ORDER BY created_at ASC, id ASC
+`
+
+type ListActiveTeamWebhooksForEventParams struct {
+	TeamID    string `json:"team_id"`
+	EventType string `json:"event_type"`
+}
+
+func (q *Queries) ListActiveTeamWebhooksForEvent(ctx context.Context, arg ListActiveTeamWebhooksForEventParams) ([]TeamWebhook, error) {
+	rows, err := q.db.Query(ctx, listActiveTeamWebhooksForEvent, arg.TeamID, arg.EventType)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TeamWebhook
+	for rows.Next() {
+		var i TeamWebhook
+		if err := rows.Scan(
+			&i.ID,
+			&i.TeamID,
+			&i.Url,
+			&i.Secret,
+			&i.EventTypes,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTeamWebhookDeliveriesByWebhookID = `-- name: ListTeamWebhookDeliveriesByWebhookID :many
+SELECT id, webhook_id, team_id, event_type, payload, status, attempt_count, next_attempt_at, last_attempt_at, last_response_status, last_error, delivered_at, created_at
+FROM team_webhook_deliveries
+WHERE webhook_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT $2
+`
+
+type ListTeamWebhookDeliveriesByWebhookIDParams struct {
+	WebhookID string `json:"webhook_id"`
+	Limit     int32  `json:"limit"`
+}
+
+func (q *Queries) ListTeamWebhookDeliveriesByWebhookID(ctx context.Context, arg ListTeamWebhookDeliveriesByWebhookIDParams) ([]TeamWebhookDelivery, error) {
+	rows, err := q.db.Query(ctx, listTeamWebhookDeliveriesByWebhookID, arg.WebhookID, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TeamWebhookDelivery
+	for rows.Next() {
+		var i TeamWebhookDelivery
+		if err := rows.Scan(
+			&i.ID,
+			&i.WebhookID,
+			&i.TeamID,
+			&i.EventType,
+			&i.Payload,
+			&i.Status,
+			&i.AttemptCount,
+			&i.NextAttemptAt,
+			&i.LastAttemptAt,
+			&i.LastResponseStatus,
+			&i.LastError,
+			&i.DeliveredAt,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTeamWebhooksByTeamID = `-- name: ListTeamWebhooksByTeamID :many
+SELECT id, team_id, url, secret, event_types, is_active, created_at, updated_at
+FROM team_webhooks
+WHERE team_id = $1
+ORDER BY created_at ASC, id ASC
+`
+
+func (q *Queries) ListTeamWebhooksByTeamID(ctx context.Context, teamID string) ([]TeamWebhook, error) {
+	rows, err := q.db.Query(ctx, listTeamWebhooksByTeamID, teamID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TeamWebhook
+	for rows.Next() {
+		var i TeamWebhook
+		if err := rows.Scan(
+			&i.ID,
+			&i.TeamID,
+			&i.Url,
+			&i.Secret,
+			&i.EventTypes,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const markTeamWebhookDeliveryAttemptFailed = `-- name: MarkTeamWebhookDeliveryAttemptFailed :exec
+UPDATE team_webhook_deliveries
+SET status = $1,
+    attempt_count = attempt_count + 1,
+    last_attempt_at = $2,
+    next_attempt_at = $3,
+    last_response_status = $4,
+    last_error = $5
+WHERE id = $6
+`
+
+type MarkTeamWebhookDeliveryAttemptFailedParams struct {
+	Status         string             `json:"status"`
+	AttemptedAt    pgtype.Timestamptz `json:"attempted_at"`
+	NextAttemptAt  pgtype.Timestamptz `json:"next_attempt_at"`
+	ResponseStatus pgtype.Int4        `json:"response_status"`
+	LastError      pgtype.Text        `json:"last_error"`
+	ID             string             `json:"id"`
+}
+
+func (q *Queries) MarkTeamWebhookDeliveryAttemptFailed(ctx context.Context, arg MarkTeamWebhookDeliveryAttemptFailedParams) error {
+	_, err := q.db.Exec(ctx, markTeamWebhookDeliveryAttemptFailed,
+		arg.Status,
+		arg.AttemptedAt,
+		arg.NextAttemptAt,
+		arg.ResponseStatus,
+		arg.LastError,
+		arg.ID,
+	)
+	return err
+}
+
+const markTeamWebhookDeliverySucceeded = `-- name: MarkTeamWebhookDeliverySucceeded :exec
+UPDATE team_webhook_deliveries
+SET status = 'succeeded',
+    attempt_count = attempt_count + 1,
+    last_attempt_at = $1,
+    last_response_status = $2,
+    last_error = NULL,
+    delivered_at = $1
+WHERE id = $3
+`
+
+type MarkTeamWebhookDeliverySucceededParams struct {
+	AttemptedAt    pgtype.Timestamptz `json:"attempted_at"`
+	ResponseStatus pgtype.Int4        `json:"response_status"`
+	ID             string             `json:"id"`
+}
+
+func (q *Queries) MarkTeamWebhookDeliverySucceeded(ctx context.Context, arg MarkTeamWebhookDeliverySucceededParams) error {
+	_, err := q.db.Exec(ctx, markTeamWebhookDeliverySucceeded, arg.AttemptedAt, arg.ResponseStatus, arg.ID)
+	return err
+}
+
+const updateTeamWebhook = `-- name: UpdateTeamWebhook :exec
+UPDATE team_webhooks
+SET url = $2,
+    secret = $3,
+    event_types = $4,
+    is_active = $5,
+    updated_at = $6
+WHERE id = $1
+`
+
+type UpdateTeamWebhookParams struct {
+	ID         string             `json:"id"`
+	Url        string             `json:"url"`
+	Secret     string             `json:"secret"`
+	EventTypes []string           `json:"event_types"`
+	IsActive   bool               `json:"is_active"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+}
+
+func (q *Queries) UpdateTeamWebhook(ctx context.Context, arg UpdateTeamWebhookParams) error {
+	_, err := q.db.Exec(ctx, updateTeamWebhook,
+		arg.ID,
+		arg.Url,
+		arg.Secret,
+		arg.EventTypes,
+		arg.IsActive,
+		arg.UpdatedAt,
+	)
+	return err
+}
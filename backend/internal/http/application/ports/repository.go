@@ -19,20 +19,35 @@ type TeamRepository interface {
 	GetMe(ctx context.Context, userID string) (api.MeResponse, error)
 	PatchMeNickname(ctx context.Context, userID string, req api.UpdateNicknameRequest) (api.UpdateNicknameResponse, error)
 	PatchMeColor(ctx context.Context, userID string, req api.UpdateColorRequest) (api.UpdateColorResponse, error)
+	GetMeExport(ctx context.Context, userID string, refresh bool) (PersonalDataExport, error)
+	DeleteMe(ctx context.Context, userID string) error
 	CreateInvite(ctx context.Context, userID string, req api.CreateInviteRequest) (api.InviteCodeResponse, error)
 	GetTeamCurrentInvite(ctx context.Context, userID string) (api.InviteCodeResponse, error)
 	PatchTeamCurrent(ctx context.Context, userID string, req api.UpdateCurrentTeamRequest) (api.TeamInfoResponse, error)
 	GetTeamCurrentMembers(ctx context.Context, userID string) (api.TeamMembersResponse, error)
+	ListTeamActivity(ctx context.Context, userID string, limit *int, cursor *string) (api.TeamActivityPage, error)
 	JoinTeam(ctx context.Context, userID, code string) (api.JoinTeamResponse, error)
 	PostTeamLeave(ctx context.Context, userID string) (api.JoinTeamResponse, error)
 }
 
 type TaskRepository interface {
-	ListTasks(ctx context.Context, userID string, filter *api.TaskType) ([]api.Task, error)
+	ListTasks(ctx context.Context, userID string, filter *api.TaskType, categoryID *string) ([]api.Task, error)
 	CreateTask(ctx context.Context, userID string, req api.CreateTaskRequest) (api.Task, error)
 	PatchTask(ctx context.Context, userID, taskID string, req api.UpdateTaskRequest) (api.Task, error)
 	DeleteTask(ctx context.Context, userID, taskID string) error
 	ToggleTaskCompletion(ctx context.Context, userID, taskID string, target time.Time, action *api.ToggleTaskCompletionRequestAction) (api.TaskCompletionResponse, error)
+	ListTaskChecklistItems(ctx context.Context, userID, taskID string) ([]api.TaskChecklistItem, error)
+	CreateTaskChecklistItem(ctx context.Context, userID, taskID string, req api.CreateTaskChecklistItemRequest) (api.TaskChecklistItem, error)
+	PatchTaskChecklistItem(ctx context.Context, userID, taskID, itemID string, req api.UpdateTaskChecklistItemRequest) (api.TaskChecklistItem, error)
+	DeleteTaskChecklistItem(ctx context.Context, userID, taskID, itemID string) error
+	ToggleTaskChecklistItem(ctx context.Context, userID, taskID, itemID string, target time.Time) (api.TaskChecklistToggleResponse, error)
+	SaveTaskCompletionEvidence(ctx context.Context, userID, taskID string, upload CompletionEvidenceUpload) (api.TaskCompletionEvidenceResponse, error)
+	GetCompletionAttachment(ctx context.Context, userID, attachmentID string, thumbnail bool) (CompletionAttachmentContent, error)
+	ListTaskComments(ctx context.Context, userID, taskID string, limit *int, cursor *string) (api.TaskCommentPage, error)
+	CreateTaskComment(ctx context.Context, userID, taskID string, req api.CreateTaskCommentRequest) (api.TaskComment, error)
+	PatchTaskComment(ctx context.Context, userID, taskID, commentID string, req api.UpdateTaskCommentRequest) (api.TaskComment, error)
+	DeleteTaskComment(ctx context.Context, userID, taskID, commentID string) error
+	ToggleCompletionReaction(ctx context.Context, userID, taskID string, req api.ToggleCompletionReactionRequest) (api.CompletionReactionResponse, error)
 }
 
 type PenaltyRepository interface {
@@ -43,7 +58,7 @@ type PenaltyRepository interface {
 }
 
 type TaskOverviewRepository interface {
-	GetTaskOverview(ctx context.Context, userID string) (api.TaskOverviewResponse, error)
+	GetTaskOverview(ctx context.Context, userID string, categoryID *string) (api.TaskOverviewResponse, error)
 	GetMonthlySummary(ctx context.Context, userID string, month *string) (api.MonthlyPenaltySummary, error)
 }
 
@@ -51,6 +66,46 @@ type AdminRepository interface {
 	CloseDayForUser(ctx context.Context, userID string) (api.CloseResponse, error)
 	CloseWeekForUser(ctx context.Context, userID string) (api.CloseResponse, error)
 	CloseMonthForUser(ctx context.Context, userID string) (api.CloseResponse, error)
+	ListCloseRuns(ctx context.Context, userID string, limit *int) (api.CloseRunsResponse, error)
+}
+
+type BatchRepository interface {
+	ApplyBatch(ctx context.Context, userID string, req api.BatchMutationRequest) (BatchResult, error)
+}
+
+type WebhookRepository interface {
+	ListTeamWebhooks(ctx context.Context, userID string) ([]api.TeamWebhook, error)
+	CreateTeamWebhook(ctx context.Context, userID string, req api.CreateTeamWebhookRequest) (api.TeamWebhookCreatedResponse, error)
+	PatchTeamWebhook(ctx context.Context, userID, webhookID string, req api.UpdateTeamWebhookRequest) (api.TeamWebhook, error)
+	DeleteTeamWebhook(ctx context.Context, userID, webhookID string) error
+	ListTeamWebhookDeliveries(ctx context.Context, userID, webhookID string, limit *int) ([]api.TeamWebhookDelivery, error)
+}
+
+type AbsenceRepository interface {
+	ListTeamAbsences(ctx context.Context, userID string, includePast bool) ([]api.TeamAbsence, error)
+	CreateTeamAbsence(ctx context.Context, userID string, req api.CreateTeamAbsenceRequest) (api.TeamAbsence, error)
+	DeleteTeamAbsence(ctx context.Context, userID, absenceID string) error
+}
+
+type HolidayRepository interface {
+	ListTeamHolidays(ctx context.Context, userID string, from, to *time.Time) ([]api.TeamHoliday, error)
+	CreateTeamHoliday(ctx context.Context, userID string, req api.CreateTeamHolidayRequest) (api.TeamHoliday, error)
+	ImportTeamHolidays(ctx context.Context, userID string, req api.ImportTeamHolidaysRequest) (api.ImportTeamHolidaysResponse, error)
+	DeleteTeamHoliday(ctx context.Context, userID string, date time.Time) error
+}
+
+type TaskCategoryRepository interface {
+	ListTaskCategories(ctx context.Context, userID string) ([]api.TaskCategory, error)
+	CreateTaskCategory(ctx context.Context, userID string, req api.CreateTaskCategoryRequest) (api.TaskCategory, error)
+	PatchTaskCategory(ctx context.Context, userID, categoryID string, req api.UpdateTaskCategoryRequest) (api.TaskCategory, error)
+	DeleteTaskCategory(ctx context.Context, userID, categoryID string) error
+}
+
+type TaskTemplateRepository interface {
+	ListTaskTemplates(ctx context.Context, userID string) ([]api.TaskTemplate, error)
+	CreateTaskTemplate(ctx context.Context, userID string, req api.CreateTaskTemplateRequest) (api.TaskTemplate, error)
+	DeleteTaskTemplate(ctx context.Context, userID, templateID string) error
+	InstantiateTaskTemplate(ctx context.Context, userID, templateID string, req api.InstantiateTaskTemplateRequest) ([]api.Task, error)
 }
 
 type Dependencies struct {
@@ -60,4 +115,10 @@ type Dependencies struct {
 	PenaltyRepo      PenaltyRepository
 	TaskOverviewRepo TaskOverviewRepository
 	AdminRepo        AdminRepository
+	BatchRepo        BatchRepository
+	WebhookRepo      WebhookRepository
+	AbsenceRepo      AbsenceRepository
+	HolidayRepo      HolidayRepository
+	CategoryRepo     TaskCategoryRepository
+	TemplateRepo     TaskTemplateRepository
 }
@@ -2,6 +2,7 @@ package ports
 
 import (
 	"context"
+	"io"
 	"time"
 
 	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
@@ -14,6 +15,12 @@ type Services struct {
 	Penalty      PenaltyService
 	TaskOverview TaskOverviewService
 	Admin        AdminService
+	Batch        BatchService
+	Webhook      WebhookService
+	Absence      AbsenceService
+	Holiday      HolidayService
+	Category     TaskCategoryService
+	Template     TaskTemplateService
 }
 
 type AuthSession struct {
@@ -21,6 +28,42 @@ type AuthSession struct {
 	User  api.User
 }
 
+// PersonalDataExport is the state of a user's data export. Payload is set
+// only once the bundle is ready to download.
+type PersonalDataExport struct {
+	Status  api.PersonalDataExport
+	Payload []byte
+}
+
+// CompletionEvidenceUpload is a parsed evidence form. Note and Image are nil
+// when the field was not sent; a nil Slot picks the latest weekly
+// completion.
+type CompletionEvidenceUpload struct {
+	TargetDate  time.Time
+	Slot        *int
+	Note        *string
+	Image       []byte
+	RemoveImage bool
+}
+
+// CompletionAttachmentContent is an opened completion photo. The caller must
+// close Body.
+type CompletionAttachmentContent struct {
+	ContentType string
+	Body        io.ReadCloser
+}
+
+type BatchOperationOutcome struct {
+	Result api.BatchOperationResult
+	Err    error
+}
+
+type BatchResult struct {
+	Committed  bool
+	ETag       string
+	Operations []BatchOperationOutcome
+}
+
 type AuthService interface {
 	StartGoogleAuth(ctx context.Context) (api.AuthStartResponse, error)
 	CompleteGoogleAuth(ctx context.Context, code, state, mockEmail, mockName, mockSub, mockIss string) (string, string, error)
@@ -33,20 +76,35 @@ type TeamService interface {
 	GetMe(ctx context.Context, userID string) (api.MeResponse, error)
 	PatchMeNickname(ctx context.Context, userID string, req api.UpdateNicknameRequest) (api.UpdateNicknameResponse, error)
 	PatchMeColor(ctx context.Context, userID string, req api.UpdateColorRequest) (api.UpdateColorResponse, error)
+	GetMeExport(ctx context.Context, userID string, refresh bool) (PersonalDataExport, error)
+	DeleteMe(ctx context.Context, userID string) error
 	CreateInvite(ctx context.Context, userID string, req api.CreateInviteRequest) (api.InviteCodeResponse, error)
 	GetTeamCurrentInvite(ctx context.Context, userID string) (api.InviteCodeResponse, error)
 	PatchTeamCurrent(ctx context.Context, userID string, req api.UpdateCurrentTeamRequest) (api.TeamInfoResponse, error)
 	GetTeamCurrentMembers(ctx context.Context, userID string) (api.TeamMembersResponse, error)
+	ListTeamActivity(ctx context.Context, userID string, limit *int, cursor *string) (api.TeamActivityPage, error)
 	JoinTeam(ctx context.Context, userID, code string) (api.JoinTeamResponse, error)
 	PostTeamLeave(ctx context.Context, userID string) (api.JoinTeamResponse, error)
 }
 
 type TaskService interface {
-	ListTasks(ctx context.Context, userID string, filter *api.TaskType) ([]api.Task, error)
+	ListTasks(ctx context.Context, userID string, filter *api.TaskType, categoryID *string) ([]api.Task, error)
 	CreateTask(ctx context.Context, userID string, req api.CreateTaskRequest) (api.Task, error)
 	PatchTask(ctx context.Context, userID, taskID string, req api.UpdateTaskRequest) (api.Task, error)
 	DeleteTask(ctx context.Context, userID, taskID string) error
 	ToggleTaskCompletion(ctx context.Context, userID, taskID string, target time.Time, action *api.ToggleTaskCompletionRequestAction) (api.TaskCompletionResponse, error)
+	ListTaskChecklistItems(ctx context.Context, userID, taskID string) ([]api.TaskChecklistItem, error)
+	CreateTaskChecklistItem(ctx context.Context, userID, taskID string, req api.CreateTaskChecklistItemRequest) (api.TaskChecklistItem, error)
+	PatchTaskChecklistItem(ctx context.Context, userID, taskID, itemID string, req api.UpdateTaskChecklistItemRequest) (api.TaskChecklistItem, error)
+	DeleteTaskChecklistItem(ctx context.Context, userID, taskID, itemID string) error
+	ToggleTaskChecklistItem(ctx context.Context, userID, taskID, itemID string, target time.Time) (api.TaskChecklistToggleResponse, error)
+	SaveTaskCompletionEvidence(ctx context.Context, userID, taskID string, upload CompletionEvidenceUpload) (api.TaskCompletionEvidenceResponse, error)
+	GetCompletionAttachment(ctx context.Context, userID, attachmentID string, thumbnail bool) (CompletionAttachmentContent, error)
+	ListTaskComments(ctx context.Context, userID, taskID string, limit *int, cursor *string) (api.TaskCommentPage, error)
+	CreateTaskComment(ctx context.Context, userID, taskID string, req api.CreateTaskCommentRequest) (api.TaskComment, error)
+	PatchTaskComment(ctx context.Context, userID, taskID, commentID string, req api.UpdateTaskCommentRequest) (api.TaskComment, error)
+	DeleteTaskComment(ctx context.Context, userID, taskID, commentID string) error
+	ToggleCompletionReaction(ctx context.Context, userID, taskID string, req api.ToggleCompletionReactionRequest) (api.CompletionReactionResponse, error)
 }
 
 type PenaltyService interface {
@@ -57,7 +115,7 @@ type PenaltyService interface {
 }
 
 type TaskOverviewService interface {
-	GetTaskOverview(ctx context.Context, userID string) (api.TaskOverviewResponse, error)
+	GetTaskOverview(ctx context.Context, userID string, categoryID *string) (api.TaskOverviewResponse, error)
 	GetMonthlySummary(ctx context.Context, userID string, month *string) (api.MonthlyPenaltySummary, error)
 }
 
@@ -65,4 +123,44 @@ type AdminService interface {
 	CloseDayForUser(ctx context.Context, userID string) (api.CloseResponse, error)
 	CloseWeekForUser(ctx context.Context, userID string) (api.CloseResponse, error)
 	CloseMonthForUser(ctx context.Context, userID string) (api.CloseResponse, error)
+	ListCloseRuns(ctx context.Context, userID string, limit *int) (api.CloseRunsResponse, error)
+}
+
+type BatchService interface {
+	ApplyBatch(ctx context.Context, userID string, req api.BatchMutationRequest) (BatchResult, error)
+}
+
+type WebhookService interface {
+	ListTeamWebhooks(ctx context.Context, userID string) ([]api.TeamWebhook, error)
+	CreateTeamWebhook(ctx context.Context, userID string, req api.CreateTeamWebhookRequest) (api.TeamWebhookCreatedResponse, error)
+	PatchTeamWebhook(ctx context.Context, userID, webhookID string, req api.UpdateTeamWebhookRequest) (api.TeamWebhook, error)
+	DeleteTeamWebhook(ctx context.Context, userID, webhookID string) error
+	ListTeamWebhookDeliveries(ctx context.Context, userID, webhookID string, limit *int) ([]api.TeamWebhookDelivery, error)
+}
+
+type AbsenceService interface {
+	ListTeamAbsences(ctx context.Context, userID string, includePast bool) ([]api.TeamAbsence, error)
+	CreateTeamAbsence(ctx context.Context, userID string, req api.CreateTeamAbsenceRequest) (api.TeamAbsence, error)
+	DeleteTeamAbsence(ctx context.Context, userID, absenceID string) error
+}
+
+type HolidayService interface {
+	ListTeamHolidays(ctx context.Context, userID string, from, to *time.Time) ([]api.TeamHoliday, error)
+	CreateTeamHoliday(ctx context.Context, userID string, req api.CreateTeamHolidayRequest) (api.TeamHoliday, error)
+	ImportTeamHolidays(ctx context.Context, userID string, req api.ImportTeamHolidaysRequest) (api.ImportTeamHolidaysResponse, error)
+	DeleteTeamHoliday(ctx context.Context, userID string, date time.Time) error
+}
+
+type TaskCategoryService interface {
+	ListTaskCategories(ctx context.Context, userID string) ([]api.TaskCategory, error)
+	CreateTaskCategory(ctx context.Context, userID string, req api.CreateTaskCategoryRequest) (api.TaskCategory, error)
+	PatchTaskCategory(ctx context.Context, userID, categoryID string, req api.UpdateTaskCategoryRequest) (api.TaskCategory, error)
+	DeleteTaskCategory(ctx context.Context, userID, categoryID string) error
+}
+
+type TaskTemplateService interface {
+	ListTaskTemplates(ctx context.Context, userID string) ([]api.TaskTemplate, error)
+	CreateTaskTemplate(ctx context.Context, userID string, req api.CreateTaskTemplateRequest) (api.TaskTemplate, error)
+	DeleteTaskTemplate(ctx context.Context, userID, templateID string) error
+	InstantiateTaskTemplate(ctx context.Context, userID, templateID string, req api.InstantiateTaskTemplateRequest) ([]api.Task, error)
 }
@@ -8,6 +8,12 @@ type taskUsecase struct{ repo ports.TaskRepository }
 type penaltyUsecase struct{ repo ports.PenaltyRepository }
 type taskOverviewUsecase struct{ repo ports.TaskOverviewRepository }
 type adminUsecase struct{ repo ports.AdminRepository }
+type batchUsecase struct{ repo ports.BatchRepository }
+type webhookUsecase struct{ repo ports.WebhookRepository }
+type absenceUsecase struct{ repo ports.AbsenceRepository }
+type holidayUsecase struct{ repo ports.HolidayRepository }
+type categoryUsecase struct{ repo ports.TaskCategoryRepository }
+type templateUsecase struct{ repo ports.TaskTemplateRepository }
 
 func NewServices(deps ports.Dependencies) *ports.Services {
 	return &ports.Services{
@@ -17,5 +23,11 @@ func NewServices(deps ports.Dependencies) *ports.Services {
 		Penalty:      penaltyUsecase{repo: deps.PenaltyRepo},
 		TaskOverview: taskOverviewUsecase{repo: deps.TaskOverviewRepo},
 		Admin:        adminUsecase{repo: deps.AdminRepo},
+		Batch:        batchUsecase{repo: deps.BatchRepo},
+		Webhook:      webhookUsecase{repo: deps.WebhookRepo},
+		Absence:      absenceUsecase{repo: deps.AbsenceRepo},
+		Holiday:      holidayUsecase{repo: deps.HolidayRepo},
+		Category:     categoryUsecase{repo: deps.CategoryRepo},
+		Template:     templateUsecase{repo: deps.TemplateRepo},
 	}
 }
@@ -0,0 +1,19 @@
+package usecases
+
+import (
+	"context"
+
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func (u absenceUsecase) ListTeamAbsences(ctx context.Context, userID string, includePast bool) ([]api.TeamAbsence, error) {
+	return u.repo.ListTeamAbsences(ctx, userID, includePast)
+}
+
+func (u absenceUsecase) CreateTeamAbsence(ctx context.Context, userID string, req api.CreateTeamAbsenceRequest) (api.TeamAbsence, error) {
+	return u.repo.CreateTeamAbsence(ctx, userID, req)
+}
+
+func (u absenceUsecase) DeleteTeamAbsence(ctx context.Context, userID, absenceID string) error {
+	return u.repo.DeleteTeamAbsence(ctx, userID, absenceID)
+}
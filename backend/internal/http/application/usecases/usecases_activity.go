@@ -0,0 +1,11 @@
+package usecases
+
+import (
+	"context"
+
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func (u teamUsecase) ListTeamActivity(ctx context.Context, userID string, limit *int, cursor *string) (api.TeamActivityPage, error) {
+	return u.repo.ListTeamActivity(ctx, userID, limit, cursor)
+}
@@ -0,0 +1,12 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/megu/kaji-challenge/backend/internal/http/application/ports"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func (u batchUsecase) ApplyBatch(ctx context.Context, userID string, req api.BatchMutationRequest) (ports.BatchResult, error) {
+	return u.repo.ApplyBatch(ctx, userID, req)
+}
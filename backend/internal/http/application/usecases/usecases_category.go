@@ -0,0 +1,23 @@
+package usecases
+
+import (
+	"context"
+
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func (u categoryUsecase) ListTaskCategories(ctx context.Context, userID string) ([]api.TaskCategory, error) {
+	return u.repo.ListTaskCategories(ctx, userID)
+}
+
+func (u categoryUsecase) CreateTaskCategory(ctx context.Context, userID string, req api.CreateTaskCategoryRequest) (api.TaskCategory, error) {
+	return u.repo.CreateTaskCategory(ctx, userID, req)
+}
+
+func (u categoryUsecase) PatchTaskCategory(ctx context.Context, userID, categoryID string, req api.UpdateTaskCategoryRequest) (api.TaskCategory, error) {
+	return u.repo.PatchTaskCategory(ctx, userID, categoryID, req)
+}
+
+func (u categoryUsecase) DeleteTaskCategory(ctx context.Context, userID, categoryID string) error {
+	return u.repo.DeleteTaskCategory(ctx, userID, categoryID)
+}
@@ -0,0 +1,28 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func (u taskUsecase) ListTaskChecklistItems(ctx context.Context, userID, taskID string) ([]api.TaskChecklistItem, error) {
+	return u.repo.ListTaskChecklistItems(ctx, userID, taskID)
+}
+
+func (u taskUsecase) CreateTaskChecklistItem(ctx context.Context, userID, taskID string, req api.CreateTaskChecklistItemRequest) (api.TaskChecklistItem, error) {
+	return u.repo.CreateTaskChecklistItem(ctx, userID, taskID, req)
+}
+
+func (u taskUsecase) PatchTaskChecklistItem(ctx context.Context, userID, taskID, itemID string, req api.UpdateTaskChecklistItemRequest) (api.TaskChecklistItem, error) {
+	return u.repo.PatchTaskChecklistItem(ctx, userID, taskID, itemID, req)
+}
+
+func (u taskUsecase) DeleteTaskChecklistItem(ctx context.Context, userID, taskID, itemID string) error {
+	return u.repo.DeleteTaskChecklistItem(ctx, userID, taskID, itemID)
+}
+
+func (u taskUsecase) ToggleTaskChecklistItem(ctx context.Context, userID, taskID, itemID string, target time.Time) (api.TaskChecklistToggleResponse, error) {
+	return u.repo.ToggleTaskChecklistItem(ctx, userID, taskID, itemID, target)
+}
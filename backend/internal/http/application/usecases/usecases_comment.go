@@ -0,0 +1,27 @@
+package usecases
+
+import (
+	"context"
+
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func (u taskUsecase) ListTaskComments(ctx context.Context, userID, taskID string, limit *int, cursor *string) (api.TaskCommentPage, error) {
+	return u.repo.ListTaskComments(ctx, userID, taskID, limit, cursor)
+}
+
+func (u taskUsecase) CreateTaskComment(ctx context.Context, userID, taskID string, req api.CreateTaskCommentRequest) (api.TaskComment, error) {
+	return u.repo.CreateTaskComment(ctx, userID, taskID, req)
+}
+
+func (u taskUsecase) PatchTaskComment(ctx context.Context, userID, taskID, commentID string, req api.UpdateTaskCommentRequest) (api.TaskComment, error) {
+	return u.repo.PatchTaskComment(ctx, userID, taskID, commentID, req)
+}
+
+func (u taskUsecase) DeleteTaskComment(ctx context.Context, userID, taskID, commentID string) error {
+	return u.repo.DeleteTaskComment(ctx, userID, taskID, commentID)
+}
+
+func (u taskUsecase) ToggleCompletionReaction(ctx context.Context, userID, taskID string, req api.ToggleCompletionReactionRequest) (api.CompletionReactionResponse, error) {
+	return u.repo.ToggleCompletionReaction(ctx, userID, taskID, req)
+}
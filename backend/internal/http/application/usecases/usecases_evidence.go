@@ -0,0 +1,16 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/megu/kaji-challenge/backend/internal/http/application/ports"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func (u taskUsecase) SaveTaskCompletionEvidence(ctx context.Context, userID, taskID string, upload ports.CompletionEvidenceUpload) (api.TaskCompletionEvidenceResponse, error) {
+	return u.repo.SaveTaskCompletionEvidence(ctx, userID, taskID, upload)
+}
+
+func (u taskUsecase) GetCompletionAttachment(ctx context.Context, userID, attachmentID string, thumbnail bool) (ports.CompletionAttachmentContent, error) {
+	return u.repo.GetCompletionAttachment(ctx, userID, attachmentID, thumbnail)
+}
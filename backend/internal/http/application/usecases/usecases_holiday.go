@@ -0,0 +1,24 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func (u holidayUsecase) ListTeamHolidays(ctx context.Context, userID string, from, to *time.Time) ([]api.TeamHoliday, error) {
+	return u.repo.ListTeamHolidays(ctx, userID, from, to)
+}
+
+func (u holidayUsecase) CreateTeamHoliday(ctx context.Context, userID string, req api.CreateTeamHolidayRequest) (api.TeamHoliday, error) {
+	return u.repo.CreateTeamHoliday(ctx, userID, req)
+}
+
+func (u holidayUsecase) ImportTeamHolidays(ctx context.Context, userID string, req api.ImportTeamHolidaysRequest) (api.ImportTeamHolidaysResponse, error) {
+	return u.repo.ImportTeamHolidays(ctx, userID, req)
+}
+
+func (u holidayUsecase) DeleteTeamHoliday(ctx context.Context, userID string, date time.Time) error {
+	return u.repo.DeleteTeamHoliday(ctx, userID, date)
+}
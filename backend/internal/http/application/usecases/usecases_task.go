@@ -7,8 +7,8 @@ import (
 	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
 )
 
-func (u taskUsecase) ListTasks(ctx context.Context, userID string, filter *api.TaskType) ([]api.Task, error) {
-	return u.repo.ListTasks(ctx, userID, filter)
+func (u taskUsecase) ListTasks(ctx context.Context, userID string, filter *api.TaskType, categoryID *string) ([]api.Task, error) {
+	return u.repo.ListTasks(ctx, userID, filter, categoryID)
 }
 
 func (u taskUsecase) CreateTask(ctx context.Context, userID string, req api.CreateTaskRequest) (api.Task, error) {
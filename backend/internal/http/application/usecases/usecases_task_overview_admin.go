@@ -6,8 +6,8 @@ import (
 	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
 )
 
-func (u taskOverviewUsecase) GetTaskOverview(ctx context.Context, userID string) (api.TaskOverviewResponse, error) {
-	return u.repo.GetTaskOverview(ctx, userID)
+func (u taskOverviewUsecase) GetTaskOverview(ctx context.Context, userID string, categoryID *string) (api.TaskOverviewResponse, error) {
+	return u.repo.GetTaskOverview(ctx, userID, categoryID)
 }
 
 func (u taskOverviewUsecase) GetMonthlySummary(ctx context.Context, userID string, month *string) (api.MonthlyPenaltySummary, error) {
@@ -25,3 +25,7 @@ func (u adminUsecase) CloseWeekForUser(ctx context.Context, userID string) (api.
 func (u adminUsecase) CloseMonthForUser(ctx context.Context, userID string) (api.CloseResponse, error) {
 	return u.repo.CloseMonthForUser(ctx, userID)
 }
+
+func (u adminUsecase) ListCloseRuns(ctx context.Context, userID string, limit *int) (api.CloseRunsResponse, error) {
+	return u.repo.ListCloseRuns(ctx, userID, limit)
+}
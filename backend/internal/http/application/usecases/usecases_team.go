@@ -3,6 +3,7 @@ package usecases
 import (
 	"context"
 
+	"github.com/megu/kaji-challenge/backend/internal/http/application/ports"
 	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
 )
 
@@ -18,6 +19,14 @@ func (u teamUsecase) PatchMeColor(ctx context.Context, userID string, req api.Up
 	return u.repo.PatchMeColor(ctx, userID, req)
 }
 
+func (u teamUsecase) GetMeExport(ctx context.Context, userID string, refresh bool) (ports.PersonalDataExport, error) {
+	return u.repo.GetMeExport(ctx, userID, refresh)
+}
+
+func (u teamUsecase) DeleteMe(ctx context.Context, userID string) error {
+	return u.repo.DeleteMe(ctx, userID)
+}
+
 func (u teamUsecase) CreateInvite(ctx context.Context, userID string, req api.CreateInviteRequest) (api.InviteCodeResponse, error) {
 	return u.repo.CreateInvite(ctx, userID, req)
 }
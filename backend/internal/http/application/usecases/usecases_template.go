@@ -0,0 +1,23 @@
+package usecases
+
+import (
+	"context"
+
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func (u templateUsecase) ListTaskTemplates(ctx context.Context, userID string) ([]api.TaskTemplate, error) {
+	return u.repo.ListTaskTemplates(ctx, userID)
+}
+
+func (u templateUsecase) CreateTaskTemplate(ctx context.Context, userID string, req api.CreateTaskTemplateRequest) (api.TaskTemplate, error) {
+	return u.repo.CreateTaskTemplate(ctx, userID, req)
+}
+
+func (u templateUsecase) DeleteTaskTemplate(ctx context.Context, userID, templateID string) error {
+	return u.repo.DeleteTaskTemplate(ctx, userID, templateID)
+}
+
+func (u templateUsecase) InstantiateTaskTemplate(ctx context.Context, userID, templateID string, req api.InstantiateTaskTemplateRequest) ([]api.Task, error) {
+	return u.repo.InstantiateTaskTemplate(ctx, userID, templateID, req)
+}
@@ -0,0 +1,27 @@
+package usecases
+
+import (
+	"context"
+
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func (u webhookUsecase) ListTeamWebhooks(ctx context.Context, userID string) ([]api.TeamWebhook, error) {
+	return u.repo.ListTeamWebhooks(ctx, userID)
+}
+
+func (u webhookUsecase) CreateTeamWebhook(ctx context.Context, userID string, req api.CreateTeamWebhookRequest) (api.TeamWebhookCreatedResponse, error) {
+	return u.repo.CreateTeamWebhook(ctx, userID, req)
+}
+
+func (u webhookUsecase) PatchTeamWebhook(ctx context.Context, userID, webhookID string, req api.UpdateTeamWebhookRequest) (api.TeamWebhook, error) {
+	return u.repo.PatchTeamWebhook(ctx, userID, webhookID, req)
+}
+
+func (u webhookUsecase) DeleteTeamWebhook(ctx context.Context, userID, webhookID string) error {
+	return u.repo.DeleteTeamWebhook(ctx, userID, webhookID)
+}
+
+func (u webhookUsecase) ListTeamWebhookDeliveries(ctx context.Context, userID, webhookID string, limit *int) ([]api.TeamWebhookDelivery, error) {
+	return u.repo.ListTeamWebhookDeliveries(ctx, userID, webhookID, limit)
+}
@@ -11,10 +11,41 @@ import (
 
 type Store = store.Store
 
+type Clock = store.Clock
+
+type (
+	CloseReport        = store.CloseReport
+	ClosePeriodReport  = store.ClosePeriodReport
+	ClosePenaltyReport = store.ClosePenaltyReport
+	CloseRuleReport    = store.CloseRuleReport
+)
+
+type (
+	GCPolicy = store.GCPolicy
+	GCResult = store.GCResult
+)
+
+type (
+	TeamArchive      = store.TeamArchive
+	TeamImportResult = store.TeamImportResult
+)
+
 func NewStore() *Store {
 	return store.NewStore()
 }
 
+func FixedClock(at time.Time) Clock {
+	return store.FixedClock(at)
+}
+
+func CloseErrorClass(err error) string {
+	return store.CloseErrorClass(err)
+}
+
+func DefaultGCPolicies() []GCPolicy {
+	return store.DefaultGCPolicies()
+}
+
 func NewServices(s *Store) *ports.Services {
 	return repositories.NewServices(s)
 }
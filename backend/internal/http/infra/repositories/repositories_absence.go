@@ -0,0 +1,21 @@
+package repositories
+
+import (
+	"context"
+
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func (r absenceRepo) ListTeamAbsences(ctx context.Context, userID string, includePast bool) ([]api.TeamAbsence, error) {
+	items, err := r.store.ListTeamAbsences(ctx, userID, includePast)
+	return items, mapInfraErr(err)
+}
+
+func (r absenceRepo) CreateTeamAbsence(ctx context.Context, userID string, req api.CreateTeamAbsenceRequest) (api.TeamAbsence, error) {
+	res, err := r.store.CreateTeamAbsence(ctx, userID, req)
+	return res, mapInfraErr(err)
+}
+
+func (r absenceRepo) DeleteTeamAbsence(ctx context.Context, userID, absenceID string) error {
+	return mapInfraErr(r.store.DeleteTeamAbsence(ctx, userID, absenceID))
+}
@@ -0,0 +1,12 @@
+package repositories
+
+import (
+	"context"
+
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func (r teamRepo) ListTeamActivity(ctx context.Context, userID string, limit *int, cursor *string) (api.TeamActivityPage, error) {
+	res, err := r.store.ListTeamActivity(ctx, userID, limit, cursor)
+	return res, mapInfraErr(err)
+}
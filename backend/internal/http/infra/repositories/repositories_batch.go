@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/megu/kaji-challenge/backend/internal/http/application/ports"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func (r batchRepo) ApplyBatch(ctx context.Context, userID string, req api.BatchMutationRequest) (ports.BatchResult, error) {
+	res, err := r.store.ApplyBatch(ctx, userID, req)
+	if err != nil {
+		return ports.BatchResult{}, mapInfraErr(err)
+	}
+	for i := range res.Operations {
+		res.Operations[i].Err = mapInfraErr(res.Operations[i].Err)
+	}
+	return res, nil
+}
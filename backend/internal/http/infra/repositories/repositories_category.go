@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"context"
+
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func (r categoryRepo) ListTaskCategories(ctx context.Context, userID string) ([]api.TaskCategory, error) {
+	items, err := r.store.ListTaskCategories(ctx, userID)
+	return items, mapInfraErr(err)
+}
+
+func (r categoryRepo) CreateTaskCategory(ctx context.Context, userID string, req api.CreateTaskCategoryRequest) (api.TaskCategory, error) {
+	res, err := r.store.CreateTaskCategory(ctx, userID, req)
+	return res, mapInfraErr(err)
+}
+
+func (r categoryRepo) PatchTaskCategory(ctx context.Context, userID, categoryID string, req api.UpdateTaskCategoryRequest) (api.TaskCategory, error) {
+	res, err := r.store.PatchTaskCategory(ctx, userID, categoryID, req)
+	return res, mapInfraErr(err)
+}
+
+func (r categoryRepo) DeleteTaskCategory(ctx context.Context, userID, categoryID string) error {
+	return mapInfraErr(r.store.DeleteTaskCategory(ctx, userID, categoryID))
+}
@@ -0,0 +1,32 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func (r taskRepo) ListTaskChecklistItems(ctx context.Context, userID, taskID string) ([]api.TaskChecklistItem, error) {
+	items, err := r.store.ListTaskChecklistItems(ctx, userID, taskID)
+	return items, mapInfraErr(err)
+}
+
+func (r taskRepo) CreateTaskChecklistItem(ctx context.Context, userID, taskID string, req api.CreateTaskChecklistItemRequest) (api.TaskChecklistItem, error) {
+	res, err := r.store.CreateTaskChecklistItem(ctx, userID, taskID, req)
+	return res, mapInfraErr(err)
+}
+
+func (r taskRepo) PatchTaskChecklistItem(ctx context.Context, userID, taskID, itemID string, req api.UpdateTaskChecklistItemRequest) (api.TaskChecklistItem, error) {
+	res, err := r.store.PatchTaskChecklistItem(ctx, userID, taskID, itemID, req)
+	return res, mapInfraErr(err)
+}
+
+func (r taskRepo) DeleteTaskChecklistItem(ctx context.Context, userID, taskID, itemID string) error {
+	return mapInfraErr(r.store.DeleteTaskChecklistItem(ctx, userID, taskID, itemID))
+}
+
+func (r taskRepo) ToggleTaskChecklistItem(ctx context.Context, userID, taskID, itemID string, target time.Time) (api.TaskChecklistToggleResponse, error) {
+	res, err := r.store.ToggleTaskChecklistItem(ctx, userID, taskID, itemID, target)
+	return res, mapInfraErr(err)
+}
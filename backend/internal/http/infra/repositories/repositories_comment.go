@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"context"
+
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func (r taskRepo) ListTaskComments(ctx context.Context, userID, taskID string, limit *int, cursor *string) (api.TaskCommentPage, error) {
+	res, err := r.store.ListTaskComments(ctx, userID, taskID, limit, cursor)
+	return res, mapInfraErr(err)
+}
+
+func (r taskRepo) CreateTaskComment(ctx context.Context, userID, taskID string, req api.CreateTaskCommentRequest) (api.TaskComment, error) {
+	res, err := r.store.CreateTaskComment(ctx, userID, taskID, req)
+	return res, mapInfraErr(err)
+}
+
+func (r taskRepo) PatchTaskComment(ctx context.Context, userID, taskID, commentID string, req api.UpdateTaskCommentRequest) (api.TaskComment, error) {
+	res, err := r.store.PatchTaskComment(ctx, userID, taskID, commentID, req)
+	return res, mapInfraErr(err)
+}
+
+func (r taskRepo) DeleteTaskComment(ctx context.Context, userID, taskID, commentID string) error {
+	return mapInfraErr(r.store.DeleteTaskComment(ctx, userID, taskID, commentID))
+}
+
+func (r taskRepo) ToggleCompletionReaction(ctx context.Context, userID, taskID string, req api.ToggleCompletionReactionRequest) (api.CompletionReactionResponse, error) {
+	res, err := r.store.ToggleCompletionReaction(ctx, userID, taskID, req)
+	return res, mapInfraErr(err)
+}
@@ -0,0 +1,18 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/megu/kaji-challenge/backend/internal/http/application/ports"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func (r taskRepo) SaveTaskCompletionEvidence(ctx context.Context, userID, taskID string, upload ports.CompletionEvidenceUpload) (api.TaskCompletionEvidenceResponse, error) {
+	res, err := r.store.SaveTaskCompletionEvidence(ctx, userID, taskID, upload)
+	return res, mapInfraErr(err)
+}
+
+func (r taskRepo) GetCompletionAttachment(ctx context.Context, userID, attachmentID string, thumbnail bool) (ports.CompletionAttachmentContent, error) {
+	res, err := r.store.GetCompletionAttachment(ctx, userID, attachmentID, thumbnail)
+	return res, mapInfraErr(err)
+}
@@ -0,0 +1,27 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func (r holidayRepo) ListTeamHolidays(ctx context.Context, userID string, from, to *time.Time) ([]api.TeamHoliday, error) {
+	items, err := r.store.ListTeamHolidays(ctx, userID, from, to)
+	return items, mapInfraErr(err)
+}
+
+func (r holidayRepo) CreateTeamHoliday(ctx context.Context, userID string, req api.CreateTeamHolidayRequest) (api.TeamHoliday, error) {
+	res, err := r.store.CreateTeamHoliday(ctx, userID, req)
+	return res, mapInfraErr(err)
+}
+
+func (r holidayRepo) ImportTeamHolidays(ctx context.Context, userID string, req api.ImportTeamHolidaysRequest) (api.ImportTeamHolidaysResponse, error) {
+	res, err := r.store.ImportTeamHolidays(ctx, userID, req)
+	return res, mapInfraErr(err)
+}
+
+func (r holidayRepo) DeleteTeamHoliday(ctx context.Context, userID string, date time.Time) error {
+	return mapInfraErr(r.store.DeleteTeamHoliday(ctx, userID, date))
+}
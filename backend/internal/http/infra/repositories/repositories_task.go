@@ -7,8 +7,8 @@ import (
 	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
 )
 
-func (r taskRepo) ListTasks(ctx context.Context, userID string, filter *api.TaskType) ([]api.Task, error) {
-	items, err := r.store.ListTasks(ctx, userID, filter)
+func (r taskRepo) ListTasks(ctx context.Context, userID string, filter *api.TaskType, categoryID *string) ([]api.Task, error) {
+	items, err := r.store.ListTasks(ctx, userID, filter, categoryID)
 	return items, mapInfraErr(err)
 }
 
@@ -6,8 +6,8 @@ import (
 	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
 )
 
-func (r taskOverviewRepo) GetTaskOverview(ctx context.Context, userID string) (api.TaskOverviewResponse, error) {
-	res, err := r.store.GetTaskOverview(ctx, userID)
+func (r taskOverviewRepo) GetTaskOverview(ctx context.Context, userID string, categoryID *string) (api.TaskOverviewResponse, error) {
+	res, err := r.store.GetTaskOverview(ctx, userID, categoryID)
 	return res, mapInfraErr(err)
 }
 
@@ -30,3 +30,8 @@ func (r adminRepo) CloseMonthForUser(ctx context.Context, userID string) (api.Cl
 	res, err := r.store.CloseMonthForUser(ctx, userID)
 	return res, mapInfraErr(err)
 }
+
+func (r adminRepo) ListCloseRuns(ctx context.Context, userID string, limit *int) (api.CloseRunsResponse, error) {
+	res, err := r.store.ListCloseRunsForUser(ctx, userID, limit)
+	return res, mapInfraErr(err)
+}
@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 
+	"github.com/megu/kaji-challenge/backend/internal/http/application/ports"
 	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
 )
 
@@ -21,6 +22,15 @@ func (r teamRepo) PatchMeColor(ctx context.Context, userID string, req api.Updat
 	return res, mapInfraErr(err)
 }
 
+func (r teamRepo) GetMeExport(ctx context.Context, userID string, refresh bool) (ports.PersonalDataExport, error) {
+	res, err := r.store.GetMeExport(ctx, userID, refresh)
+	return res, mapInfraErr(err)
+}
+
+func (r teamRepo) DeleteMe(ctx context.Context, userID string) error {
+	return mapInfraErr(r.store.DeleteMe(ctx, userID))
+}
+
 func (r teamRepo) CreateInvite(ctx context.Context, userID string, req api.CreateInviteRequest) (api.InviteCodeResponse, error) {
 	res, err := r.store.CreateInvite(ctx, userID, req)
 	return res, mapInfraErr(err)
@@ -0,0 +1,26 @@
+package repositories
+
+import (
+	"context"
+
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func (r templateRepo) ListTaskTemplates(ctx context.Context, userID string) ([]api.TaskTemplate, error) {
+	items, err := r.store.ListTaskTemplates(ctx, userID)
+	return items, mapInfraErr(err)
+}
+
+func (r templateRepo) CreateTaskTemplate(ctx context.Context, userID string, req api.CreateTaskTemplateRequest) (api.TaskTemplate, error) {
+	res, err := r.store.CreateTaskTemplate(ctx, userID, req)
+	return res, mapInfraErr(err)
+}
+
+func (r templateRepo) DeleteTaskTemplate(ctx context.Context, userID, templateID string) error {
+	return mapInfraErr(r.store.DeleteTaskTemplate(ctx, userID, templateID))
+}
+
+func (r templateRepo) InstantiateTaskTemplate(ctx context.Context, userID, templateID string, req api.InstantiateTaskTemplateRequest) ([]api.Task, error) {
+	tasks, err := r.store.InstantiateTaskTemplate(ctx, userID, templateID, req)
+	return tasks, mapInfraErr(err)
+}
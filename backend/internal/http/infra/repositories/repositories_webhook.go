@@ -0,0 +1,31 @@
+package repositories
+
+import (
+	"context"
+
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func (r webhookRepo) ListTeamWebhooks(ctx context.Context, userID string) ([]api.TeamWebhook, error) {
+	items, err := r.store.ListTeamWebhooks(ctx, userID)
+	return items, mapInfraErr(err)
+}
+
+func (r webhookRepo) CreateTeamWebhook(ctx context.Context, userID string, req api.CreateTeamWebhookRequest) (api.TeamWebhookCreatedResponse, error) {
+	res, err := r.store.CreateTeamWebhook(ctx, userID, req)
+	return res, mapInfraErr(err)
+}
+
+func (r webhookRepo) PatchTeamWebhook(ctx context.Context, userID, webhookID string, req api.UpdateTeamWebhookRequest) (api.TeamWebhook, error) {
+	res, err := r.store.PatchTeamWebhook(ctx, userID, webhookID, req)
+	return res, mapInfraErr(err)
+}
+
+func (r webhookRepo) DeleteTeamWebhook(ctx context.Context, userID, webhookID string) error {
+	return mapInfraErr(r.store.DeleteTeamWebhook(ctx, userID, webhookID))
+}
+
+func (r webhookRepo) ListTeamWebhookDeliveries(ctx context.Context, userID, webhookID string, limit *int) ([]api.TeamWebhookDelivery, error) {
+	items, err := r.store.ListTeamWebhookDeliveries(ctx, userID, webhookID, limit)
+	return items, mapInfraErr(err)
+}
@@ -0,0 +1,47 @@
+package store
+
+import "time"
+
+// Clock is the store's time source. Every "now" the store uses comes from it,
+// so tests and `ops close --as-of` can run as of an arbitrary instant.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+type fixedClock struct {
+	at time.Time
+}
+
+// FixedClock always reports at. After still waits in real time.
+func FixedClock(at time.Time) Clock {
+	return fixedClock{at: at}
+}
+
+func (c fixedClock) Now() time.Time {
+	return c.at
+}
+
+func (fixedClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// SetClock replaces the store's time source. It must be called before the
+// store is shared between goroutines.
+func (s *Store) SetClock(clock Clock) {
+	s.clock = clock
+}
+
+func (s *Store) now() time.Time {
+	return s.clock.Now().In(s.loc)
+}
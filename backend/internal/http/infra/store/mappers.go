@@ -17,6 +17,7 @@ func (u userRecord) toAPI() api.User {
 }
 
 func (t taskRecord) toAPI() api.Task {
+	etag := entityETag(etagKindTask, t.ID, t.Revision)
 	return api.Task{
 		Id:                         t.ID,
 		TeamId:                     t.TeamID,
@@ -26,12 +27,18 @@ func (t taskRecord) toAPI() api.Task {
 		PenaltyPoints:              t.Penalty,
 		AssigneeUserId:             t.AssigneeID,
 		RequiredCompletionsPerWeek: t.Required,
+		DueOn:                      toDatePtr(t.DueOn),
+		StartsOn:                   toDatePtr(t.Window.StartsOn),
+		EndsOn:                     toDatePtr(t.Window.EndsOn),
+		CategoryId:                 t.CategoryID,
 		CreatedAt:                  t.CreatedAt,
 		UpdatedAt:                  t.UpdatedAt,
+		Etag:                       &etag,
 	}
 }
 
 func (r ruleRecord) toAPI() api.PenaltyRule {
+	etag := entityETag(etagKindPenaltyRule, r.ID, r.Revision)
 	return api.PenaltyRule{
 		Id:          r.ID,
 		TeamId:      r.TeamID,
@@ -41,6 +48,7 @@ func (r ruleRecord) toAPI() api.PenaltyRule {
 		DeletedAt:   r.DeletedAt,
 		CreatedAt:   r.CreatedAt,
 		UpdatedAt:   r.UpdatedAt,
+		Etag:        &etag,
 	}
 }
 
@@ -54,6 +62,7 @@ func (m monthSummary) toAPI() api.MonthlyPenaltySummary {
 		IsClosed:                m.IsClosed,
 		TriggeredPenaltyRuleIds: m.TriggeredRuleID,
 		TaskStatusByDate:        m.TaskStatusByDate,
+		CategoryStats:           &m.CategoryStats,
 	}
 }
 
@@ -67,6 +76,10 @@ func taskFromGetRow(row dbsqlc.GetTaskByIDRow, loc *time.Location) taskRecord {
 		Penalty:    int(row.PenaltyPoints),
 		AssigneeID: ptrFromAny(row.AssigneeUserID),
 		Required:   int(row.RequiredCompletionsPerWeek),
+		DueOn:      ptrFromPgDate(row.DueOn, loc),
+		Window:     taskWindowFromPg(row.StartsOn, row.EndsOn, loc),
+		CategoryID: ptrFromAny(row.CategoryID),
+		Revision:   row.Revision,
 		CreatedAt:  row.CreatedAt.Time.In(loc),
 		UpdatedAt:  row.UpdatedAt.Time.In(loc),
 		DeletedAt:  ptrFromTimestamptz(row.DeletedAt, loc),
@@ -83,6 +96,10 @@ func taskFromListRow(row dbsqlc.ListTasksByTeamIDRow, loc *time.Location) taskRe
 		Penalty:    int(row.PenaltyPoints),
 		AssigneeID: ptrFromAny(row.AssigneeUserID),
 		Required:   int(row.RequiredCompletionsPerWeek),
+		DueOn:      ptrFromPgDate(row.DueOn, loc),
+		Window:     taskWindowFromPg(row.StartsOn, row.EndsOn, loc),
+		CategoryID: ptrFromAny(row.CategoryID),
+		Revision:   row.Revision,
 		CreatedAt:  row.CreatedAt.Time.In(loc),
 		UpdatedAt:  row.UpdatedAt.Time.In(loc),
 		DeletedAt:  ptrFromTimestamptz(row.DeletedAt, loc),
@@ -99,6 +116,10 @@ func taskFromUndeletedListRow(row dbsqlc.ListUndeletedTasksByTeamIDRow, loc *tim
 		Penalty:    int(row.PenaltyPoints),
 		AssigneeID: ptrFromAny(row.AssigneeUserID),
 		Required:   int(row.RequiredCompletionsPerWeek),
+		DueOn:      ptrFromPgDate(row.DueOn, loc),
+		Window:     taskWindowFromPg(row.StartsOn, row.EndsOn, loc),
+		CategoryID: ptrFromAny(row.CategoryID),
+		Revision:   row.Revision,
 		CreatedAt:  row.CreatedAt.Time.In(loc),
 		UpdatedAt:  row.UpdatedAt.Time.In(loc),
 		DeletedAt:  ptrFromTimestamptz(row.DeletedAt, loc),
@@ -112,6 +133,7 @@ func ruleFromDB(row dbsqlc.PenaltyRule, loc *time.Location) ruleRecord {
 		Threshold:   int(row.Threshold),
 		Name:        row.Name,
 		Description: ptrFromText(row.Description),
+		Revision:    row.Revision,
 		DeletedAt:   ptrFromTimestamptz(row.DeletedAt, loc),
 		CreatedAt:   row.CreatedAt.Time.In(loc),
 		UpdatedAt:   row.UpdatedAt.Time.In(loc),
@@ -0,0 +1,282 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+	dbsqlc "github.com/megu/kaji-challenge/backend/internal/db/sqlc"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+const (
+	absenceMaxDays         = 366
+	absenceReasonMaxLength = 200
+)
+
+// teamAbsence is one absence row. An empty UserID means the whole team is
+// away.
+type teamAbsence struct {
+	ID              string
+	TeamID          string
+	UserID          string
+	StartsOn        time.Time
+	EndsOn          time.Time
+	Reason          *string
+	CreatedByUserID string
+	CreatedAt       time.Time
+	Revision        int64
+}
+
+func (a teamAbsence) toAPI() api.TeamAbsence {
+	etag := entityETag(etagKindAbsence, a.ID, a.Revision)
+	return api.TeamAbsence{
+		Id:              a.ID,
+		TeamId:          a.TeamID,
+		UserId:          ptrFromUUIDString(a.UserID),
+		StartsOn:        toDate(a.StartsOn),
+		EndsOn:          toDate(a.EndsOn),
+		Reason:          a.Reason,
+		CreatedByUserId: ptrFromUUIDString(a.CreatedByUserID),
+		CreatedAt:       a.CreatedAt,
+		Etag:            &etag,
+	}
+}
+
+// covers reports whether the absence pauses a task assigned to assigneeUserID
+// on day. Team-wide absences pause every task; a member's absence only pauses
+// the tasks assigned to them.
+func (a teamAbsence) covers(day time.Time, assigneeUserID string) bool {
+	if a.UserID != "" && a.UserID != assigneeUserID {
+		return false
+	}
+	return !day.Before(a.StartsOn) && !day.After(a.EndsOn)
+}
+
+// absenceCalendar answers pause questions for a set of absences. The close
+// queries in task_evaluation_dedupes.sql apply the same rules in SQL.
+type absenceCalendar []teamAbsence
+
+func (c absenceCalendar) pausedOn(day time.Time, assigneeUserID string) bool {
+	for _, a := range c {
+		if a.covers(day, assigneeUserID) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c absenceCalendar) pausedDaysInWeek(weekStart time.Time, assigneeUserID string) int {
+	return c.pausedDaysInTaskWeek(weekStart, assigneeUserID, taskWindow{})
+}
+
+// pausedDaysInTaskWeek also counts the days outside the task's active window,
+// so a window that starts or ends mid-week prorates the requirement.
+func (c absenceCalendar) pausedDaysInTaskWeek(weekStart time.Time, assigneeUserID string, window taskWindow) int {
+	paused := 0
+	for i := 0; i < 7; i++ {
+		day := weekStart.AddDate(0, 0, i)
+		if c.pausedOn(day, assigneeUserID) || !window.activeOn(day) {
+			paused++
+		}
+	}
+	return paused
+}
+
+// proratedRequiredCompletions scales a weekly requirement to the days not
+// paused, rounding up, so a partly paused week still needs at least one
+// completion and a fully paused week needs none.
+func proratedRequiredCompletions(required, pausedDays int) int {
+	return (required*(7-pausedDays) + 6) / 7
+}
+
+// listTeamAbsences returns the absences overlapping [from, to].
+func (s *Store) listTeamAbsences(ctx context.Context, teamID string, from, to time.Time) (absenceCalendar, error) {
+	rows, err := s.queries(ctx).ListTeamAbsencesOverlapping(ctx, dbsqlc.ListTeamAbsencesOverlappingParams{
+		TeamID:     teamID,
+		RangeStart: toPgDate(from),
+		RangeEnd:   toPgDate(to),
+	})
+	if err != nil {
+		return nil, err
+	}
+	items := make(absenceCalendar, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, s.absenceFromRow(dbsqlc.ListTeamAbsencesEndingFromRow(row)))
+	}
+	return items, nil
+}
+
+func (s *Store) absenceFromRow(row dbsqlc.ListTeamAbsencesEndingFromRow) teamAbsence {
+	return teamAbsence{
+		ID:              row.ID,
+		TeamID:          row.TeamID,
+		UserID:          uuidStringFromPtr(ptrFromAny(row.UserID)),
+		StartsOn:        dateOnly(row.StartsOn.Time, s.loc),
+		EndsOn:          dateOnly(row.EndsOn.Time, s.loc),
+		Reason:          ptrFromText(row.Reason),
+		CreatedByUserID: uuidStringFromPtr(ptrFromAny(row.CreatedByUserID)),
+		CreatedAt:       row.CreatedAt.Time.In(s.loc),
+		Revision:        row.Revision,
+	}
+}
+
+// ListTeamAbsences lists the current and upcoming absences, plus those of the
+// past year when includePast is set.
+func (s *Store) ListTeamAbsences(ctx context.Context, userID string, includePast bool) ([]api.TeamAbsence, error) {
+	teamID, err := s.primaryTeamLocked(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	from := dateOnly(s.now(), s.loc)
+	if includePast {
+		from = from.AddDate(-1, 0, 0)
+	}
+	rows, err := s.q.ListTeamAbsencesEndingFrom(ctx, dbsqlc.ListTeamAbsencesEndingFromParams{
+		TeamID:   teamID,
+		EndsFrom: toPgDate(from),
+	})
+	if err != nil {
+		return nil, err
+	}
+	items := make([]api.TeamAbsence, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, s.absenceFromRow(row).toAPI())
+	}
+	return items, nil
+}
+
+func (s *Store) CreateTeamAbsence(ctx context.Context, userID string, req api.CreateTeamAbsenceRequest) (api.TeamAbsence, error) {
+	membership, err := s.primaryMembershipLocked(ctx, userID)
+	if err != nil {
+		return api.TeamAbsence{}, err
+	}
+	isOwner := membership.Role == string(api.TeamMembershipRoleOwner)
+
+	absentUserID := userID
+	if req.UserId != nil && strings.TrimSpace(*req.UserId) != "" {
+		absentUserID = strings.TrimSpace(*req.UserId)
+	}
+	if req.TeamWide != nil && *req.TeamWide {
+		absentUserID = ""
+	}
+	if absentUserID != userID && !isOwner {
+		return api.TeamAbsence{}, errors.New("forbidden: owner role required")
+	}
+	if absentUserID != "" && absentUserID != userID {
+		if err := s.ensureTeamMember(ctx, membership.TeamID, absentUserID); err != nil {
+			return api.TeamAbsence{}, err
+		}
+	}
+
+	absence := teamAbsence{
+		ID:              s.nextID("abs"),
+		TeamID:          membership.TeamID,
+		UserID:          absentUserID,
+		StartsOn:        dateOnly(req.StartsOn.Time, s.loc),
+		EndsOn:          dateOnly(req.EndsOn.Time, s.loc),
+		CreatedByUserID: userID,
+		CreatedAt:       s.now(),
+	}
+	if absence.EndsOn.Before(absence.StartsOn) {
+		return api.TeamAbsence{}, errors.New("invalid absence: endsOn must not be before startsOn")
+	}
+	if days := int(absence.EndsOn.Sub(absence.StartsOn).Hours()/24) + 1; days > absenceMaxDays {
+		return api.TeamAbsence{}, fmt.Errorf("invalid absence: must be %d days or shorter", absenceMaxDays)
+	}
+	// A past absence would waive penalties already charged by a close, so
+	// only the owner may record one.
+	if absence.StartsOn.Before(dateOnly(absence.CreatedAt, s.loc)) && !isOwner {
+		return api.TeamAbsence{}, errors.New("forbidden: owner role required to record a past absence")
+	}
+	if req.Reason != nil {
+		reason := strings.TrimSpace(*req.Reason)
+		if utf8.RuneCountInString(reason) > absenceReasonMaxLength {
+			return api.TeamAbsence{}, fmt.Errorf("invalid absence: reason must be %d characters or fewer", absenceReasonMaxLength)
+		}
+		if reason != "" {
+			absence.Reason = &reason
+		}
+	}
+
+	if _, err := s.runWithTeamRevisionCAS(
+		ctx,
+		absence.TeamID,
+		"absence",
+		map[string]string{"absenceId": absence.ID, "action": "create"},
+		func(txCtx context.Context, qtx *dbsqlc.Queries) error {
+			absence.Revision = nextEntityRevision(txCtx)
+			return qtx.CreateTeamAbsence(txCtx, dbsqlc.CreateTeamAbsenceParams{
+				ID:              absence.ID,
+				TeamID:          absence.TeamID,
+				UserID:          absence.UserID,
+				StartsOn:        toPgDate(absence.StartsOn),
+				EndsOn:          toPgDate(absence.EndsOn),
+				Reason:          textFromPtr(absence.Reason),
+				CreatedByUserID: absence.CreatedByUserID,
+				CreatedAt:       toPgTimestamptz(absence.CreatedAt),
+				Revision:        absence.Revision,
+			})
+		},
+	); err != nil {
+		return api.TeamAbsence{}, err
+	}
+	return absence.toAPI(), nil
+}
+
+func (s *Store) DeleteTeamAbsence(ctx context.Context, userID, absenceID string) error {
+	membership, err := s.primaryMembershipLocked(ctx, userID)
+	if err != nil {
+		return err
+	}
+	_, err = s.runWithTeamRevisionCAS(
+		ctx,
+		membership.TeamID,
+		"absence",
+		map[string]string{"absenceId": absenceID, "action": "delete"},
+		func(txCtx context.Context, qtx *dbsqlc.Queries) error {
+			row, err := qtx.GetTeamAbsenceForUpdate(txCtx, absenceID)
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return errors.New("absence not found")
+				}
+				return err
+			}
+			if row.TeamID != membership.TeamID {
+				return errors.New("absence not found")
+			}
+			if membership.Role != string(api.TeamMembershipRoleOwner) && uuidStringFromPtr(ptrFromAny(row.UserID)) != userID {
+				return errors.New("forbidden: owner role required")
+			}
+			if err := checkEntityIfMatch(txCtx, etagKindAbsence, row.ID, row.Revision); err != nil {
+				return err
+			}
+			n, err := qtx.DeleteTeamAbsence(txCtx, dbsqlc.DeleteTeamAbsenceParams{ID: absenceID, TeamID: membership.TeamID})
+			if err != nil {
+				return err
+			}
+			if n == 0 {
+				return errors.New("absence not found")
+			}
+			return nil
+		},
+	)
+	return err
+}
+
+func (s *Store) ensureTeamMember(ctx context.Context, teamID, userID string) error {
+	members, err := s.q.ListTeamMembersByTeamID(ctx, teamID)
+	if err != nil {
+		return err
+	}
+	for _, m := range members {
+		if m.UserID == userID {
+			return nil
+		}
+	}
+	return errors.New("invalid absence: user is not a member of the team")
+}
@@ -0,0 +1,154 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dbsqlc "github.com/megu/kaji-challenge/backend/internal/db/sqlc"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func TestProratedRequiredCompletions(t *testing.T) {
+	cases := []struct {
+		required, paused, want int
+	}{
+		{required: 3, paused: 0, want: 3},
+		{required: 3, paused: 3, want: 2},
+		{required: 1, paused: 6, want: 1},
+		{required: 5, paused: 7, want: 0},
+		{required: 7, paused: 2, want: 5},
+	}
+	for _, tc := range cases {
+		if got := proratedRequiredCompletions(tc.required, tc.paused); got != tc.want {
+			t.Fatalf("proratedRequiredCompletions(%d, %d)=%d, want %d", tc.required, tc.paused, got, tc.want)
+		}
+	}
+}
+
+func TestAbsenceCalendarPausesAssigneeOrWholeTeam(t *testing.T) {
+	loc := time.UTC
+	weekStart := time.Date(2026, 2, 2, 0, 0, 0, 0, loc)
+	calendar := absenceCalendar{
+		{UserID: "user-a", StartsOn: weekStart, EndsOn: weekStart.AddDate(0, 0, 2)},
+		{StartsOn: weekStart.AddDate(0, 0, 6), EndsOn: weekStart.AddDate(0, 0, 8)},
+	}
+
+	if !calendar.pausedOn(weekStart.AddDate(0, 0, 1), "user-a") {
+		t.Fatalf("expected user-a's task to be paused during their absence")
+	}
+	if calendar.pausedOn(weekStart.AddDate(0, 0, 1), "user-b") {
+		t.Fatalf("expected user-b's task to keep running during user-a's absence")
+	}
+	if calendar.pausedOn(weekStart.AddDate(0, 0, 1), "") {
+		t.Fatalf("expected unassigned tasks to ignore member absences")
+	}
+	if !calendar.pausedOn(weekStart.AddDate(0, 0, 6), "") {
+		t.Fatalf("expected a team-wide absence to pause unassigned tasks")
+	}
+	if got := calendar.pausedDaysInWeek(weekStart, "user-a"); got != 4 {
+		t.Fatalf("expected 4 paused days for user-a, got %d", got)
+	}
+	if got := calendar.pausedDaysInWeek(weekStart, "user-b"); got != 1 {
+		t.Fatalf("expected 1 paused day for user-b, got %d", got)
+	}
+}
+
+func TestCloseSkipsAbsentDaysAndProratesWeeklyRequirement(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	weekStart := time.Date(2026, 2, 2, 0, 0, 0, 0, s.loc)
+	s.SetClock(FixedClock(weekStart.Add(9 * time.Hour)))
+
+	teamID, userID := createTeamWithMember(t, s, "absence@example.com", weekStart.AddDate(0, 0, -1))
+	createTaskAt(t, s, teamID, api.Daily, 3, 1, weekStart.AddDate(0, 0, -1))
+	weeklyID := createTaskAtWithID(t, s, teamID, api.Weekly, 5, 3, weekStart.AddDate(0, 0, -1))
+
+	teamWide := true
+	absence, err := s.CreateTeamAbsence(withLatestIfMatchForUser(t, s, ctx, userID), userID, api.CreateTeamAbsenceRequest{
+		TeamWide: &teamWide,
+		StartsOn: toDate(weekStart),
+		EndsOn:   toDate(weekStart.AddDate(0, 0, 2)),
+	})
+	if err != nil {
+		t.Fatalf("CreateTeamAbsence failed: %v", err)
+	}
+	if absence.UserId != nil {
+		t.Fatalf("expected a team-wide absence, got user %v", *absence.UserId)
+	}
+
+	for i := 0; i < 4; i++ {
+		if _, err := s.closeDayForTargetLocked(ctx, weekStart.AddDate(0, 0, i), teamID); err != nil {
+			t.Fatalf("closeDayForTargetLocked failed: %v", err)
+		}
+	}
+	for i := 0; i < 2; i++ {
+		if err := s.q.InsertTaskCompletionWeeklyEntry(ctx, dbsqlc.InsertTaskCompletionWeeklyEntryParams{
+			ID:                s.nextID("tcw"),
+			TaskID:            weeklyID,
+			WeekStart:         toPgDate(weekStart),
+			CompletedByUserID: userID,
+		}); err != nil {
+			t.Fatalf("failed to complete weekly task: %v", err)
+		}
+	}
+	if _, err := s.closeWeekForTargetLocked(ctx, weekStart, teamID); err != nil {
+		t.Fatalf("closeWeekForTargetLocked failed: %v", err)
+	}
+
+	feb := getMonthSummary(t, s, teamID, "2026-02")
+	if feb.DailyPenaltyTotal != 3 {
+		t.Fatalf("expected only the day after the absence to be penalised, got daily total=%d", feb.DailyPenaltyTotal)
+	}
+	if feb.WeeklyPenaltyTotal != 0 {
+		t.Fatalf("expected 2 completions to meet the prorated requirement, got weekly total=%d", feb.WeeklyPenaltyTotal)
+	}
+
+	if err := s.DeleteTeamAbsence(withLatestIfMatchForUser(t, s, ctx, userID), userID, absence.Id); err != nil {
+		t.Fatalf("DeleteTeamAbsence failed: %v", err)
+	}
+	if err := s.DeleteTeamAbsence(withLatestIfMatchForUser(t, s, ctx, userID), userID, absence.Id); err == nil || err.Error() != "absence not found" {
+		t.Fatalf("expected a second delete to report absence not found, got %v", err)
+	}
+}
+
+func TestCreateTeamAbsenceBackdatingRequiresOwner(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	today := time.Date(2026, 2, 4, 0, 0, 0, 0, s.loc)
+	s.SetClock(FixedClock(today.Add(9 * time.Hour)))
+
+	teamID, ownerID := createTeamWithMember(t, s, "absence-owner@example.com", today.AddDate(0, 0, -7))
+	memberID := s.nextID("user")
+	if err := s.q.CreateUser(ctx, dbsqlc.CreateUserParams{
+		ID:          memberID,
+		Email:       "absence-member@example.com",
+		DisplayName: "Member",
+		CreatedAt:   toPgTimestamptz(today.AddDate(0, 0, -7)),
+	}); err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	if err := s.q.AddTeamMember(ctx, dbsqlc.AddTeamMemberParams{
+		TeamID:    teamID,
+		UserID:    memberID,
+		Role:      string(api.TeamMembershipRoleMember),
+		CreatedAt: toPgTimestamptz(today.AddDate(0, 0, -7)),
+	}); err != nil {
+		t.Fatalf("failed to add team member: %v", err)
+	}
+
+	past := api.CreateTeamAbsenceRequest{StartsOn: toDate(today.AddDate(0, 0, -2)), EndsOn: toDate(today)}
+	if _, err := s.CreateTeamAbsence(withLatestIfMatchForUser(t, s, ctx, memberID), memberID, past); err == nil ||
+		err.Error() != "forbidden: owner role required to record a past absence" {
+		t.Fatalf("expected a member's backdated absence to be rejected, got %v", err)
+	}
+	if _, err := s.CreateTeamAbsence(withLatestIfMatchForUser(t, s, ctx, memberID), memberID, api.CreateTeamAbsenceRequest{
+		StartsOn: toDate(today), EndsOn: toDate(today.AddDate(0, 0, 1)),
+	}); err != nil {
+		t.Fatalf("expected a member to record an absence from today, got %v", err)
+	}
+	past.UserId = &memberID
+	if _, err := s.CreateTeamAbsence(withLatestIfMatchForUser(t, s, ctx, ownerID), ownerID, past); err != nil {
+		t.Fatalf("expected the owner to record a past absence, got %v", err)
+	}
+}
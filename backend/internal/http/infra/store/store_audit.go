@@ -0,0 +1,195 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	dbsqlc "github.com/megu/kaji-challenge/backend/internal/db/sqlc"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+const (
+	teamActivityDefaultLimit = 30
+	teamActivityMaxLimit     = 100
+)
+
+type actorContextKey struct{}
+type auditRecorderContextKey struct{}
+
+// NewActorContext attributes the team writes made with ctx to userID in the
+// audit log.
+func NewActorContext(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, actorContextKey{}, userID)
+}
+
+func actorFromContext(ctx context.Context) string {
+	userID, _ := ctx.Value(actorContextKey{}).(string)
+	return userID
+}
+
+// auditChange is one audit entry. Before and After are marshalled as JSON and
+// left NULL when nil.
+type auditChange struct {
+	Entity   string
+	EntityID string
+	Action   string
+	Before   any
+	After    any
+}
+
+// auditRecorder collects the changes a transaction makes. They are written
+// once the revision the transaction commits as is known.
+type auditRecorder struct {
+	changes []auditChange
+}
+
+func withAuditRecorder(ctx context.Context, r *auditRecorder) context.Context {
+	return context.WithValue(ctx, auditRecorderContextKey{}, r)
+}
+
+// recordAudit adds a change to the transaction's audit entries. Writes
+// outside a recorded transaction, such as archive imports, are not audited.
+func recordAudit(ctx context.Context, change auditChange) {
+	if r, ok := ctx.Value(auditRecorderContextKey{}).(*auditRecorder); ok && r != nil {
+		r.changes = append(r.changes, change)
+	}
+}
+
+// auditEntityIDHints are the event hints naming the entity a change touched,
+// most specific first.
+var auditEntityIDHints = []string{"commentId", "itemId", "categoryId", "templateId", "ruleId", "absenceId", "userId", "taskId", "date"}
+
+// fallbackAuditChange describes a write from the entity and hints of its
+// team event, for writes that record no change of that entity themselves.
+func fallbackAuditChange(entity string, hints map[string]string) auditChange {
+	change := auditChange{Entity: entity, Action: hints["action"]}
+	if change.Action == "" {
+		// Close runs are keyed by scope rather than an action.
+		change.Action = hints["scope"]
+	}
+	for _, key := range auditEntityIDHints {
+		if id := hints[key]; id != "" {
+			change.EntityID = id
+			break
+		}
+	}
+	return change
+}
+
+// writeAuditLocked stores the recorded changes of a write committing as
+// revision. The fallback entry goes first unless a recorded change already
+// covers its entity, so a checklist toggle that completes its task, or a
+// batch, is logged next to the changes it caused.
+func (s *Store) writeAuditLocked(ctx context.Context, qtx *dbsqlc.Queries, teamID string, revision int64, r *auditRecorder, fallback auditChange) error {
+	changes := r.changes
+	if !slices.ContainsFunc(changes, func(c auditChange) bool { return c.Entity == fallback.Entity }) {
+		changes = append([]auditChange{fallback}, changes...)
+	}
+	actorID := actorFromContext(ctx)
+	now := s.now()
+	for _, change := range changes {
+		before, err := auditJSON(change.Before)
+		if err != nil {
+			return err
+		}
+		after, err := auditJSON(change.After)
+		if err != nil {
+			return err
+		}
+		if err := qtx.CreateTeamAuditEntry(ctx, dbsqlc.CreateTeamAuditEntryParams{
+			ID:          s.nextID("audit"),
+			TeamID:      teamID,
+			ActorUserID: actorID,
+			Entity:      change.Entity,
+			EntityID:    pgtype.Text{String: change.EntityID, Valid: change.EntityID != ""},
+			Action:      change.Action,
+			Before:      before,
+			After:       after,
+			Revision:    revision,
+			CreatedAt:   toPgTimestamptz(now),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func auditJSON(v any) ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("marshal audit state: %w", err)
+	}
+	return raw, nil
+}
+
+// ListTeamActivity returns one page of the team's audit log, newest first.
+// Every member can read it.
+func (s *Store) ListTeamActivity(ctx context.Context, userID string, limit *int, cursor *string) (api.TeamActivityPage, error) {
+	teamID, err := s.primaryTeamLocked(ctx, userID)
+	if err != nil {
+		return api.TeamActivityPage{}, err
+	}
+	n := teamActivityDefaultLimit
+	if limit != nil {
+		n = *limit
+	}
+	if n < 1 || n > teamActivityMaxLimit {
+		return api.TeamActivityPage{}, fmt.Errorf("invalid limit: must be between 1 and %d", teamActivityMaxLimit)
+	}
+	params := dbsqlc.ListTeamAuditEntriesParams{TeamID: teamID, RowLimit: int32(n + 1)}
+	if cursor != nil && *cursor != "" {
+		createdAt, id, err := decodeKeysetCursor(*cursor)
+		if err != nil {
+			return api.TeamActivityPage{}, err
+		}
+		params.BeforeCreatedAt = toPgTimestamptz(createdAt)
+		params.BeforeID = id
+	}
+	rows, err := s.q.ListTeamAuditEntries(ctx, params)
+	if err != nil {
+		return api.TeamActivityPage{}, err
+	}
+	page := api.TeamActivityPage{Items: make([]api.TeamActivityEntry, 0, min(len(rows), n))}
+	for i, row := range rows {
+		if i == n {
+			last := rows[n-1]
+			next := encodeKeysetCursor(last.CreatedAt.Time, last.ID)
+			page.NextCursor = &next
+			break
+		}
+		entry := api.TeamActivityEntry{
+			Id:        row.ID,
+			Actor:     taskCompletionActorPtr(row.ActorUserID, row.ActorEffectiveName, row.ActorColorHex),
+			Entity:    row.Entity,
+			EntityId:  ptrFromText(row.EntityID),
+			Action:    row.Action,
+			Revision:  row.Revision,
+			CreatedAt: row.CreatedAt.Time.In(s.loc),
+		}
+		if entry.Before, err = auditStateFromJSON(row.Before); err != nil {
+			return api.TeamActivityPage{}, err
+		}
+		if entry.After, err = auditStateFromJSON(row.After); err != nil {
+			return api.TeamActivityPage{}, err
+		}
+		page.Items = append(page.Items, entry)
+	}
+	return page, nil
+}
+
+func auditStateFromJSON(raw []byte) (*map[string]interface{}, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	var state map[string]interface{}
+	if err := json.Unmarshal(raw, &state); err != nil {
+		return nil, fmt.Errorf("decode audit state: %w", err)
+	}
+	return &state, nil
+}
@@ -0,0 +1,170 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func TestFallbackAuditChange(t *testing.T) {
+	change := fallbackAuditChange("task_checklist", map[string]string{"taskId": "task-1", "itemId": "item-1", "action": "toggle"})
+	if change.Entity != "task_checklist" || change.EntityID != "item-1" || change.Action != "toggle" {
+		t.Fatalf("expected the most specific id hint, got %+v", change)
+	}
+	change = fallbackAuditChange("close_run", map[string]string{"scope": "week"})
+	if change.EntityID != "" || change.Action != "week" {
+		t.Fatalf("expected close runs to be keyed by scope, got %+v", change)
+	}
+
+	recordAudit(context.Background(), auditChange{Entity: "task"})
+	r := &auditRecorder{}
+	recordAudit(withAuditRecorder(context.Background(), r), auditChange{Entity: "task", Action: "create"})
+	if len(r.changes) != 1 || r.changes[0].Action != "create" {
+		t.Fatalf("expected the change on the recorder, got %+v", r.changes)
+	}
+}
+
+func TestTeamActivityRecordsMutations(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	today := time.Date(2026, 4, 8, 0, 0, 0, 0, s.loc)
+	s.SetClock(FixedClock(today.Add(9 * time.Hour)))
+	_, userID := createTeamWithMember(t, s, "activity@example.com", today.AddDate(0, 0, -1))
+
+	task, err := s.CreateTask(withLatestIfMatchForUser(t, s, ctx, userID), userID, api.CreateTaskRequest{
+		Title: "Dishes", Type: api.Daily, PenaltyPoints: 1,
+	})
+	if err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+	title := "Dishes and sink"
+	if _, err := s.PatchTask(withLatestIfMatchForUser(t, s, ctx, userID), userID, task.Id, api.UpdateTaskRequest{Title: &title}); err != nil {
+		t.Fatalf("PatchTask failed: %v", err)
+	}
+	if _, err := s.ToggleTaskCompletion(withLatestIfMatchForUser(t, s, ctx, userID), userID, task.Id, today, nil); err != nil {
+		t.Fatalf("ToggleTaskCompletion failed: %v", err)
+	}
+	if _, err := s.PatchTeamCurrent(withLatestIfMatchForUser(t, s, ctx, userID), userID, api.UpdateCurrentTeamRequest{Name: "Home"}); err != nil {
+		t.Fatalf("PatchTeamCurrent failed: %v", err)
+	}
+	missing := "task-missing"
+	if _, err := s.ApplyBatch(withLatestIfMatchForUser(t, s, ctx, userID), userID, api.BatchMutationRequest{
+		Mode: func() *api.BatchMutationRequestMode { m := api.Independent; return &m }(),
+		Operations: []api.BatchOperation{
+			{Id: "op-1", Type: api.CreatePenaltyRule, CreatePenaltyRule: &api.CreatePenaltyRuleRequest{Threshold: 3, Name: "Treat"}},
+			{Id: "op-2", Type: api.DeleteTask, TaskId: &missing},
+		},
+	}); err != nil {
+		t.Fatalf("ApplyBatch failed: %v", err)
+	}
+
+	page, err := s.ListTeamActivity(ctx, userID, nil, nil)
+	if err != nil {
+		t.Fatalf("ListTeamActivity failed: %v", err)
+	}
+	want := []struct{ entity, action string }{
+		{"penalty_rule", "create"},
+		{"batch", "apply"},
+		{"team_state", "rename"},
+		{"task_completion", "toggle"},
+		{"task", "update"},
+		{"task", "create"},
+	}
+	if len(page.Items) != len(want) || page.NextCursor != nil {
+		t.Fatalf("expected %d entries on one page, got %+v", len(want), page)
+	}
+	for i, w := range want {
+		entry := page.Items[i]
+		if entry.Entity != w.entity || entry.Action != w.action {
+			t.Fatalf("entry %d: expected %s %s, got %s %s", i, w.entity, w.action, entry.Entity, entry.Action)
+		}
+		if entry.Actor == nil || entry.Actor.UserId != userID {
+			t.Fatalf("entry %d: expected the member as actor, got %+v", i, entry.Actor)
+		}
+	}
+	if page.Items[0].Revision != page.Items[1].Revision {
+		t.Fatalf("expected a batch's entries to share its revision, got %d and %d", page.Items[0].Revision, page.Items[1].Revision)
+	}
+	update := page.Items[4]
+	if update.EntityId == nil || *update.EntityId != task.Id || (*update.Before)["title"] != "Dishes" || (*update.After)["title"] != title {
+		t.Fatalf("expected the task before and after the update, got %+v", update)
+	}
+	if completion := page.Items[3]; (*completion.Before)["completed"] != false || (*completion.After)["completed"] != true {
+		t.Fatalf("expected the completion state before and after the toggle, got %+v", completion)
+	}
+	if rename := page.Items[2]; (*rename.After)["name"] != "Home" {
+		t.Fatalf("expected the new team name, got %+v", rename)
+	}
+
+	limit := 4
+	first, err := s.ListTeamActivity(ctx, userID, &limit, nil)
+	if err != nil || len(first.Items) != 4 || first.NextCursor == nil {
+		t.Fatalf("expected a first page with a cursor, got %+v, %v", first, err)
+	}
+	second, err := s.ListTeamActivity(ctx, userID, &limit, first.NextCursor)
+	if err != nil || len(second.Items) != 2 || second.NextCursor != nil || second.Items[1].Id != page.Items[5].Id {
+		t.Fatalf("expected the remaining entries on the last page, got %+v, %v", second, err)
+	}
+
+	_, otherUserID := createTeamWithMember(t, s, "activity-other@example.com", today.AddDate(0, 0, -1))
+	other, err := s.ListTeamActivity(ctx, otherUserID, nil, nil)
+	if err != nil || len(other.Items) != 0 {
+		t.Fatalf("expected another team's activity to be separate, got %+v, %v", other, err)
+	}
+}
+
+func TestTeamActivityRecordsMembershipChanges(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	today := time.Date(2026, 4, 8, 0, 0, 0, 0, s.loc)
+	s.SetClock(FixedClock(today.Add(9 * time.Hour)))
+	_, ownerID := createTeamWithMember(t, s, "activity-owner@example.com", today.AddDate(0, 0, -2))
+	_, memberID := createTeamWithMember(t, s, "activity-member@example.com", today.AddDate(0, 0, -1))
+
+	invite, err := s.CreateInvite(withLatestIfMatchForUser(t, s, ctx, ownerID), ownerID, api.CreateInviteRequest{})
+	if err != nil {
+		t.Fatalf("CreateInvite failed: %v", err)
+	}
+	if _, err := s.JoinTeam(withLatestIfMatchForUser(t, s, ctx, memberID), memberID, invite.Code); err != nil {
+		t.Fatalf("JoinTeam failed: %v", err)
+	}
+	if _, err := s.PostTeamLeave(withLatestIfMatchForUser(t, s, ctx, ownerID), ownerID); err != nil {
+		t.Fatalf("PostTeamLeave failed: %v", err)
+	}
+
+	page, err := s.ListTeamActivity(ctx, memberID, nil, nil)
+	if err != nil {
+		t.Fatalf("ListTeamActivity failed: %v", err)
+	}
+	want := []struct{ action, userID, actorID string }{
+		{"promote", memberID, ownerID},
+		{"leave", ownerID, ownerID},
+		{"join", memberID, memberID},
+	}
+	if len(page.Items) < len(want) {
+		t.Fatalf("expected at least %d entries, got %+v", len(want), page.Items)
+	}
+	for i, w := range want {
+		entry := page.Items[i]
+		if entry.Entity != "team_member" || entry.Action != w.action || entry.EntityId == nil || *entry.EntityId != w.userID {
+			t.Fatalf("entry %d: expected team_member %s of %s, got %+v", i, w.action, w.userID, entry)
+		}
+		if entry.Actor == nil || entry.Actor.UserId != w.actorID {
+			t.Fatalf("entry %d: expected %s as actor, got %+v", i, w.actorID, entry.Actor)
+		}
+	}
+	if page.Items[0].Revision != page.Items[1].Revision {
+		t.Fatalf("expected the hand-over to share the leave's revision")
+	}
+	if leave := page.Items[1]; (*leave.Before)["role"] != "owner" {
+		t.Fatalf("expected the owner role on the leave, got %+v", leave.Before)
+	}
+	if promote := page.Items[0]; (*promote.After)["role"] != "owner" {
+		t.Fatalf("expected the promoted role, got %+v", promote.After)
+	}
+	if join := page.Items[2]; (*join.After)["role"] != "member" {
+		t.Fatalf("expected the member role on the join, got %+v", join.After)
+	}
+}
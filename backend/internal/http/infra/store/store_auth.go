@@ -39,7 +39,10 @@ type exchangeCodeRecord struct {
 }
 
 func (s *Store) LookupSession(ctx context.Context, token string) (string, bool) {
-	rec, err := s.q.GetSessionByToken(ctx, hashToken(token))
+	rec, err := s.q.GetSessionByToken(ctx, dbsqlc.GetSessionByTokenParams{
+		Token: hashToken(token),
+		Now:   toPgTimestamptz(s.now()),
+	})
 	if err != nil {
 		return "", false
 	}
@@ -59,7 +62,7 @@ func (s *Store) StartGoogleAuth(ctx context.Context) (api.AuthStartResponse, err
 	if err != nil {
 		return api.AuthStartResponse{}, err
 	}
-	expiresAt := time.Now().In(s.loc).Add(10 * time.Minute)
+	expiresAt := s.now().Add(10 * time.Minute)
 	if s.q != nil {
 		if err := s.q.InsertAuthRequest(ctx, dbsqlc.InsertAuthRequestParams{
 			State:        state,
@@ -99,7 +102,7 @@ func (s *Store) CompleteGoogleAuth(ctx context.Context, code, state, mockEmail,
 			CodeVerifier: row.CodeVerifier,
 			ExpiresAt:    row.ExpiresAt.Time.In(s.loc),
 		}
-		if time.Now().In(s.loc).After(req.ExpiresAt) {
+		if s.now().After(req.ExpiresAt) {
 			_ = s.q.DeleteAuthRequest(ctx, state)
 			return "", "", errors.New("state expired")
 		}
@@ -112,7 +115,7 @@ func (s *Store) CompleteGoogleAuth(ctx context.Context, code, state, mockEmail,
 			s.mu.Unlock()
 			return "", "", errors.New("invalid state")
 		}
-		if time.Now().In(s.loc).After(req.ExpiresAt) {
+		if s.now().After(req.ExpiresAt) {
 			delete(s.authRequests, state)
 			s.mu.Unlock()
 			return "", "", errors.New("state expired")
@@ -167,7 +170,7 @@ func (s *Store) CompleteGoogleAuth(ctx context.Context, code, state, mockEmail,
 		s.mu.Unlock()
 		return "", "", err
 	}
-	expiresAt := time.Now().In(s.loc).Add(2 * time.Minute)
+	expiresAt := s.now().Add(2 * time.Minute)
 	if s.q != nil {
 		if err := s.q.InsertExchangeCode(ctx, dbsqlc.InsertExchangeCodeParams{
 			Code:      exchangeCode,
@@ -334,7 +337,7 @@ func (s *Store) ExchangeSession(ctx context.Context, exchangeCode string) (ports
 	if err != nil {
 		return ports.AuthSession{}, errors.New("invalid exchange code")
 	}
-	if rec.UsedAt.Valid || time.Now().In(s.loc).After(rec.ExpiresAt.Time.In(s.loc)) {
+	if rec.UsedAt.Valid || s.now().After(rec.ExpiresAt.Time.In(s.loc)) {
 		_ = s.q.ConsumeExchangeCode(ctx, exchangeCode)
 		return ports.AuthSession{}, errors.New("exchange code expired")
 	}
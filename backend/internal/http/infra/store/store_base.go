@@ -10,6 +10,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/megu/kaji-challenge/backend/internal/blobstore"
 	dbsqlc "github.com/megu/kaji-challenge/backend/internal/db/sqlc"
 )
 
@@ -23,6 +24,7 @@ func newStore() *Store {
 
 	s := &Store{
 		loc:            loc,
+		clock:          systemClock{},
 		eventHub:       newTeamEventHub(),
 		users:          map[string]userRecord{},
 		usersByMail:    map[string]string{},
@@ -45,6 +47,29 @@ func newStore() *Store {
 	if err := validateSignupGuardSettings(); err != nil {
 		panic(err)
 	}
+	s.outboxWake = make(chan struct{}, 1)
+	s.outboxConsumers = []teamEventConsumer{s.enqueueWebhooksForTeamEvent}
+	outboxPollInterval, err := loadOutboxPollInterval()
+	if err != nil {
+		panic(err)
+	}
+	s.outboxPollInterval = outboxPollInterval
+	webhookCfg, err := loadWebhookWorkerConfig()
+	if err != nil {
+		panic(err)
+	}
+	s.webhookCfg = webhookCfg
+	s.webhookClient = newWebhookClient(webhookCfg)
+	closeSchedulerCfg, err := loadCloseSchedulerConfig()
+	if err != nil {
+		panic(err)
+	}
+	s.closeSchedulerCfg = closeSchedulerCfg
+	blobs, err := blobstore.LoadFromEnv()
+	if err != nil {
+		panic(err)
+	}
+	s.blobs = blobs
 	if err := s.initPersistence(); err != nil {
 		panic(err)
 	}
@@ -0,0 +1,361 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	dbsqlc "github.com/megu/kaji-challenge/backend/internal/db/sqlc"
+	"github.com/megu/kaji-challenge/backend/internal/http/application"
+	"github.com/megu/kaji-challenge/backend/internal/http/application/ports"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+const batchMaxOperations = 100
+
+// ApplyBatch applies queued offline mutations inside one transaction. Each
+// operation runs in its own savepoint and is checked against the entity it
+// touches as it was before the batch; the team revision is bumped once for
+// the whole batch.
+func (s *Store) ApplyBatch(ctx context.Context, userID string, req api.BatchMutationRequest) (ports.BatchResult, error) {
+	teamID, err := s.primaryTeamLocked(ctx, userID)
+	if err != nil {
+		return ports.BatchResult{}, err
+	}
+	atomic, err := validateBatchRequest(req)
+	if err != nil {
+		return ports.BatchResult{}, err
+	}
+	fallbackIfMatch, _ := ctx.Value(ifMatchContextKey{}).(string)
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return ports.BatchResult{}, err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+	qtx := s.q.WithTx(tx)
+
+	// Lock the team row so the base revision cannot move while the batch runs.
+	baseRevision, err := qtx.GetTeamStateRevisionForUpdate(ctx, teamID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ports.BatchResult{}, errors.New("team not found")
+		}
+		return ports.BatchResult{}, err
+	}
+
+	audit := &auditRecorder{}
+	batchCtx := withAuditRecorder(NewActorContext(ctx, userID), audit)
+	original := map[string]int64{}
+	outcomes := make([]ports.BatchOperationOutcome, len(req.Operations))
+	appliedCount := 0
+	for i, op := range req.Operations {
+		ifMatch := fallbackIfMatch
+		if op.IfMatch != nil && strings.TrimSpace(*op.IfMatch) != "" {
+			ifMatch = *op.IfMatch
+		}
+		outcomes[i] = s.applyBatchOperationLocked(batchCtx, tx, teamID, userID, baseRevision, original, ifMatch, op)
+		if outcomes[i].Err == nil {
+			appliedCount++
+			continue
+		}
+		if atomic {
+			abortBatchOutcomes(outcomes, req.Operations, i)
+			return ports.BatchResult{
+				Committed:  false,
+				ETag:       etagFromRevision(teamID, baseRevision),
+				Operations: outcomes,
+			}, nil
+		}
+	}
+	if appliedCount == 0 {
+		return ports.BatchResult{
+			Committed:  false,
+			ETag:       etagFromRevision(teamID, baseRevision),
+			Operations: outcomes,
+		}, nil
+	}
+
+	revision, err := qtx.UpdateTeamStateRevisionIfMatch(ctx, dbsqlc.UpdateTeamStateRevisionIfMatchParams{
+		ID:            teamID,
+		StateRevision: baseRevision,
+	})
+	if err != nil {
+		return ports.BatchResult{}, err
+	}
+	hints := map[string]string{"action": "apply", "operations": strconv.Itoa(appliedCount)}
+	if err := s.writeAuditLocked(batchCtx, qtx, teamID, revision, audit, fallbackAuditChange("batch", hints)); err != nil {
+		return ports.BatchResult{}, err
+	}
+	if err := s.enqueueTeamEventLocked(ctx, qtx, TeamEvent{
+		TeamID:    teamID,
+		Entity:    "batch",
+		Revision:  revision,
+		ChangedAt: s.now(),
+		Hints:     hints,
+	}); err != nil {
+		return ports.BatchResult{}, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return ports.BatchResult{}, err
+	}
+	s.notifyOutbox()
+	return ports.BatchResult{
+		Committed:  true,
+		ETag:       etagFromRevision(teamID, revision),
+		Operations: outcomes,
+	}, nil
+}
+
+func validateBatchRequest(req api.BatchMutationRequest) (bool, error) {
+	atomic := true
+	if req.Mode != nil {
+		switch *req.Mode {
+		case api.Atomic:
+		case api.Independent:
+			atomic = false
+		default:
+			return false, errors.New("invalid batch mode")
+		}
+	}
+	if len(req.Operations) == 0 {
+		return false, errors.New("operations are required")
+	}
+	if len(req.Operations) > batchMaxOperations {
+		return false, fmt.Errorf("invalid batch: at most %d operations are allowed", batchMaxOperations)
+	}
+	seen := make(map[string]struct{}, len(req.Operations))
+	for _, op := range req.Operations {
+		id := strings.TrimSpace(op.Id)
+		if id == "" {
+			return false, errors.New("operation id is required")
+		}
+		if _, ok := seen[id]; ok {
+			return false, fmt.Errorf("invalid batch: duplicate operation id %q", id)
+		}
+		seen[id] = struct{}{}
+	}
+	return atomic, nil
+}
+
+// abortBatchOutcomes marks every operation except the failed one as aborted,
+// since an atomic batch rolls back the operations that already succeeded.
+func abortBatchOutcomes(outcomes []ports.BatchOperationOutcome, ops []api.BatchOperation, failedIndex int) {
+	for i := range outcomes {
+		if i == failedIndex {
+			continue
+		}
+		outcomes[i] = ports.BatchOperationOutcome{
+			Result: api.BatchOperationResult{Id: ops[i].Id, Status: api.Aborted},
+		}
+	}
+}
+
+func (s *Store) applyBatchOperationLocked(
+	ctx context.Context,
+	tx pgx.Tx,
+	teamID, userID string,
+	baseRevision int64,
+	original map[string]int64,
+	ifMatch string,
+	op api.BatchOperation,
+) ports.BatchOperationOutcome {
+	result := api.BatchOperationResult{Id: op.Id}
+	fail := func(err error) ports.BatchOperationOutcome {
+		result.Status = api.Failed
+		return ports.BatchOperationOutcome{Result: result, Err: err}
+	}
+
+	parsed, err := batchOperationIfMatch(teamID, baseRevision, ifMatch)
+	if err != nil {
+		return fail(err)
+	}
+	scope := revisionScope{TeamID: teamID, Base: baseRevision, IfMatch: parsed, Original: original}
+	err = s.runBatchOperationInSavepoint(withRevisionScope(ctx, scope), tx, teamID, userID, op, &result)
+	var preconditionErr *application.PreconditionError
+	if errors.As(err, &preconditionErr) && isCommutativeBatchOperation(op) {
+		// Commutative operations are rebased onto the current state instead
+		// of failing on a stale ETag.
+		scope.IfMatch = parsedETag{Any: true}
+		result = api.BatchOperationResult{Id: op.Id}
+		if err := s.runBatchOperationInSavepoint(withRevisionScope(ctx, scope), tx, teamID, userID, op, &result); err != nil {
+			return fail(err)
+		}
+		result.Status = api.Rebased
+		return ports.BatchOperationOutcome{Result: result}
+	}
+	if err != nil {
+		return fail(err)
+	}
+	result.Status = api.Applied
+	return ports.BatchOperationOutcome{Result: result}
+}
+
+// runBatchOperationInSavepoint isolates one operation so a failed item does
+// not poison the batch. Its audit entries are kept only once the savepoint
+// commits.
+func (s *Store) runBatchOperationInSavepoint(
+	ctx context.Context,
+	tx pgx.Tx,
+	teamID, userID string,
+	op api.BatchOperation,
+	result *api.BatchOperationResult,
+) error {
+	sp, err := tx.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = sp.Rollback(ctx)
+	}()
+	audit := &auditRecorder{}
+	if err := s.runBatchOperationLocked(withAuditRecorder(ctx, audit), s.q.WithTx(sp), teamID, userID, op, result); err != nil {
+		return err
+	}
+	if err := sp.Commit(ctx); err != nil {
+		return err
+	}
+	for _, change := range audit.changes {
+		recordAudit(ctx, change)
+	}
+	return nil
+}
+
+// batchOperationIfMatch validates the ETag an operation was queued with. The
+// entity-level check happens when the operation loads the entity it writes.
+func batchOperationIfMatch(teamID string, baseRevision int64, ifMatch string) (parsedETag, error) {
+	if strings.TrimSpace(ifMatch) == "" {
+		return parsedETag{}, &application.PreconditionRequiredError{Message: "If-Match is required for each operation"}
+	}
+	parsed, err := parseETag(ifMatch)
+	if err != nil {
+		return parsedETag{}, &application.PreconditionError{Message: "If-Match header is invalid"}
+	}
+	if err := checkTeamIfMatch(teamID, baseRevision, parsed); err != nil {
+		return parsedETag{}, err
+	}
+	return parsed, nil
+}
+
+func isCommutativeBatchOperation(op api.BatchOperation) bool {
+	if op.Type != api.ToggleTaskCompletion || op.ToggleTaskCompletion == nil {
+		return false
+	}
+	mode := completionActionOrDefault(op.ToggleTaskCompletion.Action)
+	return mode == api.Increment || mode == api.Decrement
+}
+
+func (s *Store) runBatchOperationLocked(
+	ctx context.Context,
+	qtx *dbsqlc.Queries,
+	teamID, userID string,
+	op api.BatchOperation,
+	result *api.BatchOperationResult,
+) error {
+	switch op.Type {
+	case api.CreateTask:
+		if op.CreateTask == nil {
+			return errors.New("createTask payload is required")
+		}
+		task, err := s.newTaskRecord(teamID, *op.CreateTask)
+		if err != nil {
+			return err
+		}
+		task.Revision = nextEntityRevision(ctx)
+		if err := insertTaskLocked(ctx, qtx, task); err != nil {
+			return err
+		}
+		res := task.toAPI()
+		result.Task = &res
+	case api.UpdateTask:
+		taskID, err := requiredBatchID(op.TaskId, "taskId")
+		if err != nil {
+			return err
+		}
+		if op.UpdateTask == nil {
+			return errors.New("updateTask payload is required")
+		}
+		task, err := s.updateTaskLocked(ctx, qtx, teamID, taskID, *op.UpdateTask)
+		if err != nil {
+			return err
+		}
+		res := task.toAPI()
+		result.Task = &res
+	case api.DeleteTask:
+		taskID, err := requiredBatchID(op.TaskId, "taskId")
+		if err != nil {
+			return err
+		}
+		return s.deleteTaskLocked(ctx, qtx, teamID, taskID)
+	case api.ToggleTaskCompletion:
+		taskID, err := requiredBatchID(op.TaskId, "taskId")
+		if err != nil {
+			return err
+		}
+		if op.ToggleTaskCompletion == nil {
+			return errors.New("toggleTaskCompletion payload is required")
+		}
+		res, err := s.toggleTaskCompletionLocked(
+			ctx,
+			qtx,
+			teamID,
+			userID,
+			taskID,
+			op.ToggleTaskCompletion.TargetDate.Time,
+			completionActionOrDefault(op.ToggleTaskCompletion.Action),
+		)
+		if err != nil {
+			return err
+		}
+		result.Completion = &res
+	case api.CreatePenaltyRule:
+		if op.CreatePenaltyRule == nil {
+			return errors.New("createPenaltyRule payload is required")
+		}
+		rule, err := s.newRuleRecord(teamID, *op.CreatePenaltyRule)
+		if err != nil {
+			return err
+		}
+		rule.Revision = nextEntityRevision(ctx)
+		if err := insertPenaltyRuleLocked(ctx, qtx, rule); err != nil {
+			return err
+		}
+		res := rule.toAPI()
+		result.PenaltyRule = &res
+	case api.UpdatePenaltyRule:
+		ruleID, err := requiredBatchID(op.RuleId, "ruleId")
+		if err != nil {
+			return err
+		}
+		if op.UpdatePenaltyRule == nil {
+			return errors.New("updatePenaltyRule payload is required")
+		}
+		rule, err := s.updatePenaltyRuleLocked(ctx, qtx, teamID, ruleID, *op.UpdatePenaltyRule)
+		if err != nil {
+			return err
+		}
+		res := rule.toAPI()
+		result.PenaltyRule = &res
+	case api.DeletePenaltyRule:
+		ruleID, err := requiredBatchID(op.RuleId, "ruleId")
+		if err != nil {
+			return err
+		}
+		return s.deletePenaltyRuleLocked(ctx, qtx, teamID, ruleID)
+	default:
+		return errors.New("invalid batch operation type")
+	}
+	return nil
+}
+
+func requiredBatchID(value *string, field string) (string, error) {
+	if value == nil || strings.TrimSpace(*value) == "" {
+		return "", fmt.Errorf("%s is required", field)
+	}
+	return strings.TrimSpace(*value), nil
+}
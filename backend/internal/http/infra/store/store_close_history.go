@@ -0,0 +1,208 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	dbsqlc "github.com/megu/kaji-challenge/backend/internal/db/sqlc"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+// Close triggers recorded in close_run_history.
+const (
+	CloseTriggerOps       = "ops"
+	CloseTriggerAdmin     = "admin"
+	CloseTriggerScheduler = "scheduler"
+)
+
+const (
+	closeRunsDefaultLimit = 50
+	closeRunsMaxLimit     = 200
+)
+
+var closeScopes = []string{"day", "week", "month"}
+
+type closeTriggerContextKey struct{}
+
+// WithCloseTrigger marks the team closes run with ctx as started by trigger.
+// Team closes without a trigger are recorded as ops runs.
+func WithCloseTrigger(ctx context.Context, trigger string) context.Context {
+	return context.WithValue(ctx, closeTriggerContextKey{}, trigger)
+}
+
+func closeTriggerFromContext(ctx context.Context) string {
+	if trigger, ok := ctx.Value(closeTriggerContextKey{}).(string); ok && trigger != "" {
+		return trigger
+	}
+	return CloseTriggerOps
+}
+
+// trackCloseRun runs one close invocation and records it in
+// close_run_history, failures included. The history row is written after run
+// returns, so it never holds up the team lock.
+func (s *Store) trackCloseRun(ctx context.Context, teamID, scope, trigger string, asOf time.Time, run func(ctx context.Context) error) error {
+	report, ok := ctx.Value(closeReportContextKey{}).(*CloseReport)
+	if !ok || report == nil {
+		report = &CloseReport{TeamID: teamID, Scope: scope, AsOf: asOf, Periods: []ClosePeriodReport{}}
+		ctx = withCloseReport(ctx, report)
+	}
+	startedAt := time.Now()
+	err := run(ctx)
+	s.recordCloseRun(ctx, teamID, scope, trigger, asOf, startedAt, *report, err)
+	return err
+}
+
+// recordCloseRun is best effort: losing a history row must not fail a close
+// that already committed. Started and finished times are wall-clock even
+// when the store clock is fixed (ops close --as-of), so backdated runs still
+// sort, time and expire by when they ran; as_of keeps the instant they closed
+// as.
+func (s *Store) recordCloseRun(ctx context.Context, teamID, scope, trigger string, asOf, startedAt time.Time, report CloseReport, runErr error) {
+	penalizedTasks := 0
+	for _, p := range report.Periods {
+		penalizedTasks += len(p.Penalties)
+	}
+	params := dbsqlc.InsertCloseRunHistoryParams{
+		ID:               s.nextID("crh"),
+		TeamID:           teamID,
+		Scope:            scope,
+		Trigger:          trigger,
+		AsOf:             toPgTimestamptz(asOf),
+		StartedAt:        toPgTimestamptz(startedAt),
+		FinishedAt:       toPgTimestamptz(time.Now()),
+		PeriodsProcessed: int32(len(report.Periods)),
+		PenaltyAdded:     int32(report.PenaltyAdded()),
+		PenalizedTasks:   int32(penalizedTasks),
+	}
+	if runErr != nil {
+		params.ErrorClass = pgtype.Text{String: CloseErrorClass(runErr), Valid: true}
+		params.ErrorMessage = pgtype.Text{String: runErr.Error(), Valid: true}
+	}
+	// The run may have failed because ctx expired; the record still belongs.
+	if err := s.q.InsertCloseRunHistory(context.WithoutCancel(ctx), params); err != nil {
+		log.Printf("failed to record close run: team_id=%s scope=%s err=%v", teamID, scope, err)
+	}
+}
+
+func (s *Store) ListCloseRunsForUser(ctx context.Context, userID string, limit *int) (api.CloseRunsResponse, error) {
+	teamID, err := s.primaryTeamLocked(ctx, userID)
+	if err != nil {
+		return api.CloseRunsResponse{}, err
+	}
+	n := closeRunsDefaultLimit
+	if limit != nil {
+		n = *limit
+	}
+	if n < 1 || n > closeRunsMaxLimit {
+		return api.CloseRunsResponse{}, fmt.Errorf("invalid limit: must be between 1 and %d", closeRunsMaxLimit)
+	}
+	status, err := s.GetCloseStatusForTeam(ctx, teamID)
+	if err != nil {
+		return api.CloseRunsResponse{}, err
+	}
+	rows, err := s.q.ListCloseRunHistoryByTeamID(ctx, dbsqlc.ListCloseRunHistoryByTeamIDParams{
+		TeamID: teamID,
+		Limit:  int32(n),
+	})
+	if err != nil {
+		return api.CloseRunsResponse{}, err
+	}
+	items := make([]api.CloseRun, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, closeRunFromDB(row, s.loc))
+	}
+	return api.CloseRunsResponse{Status: status, Items: items}, nil
+}
+
+// GetCloseStatusForTeam reports, per scope, the latest closed period and
+// whether the team lags behind the latest period that should be closed.
+func (s *Store) GetCloseStatusForTeam(ctx context.Context, teamID string) ([]api.CloseScopeStatus, error) {
+	now := s.now()
+	items := make([]api.CloseScopeStatus, 0, len(closeScopes))
+	for _, scope := range closeScopes {
+		status, err := s.closeScopeStatusLocked(ctx, teamID, scope, now)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, status)
+	}
+	return items, nil
+}
+
+func (s *Store) closeScopeStatusLocked(ctx context.Context, teamID, scope string, now time.Time) (api.CloseScopeStatus, error) {
+	today := dateOnly(now, s.loc)
+	var expected, next time.Time
+	var pending bool
+	var err error
+	switch scope {
+	case "day":
+		expected = today.AddDate(0, 0, -1)
+		next, pending, err = s.nextDayTargetLocked(ctx, teamID)
+	case "week":
+		expected = startOfWeek(today, s.loc).AddDate(0, 0, -7)
+		next, pending, err = s.nextWeekTargetLocked(ctx, teamID)
+	case "month":
+		// The previous month is only due from the configured close day on,
+		// since month-spanning weeks settle after the 1st.
+		expected = time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, s.loc).AddDate(0, -1, 0)
+		if today.Day() < s.closeSchedulerCfg.MonthDay {
+			expected = expected.AddDate(0, -1, 0)
+		}
+		next, pending, err = s.nextMonthTargetLocked(ctx, teamID)
+	default:
+		return api.CloseScopeStatus{}, fmt.Errorf("unsupported scope: %s", scope)
+	}
+	if err != nil {
+		return api.CloseScopeStatus{}, err
+	}
+
+	status := api.CloseScopeStatus{
+		Scope:              scope,
+		ExpectedTargetDate: toDate(expected),
+		Lagging:            pending && !next.After(expected),
+	}
+	latest, err := s.queries(ctx).GetLatestCloseRunTargetDate(ctx, dbsqlc.GetLatestCloseRunTargetDateParams{
+		TeamID: teamID,
+		Scope:  "close_" + scope,
+	})
+	if err != nil {
+		return api.CloseScopeStatus{}, err
+	}
+	if latest.Valid {
+		d := toDate(dateOnly(latest.Time, s.loc))
+		status.LatestTargetDate = &d
+	}
+	lastRun, err := s.queries(ctx).GetLatestCloseRunHistory(ctx, dbsqlc.GetLatestCloseRunHistoryParams{
+		TeamID: teamID,
+		Scope:  scope,
+	})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return api.CloseScopeStatus{}, err
+	}
+	if err == nil {
+		run := closeRunFromDB(lastRun, s.loc)
+		status.LastRun = &run
+	}
+	return status, nil
+}
+
+func closeRunFromDB(row dbsqlc.CloseRunHistory, loc *time.Location) api.CloseRun {
+	return api.CloseRun{
+		Id:               row.ID,
+		Scope:            row.Scope,
+		Trigger:          row.Trigger,
+		AsOf:             row.AsOf.Time.In(loc),
+		StartedAt:        row.StartedAt.Time.In(loc),
+		FinishedAt:       row.FinishedAt.Time.In(loc),
+		PeriodsProcessed: int(row.PeriodsProcessed),
+		PenaltyAdded:     int(row.PenaltyAdded),
+		PenalizedTasks:   int(row.PenalizedTasks),
+		ErrorClass:       ptrFromText(row.ErrorClass),
+		ErrorMessage:     ptrFromText(row.ErrorMessage),
+	}
+}
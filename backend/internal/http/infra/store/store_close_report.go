@@ -0,0 +1,193 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+// CloseReport describes the periods one close run closed for a team, or
+// would close for PreviewCloseForTeam. Both are built from the same code
+// path. Month matches CloseResponse.Month of the equivalent close.
+type CloseReport struct {
+	TeamID  string              `json:"teamId"`
+	Scope   string              `json:"scope"`
+	AsOf    time.Time           `json:"asOf"`
+	Month   string              `json:"month"`
+	Periods []ClosePeriodReport `json:"periods"`
+}
+
+// ClosePeriodReport is one closed day, week or month. Target is the date for
+// day, the week start for week and the month key for month. For a month,
+// PenaltyTotal is the month total the rules were evaluated against.
+type ClosePeriodReport struct {
+	Scope          string               `json:"scope"`
+	Target         string               `json:"target"`
+	Month          string               `json:"month"`
+	Penalties      []ClosePenaltyReport `json:"penalties"`
+	PenaltyTotal   int                  `json:"penaltyTotal"`
+	TriggeredRules []CloseRuleReport    `json:"triggeredRules"`
+}
+
+type ClosePenaltyReport struct {
+	TaskID string `json:"taskId"`
+	Title  string `json:"title"`
+	Points int    `json:"points"`
+}
+
+type CloseRuleReport struct {
+	RuleID    string `json:"ruleId"`
+	Name      string `json:"name"`
+	Threshold int    `json:"threshold"`
+}
+
+// PenaltyAdded is the penalty the report's day and week closes added.
+func (r CloseReport) PenaltyAdded() int {
+	total := 0
+	for _, p := range r.Periods {
+		if p.Scope != "month" {
+			total += p.PenaltyTotal
+		}
+	}
+	return total
+}
+
+// TriggeredRules lists the rules triggered by the report's month closes.
+func (r CloseReport) TriggeredRules() []CloseRuleReport {
+	rules := []CloseRuleReport{}
+	for _, p := range r.Periods {
+		rules = append(rules, p.TriggeredRules...)
+	}
+	return rules
+}
+
+func (p *ClosePeriodReport) addPenalty(taskID, title string, points int32) {
+	p.Penalties = append(p.Penalties, ClosePenaltyReport{TaskID: taskID, Title: title, Points: int(points)})
+	p.PenaltyTotal += int(points)
+}
+
+type closeReportContextKey struct{}
+
+func withCloseReport(ctx context.Context, report *CloseReport) context.Context {
+	return context.WithValue(ctx, closeReportContextKey{}, report)
+}
+
+// closeReportMark and rollbackCloseReport drop the periods recorded by a
+// period transaction that did not commit.
+func closeReportMark(ctx context.Context) int {
+	report, ok := ctx.Value(closeReportContextKey{}).(*CloseReport)
+	if !ok || report == nil {
+		return 0
+	}
+	return len(report.Periods)
+}
+
+func rollbackCloseReport(ctx context.Context, mark int) {
+	report, ok := ctx.Value(closeReportContextKey{}).(*CloseReport)
+	if !ok || report == nil || len(report.Periods) < mark {
+		return
+	}
+	report.Periods = report.Periods[:mark]
+}
+
+// recordClosePeriod appends period to the report collected in ctx, if any.
+// Real closes carry no report, so this is a no-op for them.
+func recordClosePeriod(ctx context.Context, period ClosePeriodReport) {
+	report, ok := ctx.Value(closeReportContextKey{}).(*CloseReport)
+	if !ok || report == nil {
+		return
+	}
+	if period.Penalties == nil {
+		period.Penalties = []ClosePenaltyReport{}
+	}
+	if period.TriggeredRules == nil {
+		period.TriggeredRules = []CloseRuleReport{}
+	}
+	report.Periods = append(report.Periods, period)
+}
+
+// PreviewCloseForTeam runs the catch-up close for scope inside a transaction
+// that is always rolled back, and reports what it would have closed.
+func (s *Store) PreviewCloseForTeam(ctx context.Context, teamID, scope string) (CloseReport, error) {
+	now := s.now()
+	report := CloseReport{TeamID: teamID, Scope: scope, AsOf: now, Month: monthKeyFromTime(now, s.loc), Periods: []ClosePeriodReport{}}
+	lockedCtx, release, err := s.lockTeamClose(ctx, teamID)
+	if err != nil {
+		return CloseReport{}, err
+	}
+	defer release()
+
+	tx, err := s.beginCloseTx(lockedCtx)
+	if err != nil {
+		return CloseReport{}, err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+	txCtx := withCloseReport(withTxQueries(ctx, s.q.WithTx(tx)), &report)
+
+	switch scope {
+	case "day":
+		_, err = s.catchUpDayLocked(txCtx, now, teamID)
+	case "week":
+		_, err = s.catchUpWeekLocked(txCtx, now, teamID)
+	case "month":
+		_, report.Month, err = s.catchUpMonthLocked(txCtx, now, teamID)
+	default:
+		err = fmt.Errorf("unsupported scope: %s", scope)
+	}
+	if err != nil {
+		return CloseReport{}, err
+	}
+	return report, nil
+}
+
+// RunCloseForTeam runs the close for scope like Close{Day,Week,Month}ForTeam
+// and reports the periods it committed. On error the report still lists the
+// periods closed before the failure.
+func (s *Store) RunCloseForTeam(ctx context.Context, teamID, scope string) (CloseReport, error) {
+	report := CloseReport{TeamID: teamID, Scope: scope, AsOf: s.now(), Periods: []ClosePeriodReport{}}
+	ctx = withCloseReport(ctx, &report)
+
+	var res api.CloseResponse
+	var err error
+	switch scope {
+	case "day":
+		res, err = s.CloseDayForTeam(ctx, teamID)
+	case "week":
+		res, err = s.CloseWeekForTeam(ctx, teamID)
+	case "month":
+		res, err = s.CloseMonthForTeam(ctx, teamID)
+	default:
+		err = fmt.Errorf("unsupported scope: %s", scope)
+	}
+	if err != nil {
+		return report, err
+	}
+	report.AsOf = res.ClosedAt
+	report.Month = res.Month
+	return report, nil
+}
+
+// CloseErrorClass buckets a close failure for monitoring.
+func CloseErrorClass(err error) string {
+	var pgErr *pgconn.PgError
+	switch {
+	case err == nil:
+		return ""
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, errMonthAlreadyClosed):
+		return "month_closed"
+	case errors.As(err, &pgErr):
+		return "database"
+	default:
+		return "internal"
+	}
+}
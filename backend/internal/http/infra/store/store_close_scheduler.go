@@ -0,0 +1,210 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	dbsqlc "github.com/megu/kaji-challenge/backend/internal/db/sqlc"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+const (
+	closeSchedulerDefaultMonthDay = 6
+	closeSchedulerMonthDayLimit   = 28
+	// closeSchedulerDelay keeps rounds clear of the exact day boundary.
+	closeSchedulerDelay      = time.Minute
+	closeSchedulerRetryDelay = 5 * time.Minute
+	// closeSchedulerLockKey is the advisory lock shared by every replica, so
+	// only one of them runs a round at a time.
+	closeSchedulerLockKey int64 = 0x6b616a69636c6f73
+)
+
+type closeSchedulerConfig struct {
+	Enabled  bool
+	MonthDay int
+}
+
+func loadCloseSchedulerConfig() (closeSchedulerConfig, error) {
+	cfg := closeSchedulerConfig{
+		Enabled:  strings.EqualFold(strings.TrimSpace(os.Getenv("CLOSE_SCHEDULER_ENABLED")), "true"),
+		MonthDay: closeSchedulerDefaultMonthDay,
+	}
+	if v := strings.TrimSpace(os.Getenv("CLOSE_SCHEDULER_MONTH_DAY")); v != "" {
+		day, err := parseEnvInt32(v, "CLOSE_SCHEDULER_MONTH_DAY", false)
+		if err != nil {
+			return closeSchedulerConfig{}, err
+		}
+		if err := ensureInt32UpperLimit(day, "CLOSE_SCHEDULER_MONTH_DAY", closeSchedulerMonthDayLimit, v); err != nil {
+			return closeSchedulerConfig{}, err
+		}
+		cfg.MonthDay = int(day)
+	}
+	return cfg, nil
+}
+
+// closeSchedulerRunner is the subset of Store the scheduler drives.
+type closeSchedulerRunner interface {
+	ListClosableTeamIDs(ctx context.Context) ([]string, error)
+	CloseDayForTeam(ctx context.Context, teamID string) (api.CloseResponse, error)
+	CloseWeekForTeam(ctx context.Context, teamID string) (api.CloseResponse, error)
+	CloseMonthForTeam(ctx context.Context, teamID string) (api.CloseResponse, error)
+}
+
+// closeScheduler runs the same catch-up closes as `ops close` in process:
+// day, then week, then month for every team, once per local day.
+type closeScheduler struct {
+	clock    Clock
+	loc      *time.Location
+	monthDay int
+	runner   closeSchedulerRunner
+	// tryLock returns ok=false when another replica holds the lock.
+	tryLock func(ctx context.Context) (release func(), ok bool, err error)
+}
+
+// RunCloseScheduler runs scheduled closes until ctx is cancelled. It returns
+// immediately unless CLOSE_SCHEDULER_ENABLED=true.
+func (s *Store) RunCloseScheduler(ctx context.Context) {
+	if !s.closeSchedulerCfg.Enabled {
+		return
+	}
+	scheduler := &closeScheduler{
+		clock:    s.clock,
+		loc:      s.loc,
+		monthDay: s.closeSchedulerCfg.MonthDay,
+		runner:   s,
+		tryLock: func(ctx context.Context) (func(), bool, error) {
+			return s.tryAdvisoryLock(ctx, closeSchedulerLockKey)
+		},
+	}
+	log.Printf("close scheduler started: month_day=%d", scheduler.monthDay)
+	scheduler.run(WithCloseTrigger(ctx, CloseTriggerScheduler))
+}
+
+func (c *closeScheduler) run(ctx context.Context) {
+	for {
+		// The first round runs at startup so a restart catches up missed days.
+		err := c.runRound(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		now := c.clock.Now()
+		wait := nextCloseRunAt(now, c.loc).Sub(now)
+		if err != nil {
+			log.Printf("close scheduler round failed: %v", err)
+			if wait > closeSchedulerRetryDelay {
+				wait = closeSchedulerRetryDelay
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-c.clock.After(wait):
+		}
+	}
+}
+
+// runRound closes every team once. Failures are per team: the later scopes
+// of a failed team are skipped and the remaining teams still run.
+func (c *closeScheduler) runRound(ctx context.Context) error {
+	release, ok, err := c.tryLock(ctx)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return nil
+	}
+	defer release()
+
+	teamIDs, err := c.runner.ListClosableTeamIDs(ctx)
+	if err != nil {
+		return err
+	}
+	includeMonth := c.clock.Now().In(c.loc).Day() >= c.monthDay
+	failed := 0
+	for _, teamID := range teamIDs {
+		if err := c.closeTeam(ctx, teamID, includeMonth); err != nil {
+			failed++
+			log.Printf("close scheduler failed: team_id=%s err=%v", teamID, err)
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d teams failed", failed, len(teamIDs))
+	}
+	return nil
+}
+
+func (c *closeScheduler) closeTeam(ctx context.Context, teamID string, includeMonth bool) error {
+	if _, err := c.runner.CloseDayForTeam(ctx, teamID); err != nil {
+		return fmt.Errorf("scope=day: %w", err)
+	}
+	if _, err := c.runner.CloseWeekForTeam(ctx, teamID); err != nil {
+		return fmt.Errorf("scope=week: %w", err)
+	}
+	if !includeMonth {
+		return nil
+	}
+	if _, err := c.runner.CloseMonthForTeam(ctx, teamID); err != nil {
+		return fmt.Errorf("scope=month: %w", err)
+	}
+	return nil
+}
+
+// nextCloseRunAt is shortly after the next local midnight. Every team shares
+// the store location, so one boundary covers all of them.
+func nextCloseRunAt(now time.Time, loc *time.Location) time.Time {
+	local := now.In(loc)
+	midnight := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, loc)
+	next := midnight.Add(closeSchedulerDelay)
+	if !next.After(local) {
+		next = midnight.AddDate(0, 0, 1).Add(closeSchedulerDelay)
+	}
+	return next
+}
+
+// tryAdvisoryLock takes a session-level advisory lock on a dedicated pool
+// connection. release unlocks it and hands the connection back.
+func (s *Store) tryAdvisoryLock(ctx context.Context, key int64) (func(), bool, error) {
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+	q := dbsqlc.New(conn)
+	locked, err := q.TryAdvisoryLock(ctx, key)
+	if err != nil || !locked {
+		conn.Release()
+		return nil, false, err
+	}
+	return advisoryLockRelease(conn, q, key), true, nil
+}
+
+// advisoryLock is tryAdvisoryLock that waits for the lock until ctx is done.
+// The locked connection is returned so the caller can keep working on it.
+func (s *Store) advisoryLock(ctx context.Context, key int64) (*pgxpool.Conn, func(), error) {
+	conn, err := s.db.Acquire(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	q := dbsqlc.New(conn)
+	if err := q.AdvisoryLock(ctx, key); err != nil {
+		// A cancelled wait may still have been granted server-side.
+		_ = conn.Conn().Close(context.Background())
+		conn.Release()
+		return nil, nil, err
+	}
+	return conn, advisoryLockRelease(conn, q, key), nil
+}
+
+func advisoryLockRelease(conn *pgxpool.Conn, q *dbsqlc.Queries, key int64) func() {
+	return func() {
+		if _, err := q.ReleaseAdvisoryLock(context.Background(), key); err != nil {
+			// Never return a connection that may still hold the lock.
+			_ = conn.Conn().Close(context.Background())
+		}
+		conn.Release()
+	}
+}
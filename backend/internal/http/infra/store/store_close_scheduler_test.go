@@ -0,0 +1,276 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waits   []time.Duration
+	waiters chan chan time.Time
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{now: now, waiters: make(chan chan time.Time, 8)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	c.waits = append(c.waits, d)
+	c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	c.waiters <- ch
+	return ch
+}
+
+// awaitWait blocks until the scheduler waits on the clock, which means the
+// round before it has finished.
+func (c *fakeClock) awaitWait(t *testing.T) chan time.Time {
+	t.Helper()
+	select {
+	case ch := <-c.waiters:
+		return ch
+	case <-time.After(time.Second):
+		t.Fatalf("scheduler did not wait on the clock")
+		return nil
+	}
+}
+
+// advance moves the clock to the pending deadline and fires the waiter.
+func (c *fakeClock) advance(t *testing.T) {
+	t.Helper()
+	ch := c.awaitWait(t)
+	c.mu.Lock()
+	c.now = c.now.Add(c.waits[len(c.waits)-1])
+	now := c.now
+	c.mu.Unlock()
+	ch <- now
+}
+
+func (c *fakeClock) recordedWaits() []time.Duration {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]time.Duration{}, c.waits...)
+}
+
+type fakeSchedulerRunner struct {
+	mu    sync.Mutex
+	teams []string
+	errs  map[string]error
+	calls []string
+}
+
+func (f *fakeSchedulerRunner) ListClosableTeamIDs(context.Context) ([]string, error) {
+	return f.teams, nil
+}
+
+func (f *fakeSchedulerRunner) record(scope, teamID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, scope+":"+teamID)
+	return f.errs[scope+":"+teamID]
+}
+
+func (f *fakeSchedulerRunner) CloseDayForTeam(_ context.Context, teamID string) (api.CloseResponse, error) {
+	return api.CloseResponse{}, f.record("day", teamID)
+}
+
+func (f *fakeSchedulerRunner) CloseWeekForTeam(_ context.Context, teamID string) (api.CloseResponse, error) {
+	return api.CloseResponse{}, f.record("week", teamID)
+}
+
+func (f *fakeSchedulerRunner) CloseMonthForTeam(_ context.Context, teamID string) (api.CloseResponse, error) {
+	return api.CloseResponse{}, f.record("month", teamID)
+}
+
+func (f *fakeSchedulerRunner) joinedCalls() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return strings.Join(f.calls, ",")
+}
+
+func alwaysLocked(context.Context) (func(), bool, error) {
+	return func() {}, true, nil
+}
+
+func TestNextCloseRunAt(t *testing.T) {
+	t.Parallel()
+
+	loc := time.FixedZone("JST", 9*60*60)
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		{name: "evening", now: time.Date(2026, 3, 14, 21, 0, 0, 0, loc), want: time.Date(2026, 3, 15, 0, 1, 0, 0, loc)},
+		{name: "just after midnight", now: time.Date(2026, 3, 15, 0, 0, 30, 0, loc), want: time.Date(2026, 3, 15, 0, 1, 0, 0, loc)},
+		{name: "exactly at run time", now: time.Date(2026, 3, 15, 0, 1, 0, 0, loc), want: time.Date(2026, 3, 16, 0, 1, 0, 0, loc)},
+		{name: "utc input", now: time.Date(2026, 3, 31, 14, 30, 0, 0, time.UTC), want: time.Date(2026, 4, 1, 0, 1, 0, 0, loc)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := nextCloseRunAt(tt.now, loc); !got.Equal(tt.want) {
+				t.Fatalf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCloseSchedulerRoundRunsScopesInOrder(t *testing.T) {
+	t.Parallel()
+
+	loc := time.FixedZone("JST", 9*60*60)
+	runner := &fakeSchedulerRunner{
+		teams: []string{"team-1", "team-2"},
+		errs:  map[string]error{"day:team-1": errors.New("boom")},
+	}
+	scheduler := &closeScheduler{
+		clock:    newFakeClock(time.Date(2026, 3, 6, 0, 1, 0, 0, loc)),
+		loc:      loc,
+		monthDay: 6,
+		runner:   runner,
+		tryLock:  alwaysLocked,
+	}
+
+	err := scheduler.runRound(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "1 of 2 teams failed") {
+		t.Fatalf("expected partial failure, got %v", err)
+	}
+	if got := runner.joinedCalls(); got != "day:team-1,day:team-2,week:team-2,month:team-2" {
+		t.Fatalf("unexpected calls: %s", got)
+	}
+}
+
+func TestCloseSchedulerRoundSkipsMonthBeforeMonthDay(t *testing.T) {
+	t.Parallel()
+
+	loc := time.FixedZone("JST", 9*60*60)
+	runner := &fakeSchedulerRunner{teams: []string{"team-1"}}
+	scheduler := &closeScheduler{
+		clock:    newFakeClock(time.Date(2026, 3, 5, 0, 1, 0, 0, loc)),
+		loc:      loc,
+		monthDay: 6,
+		runner:   runner,
+		tryLock:  alwaysLocked,
+	}
+	if err := scheduler.runRound(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := runner.joinedCalls(); got != "day:team-1,week:team-1" {
+		t.Fatalf("unexpected calls: %s", got)
+	}
+}
+
+func TestCloseSchedulerRoundSkipsWhenLockIsHeld(t *testing.T) {
+	t.Parallel()
+
+	runner := &fakeSchedulerRunner{teams: []string{"team-1"}}
+	scheduler := &closeScheduler{
+		clock:    newFakeClock(time.Date(2026, 3, 6, 0, 1, 0, 0, time.UTC)),
+		loc:      time.UTC,
+		monthDay: 6,
+		runner:   runner,
+		tryLock: func(context.Context) (func(), bool, error) {
+			return nil, false, nil
+		},
+	}
+	if err := scheduler.runRound(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := runner.joinedCalls(); got != "" {
+		t.Fatalf("expected no closes while another replica holds the lock, got %s", got)
+	}
+}
+
+func TestCloseSchedulerWaitsForNextBoundary(t *testing.T) {
+	t.Parallel()
+
+	loc := time.FixedZone("JST", 9*60*60)
+	clock := newFakeClock(time.Date(2026, 3, 14, 21, 0, 0, 0, loc))
+	runner := &fakeSchedulerRunner{teams: []string{"team-1"}}
+	scheduler := &closeScheduler{clock: clock, loc: loc, monthDay: 28, runner: runner, tryLock: alwaysLocked}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		scheduler.run(ctx)
+	}()
+
+	clock.advance(t)
+	clock.awaitWait(t)
+	cancel()
+	<-done
+
+	if got := runner.joinedCalls(); got != "day:team-1,week:team-1,day:team-1,week:team-1" {
+		t.Fatalf("expected a startup round and a boundary round, got %s", got)
+	}
+	waits := clock.recordedWaits()
+	if waits[0] != 3*time.Hour+time.Minute {
+		t.Fatalf("expected first wait until the next boundary, got %s", waits[0])
+	}
+	if waits[1] != 24*time.Hour {
+		t.Fatalf("expected daily cadence after the boundary, got %s", waits[1])
+	}
+}
+
+func TestCloseSchedulerRetriesFailedRoundSooner(t *testing.T) {
+	t.Parallel()
+
+	loc := time.FixedZone("JST", 9*60*60)
+	clock := newFakeClock(time.Date(2026, 3, 14, 21, 0, 0, 0, loc))
+	runner := &fakeSchedulerRunner{
+		teams: []string{"team-1"},
+		errs:  map[string]error{"week:team-1": errors.New("boom")},
+	}
+	scheduler := &closeScheduler{clock: clock, loc: loc, monthDay: 28, runner: runner, tryLock: alwaysLocked}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		scheduler.run(ctx)
+	}()
+	clock.awaitWait(t)
+	cancel()
+	<-done
+
+	if waits := clock.recordedWaits(); waits[0] != closeSchedulerRetryDelay {
+		t.Fatalf("expected retry delay after a failed round, got %s", waits[0])
+	}
+}
+
+func TestTryAdvisoryLockIsExclusive(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	release, ok, err := s.tryAdvisoryLock(ctx, closeSchedulerLockKey)
+	if err != nil || !ok {
+		t.Fatalf("expected first lock to succeed, ok=%v err=%v", ok, err)
+	}
+	if _, ok, err := s.tryAdvisoryLock(ctx, closeSchedulerLockKey); err != nil || ok {
+		t.Fatalf("expected second lock to be refused, ok=%v err=%v", ok, err)
+	}
+	release()
+
+	again, ok, err := s.tryAdvisoryLock(ctx, closeSchedulerLockKey)
+	if err != nil || !ok {
+		t.Fatalf("expected lock to be free after release, ok=%v err=%v", ok, err)
+	}
+	again()
+}
@@ -4,10 +4,12 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"hash/fnv"
 	"sort"
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	dbsqlc "github.com/megu/kaji-challenge/backend/internal/db/sqlc"
 	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
 )
@@ -19,23 +21,12 @@ func (s *Store) CloseDayForUser(ctx context.Context, userID string) (api.CloseRe
 	if err != nil {
 		return api.CloseResponse{}, err
 	}
-	now := time.Now().In(s.loc)
-	if _, err := s.runWithTeamRevisionCAS(
-		ctx,
-		teamID,
-		"close_run",
-		map[string]string{"scope": "day"},
-		func(txCtx context.Context, _ *dbsqlc.Queries) error {
-			processed, err := s.catchUpDayLocked(txCtx, now, teamID)
-			if err != nil {
-				return err
-			}
-			if processed == 0 {
-				return errNoStateChange
-			}
-			return err
-		},
-	); err != nil {
+	now := s.now()
+	if err := s.trackCloseRun(ctx, teamID, "day", CloseTriggerAdmin, now, func(ctx context.Context) error {
+		return s.runAdminClose(ctx, teamID, "day", func(txCtx context.Context) (int, error) {
+			return s.catchUpDayLocked(txCtx, now, teamID)
+		})
+	}); err != nil {
 		return api.CloseResponse{}, err
 	}
 	return api.CloseResponse{ClosedAt: now, Month: monthKeyFromTime(now, s.loc)}, nil
@@ -46,23 +37,12 @@ func (s *Store) CloseWeekForUser(ctx context.Context, userID string) (api.CloseR
 	if err != nil {
 		return api.CloseResponse{}, err
 	}
-	now := time.Now().In(s.loc)
-	if _, err := s.runWithTeamRevisionCAS(
-		ctx,
-		teamID,
-		"close_run",
-		map[string]string{"scope": "week"},
-		func(txCtx context.Context, _ *dbsqlc.Queries) error {
-			processed, err := s.catchUpWeekLocked(txCtx, now, teamID)
-			if err != nil {
-				return err
-			}
-			if processed == 0 {
-				return errNoStateChange
-			}
-			return err
-		},
-	); err != nil {
+	now := s.now()
+	if err := s.trackCloseRun(ctx, teamID, "week", CloseTriggerAdmin, now, func(ctx context.Context) error {
+		return s.runAdminClose(ctx, teamID, "week", func(txCtx context.Context) (int, error) {
+			return s.catchUpWeekLocked(txCtx, now, teamID)
+		})
+	}); err != nil {
 		return api.CloseResponse{}, err
 	}
 	return api.CloseResponse{ClosedAt: now, Month: monthKeyFromTime(now, s.loc)}, nil
@@ -73,56 +53,178 @@ func (s *Store) CloseMonthForUser(ctx context.Context, userID string) (api.Close
 	if err != nil {
 		return api.CloseResponse{}, err
 	}
-	now := time.Now().In(s.loc)
-	processed := 0
+	now := s.now()
 	closedMonth := monthKeyFromTime(now, s.loc)
-	if _, err := s.runWithTeamRevisionCAS(
+	if err := s.trackCloseRun(ctx, teamID, "month", CloseTriggerAdmin, now, func(ctx context.Context) error {
+		return s.runAdminClose(ctx, teamID, "month", func(txCtx context.Context) (int, error) {
+			processed, month, err := s.catchUpMonthLocked(txCtx, now, teamID)
+			if err == nil {
+				closedMonth = month
+			}
+			return processed, err
+		})
+	}); err != nil {
+		return api.CloseResponse{}, err
+	}
+	return api.CloseResponse{ClosedAt: now, Month: closedMonth}, nil
+}
+
+func (s *Store) CloseDayForTeam(ctx context.Context, teamID string) (api.CloseResponse, error) {
+	now := s.now()
+	if err := s.trackCloseRun(ctx, teamID, "day", closeTriggerFromContext(ctx), now, func(ctx context.Context) error {
+		lockedCtx, release, err := s.lockTeamClose(ctx, teamID)
+		if err != nil {
+			return err
+		}
+		defer release()
+		_, err = s.catchUpDayLocked(lockedCtx, now, teamID)
+		return err
+	}); err != nil {
+		return api.CloseResponse{}, err
+	}
+	return api.CloseResponse{ClosedAt: now, Month: monthKeyFromTime(now, s.loc)}, nil
+}
+
+func (s *Store) CloseWeekForTeam(ctx context.Context, teamID string) (api.CloseResponse, error) {
+	now := s.now()
+	if err := s.trackCloseRun(ctx, teamID, "week", closeTriggerFromContext(ctx), now, func(ctx context.Context) error {
+		lockedCtx, release, err := s.lockTeamClose(ctx, teamID)
+		if err != nil {
+			return err
+		}
+		defer release()
+		_, err = s.catchUpWeekLocked(lockedCtx, now, teamID)
+		return err
+	}); err != nil {
+		return api.CloseResponse{}, err
+	}
+	return api.CloseResponse{ClosedAt: now, Month: monthKeyFromTime(now, s.loc)}, nil
+}
+
+func (s *Store) CloseMonthForTeam(ctx context.Context, teamID string) (api.CloseResponse, error) {
+	now := s.now()
+	closedMonth := ""
+	if err := s.trackCloseRun(ctx, teamID, "month", closeTriggerFromContext(ctx), now, func(ctx context.Context) error {
+		lockedCtx, release, err := s.lockTeamClose(ctx, teamID)
+		if err != nil {
+			return err
+		}
+		defer release()
+		_, closedMonth, err = s.catchUpMonthLocked(lockedCtx, now, teamID)
+		return err
+	}); err != nil {
+		return api.CloseResponse{}, err
+	}
+	return api.CloseResponse{ClosedAt: now, Month: closedMonth}, nil
+}
+
+// runAdminClose runs an admin close as one team-revision write under the
+// team close lock. Nothing is committed on error, so the periods it recorded
+// are dropped from the report as well.
+func (s *Store) runAdminClose(ctx context.Context, teamID, scope string, catchUp func(txCtx context.Context) (int, error)) error {
+	_, release, err := s.lockTeamClose(ctx, teamID)
+	if err != nil {
+		return err
+	}
+	defer release()
+	mark := closeReportMark(ctx)
+	_, err = s.runWithTeamRevisionCAS(
 		ctx,
 		teamID,
 		"close_run",
-		map[string]string{"scope": "month"},
+		map[string]string{"scope": scope},
 		func(txCtx context.Context, _ *dbsqlc.Queries) error {
-			var err error
-			processed, closedMonth, err = s.catchUpMonthLocked(txCtx, now, teamID)
+			processed, err := catchUp(txCtx)
 			if err != nil {
 				return err
 			}
 			if processed == 0 {
 				return errNoStateChange
 			}
-			return err
+			return nil
 		},
-	); err != nil {
-		return api.CloseResponse{}, err
+	)
+	if err != nil {
+		rollbackCloseReport(ctx, mark)
 	}
-	return api.CloseResponse{ClosedAt: now, Month: closedMonth}, nil
+	return err
 }
 
-func (s *Store) CloseDayForTeam(ctx context.Context, teamID string) (api.CloseResponse, error) {
-	now := time.Now().In(s.loc)
-	_, err := s.catchUpDayLocked(ctx, now, teamID)
+type closeConnContextKey struct{}
+
+// lockTeamClose serialises closes of one team across processes: `ops close`,
+// the scheduler and the admin endpoints all take it before any row lock, so
+// they queue behind each other instead of deadlocking. The returned context
+// runs the team's queries on the locked connection, so a team in flight
+// holds exactly one pool connection however many teams run in parallel.
+func (s *Store) lockTeamClose(ctx context.Context, teamID string) (context.Context, func(), error) {
+	conn, release, err := s.advisoryLock(ctx, teamCloseLockKey(teamID))
 	if err != nil {
-		return api.CloseResponse{}, err
+		return nil, nil, err
 	}
-	return api.CloseResponse{ClosedAt: now, Month: monthKeyFromTime(now, s.loc)}, nil
+	return context.WithValue(ctx, closeConnContextKey{}, conn), release, nil
 }
 
-func (s *Store) CloseWeekForTeam(ctx context.Context, teamID string) (api.CloseResponse, error) {
-	now := time.Now().In(s.loc)
-	_, err := s.catchUpWeekLocked(ctx, now, teamID)
-	if err != nil {
-		return api.CloseResponse{}, err
+func teamCloseLockKey(teamID string) int64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte("team_close:" + teamID))
+	return int64(h.Sum64())
+}
+
+func (s *Store) beginCloseTx(ctx context.Context) (pgx.Tx, error) {
+	if conn, ok := ctx.Value(closeConnContextKey{}).(*pgxpool.Conn); ok && conn != nil {
+		return conn.Begin(ctx)
 	}
-	return api.CloseResponse{ClosedAt: now, Month: monthKeyFromTime(now, s.loc)}, nil
+	return s.db.Begin(ctx)
 }
 
-func (s *Store) CloseMonthForTeam(ctx context.Context, teamID string) (api.CloseResponse, error) {
-	now := time.Now().In(s.loc)
-	_, closedMonth, err := s.catchUpMonthLocked(ctx, now, teamID)
+// runClosePeriodTx closes one period atomically, so a failure part-way
+// leaves neither the close_runs row nor a partial penalty behind. A period
+// that ran bumps the team revision and enqueues its close_run event in the
+// same transaction, so cached reads revalidate without an event ever being
+// lost. Callers already inside a transaction (admin closes, previews) reuse
+// it and bump the revision themselves.
+func (s *Store) runClosePeriodTx(ctx context.Context, teamID, scope string, fn func(ctx context.Context) (bool, error)) error {
+	if _, ok := ctx.Value(txQueriesContextKey{}).(*dbsqlc.Queries); ok {
+		_, err := fn(ctx)
+		return err
+	}
+	tx, err := s.beginCloseTx(ctx)
 	if err != nil {
-		return api.CloseResponse{}, err
+		return err
 	}
-	return api.CloseResponse{ClosedAt: now, Month: closedMonth}, nil
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+	qtx := s.q.WithTx(tx)
+	mark := closeReportMark(ctx)
+	didRun, err := func() (bool, error) {
+		// Lock the team row first, in the same order as user writes, so the
+		// bump at the end cannot deadlock with them.
+		if _, err := qtx.GetTeamStateRevisionForUpdate(ctx, teamID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return false, errors.New("team not found")
+			}
+			return false, err
+		}
+		didRun, err := fn(withTxQueries(ctx, qtx))
+		if err != nil || !didRun {
+			return false, err
+		}
+		_, err = s.bumpTeamRevisionLocked(ctx, qtx, teamID, "close_run", map[string]string{"scope": scope}, &auditRecorder{})
+		return true, err
+	}()
+	if err == nil {
+		err = tx.Commit(ctx)
+	}
+	if err != nil {
+		rollbackCloseReport(ctx, mark)
+		return err
+	}
+	if didRun {
+		s.notifyOutbox()
+	}
+	return nil
 }
 
 func (s *Store) ListClosableTeamIDs(ctx context.Context) ([]string, error) {
@@ -163,7 +265,7 @@ func (s *Store) closeDayForTargetLocked(ctx context.Context, targetDate time.Tim
 		return false, err
 	}
 	cutoff := dateOnly(targetDate, s.loc).AddDate(0, 0, 1)
-	totalPenalty, err := s.queries(ctx).SumDailyPenaltyForClose(ctx, dbsqlc.SumDailyPenaltyForCloseParams{
+	penalized, err := s.queries(ctx).RecordDailyPenaltiesForClose(ctx, dbsqlc.RecordDailyPenaltiesForCloseParams{
 		TeamID:     teamID,
 		TargetDate: toPgDate(targetDate),
 		CreatedAt:  toPgTimestamptz(cutoff),
@@ -172,6 +274,26 @@ func (s *Store) closeDayForTargetLocked(ctx context.Context, targetDate time.Tim
 	if err != nil {
 		return false, err
 	}
+	// One-off tasks due on the target date are settled by the same close and
+	// count towards the daily total.
+	penalizedOneOff, err := s.queries(ctx).RecordOneOffPenaltiesForClose(ctx, dbsqlc.RecordOneOffPenaltiesForCloseParams{
+		TeamID:     teamID,
+		TargetDate: toPgDate(targetDate),
+		Cutoff:     toPgTimestamptz(cutoff),
+	})
+	queryCount++
+	if err != nil {
+		return false, err
+	}
+	period := ClosePeriodReport{Scope: "day", Target: targetDate.Format("2006-01-02"), Month: month}
+	for _, row := range penalized {
+		period.addPenalty(row.TaskID, row.Title, row.PenaltyPoints)
+	}
+	for _, row := range penalizedOneOff {
+		period.addPenalty(row.TaskID, row.Title, row.PenaltyPoints)
+	}
+	recordClosePeriod(ctx, period)
+	totalPenalty := int64(period.PenaltyTotal)
 
 	if totalPenalty <= 0 {
 		return true, nil
@@ -226,7 +348,7 @@ func (s *Store) closeWeekForTargetLocked(ctx context.Context, previousWeekStart
 		return false, err
 	}
 	cutoff := dateOnly(previousWeekStart, s.loc).AddDate(0, 0, 7)
-	totalPenalty, err := s.queries(ctx).SumWeeklyPenaltyForClose(ctx, dbsqlc.SumWeeklyPenaltyForCloseParams{
+	penalized, err := s.queries(ctx).RecordWeeklyPenaltiesForClose(ctx, dbsqlc.RecordWeeklyPenaltiesForCloseParams{
 		TeamID:    teamID,
 		WeekStart: toPgDate(previousWeekStart),
 		CreatedAt: toPgTimestamptz(cutoff),
@@ -235,6 +357,12 @@ func (s *Store) closeWeekForTargetLocked(ctx context.Context, previousWeekStart
 	if err != nil {
 		return false, err
 	}
+	period := ClosePeriodReport{Scope: "week", Target: previousWeekStart.Format("2006-01-02"), Month: month}
+	for _, row := range penalized {
+		period.addPenalty(row.TaskID, row.Title, row.PenaltyPoints)
+	}
+	recordClosePeriod(ctx, period)
+	totalPenalty := int64(period.PenaltyTotal)
 
 	if totalPenalty <= 0 {
 		return true, nil
@@ -291,11 +419,14 @@ func (s *Store) closeMonthForTargetLocked(ctx context.Context, monthStart time.T
 	sort.Slice(rules, func(i, j int) bool { return rules[i].Threshold < rules[j].Threshold })
 	total := int(summary.DailyPenaltyTotal + summary.WeeklyPenaltyTotal)
 	triggered := []string{}
+	period := ClosePeriodReport{Scope: "month", Target: month, Month: month, PenaltyTotal: total}
 	for _, r := range rules {
 		if total >= r.Threshold {
 			triggered = append(triggered, r.ID)
+			period.TriggeredRules = append(period.TriggeredRules, CloseRuleReport{RuleID: r.ID, Name: r.Name, Threshold: r.Threshold})
 		}
 	}
+	recordClosePeriod(ctx, period)
 	if err := s.queries(ctx).CloseMonthlyPenaltySummary(ctx, dbsqlc.CloseMonthlyPenaltySummaryParams{
 		TeamID:     teamID,
 		MonthStart: toPgDate(monthStart),
@@ -317,6 +448,15 @@ func (s *Store) closeMonthForTargetLocked(ctx context.Context, monthStart time.T
 			return false, "", err
 		}
 	}
+	if err := s.enqueueWebhookEvent(ctx, s.queries(ctx), teamID, webhookEventMonthClosed, s.now(), monthClosedWebhookData{
+		Month:                   month,
+		DailyPenaltyTotal:       int(summary.DailyPenaltyTotal),
+		WeeklyPenaltyTotal:      int(summary.WeeklyPenaltyTotal),
+		TotalPenalty:            total,
+		TriggeredPenaltyRuleIDs: triggered,
+	}); err != nil {
+		return false, "", err
+	}
 	return true, month, nil
 }
 
@@ -331,7 +471,12 @@ func (s *Store) catchUpDayLocked(ctx context.Context, now time.Time, teamID stri
 	}
 	processed := 0
 	for target := start; !target.After(end); target = target.AddDate(0, 0, 1) {
-		didRun, err := s.closeDayForTargetLocked(ctx, target, teamID)
+		didRun := false
+		err := s.runClosePeriodTx(ctx, teamID, "day", func(txCtx context.Context) (bool, error) {
+			var err error
+			didRun, err = s.closeDayForTargetLocked(txCtx, target, teamID)
+			return didRun, err
+		})
 		if err != nil {
 			return processed, err
 		}
@@ -354,7 +499,12 @@ func (s *Store) catchUpWeekLocked(ctx context.Context, now time.Time, teamID str
 	}
 	processed := 0
 	for target := start; !target.After(end); target = target.AddDate(0, 0, 7) {
-		didRun, err := s.closeWeekForTargetLocked(ctx, target, teamID)
+		didRun := false
+		err := s.runClosePeriodTx(ctx, teamID, "week", func(txCtx context.Context) (bool, error) {
+			var err error
+			didRun, err = s.closeWeekForTargetLocked(txCtx, target, teamID)
+			return didRun, err
+		})
 		if err != nil {
 			return processed, err
 		}
@@ -378,7 +528,12 @@ func (s *Store) catchUpMonthLocked(ctx context.Context, now time.Time, teamID st
 	}
 	processed := 0
 	for target := start; !target.After(end); target = target.AddDate(0, 1, 0) {
-		didRun, month, err := s.closeMonthForTargetLocked(ctx, target, teamID)
+		didRun, month := false, ""
+		err := s.runClosePeriodTx(ctx, teamID, "month", func(txCtx context.Context) (bool, error) {
+			var err error
+			didRun, month, err = s.closeMonthForTargetLocked(txCtx, target, teamID)
+			return didRun, err
+		})
 		if err != nil {
 			return processed, "", err
 		}
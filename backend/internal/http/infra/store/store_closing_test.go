@@ -3,9 +3,12 @@ package store
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 	dbsqlc "github.com/megu/kaji-challenge/backend/internal/db/sqlc"
 	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
@@ -59,6 +62,212 @@ func TestCloseDayForTeamIsIdempotent(t *testing.T) {
 	}
 }
 
+func TestRunCloseForTeamReportsCommittedPeriods(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	base := time.Date(2026, 1, 29, 12, 0, 0, 0, s.loc)
+
+	teamID, _ := createTeamWithMember(t, s, "run-report@example.com", base)
+	createTaskAt(t, s, teamID, api.Daily, 3, 1, base)
+	s.SetClock(FixedClock(time.Date(2026, 1, 31, 12, 0, 0, 0, s.loc)))
+
+	report, err := s.RunCloseForTeam(ctx, teamID, "day")
+	if err != nil {
+		t.Fatalf("RunCloseForTeam failed: %v", err)
+	}
+	if len(report.Periods) != 2 || report.PenaltyAdded() != 6 || report.Month != "2026-01" {
+		t.Fatalf("unexpected report: %+v", report)
+	}
+	if jan := getMonthSummary(t, s, teamID, "2026-01"); jan.DailyPenaltyTotal != 6 {
+		t.Fatalf("expected the report to match the summary, got total=%d", jan.DailyPenaltyTotal)
+	}
+
+	again, err := s.RunCloseForTeam(ctx, teamID, "day")
+	if err != nil {
+		t.Fatalf("second RunCloseForTeam failed: %v", err)
+	}
+	if len(again.Periods) != 0 {
+		t.Fatalf("expected nothing left to close, got %+v", again.Periods)
+	}
+}
+
+func TestCloseErrorClass(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{err: nil, want: ""},
+		{err: fmt.Errorf("close: %w", context.DeadlineExceeded), want: "timeout"},
+		{err: context.Canceled, want: "canceled"},
+		{err: fmt.Errorf("%w: scope=day month=2026-01", errMonthAlreadyClosed), want: "month_closed"},
+		{err: &pgconn.PgError{Code: "40P01"}, want: "database"},
+		{err: errors.New("boom"), want: "internal"},
+	}
+	for _, tt := range tests {
+		if got := CloseErrorClass(tt.err); got != tt.want {
+			t.Fatalf("CloseErrorClass(%v) = %q, want %q", tt.err, got, tt.want)
+		}
+	}
+}
+
+func TestCloseRunsAreRecordedWithTrigger(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	base := time.Date(2026, 1, 29, 12, 0, 0, 0, s.loc)
+
+	teamID, userID := createTeamWithMember(t, s, "history@example.com", base)
+	createTaskAt(t, s, teamID, api.Daily, 1, 1, base)
+	s.SetClock(FixedClock(time.Date(2026, 1, 31, 12, 0, 0, 0, s.loc)))
+
+	if _, err := s.CloseDayForTeam(WithCloseTrigger(ctx, CloseTriggerScheduler), teamID); err != nil {
+		t.Fatalf("CloseDayForTeam failed: %v", err)
+	}
+	if _, err := s.CloseWeekForTeam(ctx, teamID); err != nil {
+		t.Fatalf("CloseWeekForTeam failed: %v", err)
+	}
+
+	res, err := s.ListCloseRunsForUser(ctx, userID, nil)
+	if err != nil {
+		t.Fatalf("ListCloseRunsForUser failed: %v", err)
+	}
+	if len(res.Items) != 2 {
+		t.Fatalf("expected 2 recorded runs, got %d", len(res.Items))
+	}
+	week, day := res.Items[0], res.Items[1]
+	if week.Scope != "week" || week.Trigger != CloseTriggerOps {
+		t.Fatalf("expected the latest run to be the ops week close, got %+v", week)
+	}
+	if day.Scope != "day" || day.Trigger != CloseTriggerScheduler || day.PeriodsProcessed != 2 || day.PenaltyAdded != 2 || day.PenalizedTasks != 2 {
+		t.Fatalf("unexpected day run: %+v", day)
+	}
+	if day.ErrorClass != nil || !day.AsOf.Equal(time.Date(2026, 1, 31, 12, 0, 0, 0, s.loc)) {
+		t.Fatalf("expected a successful run as of the store clock, got %+v", day)
+	}
+
+	limit := 0
+	if _, err := s.ListCloseRunsForUser(ctx, userID, &limit); err == nil {
+		t.Fatalf("expected invalid limit error")
+	}
+}
+
+func TestCloseRunRecordsFailure(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	base := time.Date(2026, 1, 29, 12, 0, 0, 0, s.loc)
+
+	teamID, userID := createTeamWithMember(t, s, "history-failure@example.com", base)
+	s.SetClock(FixedClock(time.Date(2026, 1, 31, 12, 0, 0, 0, s.loc)))
+
+	timeoutCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := s.CloseDayForTeam(timeoutCtx, teamID); err == nil {
+		t.Fatalf("expected close with a cancelled context to fail")
+	}
+
+	res, err := s.ListCloseRunsForUser(ctx, userID, nil)
+	if err != nil {
+		t.Fatalf("ListCloseRunsForUser failed: %v", err)
+	}
+	if len(res.Items) != 1 || res.Items[0].ErrorClass == nil || *res.Items[0].ErrorClass != "canceled" {
+		t.Fatalf("expected the cancelled run to be recorded, got %+v", res.Items)
+	}
+}
+
+func TestCloseStatusFlagsLaggingScopes(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	base := time.Date(2026, 1, 20, 12, 0, 0, 0, s.loc)
+
+	teamID, _ := createTeamWithMember(t, s, "status@example.com", base)
+	s.closeSchedulerCfg.MonthDay = 6
+	s.SetClock(FixedClock(time.Date(2026, 2, 10, 12, 0, 0, 0, s.loc)))
+	if _, err := s.CloseDayForTeam(ctx, teamID); err != nil {
+		t.Fatalf("CloseDayForTeam failed: %v", err)
+	}
+
+	status, err := s.GetCloseStatusForTeam(ctx, teamID)
+	if err != nil {
+		t.Fatalf("GetCloseStatusForTeam failed: %v", err)
+	}
+	byScope := map[string]api.CloseScopeStatus{}
+	for _, item := range status {
+		byScope[item.Scope] = item
+	}
+	day := byScope["day"]
+	if day.Lagging || day.LatestTargetDate == nil || day.LatestTargetDate.String() != "2026-02-09" || day.LastRun == nil {
+		t.Fatalf("expected day to be up to date, got %+v", day)
+	}
+	if week := byScope["week"]; !week.Lagging || week.LatestTargetDate != nil {
+		t.Fatalf("expected never-closed week to lag, got %+v", week)
+	}
+	if month := byScope["month"]; !month.Lagging || month.ExpectedTargetDate.String() != "2026-01-01" {
+		t.Fatalf("expected January to be due after the 6th, got %+v", month)
+	}
+}
+
+func TestConcurrentCloseForTeamDoesNotDoubleCount(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	base := time.Date(2026, 1, 20, 12, 0, 0, 0, s.loc)
+
+	teamID, _ := createTeamWithMember(t, s, "concurrent@example.com", base)
+	createTaskAt(t, s, teamID, api.Daily, 1, 1, base)
+	s.SetClock(FixedClock(time.Date(2026, 2, 1, 0, 0, 0, 0, s.loc)))
+
+	const runs = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, runs)
+	for i := 0; i < runs; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := s.CloseDayForTeam(ctx, teamID); err != nil {
+				errs <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("concurrent CloseDayForTeam failed: %v", err)
+	}
+
+	// January 20-31 are closed exactly once, whichever run got there first.
+	if jan := getMonthSummary(t, s, teamID, "2026-01"); jan.DailyPenaltyTotal != 12 {
+		t.Fatalf("expected daily penalty total=12, got %d", jan.DailyPenaltyTotal)
+	}
+}
+
+func TestCloseDayForTeamWaitsForTeamLock(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	base := time.Date(2026, 1, 29, 12, 0, 0, 0, s.loc)
+
+	teamID, _ := createTeamWithMember(t, s, "locked@example.com", base)
+	createTaskAt(t, s, teamID, api.Daily, 1, 1, base)
+	s.SetClock(FixedClock(time.Date(2026, 1, 31, 12, 0, 0, 0, s.loc)))
+
+	_, release, err := s.lockTeamClose(ctx, teamID)
+	if err != nil {
+		t.Fatalf("lockTeamClose failed: %v", err)
+	}
+	timeoutCtx, cancel := context.WithTimeout(ctx, 200*time.Millisecond)
+	defer cancel()
+	if _, err := s.CloseDayForTeam(timeoutCtx, teamID); err == nil {
+		t.Fatalf("expected close to wait for the held lock until its deadline")
+	}
+	release()
+
+	if _, err := s.CloseDayForTeam(ctx, teamID); err != nil {
+		t.Fatalf("CloseDayForTeam failed after release: %v", err)
+	}
+	if jan := getMonthSummary(t, s, teamID, "2026-01"); jan.DailyPenaltyTotal != 2 {
+		t.Fatalf("expected daily penalty total=2, got %d", jan.DailyPenaltyTotal)
+	}
+}
+
 func TestCloseWeekAndMonthForTeam(t *testing.T) {
 	s := newTestStore(t)
 	ctx := context.Background()
@@ -125,6 +334,82 @@ func TestCatchUpDayLockedProcessesMissingDays(t *testing.T) {
 	}
 }
 
+func TestClosePipelineRunsAsOfStoreClock(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	base := time.Date(2026, 1, 29, 12, 0, 0, 0, s.loc)
+
+	teamID, _ := createTeamWithMember(t, s, "clock@example.com", base)
+	createTaskAt(t, s, teamID, api.Daily, 1, 1, base)
+
+	// Just before the month boundary only January 29-30 are closable.
+	s.SetClock(FixedClock(time.Date(2026, 1, 31, 23, 59, 0, 0, s.loc)))
+	if _, err := s.CloseDayForTeam(ctx, teamID); err != nil {
+		t.Fatalf("CloseDayForTeam failed: %v", err)
+	}
+	res, err := s.CloseMonthForTeam(ctx, teamID)
+	if err != nil {
+		t.Fatalf("CloseMonthForTeam failed: %v", err)
+	}
+	if jan := getMonthSummary(t, s, teamID, "2026-01"); jan.DailyPenaltyTotal != 2 || jan.IsClosed {
+		t.Fatalf("expected open January with 2 closed days, got total=%d closed=%v", jan.DailyPenaltyTotal, jan.IsClosed)
+	}
+	if res.Month != "2025-12" {
+		t.Fatalf("expected month close to stop before January, got %s", res.Month)
+	}
+
+	// One minute later January 31 and the month itself become closable.
+	s.SetClock(FixedClock(time.Date(2026, 2, 1, 0, 0, 0, 0, s.loc)))
+	if _, err := s.CloseDayForTeam(ctx, teamID); err != nil {
+		t.Fatalf("CloseDayForTeam failed: %v", err)
+	}
+	res, err = s.CloseMonthForTeam(ctx, teamID)
+	if err != nil {
+		t.Fatalf("CloseMonthForTeam failed: %v", err)
+	}
+	jan := getMonthSummary(t, s, teamID, "2026-01")
+	if jan.DailyPenaltyTotal != 3 || !jan.IsClosed || res.Month != "2026-01" {
+		t.Fatalf("expected closed January with total=3, got total=%d closed=%v month=%s", jan.DailyPenaltyTotal, jan.IsClosed, res.Month)
+	}
+	if !res.ClosedAt.Equal(time.Date(2026, 2, 1, 0, 0, 0, 0, s.loc)) {
+		t.Fatalf("expected closedAt from the store clock, got %s", res.ClosedAt)
+	}
+}
+
+func TestPreviewCloseForTeamReportsWithoutWriting(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	base := time.Date(2026, 1, 29, 12, 0, 0, 0, s.loc)
+
+	teamID, _ := createTeamWithMember(t, s, "preview@example.com", base)
+	createTaskAt(t, s, teamID, api.Daily, 2, 1, base)
+	s.SetClock(FixedClock(time.Date(2026, 1, 31, 12, 0, 0, 0, s.loc)))
+
+	report, err := s.PreviewCloseForTeam(ctx, teamID, "day")
+	if err != nil {
+		t.Fatalf("PreviewCloseForTeam failed: %v", err)
+	}
+	if len(report.Periods) != 2 {
+		t.Fatalf("expected January 29-30 to be reported, got %+v", report.Periods)
+	}
+	for _, p := range report.Periods {
+		if p.Scope != "day" || p.Month != "2026-01" || p.PenaltyTotal != 2 || len(p.Penalties) != 1 {
+			t.Fatalf("unexpected period: %+v", p)
+		}
+	}
+	if jan := getMonthSummary(t, s, teamID, "2026-01"); jan.DailyPenaltyTotal != 0 {
+		t.Fatalf("expected preview to leave the summary untouched, got total=%d", jan.DailyPenaltyTotal)
+	}
+
+	// The real close still finds the same periods pending.
+	if _, err := s.CloseDayForTeam(ctx, teamID); err != nil {
+		t.Fatalf("CloseDayForTeam failed: %v", err)
+	}
+	if jan := getMonthSummary(t, s, teamID, "2026-01"); jan.DailyPenaltyTotal != 4 {
+		t.Fatalf("expected close after preview to apply 4 points, got %d", jan.DailyPenaltyTotal)
+	}
+}
+
 func withLatestIfMatchForUser(t *testing.T, s *Store, ctx context.Context, userID string) context.Context {
 	t.Helper()
 	teamID, err := s.primaryTeamLocked(ctx, userID)
@@ -135,7 +420,7 @@ func withLatestIfMatchForUser(t *testing.T, s *Store, ctx context.Context, userI
 	if err != nil {
 		t.Fatalf("failed to load state revision: %v", err)
 	}
-	return NewIfMatchContext(ctx, etagFromRevision(teamID, revision))
+	return NewIfMatchContext(NewActorContext(ctx, userID), etagFromRevision(teamID, revision))
 }
 
 func TestCatchUpDayLockedUsesTargetTimeTaskSnapshot(t *testing.T) {
@@ -407,6 +692,10 @@ func newTestStore(t *testing.T) *Store {
 	t.Setenv("OIDC_REDIRECT_URL", "")
 	t.Setenv("SIGNUP_GUARD_ENABLED", "false")
 	t.Setenv("SIGNUP_ALLOWED_EMAILS", "")
+	t.Setenv("BLOB_STORE_DRIVER", "local")
+	t.Setenv("BLOB_LOCAL_DIR", t.TempDir())
+	// Webhook tests deliver to receivers on localhost.
+	t.Setenv("WEBHOOK_ALLOW_PRIVATE_NETWORKS", "true")
 	s := NewStore()
 	t.Cleanup(func() {
 		if s.db != nil {
@@ -0,0 +1,311 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/megu/kaji-challenge/backend/internal/blobstore"
+	dbsqlc "github.com/megu/kaji-challenge/backend/internal/db/sqlc"
+	"github.com/megu/kaji-challenge/backend/internal/http/application/ports"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+const completionNoteMaxLength = 500
+
+// errCompletionNotFound is returned when the addressed completion has not
+// been recorded; callers wrap it with what they were trying to do.
+var errCompletionNotFound = errors.New("completion not found")
+
+// completionEvidenceState is the note and photo of one recorded completion.
+// EntryID is set for weekly completions only.
+type completionEvidenceState struct {
+	EntryID      string
+	Slot         int
+	Note         pgtype.Text
+	AttachmentID string
+}
+
+// SaveTaskCompletionEvidence attaches a note and photo to the completion
+// recorded for the period of upload.TargetDate. The photo is written to the
+// blob store before the transaction and removed again if it fails; a photo
+// replaced or detached here is left to the orphaned attachment GC.
+func (s *Store) SaveTaskCompletionEvidence(ctx context.Context, userID, taskID string, upload ports.CompletionEvidenceUpload) (api.TaskCompletionEvidenceResponse, error) {
+	if upload.Note != nil && utf8.RuneCountInString(*upload.Note) > completionNoteMaxLength {
+		return api.TaskCompletionEvidenceResponse{}, fmt.Errorf("invalid note: must be at most %d characters", completionNoteMaxLength)
+	}
+	slot := 0
+	if upload.Slot != nil {
+		slot = *upload.Slot
+		if slot < 1 || slot > requiredCompletionsPerWeekMax {
+			return api.TaskCompletionEvidenceResponse{}, fmt.Errorf("invalid slot: must be between 1 and %d", requiredCompletionsPerWeekMax)
+		}
+	}
+	teamID, err := s.primaryTeamLocked(ctx, userID)
+	if err != nil {
+		return api.TaskCompletionEvidenceResponse{}, err
+	}
+
+	var photo *evidenceImage
+	attachmentID := ""
+	if upload.Image != nil {
+		photo, err = prepareEvidenceImage(upload.Image)
+		if err != nil {
+			return api.TaskCompletionEvidenceResponse{}, err
+		}
+		attachmentID = s.nextID("attachment")
+		photo.BlobKey, photo.ThumbnailKey = completionAttachmentKeys(teamID, attachmentID, photo.ContentType)
+		if err := s.putEvidenceBlobs(ctx, photo); err != nil {
+			return api.TaskCompletionEvidenceResponse{}, err
+		}
+	}
+
+	res := api.TaskCompletionEvidenceResponse{}
+	_, err = s.runWithTeamRevisionCAS(
+		ctx,
+		teamID,
+		"task_completion",
+		map[string]string{"taskId": taskID, "action": "evidence"},
+		func(txCtx context.Context, qtx *dbsqlc.Queries) error {
+			task, err := s.lockTaskStateLocked(txCtx, qtx, teamID, taskID)
+			if err != nil {
+				return err
+			}
+			targetDate, err := s.completionTargetDate(task, upload.TargetDate)
+			if err != nil {
+				return err
+			}
+			state, err := s.getCompletionEvidenceLocked(txCtx, qtx, task, targetDate, slot)
+			if errors.Is(err, errCompletionNotFound) {
+				return fmt.Errorf("%w: record the completion before adding evidence", err)
+			}
+			if err != nil {
+				return err
+			}
+			if upload.Note != nil {
+				state.Note = pgtype.Text{String: *upload.Note, Valid: *upload.Note != ""}
+			}
+			if photo != nil {
+				if err := qtx.CreateCompletionAttachment(txCtx, dbsqlc.CreateCompletionAttachmentParams{
+					ID:               attachmentID,
+					TeamID:           teamID,
+					TaskID:           task.ID,
+					BlobKey:          photo.BlobKey,
+					ThumbnailKey:     photo.ThumbnailKey,
+					ContentType:      photo.ContentType,
+					SizeBytes:        int32(len(photo.Original)),
+					Width:            int32(photo.Width),
+					Height:           int32(photo.Height),
+					UploadedByUserID: userID,
+					CreatedAt:        toPgTimestamptz(s.now()),
+				}); err != nil {
+					return err
+				}
+				state.AttachmentID = attachmentID
+			} else if upload.RemoveImage {
+				state.AttachmentID = ""
+			}
+			if err := setCompletionEvidenceLocked(txCtx, qtx, task, targetDate, state); err != nil {
+				return err
+			}
+			evidence, err := completionEvidenceFromState(txCtx, qtx, state)
+			if err != nil {
+				return err
+			}
+			res = api.TaskCompletionEvidenceResponse{
+				TaskId:     task.ID,
+				TargetDate: toDate(targetDate),
+				Slot:       state.Slot,
+				Evidence:   evidence,
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		if photo != nil {
+			s.deleteEvidenceBlobs(context.WithoutCancel(ctx), photo.BlobKey, photo.ThumbnailKey)
+		}
+		return api.TaskCompletionEvidenceResponse{}, err
+	}
+	return res, nil
+}
+
+// GetCompletionAttachment opens a photo of the user's team. The caller must
+// close the returned body.
+func (s *Store) GetCompletionAttachment(ctx context.Context, userID, attachmentID string, thumbnail bool) (ports.CompletionAttachmentContent, error) {
+	teamID, err := s.primaryTeamLocked(ctx, userID)
+	if err != nil {
+		return ports.CompletionAttachmentContent{}, err
+	}
+	row, err := s.q.GetCompletionAttachmentByID(ctx, attachmentID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ports.CompletionAttachmentContent{}, errors.New("attachment not found")
+		}
+		return ports.CompletionAttachmentContent{}, err
+	}
+	if ownerTeam := ptrFromAny(row.TeamID); ownerTeam == nil || *ownerTeam != teamID {
+		return ports.CompletionAttachmentContent{}, errors.New("attachment not found")
+	}
+	key, contentType := row.BlobKey, row.ContentType
+	if thumbnail {
+		key, contentType = row.ThumbnailKey, "image/jpeg"
+	}
+	body, err := s.blobs.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, blobstore.ErrNotFound) {
+			return ports.CompletionAttachmentContent{}, errors.New("attachment not found")
+		}
+		return ports.CompletionAttachmentContent{}, err
+	}
+	return ports.CompletionAttachmentContent{ContentType: contentType, Body: body}, nil
+}
+
+// getCompletionEvidenceLocked loads the completion the evidence belongs to:
+// the day's completion for daily tasks, the given slot (or the latest) of the
+// week for weekly tasks, and the single completion of a one_off task.
+func (s *Store) getCompletionEvidenceLocked(ctx context.Context, qtx *dbsqlc.Queries, task taskRecord, targetDate time.Time, slot int) (completionEvidenceState, error) {
+	var state completionEvidenceState
+	var err error
+	switch task.Type {
+	case api.Weekly:
+		var row dbsqlc.GetTaskCompletionWeeklyEntryEvidenceRow
+		row, err = qtx.GetTaskCompletionWeeklyEntryEvidence(ctx, dbsqlc.GetTaskCompletionWeeklyEntryEvidenceParams{
+			TaskID:    task.ID,
+			WeekStart: toPgDate(startOfWeek(targetDate, s.loc)),
+			Slot:      int32(slot),
+		})
+		state = completionEvidenceState{EntryID: row.ID, Slot: int(row.Slot), Note: row.Note, AttachmentID: uuidStringFromPtr(ptrFromAny(row.AttachmentID))}
+	case api.OneOff:
+		var row dbsqlc.GetTaskCompletionOneOffEvidenceRow
+		row, err = qtx.GetTaskCompletionOneOffEvidence(ctx, task.ID)
+		state = completionEvidenceState{Slot: 1, Note: row.Note, AttachmentID: uuidStringFromPtr(ptrFromAny(row.AttachmentID))}
+	default:
+		var row dbsqlc.GetTaskCompletionDailyEvidenceRow
+		row, err = qtx.GetTaskCompletionDailyEvidence(ctx, dbsqlc.GetTaskCompletionDailyEvidenceParams{
+			TaskID:     task.ID,
+			TargetDate: toPgDate(targetDate),
+		})
+		state = completionEvidenceState{Slot: 1, Note: row.Note, AttachmentID: uuidStringFromPtr(ptrFromAny(row.AttachmentID))}
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		return completionEvidenceState{}, errCompletionNotFound
+	}
+	return state, err
+}
+
+func setCompletionEvidenceLocked(ctx context.Context, qtx *dbsqlc.Queries, task taskRecord, targetDate time.Time, state completionEvidenceState) error {
+	switch task.Type {
+	case api.Weekly:
+		return qtx.SetTaskCompletionWeeklyEntryEvidence(ctx, dbsqlc.SetTaskCompletionWeeklyEntryEvidenceParams{
+			ID:           state.EntryID,
+			Note:         state.Note,
+			AttachmentID: state.AttachmentID,
+		})
+	case api.OneOff:
+		return qtx.SetTaskCompletionOneOffEvidence(ctx, dbsqlc.SetTaskCompletionOneOffEvidenceParams{
+			TaskID:       task.ID,
+			Note:         state.Note,
+			AttachmentID: state.AttachmentID,
+		})
+	default:
+		return qtx.SetTaskCompletionDailyEvidence(ctx, dbsqlc.SetTaskCompletionDailyEvidenceParams{
+			TaskID:       task.ID,
+			TargetDate:   toPgDate(targetDate),
+			Note:         state.Note,
+			AttachmentID: state.AttachmentID,
+		})
+	}
+}
+
+func completionEvidenceFromState(ctx context.Context, qtx *dbsqlc.Queries, state completionEvidenceState) (api.CompletionEvidence, error) {
+	evidence := api.CompletionEvidence{}
+	if state.Note.Valid {
+		evidence.Note = &state.Note.String
+	}
+	if state.AttachmentID == "" {
+		return evidence, nil
+	}
+	row, err := qtx.GetCompletionAttachmentByID(ctx, state.AttachmentID)
+	if err != nil {
+		return api.CompletionEvidence{}, err
+	}
+	evidence.Attachment = completionAttachmentAPI(row.ID, row.ContentType, row.SizeBytes, row.Width, row.Height, row.CreatedAt)
+	return evidence, nil
+}
+
+func completionAttachmentAPI(id, contentType string, sizeBytes, width, height int32, createdAt pgtype.Timestamptz) *api.CompletionAttachment {
+	return &api.CompletionAttachment{
+		Id:          id,
+		ContentType: api.CompletionAttachmentContentType(contentType),
+		SizeBytes:   int(sizeBytes),
+		Width:       int(width),
+		Height:      int(height),
+		CreatedAt:   createdAt.Time,
+	}
+}
+
+// completionAttachmentKeys places both variants of a photo under the team,
+// so a team's blobs can be listed or removed together.
+func completionAttachmentKeys(teamID, attachmentID, contentType string) (string, string) {
+	ext := "jpg"
+	if contentType == "image/png" {
+		ext = "png"
+	}
+	prefix := "teams/" + teamID + "/completions/" + attachmentID
+	return prefix + "/original." + ext, prefix + "/thumbnail.jpg"
+}
+
+func (s *Store) putEvidenceBlobs(ctx context.Context, photo *evidenceImage) error {
+	if err := s.blobs.Put(ctx, photo.BlobKey, photo.ContentType, photo.Original); err != nil {
+		return fmt.Errorf("store completion photo: %w", err)
+	}
+	if err := s.blobs.Put(ctx, photo.ThumbnailKey, "image/jpeg", photo.Thumbnail); err != nil {
+		s.deleteEvidenceBlobs(context.WithoutCancel(ctx), photo.BlobKey)
+		return fmt.Errorf("store completion thumbnail: %w", err)
+	}
+	return nil
+}
+
+func (s *Store) deleteEvidenceBlobs(ctx context.Context, keys ...string) {
+	for _, key := range keys {
+		if err := s.blobs.Delete(ctx, key); err != nil {
+			log.Printf("completion blob cleanup failed key=%s: %v", key, err)
+		}
+	}
+}
+
+// listCompletionEvidenceByTask returns the evidence of today's daily
+// completions, this week's weekly completions and one_off completions, keyed
+// by task ID and completion slot.
+func (s *Store) listCompletionEvidenceByTask(ctx context.Context, teamID string, today, weekStart time.Time) (map[string]map[int]*api.CompletionEvidence, error) {
+	rows, err := s.q.ListCompletionEvidenceByTeam(ctx, dbsqlc.ListCompletionEvidenceByTeamParams{
+		TeamID:     teamID,
+		TargetDate: toPgDate(today),
+		WeekStart:  toPgDate(weekStart),
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := map[string]map[int]*api.CompletionEvidence{}
+	for _, row := range rows {
+		evidence := &api.CompletionEvidence{}
+		if row.Note.Valid {
+			note := row.Note.String
+			evidence.Note = &note
+		}
+		if id := ptrFromAny(row.AttachmentID); id != nil {
+			evidence.Attachment = completionAttachmentAPI(*id, row.ContentType, row.SizeBytes, row.Width, row.Height, row.AttachmentCreatedAt)
+		}
+		if out[row.TaskID] == nil {
+			out[row.TaskID] = map[int]*api.CompletionEvidence{}
+		}
+		out[row.TaskID][int(row.Slot)] = evidence
+	}
+	return out, nil
+}
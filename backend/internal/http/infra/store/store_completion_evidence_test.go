@@ -0,0 +1,101 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"image"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/megu/kaji-challenge/backend/internal/blobstore"
+	"github.com/megu/kaji-challenge/backend/internal/http/application/ports"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func TestCompletionEvidenceLifecycle(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	today := time.Date(2026, 4, 6, 0, 0, 0, 0, s.loc)
+	s.SetClock(FixedClock(today.Add(9 * time.Hour)))
+
+	_, userID := createTeamWithMember(t, s, "evidence@example.com", today.AddDate(0, 0, -1))
+	task, err := s.CreateTask(withLatestIfMatchForUser(t, s, ctx, userID), userID, api.CreateTaskRequest{
+		Title: "Clean the sink", Type: api.Daily, PenaltyPoints: 1,
+	})
+	if err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+	note := "Scrubbed and dried"
+	photo := encodePNG(t, image.NewGray(image.Rect(0, 0, 800, 600)))
+	upload := ports.CompletionEvidenceUpload{TargetDate: today, Note: &note, Image: photo}
+	if _, err := s.SaveTaskCompletionEvidence(withLatestIfMatchForUser(t, s, ctx, userID), userID, task.Id, upload); err == nil ||
+		err.Error() != "completion not found: record the completion before adding evidence" {
+		t.Fatalf("expected evidence to require a completion, got %v", err)
+	}
+
+	if _, err := s.ToggleTaskCompletion(withLatestIfMatchForUser(t, s, ctx, userID), userID, task.Id, today, nil); err != nil {
+		t.Fatalf("ToggleTaskCompletion failed: %v", err)
+	}
+	res, err := s.SaveTaskCompletionEvidence(withLatestIfMatchForUser(t, s, ctx, userID), userID, task.Id, upload)
+	if err != nil {
+		t.Fatalf("SaveTaskCompletionEvidence failed: %v", err)
+	}
+	if res.Slot != 1 || res.Evidence.Note == nil || *res.Evidence.Note != note || res.Evidence.Attachment == nil {
+		t.Fatalf("expected the note and photo on the completion, got %+v", res)
+	}
+	attachment := *res.Evidence.Attachment
+	if attachment.ContentType != api.CompletionAttachmentContentType("image/png") || attachment.Width != 800 || attachment.Height != 600 {
+		t.Fatalf("unexpected attachment metadata: %+v", attachment)
+	}
+
+	overview, err := s.GetTaskOverview(ctx, userID, nil)
+	if err != nil {
+		t.Fatalf("GetTaskOverview failed: %v", err)
+	}
+	if len(overview.DailyTasks) != 1 || overview.DailyTasks[0].Evidence == nil || overview.DailyTasks[0].Evidence.Attachment.Id != attachment.Id {
+		t.Fatalf("expected the evidence in the overview, got %+v", overview.DailyTasks)
+	}
+
+	thumb, err := s.GetCompletionAttachment(ctx, userID, attachment.Id, true)
+	if err != nil {
+		t.Fatalf("GetCompletionAttachment failed: %v", err)
+	}
+	data, _ := io.ReadAll(thumb.Body)
+	thumb.Body.Close()
+	if thumb.ContentType != "image/jpeg" || len(data) == 0 {
+		t.Fatalf("expected a JPEG thumbnail, got %s with %d bytes", thumb.ContentType, len(data))
+	}
+	_, otherUserID := createTeamWithMember(t, s, "other@example.com", today.AddDate(0, 0, -1))
+	if _, err := s.GetCompletionAttachment(ctx, otherUserID, attachment.Id, false); err == nil || err.Error() != "attachment not found" {
+		t.Fatalf("expected another team's photo to be hidden, got %v", err)
+	}
+
+	empty := ""
+	res, err = s.SaveTaskCompletionEvidence(withLatestIfMatchForUser(t, s, ctx, userID), userID, task.Id, ports.CompletionEvidenceUpload{
+		TargetDate: today, Note: &empty, RemoveImage: true,
+	})
+	if err != nil || res.Evidence.Note != nil || res.Evidence.Attachment != nil {
+		t.Fatalf("expected the note and photo to be cleared, got %+v, %v", res, err)
+	}
+
+	s.SetClock(FixedClock(today.AddDate(0, 0, 2)))
+	results, err := s.RunGC(ctx, []GCPolicy{{Target: GCTargetCompletionAttachments, Retention: 24 * time.Hour}}, 100, false)
+	if err != nil || len(results) != 1 || results[0].Deleted != 1 {
+		t.Fatalf("expected the detached photo to be purged, got %+v, %v", results, err)
+	}
+	blobKey, _ := completionAttachmentKeys(task.TeamId, attachment.Id, "image/png")
+	if _, err := s.blobs.Get(ctx, blobKey); !errors.Is(err, blobstore.ErrNotFound) {
+		t.Fatalf("expected the purged photo blob to be deleted, got %v", err)
+	}
+}
+
+func TestSaveTaskCompletionEvidenceRejectsInvalidSlot(t *testing.T) {
+	s := &Store{}
+	for _, slot := range []int{0, requiredCompletionsPerWeekMax + 1} {
+		upload := ports.CompletionEvidenceUpload{TargetDate: time.Date(2026, 4, 6, 0, 0, 0, 0, time.UTC), Slot: &slot}
+		if _, err := s.SaveTaskCompletionEvidence(context.Background(), "user-1", "task-1", upload); err == nil || err.Error() != "invalid slot: must be between 1 and 7" {
+			t.Fatalf("slot %d: expected the slot to be rejected, got %v", slot, err)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package store
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+)
+
+const (
+	completionImageMaxBytes      = 5 << 20
+	completionImageMaxSide       = 8000
+	completionImageMaxPixels     = 40_000_000
+	completionThumbnailMaxSide   = 320
+	completionThumbnailQuality   = 80
+	completionThumbnailSampleMax = 4
+)
+
+// evidenceImage is a validated completion photo with its thumbnail. The blob
+// keys are filled in once the attachment ID is known.
+type evidenceImage struct {
+	ContentType  string
+	Width        int
+	Height       int
+	Original     []byte
+	Thumbnail    []byte
+	BlobKey      string
+	ThumbnailKey string
+}
+
+// prepareEvidenceImage checks size, type, dimensions and pixel count before
+// decoding, so a small file that claims huge dimensions is rejected without
+// allocating its pixels, and then renders the JPEG thumbnail.
+func prepareEvidenceImage(data []byte) (*evidenceImage, error) {
+	if len(data) == 0 {
+		return nil, errors.New("invalid image: file is empty")
+	}
+	if len(data) > completionImageMaxBytes {
+		return nil, fmt.Errorf("invalid image: must be at most %d MiB", completionImageMaxBytes>>20)
+	}
+	contentType := http.DetectContentType(data)
+	if contentType != "image/jpeg" && contentType != "image/png" {
+		return nil, fmt.Errorf("invalid image: only JPEG and PNG are supported, got %s", contentType)
+	}
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || "image/"+format != contentType {
+		return nil, errors.New("invalid image: file could not be decoded")
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width > completionImageMaxSide || cfg.Height > completionImageMaxSide {
+		return nil, fmt.Errorf("invalid image: each side must be at most %d pixels", completionImageMaxSide)
+	}
+	if cfg.Width*cfg.Height > completionImageMaxPixels {
+		return nil, fmt.Errorf("invalid image: must be at most %d megapixels", completionImageMaxPixels/1_000_000)
+	}
+	var img image.Image
+	if contentType == "image/png" {
+		img, err = png.Decode(bytes.NewReader(data))
+	} else {
+		img, err = jpeg.Decode(bytes.NewReader(data))
+	}
+	if err != nil {
+		return nil, errors.New("invalid image: file could not be decoded")
+	}
+	thumb, err := encodeThumbnail(img, completionThumbnailMaxSide)
+	if err != nil {
+		return nil, err
+	}
+	return &evidenceImage{
+		ContentType: contentType,
+		Width:       cfg.Width,
+		Height:      cfg.Height,
+		Original:    data,
+		Thumbnail:   thumb,
+	}, nil
+}
+
+// thumbnailSize fits width x height into a maxSide square, keeping the
+// aspect ratio and never upscaling.
+func thumbnailSize(width, height, maxSide int) (int, int) {
+	if width <= maxSide && height <= maxSide {
+		return width, height
+	}
+	if width >= height {
+		return maxSide, max(1, height*maxSide/width)
+	}
+	return max(1, width*maxSide/height), maxSide
+}
+
+// encodeThumbnail downscales img by averaging a grid of up to 4x4 samples per
+// target pixel, which is smooth enough for a preview and keeps the cost
+// independent of the source size. Transparent pixels are flattened onto
+// white since JPEG has no alpha.
+func encodeThumbnail(img image.Image, maxSide int) ([]byte, error) {
+	bounds := img.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+	dstW, dstH := thumbnailSize(srcW, srcH, maxSide)
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	for y := 0; y < dstH; y++ {
+		y0, y1 := y*srcH/dstH, max((y+1)*srcH/dstH, y*srcH/dstH+1)
+		stepY := max(1, (y1-y0)/completionThumbnailSampleMax)
+		for x := 0; x < dstW; x++ {
+			x0, x1 := x*srcW/dstW, max((x+1)*srcW/dstW, x*srcW/dstW+1)
+			stepX := max(1, (x1-x0)/completionThumbnailSampleMax)
+			var r, g, b, n uint64
+			for sy := y0; sy < y1; sy += stepY {
+				for sx := x0; sx < x1; sx += stepX {
+					pr, pg, pb, pa := img.At(bounds.Min.X+sx, bounds.Min.Y+sy).RGBA()
+					r += uint64(pr + 0xffff - pa)
+					g += uint64(pg + 0xffff - pa)
+					b += uint64(pb + 0xffff - pa)
+					n++
+				}
+			}
+			dst.SetRGBA(x, y, color.RGBA{
+				R: uint8(r / n >> 8),
+				G: uint8(g / n >> 8),
+				B: uint8(b / n >> 8),
+				A: 0xff,
+			})
+		}
+	}
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: completionThumbnailQuality}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
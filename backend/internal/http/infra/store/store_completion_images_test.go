@@ -0,0 +1,106 @@
+package store
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("png.Encode failed: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// pngHeaderWithSize returns a tiny PNG whose header claims width x height,
+// which is all DecodeConfig reads.
+func pngHeaderWithSize(t *testing.T, width, height int) []byte {
+	t.Helper()
+	data := encodePNG(t, image.NewGray(image.Rect(0, 0, 1, 1)))
+	// The IHDR chunk follows the 8-byte signature: length, type, data, CRC.
+	binary.BigEndian.PutUint32(data[16:20], uint32(width))
+	binary.BigEndian.PutUint32(data[20:24], uint32(height))
+	binary.BigEndian.PutUint32(data[29:33], crc32.ChecksumIEEE(data[12:29]))
+	return data
+}
+
+func TestThumbnailSize(t *testing.T) {
+	cases := []struct{ w, h, wantW, wantH int }{
+		{w: 100, h: 50, wantW: 100, wantH: 50},
+		{w: 640, h: 480, wantW: 320, wantH: 240},
+		{w: 480, h: 640, wantW: 240, wantH: 320},
+		{w: 8000, h: 1, wantW: 320, wantH: 1},
+	}
+	for _, tc := range cases {
+		if w, h := thumbnailSize(tc.w, tc.h, 320); w != tc.wantW || h != tc.wantH {
+			t.Fatalf("thumbnailSize(%d, %d) = %dx%d, want %dx%d", tc.w, tc.h, w, h, tc.wantW, tc.wantH)
+		}
+	}
+}
+
+func TestPrepareEvidenceImage(t *testing.T) {
+	photo := image.NewNRGBA(image.Rect(0, 0, 640, 480))
+	for y := 0; y < 480; y++ {
+		for x := 0; x < 640; x++ {
+			photo.Set(x, y, color.NRGBA{R: 200, G: 30, B: 30, A: 0xff})
+		}
+	}
+	img, err := prepareEvidenceImage(encodePNG(t, photo))
+	if err != nil {
+		t.Fatalf("prepareEvidenceImage failed: %v", err)
+	}
+	if img.ContentType != "image/png" || img.Width != 640 || img.Height != 480 {
+		t.Fatalf("unexpected image metadata: %s %dx%d", img.ContentType, img.Width, img.Height)
+	}
+	thumb, err := jpeg.Decode(bytes.NewReader(img.Thumbnail))
+	if err != nil {
+		t.Fatalf("thumbnail is not a JPEG: %v", err)
+	}
+	if b := thumb.Bounds(); b.Dx() != 320 || b.Dy() != 240 {
+		t.Fatalf("expected a 320x240 thumbnail, got %v", b)
+	}
+	if r, g, _, _ := thumb.At(160, 120).RGBA(); r>>8 < 180 || g>>8 > 60 {
+		t.Fatalf("expected the thumbnail to keep the colour, got r=%d g=%d", r>>8, g>>8)
+	}
+
+	clear := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	img, err = prepareEvidenceImage(encodePNG(t, clear))
+	if err != nil {
+		t.Fatalf("prepareEvidenceImage failed: %v", err)
+	}
+	thumb, _ = jpeg.Decode(bytes.NewReader(img.Thumbnail))
+	if r, g, b, _ := thumb.At(1, 1).RGBA(); r>>8 < 250 || g>>8 < 250 || b>>8 < 250 {
+		t.Fatalf("expected transparent pixels to become white, got %d %d %d", r>>8, g>>8, b>>8)
+	}
+}
+
+func TestPrepareEvidenceImageRejectsInvalidFiles(t *testing.T) {
+	cases := []struct {
+		name string
+		data []byte
+		want string
+	}{
+		{name: "empty", data: []byte{}, want: "invalid image: file is empty"},
+		{name: "too large", data: make([]byte, completionImageMaxBytes+1), want: "invalid image: must be at most 5 MiB"},
+		{name: "not an image", data: []byte("<html>not a photo</html>"), want: "invalid image: only JPEG and PNG are supported"},
+		{name: "truncated png", data: encodePNG(t, image.NewGray(image.Rect(0, 0, 8, 8)))[:40], want: "invalid image: file could not be decoded"},
+		{name: "too wide", data: encodePNG(t, image.NewGray(image.Rect(0, 0, completionImageMaxSide+1, 1))), want: "invalid image: each side must be at most 8000 pixels"},
+		{name: "too many pixels", data: pngHeaderWithSize(t, 7000, 7000), want: "invalid image: must be at most 40 megapixels"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if _, err := prepareEvidenceImage(tc.data); err == nil || !strings.HasPrefix(err.Error(), tc.want) {
+				t.Fatalf("expected %q, got %v", tc.want, err)
+			}
+		})
+	}
+}
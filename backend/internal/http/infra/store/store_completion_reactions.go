@@ -0,0 +1,239 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	dbsqlc "github.com/megu/kaji-challenge/backend/internal/db/sqlc"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+const (
+	reactionEmojiMaxBytes = 32
+	reactionEmojiMaxRunes = 8
+	// reactionEmojisPerCompletionMax caps the distinct emojis on one
+	// completion; adding to an existing emoji is always allowed.
+	reactionEmojisPerCompletionMax = 20
+)
+
+// completionReactionRow is one member's reaction, in the order reactions
+// were added.
+type completionReactionRow struct {
+	Emoji  string
+	UserID string
+}
+
+// ToggleCompletionReaction adds the user's reaction to the completion
+// addressed by req, or removes it when the user already reacted with the same
+// emoji.
+func (s *Store) ToggleCompletionReaction(ctx context.Context, userID, taskID string, req api.ToggleCompletionReactionRequest) (api.CompletionReactionResponse, error) {
+	emoji := strings.TrimSpace(req.Emoji)
+	if !validReactionEmoji(emoji) {
+		return api.CompletionReactionResponse{}, errors.New("invalid emoji: must be a single emoji")
+	}
+	slot := 0
+	if req.Slot != nil {
+		slot = *req.Slot
+		if slot < 1 || slot > requiredCompletionsPerWeekMax {
+			return api.CompletionReactionResponse{}, fmt.Errorf("invalid slot: must be between 1 and %d", requiredCompletionsPerWeekMax)
+		}
+	}
+	teamID, err := s.primaryTeamLocked(ctx, userID)
+	if err != nil {
+		return api.CompletionReactionResponse{}, err
+	}
+
+	res := api.CompletionReactionResponse{}
+	hints := map[string]string{"taskId": taskID, "action": "add"}
+	_, err = s.runWithTeamRevisionCAS(
+		ctx,
+		teamID,
+		"completion_reaction",
+		hints,
+		func(txCtx context.Context, qtx *dbsqlc.Queries) error {
+			task, err := getTeamTaskLocked(txCtx, qtx, teamID, taskID, s.loc)
+			if err != nil {
+				return err
+			}
+			targetDate, err := s.completionTargetDate(task, req.TargetDate.Time)
+			if err != nil {
+				return err
+			}
+			completion, err := s.getCompletionEvidenceLocked(txCtx, qtx, task, targetDate, slot)
+			if errors.Is(err, errCompletionNotFound) {
+				return fmt.Errorf("%w: only recorded completions can be reacted to", err)
+			}
+			if err != nil {
+				return err
+			}
+			target := completionReactionTarget(task, targetDate, completion)
+
+			removed, err := qtx.DeleteCompletionReaction(txCtx, dbsqlc.DeleteCompletionReactionParams{
+				TaskID:        target.TaskID,
+				TargetDate:    target.TargetDate,
+				WeeklyEntryID: target.WeeklyEntryID,
+				OneOffTaskID:  target.OneOffTaskID,
+				UserID:        userID,
+				Emoji:         emoji,
+			})
+			if err != nil {
+				return err
+			}
+			reacted := removed == 0
+			if reacted {
+				existing, err := listCompletionReactionsLocked(txCtx, qtx, target)
+				if err != nil {
+					return err
+				}
+				if !hasReactionEmoji(existing, emoji) && len(groupCompletionReactions(existing)) >= reactionEmojisPerCompletionMax {
+					return fmt.Errorf("invalid emoji: at most %d different emojis per completion", reactionEmojisPerCompletionMax)
+				}
+				if _, err := qtx.CreateCompletionReaction(txCtx, dbsqlc.CreateCompletionReactionParams{
+					ID:            s.nextID("reaction"),
+					TaskID:        target.TaskID,
+					TargetDate:    target.TargetDate,
+					WeeklyEntryID: target.WeeklyEntryID,
+					OneOffTaskID:  target.OneOffTaskID,
+					UserID:        userID,
+					Emoji:         emoji,
+					CreatedAt:     toPgTimestamptz(s.now()),
+				}); err != nil {
+					return err
+				}
+			} else {
+				// The event is published after this function returns, so the
+				// hint can still describe what the toggle did.
+				hints["action"] = "remove"
+			}
+			rows, err := listCompletionReactionsLocked(txCtx, qtx, target)
+			if err != nil {
+				return err
+			}
+			res = api.CompletionReactionResponse{
+				TaskId:     task.ID,
+				TargetDate: toDate(targetDate),
+				Slot:       completion.Slot,
+				Reacted:    reacted,
+				Reactions:  groupCompletionReactions(rows),
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return api.CompletionReactionResponse{}, err
+	}
+	return res, nil
+}
+
+// completionReactionTarget points a reaction at exactly one completion row:
+// the day's row for daily tasks, the weekly entry, or the one_off row.
+func completionReactionTarget(task taskRecord, targetDate time.Time, completion completionEvidenceState) dbsqlc.ListCompletionReactionsByTargetParams {
+	target := dbsqlc.ListCompletionReactionsByTargetParams{TaskID: task.ID}
+	switch task.Type {
+	case api.Weekly:
+		target.WeeklyEntryID = completion.EntryID
+	case api.OneOff:
+		target.OneOffTaskID = task.ID
+	default:
+		target.TargetDate = toPgDate(targetDate)
+	}
+	return target
+}
+
+func listCompletionReactionsLocked(ctx context.Context, qtx *dbsqlc.Queries, target dbsqlc.ListCompletionReactionsByTargetParams) ([]completionReactionRow, error) {
+	rows, err := qtx.ListCompletionReactionsByTarget(ctx, target)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]completionReactionRow, 0, len(rows))
+	for _, row := range rows {
+		out = append(out, completionReactionRow{Emoji: row.Emoji, UserID: row.UserID})
+	}
+	return out, nil
+}
+
+// listCompletionReactionsByTask returns the reactions on today's daily
+// completions, this week's weekly completions and one_off completions, keyed
+// by task ID and completion slot.
+func (s *Store) listCompletionReactionsByTask(ctx context.Context, teamID string, today, weekStart time.Time) (map[string]map[int]*[]api.CompletionReaction, error) {
+	rows, err := s.q.ListCompletionReactionsByTeam(ctx, dbsqlc.ListCompletionReactionsByTeamParams{
+		TeamID:     teamID,
+		TargetDate: toPgDate(today),
+		WeekStart:  toPgDate(weekStart),
+	})
+	if err != nil {
+		return nil, err
+	}
+	bySlot := map[string]map[int][]completionReactionRow{}
+	for _, row := range rows {
+		if bySlot[row.TaskID] == nil {
+			bySlot[row.TaskID] = map[int][]completionReactionRow{}
+		}
+		slot := int(row.Slot)
+		bySlot[row.TaskID][slot] = append(bySlot[row.TaskID][slot], completionReactionRow{Emoji: row.Emoji, UserID: row.UserID})
+	}
+	out := make(map[string]map[int]*[]api.CompletionReaction, len(bySlot))
+	for taskID, slots := range bySlot {
+		out[taskID] = make(map[int]*[]api.CompletionReaction, len(slots))
+		for slot, reactions := range slots {
+			grouped := groupCompletionReactions(reactions)
+			out[taskID][slot] = &grouped
+		}
+	}
+	return out, nil
+}
+
+// groupCompletionReactions counts reactions per emoji. Emojis keep the order
+// of their first reaction so the UI does not reshuffle as counts change.
+func groupCompletionReactions(rows []completionReactionRow) []api.CompletionReaction {
+	out := []api.CompletionReaction{}
+	index := map[string]int{}
+	for _, row := range rows {
+		i, ok := index[row.Emoji]
+		if !ok {
+			i = len(out)
+			index[row.Emoji] = i
+			out = append(out, api.CompletionReaction{Emoji: row.Emoji, UserIds: []string{}})
+		}
+		out[i].UserIds = append(out[i].UserIds, row.UserID)
+		out[i].Count++
+	}
+	return out
+}
+
+func hasReactionEmoji(rows []completionReactionRow, emoji string) bool {
+	for _, row := range rows {
+		if row.Emoji == emoji {
+			return true
+		}
+	}
+	return false
+}
+
+// validReactionEmoji accepts one emoji, including flags, skin tone modifiers
+// and ZWJ sequences such as family emojis. It does not try to decide whether
+// a sequence is one that fonts render as a single glyph; the length limits
+// keep anything else short.
+func validReactionEmoji(emoji string) bool {
+	if emoji == "" || len(emoji) > reactionEmojiMaxBytes || utf8.RuneCountInString(emoji) > reactionEmojiMaxRunes {
+		return false
+	}
+	hasSymbol := false
+	for _, r := range emoji {
+		switch {
+		case unicode.Is(unicode.So, r):
+			hasSymbol = true
+		case r >= 0x1F3FB && r <= 0x1F3FF: // skin tone modifiers
+		case r == 0x200D, r == 0xFE0F: // zero width joiner, emoji presentation selector
+		case r >= 0xE0020 && r <= 0xE007F: // tag sequences of subdivision flags
+		default:
+			return false
+		}
+	}
+	return hasSymbol
+}
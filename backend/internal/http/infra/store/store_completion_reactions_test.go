@@ -0,0 +1,88 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+func TestValidReactionEmoji(t *testing.T) {
+	for _, emoji := range []string{"👍", "❤️", "🧽", "👍🏽", "🇯🇵", "👨‍👩‍👧", "🏴󠁧󠁢󠁳󠁣󠁴󠁿"} {
+		if !validReactionEmoji(emoji) {
+			t.Fatalf("expected %q to be accepted", emoji)
+		}
+	}
+	for _, emoji := range []string{"", "a", "+1", "👍 ", "ありがとう", "👍👍👍👍👍👍👍👍👍", "‍"} {
+		if validReactionEmoji(emoji) {
+			t.Fatalf("expected %q to be rejected", emoji)
+		}
+	}
+}
+
+func TestGroupCompletionReactions(t *testing.T) {
+	got := groupCompletionReactions([]completionReactionRow{
+		{Emoji: "🎉", UserID: "a"},
+		{Emoji: "👍", UserID: "b"},
+		{Emoji: "🎉", UserID: "b"},
+	})
+	if len(got) != 2 || got[0].Emoji != "🎉" || got[0].Count != 2 || got[0].UserIds[1] != "b" || got[1].Emoji != "👍" || got[1].Count != 1 {
+		t.Fatalf("expected reactions grouped in first-reaction order, got %+v", got)
+	}
+	if got := groupCompletionReactions(nil); got == nil || len(got) != 0 {
+		t.Fatalf("expected an empty list, got %#v", got)
+	}
+}
+
+func TestCompletionReactionToggle(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	today := time.Date(2026, 4, 8, 0, 0, 0, 0, s.loc)
+	s.SetClock(FixedClock(today.Add(9 * time.Hour)))
+
+	_, userID := createTeamWithMember(t, s, "reactions@example.com", today.AddDate(0, 0, -1))
+	task, err := s.CreateTask(withLatestIfMatchForUser(t, s, ctx, userID), userID, api.CreateTaskRequest{
+		Title: "Take out the trash", Type: api.Daily, PenaltyPoints: 1,
+	})
+	if err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+	req := api.ToggleCompletionReactionRequest{TargetDate: openapi_types.Date{Time: today}, Emoji: "🎉"}
+	if _, err := s.ToggleCompletionReaction(withLatestIfMatchForUser(t, s, ctx, userID), userID, task.Id, req); err == nil ||
+		err.Error() != "completion not found: only recorded completions can be reacted to" {
+		t.Fatalf("expected reactions to need a completion, got %v", err)
+	}
+	if _, err := s.ToggleTaskCompletion(withLatestIfMatchForUser(t, s, ctx, userID), userID, task.Id, today, nil); err != nil {
+		t.Fatalf("ToggleTaskCompletion failed: %v", err)
+	}
+
+	added, err := s.ToggleCompletionReaction(withLatestIfMatchForUser(t, s, ctx, userID), userID, task.Id, req)
+	if err != nil || !added.Reacted || len(added.Reactions) != 1 || added.Reactions[0].Count != 1 || added.Reactions[0].UserIds[0] != userID {
+		t.Fatalf("expected the reaction to be added, got %+v, %v", added, err)
+	}
+	overview, err := s.GetTaskOverview(ctx, userID, nil)
+	if err != nil || len(overview.DailyTasks) != 1 || overview.DailyTasks[0].Reactions == nil || (*overview.DailyTasks[0].Reactions)[0].Emoji != "🎉" {
+		t.Fatalf("expected the overview to show the reaction, got %+v, %v", overview.DailyTasks, err)
+	}
+
+	removed, err := s.ToggleCompletionReaction(withLatestIfMatchForUser(t, s, ctx, userID), userID, task.Id, req)
+	if err != nil || removed.Reacted || len(removed.Reactions) != 0 {
+		t.Fatalf("expected the second toggle to remove the reaction, got %+v, %v", removed, err)
+	}
+
+	if _, err := s.ToggleCompletionReaction(withLatestIfMatchForUser(t, s, ctx, userID), userID, task.Id, req); err != nil {
+		t.Fatalf("ToggleCompletionReaction failed: %v", err)
+	}
+	if _, err := s.ToggleTaskCompletion(withLatestIfMatchForUser(t, s, ctx, userID), userID, task.Id, today, nil); err != nil {
+		t.Fatalf("ToggleTaskCompletion failed: %v", err)
+	}
+	if _, err := s.ToggleTaskCompletion(withLatestIfMatchForUser(t, s, ctx, userID), userID, task.Id, today, nil); err != nil {
+		t.Fatalf("ToggleTaskCompletion failed: %v", err)
+	}
+	overview, err = s.GetTaskOverview(ctx, userID, nil)
+	if err != nil || overview.DailyTasks[0].Reactions != nil {
+		t.Fatalf("expected reactions to go away with the undone completion, got %+v, %v", overview.DailyTasks, err)
+	}
+}
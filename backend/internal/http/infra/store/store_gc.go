@@ -0,0 +1,250 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	dbsqlc "github.com/megu/kaji-challenge/backend/internal/db/sqlc"
+)
+
+// Targets purged by RunGC.
+const (
+	GCTargetAuthRequests          = "oauth_auth_requests"
+	GCTargetExchangeCodes         = "oauth_exchange_codes"
+	GCTargetSessions              = "sessions"
+	GCTargetInviteCodes           = "invite_codes"
+	GCTargetDeletedTasks          = "deleted_tasks"
+	GCTargetDeletedRules          = "deleted_penalty_rules"
+	GCTargetCloseRunHistory       = "close_run_history"
+	GCTargetWebhookDeliveries     = "webhook_deliveries"
+	GCTargetPersonalExports       = "personal_data_exports"
+	GCTargetCompletionAttachments = "completion_attachments"
+	GCTargetTeamAuditLog          = "team_audit_log"
+)
+
+const (
+	gcMaxBatchSize = 10000
+	// sessionCookieLifetime matches the session cookie max age. Sessions are
+	// stored without expires_at, so older ones can no longer be presented.
+	sessionCookieLifetime = 30 * 24 * time.Hour
+)
+
+// GCPolicy keeps rows of Target until they are older than Retention. What
+// "older" means depends on the target: past expiry for auth artifacts, past
+// deleted_at for soft-deleted rows, past creation for logs.
+type GCPolicy struct {
+	Target    string
+	Retention time.Duration
+}
+
+// GCResult reports one target. Matched is counted before deleting, so a dry
+// run reports what a real run would remove.
+type GCResult struct {
+	Target    string    `json:"target"`
+	Retention string    `json:"retention"`
+	Cutoff    time.Time `json:"cutoff"`
+	Matched   int64     `json:"matched"`
+	Deleted   int64     `json:"deleted"`
+	Batches   int       `json:"batches"`
+}
+
+// DefaultGCPolicies returns every target with its default retention, in the
+// order RunGC processes them.
+func DefaultGCPolicies() []GCPolicy {
+	return []GCPolicy{
+		{Target: GCTargetAuthRequests, Retention: 24 * time.Hour},
+		{Target: GCTargetExchangeCodes, Retention: 24 * time.Hour},
+		{Target: GCTargetSessions, Retention: 7 * 24 * time.Hour},
+		{Target: GCTargetInviteCodes, Retention: 30 * 24 * time.Hour},
+		{Target: GCTargetDeletedTasks, Retention: 180 * 24 * time.Hour},
+		{Target: GCTargetDeletedRules, Retention: 180 * 24 * time.Hour},
+		{Target: GCTargetCloseRunHistory, Retention: 180 * 24 * time.Hour},
+		{Target: GCTargetWebhookDeliveries, Retention: 30 * 24 * time.Hour},
+		{Target: GCTargetPersonalExports, Retention: 24 * time.Hour},
+		{Target: GCTargetCompletionAttachments, Retention: 24 * time.Hour},
+		{Target: GCTargetTeamAuditLog, Retention: 365 * 24 * time.Hour},
+	}
+}
+
+type gcTarget struct {
+	count       func(ctx context.Context, q *dbsqlc.Queries, cutoff pgtype.Timestamptz) (int64, error)
+	deleteBatch func(ctx context.Context, q *dbsqlc.Queries, cutoff pgtype.Timestamptz, limit int32) (int64, error)
+}
+
+// gcTargets holds the purge queries per target. Soft-deleted tasks and rules
+// are only purged once the month after their deletion is closed, so a
+// catch-up close can still read them; rules referenced by a month summary
+// are kept for good. A purged task's penalty charges stay behind, since
+// task_evaluation_dedupes has no foreign key to tasks, so closed months keep
+// their category breakdown. Completion photos are purged once no completion
+// references them, blobs first so a failed blob delete is retried on the
+// next run.
+func (s *Store) gcTargets() map[string]gcTarget {
+	tz := s.loc.String()
+	return map[string]gcTarget{
+		GCTargetAuthRequests: {
+			count: func(ctx context.Context, q *dbsqlc.Queries, cutoff pgtype.Timestamptz) (int64, error) {
+				return q.CountExpiredAuthRequests(ctx, cutoff)
+			},
+			deleteBatch: func(ctx context.Context, q *dbsqlc.Queries, cutoff pgtype.Timestamptz, limit int32) (int64, error) {
+				return q.DeleteExpiredAuthRequestsBatch(ctx, dbsqlc.DeleteExpiredAuthRequestsBatchParams{Cutoff: cutoff, BatchSize: limit})
+			},
+		},
+		GCTargetExchangeCodes: {
+			count: func(ctx context.Context, q *dbsqlc.Queries, cutoff pgtype.Timestamptz) (int64, error) {
+				return q.CountStaleExchangeCodes(ctx, cutoff)
+			},
+			deleteBatch: func(ctx context.Context, q *dbsqlc.Queries, cutoff pgtype.Timestamptz, limit int32) (int64, error) {
+				return q.DeleteStaleExchangeCodesBatch(ctx, dbsqlc.DeleteStaleExchangeCodesBatchParams{Cutoff: cutoff, BatchSize: limit})
+			},
+		},
+		GCTargetSessions: {
+			count: func(ctx context.Context, q *dbsqlc.Queries, cutoff pgtype.Timestamptz) (int64, error) {
+				return q.CountStaleSessions(ctx, dbsqlc.CountStaleSessionsParams{
+					Cutoff:        cutoff,
+					CreatedBefore: toPgTimestamptz(cutoff.Time.Add(-sessionCookieLifetime)),
+				})
+			},
+			deleteBatch: func(ctx context.Context, q *dbsqlc.Queries, cutoff pgtype.Timestamptz, limit int32) (int64, error) {
+				return q.DeleteStaleSessionsBatch(ctx, dbsqlc.DeleteStaleSessionsBatchParams{
+					Cutoff:        cutoff,
+					CreatedBefore: toPgTimestamptz(cutoff.Time.Add(-sessionCookieLifetime)),
+					BatchSize:     limit,
+				})
+			},
+		},
+		GCTargetInviteCodes: {
+			count: func(ctx context.Context, q *dbsqlc.Queries, cutoff pgtype.Timestamptz) (int64, error) {
+				return q.CountExpiredInviteCodes(ctx, cutoff)
+			},
+			deleteBatch: func(ctx context.Context, q *dbsqlc.Queries, cutoff pgtype.Timestamptz, limit int32) (int64, error) {
+				return q.DeleteExpiredInviteCodesBatch(ctx, dbsqlc.DeleteExpiredInviteCodesBatchParams{Cutoff: cutoff, BatchSize: limit})
+			},
+		},
+		GCTargetDeletedTasks: {
+			count: func(ctx context.Context, q *dbsqlc.Queries, cutoff pgtype.Timestamptz) (int64, error) {
+				return q.CountPurgeableDeletedTasks(ctx, dbsqlc.CountPurgeableDeletedTasksParams{Cutoff: cutoff, TimeZone: tz})
+			},
+			deleteBatch: func(ctx context.Context, q *dbsqlc.Queries, cutoff pgtype.Timestamptz, limit int32) (int64, error) {
+				return q.DeletePurgeableDeletedTasksBatch(ctx, dbsqlc.DeletePurgeableDeletedTasksBatchParams{Cutoff: cutoff, TimeZone: tz, BatchSize: limit})
+			},
+		},
+		GCTargetDeletedRules: {
+			count: func(ctx context.Context, q *dbsqlc.Queries, cutoff pgtype.Timestamptz) (int64, error) {
+				return q.CountPurgeableDeletedPenaltyRules(ctx, dbsqlc.CountPurgeableDeletedPenaltyRulesParams{Cutoff: cutoff, TimeZone: tz})
+			},
+			deleteBatch: func(ctx context.Context, q *dbsqlc.Queries, cutoff pgtype.Timestamptz, limit int32) (int64, error) {
+				return q.DeletePurgeableDeletedPenaltyRulesBatch(ctx, dbsqlc.DeletePurgeableDeletedPenaltyRulesBatchParams{Cutoff: cutoff, TimeZone: tz, BatchSize: limit})
+			},
+		},
+		GCTargetCloseRunHistory: {
+			count: func(ctx context.Context, q *dbsqlc.Queries, cutoff pgtype.Timestamptz) (int64, error) {
+				return q.CountOldCloseRunHistory(ctx, cutoff)
+			},
+			deleteBatch: func(ctx context.Context, q *dbsqlc.Queries, cutoff pgtype.Timestamptz, limit int32) (int64, error) {
+				return q.DeleteOldCloseRunHistoryBatch(ctx, dbsqlc.DeleteOldCloseRunHistoryBatchParams{Cutoff: cutoff, BatchSize: limit})
+			},
+		},
+		GCTargetWebhookDeliveries: {
+			count: func(ctx context.Context, q *dbsqlc.Queries, cutoff pgtype.Timestamptz) (int64, error) {
+				return q.CountFinishedWebhookDeliveries(ctx, cutoff)
+			},
+			deleteBatch: func(ctx context.Context, q *dbsqlc.Queries, cutoff pgtype.Timestamptz, limit int32) (int64, error) {
+				return q.DeleteFinishedWebhookDeliveriesBatch(ctx, dbsqlc.DeleteFinishedWebhookDeliveriesBatchParams{Cutoff: cutoff, BatchSize: limit})
+			},
+		},
+		GCTargetPersonalExports: {
+			count: func(ctx context.Context, q *dbsqlc.Queries, cutoff pgtype.Timestamptz) (int64, error) {
+				return q.CountExpiredPersonalDataExports(ctx, cutoff)
+			},
+			deleteBatch: func(ctx context.Context, q *dbsqlc.Queries, cutoff pgtype.Timestamptz, limit int32) (int64, error) {
+				return q.DeleteExpiredPersonalDataExportsBatch(ctx, dbsqlc.DeleteExpiredPersonalDataExportsBatchParams{Cutoff: cutoff, BatchSize: limit})
+			},
+		},
+		GCTargetCompletionAttachments: {
+			count: func(ctx context.Context, q *dbsqlc.Queries, cutoff pgtype.Timestamptz) (int64, error) {
+				return q.CountOrphanedCompletionAttachments(ctx, cutoff)
+			},
+			deleteBatch: s.deleteOrphanedAttachmentsBatch,
+		},
+		GCTargetTeamAuditLog: {
+			count: func(ctx context.Context, q *dbsqlc.Queries, cutoff pgtype.Timestamptz) (int64, error) {
+				return q.CountOldTeamAuditEntries(ctx, cutoff)
+			},
+			deleteBatch: func(ctx context.Context, q *dbsqlc.Queries, cutoff pgtype.Timestamptz, limit int32) (int64, error) {
+				return q.DeleteOldTeamAuditEntriesBatch(ctx, dbsqlc.DeleteOldTeamAuditEntriesBatchParams{Cutoff: cutoff, BatchSize: limit})
+			},
+		},
+	}
+}
+
+// RunGC purges rows older than each policy's retention. Deletes run in
+// batches of batchSize, each committed on its own, so a large backlog never
+// holds row locks for long. With dryRun only the counts are taken. On error
+// the results gathered so far are returned with it.
+func (s *Store) RunGC(ctx context.Context, policies []GCPolicy, batchSize int, dryRun bool) ([]GCResult, error) {
+	if batchSize < 1 || batchSize > gcMaxBatchSize {
+		return nil, fmt.Errorf("invalid batch size: must be between 1 and %d", gcMaxBatchSize)
+	}
+	targets := s.gcTargets()
+	for _, policy := range policies {
+		if _, ok := targets[policy.Target]; !ok {
+			return nil, fmt.Errorf("invalid gc target: %s", policy.Target)
+		}
+		if policy.Retention < 0 {
+			return nil, fmt.Errorf("invalid retention for %s: must not be negative", policy.Target)
+		}
+	}
+
+	now := s.now()
+	results := make([]GCResult, 0, len(policies))
+	for _, policy := range policies {
+		target := targets[policy.Target]
+		cutoffAt := now.Add(-policy.Retention)
+		cutoff := toPgTimestamptz(cutoffAt)
+		res := GCResult{Target: policy.Target, Retention: policy.Retention.String(), Cutoff: cutoffAt}
+		matched, err := target.count(ctx, s.q, cutoff)
+		if err != nil {
+			return results, fmt.Errorf("count %s: %w", policy.Target, err)
+		}
+		res.Matched = matched
+		if !dryRun {
+			for {
+				n, err := target.deleteBatch(ctx, s.q, cutoff, int32(batchSize))
+				if err != nil {
+					results = append(results, res)
+					return results, fmt.Errorf("purge %s: %w", policy.Target, err)
+				}
+				if n == 0 {
+					break
+				}
+				res.Deleted += n
+				res.Batches++
+				if n < int64(batchSize) {
+					break
+				}
+			}
+		}
+		results = append(results, res)
+	}
+	return results, nil
+}
+
+func (s *Store) deleteOrphanedAttachmentsBatch(ctx context.Context, q *dbsqlc.Queries, cutoff pgtype.Timestamptz, limit int32) (int64, error) {
+	rows, err := q.ListOrphanedCompletionAttachmentsBatch(ctx, dbsqlc.ListOrphanedCompletionAttachmentsBatchParams{Cutoff: cutoff, BatchSize: limit})
+	if err != nil || len(rows) == 0 {
+		return 0, err
+	}
+	ids := make([]string, 0, len(rows))
+	for _, row := range rows {
+		for _, key := range []string{row.BlobKey, row.ThumbnailKey} {
+			if err := s.blobs.Delete(ctx, key); err != nil {
+				return 0, fmt.Errorf("delete blob %s: %w", key, err)
+			}
+		}
+		ids = append(ids, row.ID)
+	}
+	return q.DeleteCompletionAttachmentsByIDs(ctx, ids)
+}
@@ -0,0 +1,126 @@
+package store
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	dbsqlc "github.com/megu/kaji-challenge/backend/internal/db/sqlc"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func TestRunGCPurgesDeletedTasksOnlyAfterTheNextMonthIsClosed(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	base := time.Date(2026, 1, 5, 12, 0, 0, 0, s.loc)
+
+	teamID, _ := createTeamWithMember(t, s, "gc@example.com", base)
+	deleteTask := func(deletedAt time.Time) string {
+		t.Helper()
+		taskID := createTaskAtWithID(t, s, teamID, api.Daily, 1, 1, base)
+		if err := s.q.DeleteTask(ctx, dbsqlc.DeleteTaskParams{ID: taskID, DeletedAt: toPgTimestamptz(deletedAt)}); err != nil {
+			t.Fatalf("failed to delete task: %v", err)
+		}
+		return taskID
+	}
+	januaryA := deleteTask(time.Date(2026, 1, 10, 12, 0, 0, 0, s.loc))
+	januaryB := deleteTask(time.Date(2026, 1, 20, 12, 0, 0, 0, s.loc))
+	february := deleteTask(time.Date(2026, 2, 10, 12, 0, 0, 0, s.loc))
+
+	s.SetClock(FixedClock(time.Date(2026, 3, 5, 12, 0, 0, 0, s.loc)))
+	if _, err := s.CloseMonthForTeam(ctx, teamID); err != nil {
+		t.Fatalf("CloseMonthForTeam failed: %v", err)
+	}
+	policies := []GCPolicy{{Target: GCTargetDeletedTasks, Retention: 0}}
+
+	dry, err := s.RunGC(ctx, policies, 1, true)
+	if err != nil {
+		t.Fatalf("RunGC dry run failed: %v", err)
+	}
+	if len(dry) != 1 || dry[0].Matched != 2 || dry[0].Deleted != 0 {
+		t.Fatalf("expected 2 purgeable tasks and no deletes, got %+v", dry)
+	}
+	if _, err := s.q.GetTaskByID(ctx, januaryA); err != nil {
+		t.Fatalf("expected dry run to keep the task: %v", err)
+	}
+
+	res, err := s.RunGC(ctx, policies, 1, false)
+	if err != nil {
+		t.Fatalf("RunGC failed: %v", err)
+	}
+	if res[0].Deleted != 2 || res[0].Batches != 2 {
+		t.Fatalf("expected 2 tasks purged in 2 batches, got %+v", res[0])
+	}
+	for _, id := range []string{januaryA, januaryB} {
+		if _, err := s.q.GetTaskByID(ctx, id); err == nil {
+			t.Fatalf("expected task %s to be purged", id)
+		}
+	}
+	if _, err := s.q.GetTaskByID(ctx, february); err != nil {
+		t.Fatalf("expected the task deleted in the open month to stay: %v", err)
+	}
+}
+
+func TestRunGCKeepsClosedMonthCategoryStats(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	base := time.Date(2026, 1, 5, 12, 0, 0, 0, s.loc)
+	s.SetClock(FixedClock(base))
+
+	teamID, userID := createTeamWithMember(t, s, "gc-stats@example.com", base.AddDate(0, 0, -1))
+	kitchen, err := s.CreateTaskCategory(withLatestIfMatchForUser(t, s, ctx, userID), userID, api.CreateTaskCategoryRequest{Name: "Kitchen"})
+	if err != nil {
+		t.Fatalf("CreateTaskCategory failed: %v", err)
+	}
+	dishes, err := s.CreateTask(withLatestIfMatchForUser(t, s, ctx, userID), userID, api.CreateTaskRequest{
+		Title: "Dishes", Type: api.Daily, PenaltyPoints: 3, CategoryId: &kitchen.Id,
+	})
+	if err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+	s.SetClock(FixedClock(time.Date(2026, 1, 8, 12, 0, 0, 0, s.loc)))
+	if _, err := s.closeDayForTargetLocked(ctx, time.Date(2026, 1, 6, 0, 0, 0, 0, s.loc), teamID); err != nil {
+		t.Fatalf("closeDayForTargetLocked failed: %v", err)
+	}
+	if err := s.q.DeleteTask(ctx, dbsqlc.DeleteTaskParams{ID: dishes.Id, DeletedAt: toPgTimestamptz(time.Date(2026, 1, 10, 12, 0, 0, 0, s.loc))}); err != nil {
+		t.Fatalf("failed to delete task: %v", err)
+	}
+	s.SetClock(FixedClock(time.Date(2026, 3, 5, 12, 0, 0, 0, s.loc)))
+	if _, err := s.CloseMonthForTeam(ctx, teamID); err != nil {
+		t.Fatalf("CloseMonthForTeam failed: %v", err)
+	}
+
+	january := "2026-01"
+	before, err := s.GetMonthlySummary(ctx, userID, &january)
+	if err != nil {
+		t.Fatalf("GetMonthlySummary failed: %v", err)
+	}
+	if before.CategoryStats == nil || len(*before.CategoryStats) == 0 || (*before.CategoryStats)[0].PenaltyTotal == 0 {
+		t.Fatalf("expected January penalties in the kitchen category, got %+v", before.CategoryStats)
+	}
+	res, err := s.RunGC(ctx, []GCPolicy{{Target: GCTargetDeletedTasks, Retention: 0}}, 100, false)
+	if err != nil {
+		t.Fatalf("RunGC failed: %v", err)
+	}
+	if res[0].Deleted != 1 {
+		t.Fatalf("expected the deleted task to be purged, got %+v", res[0])
+	}
+	after, err := s.GetMonthlySummary(ctx, userID, &january)
+	if err != nil {
+		t.Fatalf("GetMonthlySummary failed: %v", err)
+	}
+	if !reflect.DeepEqual(before.CategoryStats, after.CategoryStats) {
+		t.Fatalf("expected closed-month category stats to survive the purge, got %+v, want %+v", after.CategoryStats, before.CategoryStats)
+	}
+}
+
+func TestRunGCRejectsUnknownTarget(t *testing.T) {
+	s := newTestStore(t)
+	if _, err := s.RunGC(context.Background(), []GCPolicy{{Target: "users"}}, 100, true); err == nil {
+		t.Fatalf("expected unknown target error")
+	}
+	if _, err := s.RunGC(context.Background(), DefaultGCPolicies(), 0, true); err == nil {
+		t.Fatalf("expected invalid batch size error")
+	}
+}
@@ -0,0 +1,315 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+	dbsqlc "github.com/megu/kaji-challenge/backend/internal/db/sqlc"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+const (
+	holidayNameMaxLength   = 100
+	holidayDefaultName     = "Holiday"
+	holidayListMaxDays     = 731
+	holidayImportMaxDates  = 1000
+	holidayEventMaxDays    = 31
+	holidaySourceManual    = "manual"
+	holidaySourceICalendar = "ical"
+)
+
+type teamHoliday struct {
+	TeamID          string
+	Date            time.Time
+	Name            string
+	Source          string
+	CreatedByUserID string
+	CreatedAt       time.Time
+	Revision        int64
+}
+
+// holidayETagID identifies a holiday in its ETag; holidays are keyed by date.
+func holidayETagID(date time.Time) string {
+	return date.Format("2006-01-02")
+}
+
+func (h teamHoliday) toAPI() api.TeamHoliday {
+	etag := entityETag(etagKindHoliday, holidayETagID(h.Date), h.Revision)
+	return api.TeamHoliday{
+		TeamId:          h.TeamID,
+		Date:            toDate(h.Date),
+		Name:            h.Name,
+		Source:          api.TeamHolidaySource(h.Source),
+		CreatedByUserId: ptrFromUUIDString(h.CreatedByUserID),
+		CreatedAt:       h.CreatedAt,
+		Etag:            &etag,
+	}
+}
+
+// asAbsence expresses the holiday as a one-day team-wide absence so it can
+// join an absenceCalendar.
+func (h teamHoliday) asAbsence() teamAbsence {
+	return teamAbsence{TeamID: h.TeamID, StartsOn: h.Date, EndsOn: h.Date}
+}
+
+// listTeamHolidays returns the holidays in [from, to].
+func (s *Store) listTeamHolidays(ctx context.Context, teamID string, from, to time.Time) ([]teamHoliday, error) {
+	rows, err := s.queries(ctx).ListTeamHolidaysBetween(ctx, dbsqlc.ListTeamHolidaysBetweenParams{
+		TeamID:     teamID,
+		RangeStart: toPgDate(from),
+		RangeEnd:   toPgDate(to),
+	})
+	if err != nil {
+		return nil, err
+	}
+	items := make([]teamHoliday, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, teamHoliday{
+			TeamID:          row.TeamID,
+			Date:            dateOnly(row.HolidayDate.Time, s.loc),
+			Name:            row.Name,
+			Source:          row.Source,
+			CreatedByUserID: uuidStringFromPtr(ptrFromAny(row.CreatedByUserID)),
+			CreatedAt:       row.CreatedAt.Time.In(s.loc),
+			Revision:        row.Revision,
+		})
+	}
+	return items, nil
+}
+
+// listPauseCalendar merges the absences and holidays overlapping [from, to].
+func (s *Store) listPauseCalendar(ctx context.Context, teamID string, from, to time.Time) (absenceCalendar, error) {
+	calendar, err := s.listTeamAbsences(ctx, teamID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	holidays, err := s.listTeamHolidays(ctx, teamID, from, to)
+	if err != nil {
+		return nil, err
+	}
+	for _, h := range holidays {
+		calendar = append(calendar, h.asAbsence())
+	}
+	return calendar, nil
+}
+
+// ListTeamHolidays lists the holidays between from and to, which default to
+// today and one year later.
+func (s *Store) ListTeamHolidays(ctx context.Context, userID string, from, to *time.Time) ([]api.TeamHoliday, error) {
+	teamID, err := s.primaryTeamLocked(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	rangeStart := dateOnly(s.now(), s.loc)
+	if from != nil {
+		rangeStart = dateOnly(*from, s.loc)
+	}
+	rangeEnd := rangeStart.AddDate(1, 0, 0)
+	if to != nil {
+		rangeEnd = dateOnly(*to, s.loc)
+	}
+	if rangeEnd.Before(rangeStart) {
+		return nil, errors.New("invalid holiday range: to must not be before from")
+	}
+	if days := int(rangeEnd.Sub(rangeStart).Hours()/24) + 1; days > holidayListMaxDays {
+		return nil, fmt.Errorf("invalid holiday range: must be %d days or shorter", holidayListMaxDays)
+	}
+	holidays, err := s.listTeamHolidays(ctx, teamID, rangeStart, rangeEnd)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]api.TeamHoliday, 0, len(holidays))
+	for _, h := range holidays {
+		items = append(items, h.toAPI())
+	}
+	return items, nil
+}
+
+func (s *Store) CreateTeamHoliday(ctx context.Context, userID string, req api.CreateTeamHolidayRequest) (api.TeamHoliday, error) {
+	teamID, err := s.holidayOwnerTeam(ctx, userID)
+	if err != nil {
+		return api.TeamHoliday{}, err
+	}
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		return api.TeamHoliday{}, errors.New("invalid holiday: name is required")
+	}
+	if utf8.RuneCountInString(name) > holidayNameMaxLength {
+		return api.TeamHoliday{}, fmt.Errorf("invalid holiday: name must be %d characters or fewer", holidayNameMaxLength)
+	}
+	holiday := teamHoliday{
+		TeamID:          teamID,
+		Date:            dateOnly(req.Date.Time, s.loc),
+		Name:            name,
+		Source:          holidaySourceManual,
+		CreatedByUserID: userID,
+		CreatedAt:       s.now(),
+	}
+	if _, err := s.runWithTeamRevisionCAS(
+		ctx,
+		teamID,
+		"holiday",
+		map[string]string{"date": holiday.Date.Format("2006-01-02"), "action": "create"},
+		func(txCtx context.Context, qtx *dbsqlc.Queries) error {
+			holiday.Revision = nextEntityRevision(txCtx)
+			return upsertTeamHoliday(txCtx, qtx, holiday)
+		},
+	); err != nil {
+		return api.TeamHoliday{}, err
+	}
+	return holiday.toAPI(), nil
+}
+
+// ImportTeamHolidays saves every date covered by the calendar's events as a
+// holiday. Recurring, undated and overly long events are skipped rather than
+// guessed at.
+func (s *Store) ImportTeamHolidays(ctx context.Context, userID string, req api.ImportTeamHolidaysRequest) (api.ImportTeamHolidaysResponse, error) {
+	teamID, err := s.holidayOwnerTeam(ctx, userID)
+	if err != nil {
+		return api.ImportTeamHolidaysResponse{}, err
+	}
+	events, err := parseICalEvents(req.Ics, s.loc)
+	if err != nil {
+		return api.ImportTeamHolidaysResponse{}, err
+	}
+
+	now := s.now()
+	skipped := 0
+	holidays := []teamHoliday{}
+	seen := map[string]bool{}
+	for _, event := range events {
+		if !event.Dated || event.Recurring {
+			skipped++
+			continue
+		}
+		days, ok := event.dates(s.loc, holidayEventMaxDays)
+		if !ok {
+			skipped++
+			continue
+		}
+		name := event.Summary
+		if name == "" {
+			name = holidayDefaultName
+		}
+		if utf8.RuneCountInString(name) > holidayNameMaxLength {
+			name = string([]rune(name)[:holidayNameMaxLength])
+		}
+		for _, day := range days {
+			key := day.Format("2006-01-02")
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			holidays = append(holidays, teamHoliday{
+				TeamID:          teamID,
+				Date:            day,
+				Name:            name,
+				Source:          holidaySourceICalendar,
+				CreatedByUserID: userID,
+				CreatedAt:       now,
+			})
+		}
+	}
+	if len(holidays) > holidayImportMaxDates {
+		return api.ImportTeamHolidaysResponse{}, fmt.Errorf("invalid holiday calendar: more than %d dates", holidayImportMaxDates)
+	}
+	if len(holidays) == 0 {
+		return api.ImportTeamHolidaysResponse{Imported: 0, Skipped: skipped}, nil
+	}
+
+	if _, err := s.runWithTeamRevisionCAS(
+		ctx,
+		teamID,
+		"holiday",
+		map[string]string{"action": "import", "count": strconv.Itoa(len(holidays))},
+		func(txCtx context.Context, qtx *dbsqlc.Queries) error {
+			for _, holiday := range holidays {
+				holiday.Revision = nextEntityRevision(txCtx)
+				if err := upsertTeamHoliday(txCtx, qtx, holiday); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	); err != nil {
+		return api.ImportTeamHolidaysResponse{}, err
+	}
+	return api.ImportTeamHolidaysResponse{Imported: len(holidays), Skipped: skipped}, nil
+}
+
+func (s *Store) DeleteTeamHoliday(ctx context.Context, userID string, date time.Time) error {
+	teamID, err := s.holidayOwnerTeam(ctx, userID)
+	if err != nil {
+		return err
+	}
+	day := dateOnly(date, s.loc)
+	_, err = s.runWithTeamRevisionCAS(
+		ctx,
+		teamID,
+		"holiday",
+		map[string]string{"date": day.Format("2006-01-02"), "action": "delete"},
+		func(txCtx context.Context, qtx *dbsqlc.Queries) error {
+			row, err := qtx.GetTeamHolidayForUpdate(txCtx, dbsqlc.GetTeamHolidayForUpdateParams{TeamID: teamID, HolidayDate: toPgDate(day)})
+			if err != nil {
+				if errors.Is(err, pgx.ErrNoRows) {
+					return errors.New("holiday not found")
+				}
+				return err
+			}
+			if err := checkEntityIfMatch(txCtx, etagKindHoliday, holidayETagID(day), row.Revision); err != nil {
+				return err
+			}
+			n, err := qtx.DeleteTeamHoliday(txCtx, dbsqlc.DeleteTeamHolidayParams{TeamID: teamID, HolidayDate: toPgDate(day)})
+			if err != nil {
+				return err
+			}
+			if n == 0 {
+				return errors.New("holiday not found")
+			}
+			return nil
+		},
+	)
+	return err
+}
+
+// holidayOwnerTeam returns the caller's team, requiring the owner role since
+// holidays exempt every member.
+func (s *Store) holidayOwnerTeam(ctx context.Context, userID string) (string, error) {
+	membership, err := s.primaryMembershipLocked(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if membership.Role != string(api.TeamMembershipRoleOwner) {
+		return "", errors.New("forbidden: owner role required")
+	}
+	return membership.TeamID, nil
+}
+
+// upsertTeamHoliday saves the holiday, replacing any holiday already on that
+// date as long as the request's If-Match still covers it.
+func upsertTeamHoliday(ctx context.Context, qtx *dbsqlc.Queries, holiday teamHoliday) error {
+	existing, err := qtx.GetTeamHolidayForUpdate(ctx, dbsqlc.GetTeamHolidayForUpdateParams{TeamID: holiday.TeamID, HolidayDate: toPgDate(holiday.Date)})
+	switch {
+	case err == nil:
+		if err := checkEntityIfMatch(ctx, etagKindHoliday, holidayETagID(holiday.Date), existing.Revision); err != nil {
+			return err
+		}
+	case !errors.Is(err, pgx.ErrNoRows):
+		return err
+	}
+	return qtx.UpsertTeamHoliday(ctx, dbsqlc.UpsertTeamHolidayParams{
+		TeamID:          holiday.TeamID,
+		HolidayDate:     toPgDate(holiday.Date),
+		Name:            holiday.Name,
+		Source:          holiday.Source,
+		CreatedByUserID: holiday.CreatedByUserID,
+		CreatedAt:       toPgTimestamptz(holiday.CreatedAt),
+		Revision:        holiday.Revision,
+	})
+}
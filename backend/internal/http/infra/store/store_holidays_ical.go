@@ -0,0 +1,162 @@
+package store
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// icalEvent is the part of a VEVENT the holiday import needs. End is
+// exclusive, as in RFC 5545, and zero when the event has no DTEND.
+type icalEvent struct {
+	Summary   string
+	Start     time.Time
+	End       time.Time
+	Dated     bool
+	Recurring bool
+}
+
+// dates returns the calendar days the event covers in loc, or false when
+// they are more than maxDays. The span is checked before any day is built,
+// so an event spanning millennia costs no more than a short one.
+func (e icalEvent) dates(loc *time.Location, maxDays int) ([]time.Time, bool) {
+	first := dateOnly(e.Start, loc)
+	last := first
+	if e.End.After(e.Start) {
+		last = dateOnly(e.End.Add(-time.Nanosecond), loc)
+	}
+	if last.After(first.AddDate(0, 0, maxDays-1)) {
+		return nil, false
+	}
+	days := []time.Time{}
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		days = append(days, day)
+	}
+	return days, true
+}
+
+// parseICalEvents reads the VEVENTs of an iCalendar document. Only the
+// properties needed to place all-day holidays are understood; everything
+// else is ignored. Floating times are read in loc.
+func parseICalEvents(doc string, loc *time.Location) ([]icalEvent, error) {
+	lines := unfoldICalLines(doc)
+	sawCalendar := false
+	events := []icalEvent{}
+	var current *icalEvent
+	for _, line := range lines {
+		name, params, value, ok := splitICalProperty(line)
+		if !ok {
+			continue
+		}
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VCALENDAR"):
+			sawCalendar = true
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			current = &icalEvent{}
+		case name == "END" && strings.EqualFold(value, "VEVENT"):
+			if current != nil {
+				events = append(events, *current)
+				current = nil
+			}
+		case current == nil:
+			continue
+		case name == "SUMMARY":
+			current.Summary = unescapeICalText(value)
+		case name == "DTSTART":
+			start, err := parseICalTime(value, params, loc)
+			if err != nil {
+				return nil, err
+			}
+			current.Start = start
+			current.Dated = true
+		case name == "DTEND":
+			end, err := parseICalTime(value, params, loc)
+			if err != nil {
+				return nil, err
+			}
+			current.End = end
+		case name == "RRULE" || name == "RDATE":
+			current.Recurring = true
+		}
+	}
+	if !sawCalendar {
+		return nil, errors.New("invalid holiday calendar: BEGIN:VCALENDAR is missing")
+	}
+	return events, nil
+}
+
+func unfoldICalLines(doc string) []string {
+	raw := strings.Split(strings.ReplaceAll(doc, "\r\n", "\n"), "\n")
+	lines := make([]string, 0, len(raw))
+	for _, line := range raw {
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, strings.TrimRight(line, "\r"))
+	}
+	return lines
+}
+
+// splitICalProperty splits "NAME;PARAM=x:value" into its parts. Colons inside
+// quoted parameter values do not end the property name.
+func splitICalProperty(line string) (string, map[string]string, string, bool) {
+	inQuotes := false
+	colon := -1
+	for i, r := range line {
+		if r == '"' {
+			inQuotes = !inQuotes
+		}
+		if r == ':' && !inQuotes {
+			colon = i
+			break
+		}
+	}
+	if colon <= 0 {
+		return "", nil, "", false
+	}
+	parts := strings.Split(line[:colon], ";")
+	params := map[string]string{}
+	for _, part := range parts[1:] {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			continue
+		}
+		params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+	}
+	return strings.ToUpper(parts[0]), params, strings.TrimSpace(line[colon+1:]), true
+}
+
+func parseICalTime(value string, params map[string]string, loc *time.Location) (time.Time, error) {
+	if len(value) == len("20060102") {
+		t, err := time.ParseInLocation("20060102", value, loc)
+		if err != nil {
+			return time.Time{}, errors.New("invalid holiday calendar: malformed date " + value)
+		}
+		return t, nil
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse("20060102T150405Z", value)
+		if err != nil {
+			return time.Time{}, errors.New("invalid holiday calendar: malformed date-time " + value)
+		}
+		return t.In(loc), nil
+	}
+	eventLoc := loc
+	if tzid := params["TZID"]; tzid != "" {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			eventLoc = l
+		}
+	}
+	t, err := time.ParseInLocation("20060102T150405", value, eventLoc)
+	if err != nil {
+		return time.Time{}, errors.New("invalid holiday calendar: malformed date-time " + value)
+	}
+	return t.In(loc), nil
+}
+
+var icalTextReplacer = strings.NewReplacer(`\\`, `\`, `\,`, ",", `\;`, ";", `\n`, " ", `\N`, " ")
+
+func unescapeICalText(value string) string {
+	return strings.TrimSpace(icalTextReplacer.Replace(value))
+}
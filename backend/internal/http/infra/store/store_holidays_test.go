@@ -0,0 +1,134 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dbsqlc "github.com/megu/kaji-challenge/backend/internal/db/sqlc"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+const testHolidayCalendar = "BEGIN:VCALENDAR\r\n" +
+	"VERSION:2.0\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;VALUE=DATE:20260203\r\n" +
+	"DTEND;VALUE=DATE:20260204\r\n" +
+	"SUMMARY:Setsubun\\, family day\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;VALUE=DATE:20260813\r\n" +
+	"DTEND;VALUE=DATE:20260816\r\n" +
+	"SUMMARY:Ob\r\n" +
+	" on\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART;VALUE=DATE:20260101\r\n" +
+	"RRULE:FREQ=YEARLY\r\n" +
+	"SUMMARY:New Year\r\n" +
+	"END:VEVENT\r\n" +
+	"BEGIN:VEVENT\r\n" +
+	"DTSTART:20260310T160000Z\r\n" +
+	"DTEND:20260310T170000Z\r\n" +
+	"END:VEVENT\r\n" +
+	"END:VCALENDAR\r\n"
+
+func TestParseICalEvents(t *testing.T) {
+	loc := time.FixedZone("JST", 9*60*60)
+	events, err := parseICalEvents(testHolidayCalendar, loc)
+	if err != nil {
+		t.Fatalf("parseICalEvents failed: %v", err)
+	}
+	if len(events) != 4 {
+		t.Fatalf("expected 4 events, got %d", len(events))
+	}
+	if days, ok := events[0].dates(loc, holidayEventMaxDays); events[0].Summary != "Setsubun, family day" || !ok || len(days) != 1 {
+		t.Fatalf("unexpected single-day event: %+v", events[0])
+	}
+	obon, _ := events[1].dates(loc, holidayEventMaxDays)
+	if events[1].Summary != "Obon" || len(obon) != 3 || obon[2].Format("2006-01-02") != "2026-08-15" {
+		t.Fatalf("expected a folded 3-day event ending on 2026-08-15, got %q %v", events[1].Summary, obon)
+	}
+	if !events[2].Recurring {
+		t.Fatalf("expected RRULE to mark the event as recurring")
+	}
+	timed, _ := events[3].dates(loc, holidayEventMaxDays)
+	if len(timed) != 1 || timed[0].Format("2006-01-02") != "2026-03-11" {
+		t.Fatalf("expected the UTC event to fall on 2026-03-11 in JST, got %v", timed)
+	}
+
+	if _, ok := events[1].dates(loc, 2); ok {
+		t.Fatalf("expected a 3-day event to exceed a 2-day limit")
+	}
+	endless := icalEvent{Start: time.Date(1, 1, 1, 0, 0, 0, 0, loc), End: time.Date(9999, 12, 31, 0, 0, 0, 0, loc), Dated: true}
+	if days, ok := endless.dates(loc, holidayEventMaxDays); ok || days != nil {
+		t.Fatalf("expected an event spanning millennia to be refused without building its days, got %d days", len(days))
+	}
+
+	if _, err := parseICalEvents("BEGIN:VEVENT\nEND:VEVENT\n", loc); err == nil {
+		t.Fatalf("expected a document without VCALENDAR to be rejected")
+	}
+}
+
+func TestImportedHolidaysExemptCloseAndMonthlyStatus(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	weekStart := time.Date(2026, 2, 2, 0, 0, 0, 0, s.loc)
+	s.SetClock(FixedClock(weekStart.Add(9 * time.Hour)))
+
+	teamID, ownerID := createTeamWithMember(t, s, "holiday@example.com", weekStart.AddDate(0, 0, -1))
+	createTaskAt(t, s, teamID, api.Daily, 2, 1, weekStart.AddDate(0, 0, -1))
+
+	res, err := s.ImportTeamHolidays(withLatestIfMatchForUser(t, s, ctx, ownerID), ownerID, api.ImportTeamHolidaysRequest{Ics: testHolidayCalendar})
+	if err != nil {
+		t.Fatalf("ImportTeamHolidays failed: %v", err)
+	}
+	if res.Imported != 5 || res.Skipped != 1 {
+		t.Fatalf("expected 5 imported dates and 1 skipped event, got %+v", res)
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := s.closeDayForTargetLocked(ctx, weekStart.AddDate(0, 0, i), teamID); err != nil {
+			t.Fatalf("closeDayForTargetLocked failed: %v", err)
+		}
+	}
+	feb := getMonthSummary(t, s, teamID, "2026-02")
+	if feb.DailyPenaltyTotal != 4 {
+		t.Fatalf("expected the holiday to be skipped, got daily total=%d", feb.DailyPenaltyTotal)
+	}
+
+	groups, err := s.buildMonthlyTaskStatusByDate(ctx, teamID, "2026-02")
+	if err != nil {
+		t.Fatalf("buildMonthlyTaskStatusByDate failed: %v", err)
+	}
+	for _, group := range groups {
+		isHoliday := group.Date.Time.Format("2006-01-02") == "2026-02-03"
+		if group.Exempt == nil || *group.Exempt != isHoliday {
+			t.Fatalf("unexpected exempt flag on %s: %v", group.Date, group.Exempt)
+		}
+		if isHoliday && (group.HolidayName == nil || *group.HolidayName != "Setsubun, family day") {
+			t.Fatalf("expected the holiday name on %s, got %v", group.Date, group.HolidayName)
+		}
+	}
+
+	memberID := s.nextID("user")
+	if err := s.q.CreateUser(ctx, dbsqlc.CreateUserParams{
+		ID:          memberID,
+		Email:       "holiday-member@example.com",
+		DisplayName: "Member",
+		CreatedAt:   toPgTimestamptz(weekStart),
+	}); err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	if err := s.q.AddTeamMember(ctx, dbsqlc.AddTeamMemberParams{
+		TeamID:    teamID,
+		UserID:    memberID,
+		Role:      string(api.TeamMembershipRoleMember),
+		CreatedAt: toPgTimestamptz(weekStart),
+	}); err != nil {
+		t.Fatalf("failed to add team member: %v", err)
+	}
+	if err := s.DeleteTeamHoliday(ctx, memberID, weekStart.AddDate(0, 0, 1)); err == nil || err.Error() != "forbidden: owner role required" {
+		t.Fatalf("expected members to be unable to remove holidays, got %v", err)
+	}
+}
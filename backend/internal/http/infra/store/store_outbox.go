@@ -0,0 +1,211 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	dbsqlc "github.com/megu/kaji-challenge/backend/internal/db/sqlc"
+)
+
+const (
+	outboxDefaultPollInterval = time.Second
+	outboxClaimBatchSize      = 100
+	outboxMaxAttempts         = 10
+	outboxRetention           = 24 * time.Hour
+	outboxPruneInterval       = time.Hour
+)
+
+// teamEventConsumer receives every outbox event inside the dispatch
+// transaction, so anything it writes commits together with the dispatched
+// mark. Returning an error leaves the event pending for the next round.
+type teamEventConsumer func(ctx context.Context, q *dbsqlc.Queries, event TeamEvent) error
+
+func loadOutboxPollInterval() (time.Duration, error) {
+	v := strings.TrimSpace(os.Getenv("OUTBOX_POLL_INTERVAL"))
+	if v == "" {
+		return outboxDefaultPollInterval, nil
+	}
+	dur, err := time.ParseDuration(v)
+	if err != nil || dur <= 0 {
+		return 0, fmt.Errorf("OUTBOX_POLL_INTERVAL must be a positive duration: %q", v)
+	}
+	return dur, nil
+}
+
+// enqueueTeamEventLocked records event in the outbox using the caller's
+// transaction, so the event exists if and only if the revision bump commits.
+func (s *Store) enqueueTeamEventLocked(ctx context.Context, q *dbsqlc.Queries, event TeamEvent) error {
+	hints := event.Hints
+	if hints == nil {
+		hints = map[string]string{}
+	}
+	raw, err := json.Marshal(hints)
+	if err != nil {
+		return err
+	}
+	return q.InsertTeamEventOutbox(ctx, dbsqlc.InsertTeamEventOutboxParams{
+		TeamID:    event.TeamID,
+		Entity:    event.Entity,
+		Revision:  event.Revision,
+		Hints:     raw,
+		ChangedAt: toPgTimestamptz(event.ChangedAt),
+	})
+}
+
+// notifyOutbox wakes the dispatcher after a commit so SSE latency does not
+// depend on the poll interval.
+func (s *Store) notifyOutbox() {
+	select {
+	case s.outboxWake <- struct{}{}:
+	default:
+	}
+}
+
+// RunOutboxDispatcher drains the team event outbox until ctx is cancelled.
+func (s *Store) RunOutboxDispatcher(ctx context.Context) {
+	ticker := time.NewTicker(s.outboxPollInterval)
+	defer ticker.Stop()
+	var lastPrune time.Time
+	for {
+		if _, err := s.DispatchTeamEventOutbox(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("outbox dispatch failed: %v", err)
+		}
+		if time.Since(lastPrune) >= outboxPruneInterval {
+			if _, err := s.q.DeleteDispatchedTeamEventOutboxBefore(ctx, toPgTimestamptz(s.now().Add(-outboxRetention))); err != nil && ctx.Err() == nil {
+				log.Printf("outbox prune failed: %v", err)
+			}
+			lastPrune = time.Now()
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-s.outboxWake:
+		}
+	}
+}
+
+// DispatchTeamEventOutbox hands pending events to the consumers and the SSE
+// hub, returning how many were dispatched. Delivery is at-least-once: a crash
+// after a consumer ran but before commit replays the event.
+func (s *Store) DispatchTeamEventOutbox(ctx context.Context) (int, error) {
+	dispatched := 0
+	for {
+		n, more, err := s.dispatchTeamEventOutboxBatch(ctx)
+		dispatched += n
+		if err != nil || !more {
+			return dispatched, err
+		}
+	}
+}
+
+func (s *Store) dispatchTeamEventOutboxBatch(ctx context.Context) (int, bool, error) {
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return 0, false, err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+	qtx := s.q.WithTx(tx)
+
+	rows, err := qtx.ClaimPendingTeamEventOutbox(ctx, outboxClaimBatchSize)
+	if err != nil {
+		return 0, false, err
+	}
+	now := s.now()
+	published := make([]TeamEvent, 0, len(rows))
+	failed := false
+	for _, row := range rows {
+		event, consumeErr := teamEventFromOutbox(row, s.loc)
+		if consumeErr == nil {
+			consumeErr = s.consumeTeamEventLocked(ctx, tx, event)
+		}
+		if consumeErr == nil {
+			if err := qtx.MarkTeamEventOutboxDispatched(ctx, dbsqlc.MarkTeamEventOutboxDispatchedParams{
+				ID:           row.ID,
+				DispatchedAt: toPgTimestamptz(now),
+			}); err != nil {
+				return 0, false, err
+			}
+			published = append(published, event)
+			continue
+		}
+
+		failed = true
+		log.Printf("outbox event %d (team=%s entity=%s) failed: %v", row.ID, row.TeamID, row.Entity, consumeErr)
+		lastError := pgtype.Text{String: consumeErr.Error(), Valid: true}
+		if row.AttemptCount+1 >= outboxMaxAttempts {
+			// Give up so one broken event cannot stall the queue forever.
+			if err := qtx.MarkTeamEventOutboxDispatched(ctx, dbsqlc.MarkTeamEventOutboxDispatchedParams{
+				ID:           row.ID,
+				DispatchedAt: toPgTimestamptz(now),
+				LastError:    lastError,
+			}); err != nil {
+				return 0, false, err
+			}
+			continue
+		}
+		if err := qtx.MarkTeamEventOutboxAttemptFailed(ctx, dbsqlc.MarkTeamEventOutboxAttemptFailedParams{
+			ID:        row.ID,
+			LastError: lastError,
+		}); err != nil {
+			return 0, false, err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, false, err
+	}
+
+	// The hub is in-memory and cannot roll back, so it only sees committed events.
+	for _, event := range published {
+		s.eventHub.publish(event)
+	}
+	more := len(rows) == outboxClaimBatchSize && !failed
+	return len(published), more, nil
+}
+
+// consumeTeamEventLocked runs every consumer in a savepoint so a failure does
+// not discard the work already done for other events in the batch.
+func (s *Store) consumeTeamEventLocked(ctx context.Context, tx pgx.Tx, event TeamEvent) error {
+	sp, err := tx.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = sp.Rollback(ctx)
+	}()
+	qsp := s.q.WithTx(sp)
+	for _, consume := range s.outboxConsumers {
+		if err := consume(ctx, qsp, event); err != nil {
+			return err
+		}
+	}
+	return sp.Commit(ctx)
+}
+
+func teamEventFromOutbox(row dbsqlc.ClaimPendingTeamEventOutboxRow, loc *time.Location) (TeamEvent, error) {
+	hints := map[string]string{}
+	if len(row.Hints) > 0 {
+		if err := json.Unmarshal(row.Hints, &hints); err != nil {
+			return TeamEvent{}, fmt.Errorf("invalid outbox hints: %w", err)
+		}
+	}
+	if len(hints) == 0 {
+		hints = nil
+	}
+	return TeamEvent{
+		TeamID:    row.TeamID,
+		Entity:    row.Entity,
+		Revision:  row.Revision,
+		ChangedAt: row.ChangedAt.Time.In(loc),
+		Hints:     hints,
+	}, nil
+}
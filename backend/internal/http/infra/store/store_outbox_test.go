@@ -0,0 +1,148 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	dbsqlc "github.com/megu/kaji-challenge/backend/internal/db/sqlc"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func TestTeamEventFromOutboxDecodesHints(t *testing.T) {
+	t.Parallel()
+
+	loc := time.FixedZone("JST", 9*60*60)
+	event, err := teamEventFromOutbox(dbsqlc.ClaimPendingTeamEventOutboxRow{
+		TeamID:    "team-1",
+		Entity:    "task",
+		Revision:  7,
+		Hints:     []byte(`{"action":"create"}`),
+		ChangedAt: toPgTimestamptz(time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)),
+	}, loc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.TeamID != "team-1" || event.Entity != "task" || event.Revision != 7 || event.Hints["action"] != "create" {
+		t.Fatalf("unexpected event: %+v", event)
+	}
+	if event.ChangedAt.Location() != loc {
+		t.Fatalf("expected changedAt in store location, got %s", event.ChangedAt.Location())
+	}
+
+	empty, err := teamEventFromOutbox(dbsqlc.ClaimPendingTeamEventOutboxRow{Hints: []byte(`{}`)}, loc)
+	if err != nil || empty.Hints != nil {
+		t.Fatalf("expected empty hints to decode as nil, got %v err=%v", empty.Hints, err)
+	}
+	if _, err := teamEventFromOutbox(dbsqlc.ClaimPendingTeamEventOutboxRow{Hints: []byte(`not-json`)}, loc); err == nil {
+		t.Fatalf("expected invalid hints to be rejected")
+	}
+}
+
+func TestOutboxDeliversCommittedEventsToHub(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	teamID, userID := createTeamWithMember(t, s, "outbox@example.com", time.Now().In(s.loc).Add(-time.Hour))
+	_, stream, cancel := s.eventHub.subscribe(teamID)
+	defer cancel()
+
+	if _, err := s.CreateTask(withLatestIfMatchForUser(t, s, ctx, userID), userID, api.CreateTaskRequest{
+		Title:         "dishes",
+		Type:          api.Daily,
+		PenaltyPoints: 1,
+	}); err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+	select {
+	case event := <-stream:
+		t.Fatalf("event must not reach the hub before dispatch: %+v", event)
+	default:
+	}
+	if pending := countPendingOutbox(t, s); pending != 1 {
+		t.Fatalf("expected 1 pending outbox event, got %d", pending)
+	}
+
+	dispatched, err := s.DispatchTeamEventOutbox(ctx)
+	if err != nil {
+		t.Fatalf("DispatchTeamEventOutbox failed: %v", err)
+	}
+	if dispatched != 1 {
+		t.Fatalf("expected 1 dispatched event, got %d", dispatched)
+	}
+	select {
+	case event := <-stream:
+		if event.Entity != "task" || event.Hints["action"] != "create" {
+			t.Fatalf("unexpected event: %+v", event)
+		}
+	default:
+		t.Fatalf("expected event on hub after dispatch")
+	}
+	if pending := countPendingOutbox(t, s); pending != 0 {
+		t.Fatalf("expected empty outbox after dispatch, got %d", pending)
+	}
+}
+
+func TestOutboxSkipsRolledBackWrites(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	_, userID := createTeamWithMember(t, s, "outbox-stale@example.com", time.Now().In(s.loc).Add(-time.Hour))
+	task, err := s.CreateTask(withLatestIfMatchForUser(t, s, ctx, userID), userID, api.CreateTaskRequest{Title: "a", Type: api.Daily, PenaltyPoints: 1})
+	if err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+	stale := withLatestIfMatchForUser(t, s, ctx, userID)
+	renamed := "b"
+	if _, err := s.PatchTask(stale, userID, task.Id, api.UpdateTaskRequest{Title: &renamed}); err != nil {
+		t.Fatalf("PatchTask failed: %v", err)
+	}
+	renamed = "c"
+	if _, err := s.PatchTask(stale, userID, task.Id, api.UpdateTaskRequest{Title: &renamed}); err == nil {
+		t.Fatalf("expected stale If-Match to fail")
+	}
+	if pending := countPendingOutbox(t, s); pending != 2 {
+		t.Fatalf("expected only the committed writes in the outbox, got %d", pending)
+	}
+}
+
+func TestOutboxRetriesFailedConsumer(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	_, userID := createTeamWithMember(t, s, "outbox-retry@example.com", time.Now().In(s.loc).Add(-time.Hour))
+	calls := 0
+	s.outboxConsumers = []teamEventConsumer{func(context.Context, *dbsqlc.Queries, TeamEvent) error {
+		calls++
+		if calls == 1 {
+			return errors.New("consumer unavailable")
+		}
+		return nil
+	}}
+	if _, err := s.PatchTeamCurrent(withLatestIfMatchForUser(t, s, ctx, userID), userID, api.UpdateCurrentTeamRequest{Name: "Retry"}); err != nil {
+		t.Fatalf("PatchTeamCurrent failed: %v", err)
+	}
+
+	if dispatched, err := s.DispatchTeamEventOutbox(ctx); err != nil || dispatched != 0 {
+		t.Fatalf("expected failed first attempt, dispatched=%d err=%v", dispatched, err)
+	}
+	if pending := countPendingOutbox(t, s); pending != 1 {
+		t.Fatalf("expected event to stay pending after failure, got %d", pending)
+	}
+	if dispatched, err := s.DispatchTeamEventOutbox(ctx); err != nil || dispatched != 1 {
+		t.Fatalf("expected retry to dispatch, dispatched=%d err=%v", dispatched, err)
+	}
+	if calls != 2 {
+		t.Fatalf("expected consumer to run twice, got %d", calls)
+	}
+}
+
+func countPendingOutbox(t *testing.T, s *Store) int64 {
+	t.Helper()
+	n, err := s.q.CountPendingTeamEventOutbox(context.Background())
+	if err != nil {
+		t.Fatalf("failed to count outbox: %v", err)
+	}
+	return n
+}
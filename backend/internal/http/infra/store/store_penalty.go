@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"strings"
-	"time"
 
 	dbsqlc "github.com/megu/kaji-challenge/backend/internal/db/sqlc"
 	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
@@ -36,17 +35,7 @@ func (s *Store) CreatePenaltyRule(ctx context.Context, userID string, req api.Cr
 	if err != nil {
 		return api.PenaltyRule{}, err
 	}
-	now := time.Now().In(s.loc)
-	r := ruleRecord{
-		ID:          s.nextID("pr"),
-		TeamID:      teamID,
-		Threshold:   req.Threshold,
-		Name:        req.Name,
-		Description: req.Description,
-		CreatedAt:   now,
-		UpdatedAt:   now,
-	}
-	threshold32, err := safeInt32(r.Threshold, "threshold")
+	r, err := s.newRuleRecord(teamID, req)
 	if err != nil {
 		return api.PenaltyRule{}, err
 	}
@@ -55,16 +44,9 @@ func (s *Store) CreatePenaltyRule(ctx context.Context, userID string, req api.Cr
 		teamID,
 		"penalty_rule",
 		map[string]string{"ruleId": r.ID, "action": "create"},
-		func(_ context.Context, qtx *dbsqlc.Queries) error {
-			return qtx.CreatePenaltyRule(ctx, dbsqlc.CreatePenaltyRuleParams{
-				ID:          r.ID,
-				TeamID:      r.TeamID,
-				Threshold:   threshold32,
-				Name:        r.Name,
-				Description: textFromPtr(r.Description),
-				CreatedAt:   toPgTimestamptz(r.CreatedAt),
-				UpdatedAt:   toPgTimestamptz(r.UpdatedAt),
-			})
+		func(txCtx context.Context, qtx *dbsqlc.Queries) error {
+			r.Revision = nextEntityRevision(txCtx)
+			return insertPenaltyRuleLocked(txCtx, qtx, r)
 		},
 	); err != nil {
 		return api.PenaltyRule{}, err
@@ -72,6 +54,43 @@ func (s *Store) CreatePenaltyRule(ctx context.Context, userID string, req api.Cr
 	return r.toAPI(), nil
 }
 
+func (s *Store) newRuleRecord(teamID string, req api.CreatePenaltyRuleRequest) (ruleRecord, error) {
+	if _, err := safeInt32(req.Threshold, "threshold"); err != nil {
+		return ruleRecord{}, err
+	}
+	now := s.now()
+	return ruleRecord{
+		ID:          s.nextID("pr"),
+		TeamID:      teamID,
+		Threshold:   req.Threshold,
+		Name:        req.Name,
+		Description: req.Description,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+func insertPenaltyRuleLocked(ctx context.Context, qtx *dbsqlc.Queries, r ruleRecord) error {
+	threshold32, err := safeInt32(r.Threshold, "threshold")
+	if err != nil {
+		return err
+	}
+	if err := qtx.CreatePenaltyRule(ctx, dbsqlc.CreatePenaltyRuleParams{
+		ID:          r.ID,
+		TeamID:      r.TeamID,
+		Threshold:   threshold32,
+		Name:        r.Name,
+		Description: textFromPtr(r.Description),
+		CreatedAt:   toPgTimestamptz(r.CreatedAt),
+		UpdatedAt:   toPgTimestamptz(r.UpdatedAt),
+		Revision:    r.Revision,
+	}); err != nil {
+		return err
+	}
+	recordAudit(ctx, auditChange{Entity: "penalty_rule", EntityID: r.ID, Action: "create", After: r.toAPI()})
+	return nil
+}
+
 func (s *Store) PatchPenaltyRule(ctx context.Context, userID, ruleID string, req api.UpdatePenaltyRuleRequest) (api.PenaltyRule, error) {
 	teamID, err := s.primaryTeamLocked(ctx, userID)
 	if err != nil {
@@ -83,36 +102,9 @@ func (s *Store) PatchPenaltyRule(ctx context.Context, userID, ruleID string, req
 		teamID,
 		"penalty_rule",
 		map[string]string{"ruleId": ruleID, "action": "update"},
-		func(_ context.Context, qtx *dbsqlc.Queries) error {
-			row, err := qtx.GetUndeletedPenaltyRuleByID(ctx, ruleID)
-			if err != nil {
-				return errors.New("rule not found")
-			}
-			rule = ruleFromDB(row, s.loc)
-			if rule.TeamID != teamID {
-				return errors.New("rule not found")
-			}
-			if req.Threshold != nil {
-				rule.Threshold = *req.Threshold
-			}
-			if req.Name != nil {
-				rule.Name = strings.TrimSpace(*req.Name)
-			}
-			if req.Description != nil {
-				rule.Description = req.Description
-			}
-			rule.UpdatedAt = time.Now().In(s.loc)
-			threshold32, err := safeInt32(rule.Threshold, "threshold")
-			if err != nil {
-				return err
-			}
-			return qtx.UpdatePenaltyRule(ctx, dbsqlc.UpdatePenaltyRuleParams{
-				ID:          rule.ID,
-				Threshold:   threshold32,
-				Name:        rule.Name,
-				Description: textFromPtr(rule.Description),
-				UpdatedAt:   toPgTimestamptz(rule.UpdatedAt),
-			})
+		func(txCtx context.Context, qtx *dbsqlc.Queries) error {
+			rule, err = s.updatePenaltyRuleLocked(txCtx, qtx, teamID, ruleID, req)
+			return err
 		},
 	); err != nil {
 		return api.PenaltyRule{}, err
@@ -120,6 +112,48 @@ func (s *Store) PatchPenaltyRule(ctx context.Context, userID, ruleID string, req
 	return rule.toAPI(), nil
 }
 
+func (s *Store) updatePenaltyRuleLocked(ctx context.Context, qtx *dbsqlc.Queries, teamID, ruleID string, req api.UpdatePenaltyRuleRequest) (ruleRecord, error) {
+	row, err := qtx.GetUndeletedPenaltyRuleByID(ctx, ruleID)
+	if err != nil {
+		return ruleRecord{}, errors.New("rule not found")
+	}
+	rule := ruleFromDB(row, s.loc)
+	if rule.TeamID != teamID {
+		return ruleRecord{}, errors.New("rule not found")
+	}
+	if err := checkEntityIfMatch(ctx, etagKindPenaltyRule, rule.ID, rule.Revision); err != nil {
+		return ruleRecord{}, err
+	}
+	before := rule.toAPI()
+	if req.Threshold != nil {
+		rule.Threshold = *req.Threshold
+	}
+	if req.Name != nil {
+		rule.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Description != nil {
+		rule.Description = req.Description
+	}
+	rule.UpdatedAt = s.now()
+	rule.Revision = nextEntityRevision(ctx)
+	threshold32, err := safeInt32(rule.Threshold, "threshold")
+	if err != nil {
+		return ruleRecord{}, err
+	}
+	if err := qtx.UpdatePenaltyRule(ctx, dbsqlc.UpdatePenaltyRuleParams{
+		ID:          rule.ID,
+		Threshold:   threshold32,
+		Name:        rule.Name,
+		Description: textFromPtr(rule.Description),
+		UpdatedAt:   toPgTimestamptz(rule.UpdatedAt),
+		Revision:    rule.Revision,
+	}); err != nil {
+		return ruleRecord{}, err
+	}
+	recordAudit(ctx, auditChange{Entity: "penalty_rule", EntityID: rule.ID, Action: "update", Before: before, After: rule.toAPI()})
+	return rule, nil
+}
+
 func (s *Store) DeletePenaltyRule(ctx context.Context, userID, ruleID string) error {
 	teamID, err := s.primaryTeamLocked(ctx, userID)
 	if err != nil {
@@ -130,24 +164,33 @@ func (s *Store) DeletePenaltyRule(ctx context.Context, userID, ruleID string) er
 		teamID,
 		"penalty_rule",
 		map[string]string{"ruleId": ruleID, "action": "delete"},
-		func(_ context.Context, qtx *dbsqlc.Queries) error {
-			rule, err := qtx.GetUndeletedPenaltyRuleByID(ctx, ruleID)
-			if err != nil || rule.TeamID != teamID {
-				return errors.New("rule not found")
-			}
-			now := time.Now().In(s.loc)
-			rows, err := qtx.SoftDeletePenaltyRule(ctx, dbsqlc.SoftDeletePenaltyRuleParams{
-				ID:        ruleID,
-				DeletedAt: toPgTimestamptz(now),
-			})
-			if err != nil {
-				return err
-			}
-			if rows == 0 {
-				return errors.New("rule not found")
-			}
-			return nil
+		func(txCtx context.Context, qtx *dbsqlc.Queries) error {
+			return s.deletePenaltyRuleLocked(txCtx, qtx, teamID, ruleID)
 		},
 	)
 	return err
 }
+
+func (s *Store) deletePenaltyRuleLocked(ctx context.Context, qtx *dbsqlc.Queries, teamID, ruleID string) error {
+	rule, err := qtx.GetUndeletedPenaltyRuleByID(ctx, ruleID)
+	if err != nil || rule.TeamID != teamID {
+		return errors.New("rule not found")
+	}
+	if err := checkEntityIfMatch(ctx, etagKindPenaltyRule, rule.ID, rule.Revision); err != nil {
+		return err
+	}
+	now := s.now()
+	rows, err := qtx.SoftDeletePenaltyRule(ctx, dbsqlc.SoftDeletePenaltyRuleParams{
+		ID:        ruleID,
+		DeletedAt: toPgTimestamptz(now),
+		Revision:  nextEntityRevision(ctx),
+	})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errors.New("rule not found")
+	}
+	recordAudit(ctx, auditChange{Entity: "penalty_rule", EntityID: ruleID, Action: "delete", Before: ruleFromDB(rule, s.loc).toAPI()})
+	return nil
+}
@@ -0,0 +1,424 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	dbsqlc "github.com/megu/kaji-challenge/backend/internal/db/sqlc"
+	"github.com/megu/kaji-challenge/backend/internal/http/application/ports"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+// PersonalDataFormat and PersonalDataVersion identify a bundle written for
+// GET /v1/me/export. Bump the version whenever the bundle layout changes.
+const (
+	PersonalDataFormat  = "kaji-challenge.personal-data"
+	PersonalDataVersion = 2
+)
+
+const (
+	personalExportStatusPending = "pending"
+	personalExportStatusReady   = "ready"
+
+	// personalExportInlineLimit is the number of completions a user may have
+	// before the bundle is built by the worker instead of in the request.
+	personalExportInlineLimit  = 2000
+	personalExportTTL          = 7 * 24 * time.Hour
+	personalExportPollInterval = 10 * time.Second
+	personalExportLease        = 5 * time.Minute
+	personalExportClaimBatch   = 5
+	personalExportMaxAttempts  = 5
+	personalExportRetryDelay   = time.Minute
+	personalExportErrorMaxLen  = 500
+)
+
+// personalDataBundle is everything the app holds about one user: the profile,
+// memberships, the completions they performed, the comments they wrote, their
+// sessions, and the team data they can see in the app. Completions performed by other members are
+// left out; they belong to those members' exports.
+type personalDataBundle struct {
+	Format      string                   `json:"format"`
+	Version     int                      `json:"version"`
+	GeneratedAt time.Time                `json:"generatedAt"`
+	Profile     personalDataProfile      `json:"profile"`
+	Memberships []personalDataMembership `json:"memberships"`
+	Completions personalDataCompletions  `json:"completions"`
+	Comments    []personalDataComment    `json:"comments"`
+	Sessions    []personalDataSession    `json:"sessions"`
+	Teams       []personalDataTeam       `json:"teams"`
+}
+
+type personalDataProfile struct {
+	UserID       string     `json:"userId"`
+	Email        string     `json:"email"`
+	DisplayName  string     `json:"displayName"`
+	Nickname     *string    `json:"nickname,omitempty"`
+	ColorHex     *string    `json:"colorHex,omitempty"`
+	OIDCIssuer   *string    `json:"oidcIssuer,omitempty"`
+	OIDCLinkedAt *time.Time `json:"oidcLinkedAt,omitempty"`
+	CreatedAt    time.Time  `json:"createdAt"`
+}
+
+type personalDataMembership struct {
+	TeamID   string    `json:"teamId"`
+	TeamName string    `json:"teamName"`
+	Role     string    `json:"role"`
+	JoinedAt time.Time `json:"joinedAt"`
+}
+
+type personalDataCompletions struct {
+	Daily  []personalDataCompletion `json:"daily"`
+	Weekly []personalDataCompletion `json:"weekly"`
+	OneOff []personalDataCompletion `json:"oneOff"`
+}
+
+// personalDataCompletion carries TargetDate for daily tasks, WeekStart for
+// weekly ones and DueOn for one-off ones.
+type personalDataCompletion struct {
+	TaskID      string    `json:"taskId"`
+	TaskTitle   string    `json:"taskTitle"`
+	TeamID      string    `json:"teamId"`
+	TargetDate  string    `json:"targetDate,omitempty"`
+	WeekStart   string    `json:"weekStart,omitempty"`
+	DueOn       string    `json:"dueOn,omitempty"`
+	CompletedAt time.Time `json:"completedAt"`
+}
+
+type personalDataComment struct {
+	ID        string     `json:"id"`
+	TaskID    string     `json:"taskId"`
+	TaskTitle string     `json:"taskTitle"`
+	TeamID    string     `json:"teamId"`
+	Body      string     `json:"body"`
+	CreatedAt time.Time  `json:"createdAt"`
+	EditedAt  *time.Time `json:"editedAt,omitempty"`
+}
+
+type personalDataSession struct {
+	CreatedAt time.Time  `json:"createdAt"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+}
+
+type personalDataTeam struct {
+	ID               string                  `json:"id"`
+	Name             string                  `json:"name"`
+	Members          []personalDataMember    `json:"members"`
+	Tasks            []ArchiveTask           `json:"tasks"`
+	PenaltyRules     []ArchivePenaltyRule    `json:"penaltyRules"`
+	MonthlySummaries []ArchiveMonthlySummary `json:"monthlySummaries"`
+}
+
+// personalDataMember is a team member as shown in the app, without the email
+// address.
+type personalDataMember struct {
+	UserID      string    `json:"userId"`
+	DisplayName string    `json:"displayName"`
+	Nickname    *string   `json:"nickname,omitempty"`
+	ColorHex    *string   `json:"colorHex,omitempty"`
+	Role        string    `json:"role"`
+	JoinedAt    time.Time `json:"joinedAt"`
+}
+
+// GetMeExport returns the user's personal data bundle. A ready bundle is
+// reused until it expires unless refresh is set. Otherwise small histories are
+// built inline, and larger ones are queued for RunPersonalDataExportWorker;
+// the result then has no payload and the status tells the client to poll.
+func (s *Store) GetMeExport(ctx context.Context, userID string, refresh bool) (ports.PersonalDataExport, error) {
+	if _, err := s.q.GetUserByID(ctx, userID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ports.PersonalDataExport{}, errors.New("user not found")
+		}
+		return ports.PersonalDataExport{}, err
+	}
+	now := s.now()
+	latest, err := s.q.GetLatestPersonalDataExport(ctx, userID)
+	hasLatest := err == nil
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return ports.PersonalDataExport{}, err
+	}
+	if hasLatest && latest.Status == personalExportStatusPending {
+		return ports.PersonalDataExport{Status: s.personalExportToAPI(latest)}, nil
+	}
+	if hasLatest && latest.Status == personalExportStatusReady && !refresh && latest.ExpiresAt.Time.After(now) {
+		payload, err := s.q.GetPersonalDataExportPayload(ctx, latest.ID)
+		if err != nil {
+			return ports.PersonalDataExport{}, err
+		}
+		return ports.PersonalDataExport{Status: s.personalExportToAPI(latest), Payload: payload}, nil
+	}
+
+	completions, err := s.q.CountPersonalCompletions(ctx, userID)
+	if err != nil {
+		return ports.PersonalDataExport{}, err
+	}
+	params := dbsqlc.InsertPersonalDataExportParams{
+		ID:          s.nextID("pde"),
+		UserID:      userID,
+		Status:      personalExportStatusPending,
+		RequestedAt: toPgTimestamptz(now),
+	}
+	var payload []byte
+	if completions <= personalExportInlineLimit {
+		payload, err = s.buildPersonalDataBundle(ctx, userID)
+		if err != nil {
+			return ports.PersonalDataExport{}, err
+		}
+		params.Status = personalExportStatusReady
+		params.Payload = payload
+		params.CompletedAt = toPgTimestamptz(now)
+		params.ExpiresAt = toPgTimestamptz(now.Add(personalExportTTL))
+	}
+	// A concurrent request may have queued an export first; the unique
+	// pending index turns this insert into a no-op and that export is used.
+	if _, err := s.q.InsertPersonalDataExport(ctx, params); err != nil {
+		return ports.PersonalDataExport{}, err
+	}
+	latest, err = s.q.GetLatestPersonalDataExport(ctx, userID)
+	if err != nil {
+		return ports.PersonalDataExport{}, err
+	}
+	if latest.Status != personalExportStatusReady {
+		payload = nil
+	}
+	return ports.PersonalDataExport{Status: s.personalExportToAPI(latest), Payload: payload}, nil
+}
+
+func (s *Store) personalExportToAPI(row dbsqlc.GetLatestPersonalDataExportRow) api.PersonalDataExport {
+	return api.PersonalDataExport{
+		Id:           row.ID,
+		Status:       row.Status,
+		AttemptCount: int(row.AttemptCount),
+		LastError:    ptrFromText(row.LastError),
+		RequestedAt:  row.RequestedAt.Time.In(s.loc),
+		CompletedAt:  ptrFromTimestamptz(row.CompletedAt, s.loc),
+		ExpiresAt:    ptrFromTimestamptz(row.ExpiresAt, s.loc),
+	}
+}
+
+// buildPersonalDataBundle encodes the bundle for one user. Team data comes
+// from ExportTeam, trimmed to what members can see in the app: soft-deleted
+// tasks and rules, close bookkeeping and other members' emails are dropped.
+func (s *Store) buildPersonalDataBundle(ctx context.Context, userID string) ([]byte, error) {
+	profile, err := s.q.GetPersonalDataProfile(ctx, userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errors.New("user not found")
+		}
+		return nil, err
+	}
+	bundle := personalDataBundle{
+		Format:      PersonalDataFormat,
+		Version:     PersonalDataVersion,
+		GeneratedAt: s.now().In(s.loc),
+		Profile: personalDataProfile{
+			UserID:       profile.ID,
+			Email:        profile.Email,
+			DisplayName:  profile.DisplayName,
+			Nickname:     ptrFromText(profile.Nickname),
+			ColorHex:     ptrFromText(profile.ColorHex),
+			OIDCIssuer:   ptrFromText(profile.OidcIssuer),
+			OIDCLinkedAt: ptrFromTimestamptz(profile.OidcLinkedAt, s.loc),
+			CreatedAt:    profile.CreatedAt.Time.In(s.loc),
+		},
+		Memberships: []personalDataMembership{},
+		Completions: personalDataCompletions{Daily: []personalDataCompletion{}, Weekly: []personalDataCompletion{}, OneOff: []personalDataCompletion{}},
+		Comments:    []personalDataComment{},
+		Sessions:    []personalDataSession{},
+		Teams:       []personalDataTeam{},
+	}
+
+	memberships, err := s.q.ListPersonalDataMemberships(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range memberships {
+		bundle.Memberships = append(bundle.Memberships, personalDataMembership{
+			TeamID:   m.TeamID,
+			TeamName: m.TeamName,
+			Role:     m.Role,
+			JoinedAt: m.JoinedAt.Time.In(s.loc),
+		})
+	}
+
+	daily, err := s.q.ListPersonalDailyCompletions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range daily {
+		bundle.Completions.Daily = append(bundle.Completions.Daily, personalDataCompletion{
+			TaskID:      d.TaskID,
+			TaskTitle:   d.TaskTitle,
+			TeamID:      d.TeamID,
+			TargetDate:  d.TargetDate.Time.Format(archiveDateLayout),
+			CompletedAt: d.CreatedAt.Time.In(s.loc),
+		})
+	}
+	weekly, err := s.q.ListPersonalWeeklyCompletions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, w := range weekly {
+		bundle.Completions.Weekly = append(bundle.Completions.Weekly, personalDataCompletion{
+			TaskID:      w.TaskID,
+			TaskTitle:   w.TaskTitle,
+			TeamID:      w.TeamID,
+			WeekStart:   w.WeekStart.Time.Format(archiveDateLayout),
+			CompletedAt: w.CreatedAt.Time.In(s.loc),
+		})
+	}
+	oneOff, err := s.q.ListPersonalOneOffCompletions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range oneOff {
+		bundle.Completions.OneOff = append(bundle.Completions.OneOff, personalDataCompletion{
+			TaskID:      o.TaskID,
+			TaskTitle:   o.TaskTitle,
+			TeamID:      o.TeamID,
+			DueOn:       o.DueOn.Time.Format(archiveDateLayout),
+			CompletedAt: o.CompletedAt.Time.In(s.loc),
+		})
+	}
+
+	comments, err := s.q.ListPersonalTaskComments(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range comments {
+		bundle.Comments = append(bundle.Comments, personalDataComment{
+			ID:        c.ID,
+			TaskID:    c.TaskID,
+			TaskTitle: c.TaskTitle,
+			TeamID:    c.TeamID,
+			Body:      c.Body,
+			CreatedAt: c.CreatedAt.Time.In(s.loc),
+			EditedAt:  ptrFromTimestamptz(c.EditedAt, s.loc),
+		})
+	}
+
+	sessions, err := s.q.ListPersonalSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for _, sess := range sessions {
+		bundle.Sessions = append(bundle.Sessions, personalDataSession{
+			CreatedAt: sess.CreatedAt.Time.In(s.loc),
+			ExpiresAt: ptrFromTimestamptz(sess.ExpiresAt, s.loc),
+		})
+	}
+
+	for _, m := range memberships {
+		archive, err := s.ExportTeam(ctx, m.TeamID)
+		if err != nil {
+			return nil, fmt.Errorf("export team %s: %w", m.TeamID, err)
+		}
+		bundle.Teams = append(bundle.Teams, personalDataTeamFromArchive(archive))
+	}
+	return json.Marshal(bundle)
+}
+
+func personalDataTeamFromArchive(a TeamArchive) personalDataTeam {
+	team := personalDataTeam{
+		ID:               a.Team.ID,
+		Name:             a.Team.Name,
+		Members:          make([]personalDataMember, 0, len(a.Members)),
+		Tasks:            []ArchiveTask{},
+		PenaltyRules:     []ArchivePenaltyRule{},
+		MonthlySummaries: a.MonthlySummaries,
+	}
+	for _, m := range a.Members {
+		team.Members = append(team.Members, personalDataMember{
+			UserID:      m.UserID,
+			DisplayName: m.DisplayName,
+			Nickname:    m.Nickname,
+			ColorHex:    m.ColorHex,
+			Role:        m.Role,
+			JoinedAt:    m.JoinedAt,
+		})
+	}
+	for _, t := range a.Tasks {
+		if t.DeletedAt == nil {
+			team.Tasks = append(team.Tasks, t)
+		}
+	}
+	for _, r := range a.PenaltyRules {
+		if r.DeletedAt == nil {
+			team.PenaltyRules = append(team.PenaltyRules, r)
+		}
+	}
+	return team
+}
+
+// RunPersonalDataExportWorker builds queued personal data exports until ctx is
+// cancelled.
+func (s *Store) RunPersonalDataExportWorker(ctx context.Context) {
+	ticker := time.NewTicker(personalExportPollInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := s.ProcessDuePersonalDataExports(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("personal data export failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessDuePersonalDataExports builds every pending export whose next attempt
+// is due and returns how many were attempted. Claimed rows are leased so a
+// second worker leaves them alone while the bundle is being built.
+func (s *Store) ProcessDuePersonalDataExports(ctx context.Context) (int, error) {
+	attempted := 0
+	for {
+		now := s.now()
+		claimed, err := s.q.ClaimDuePersonalDataExports(ctx, dbsqlc.ClaimDuePersonalDataExportsParams{
+			LeaseUntil: toPgTimestamptz(now.Add(personalExportLease)),
+			DueBefore:  toPgTimestamptz(now),
+			BatchSize:  personalExportClaimBatch,
+		})
+		if err != nil {
+			return attempted, err
+		}
+		for _, e := range claimed {
+			if err := s.processPersonalDataExport(ctx, e); err != nil {
+				return attempted, err
+			}
+			attempted++
+		}
+		if len(claimed) < personalExportClaimBatch {
+			return attempted, nil
+		}
+	}
+}
+
+func (s *Store) processPersonalDataExport(ctx context.Context, e dbsqlc.ClaimDuePersonalDataExportsRow) error {
+	payload, buildErr := s.buildPersonalDataBundle(ctx, e.UserID)
+	now := s.now()
+	if buildErr != nil {
+		attempts := e.AttemptCount + 1
+		reason := buildErr.Error()
+		if len(reason) > personalExportErrorMaxLen {
+			reason = reason[:personalExportErrorMaxLen]
+		}
+		return s.q.RecordPersonalDataExportFailure(ctx, dbsqlc.RecordPersonalDataExportFailureParams{
+			ID:            e.ID,
+			GiveUp:        attempts >= personalExportMaxAttempts,
+			LastError:     pgtype.Text{String: reason, Valid: true},
+			NextAttemptAt: toPgTimestamptz(now.Add(time.Duration(attempts) * personalExportRetryDelay)),
+		})
+	}
+	return s.q.MarkPersonalDataExportReady(ctx, dbsqlc.MarkPersonalDataExportReadyParams{
+		ID:          e.ID,
+		Payload:     payload,
+		CompletedAt: toPgTimestamptz(now),
+		ExpiresAt:   toPgTimestamptz(now.Add(personalExportTTL)),
+	})
+}
@@ -0,0 +1,104 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	dbsqlc "github.com/megu/kaji-challenge/backend/internal/db/sqlc"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func TestGetMeExportBuildsSmallBundleInlineAndReusesIt(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	base := time.Date(2026, 3, 2, 9, 0, 0, 0, s.loc)
+	s.SetClock(FixedClock(base))
+
+	teamID, userID := createTeamWithMember(t, s, "export@example.com", base)
+	taskID := createTaskAtWithID(t, s, teamID, api.Daily, 1, 1, base)
+	if err := s.q.CreateTaskCompletionDaily(ctx, dbsqlc.CreateTaskCompletionDailyParams{
+		TaskID:            taskID,
+		TargetDate:        toPgDate(base),
+		CompletedByUserID: userID,
+	}); err != nil {
+		t.Fatalf("failed to complete task: %v", err)
+	}
+
+	first, err := s.GetMeExport(ctx, userID, false)
+	if err != nil {
+		t.Fatalf("GetMeExport failed: %v", err)
+	}
+	if first.Payload == nil || first.Status.Status != personalExportStatusReady {
+		t.Fatalf("expected a ready bundle, got %+v", first.Status)
+	}
+	var bundle personalDataBundle
+	if err := json.Unmarshal(first.Payload, &bundle); err != nil {
+		t.Fatalf("invalid bundle: %v", err)
+	}
+	if bundle.Format != PersonalDataFormat || bundle.Profile.Email != "export@example.com" {
+		t.Fatalf("unexpected bundle header: %+v", bundle)
+	}
+	if len(bundle.Completions.Daily) != 1 || bundle.Completions.Daily[0].TaskID != taskID || bundle.Completions.Daily[0].TargetDate != "2026-03-02" {
+		t.Fatalf("expected the user's completion, got %+v", bundle.Completions)
+	}
+	if len(bundle.Teams) != 1 || bundle.Teams[0].ID != teamID || len(bundle.Teams[0].Tasks) != 1 {
+		t.Fatalf("expected the team data, got %+v", bundle.Teams)
+	}
+
+	again, err := s.GetMeExport(ctx, userID, false)
+	if err != nil {
+		t.Fatalf("GetMeExport failed: %v", err)
+	}
+	if again.Status.Id != first.Status.Id {
+		t.Fatalf("expected the ready bundle to be reused")
+	}
+	refreshed, err := s.GetMeExport(ctx, userID, true)
+	if err != nil {
+		t.Fatalf("GetMeExport with refresh failed: %v", err)
+	}
+	if refreshed.Status.Id == first.Status.Id || refreshed.Payload == nil {
+		t.Fatalf("expected refresh to build a new bundle, got %+v", refreshed.Status)
+	}
+}
+
+func TestProcessDuePersonalDataExportsBuildsQueuedExport(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	base := time.Date(2026, 3, 2, 9, 0, 0, 0, s.loc)
+	s.SetClock(FixedClock(base))
+
+	_, userID := createTeamWithMember(t, s, "queued@example.com", base)
+	if _, err := s.q.InsertPersonalDataExport(ctx, dbsqlc.InsertPersonalDataExportParams{
+		ID:          s.nextID("pde"),
+		UserID:      userID,
+		Status:      personalExportStatusPending,
+		RequestedAt: toPgTimestamptz(base),
+	}); err != nil {
+		t.Fatalf("failed to queue export: %v", err)
+	}
+
+	pending, err := s.GetMeExport(ctx, userID, true)
+	if err != nil {
+		t.Fatalf("GetMeExport failed: %v", err)
+	}
+	if pending.Payload != nil || pending.Status.Status != personalExportStatusPending {
+		t.Fatalf("expected the queued export to be reported, got %+v", pending.Status)
+	}
+
+	attempted, err := s.ProcessDuePersonalDataExports(ctx)
+	if err != nil {
+		t.Fatalf("ProcessDuePersonalDataExports failed: %v", err)
+	}
+	if attempted != 1 {
+		t.Fatalf("expected 1 attempted export, got %d", attempted)
+	}
+	ready, err := s.GetMeExport(ctx, userID, false)
+	if err != nil {
+		t.Fatalf("GetMeExport failed: %v", err)
+	}
+	if ready.Payload == nil || ready.Status.Id != pending.Status.Id || ready.Status.AttemptCount != 1 {
+		t.Fatalf("expected the worker to finish the queued export, got %+v", ready.Status)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 	dbsqlc "github.com/megu/kaji-challenge/backend/internal/db/sqlc"
 	"github.com/megu/kaji-challenge/backend/internal/http/application"
 )
@@ -26,22 +27,44 @@ func etagFromRevision(teamID string, revision int64) string {
 	return fmt.Sprintf(`W/"team:%s:rev:%d"`, teamID, revision)
 }
 
+// etagFromRevisionOnDay scopes a team ETag to the local day for payloads that
+// change at the day boundary without a revision bump (overview, summaries).
+func etagFromRevisionOnDay(teamID string, revision int64, day time.Time) string {
+	return fmt.Sprintf(`W/"team:%s:rev:%d:day:%s"`, teamID, revision, day.Format("2006-01-02"))
+}
+
 func parseRevisionFromETag(etag string) (int64, error) {
+	parsed, err := parseETag(etag)
+	if err != nil {
+		return 0, err
+	}
+	if parsed.Kind != etagKindTeam {
+		return 0, fmt.Errorf("invalid etag format")
+	}
+	return parsed.Revision, nil
+}
+
+// parseETag accepts team ETags (optionally day scoped) and entity ETags.
+// `*` is refused: it would switch off conflict detection for the write.
+func parseETag(etag string) (parsedETag, error) {
 	normalized := strings.TrimSpace(etag)
 	if normalized == "" {
-		return 0, fmt.Errorf("etag is empty")
+		return parsedETag{}, fmt.Errorf("etag is empty")
 	}
 	normalized = strings.TrimPrefix(normalized, "W/")
 	normalized = strings.Trim(normalized, `"`)
 	parts := strings.Split(normalized, ":")
-	if len(parts) != 4 || parts[0] != "team" || parts[2] != "rev" {
-		return 0, fmt.Errorf("invalid etag format")
+	if len(parts) == 6 && parts[0] == etagKindTeam && parts[4] == "day" {
+		parts = parts[:4]
+	}
+	if len(parts) != 4 || !isETagKind(parts[0]) || parts[1] == "" || parts[2] != "rev" {
+		return parsedETag{}, fmt.Errorf("invalid etag format")
 	}
 	revision, err := strconv.ParseInt(parts[3], 10, 64)
 	if err != nil || revision < 0 {
-		return 0, fmt.Errorf("invalid etag revision")
+		return parsedETag{}, fmt.Errorf("invalid etag revision")
 	}
-	return revision, nil
+	return parsedETag{Kind: parts[0], ID: parts[1], Revision: revision}, nil
 }
 
 func (s *Store) TeamETagForUser(ctx context.Context, userID string) (string, error) {
@@ -56,6 +79,18 @@ func (s *Store) TeamETagForUser(ctx context.Context, userID string) (string, err
 	return etagFromRevision(teamID, revision), nil
 }
 
+func (s *Store) TeamDayETagForUser(ctx context.Context, userID string) (string, error) {
+	teamID, err := s.primaryTeamLocked(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	revision, err := s.q.GetTeamStateRevision(ctx, teamID)
+	if err != nil {
+		return "", err
+	}
+	return etagFromRevisionOnDay(teamID, revision, dateOnly(s.now(), s.loc)), nil
+}
+
 func (s *Store) TeamEventStreamForUser(ctx context.Context, userID string) (string, int64, <-chan TeamEvent, func(), error) {
 	teamID, err := s.primaryTeamLocked(ctx, userID)
 	if err != nil {
@@ -77,19 +112,22 @@ func (s *Store) queries(ctx context.Context) *dbsqlc.Queries {
 	if q, ok := ctx.Value(txQueriesContextKey{}).(*dbsqlc.Queries); ok && q != nil {
 		return q
 	}
+	if conn, ok := ctx.Value(closeConnContextKey{}).(*pgxpool.Conn); ok && conn != nil {
+		return dbsqlc.New(conn)
+	}
 	return s.q
 }
 
-func (s *Store) requireIfMatch(ctx context.Context) (int64, error) {
+func (s *Store) requireIfMatch(ctx context.Context) (parsedETag, error) {
 	raw, _ := ctx.Value(ifMatchContextKey{}).(string)
 	if strings.TrimSpace(raw) == "" {
-		return 0, &application.PreconditionRequiredError{Message: "If-Match header is required"}
+		return parsedETag{}, &application.PreconditionRequiredError{Message: "If-Match header is required"}
 	}
-	expectedRevision, err := parseRevisionFromETag(raw)
+	parsed, err := parseETag(raw)
 	if err != nil {
-		return 0, &application.PreconditionError{Message: "If-Match header is invalid"}
+		return parsedETag{}, &application.PreconditionError{Message: "If-Match header is invalid"}
 	}
-	return expectedRevision, nil
+	return parsed, nil
 }
 
 func (s *Store) verifyIfMatchAgainstTeam(ctx context.Context, teamID string, required bool) error {
@@ -100,7 +138,7 @@ func (s *Store) verifyIfMatchAgainstTeam(ctx context.Context, teamID string, req
 		}
 		return nil
 	}
-	expectedRevision, err := parseRevisionFromETag(raw)
+	ifMatch, err := parseETag(raw)
 	if err != nil {
 		return &application.PreconditionError{Message: "If-Match header is invalid"}
 	}
@@ -108,13 +146,7 @@ func (s *Store) verifyIfMatchAgainstTeam(ctx context.Context, teamID string, req
 	if err != nil {
 		return err
 	}
-	if expectedRevision != currentRevision {
-		return &application.PreconditionError{
-			Message:     "team state changed; refresh and retry",
-			CurrentETag: etagFromRevision(teamID, currentRevision),
-		}
-	}
-	return nil
+	return checkTeamIfMatch(teamID, currentRevision, ifMatch)
 }
 
 func (s *Store) runWithTeamRevisionCAS(
@@ -124,7 +156,7 @@ func (s *Store) runWithTeamRevisionCAS(
 	hints map[string]string,
 	mutateFn func(ctx context.Context, qtx *dbsqlc.Queries) error,
 ) (int64, error) {
-	expectedRevision, err := s.requireIfMatch(ctx)
+	ifMatch, err := s.requireIfMatch(ctx)
 	if err != nil {
 		return 0, err
 	}
@@ -137,7 +169,26 @@ func (s *Store) runWithTeamRevisionCAS(
 		_ = tx.Rollback(ctx)
 	}()
 	qtx := s.q.WithTx(tx)
-	txCtx := withTxQueries(ctx, qtx)
+
+	// Lock the team row so writers are serialised and entity revisions are
+	// stamped with the revision this write commits as.
+	baseRevision, err := qtx.GetTeamStateRevisionForUpdate(ctx, teamID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, errors.New("team not found")
+		}
+		return 0, err
+	}
+	if err := checkTeamIfMatch(teamID, baseRevision, ifMatch); err != nil {
+		return 0, err
+	}
+	audit := &auditRecorder{}
+	txCtx := withAuditRecorder(withRevisionScope(withTxQueries(ctx, qtx), revisionScope{
+		TeamID:   teamID,
+		Base:     baseRevision,
+		IfMatch:  ifMatch,
+		Original: map[string]int64{},
+	}), audit)
 
 	if err := mutateFn(txCtx, qtx); err != nil {
 		if errors.Is(err, errNoStateChange) {
@@ -148,68 +199,63 @@ func (s *Store) runWithTeamRevisionCAS(
 
 	revision, err := qtx.UpdateTeamStateRevisionIfMatch(ctx, dbsqlc.UpdateTeamStateRevisionIfMatchParams{
 		ID:            teamID,
-		StateRevision: expectedRevision,
+		StateRevision: baseRevision,
 	})
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			currentRevision, currentErr := qtx.GetTeamStateRevision(ctx, teamID)
-			if currentErr != nil {
-				return 0, &application.PreconditionError{Message: "team state changed; refresh and retry"}
-			}
-			return 0, &application.PreconditionError{
-				Message:     "team state changed; refresh and retry",
-				CurrentETag: etagFromRevision(teamID, currentRevision),
-			}
-		}
 		return 0, err
 	}
-	if err := tx.Commit(ctx); err != nil {
+	if err := s.writeAuditLocked(ctx, qtx, teamID, revision, audit, fallbackAuditChange(entity, hints)); err != nil {
 		return 0, err
 	}
-
-	// Publish after commit. Event delivery failures must not break writes.
-	if revision > 0 {
-		s.eventHub.publish(TeamEvent{
-			TeamID:    teamID,
-			Entity:    entity,
-			Revision:  revision,
-			ChangedAt: time.Now().In(s.loc),
-			Hints:     hints,
-		})
+	// The event is written in the same transaction so it cannot be lost
+	// between commit and publish; the outbox dispatcher delivers it.
+	if err := s.enqueueTeamEventLocked(ctx, qtx, TeamEvent{
+		TeamID:    teamID,
+		Entity:    entity,
+		Revision:  revision,
+		ChangedAt: s.now(),
+		Hints:     hints,
+	}); err != nil {
+		return 0, err
 	}
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+	s.notifyOutbox()
 	return revision, nil
 }
 
-func (s *Store) bumpTeamRevisionBestEffort(ctx context.Context, teamID, entity string, hints map[string]string) (int64, error) {
-	var lastErr error
-	for i := 0; i < 3; i++ {
-		currentRevision, err := s.q.GetTeamStateRevision(ctx, teamID)
-		if err != nil {
-			if errors.Is(err, pgx.ErrNoRows) {
-				return 0, nil
-			}
-			lastErr = err
-			continue
-		}
-		revision, err := s.q.UpdateTeamStateRevisionIfMatch(ctx, dbsqlc.UpdateTeamStateRevisionIfMatchParams{
-			ID:            teamID,
-			StateRevision: currentRevision,
-		})
-		if err != nil {
-			lastErr = err
-			continue
+// bumpTeamRevisionLocked advances the team revision inside the caller's
+// transaction for writes made without If-Match, such as closes and
+// membership changes. The event is enqueued in the same transaction, so it
+// commits or rolls back with the change; call notifyOutbox after commit. The
+// changes on audit are logged under the new revision.
+func (s *Store) bumpTeamRevisionLocked(ctx context.Context, qtx *dbsqlc.Queries, teamID, entity string, hints map[string]string, audit *auditRecorder) (int64, error) {
+	currentRevision, err := qtx.GetTeamStateRevisionForUpdate(ctx, teamID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, errors.New("team not found")
 		}
-		s.eventHub.publish(TeamEvent{
-			TeamID:    teamID,
-			Entity:    entity,
-			Revision:  revision,
-			ChangedAt: time.Now().In(s.loc),
-			Hints:     hints,
-		})
-		return revision, nil
-	}
-	if lastErr != nil {
-		return 0, lastErr
-	}
-	return 0, nil
+		return 0, err
+	}
+	revision, err := qtx.UpdateTeamStateRevisionIfMatch(ctx, dbsqlc.UpdateTeamStateRevisionIfMatchParams{
+		ID:            teamID,
+		StateRevision: currentRevision,
+	})
+	if err != nil {
+		return 0, err
+	}
+	if err := s.writeAuditLocked(ctx, qtx, teamID, revision, audit, fallbackAuditChange(entity, hints)); err != nil {
+		return 0, err
+	}
+	if err := s.enqueueTeamEventLocked(ctx, qtx, TeamEvent{
+		TeamID:    teamID,
+		Entity:    entity,
+		Revision:  revision,
+		ChangedAt: s.now(),
+		Hints:     hints,
+	}); err != nil {
+		return 0, err
+	}
+	return revision, nil
 }
@@ -0,0 +1,90 @@
+package store
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRevisionFromETag(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name    string
+		etag    string
+		want    int64
+		wantErr bool
+	}{
+		{name: "team etag", etag: etagFromRevision("team-1", 12), want: 12},
+		{name: "day scoped etag", etag: etagFromRevisionOnDay("team-1", 7, day), want: 7},
+		{name: "strong form", etag: `"team:team-1:rev:3"`, want: 3},
+		{name: "unknown suffix", etag: `W/"team:team-1:rev:3:month:2026-03"`, wantErr: true},
+		{name: "negative revision", etag: `W/"team:team-1:rev:-1"`, wantErr: true},
+		{name: "empty", etag: " ", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseRevisionFromETag(tt.etag)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error for %q, got revision %d", tt.etag, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("expected revision %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseETagAcceptsEntityForms(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		etag    string
+		want    parsedETag
+		wantErr bool
+	}{
+		{name: "any", etag: "*", wantErr: true},
+		{name: "team", etag: etagFromRevision("team-1", 4), want: parsedETag{Kind: etagKindTeam, ID: "team-1", Revision: 4}},
+		{name: "task", etag: entityETag(etagKindTask, "task-1", 9), want: parsedETag{Kind: etagKindTask, ID: "task-1", Revision: 9}},
+		{name: "penalty rule", etag: entityETag(etagKindPenaltyRule, "rule-1", 2), want: parsedETag{Kind: etagKindPenaltyRule, ID: "rule-1", Revision: 2}},
+		{name: "team settings", etag: entityETag(etagKindTeamSettings, "team-1", 0), want: parsedETag{Kind: etagKindTeamSettings, ID: "team-1"}},
+		{name: "task category", etag: entityETag(etagKindTaskCategory, "cat-1", 5), want: parsedETag{Kind: etagKindTaskCategory, ID: "cat-1", Revision: 5}},
+		{name: "absence", etag: entityETag(etagKindAbsence, "abs-1", 3), want: parsedETag{Kind: etagKindAbsence, ID: "abs-1", Revision: 3}},
+		{name: "holiday", etag: entityETag(etagKindHoliday, "2026-03-14", 6), want: parsedETag{Kind: etagKindHoliday, ID: "2026-03-14", Revision: 6}},
+		{name: "task comment", etag: entityETag(etagKindTaskComment, "comment-1", 1), want: parsedETag{Kind: etagKindTaskComment, ID: "comment-1", Revision: 1}},
+		{name: "unknown kind", etag: `W/"member:u-1:rev:1"`, wantErr: true},
+		{name: "day scoped entity", etag: `W/"task:task-1:rev:1:day:2026-03-14"`, wantErr: true},
+		{name: "missing id", etag: `W/"task::rev:1"`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := parseETag(tt.etag)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error for %q, got %+v", tt.etag, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Fatalf("expected %+v, got %+v", tt.want, got)
+			}
+		})
+	}
+	if _, err := parseRevisionFromETag(entityETag(etagKindTask, "task-1", 1)); err == nil {
+		t.Fatalf("expected parseRevisionFromETag to reject entity ETags")
+	}
+}
@@ -0,0 +1,124 @@
+package store
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// taskWindow is the optional startsOn/endsOn range, both inclusive, in which
+// a recurring task is active. A nil bound leaves that side open, so the zero
+// value is always active.
+type taskWindow struct {
+	StartsOn *time.Time
+	EndsOn   *time.Time
+}
+
+func taskWindowFromPg(startsOn, endsOn pgtype.Date, loc *time.Location) taskWindow {
+	return taskWindow{StartsOn: ptrFromPgDate(startsOn, loc), EndsOn: ptrFromPgDate(endsOn, loc)}
+}
+
+func (w taskWindow) isZero() bool {
+	return w.StartsOn == nil && w.EndsOn == nil
+}
+
+func (w taskWindow) activeOn(day time.Time) bool {
+	if w.StartsOn != nil && day.Before(*w.StartsOn) {
+		return false
+	}
+	if w.EndsOn != nil && day.After(*w.EndsOn) {
+		return false
+	}
+	return true
+}
+
+// overlapsWeek reports whether the task is active on at least one day of the
+// week starting at weekStart.
+func (w taskWindow) overlapsWeek(weekStart time.Time) bool {
+	if w.StartsOn != nil && w.StartsOn.After(weekStart.AddDate(0, 0, 6)) {
+		return false
+	}
+	if w.EndsOn != nil && w.EndsOn.Before(weekStart) {
+		return false
+	}
+	return true
+}
+
+// newTaskWindow validates the window requested for a new task.
+func (s *Store) newTaskWindow(taskType api.TaskType, startsOn, endsOn *openapi_types.Date, today time.Time) (taskWindow, error) {
+	w := taskWindow{StartsOn: s.dateFromAPI(startsOn), EndsOn: s.dateFromAPI(endsOn)}
+	if w.StartsOn != nil && w.StartsOn.Before(today) {
+		return taskWindow{}, errors.New("startsOn must not be in the past")
+	}
+	if w.EndsOn != nil && w.EndsOn.Before(today) {
+		return taskWindow{}, errors.New("endsOn must not be in the past")
+	}
+	return w, validateTaskWindow(taskType, w)
+}
+
+// updateTaskWindow applies a patch to the task's window. Bounds that already
+// lie in the past are frozen so that closed days keep the window they were
+// evaluated with.
+func (s *Store) updateTaskWindow(task taskRecord, req api.UpdateTaskRequest, today time.Time) (taskWindow, error) {
+	current := task.Window
+	next := current
+	if req.StartsOn != nil {
+		next.StartsOn = s.dateFromAPI(req.StartsOn)
+	} else if req.ClearStartsOn != nil && *req.ClearStartsOn {
+		next.StartsOn = nil
+	}
+	if req.EndsOn != nil {
+		next.EndsOn = s.dateFromAPI(req.EndsOn)
+	} else if req.ClearEndsOn != nil && *req.ClearEndsOn {
+		next.EndsOn = nil
+	}
+
+	if !sameDatePtr(next.StartsOn, current.StartsOn) {
+		if current.StartsOn != nil && current.StartsOn.Before(today) {
+			return taskWindow{}, errors.New("startsOn cannot be changed after the task has started")
+		}
+		if next.StartsOn != nil && next.StartsOn.Before(today) {
+			return taskWindow{}, errors.New("startsOn must not be in the past")
+		}
+	}
+	if !sameDatePtr(next.EndsOn, current.EndsOn) {
+		if current.EndsOn != nil && current.EndsOn.Before(today) {
+			return taskWindow{}, errors.New("endsOn cannot be changed after the task has ended")
+		}
+		if next.EndsOn != nil && next.EndsOn.Before(today) {
+			return taskWindow{}, errors.New("endsOn must not be in the past")
+		}
+	}
+	return next, validateTaskWindow(task.Type, next)
+}
+
+func validateTaskWindow(taskType api.TaskType, w taskWindow) error {
+	if w.isZero() {
+		return nil
+	}
+	if taskType == api.OneOff {
+		return errors.New("startsOn and endsOn are not supported for one_off tasks")
+	}
+	if w.StartsOn != nil && w.EndsOn != nil && w.EndsOn.Before(*w.StartsOn) {
+		return errors.New("endsOn must not be before startsOn")
+	}
+	return nil
+}
+
+func (s *Store) dateFromAPI(d *openapi_types.Date) *time.Time {
+	if d == nil {
+		return nil
+	}
+	day := dateOnly(d.Time, s.loc)
+	return &day
+}
+
+func sameDatePtr(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return sameDate(*a, *b)
+}
@@ -0,0 +1,120 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dbsqlc "github.com/megu/kaji-challenge/backend/internal/db/sqlc"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func TestTaskWindowActiveOnAndOverlapsWeek(t *testing.T) {
+	weekStart := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	startsOn := weekStart.AddDate(0, 0, 3)
+	endsOn := weekStart.AddDate(0, 0, 9)
+	w := taskWindow{StartsOn: &startsOn, EndsOn: &endsOn}
+
+	if w.activeOn(weekStart.AddDate(0, 0, 2)) || !w.activeOn(startsOn) || !w.activeOn(endsOn) || w.activeOn(endsOn.AddDate(0, 0, 1)) {
+		t.Fatalf("expected both bounds to be inclusive")
+	}
+	if !w.overlapsWeek(weekStart) || !w.overlapsWeek(weekStart.AddDate(0, 0, 7)) || w.overlapsWeek(weekStart.AddDate(0, 0, 14)) {
+		t.Fatalf("expected the window to overlap exactly two weeks")
+	}
+	if got := (absenceCalendar{}).pausedDaysInTaskWeek(weekStart, "", w); got != 3 {
+		t.Fatalf("expected the 3 days before startsOn to be paused, got %d", got)
+	}
+	if !(taskWindow{}).activeOn(weekStart) {
+		t.Fatalf("expected the zero window to always be active")
+	}
+}
+
+func TestUpdateTaskWindowFreezesPastBounds(t *testing.T) {
+	s := &Store{loc: time.UTC}
+	today := time.Date(2026, 6, 10, 0, 0, 0, 0, time.UTC)
+	started := today.AddDate(0, 0, -5)
+	later := today.AddDate(0, 0, 30)
+	clearStart := true
+	task := taskRecord{Type: api.Weekly, Window: taskWindow{StartsOn: &started}}
+
+	if _, err := s.updateTaskWindow(task, api.UpdateTaskRequest{ClearStartsOn: &clearStart}, today); err == nil || err.Error() != "startsOn cannot be changed after the task has started" {
+		t.Fatalf("expected a past startsOn to be frozen, got %v", err)
+	}
+	endsOn := toDate(later)
+	w, err := s.updateTaskWindow(task, api.UpdateTaskRequest{EndsOn: &endsOn}, today)
+	if err != nil || !sameDatePtr(w.StartsOn, &started) || !sameDatePtr(w.EndsOn, &later) {
+		t.Fatalf("expected endsOn to be added next to the frozen startsOn, got %+v, %v", w, err)
+	}
+	past := toDate(today.AddDate(0, 0, -1))
+	if _, err := s.updateTaskWindow(task, api.UpdateTaskRequest{EndsOn: &past}, today); err == nil || err.Error() != "endsOn must not be in the past" {
+		t.Fatalf("expected a past endsOn to be rejected, got %v", err)
+	}
+	beforeStart := toDate(today)
+	future := taskRecord{Type: api.Daily, Window: taskWindow{StartsOn: &later}}
+	if _, err := s.updateTaskWindow(future, api.UpdateTaskRequest{EndsOn: &beforeStart}, today); err == nil || err.Error() != "endsOn must not be before startsOn" {
+		t.Fatalf("expected an inverted window to be rejected, got %v", err)
+	}
+	if _, err := s.newTaskWindow(api.OneOff, nil, &endsOn, today); err == nil || err.Error() != "startsOn and endsOn are not supported for one_off tasks" {
+		t.Fatalf("expected one_off tasks to reject a window, got %v", err)
+	}
+}
+
+func TestCloseAndMonthlyStatusHonourTaskWindow(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	weekStart := time.Date(2026, 6, 1, 0, 0, 0, 0, s.loc)
+	s.SetClock(FixedClock(weekStart.Add(-24 * time.Hour)))
+
+	teamID, userID := createTeamWithMember(t, s, "window@example.com", weekStart.AddDate(0, 0, -2))
+	startsOn := toDate(weekStart.AddDate(0, 0, 2))
+	endsOn := toDate(weekStart.AddDate(0, 0, 3))
+	daily, err := s.CreateTask(withLatestIfMatchForUser(t, s, ctx, userID), userID, api.CreateTaskRequest{
+		Title: "Water the garden", Type: api.Daily, PenaltyPoints: 2, StartsOn: &startsOn, EndsOn: &endsOn,
+	})
+	if err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+	required := 4
+	weekly, err := s.CreateTask(withLatestIfMatchForUser(t, s, ctx, userID), userID, api.CreateTaskRequest{
+		Title: "Mow the lawn", Type: api.Weekly, PenaltyPoints: 5, RequiredCompletionsPerWeek: &required, StartsOn: &startsOn,
+	})
+	if err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+
+	for i := 0; i < 7; i++ {
+		if _, err := s.closeDayForTargetLocked(ctx, weekStart.AddDate(0, 0, i), teamID); err != nil {
+			t.Fatalf("closeDayForTargetLocked failed: %v", err)
+		}
+	}
+	for i := 0; i < 3; i++ {
+		if err := s.q.InsertTaskCompletionWeeklyEntry(ctx, dbsqlc.InsertTaskCompletionWeeklyEntryParams{
+			ID:                s.nextID("tcw"),
+			TaskID:            weekly.Id,
+			WeekStart:         toPgDate(weekStart),
+			CompletedByUserID: userID,
+		}); err != nil {
+			t.Fatalf("failed to complete weekly task: %v", err)
+		}
+	}
+	if _, err := s.closeWeekForTargetLocked(ctx, weekStart, teamID); err != nil {
+		t.Fatalf("closeWeekForTargetLocked failed: %v", err)
+	}
+
+	june := getMonthSummary(t, s, teamID, "2026-06")
+	if june.DailyPenaltyTotal != 4 {
+		t.Fatalf("expected only the 2 days inside the window to be penalised, got daily total=%d", june.DailyPenaltyTotal)
+	}
+	if june.WeeklyPenaltyTotal != 0 {
+		t.Fatalf("expected 3 completions to meet the requirement prorated to 5 days, got weekly total=%d", june.WeeklyPenaltyTotal)
+	}
+
+	groups, err := s.buildMonthlyTaskStatusByDate(ctx, teamID, "2026-06")
+	if err != nil {
+		t.Fatalf("buildMonthlyTaskStatusByDate failed: %v", err)
+	}
+	if containsTaskOnDate(groups, "2026-06-02", daily.Id) || !containsTaskOnDate(groups, "2026-06-03", daily.Id) ||
+		!containsTaskOnDate(groups, "2026-06-04", daily.Id) || containsTaskOnDate(groups, "2026-06-05", daily.Id) {
+		t.Fatalf("expected the daily task only on the days inside its window")
+	}
+}
@@ -0,0 +1,331 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	dbsqlc "github.com/megu/kaji-challenge/backend/internal/db/sqlc"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+const (
+	taskCategoryNameMaxLength = 30
+	taskCategoryIconMaxLength = 32
+	// taskCategoryFilterNone selects uncategorised tasks in the categoryId
+	// query filters.
+	taskCategoryFilterNone = "none"
+)
+
+type taskCategory struct {
+	ID        string
+	TeamID    string
+	Name      string
+	ColorHex  *string
+	Icon      *string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Revision  int64
+}
+
+func taskCategoryFromRow(row dbsqlc.TaskCategory, loc *time.Location) taskCategory {
+	return taskCategory{
+		ID:        row.ID,
+		TeamID:    row.TeamID,
+		Name:      row.Name,
+		ColorHex:  ptrFromText(row.ColorHex),
+		Icon:      ptrFromText(row.Icon),
+		CreatedAt: row.CreatedAt.Time.In(loc),
+		UpdatedAt: row.UpdatedAt.Time.In(loc),
+		Revision:  row.Revision,
+	}
+}
+
+func (c taskCategory) toAPI() api.TaskCategory {
+	etag := entityETag(etagKindTaskCategory, c.ID, c.Revision)
+	return api.TaskCategory{
+		Id:        c.ID,
+		TeamId:    c.TeamID,
+		Name:      c.Name,
+		ColorHex:  c.ColorHex,
+		Icon:      c.Icon,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+		Etag:      &etag,
+	}
+}
+
+func (s *Store) ListTaskCategories(ctx context.Context, userID string) ([]api.TaskCategory, error) {
+	teamID, err := s.primaryTeamLocked(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.q.ListTaskCategoriesByTeamID(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]api.TaskCategory, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, taskCategoryFromRow(row, s.loc).toAPI())
+	}
+	return items, nil
+}
+
+func (s *Store) CreateTaskCategory(ctx context.Context, userID string, req api.CreateTaskCategoryRequest) (api.TaskCategory, error) {
+	teamID, err := s.primaryTeamLocked(ctx, userID)
+	if err != nil {
+		return api.TaskCategory{}, err
+	}
+	now := s.now()
+	category := taskCategory{ID: s.nextID("cat"), TeamID: teamID, CreatedAt: now, UpdatedAt: now}
+	if err := category.apply(req.Name, req.ColorHex, req.Icon); err != nil {
+		return api.TaskCategory{}, err
+	}
+	if _, err := s.runWithTeamRevisionCAS(
+		ctx,
+		teamID,
+		"task_category",
+		map[string]string{"categoryId": category.ID, "action": "create"},
+		func(txCtx context.Context, qtx *dbsqlc.Queries) error {
+			category.Revision = nextEntityRevision(txCtx)
+			return qtx.CreateTaskCategory(txCtx, dbsqlc.CreateTaskCategoryParams{
+				ID:        category.ID,
+				TeamID:    category.TeamID,
+				Name:      category.Name,
+				ColorHex:  textFromPtr(category.ColorHex),
+				Icon:      textFromPtr(category.Icon),
+				CreatedAt: toPgTimestamptz(category.CreatedAt),
+				UpdatedAt: toPgTimestamptz(category.UpdatedAt),
+				Revision:  category.Revision,
+			})
+		},
+	); err != nil {
+		return api.TaskCategory{}, err
+	}
+	return category.toAPI(), nil
+}
+
+func (s *Store) PatchTaskCategory(ctx context.Context, userID, categoryID string, req api.UpdateTaskCategoryRequest) (api.TaskCategory, error) {
+	teamID, err := s.primaryTeamLocked(ctx, userID)
+	if err != nil {
+		return api.TaskCategory{}, err
+	}
+	var category taskCategory
+	if _, err := s.runWithTeamRevisionCAS(
+		ctx,
+		teamID,
+		"task_category",
+		map[string]string{"categoryId": categoryID, "action": "update"},
+		func(txCtx context.Context, qtx *dbsqlc.Queries) error {
+			category, err = s.getTeamTaskCategory(txCtx, qtx, teamID, categoryID)
+			if err != nil {
+				return err
+			}
+			if err := checkEntityIfMatch(txCtx, etagKindTaskCategory, category.ID, category.Revision); err != nil {
+				return err
+			}
+			name := category.Name
+			if req.Name != nil {
+				name = *req.Name
+			}
+			colorHex := category.ColorHex
+			if req.ColorHex != nil {
+				colorHex = req.ColorHex
+			}
+			icon := category.Icon
+			if req.Icon != nil {
+				icon = req.Icon
+			}
+			if err := category.apply(name, colorHex, icon); err != nil {
+				return err
+			}
+			category.UpdatedAt = s.now()
+			category.Revision = nextEntityRevision(txCtx)
+			return qtx.UpdateTaskCategory(txCtx, dbsqlc.UpdateTaskCategoryParams{
+				ID:        category.ID,
+				Name:      category.Name,
+				ColorHex:  textFromPtr(category.ColorHex),
+				Icon:      textFromPtr(category.Icon),
+				UpdatedAt: toPgTimestamptz(category.UpdatedAt),
+				Revision:  category.Revision,
+			})
+		},
+	); err != nil {
+		return api.TaskCategory{}, err
+	}
+	return category.toAPI(), nil
+}
+
+// DeleteTaskCategory removes the category and leaves its tasks uncategorised.
+// The tasks' revisions move so cached task ETags stop matching.
+func (s *Store) DeleteTaskCategory(ctx context.Context, userID, categoryID string) error {
+	teamID, err := s.primaryTeamLocked(ctx, userID)
+	if err != nil {
+		return err
+	}
+	_, err = s.runWithTeamRevisionCAS(
+		ctx,
+		teamID,
+		"task_category",
+		map[string]string{"categoryId": categoryID, "action": "delete"},
+		func(txCtx context.Context, qtx *dbsqlc.Queries) error {
+			category, err := s.getTeamTaskCategory(txCtx, qtx, teamID, categoryID)
+			if err != nil {
+				return err
+			}
+			if err := checkEntityIfMatch(txCtx, etagKindTaskCategory, category.ID, category.Revision); err != nil {
+				return err
+			}
+			if err := qtx.ClearTaskCategoryFromTasks(txCtx, dbsqlc.ClearTaskCategoryFromTasksParams{
+				CategoryID: categoryID,
+				Revision:   nextEntityRevision(txCtx),
+			}); err != nil {
+				return err
+			}
+			_, err = qtx.DeleteTaskCategory(txCtx, dbsqlc.DeleteTaskCategoryParams{ID: categoryID, TeamID: teamID})
+			return err
+		},
+	)
+	return err
+}
+
+func (s *Store) getTeamTaskCategory(ctx context.Context, qtx *dbsqlc.Queries, teamID, categoryID string) (taskCategory, error) {
+	row, err := qtx.GetTaskCategoryByID(ctx, categoryID)
+	if err != nil || row.TeamID != teamID {
+		return taskCategory{}, errors.New("category not found")
+	}
+	return taskCategoryFromRow(row, s.loc), nil
+}
+
+// apply validates and sets the editable fields.
+func (c *taskCategory) apply(name string, colorHex, icon *string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return errors.New("category name is required")
+	}
+	if utf8.RuneCountInString(name) > taskCategoryNameMaxLength {
+		return fmt.Errorf("invalid category: name must be %d characters or fewer", taskCategoryNameMaxLength)
+	}
+	color, err := normalizeColorHex(colorHex)
+	if err != nil {
+		return err
+	}
+	var iconValue *string
+	if icon != nil {
+		trimmed := strings.TrimSpace(*icon)
+		if utf8.RuneCountInString(trimmed) > taskCategoryIconMaxLength {
+			return fmt.Errorf("invalid category: icon must be %d characters or fewer", taskCategoryIconMaxLength)
+		}
+		if trimmed != "" {
+			iconValue = &trimmed
+		}
+	}
+	c.Name = name
+	c.ColorHex = nil
+	if color != "" {
+		c.ColorHex = &color
+	}
+	c.Icon = iconValue
+	return nil
+}
+
+// checkTaskCategoryLocked ensures a task's category belongs to its team.
+func checkTaskCategoryLocked(ctx context.Context, qtx *dbsqlc.Queries, teamID string, categoryID *string) error {
+	if categoryID == nil {
+		return nil
+	}
+	row, err := qtx.GetTaskCategoryByID(ctx, *categoryID)
+	if err != nil || row.TeamID != teamID {
+		return errors.New("invalid categoryId: no such category in the team")
+	}
+	return nil
+}
+
+// matchesCategoryFilter applies a categoryId query filter, where
+// taskCategoryFilterNone selects uncategorised tasks.
+func matchesCategoryFilter(categoryID, filter *string) bool {
+	if filter == nil || *filter == "" {
+		return true
+	}
+	if *filter == taskCategoryFilterNone {
+		return categoryID == nil
+	}
+	return categoryID != nil && *categoryID == *filter
+}
+
+// buildMonthlyCategoryStats totals the month's penalties and completions per
+// category, ordered like ListTaskCategories with the uncategorised entry last.
+// Penalties are counted at the points and category charged at close, so a
+// category deleted since still gets an entry under the name it had then,
+// after the live categories.
+func (s *Store) buildMonthlyCategoryStats(ctx context.Context, teamID string, monthStart time.Time) ([]api.MonthlyCategoryStat, error) {
+	monthEnd := monthStart.AddDate(0, 1, 0)
+	categories, err := s.q.ListTaskCategoriesByTeamID(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	penalties, err := s.q.ListMonthlyPenaltiesByCategory(ctx, dbsqlc.ListMonthlyPenaltiesByCategoryParams{
+		TeamID:     teamID,
+		MonthStart: toPgDate(monthStart),
+		MonthEnd:   toPgDate(monthEnd),
+	})
+	if err != nil {
+		return nil, err
+	}
+	completions, err := s.q.ListMonthlyCompletionsByCategory(ctx, dbsqlc.ListMonthlyCompletionsByCategoryParams{
+		TeamID:     teamID,
+		MonthStart: toPgDate(monthStart),
+		MonthEnd:   toPgDate(monthEnd),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stats := map[string]*api.MonthlyCategoryStat{}
+	statFor := func(categoryID string) *api.MonthlyCategoryStat {
+		if stats[categoryID] == nil {
+			stats[categoryID] = &api.MonthlyCategoryStat{}
+		}
+		return stats[categoryID]
+	}
+	for _, row := range penalties {
+		stat := statFor(uuidStringFromPtr(ptrFromAny(row.CategoryID)))
+		stat.PenaltyTotal = int(row.PenaltyTotal)
+		stat.MissedCount = int(row.MissedCount)
+	}
+	for _, row := range completions {
+		statFor(uuidStringFromPtr(ptrFromAny(row.CategoryID))).CompletedCount = int(row.CompletedCount)
+	}
+
+	items := make([]api.MonthlyCategoryStat, 0, len(categories)+1)
+	live := map[string]bool{}
+	for _, category := range categories {
+		live[category.ID] = true
+		stat := api.MonthlyCategoryStat{}
+		if found := stats[category.ID]; found != nil {
+			stat = *found
+		}
+		id, name := category.ID, category.Name
+		stat.CategoryId = &id
+		stat.Name = &name
+		items = append(items, stat)
+	}
+	for _, row := range penalties {
+		id := uuidStringFromPtr(ptrFromAny(row.CategoryID))
+		if id == "" || live[id] {
+			continue
+		}
+		stat := *stats[id]
+		name := row.CategoryName
+		stat.CategoryId = &id
+		stat.Name = &name
+		items = append(items, stat)
+	}
+	if uncategorised := stats[""]; uncategorised != nil {
+		items = append(items, *uncategorised)
+	}
+	return items, nil
+}
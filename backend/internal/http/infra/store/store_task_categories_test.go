@@ -0,0 +1,187 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/megu/kaji-challenge/backend/internal/http/application"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func TestMatchesCategoryFilter(t *testing.T) {
+	kitchen, bath, none, empty := "cat-kitchen", "cat-bath", taskCategoryFilterNone, ""
+	cases := []struct {
+		name     string
+		category *string
+		filter   *string
+		want     bool
+	}{
+		{name: "no filter", category: &kitchen, want: true},
+		{name: "empty filter", category: nil, filter: &empty, want: true},
+		{name: "same category", category: &kitchen, filter: &kitchen, want: true},
+		{name: "other category", category: &kitchen, filter: &bath, want: false},
+		{name: "uncategorised against a category", category: nil, filter: &kitchen, want: false},
+		{name: "none selects uncategorised", category: nil, filter: &none, want: true},
+		{name: "none skips categorised", category: &kitchen, filter: &none, want: false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := matchesCategoryFilter(tc.category, tc.filter); got != tc.want {
+				t.Fatalf("expected %t, got %t", tc.want, got)
+			}
+		})
+	}
+}
+
+func TestTaskCategoryFiltersStatsAndDelete(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	today := time.Date(2026, 3, 2, 0, 0, 0, 0, s.loc)
+	s.SetClock(FixedClock(today.Add(9 * time.Hour)))
+
+	teamID, userID := createTeamWithMember(t, s, "category@example.com", today.AddDate(0, 0, -1))
+	kitchen, err := s.CreateTaskCategory(withLatestIfMatchForUser(t, s, ctx, userID), userID, api.CreateTaskCategoryRequest{Name: "Kitchen"})
+	if err != nil {
+		t.Fatalf("CreateTaskCategory failed: %v", err)
+	}
+	if _, err := s.CreateTaskCategory(withLatestIfMatchForUser(t, s, ctx, userID), userID, api.CreateTaskCategoryRequest{Name: " kitchen "}); err == nil {
+		t.Fatalf("expected a case-insensitive duplicate name to be rejected")
+	}
+	bath, err := s.CreateTaskCategory(withLatestIfMatchForUser(t, s, ctx, userID), userID, api.CreateTaskCategoryRequest{Name: "Bathroom"})
+	if err != nil {
+		t.Fatalf("CreateTaskCategory failed: %v", err)
+	}
+
+	dishes, err := s.CreateTask(withLatestIfMatchForUser(t, s, ctx, userID), userID, api.CreateTaskRequest{
+		Title: "Dishes", Type: api.Daily, PenaltyPoints: 3, CategoryId: &kitchen.Id,
+	})
+	if err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+	if _, err := s.CreateTask(withLatestIfMatchForUser(t, s, ctx, userID), userID, api.CreateTaskRequest{
+		Title: "Trash", Type: api.Daily, PenaltyPoints: 1,
+	}); err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+	foreign := s.nextID("cat")
+	if _, err := s.CreateTask(withLatestIfMatchForUser(t, s, ctx, userID), userID, api.CreateTaskRequest{
+		Title: "Nowhere", Type: api.Daily, PenaltyPoints: 1, CategoryId: &foreign,
+	}); err == nil || err.Error() != "invalid categoryId: no such category in the team" {
+		t.Fatalf("expected an unknown category to be rejected, got %v", err)
+	}
+
+	tasks, err := s.ListTasks(ctx, userID, nil, &kitchen.Id)
+	if err != nil || len(tasks) != 1 || tasks[0].Id != dishes.Id {
+		t.Fatalf("expected only the kitchen task, got %+v, %v", tasks, err)
+	}
+	none := taskCategoryFilterNone
+	overview, err := s.GetTaskOverview(ctx, userID, &none)
+	if err != nil {
+		t.Fatalf("GetTaskOverview failed: %v", err)
+	}
+	if len(overview.DailyTasks) != 1 || overview.DailyTasks[0].Task.Title != "Trash" {
+		t.Fatalf("expected only the uncategorised task in the overview, got %+v", overview.DailyTasks)
+	}
+
+	if _, err := s.closeDayForTargetLocked(ctx, today, teamID); err != nil {
+		t.Fatalf("closeDayForTargetLocked failed: %v", err)
+	}
+	summary, err := s.GetMonthlySummary(ctx, userID, nil)
+	if err != nil {
+		t.Fatalf("GetMonthlySummary failed: %v", err)
+	}
+	if summary.CategoryStats == nil || len(*summary.CategoryStats) != 3 {
+		t.Fatalf("expected two categories and the uncategorised entry, got %+v", summary.CategoryStats)
+	}
+	stats := *summary.CategoryStats
+	if stats[0].CategoryId == nil || *stats[0].CategoryId != bath.Id || stats[0].PenaltyTotal != 0 {
+		t.Fatalf("expected the empty bathroom category first, got %+v", stats[0])
+	}
+	if *stats[1].CategoryId != kitchen.Id || stats[1].PenaltyTotal != 3 || stats[1].MissedCount != 1 {
+		t.Fatalf("expected the missed kitchen task in its category, got %+v", stats[1])
+	}
+	if stats[2].CategoryId != nil || stats[2].PenaltyTotal != 1 {
+		t.Fatalf("expected the uncategorised entry last, got %+v", stats[2])
+	}
+
+	points := 10
+	if _, err := s.PatchTask(withLatestIfMatchForUser(t, s, ctx, userID), userID, dishes.Id, api.UpdateTaskRequest{
+		PenaltyPoints: &points, CategoryId: &bath.Id,
+	}); err != nil {
+		t.Fatalf("PatchTask failed: %v", err)
+	}
+	summary, err = s.GetMonthlySummary(ctx, userID, nil)
+	if err != nil {
+		t.Fatalf("GetMonthlySummary failed: %v", err)
+	}
+	if stats := *summary.CategoryStats; stats[0].PenaltyTotal != 0 || stats[1].PenaltyTotal != 3 {
+		t.Fatalf("expected closed penalties to keep the points and category charged, got %+v", stats)
+	}
+
+	before, err := s.q.GetTaskByID(ctx, dishes.Id)
+	if err != nil {
+		t.Fatalf("GetTaskByID failed: %v", err)
+	}
+	if err := s.DeleteTaskCategory(withLatestIfMatchForUser(t, s, ctx, userID), userID, kitchen.Id); err != nil {
+		t.Fatalf("DeleteTaskCategory failed: %v", err)
+	}
+	after, err := s.q.GetTaskByID(ctx, dishes.Id)
+	if err != nil {
+		t.Fatalf("GetTaskByID failed: %v", err)
+	}
+	if ptrFromAny(after.CategoryID) != nil || after.Revision == before.Revision {
+		t.Fatalf("expected the task to be uncategorised with a new revision, got %+v", after)
+	}
+	summary, err = s.GetMonthlySummary(ctx, userID, nil)
+	if err != nil {
+		t.Fatalf("GetMonthlySummary failed: %v", err)
+	}
+	stats = *summary.CategoryStats
+	if len(stats) != 3 || *stats[0].CategoryId != bath.Id || stats[1].CategoryId == nil || *stats[1].CategoryId != kitchen.Id ||
+		stats[1].Name == nil || *stats[1].Name != "Kitchen" || stats[1].PenaltyTotal != 3 || stats[2].PenaltyTotal != 1 {
+		t.Fatalf("expected the deleted category to keep its closed penalties under its old name, got %+v", stats)
+	}
+}
+
+func TestTaskCategoryWritesCheckCategoryETag(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	today := time.Date(2026, 3, 2, 0, 0, 0, 0, s.loc)
+	s.SetClock(FixedClock(today.Add(9 * time.Hour)))
+
+	_, userID := createTeamWithMember(t, s, "category-etag@example.com", today.AddDate(0, 0, -1))
+	kitchen, err := s.CreateTaskCategory(withLatestIfMatchForUser(t, s, ctx, userID), userID, api.CreateTaskCategoryRequest{Name: "Kitchen"})
+	if err != nil {
+		t.Fatalf("CreateTaskCategory failed: %v", err)
+	}
+	if kitchen.Etag == nil {
+		t.Fatalf("expected the category to carry an ETag")
+	}
+	staleTeamCtx := withLatestIfMatchForUser(t, s, ctx, userID)
+	stale := *kitchen.Etag
+
+	renamed := "Kitchen & dining"
+	updated, err := s.PatchTaskCategory(NewIfMatchContext(NewActorContext(ctx, userID), stale), userID, kitchen.Id, api.UpdateTaskCategoryRequest{Name: &renamed})
+	if err != nil {
+		t.Fatalf("PatchTaskCategory with the category ETag failed: %v", err)
+	}
+	if updated.Etag == nil || *updated.Etag == stale {
+		t.Fatalf("expected the category ETag to move, got %v", updated.Etag)
+	}
+
+	var precondition *application.PreconditionError
+	if _, err := s.PatchTaskCategory(NewIfMatchContext(NewActorContext(ctx, userID), stale), userID, kitchen.Id, api.UpdateTaskCategoryRequest{Name: &renamed}); !errors.As(err, &precondition) {
+		t.Fatalf("expected a stale category ETag to be rejected, got %v", err)
+	}
+	if precondition.CurrentETag != *updated.Etag {
+		t.Fatalf("expected current ETag %q, got %q", *updated.Etag, precondition.CurrentETag)
+	}
+	if err := s.DeleteTaskCategory(staleTeamCtx, userID, kitchen.Id); !errors.As(err, &precondition) {
+		t.Fatalf("expected a team ETag older than the category change to be rejected, got %v", err)
+	}
+	if err := s.DeleteTaskCategory(NewIfMatchContext(NewActorContext(ctx, userID), *updated.Etag), userID, kitchen.Id); err != nil {
+		t.Fatalf("DeleteTaskCategory with the category ETag failed: %v", err)
+	}
+}
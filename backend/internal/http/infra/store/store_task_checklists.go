@@ -0,0 +1,472 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+	dbsqlc "github.com/megu/kaji-challenge/backend/internal/db/sqlc"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+const (
+	taskChecklistTitleMaxLength = 100
+	taskChecklistMaxItems       = 20
+)
+
+type taskChecklistItem struct {
+	ID        string
+	TaskID    string
+	Title     string
+	Position  int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func taskChecklistItemFromRow(row dbsqlc.TaskChecklistItem, loc *time.Location) taskChecklistItem {
+	return taskChecklistItem{
+		ID:        row.ID,
+		TaskID:    row.TaskID,
+		Title:     row.Title,
+		Position:  int(row.Position),
+		CreatedAt: row.CreatedAt.Time.In(loc),
+		UpdatedAt: row.UpdatedAt.Time.In(loc),
+	}
+}
+
+func (i taskChecklistItem) toAPI() api.TaskChecklistItem {
+	return api.TaskChecklistItem{
+		Id:        i.ID,
+		TaskId:    i.TaskID,
+		Title:     i.Title,
+		Position:  i.Position,
+		CreatedAt: i.CreatedAt,
+		UpdatedAt: i.UpdatedAt,
+	}
+}
+
+// checklistPeriodStart keys checklist checks by the task's cadence, so they
+// reset each day for daily tasks and each week for weekly tasks. A one_off
+// task has a single period that never resets.
+func checklistPeriodStart(task taskRecord, targetDate time.Time, loc *time.Location) time.Time {
+	switch task.Type {
+	case api.Weekly:
+		return startOfWeek(targetDate, loc)
+	case api.OneOff:
+		return dateOnly(task.CreatedAt, loc)
+	default:
+		return targetDate
+	}
+}
+
+func (s *Store) ListTaskChecklistItems(ctx context.Context, userID, taskID string) ([]api.TaskChecklistItem, error) {
+	teamID, err := s.primaryTeamLocked(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := getTeamTaskLocked(ctx, s.q, teamID, taskID, s.loc); err != nil {
+		return nil, err
+	}
+	items, err := listTaskChecklistItemsLocked(ctx, s.q, taskID, s.loc)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]api.TaskChecklistItem, 0, len(items))
+	for _, item := range items {
+		res = append(res, item.toAPI())
+	}
+	return res, nil
+}
+
+func (s *Store) CreateTaskChecklistItem(ctx context.Context, userID, taskID string, req api.CreateTaskChecklistItemRequest) (api.TaskChecklistItem, error) {
+	teamID, err := s.primaryTeamLocked(ctx, userID)
+	if err != nil {
+		return api.TaskChecklistItem{}, err
+	}
+	title, err := normalizeChecklistTitle(req.Title)
+	if err != nil {
+		return api.TaskChecklistItem{}, err
+	}
+	now := s.now()
+	item := taskChecklistItem{ID: s.nextID("tci"), TaskID: taskID, Title: title, CreatedAt: now, UpdatedAt: now}
+	if _, err := s.runWithTeamRevisionCAS(
+		ctx,
+		teamID,
+		"task_checklist",
+		map[string]string{"taskId": taskID, "itemId": item.ID, "action": "create"},
+		func(txCtx context.Context, qtx *dbsqlc.Queries) error {
+			if _, err := s.lockTaskStateLocked(txCtx, qtx, teamID, taskID); err != nil {
+				return err
+			}
+			items, err := listTaskChecklistItemsLocked(txCtx, qtx, taskID, s.loc)
+			if err != nil {
+				return err
+			}
+			if len(items) >= taskChecklistMaxItems {
+				return fmt.Errorf("invalid checklist: at most %d items per task", taskChecklistMaxItems)
+			}
+			item.Position = checklistPosition(req.Position, len(items))
+			if err := qtx.CreateTaskChecklistItem(txCtx, dbsqlc.CreateTaskChecklistItemParams{
+				ID:        item.ID,
+				TaskID:    item.TaskID,
+				Title:     item.Title,
+				Position:  int32(item.Position),
+				CreatedAt: toPgTimestamptz(item.CreatedAt),
+				UpdatedAt: toPgTimestamptz(item.UpdatedAt),
+			}); err != nil {
+				return err
+			}
+			ordered := append(items[:item.Position:item.Position], item)
+			ordered = append(ordered, items[item.Position:]...)
+			return renumberChecklistLocked(txCtx, qtx, ordered)
+		},
+	); err != nil {
+		return api.TaskChecklistItem{}, err
+	}
+	return item.toAPI(), nil
+}
+
+func (s *Store) PatchTaskChecklistItem(ctx context.Context, userID, taskID, itemID string, req api.UpdateTaskChecklistItemRequest) (api.TaskChecklistItem, error) {
+	teamID, err := s.primaryTeamLocked(ctx, userID)
+	if err != nil {
+		return api.TaskChecklistItem{}, err
+	}
+	var item taskChecklistItem
+	if _, err := s.runWithTeamRevisionCAS(
+		ctx,
+		teamID,
+		"task_checklist",
+		map[string]string{"taskId": taskID, "itemId": itemID, "action": "update"},
+		func(txCtx context.Context, qtx *dbsqlc.Queries) error {
+			if _, err := s.lockTaskStateLocked(txCtx, qtx, teamID, taskID); err != nil {
+				return err
+			}
+			items, err := listTaskChecklistItemsLocked(txCtx, qtx, taskID, s.loc)
+			if err != nil {
+				return err
+			}
+			index := -1
+			for i := range items {
+				if items[i].ID == itemID {
+					index = i
+				}
+			}
+			if index < 0 {
+				return errors.New("checklist item not found")
+			}
+			item = items[index]
+			if req.Title != nil {
+				title, err := normalizeChecklistTitle(*req.Title)
+				if err != nil {
+					return err
+				}
+				item.Title = title
+				item.UpdatedAt = s.now()
+				if err := qtx.UpdateTaskChecklistItem(txCtx, dbsqlc.UpdateTaskChecklistItemParams{
+					ID:        item.ID,
+					Title:     item.Title,
+					UpdatedAt: toPgTimestamptz(item.UpdatedAt),
+				}); err != nil {
+					return err
+				}
+			}
+			if req.Position == nil {
+				return nil
+			}
+			rest := append(items[:index:index], items[index+1:]...)
+			position := checklistPosition(req.Position, len(rest))
+			ordered := append(rest[:position:position], item)
+			ordered = append(ordered, rest[position:]...)
+			if err := renumberChecklistLocked(txCtx, qtx, ordered); err != nil {
+				return err
+			}
+			item.Position = position
+			return nil
+		},
+	); err != nil {
+		return api.TaskChecklistItem{}, err
+	}
+	return item.toAPI(), nil
+}
+
+func (s *Store) DeleteTaskChecklistItem(ctx context.Context, userID, taskID, itemID string) error {
+	teamID, err := s.primaryTeamLocked(ctx, userID)
+	if err != nil {
+		return err
+	}
+	_, err = s.runWithTeamRevisionCAS(
+		ctx,
+		teamID,
+		"task_checklist",
+		map[string]string{"taskId": taskID, "itemId": itemID, "action": "delete"},
+		func(txCtx context.Context, qtx *dbsqlc.Queries) error {
+			if _, err := s.lockTaskStateLocked(txCtx, qtx, teamID, taskID); err != nil {
+				return err
+			}
+			items, err := listTaskChecklistItemsLocked(txCtx, qtx, taskID, s.loc)
+			if err != nil {
+				return err
+			}
+			rest := make([]taskChecklistItem, 0, len(items))
+			for _, item := range items {
+				if item.ID != itemID {
+					rest = append(rest, item)
+				}
+			}
+			if len(rest) == len(items) {
+				return errors.New("checklist item not found")
+			}
+			if err := qtx.DeleteTaskChecklistItem(txCtx, itemID); err != nil {
+				return err
+			}
+			return renumberChecklistLocked(txCtx, qtx, rest)
+		},
+	)
+	return err
+}
+
+// ToggleTaskChecklistItem checks or unchecks an item for the period holding
+// target. Checking the last unchecked item also completes the task for that
+// period when it is not complete yet; unchecking leaves a recorded completion
+// alone.
+func (s *Store) ToggleTaskChecklistItem(ctx context.Context, userID, taskID, itemID string, target time.Time) (api.TaskChecklistToggleResponse, error) {
+	teamID, err := s.primaryTeamLocked(ctx, userID)
+	if err != nil {
+		return api.TaskChecklistToggleResponse{}, err
+	}
+	res := api.TaskChecklistToggleResponse{}
+	if _, err := s.runWithTeamRevisionCAS(
+		ctx,
+		teamID,
+		"task_checklist",
+		map[string]string{"taskId": taskID, "itemId": itemID, "action": "toggle"},
+		func(txCtx context.Context, qtx *dbsqlc.Queries) error {
+			task, err := s.lockTaskStateLocked(txCtx, qtx, teamID, taskID)
+			if err != nil {
+				return err
+			}
+			item, err := qtx.GetTaskChecklistItemByID(txCtx, itemID)
+			if err != nil || item.TaskID != task.ID {
+				return errors.New("checklist item not found")
+			}
+			targetDate, err := s.completionTargetDate(task, target)
+			if err != nil {
+				return err
+			}
+			periodPg := toPgDate(checklistPeriodStart(task, targetDate, s.loc))
+			checked, err := qtx.HasTaskChecklistCheck(txCtx, dbsqlc.HasTaskChecklistCheckParams{ItemID: itemID, PeriodStart: periodPg})
+			if err != nil {
+				return err
+			}
+			res = api.TaskChecklistToggleResponse{TaskId: task.ID, ItemId: itemID, TargetDate: toDate(targetDate), Checked: !checked}
+			if checked {
+				return qtx.DeleteTaskChecklistCheck(txCtx, dbsqlc.DeleteTaskChecklistCheckParams{ItemID: itemID, PeriodStart: periodPg})
+			}
+			if err := qtx.CreateTaskChecklistCheck(txCtx, dbsqlc.CreateTaskChecklistCheckParams{
+				ItemID:          itemID,
+				PeriodStart:     periodPg,
+				CheckedByUserID: userID,
+				CheckedAt:       toPgTimestamptz(s.now()),
+			}); err != nil {
+				return err
+			}
+			completion, err := s.completeCheckedTaskLocked(txCtx, qtx, task, userID, targetDate)
+			if err != nil {
+				return err
+			}
+			res.TaskCompletion = completion
+			return nil
+		},
+	); err != nil {
+		return api.TaskChecklistToggleResponse{}, err
+	}
+	return res, nil
+}
+
+// lockTaskStateLocked loads the task a checklist or completion evidence
+// write belongs to. Both are part of the task's state, so the task's If-Match
+// applies and its revision moves.
+func (s *Store) lockTaskStateLocked(ctx context.Context, qtx *dbsqlc.Queries, teamID, taskID string) (taskRecord, error) {
+	task, err := getTeamTaskLocked(ctx, qtx, teamID, taskID, s.loc)
+	if err != nil {
+		return taskRecord{}, err
+	}
+	if err := checkEntityIfMatch(ctx, etagKindTask, task.ID, task.Revision); err != nil {
+		return taskRecord{}, err
+	}
+	if err := qtx.UpdateTaskRevision(ctx, dbsqlc.UpdateTaskRevisionParams{
+		ID:       task.ID,
+		Revision: nextEntityRevision(ctx),
+	}); err != nil {
+		return taskRecord{}, err
+	}
+	return task, nil
+}
+
+func getTeamTaskLocked(ctx context.Context, q *dbsqlc.Queries, teamID, taskID string, loc *time.Location) (taskRecord, error) {
+	row, err := q.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return taskRecord{}, errors.New("task not found")
+	}
+	task := taskFromGetRow(row, loc)
+	if task.TeamID != teamID || task.DeletedAt != nil {
+		return taskRecord{}, errors.New("task not found")
+	}
+	return task, nil
+}
+
+func listTaskChecklistItemsLocked(ctx context.Context, q *dbsqlc.Queries, taskID string, loc *time.Location) ([]taskChecklistItem, error) {
+	rows, err := q.ListTaskChecklistItemsByTaskID(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]taskChecklistItem, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, taskChecklistItemFromRow(row, loc))
+	}
+	return items, nil
+}
+
+// renumberChecklistLocked stores the slice order as zero-based positions,
+// writing only the items whose position changed.
+func renumberChecklistLocked(ctx context.Context, qtx *dbsqlc.Queries, ordered []taskChecklistItem) error {
+	for i, item := range ordered {
+		if item.Position == i {
+			continue
+		}
+		if err := qtx.SetTaskChecklistItemPosition(ctx, dbsqlc.SetTaskChecklistItemPositionParams{
+			ID:       item.ID,
+			Position: int32(i),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checklistPosition clamps a requested position to [0, n], defaulting to n.
+func checklistPosition(requested *int, n int) int {
+	if requested == nil || *requested > n {
+		return n
+	}
+	if *requested < 0 {
+		return 0
+	}
+	return *requested
+}
+
+func normalizeChecklistTitle(raw string) (string, error) {
+	title := strings.TrimSpace(raw)
+	if title == "" {
+		return "", errors.New("checklist item title is required")
+	}
+	if utf8.RuneCountInString(title) > taskChecklistTitleMaxLength {
+		return "", fmt.Errorf("invalid checklist item: title must be %d characters or fewer", taskChecklistTitleMaxLength)
+	}
+	return title, nil
+}
+
+func (s *Store) uncheckedChecklistItemsLocked(ctx context.Context, q *dbsqlc.Queries, task taskRecord, targetDate time.Time) (int32, error) {
+	return q.CountUncheckedTaskChecklistItems(ctx, dbsqlc.CountUncheckedTaskChecklistItemsParams{
+		TaskID:      task.ID,
+		PeriodStart: toPgDate(checklistPeriodStart(task, targetDate, s.loc)),
+	})
+}
+
+// requireChecklistCheckedLocked gates a completion on every checklist item
+// being checked in the period holding targetDate.
+func (s *Store) requireChecklistCheckedLocked(ctx context.Context, q *dbsqlc.Queries, task taskRecord, targetDate time.Time) error {
+	unchecked, err := s.uncheckedChecklistItemsLocked(ctx, q, task, targetDate)
+	if err != nil {
+		return err
+	}
+	if unchecked > 0 {
+		return fmt.Errorf("invalid completion: %d checklist items are not checked yet", unchecked)
+	}
+	return nil
+}
+
+// completeCheckedTaskLocked records the task's completion for the period
+// once every checklist item is checked. It returns nil when items remain or
+// the period already has a completion.
+func (s *Store) completeCheckedTaskLocked(ctx context.Context, q *dbsqlc.Queries, task taskRecord, userID string, targetDate time.Time) (*api.TaskCompletionResponse, error) {
+	unchecked, err := s.uncheckedChecklistItemsLocked(ctx, q, task, targetDate)
+	if err != nil || unchecked > 0 {
+		return nil, err
+	}
+	res := &api.TaskCompletionResponse{TaskId: task.ID, TargetDate: toDate(targetDate), Completed: true}
+	switch task.Type {
+	case api.Daily:
+		done, err := q.HasTaskCompletionDaily(ctx, dbsqlc.HasTaskCompletionDailyParams{TaskID: task.ID, TargetDate: toPgDate(targetDate)})
+		if err != nil || done {
+			return nil, err
+		}
+		if err := q.CreateTaskCompletionDaily(ctx, dbsqlc.CreateTaskCompletionDailyParams{
+			TaskID:            task.ID,
+			TargetDate:        toPgDate(targetDate),
+			CompletedByUserID: userID,
+		}); err != nil {
+			return nil, err
+		}
+	case api.Weekly:
+		weekStartPg := toPgDate(startOfWeek(targetDate, s.loc))
+		count, err := q.GetTaskCompletionWeeklyEntryCount(ctx, dbsqlc.GetTaskCompletionWeeklyEntryCountParams{TaskID: task.ID, WeekStart: weekStartPg})
+		if err != nil || count > 0 {
+			return nil, err
+		}
+		if err := q.InsertTaskCompletionWeeklyEntry(ctx, dbsqlc.InsertTaskCompletionWeeklyEntryParams{
+			ID:                s.nextID("twce"),
+			TaskID:            task.ID,
+			WeekStart:         weekStartPg,
+			CompletedByUserID: userID,
+		}); err != nil {
+			return nil, err
+		}
+		res.WeeklyCompletedCount = 1
+	case api.OneOff:
+		_, err = q.GetTaskCompletionOneOff(ctx, task.ID)
+		if err == nil || !errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		if err := q.CreateTaskCompletionOneOff(ctx, dbsqlc.CreateTaskCompletionOneOffParams{
+			TaskID:            task.ID,
+			CompletedByUserID: userID,
+			CompletedAt:       toPgTimestamptz(s.now()),
+		}); err != nil {
+			return nil, err
+		}
+	}
+	recordCompletionAudit(ctx, "checklist", false, 0, *res)
+	return res, nil
+}
+
+// listChecklistStatesByTask returns each undeleted task's checklist with its
+// state in the task's current period.
+func (s *Store) listChecklistStatesByTask(ctx context.Context, teamID string, today, weekStart time.Time) (map[string][]api.TaskChecklistItemState, error) {
+	rows, err := s.q.ListTaskChecklistStatesByTeam(ctx, dbsqlc.ListTaskChecklistStatesByTeamParams{
+		TeamID:    teamID,
+		Today:     toPgDate(today),
+		WeekStart: toPgDate(weekStart),
+	})
+	if err != nil {
+		return nil, err
+	}
+	states := map[string][]api.TaskChecklistItemState{}
+	for _, row := range rows {
+		state := api.TaskChecklistItemState{
+			Id:       row.ID,
+			Title:    row.Title,
+			Position: int(row.Position),
+			Checked:  row.Checked,
+		}
+		if row.Checked {
+			state.CheckedBy = taskCompletionActorPtr(row.CheckedByUserID, row.CheckedByEffectiveName, row.CheckedByColorHex)
+		}
+		states[row.TaskID] = append(states[row.TaskID], state)
+	}
+	return states, nil
+}
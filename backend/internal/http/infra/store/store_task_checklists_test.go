@@ -0,0 +1,106 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func TestChecklistPositionAndPeriodStart(t *testing.T) {
+	two, far, negative := 2, 9, -1
+	if got := checklistPosition(nil, 3); got != 3 {
+		t.Fatalf("expected a missing position to append, got %d", got)
+	}
+	if checklistPosition(&two, 3) != 2 || checklistPosition(&far, 3) != 3 || checklistPosition(&negative, 3) != 0 {
+		t.Fatalf("expected positions to be clamped to [0, n]")
+	}
+
+	loc := time.UTC
+	wednesday := time.Date(2026, 4, 8, 0, 0, 0, 0, loc)
+	created := time.Date(2026, 3, 20, 18, 0, 0, 0, loc)
+	if got := checklistPeriodStart(taskRecord{Type: api.Daily}, wednesday, loc); !sameDate(got, wednesday) {
+		t.Fatalf("expected daily checklists to reset each day, got %s", got)
+	}
+	if got := checklistPeriodStart(taskRecord{Type: api.Weekly}, wednesday, loc); !sameDate(got, wednesday.AddDate(0, 0, -2)) {
+		t.Fatalf("expected weekly checklists to reset each Monday, got %s", got)
+	}
+	if got := checklistPeriodStart(taskRecord{Type: api.OneOff, CreatedAt: created}, wednesday, loc); !sameDate(got, created) {
+		t.Fatalf("expected one_off checklists to keep a single period, got %s", got)
+	}
+}
+
+func TestChecklistGatesAndCompletesWeeklyTask(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	monday := time.Date(2026, 4, 6, 0, 0, 0, 0, s.loc)
+	s.SetClock(FixedClock(monday.Add(9 * time.Hour)))
+
+	_, userID := createTeamWithMember(t, s, "checklist@example.com", monday.AddDate(0, 0, -1))
+	task, err := s.CreateTask(withLatestIfMatchForUser(t, s, ctx, userID), userID, api.CreateTaskRequest{
+		Title: "Clean the bathroom", Type: api.Weekly, PenaltyPoints: 2,
+	})
+	if err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+	tub, err := s.CreateTaskChecklistItem(withLatestIfMatchForUser(t, s, ctx, userID), userID, task.Id, api.CreateTaskChecklistItemRequest{Title: "Scrub the tub"})
+	if err != nil {
+		t.Fatalf("CreateTaskChecklistItem failed: %v", err)
+	}
+	first := 0
+	mirror, err := s.CreateTaskChecklistItem(withLatestIfMatchForUser(t, s, ctx, userID), userID, task.Id, api.CreateTaskChecklistItemRequest{Title: "Wipe the mirror", Position: &first})
+	if err != nil {
+		t.Fatalf("CreateTaskChecklistItem failed: %v", err)
+	}
+	items, err := s.ListTaskChecklistItems(ctx, userID, task.Id)
+	if err != nil || len(items) != 2 || items[0].Id != mirror.Id || items[1].Id != tub.Id || items[1].Position != 1 {
+		t.Fatalf("expected the inserted item first and the rest renumbered, got %+v, %v", items, err)
+	}
+
+	today := toDate(monday)
+	if _, err := s.ToggleTaskCompletion(withLatestIfMatchForUser(t, s, ctx, userID), userID, task.Id, monday, nil); err == nil ||
+		err.Error() != "invalid completion: 2 checklist items are not checked yet" {
+		t.Fatalf("expected completion to be gated by the checklist, got %v", err)
+	}
+	res, err := s.ToggleTaskChecklistItem(withLatestIfMatchForUser(t, s, ctx, userID), userID, task.Id, tub.Id, today.Time)
+	if err != nil || !res.Checked || res.TaskCompletion != nil {
+		t.Fatalf("expected the first check not to complete the task, got %+v, %v", res, err)
+	}
+	res, err = s.ToggleTaskChecklistItem(withLatestIfMatchForUser(t, s, ctx, userID), userID, task.Id, mirror.Id, monday.AddDate(0, 0, 2))
+	if err != nil || res.TaskCompletion == nil || res.TaskCompletion.WeeklyCompletedCount != 1 {
+		t.Fatalf("expected the last check to complete the task for the week, got %+v, %v", res, err)
+	}
+
+	overview, err := s.GetTaskOverview(ctx, userID, nil)
+	if err != nil {
+		t.Fatalf("GetTaskOverview failed: %v", err)
+	}
+	if len(overview.WeeklyTasks) != 1 || overview.WeeklyTasks[0].WeekCompletedCount != 1 || overview.WeeklyTasks[0].Checklist == nil {
+		t.Fatalf("expected the completed weekly task with its checklist, got %+v", overview.WeeklyTasks)
+	}
+	for _, item := range *overview.WeeklyTasks[0].Checklist {
+		if !item.Checked || item.CheckedBy == nil {
+			t.Fatalf("expected every item to be checked with its actor, got %+v", item)
+		}
+	}
+
+	s.SetClock(FixedClock(monday.AddDate(0, 0, 7).Add(9 * time.Hour)))
+	overview, err = s.GetTaskOverview(ctx, userID, nil)
+	if err != nil {
+		t.Fatalf("GetTaskOverview failed: %v", err)
+	}
+	for _, item := range *overview.WeeklyTasks[0].Checklist {
+		if item.Checked {
+			t.Fatalf("expected the checklist to reset in the next week, got %+v", item)
+		}
+	}
+
+	if err := s.DeleteTaskChecklistItem(withLatestIfMatchForUser(t, s, ctx, userID), userID, task.Id, mirror.Id); err != nil {
+		t.Fatalf("DeleteTaskChecklistItem failed: %v", err)
+	}
+	items, err = s.ListTaskChecklistItems(ctx, userID, task.Id)
+	if err != nil || len(items) != 1 || items[0].Position != 0 {
+		t.Fatalf("expected the remaining item to move up, got %+v, %v", items, err)
+	}
+}
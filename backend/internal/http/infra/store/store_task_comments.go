@@ -0,0 +1,215 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	dbsqlc "github.com/megu/kaji-challenge/backend/internal/db/sqlc"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+const (
+	taskCommentBodyMaxLength = 1000
+	taskCommentsDefaultLimit = 30
+	taskCommentsMaxLimit     = 100
+)
+
+// ListTaskComments returns one page of a task's comments, newest first. The
+// cursor is the position of the last comment of the previous page, so pages
+// stay stable while new comments arrive.
+func (s *Store) ListTaskComments(ctx context.Context, userID, taskID string, limit *int, cursor *string) (api.TaskCommentPage, error) {
+	teamID, err := s.primaryTeamLocked(ctx, userID)
+	if err != nil {
+		return api.TaskCommentPage{}, err
+	}
+	n := taskCommentsDefaultLimit
+	if limit != nil {
+		n = *limit
+	}
+	if n < 1 || n > taskCommentsMaxLimit {
+		return api.TaskCommentPage{}, fmt.Errorf("invalid limit: must be between 1 and %d", taskCommentsMaxLimit)
+	}
+	params := dbsqlc.ListTaskCommentsParams{TaskID: taskID, RowLimit: int32(n + 1)}
+	if cursor != nil && *cursor != "" {
+		createdAt, id, err := decodeKeysetCursor(*cursor)
+		if err != nil {
+			return api.TaskCommentPage{}, err
+		}
+		params.BeforeCreatedAt = toPgTimestamptz(createdAt)
+		params.BeforeID = id
+	}
+	if _, err := getTeamTaskLocked(ctx, s.q, teamID, taskID, s.loc); err != nil {
+		return api.TaskCommentPage{}, err
+	}
+	rows, err := s.q.ListTaskComments(ctx, params)
+	if err != nil {
+		return api.TaskCommentPage{}, err
+	}
+	page := api.TaskCommentPage{Items: make([]api.TaskComment, 0, min(len(rows), n))}
+	for i, row := range rows {
+		if i == n {
+			last := rows[n-1]
+			next := encodeKeysetCursor(last.CreatedAt.Time, last.ID)
+			page.NextCursor = &next
+			break
+		}
+		page.Items = append(page.Items, taskCommentAPI(row.ID, row.TaskID, row.Body, row.CreatedAt, row.EditedAt, row.Revision, row.AuthorUserID, row.AuthorEffectiveName, row.AuthorColorHex, s.loc))
+	}
+	return page, nil
+}
+
+func (s *Store) CreateTaskComment(ctx context.Context, userID, taskID string, req api.CreateTaskCommentRequest) (api.TaskComment, error) {
+	teamID, err := s.primaryTeamLocked(ctx, userID)
+	if err != nil {
+		return api.TaskComment{}, err
+	}
+	body, err := normalizeTaskCommentBody(req.Body)
+	if err != nil {
+		return api.TaskComment{}, err
+	}
+	commentID := s.nextID("comment")
+	var res api.TaskComment
+	if _, err := s.runWithTeamRevisionCAS(
+		ctx,
+		teamID,
+		"task_comment",
+		map[string]string{"taskId": taskID, "commentId": commentID, "action": "create"},
+		func(txCtx context.Context, qtx *dbsqlc.Queries) error {
+			if _, err := getTeamTaskLocked(txCtx, qtx, teamID, taskID, s.loc); err != nil {
+				return err
+			}
+			if err := qtx.CreateTaskComment(txCtx, dbsqlc.CreateTaskCommentParams{
+				ID:           commentID,
+				TaskID:       taskID,
+				AuthorUserID: userID,
+				Body:         body,
+				CreatedAt:    toPgTimestamptz(s.now()),
+				Revision:     nextEntityRevision(txCtx),
+			}); err != nil {
+				return err
+			}
+			res, err = s.getTaskCommentLocked(txCtx, qtx, commentID)
+			return err
+		},
+	); err != nil {
+		return api.TaskComment{}, err
+	}
+	return res, nil
+}
+
+func (s *Store) PatchTaskComment(ctx context.Context, userID, taskID, commentID string, req api.UpdateTaskCommentRequest) (api.TaskComment, error) {
+	teamID, err := s.primaryTeamLocked(ctx, userID)
+	if err != nil {
+		return api.TaskComment{}, err
+	}
+	body, err := normalizeTaskCommentBody(req.Body)
+	if err != nil {
+		return api.TaskComment{}, err
+	}
+	var res api.TaskComment
+	if _, err := s.runWithTeamRevisionCAS(
+		ctx,
+		teamID,
+		"task_comment",
+		map[string]string{"taskId": taskID, "commentId": commentID, "action": "update"},
+		func(txCtx context.Context, qtx *dbsqlc.Queries) error {
+			if err := s.lockOwnTaskCommentLocked(txCtx, qtx, teamID, taskID, commentID, userID); err != nil {
+				return err
+			}
+			if err := qtx.UpdateTaskCommentBody(txCtx, dbsqlc.UpdateTaskCommentBodyParams{
+				ID:       commentID,
+				Body:     body,
+				EditedAt: toPgTimestamptz(s.now()),
+				Revision: nextEntityRevision(txCtx),
+			}); err != nil {
+				return err
+			}
+			res, err = s.getTaskCommentLocked(txCtx, qtx, commentID)
+			return err
+		},
+	); err != nil {
+		return api.TaskComment{}, err
+	}
+	return res, nil
+}
+
+func (s *Store) DeleteTaskComment(ctx context.Context, userID, taskID, commentID string) error {
+	teamID, err := s.primaryTeamLocked(ctx, userID)
+	if err != nil {
+		return err
+	}
+	_, err = s.runWithTeamRevisionCAS(
+		ctx,
+		teamID,
+		"task_comment",
+		map[string]string{"taskId": taskID, "commentId": commentID, "action": "delete"},
+		func(txCtx context.Context, qtx *dbsqlc.Queries) error {
+			if err := s.lockOwnTaskCommentLocked(txCtx, qtx, teamID, taskID, commentID, userID); err != nil {
+				return err
+			}
+			return qtx.DeleteTaskComment(txCtx, commentID)
+		},
+	)
+	return err
+}
+
+// lockOwnTaskCommentLocked checks that the comment belongs to a live task of
+// the team and was written by userID, and that the request's If-Match still
+// covers it. Other members can read a comment but never change it.
+func (s *Store) lockOwnTaskCommentLocked(ctx context.Context, qtx *dbsqlc.Queries, teamID, taskID, commentID, userID string) error {
+	if _, err := getTeamTaskLocked(ctx, qtx, teamID, taskID, s.loc); err != nil {
+		return err
+	}
+	row, err := qtx.GetTaskCommentForUpdate(ctx, commentID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.New("comment not found")
+		}
+		return err
+	}
+	if row.TaskID != taskID {
+		return errors.New("comment not found")
+	}
+	if author := ptrFromAny(row.AuthorUserID); author == nil || *author != userID {
+		return errors.New("forbidden: only the author can change a comment")
+	}
+	return checkEntityIfMatch(ctx, etagKindTaskComment, row.ID, row.Revision)
+}
+
+func (s *Store) getTaskCommentLocked(ctx context.Context, qtx *dbsqlc.Queries, commentID string) (api.TaskComment, error) {
+	row, err := qtx.GetTaskCommentByID(ctx, commentID)
+	if err != nil {
+		return api.TaskComment{}, err
+	}
+	return taskCommentAPI(row.ID, row.TaskID, row.Body, row.CreatedAt, row.EditedAt, row.Revision, row.AuthorUserID, row.AuthorEffectiveName, row.AuthorColorHex, s.loc), nil
+}
+
+func taskCommentAPI(id, taskID, body string, createdAt, editedAt pgtype.Timestamptz, revision int64, authorUserID interface{}, authorName string, authorColorHex pgtype.Text, loc *time.Location) api.TaskComment {
+	etag := entityETag(etagKindTaskComment, id, revision)
+	return api.TaskComment{
+		Id:        id,
+		TaskId:    taskID,
+		Body:      body,
+		Author:    taskCompletionActorPtr(authorUserID, authorName, authorColorHex),
+		CreatedAt: createdAt.Time.In(loc),
+		EditedAt:  ptrFromTimestamptz(editedAt, loc),
+		Etag:      &etag,
+	}
+}
+
+func normalizeTaskCommentBody(raw string) (string, error) {
+	body := strings.TrimSpace(raw)
+	if body == "" {
+		return "", errors.New("invalid comment: body is required")
+	}
+	if utf8.RuneCountInString(body) > taskCommentBodyMaxLength {
+		return "", fmt.Errorf("invalid comment: must be at most %d characters", taskCommentBodyMaxLength)
+	}
+	return body, nil
+}
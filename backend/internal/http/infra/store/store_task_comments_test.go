@@ -0,0 +1,115 @@
+package store
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	dbsqlc "github.com/megu/kaji-challenge/backend/internal/db/sqlc"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func TestTaskCommentCursorAndBody(t *testing.T) {
+	at := time.Date(2026, 4, 8, 9, 30, 0, 123456000, time.FixedZone("JST", 9*60*60))
+	id := "01960f3e-8a4b-7c2d-9e1f-123456789abc"
+	createdAt, gotID, err := decodeKeysetCursor(encodeKeysetCursor(at, id))
+	if err != nil || !createdAt.Equal(at) || gotID != id {
+		t.Fatalf("expected the cursor to round-trip, got %s %s %v", createdAt, gotID, err)
+	}
+	for _, cursor := range []string{"not base64!", "bm8tc2VwYXJhdG9y", encodeKeysetCursor(at, "not-a-uuid")} {
+		if _, _, err := decodeKeysetCursor(cursor); err == nil || err.Error() != "invalid cursor" {
+			t.Fatalf("expected %q to be rejected, got %v", cursor, err)
+		}
+	}
+
+	if body, err := normalizeTaskCommentBody("  please use the blue sponge \n"); err != nil || body != "please use the blue sponge" {
+		t.Fatalf("expected the body to be trimmed, got %q, %v", body, err)
+	}
+	if _, err := normalizeTaskCommentBody(" \n "); err == nil {
+		t.Fatalf("expected a blank comment to be rejected")
+	}
+	if _, err := normalizeTaskCommentBody(strings.Repeat("あ", taskCommentBodyMaxLength+1)); err == nil {
+		t.Fatalf("expected an overlong comment to be rejected")
+	}
+}
+
+func TestTaskCommentThread(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	now := time.Date(2026, 4, 8, 9, 0, 0, 0, s.loc)
+	s.SetClock(FixedClock(now))
+
+	teamID, ownerID := createTeamWithMember(t, s, "comments@example.com", now.AddDate(0, 0, -1))
+	memberID := s.nextID("user")
+	if err := s.q.CreateUser(ctx, dbsqlc.CreateUserParams{
+		ID:          memberID,
+		Email:       "comments-member@example.com",
+		DisplayName: "Member",
+		CreatedAt:   toPgTimestamptz(now),
+	}); err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	if err := s.q.AddTeamMember(ctx, dbsqlc.AddTeamMemberParams{
+		TeamID:    teamID,
+		UserID:    memberID,
+		Role:      string(api.TeamMembershipRoleMember),
+		CreatedAt: toPgTimestamptz(now),
+	}); err != nil {
+		t.Fatalf("failed to add team member: %v", err)
+	}
+	task, err := s.CreateTask(withLatestIfMatchForUser(t, s, ctx, ownerID), ownerID, api.CreateTaskRequest{
+		Title: "Wash the dishes", Type: api.Daily, PenaltyPoints: 1,
+	})
+	if err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+
+	if _, err := s.CreateTaskComment(ctx, ownerID, task.Id, api.CreateTaskCommentRequest{Body: "no If-Match"}); err == nil {
+		t.Fatalf("expected comments to require If-Match")
+	}
+	var ids []string
+	for i, body := range []string{"Please use the blue sponge", "The green one is for the bath", "Got it"} {
+		s.SetClock(FixedClock(now.Add(time.Duration(i) * time.Minute)))
+		author := ownerID
+		if i == 2 {
+			author = memberID
+		}
+		comment, err := s.CreateTaskComment(withLatestIfMatchForUser(t, s, ctx, author), author, task.Id, api.CreateTaskCommentRequest{Body: body})
+		if err != nil {
+			t.Fatalf("CreateTaskComment failed: %v", err)
+		}
+		if comment.Author == nil || comment.Author.UserId != author || comment.EditedAt != nil {
+			t.Fatalf("expected the author and no edit time, got %+v", comment)
+		}
+		ids = append(ids, comment.Id)
+	}
+
+	two := 2
+	page, err := s.ListTaskComments(ctx, memberID, task.Id, &two, nil)
+	if err != nil || len(page.Items) != 2 || page.Items[0].Id != ids[2] || page.Items[1].Id != ids[1] || page.NextCursor == nil {
+		t.Fatalf("expected the two newest comments and a cursor, got %+v, %v", page, err)
+	}
+	older, err := s.ListTaskComments(ctx, memberID, task.Id, &two, page.NextCursor)
+	if err != nil || len(older.Items) != 1 || older.Items[0].Id != ids[0] || older.NextCursor != nil {
+		t.Fatalf("expected the oldest comment on the last page, got %+v, %v", older, err)
+	}
+
+	if _, err := s.PatchTaskComment(withLatestIfMatchForUser(t, s, ctx, memberID), memberID, task.Id, ids[0], api.UpdateTaskCommentRequest{Body: "hijacked"}); err == nil || !strings.HasPrefix(err.Error(), "forbidden") {
+		t.Fatalf("expected only the author to edit, got %v", err)
+	}
+	if err := s.DeleteTaskComment(withLatestIfMatchForUser(t, s, ctx, memberID), memberID, task.Id, ids[0]); err == nil || !strings.HasPrefix(err.Error(), "forbidden") {
+		t.Fatalf("expected only the author to delete, got %v", err)
+	}
+	edited, err := s.PatchTaskComment(withLatestIfMatchForUser(t, s, ctx, ownerID), ownerID, task.Id, ids[0], api.UpdateTaskCommentRequest{Body: "Please use the blue sponge, not the steel wool"})
+	if err != nil || edited.Body != "Please use the blue sponge, not the steel wool" || edited.EditedAt == nil {
+		t.Fatalf("expected the edit to be saved and marked, got %+v, %v", edited, err)
+	}
+	if err := s.DeleteTaskComment(withLatestIfMatchForUser(t, s, ctx, memberID), memberID, task.Id, ids[2]); err != nil {
+		t.Fatalf("DeleteTaskComment failed: %v", err)
+	}
+	all, err := s.ListTaskComments(ctx, ownerID, task.Id, nil, nil)
+	if err != nil || len(all.Items) != 2 || all.Items[0].Id != ids[1] || all.Items[1].Id != ids[0] {
+		t.Fatalf("expected the deleted comment to be gone, got %+v, %v", all, err)
+	}
+}
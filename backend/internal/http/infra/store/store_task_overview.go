@@ -10,7 +10,7 @@ import (
 	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
 )
 
-func (s *Store) GetTaskOverview(ctx context.Context, userID string) (resp api.TaskOverviewResponse, err error) {
+func (s *Store) GetTaskOverview(ctx context.Context, userID string, categoryID *string) (resp api.TaskOverviewResponse, err error) {
 	startedAt := time.Now()
 	queryCount := 0
 	taskCount := 0
@@ -22,7 +22,7 @@ func (s *Store) GetTaskOverview(ctx context.Context, userID string) (resp api.Ta
 		s.logSQLPerformance("get_task_overview", startedAt, queryCount, fmt.Sprintf("team_id=%s task_count=%d error=%t", teamID, taskCount, err != nil))
 	}()
 
-	now := time.Now().In(s.loc)
+	now := s.now()
 	today := dateOnly(now, s.loc)
 	weekStart := startOfWeek(today, s.loc)
 	monthKey := monthKeyFromTime(today, s.loc)
@@ -32,6 +32,7 @@ func (s *Store) GetTaskOverview(ctx context.Context, userID string) (resp api.Ta
 	}
 	daily := []api.TaskOverviewDailyTask{}
 	weekly := []api.TaskOverviewWeeklyTask{}
+	oneOff := []api.TaskOverviewOneOffTask{}
 
 	tasks, err := s.q.ListUndeletedTasksByTeamID(ctx, teamID)
 	queryCount++
@@ -83,26 +84,114 @@ func (s *Store) GetTaskOverview(ctx context.Context, userID string) (resp api.Ta
 		weeklySlotsByTaskID[row.TaskID][int(row.Slot)] = taskCompletionActorPtr(row.CompletedByUserID, row.CompletedByEffectiveName, row.CompletedByColorHex)
 	}
 
+	absences, err := s.listPauseCalendar(ctx, teamID, weekStart, weekStart.AddDate(0, 0, 6))
+	queryCount += 2
+	if err != nil {
+		return api.TaskOverviewResponse{}, err
+	}
+
+	oneOffCompletionRows, err := s.q.ListTaskCompletionOneOffByTeam(ctx, teamID)
+	queryCount++
+	if err != nil {
+		return api.TaskOverviewResponse{}, err
+	}
+	oneOffActorByTaskID := make(map[string]*api.TaskCompletionActor, len(oneOffCompletionRows))
+	oneOffDone := make(map[string]bool, len(oneOffCompletionRows))
+	for _, row := range oneOffCompletionRows {
+		oneOffDone[row.TaskID] = true
+		oneOffActorByTaskID[row.TaskID] = taskCompletionActorPtr(row.CompletedByUserID, row.CompletedByEffectiveName, row.CompletedByColorHex)
+	}
+
+	checklists, err := s.listChecklistStatesByTask(ctx, teamID, today, weekStart)
+	queryCount++
+	if err != nil {
+		return api.TaskOverviewResponse{}, err
+	}
+	evidence, err := s.listCompletionEvidenceByTask(ctx, teamID, today, weekStart)
+	queryCount++
+	if err != nil {
+		return api.TaskOverviewResponse{}, err
+	}
+	reactions, err := s.listCompletionReactionsByTask(ctx, teamID, today, weekStart)
+	queryCount++
+	if err != nil {
+		return api.TaskOverviewResponse{}, err
+	}
+	checklistFor := func(taskID string) *[]api.TaskChecklistItemState {
+		if items, ok := checklists[taskID]; ok {
+			return &items
+		}
+		return nil
+	}
+
 	for _, row := range tasks {
 		t := taskFromUndeletedListRow(row, s.loc)
+		if !matchesCategoryFilter(t.CategoryID, categoryID) {
+			continue
+		}
+		assigneeID := uuidStringFromPtr(t.AssigneeID)
+		if t.Type == api.OneOff {
+			if t.DueOn == nil {
+				continue
+			}
+			completed := oneOffDone[t.ID]
+			daysUntilDue := int(t.DueOn.Sub(today).Hours() / 24)
+			// Completed one-offs drop off once their due date has passed.
+			if completed && daysUntilDue < 0 {
+				continue
+			}
+			oneOff = append(oneOff, api.TaskOverviewOneOffTask{
+				Task:         t.toAPI(),
+				Completed:    completed,
+				CompletedBy:  oneOffActorByTaskID[t.ID],
+				Overdue:      !completed && daysUntilDue < 0,
+				DaysUntilDue: daysUntilDue,
+				Checklist:    checklistFor(t.ID),
+				Evidence:     evidence[t.ID][1],
+				Reactions:    reactions[t.ID][1],
+			})
+			continue
+		}
 		if t.Type == api.Daily {
+			if !t.Window.activeOn(today) {
+				continue
+			}
+			paused := absences.pausedOn(today, assigneeID)
 			daily = append(daily, api.TaskOverviewDailyTask{
 				Task:           t.toAPI(),
 				CompletedToday: dailyDone[t.ID],
 				CompletedBy:    dailyActorByTaskID[t.ID],
+				Paused:         &paused,
+				Checklist:      checklistFor(t.ID),
+				Evidence:       evidence[t.ID][1],
+				Reactions:      reactions[t.ID][1],
 			})
 			continue
 		}
+		if !t.Window.overlapsWeek(weekStart) {
+			continue
+		}
+		pausedDays := absences.pausedDaysInTaskWeek(weekStart, assigneeID, t.Window)
+		requiredThisWeek := proratedRequiredCompletions(t.Required, pausedDays)
+		slots := buildCompletionSlots(t.Required, weeklySlotsByTaskID[t.ID])
+		for i := range slots {
+			slots[i].Evidence = evidence[t.ID][slots[i].Slot]
+			slots[i].Reactions = reactions[t.ID][slots[i].Slot]
+		}
 		weekly = append(weekly, api.TaskOverviewWeeklyTask{
-			Task:                       t.toAPI(),
-			WeekCompletedCount:         weeklyDone[t.ID],
-			RequiredCompletionsPerWeek: t.Required,
-			CompletionSlots:            buildCompletionSlots(t.Required, weeklySlotsByTaskID[t.ID]),
+			Task:                        t.toAPI(),
+			WeekCompletedCount:          weeklyDone[t.ID],
+			RequiredCompletionsPerWeek:  t.Required,
+			CompletionSlots:             slots,
+			PausedDays:                  &pausedDays,
+			RequiredCompletionsThisWeek: &requiredThisWeek,
+			Checklist:                   checklistFor(t.ID),
 		})
 	}
 
 	sort.Slice(daily, func(i, j int) bool { return daily[i].Task.CreatedAt.Before(daily[j].Task.CreatedAt) })
 	sort.Slice(weekly, func(i, j int) bool { return weekly[i].Task.CreatedAt.Before(weekly[j].Task.CreatedAt) })
+	sort.SliceStable(oneOff, func(i, j int) bool { return oneOff[i].DaysUntilDue < oneOff[j].DaysUntilDue })
 
 	elapsed := int(today.Sub(weekStart).Hours()/24) + 1
 	resp = api.TaskOverviewResponse{
@@ -112,6 +201,7 @@ func (s *Store) GetTaskOverview(ctx context.Context, userID string) (resp api.Ta
 		MonthlyPenaltyTotal: int(monthly.DailyPenaltyTotal + monthly.WeeklyPenaltyTotal),
 		DailyTasks:          daily,
 		WeeklyTasks:         weekly,
+		OneOffTasks:         &oneOff,
 	}
 	return resp, nil
 }
@@ -121,7 +211,7 @@ func (s *Store) GetMonthlySummary(ctx context.Context, userID string, month *str
 	if err != nil {
 		return api.MonthlyPenaltySummary{}, err
 	}
-	targetMonth := time.Now().In(s.loc).Format("2006-01")
+	targetMonth := s.now().Format("2006-01")
 	if month != nil && *month != "" {
 		targetMonth = *month
 	}
@@ -144,7 +234,7 @@ func (s *Store) GetMonthlySummary(ctx context.Context, userID string, month *str
 			return api.MonthlyPenaltySummary{}, err
 		}
 		monthEnd := monthStart.AddDate(0, 1, 0)
-		asOf := time.Now().In(s.loc)
+		asOf := s.now()
 		if asOf.After(monthEnd) {
 			asOf = monthEnd
 		}
@@ -167,6 +257,10 @@ func (s *Store) GetMonthlySummary(ctx context.Context, userID string, month *str
 	if err != nil {
 		return api.MonthlyPenaltySummary{}, err
 	}
+	categoryStats, err := s.buildMonthlyCategoryStats(ctx, teamID, dateOnly(summary.MonthStart.Time, s.loc))
+	if err != nil {
+		return api.MonthlyPenaltySummary{}, err
+	}
 	return monthSummary{
 		TeamID:           summary.TeamID,
 		Month:            monthKeyFromTime(summary.MonthStart.Time, s.loc),
@@ -175,6 +269,7 @@ func (s *Store) GetMonthlySummary(ctx context.Context, userID string, month *str
 		IsClosed:         summary.IsClosed,
 		TriggeredRuleID:  triggered,
 		TaskStatusByDate: taskStatusByDate,
+		CategoryStats:    categoryStats,
 	}.toAPI(), nil
 }
 
@@ -185,6 +280,8 @@ type monthlyTaskStatusRecord struct {
 	Type      api.TaskType
 	Penalty   int
 	Required  int
+	DueOn     *time.Time
+	Window    taskWindow
 	CreatedAt time.Time
 	DeletedAt *time.Time
 }
@@ -213,6 +310,8 @@ func (s *Store) buildMonthlyTaskStatusByDate(ctx context.Context, teamID, month
 			Type:      api.TaskType(row.Type),
 			Penalty:   int(row.PenaltyPoints),
 			Required:  int(row.RequiredCompletionsPerWeek),
+			DueOn:     ptrFromPgDate(row.DueOn, s.loc),
+			Window:    taskWindowFromPg(row.StartsOn, row.EndsOn, s.loc),
 			CreatedAt: row.CreatedAt.Time.In(s.loc),
 			DeletedAt: ptrFromTimestamptz(row.DeletedAt, s.loc),
 		})
@@ -276,6 +375,32 @@ func (s *Store) buildMonthlyTaskStatusByDate(ctx context.Context, teamID, month
 		weeklyActors[weekStartKey][row.TaskID][int(row.Slot)] = taskCompletionActorPtr(row.CompletedByUserID, row.CompletedByEffectiveName, row.CompletedByColorHex)
 	}
 
+	oneOffRows, err := s.q.ListTaskCompletionOneOffByTeam(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	oneOffActors := make(map[string]*api.TaskCompletionActor, len(oneOffRows))
+	oneOffCompletedAt := make(map[string]time.Time, len(oneOffRows))
+	for _, row := range oneOffRows {
+		oneOffActors[row.TaskID] = taskCompletionActorPtr(row.CompletedByUserID, row.CompletedByEffectiveName, row.CompletedByColorHex)
+		oneOffCompletedAt[row.TaskID] = row.CompletedAt.Time
+	}
+	// A one-off counts as done on its due day only when it was completed
+	// before that day's close cutoff, as the close charges it otherwise.
+	oneOffDoneBy := func(taskID string, cutoff time.Time) bool {
+		completedAt, ok := oneOffCompletedAt[taskID]
+		return ok && completedAt.Before(cutoff)
+	}
+
+	holidays, err := s.listTeamHolidays(ctx, teamID, startOfWeek(monthStart, s.loc), monthEnd.AddDate(0, 0, -1))
+	if err != nil {
+		return nil, err
+	}
+	holidayNames := make(map[string]string, len(holidays))
+	for _, h := range holidays {
+		holidayNames[h.Date.Format("2006-01-02")] = h.Name
+	}
+
 	weeklyAnchorByDay := map[string]time.Time{}
 	for weekStart := startOfWeek(monthStart, s.loc); weekStart.Before(monthEnd); weekStart = weekStart.AddDate(0, 0, 7) {
 		weekEnd := weekStart.AddDate(0, 0, 6)
@@ -307,6 +432,9 @@ func (s *Store) buildMonthlyTaskStatusByDate(ctx context.Context, teamID, month
 				if task.DeletedAt != nil && task.DeletedAt.Before(dayEnd) {
 					continue
 				}
+				if !task.Window.activeOn(dayStart) {
+					continue
+				}
 				completed = dailyDone[dayKey] != nil && dailyDone[dayKey][task.ID]
 				completionSlots = buildCompletionSlots(1, map[int]*api.TaskCompletionActor{
 					1: dailyActors[dayKey][task.ID],
@@ -323,9 +451,34 @@ func (s *Store) buildMonthlyTaskStatusByDate(ctx context.Context, teamID, month
 				if task.DeletedAt != nil && task.DeletedAt.Before(weekEnd) {
 					continue
 				}
+				if !task.Window.overlapsWeek(weekStart) {
+					continue
+				}
 				weekStartKey := weekStart.Format("2006-01-02")
-				completed = weeklyCounts[weekStartKey] != nil && weeklyCounts[weekStartKey][task.ID] >= task.Required
+				pausedDays := 0
+				for i := 0; i < 7; i++ {
+					day := weekStart.AddDate(0, 0, i)
+					if _, ok := holidayNames[day.Format("2006-01-02")]; ok || !task.Window.activeOn(day) {
+						pausedDays++
+					}
+				}
+				completed = weeklyCounts[weekStartKey][task.ID] >= proratedRequiredCompletions(task.Required, pausedDays)
 				completionSlots = buildCompletionSlots(task.Required, weeklyActors[weekStartKey][task.ID])
+			case api.OneOff:
+				if task.DueOn == nil || !sameDate(*task.DueOn, dayStart) {
+					continue
+				}
+				if task.DeletedAt != nil && task.DeletedAt.Before(dayEnd) {
+					continue
+				}
+				completed = oneOffDoneBy(task.ID, dayEnd)
+				var actor *api.TaskCompletionActor
+				if completed {
+					actor = oneOffActors[task.ID]
+				}
+				completionSlots = buildCompletionSlots(1, map[int]*api.TaskCompletionActor{
+					1: actor,
+				})
 			default:
 				return nil, fmt.Errorf("unknown task type: %s", task.Type)
 			}
@@ -352,10 +505,16 @@ func (s *Store) buildMonthlyTaskStatusByDate(ctx context.Context, teamID, month
 			}
 			return items[i].Title < items[j].Title
 		})
-		groups = append(groups, api.MonthlyTaskStatusGroup{
-			Date:  toDate(dayStart),
-			Items: items,
-		})
+		holidayName, exempt := holidayNames[dayKey]
+		group := api.MonthlyTaskStatusGroup{
+			Date:   toDate(dayStart),
+			Items:  items,
+			Exempt: &exempt,
+		}
+		if exempt {
+			group.HolidayName = &holidayName
+		}
+		groups = append(groups, group)
 	}
 
 	return groups, nil
@@ -0,0 +1,383 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	dbsqlc "github.com/megu/kaji-challenge/backend/internal/db/sqlc"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+	"github.com/megu/kaji-challenge/backend/internal/tasktemplates"
+)
+
+// teamTaskTemplatesMax caps the templates a team can save; built-in templates
+// do not count.
+const teamTaskTemplatesMax = 50
+
+// ListTaskTemplates returns the built-in catalogue followed by the templates
+// saved by the user's team.
+func (s *Store) ListTaskTemplates(ctx context.Context, userID string) ([]api.TaskTemplate, error) {
+	teamID, err := s.primaryTeamLocked(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	builtin := tasktemplates.Builtin()
+	teamTemplates, err := s.q.ListTaskTemplatesByTeamID(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	itemRows, err := s.q.ListTaskTemplateItemsByTeamID(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	itemsByTemplate := map[string][]tasktemplates.Item{}
+	for _, row := range itemRows {
+		itemsByTemplate[row.TemplateID] = append(itemsByTemplate[row.TemplateID], taskTemplateItemFromRow(row))
+	}
+
+	res := make([]api.TaskTemplate, 0, len(builtin)+len(teamTemplates))
+	for _, tmpl := range builtin {
+		res = append(res, taskTemplateToAPI(tmpl, api.Builtin, nil))
+	}
+	for _, row := range teamTemplates {
+		createdAt := row.CreatedAt.Time.In(s.loc)
+		tmpl := tasktemplates.Template{ID: row.ID, Name: row.Name, Description: row.Description, Items: itemsByTemplate[row.ID]}
+		res = append(res, taskTemplateToAPI(tmpl, api.Team, &createdAt))
+	}
+	return res, nil
+}
+
+// CreateTaskTemplate saves a team template, either from the items in the
+// request or by copying existing tasks of the team with their checklists.
+func (s *Store) CreateTaskTemplate(ctx context.Context, userID string, req api.CreateTaskTemplateRequest) (api.TaskTemplate, error) {
+	hasItems := req.Items != nil && len(*req.Items) > 0
+	hasTaskIDs := req.TaskIds != nil && len(*req.TaskIds) > 0
+	if hasItems == hasTaskIDs {
+		return api.TaskTemplate{}, errors.New("invalid template: set either items or taskIds")
+	}
+	teamID, err := s.primaryTeamLocked(ctx, userID)
+	if err != nil {
+		return api.TaskTemplate{}, err
+	}
+	now := s.now()
+	tmpl := tasktemplates.Template{ID: s.nextID("tpl"), Name: req.Name}
+	if req.Description != nil {
+		tmpl.Description = *req.Description
+	}
+	if hasItems {
+		for _, item := range *req.Items {
+			tmpl.Items = append(tmpl.Items, taskTemplateItemFromAPI(item))
+		}
+	}
+
+	if _, err := s.runWithTeamRevisionCAS(
+		ctx,
+		teamID,
+		"task_template",
+		map[string]string{"templateId": tmpl.ID, "action": "create"},
+		func(txCtx context.Context, qtx *dbsqlc.Queries) error {
+			count, err := qtx.CountTaskTemplatesByTeamID(txCtx, teamID)
+			if err != nil {
+				return err
+			}
+			if count >= teamTaskTemplatesMax {
+				return fmt.Errorf("invalid template: a team can save at most %d templates", teamTaskTemplatesMax)
+			}
+			if hasTaskIDs {
+				tmpl.Items, err = s.taskTemplateItemsFromTasksLocked(txCtx, qtx, teamID, *req.TaskIds, dateOnly(now, s.loc))
+				if err != nil {
+					return err
+				}
+			}
+			tmpl, err = tmpl.Normalize()
+			if err != nil {
+				return err
+			}
+			if err := qtx.CreateTaskTemplate(txCtx, dbsqlc.CreateTaskTemplateParams{
+				ID:              tmpl.ID,
+				TeamID:          teamID,
+				Name:            tmpl.Name,
+				Description:     tmpl.Description,
+				CreatedByUserID: userID,
+				CreatedAt:       toPgTimestamptz(now),
+				UpdatedAt:       toPgTimestamptz(now),
+			}); err != nil {
+				return err
+			}
+			for i, item := range tmpl.Items {
+				if err := qtx.CreateTaskTemplateItem(txCtx, s.taskTemplateItemParams(tmpl.ID, i, item)); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	); err != nil {
+		return api.TaskTemplate{}, err
+	}
+	return taskTemplateToAPI(tmpl, api.Team, &now), nil
+}
+
+func (s *Store) DeleteTaskTemplate(ctx context.Context, userID, templateID string) error {
+	if tasktemplates.IsBuiltinID(templateID) {
+		return errors.New("forbidden: built-in templates cannot be deleted")
+	}
+	teamID, err := s.primaryTeamLocked(ctx, userID)
+	if err != nil {
+		return err
+	}
+	_, err = s.runWithTeamRevisionCAS(
+		ctx,
+		teamID,
+		"task_template",
+		map[string]string{"templateId": templateID, "action": "delete"},
+		func(txCtx context.Context, qtx *dbsqlc.Queries) error {
+			if _, err := getTeamTaskTemplateLocked(txCtx, qtx, teamID, templateID); err != nil {
+				return err
+			}
+			_, err := qtx.DeleteTaskTemplate(txCtx, dbsqlc.DeleteTaskTemplateParams{ID: templateID, TeamID: teamID})
+			return err
+		},
+	)
+	return err
+}
+
+// InstantiateTaskTemplate creates every task of the template, with its
+// checklist, in one transaction. One_off tasks are due dueInDays after today.
+func (s *Store) InstantiateTaskTemplate(ctx context.Context, userID, templateID string, req api.InstantiateTaskTemplateRequest) ([]api.Task, error) {
+	teamID, err := s.primaryTeamLocked(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	var created []api.Task
+	_, err = s.runWithTeamRevisionCAS(
+		ctx,
+		teamID,
+		"task",
+		map[string]string{"templateId": templateID, "action": "instantiate"},
+		func(txCtx context.Context, qtx *dbsqlc.Queries) error {
+			tmpl, err := getTeamTaskTemplateLocked(txCtx, qtx, teamID, templateID)
+			if err != nil {
+				return err
+			}
+			tasks, err := s.instantiateTaskTemplateLocked(txCtx, qtx, teamID, tmpl, req.CategoryId)
+			if err != nil {
+				return err
+			}
+			created = make([]api.Task, 0, len(tasks))
+			for _, task := range tasks {
+				created = append(created, task.toAPI())
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// instantiateTaskTemplateLocked inserts the template's tasks and their
+// checklists using the same validation as CreateTask.
+func (s *Store) instantiateTaskTemplateLocked(ctx context.Context, qtx *dbsqlc.Queries, teamID string, tmpl tasktemplates.Template, categoryID *string) ([]taskRecord, error) {
+	today := dateOnly(s.now(), s.loc)
+	tasks := make([]taskRecord, 0, len(tmpl.Items))
+	for i, item := range tmpl.Items {
+		item, err := item.Normalize()
+		if err != nil {
+			return nil, err
+		}
+		req := api.CreateTaskRequest{
+			Title:         item.Title,
+			Type:          api.TaskType(item.Type),
+			PenaltyPoints: item.PenaltyPoints,
+			CategoryId:    categoryID,
+		}
+		if item.Notes != "" {
+			req.Notes = &item.Notes
+		}
+		if req.Type == api.Weekly {
+			req.RequiredCompletionsPerWeek = &item.RequiredCompletionsPerWeek
+		}
+		if req.Type == api.OneOff {
+			dueOn := toDate(today.AddDate(0, 0, item.DueInDays))
+			req.DueOn = &dueOn
+		}
+		task, err := s.newTaskRecord(teamID, req)
+		if err != nil {
+			return nil, fmt.Errorf("%w (task %d)", err, i+1)
+		}
+		task.Revision = nextEntityRevision(ctx)
+		if err := insertTaskLocked(ctx, qtx, task); err != nil {
+			return nil, err
+		}
+		for position, title := range item.Checklist {
+			if err := qtx.CreateTaskChecklistItem(ctx, dbsqlc.CreateTaskChecklistItemParams{
+				ID:        s.nextID("tci"),
+				TaskID:    task.ID,
+				Title:     title,
+				Position:  int32(position),
+				CreatedAt: toPgTimestamptz(task.CreatedAt),
+				UpdatedAt: toPgTimestamptz(task.CreatedAt),
+			}); err != nil {
+				return nil, err
+			}
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+// taskTemplateItemsFromTasksLocked copies tasks of the team into template
+// items. A one_off task keeps its due date as days from today, so a task due
+// in a week becomes dueInDays 7; overdue tasks become due on the day of use.
+func (s *Store) taskTemplateItemsFromTasksLocked(ctx context.Context, qtx *dbsqlc.Queries, teamID string, taskIDs []string, today time.Time) ([]tasktemplates.Item, error) {
+	seen := make(map[string]struct{}, len(taskIDs))
+	items := make([]tasktemplates.Item, 0, len(taskIDs))
+	for _, taskID := range taskIDs {
+		if _, ok := seen[taskID]; ok {
+			return nil, errors.New("invalid taskIds: duplicate task")
+		}
+		seen[taskID] = struct{}{}
+		task, err := getTeamTaskLocked(ctx, qtx, teamID, taskID, s.loc)
+		if err != nil {
+			return nil, err
+		}
+		checklist, err := listTaskChecklistItemsLocked(ctx, qtx, task.ID, s.loc)
+		if err != nil {
+			return nil, err
+		}
+		item := tasktemplates.Item{
+			Title:                      task.Title,
+			Type:                       string(task.Type),
+			PenaltyPoints:              task.Penalty,
+			RequiredCompletionsPerWeek: task.Required,
+			Checklist:                  make([]string, 0, len(checklist)),
+		}
+		if task.Notes != nil {
+			item.Notes = *task.Notes
+		}
+		if task.Type == api.OneOff && task.DueOn != nil {
+			item.DueInDays = max(0, daysBetween(today, *task.DueOn))
+		}
+		for _, checklistItem := range checklist {
+			item.Checklist = append(item.Checklist, checklistItem.Title)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// getTeamTaskTemplateLocked resolves a built-in template by ID, or a template
+// saved by the team.
+func getTeamTaskTemplateLocked(ctx context.Context, qtx *dbsqlc.Queries, teamID, templateID string) (tasktemplates.Template, error) {
+	if tasktemplates.IsBuiltinID(templateID) {
+		tmpl, ok := tasktemplates.BuiltinByID(templateID)
+		if !ok {
+			return tasktemplates.Template{}, errors.New("template not found")
+		}
+		return tmpl, nil
+	}
+	row, err := qtx.GetTaskTemplateByID(ctx, dbsqlc.GetTaskTemplateByIDParams{ID: templateID, TeamID: teamID})
+	if err != nil {
+		return tasktemplates.Template{}, errors.New("template not found")
+	}
+	itemRows, err := qtx.ListTaskTemplateItemsByTemplateID(ctx, row.ID)
+	if err != nil {
+		return tasktemplates.Template{}, err
+	}
+	tmpl := tasktemplates.Template{ID: row.ID, Name: row.Name, Description: row.Description}
+	for _, itemRow := range itemRows {
+		tmpl.Items = append(tmpl.Items, taskTemplateItemFromRow(itemRow))
+	}
+	return tmpl, nil
+}
+
+func (s *Store) taskTemplateItemParams(templateID string, position int, item tasktemplates.Item) dbsqlc.CreateTaskTemplateItemParams {
+	params := dbsqlc.CreateTaskTemplateItemParams{
+		ID:                         s.nextID("tpi"),
+		TemplateID:                 templateID,
+		Position:                   int32(position),
+		Title:                      item.Title,
+		Notes:                      item.Notes,
+		Type:                       item.Type,
+		PenaltyPoints:              int32(item.PenaltyPoints),
+		RequiredCompletionsPerWeek: int32(item.RequiredCompletionsPerWeek),
+		Checklist:                  item.Checklist,
+	}
+	if item.Type == tasktemplates.TypeOneOff {
+		params.DueInDays.Int32 = int32(item.DueInDays)
+		params.DueInDays.Valid = true
+	}
+	return params
+}
+
+func taskTemplateItemFromRow(row dbsqlc.TaskTemplateItem) tasktemplates.Item {
+	return tasktemplates.Item{
+		Title:                      row.Title,
+		Notes:                      row.Notes,
+		Type:                       row.Type,
+		PenaltyPoints:              int(row.PenaltyPoints),
+		RequiredCompletionsPerWeek: int(row.RequiredCompletionsPerWeek),
+		DueInDays:                  int(row.DueInDays.Int32),
+		Checklist:                  append([]string{}, row.Checklist...),
+	}
+}
+
+func taskTemplateItemFromAPI(item api.TaskTemplateItem) tasktemplates.Item {
+	out := tasktemplates.Item{
+		Title:                      item.Title,
+		Type:                       string(item.Type),
+		PenaltyPoints:              item.PenaltyPoints,
+		RequiredCompletionsPerWeek: item.RequiredCompletionsPerWeek,
+		Checklist:                  item.Checklist,
+	}
+	if item.Notes != nil {
+		out.Notes = *item.Notes
+	}
+	if item.DueInDays != nil {
+		out.DueInDays = *item.DueInDays
+	}
+	return out
+}
+
+func taskTemplateToAPI(tmpl tasktemplates.Template, source api.TaskTemplateSource, createdAt *time.Time) api.TaskTemplate {
+	res := api.TaskTemplate{
+		Id:        tmpl.ID,
+		Source:    source,
+		Name:      tmpl.Name,
+		Items:     make([]api.TaskTemplateItem, 0, len(tmpl.Items)),
+		CreatedAt: createdAt,
+	}
+	if tmpl.Description != "" {
+		description := tmpl.Description
+		res.Description = &description
+	}
+	for _, item := range tmpl.Items {
+		out := api.TaskTemplateItem{
+			Title:                      item.Title,
+			Type:                       api.TaskType(item.Type),
+			PenaltyPoints:              item.PenaltyPoints,
+			RequiredCompletionsPerWeek: max(item.RequiredCompletionsPerWeek, 1),
+			Checklist:                  append([]string{}, item.Checklist...),
+		}
+		if item.Notes != "" {
+			notes := item.Notes
+			out.Notes = &notes
+		}
+		if item.Type == tasktemplates.TypeOneOff {
+			dueInDays := item.DueInDays
+			out.DueInDays = &dueInDays
+		}
+		res.Items = append(res.Items, out)
+	}
+	return res
+}
+
+// daysBetween counts calendar days from one date to another, ignoring the
+// time of day and DST shifts.
+func daysBetween(from, to time.Time) int {
+	fromDay := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, time.UTC)
+	toDay := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	return int(toDay.Sub(fromDay).Hours() / 24)
+}
@@ -0,0 +1,105 @@
+package store
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+	"github.com/megu/kaji-challenge/backend/internal/tasktemplates"
+)
+
+func TestDaysBetween(t *testing.T) {
+	jst := time.FixedZone("JST", 9*60*60)
+	from := time.Date(2026, 3, 30, 23, 0, 0, 0, jst)
+	if got := daysBetween(from, time.Date(2026, 4, 6, 1, 0, 0, 0, jst)); got != 7 {
+		t.Fatalf("expected 7 days, got %d", got)
+	}
+	if got := daysBetween(from, time.Date(2026, 3, 28, 0, 0, 0, 0, jst)); got != -2 {
+		t.Fatalf("expected -2 days, got %d", got)
+	}
+}
+
+func TestTaskTemplateToAPI(t *testing.T) {
+	tmpl, _ := tasktemplates.BuiltinByID("builtin-new-apartment-basics")
+	res := taskTemplateToAPI(tmpl, api.Builtin, nil)
+	if res.Id != tmpl.ID || res.Source != api.Builtin || res.CreatedAt != nil || len(res.Items) != len(tmpl.Items) {
+		t.Fatalf("unexpected template %+v", res)
+	}
+	for _, item := range res.Items {
+		if (item.Type == api.OneOff) != (item.DueInDays != nil) || item.Checklist == nil {
+			t.Fatalf("expected dueInDays only on one_off items and a checklist list, got %+v", item)
+		}
+	}
+}
+
+func TestTaskTemplateLifecycle(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	today := time.Date(2026, 4, 8, 0, 0, 0, 0, s.loc)
+	s.SetClock(FixedClock(today.Add(9 * time.Hour)))
+	_, userID := createTeamWithMember(t, s, "templates@example.com", today.AddDate(0, 0, -1))
+
+	tasks, err := s.InstantiateTaskTemplate(withLatestIfMatchForUser(t, s, ctx, userID), userID, "builtin-new-apartment-basics", api.InstantiateTaskTemplateRequest{})
+	if err != nil {
+		t.Fatalf("InstantiateTaskTemplate failed: %v", err)
+	}
+	builtin, _ := tasktemplates.BuiltinByID("builtin-new-apartment-basics")
+	if len(tasks) != len(builtin.Items) {
+		t.Fatalf("expected %d tasks, got %d", len(builtin.Items), len(tasks))
+	}
+	var oneOff api.Task
+	for i, task := range tasks {
+		if task.Title != builtin.Items[i].Title {
+			t.Fatalf("expected tasks in template order, got %q at %d", task.Title, i)
+		}
+		if task.Type == api.OneOff {
+			oneOff = task
+		}
+	}
+	if oneOff.DueOn == nil || !sameDate(oneOff.DueOn.Time, today.AddDate(0, 0, 14)) {
+		t.Fatalf("expected the one_off task due in 14 days, got %+v", oneOff.DueOn)
+	}
+	checklist, err := s.ListTaskChecklistItems(ctx, userID, oneOff.Id)
+	if err != nil || len(checklist) != 4 {
+		t.Fatalf("expected the one_off task's checklist, got %+v, %v", checklist, err)
+	}
+
+	category := "cat-missing"
+	if _, err := s.InstantiateTaskTemplate(withLatestIfMatchForUser(t, s, ctx, userID), userID, "builtin-weekend-reset", api.InstantiateTaskTemplateRequest{CategoryId: &category}); err == nil {
+		t.Fatalf("expected an unknown category to be rejected")
+	}
+	overview, err := s.ListTasks(ctx, userID, nil, nil)
+	if err != nil || len(overview) != len(builtin.Items) {
+		t.Fatalf("expected the failed instantiation to create nothing, got %d tasks, %v", len(overview), err)
+	}
+
+	taskIDs := []string{tasks[1].Id, oneOff.Id}
+	saved, err := s.CreateTaskTemplate(withLatestIfMatchForUser(t, s, ctx, userID), userID, api.CreateTaskTemplateRequest{Name: "Moving out", TaskIds: &taskIDs})
+	if err != nil {
+		t.Fatalf("CreateTaskTemplate failed: %v", err)
+	}
+	if saved.Source != api.Team || len(saved.Items) != 2 || *saved.Items[1].DueInDays != 14 || len(saved.Items[1].Checklist) != 4 {
+		t.Fatalf("expected the tasks copied into the template, got %+v", saved)
+	}
+	if _, err := s.CreateTaskTemplate(withLatestIfMatchForUser(t, s, ctx, userID), userID, api.CreateTaskTemplateRequest{Name: "moving out", TaskIds: &taskIDs}); err == nil ||
+		!strings.Contains(err.Error(), "duplicate key") {
+		t.Fatalf("expected template names to be unique per team, got %v", err)
+	}
+
+	list, err := s.ListTaskTemplates(ctx, userID)
+	if err != nil || len(list) != len(tasktemplates.Builtin())+1 || list[len(list)-1].Id != saved.Id {
+		t.Fatalf("expected built-in templates followed by the saved one, got %d, %v", len(list), err)
+	}
+
+	if err := s.DeleteTaskTemplate(withLatestIfMatchForUser(t, s, ctx, userID), userID, "builtin-weekend-reset"); err == nil || !strings.HasPrefix(err.Error(), "forbidden") {
+		t.Fatalf("expected built-in templates to be read-only, got %v", err)
+	}
+	if err := s.DeleteTaskTemplate(withLatestIfMatchForUser(t, s, ctx, userID), userID, saved.Id); err != nil {
+		t.Fatalf("DeleteTaskTemplate failed: %v", err)
+	}
+	if _, err := s.InstantiateTaskTemplate(withLatestIfMatchForUser(t, s, ctx, userID), userID, saved.Id, api.InstantiateTaskTemplateRequest{}); err == nil || err.Error() != "template not found" {
+		t.Fatalf("expected the deleted template to be gone, got %v", err)
+	}
+}
@@ -7,11 +7,13 @@ import (
 	"strings"
 	"time"
 
+	"github.com/jackc/pgx/v5"
 	dbsqlc "github.com/megu/kaji-challenge/backend/internal/db/sqlc"
 	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
-func (s *Store) ListTasks(ctx context.Context, userID string, filter *api.TaskType) ([]api.Task, error) {
+func (s *Store) ListTasks(ctx context.Context, userID string, filter *api.TaskType, categoryID *string) ([]api.Task, error) {
 	teamID, err := s.primaryTeamLocked(ctx, userID)
 	if err != nil {
 		return nil, err
@@ -26,6 +28,9 @@ func (s *Store) ListTasks(ctx context.Context, userID string, filter *api.TaskTy
 		if filter != nil && t.Type != *filter {
 			continue
 		}
+		if !matchesCategoryFilter(t.CategoryID, categoryID) {
+			continue
+		}
 		items = append(items, t.toAPI())
 	}
 	return items, nil
@@ -36,32 +41,57 @@ func (s *Store) CreateTask(ctx context.Context, userID string, req api.CreateTas
 	if err != nil {
 		return api.Task{}, err
 	}
+	task, err := s.newTaskRecord(teamID, req)
+	if err != nil {
+		return api.Task{}, err
+	}
+	if _, err := s.runWithTeamRevisionCAS(
+		ctx,
+		teamID,
+		"task",
+		map[string]string{"taskId": task.ID, "action": "create"},
+		func(txCtx context.Context, qtx *dbsqlc.Queries) error {
+			task.Revision = nextEntityRevision(txCtx)
+			return insertTaskLocked(txCtx, qtx, task)
+		},
+	); err != nil {
+		return api.Task{}, err
+	}
+	return task.toAPI(), nil
+}
+
+func (s *Store) newTaskRecord(teamID string, req api.CreateTaskRequest) (taskRecord, error) {
 	title := strings.TrimSpace(req.Title)
 	if title == "" {
-		return api.Task{}, errors.New("title is required")
+		return taskRecord{}, errors.New("title is required")
 	}
 
 	required := 1
 	if req.Type == api.Weekly && req.RequiredCompletionsPerWeek != nil {
 		required = *req.RequiredCompletionsPerWeek
 	}
-	required, err = normalizeRequiredCompletionsPerWeek(req.Type, required)
+	required, err := normalizeRequiredCompletionsPerWeek(req.Type, required)
 	if err != nil {
-		return api.Task{}, err
+		return taskRecord{}, err
+	}
+	if _, err := safeInt32(req.PenaltyPoints, "penalty points"); err != nil {
+		return taskRecord{}, err
 	}
-	penalty32, err := safeInt32(req.PenaltyPoints, "penalty points")
+	now := s.now()
+	dueOn, err := s.normalizeTaskDueOn(req.Type, req.DueOn, now)
 	if err != nil {
-		return api.Task{}, err
+		return taskRecord{}, err
 	}
-	required32, err := safeInt32(required, "required completions")
+	if req.Type == api.OneOff && dueOn == nil {
+		return taskRecord{}, errors.New("dueOn is required for one_off tasks")
+	}
+	window, err := s.newTaskWindow(req.Type, req.StartsOn, req.EndsOn, dateOnly(now, s.loc))
 	if err != nil {
-		return api.Task{}, err
+		return taskRecord{}, err
 	}
 
-	now := time.Now().In(s.loc)
-	taskID := s.nextID("tsk")
-	task := taskRecord{
-		ID:         taskID,
+	return taskRecord{
+		ID:         s.nextID("tsk"),
 		TeamID:     teamID,
 		Title:      title,
 		Notes:      req.Notes,
@@ -69,32 +99,63 @@ func (s *Store) CreateTask(ctx context.Context, userID string, req api.CreateTas
 		Penalty:    req.PenaltyPoints,
 		AssigneeID: req.AssigneeUserId,
 		Required:   required,
+		DueOn:      dueOn,
+		Window:     window,
+		CategoryID: req.CategoryId,
 		CreatedAt:  now,
 		UpdatedAt:  now,
+	}, nil
+}
+
+func insertTaskLocked(ctx context.Context, qtx *dbsqlc.Queries, task taskRecord) error {
+	if err := checkTaskCategoryLocked(ctx, qtx, task.TeamID, task.CategoryID); err != nil {
+		return err
 	}
-	if _, err := s.runWithTeamRevisionCAS(
-		ctx,
-		teamID,
-		"task",
-		map[string]string{"taskId": task.ID, "action": "create"},
-		func(_ context.Context, qtx *dbsqlc.Queries) error {
-			return qtx.CreateTask(ctx, dbsqlc.CreateTaskParams{
-				ID:                         task.ID,
-				TeamID:                     task.TeamID,
-				Title:                      task.Title,
-				Notes:                      textFromPtr(task.Notes),
-				Type:                       string(task.Type),
-				PenaltyPoints:              penalty32,
-				Column7:                    uuidStringFromPtr(task.AssigneeID),
-				RequiredCompletionsPerWeek: required32,
-				CreatedAt:                  toPgTimestamptz(task.CreatedAt),
-				UpdatedAt:                  toPgTimestamptz(task.UpdatedAt),
-			})
-		},
-	); err != nil {
-		return api.Task{}, err
+	penalty32, err := safeInt32(task.Penalty, "penalty points")
+	if err != nil {
+		return err
 	}
-	return task.toAPI(), nil
+	required32, err := safeInt32(task.Required, "required completions")
+	if err != nil {
+		return err
+	}
+	if err := qtx.CreateTask(ctx, dbsqlc.CreateTaskParams{
+		ID:                         task.ID,
+		TeamID:                     task.TeamID,
+		Title:                      task.Title,
+		Notes:                      textFromPtr(task.Notes),
+		Type:                       string(task.Type),
+		PenaltyPoints:              penalty32,
+		Column7:                    uuidStringFromPtr(task.AssigneeID),
+		RequiredCompletionsPerWeek: required32,
+		CreatedAt:                  toPgTimestamptz(task.CreatedAt),
+		UpdatedAt:                  toPgTimestamptz(task.UpdatedAt),
+		Revision:                   task.Revision,
+		DueOn:                      pgDateFromPtr(task.DueOn),
+		StartsOn:                   pgDateFromPtr(task.Window.StartsOn),
+		EndsOn:                     pgDateFromPtr(task.Window.EndsOn),
+		Column15:                   uuidStringFromPtr(task.CategoryID),
+	}); err != nil {
+		return err
+	}
+	recordAudit(ctx, auditChange{Entity: "task", EntityID: task.ID, Action: "create", After: task.toAPI()})
+	return nil
+}
+
+// normalizeTaskDueOn validates a requested due date. Only one_off tasks have
+// one, and it may not already be in the past.
+func (s *Store) normalizeTaskDueOn(taskType api.TaskType, dueOn *openapi_types.Date, now time.Time) (*time.Time, error) {
+	if dueOn == nil {
+		return nil, nil
+	}
+	if taskType != api.OneOff {
+		return nil, errors.New("dueOn is only supported for one_off tasks")
+	}
+	day := dateOnly(dueOn.Time, s.loc)
+	if day.Before(dateOnly(now, s.loc)) {
+		return nil, errors.New("dueOn must not be in the past")
+	}
+	return &day, nil
 }
 
 func (s *Store) PatchTask(ctx context.Context, userID, taskID string, req api.UpdateTaskRequest) (api.Task, error) {
@@ -108,59 +169,9 @@ func (s *Store) PatchTask(ctx context.Context, userID, taskID string, req api.Up
 		teamID,
 		"task",
 		map[string]string{"taskId": taskID, "action": "update"},
-		func(_ context.Context, qtx *dbsqlc.Queries) error {
-			row, err := qtx.GetTaskByID(ctx, taskID)
-			if err != nil {
-				return errors.New("task not found")
-			}
-			task = taskFromGetRow(row, s.loc)
-			if task.TeamID != teamID || task.DeletedAt != nil {
-				return errors.New("task not found")
-			}
-			if req.Title != nil {
-				title := strings.TrimSpace(*req.Title)
-				if title == "" {
-					return errors.New("title cannot be empty")
-				}
-				task.Title = title
-			}
-			if req.Notes != nil {
-				task.Notes = req.Notes
-			}
-			if req.PenaltyPoints != nil {
-				task.Penalty = *req.PenaltyPoints
-			}
-			if req.AssigneeUserId != nil {
-				task.AssigneeID = req.AssigneeUserId
-			}
-			if req.RequiredCompletionsPerWeek != nil && task.Type == api.Weekly {
-				required, err := normalizeRequiredCompletionsPerWeek(
-					task.Type,
-					*req.RequiredCompletionsPerWeek,
-				)
-				if err != nil {
-					return err
-				}
-				task.Required = required
-			}
-			task.UpdatedAt = time.Now().In(s.loc)
-			penalty32, err := safeInt32(task.Penalty, "penalty points")
-			if err != nil {
-				return err
-			}
-			required32, err := safeInt32(task.Required, "required completions")
-			if err != nil {
-				return err
-			}
-			return qtx.UpdateTask(ctx, dbsqlc.UpdateTaskParams{
-				ID:                         task.ID,
-				Title:                      task.Title,
-				Notes:                      textFromPtr(task.Notes),
-				PenaltyPoints:              penalty32,
-				Column5:                    uuidStringFromPtr(task.AssigneeID),
-				RequiredCompletionsPerWeek: required32,
-				UpdatedAt:                  toPgTimestamptz(task.UpdatedAt),
-			})
+		func(txCtx context.Context, qtx *dbsqlc.Queries) error {
+			task, err = s.updateTaskLocked(txCtx, qtx, teamID, taskID, req)
+			return err
 		},
 	); err != nil {
 		return api.Task{}, err
@@ -168,8 +179,102 @@ func (s *Store) PatchTask(ctx context.Context, userID, taskID string, req api.Up
 	return task.toAPI(), nil
 }
 
+func (s *Store) updateTaskLocked(ctx context.Context, qtx *dbsqlc.Queries, teamID, taskID string, req api.UpdateTaskRequest) (taskRecord, error) {
+	row, err := qtx.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return taskRecord{}, errors.New("task not found")
+	}
+	task := taskFromGetRow(row, s.loc)
+	if task.TeamID != teamID || task.DeletedAt != nil {
+		return taskRecord{}, errors.New("task not found")
+	}
+	if err := checkEntityIfMatch(ctx, etagKindTask, task.ID, task.Revision); err != nil {
+		return taskRecord{}, err
+	}
+	before := task.toAPI()
+	if req.Title != nil {
+		title := strings.TrimSpace(*req.Title)
+		if title == "" {
+			return taskRecord{}, errors.New("title cannot be empty")
+		}
+		task.Title = title
+	}
+	if req.Notes != nil {
+		task.Notes = req.Notes
+	}
+	if req.PenaltyPoints != nil {
+		task.Penalty = *req.PenaltyPoints
+	}
+	if req.AssigneeUserId != nil {
+		task.AssigneeID = req.AssigneeUserId
+	}
+	if req.DueOn != nil && (task.DueOn == nil || !sameDate(*task.DueOn, dateOnly(req.DueOn.Time, s.loc))) {
+		// A past due date has been settled by its close, so it stays put
+		// like the bounds of an active window.
+		if task.DueOn != nil && task.DueOn.Before(dateOnly(s.now(), s.loc)) {
+			return taskRecord{}, errors.New("dueOn cannot be changed after the task was due")
+		}
+		dueOn, err := s.normalizeTaskDueOn(task.Type, req.DueOn, s.now())
+		if err != nil {
+			return taskRecord{}, err
+		}
+		task.DueOn = dueOn
+	}
+	window, err := s.updateTaskWindow(task, req, dateOnly(s.now(), s.loc))
+	if err != nil {
+		return taskRecord{}, err
+	}
+	task.Window = window
+	if req.CategoryId != nil {
+		if err := checkTaskCategoryLocked(ctx, qtx, teamID, req.CategoryId); err != nil {
+			return taskRecord{}, err
+		}
+		task.CategoryID = req.CategoryId
+	} else if req.ClearCategoryId != nil && *req.ClearCategoryId {
+		task.CategoryID = nil
+	}
+	if req.RequiredCompletionsPerWeek != nil && task.Type == api.Weekly {
+		required, err := normalizeRequiredCompletionsPerWeek(
+			task.Type,
+			*req.RequiredCompletionsPerWeek,
+		)
+		if err != nil {
+			return taskRecord{}, err
+		}
+		task.Required = required
+	}
+	task.UpdatedAt = s.now()
+	task.Revision = nextEntityRevision(ctx)
+	penalty32, err := safeInt32(task.Penalty, "penalty points")
+	if err != nil {
+		return taskRecord{}, err
+	}
+	required32, err := safeInt32(task.Required, "required completions")
+	if err != nil {
+		return taskRecord{}, err
+	}
+	if err := qtx.UpdateTask(ctx, dbsqlc.UpdateTaskParams{
+		ID:                         task.ID,
+		Title:                      task.Title,
+		Notes:                      textFromPtr(task.Notes),
+		PenaltyPoints:              penalty32,
+		Column5:                    uuidStringFromPtr(task.AssigneeID),
+		RequiredCompletionsPerWeek: required32,
+		UpdatedAt:                  toPgTimestamptz(task.UpdatedAt),
+		Revision:                   task.Revision,
+		DueOn:                      pgDateFromPtr(task.DueOn),
+		StartsOn:                   pgDateFromPtr(task.Window.StartsOn),
+		EndsOn:                     pgDateFromPtr(task.Window.EndsOn),
+		Column12:                   uuidStringFromPtr(task.CategoryID),
+	}); err != nil {
+		return taskRecord{}, err
+	}
+	recordAudit(ctx, auditChange{Entity: "task", EntityID: task.ID, Action: "update", Before: before, After: task.toAPI()})
+	return task, nil
+}
+
 func normalizeRequiredCompletionsPerWeek(taskType api.TaskType, required int) (int, error) {
-	if taskType == api.Daily {
+	if taskType != api.Weekly {
 		return requiredCompletionsPerWeekMin, nil
 	}
 	if required < requiredCompletionsPerWeekMin || required > requiredCompletionsPerWeekMax {
@@ -192,182 +297,314 @@ func (s *Store) DeleteTask(ctx context.Context, userID, taskID string) error {
 		teamID,
 		"task",
 		map[string]string{"taskId": taskID, "action": "delete"},
-		func(_ context.Context, qtx *dbsqlc.Queries) error {
-			row, err := qtx.GetTaskByID(ctx, taskID)
-			if err != nil {
-				return errors.New("task not found")
-			}
-			task := taskFromGetRow(row, s.loc)
-			if task.TeamID != teamID || task.DeletedAt != nil {
-				return errors.New("task not found")
-			}
-			return qtx.DeleteTask(ctx, taskID)
+		func(txCtx context.Context, qtx *dbsqlc.Queries) error {
+			return s.deleteTaskLocked(txCtx, qtx, teamID, taskID)
 		},
 	)
 	return err
 }
 
+func (s *Store) deleteTaskLocked(ctx context.Context, qtx *dbsqlc.Queries, teamID, taskID string) error {
+	row, err := qtx.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return errors.New("task not found")
+	}
+	task := taskFromGetRow(row, s.loc)
+	if task.TeamID != teamID || task.DeletedAt != nil {
+		return errors.New("task not found")
+	}
+	if err := checkEntityIfMatch(ctx, etagKindTask, task.ID, task.Revision); err != nil {
+		return err
+	}
+	if err := qtx.UpdateTaskRevision(ctx, dbsqlc.UpdateTaskRevisionParams{
+		ID:       taskID,
+		Revision: nextEntityRevision(ctx),
+	}); err != nil {
+		return err
+	}
+	if err := qtx.DeleteTask(ctx, dbsqlc.DeleteTaskParams{
+		ID:        taskID,
+		DeletedAt: toPgTimestamptz(s.now()),
+	}); err != nil {
+		return err
+	}
+	recordAudit(ctx, auditChange{Entity: "task", EntityID: taskID, Action: "delete", Before: task.toAPI()})
+	return nil
+}
+
 func (s *Store) ToggleTaskCompletion(ctx context.Context, userID, taskID string, target time.Time, action *api.ToggleTaskCompletionRequestAction) (api.TaskCompletionResponse, error) {
 	teamID, err := s.primaryTeamLocked(ctx, userID)
 	if err != nil {
 		return api.TaskCompletionResponse{}, err
 	}
-	mode := api.Toggle
-	if action != nil {
-		mode = *action
-		if mode == "" {
-			mode = api.Toggle
-		}
-	}
-	actionName := string(mode)
+	mode := completionActionOrDefault(action)
 	res := api.TaskCompletionResponse{}
 	if _, err := s.runWithTeamRevisionCAS(
 		ctx,
 		teamID,
 		"task_completion",
-		map[string]string{"taskId": taskID, "action": actionName},
+		map[string]string{"taskId": taskID, "action": string(mode)},
 		func(txCtx context.Context, _ *dbsqlc.Queries) error {
-			q := s.queries(txCtx)
-			row, err := q.GetTaskByID(txCtx, taskID)
+			res, err = s.toggleTaskCompletionLocked(txCtx, s.queries(txCtx), teamID, userID, taskID, target, mode)
+			return err
+		},
+	); err != nil {
+		return api.TaskCompletionResponse{}, err
+	}
+	return res, nil
+}
+
+// completionTargetDate validates the date a completion or checklist toggle
+// applies to: today for daily and one_off tasks, any day of the current week
+// for weekly tasks, and only while the task is active.
+func (s *Store) completionTargetDate(task taskRecord, target time.Time) (time.Time, error) {
+	today := dateOnly(s.now(), s.loc)
+	targetDate := dateOnly(target.In(s.loc), s.loc)
+	switch task.Type {
+	case api.Daily:
+		if !sameDate(targetDate, today) {
+			return time.Time{}, errors.New("daily completion can only be toggled for today")
+		}
+		if !task.Window.activeOn(today) {
+			return time.Time{}, errors.New("task is not active on the target date")
+		}
+	case api.OneOff:
+		if !sameDate(targetDate, today) {
+			return time.Time{}, errors.New("one_off completion can only be toggled for today")
+		}
+	case api.Weekly:
+		weekStart := startOfWeek(today, s.loc)
+		weekEnd := weekStart.AddDate(0, 0, 6)
+		if targetDate.Before(weekStart) || targetDate.After(weekEnd) {
+			return time.Time{}, errors.New("weekly completion can only be toggled within current week")
+		}
+		if !task.Window.overlapsWeek(weekStart) {
+			return time.Time{}, errors.New("task is not active on the target date")
+		}
+	}
+	return targetDate, nil
+}
+
+func completionActionOrDefault(action *api.ToggleTaskCompletionRequestAction) api.ToggleTaskCompletionRequestAction {
+	if action == nil || *action == "" {
+		return api.Toggle
+	}
+	return *action
+}
+
+func (s *Store) toggleTaskCompletionLocked(
+	ctx context.Context,
+	q *dbsqlc.Queries,
+	teamID, userID, taskID string,
+	target time.Time,
+	mode api.ToggleTaskCompletionRequestAction,
+) (api.TaskCompletionResponse, error) {
+	row, err := q.GetTaskByID(ctx, taskID)
+	if err != nil {
+		return api.TaskCompletionResponse{}, errors.New("task not found")
+	}
+	task := taskFromGetRow(row, s.loc)
+	if task.TeamID != teamID || task.DeletedAt != nil {
+		return api.TaskCompletionResponse{}, errors.New("task not found")
+	}
+	if err := checkEntityIfMatch(ctx, etagKindTask, task.ID, task.Revision); err != nil {
+		return api.TaskCompletionResponse{}, err
+	}
+	// Completions are part of the task's state, so they move its revision.
+	if err := q.UpdateTaskRevision(ctx, dbsqlc.UpdateTaskRevisionParams{
+		ID:       taskID,
+		Revision: nextEntityRevision(ctx),
+	}); err != nil {
+		return api.TaskCompletionResponse{}, err
+	}
+	targetDate, err := s.completionTargetDate(task, target)
+	if err != nil {
+		return api.TaskCompletionResponse{}, err
+	}
+	if task.Type == api.OneOff {
+		return s.toggleOneOffCompletionLocked(ctx, q, userID, task, targetDate, mode)
+	}
+
+	targetPg := toPgDate(targetDate)
+	if task.Type == api.Daily {
+		if mode != api.Toggle {
+			return api.TaskCompletionResponse{}, errors.New("daily tasks only support toggle action")
+		}
+		exists, err := q.HasTaskCompletionDaily(ctx, dbsqlc.HasTaskCompletionDailyParams{
+			TaskID:     taskID,
+			TargetDate: targetPg,
+		})
+		if err != nil {
+			return api.TaskCompletionResponse{}, err
+		}
+		if exists {
+			if err := q.DeleteTaskCompletionDaily(ctx, dbsqlc.DeleteTaskCompletionDailyParams{
+				TaskID:     taskID,
+				TargetDate: targetPg,
+			}); err != nil {
+				return api.TaskCompletionResponse{}, err
+			}
+		} else {
+			if err := s.requireChecklistCheckedLocked(ctx, q, task, targetDate); err != nil {
+				return api.TaskCompletionResponse{}, err
+			}
+			if err := q.CreateTaskCompletionDaily(ctx, dbsqlc.CreateTaskCompletionDailyParams{
+				TaskID:            taskID,
+				TargetDate:        targetPg,
+				CompletedByUserID: userID,
+			}); err != nil {
+				return api.TaskCompletionResponse{}, err
+			}
+		}
+		res := api.TaskCompletionResponse{
+			TaskId:               taskID,
+			TargetDate:           toDate(targetDate),
+			Completed:            !exists,
+			WeeklyCompletedCount: 0,
+		}
+		recordCompletionAudit(ctx, string(mode), exists, 0, res)
+		return res, nil
+	}
+
+	weekStart := startOfWeek(targetDate, s.loc)
+	weekStartPg := toPgDate(weekStart)
+	currentCount, err := q.GetTaskCompletionWeeklyEntryCount(ctx, dbsqlc.GetTaskCompletionWeeklyEntryCountParams{
+		TaskID:    taskID,
+		WeekStart: weekStartPg,
+	})
+	if err != nil {
+		return api.TaskCompletionResponse{}, err
+	}
+	nextCount := currentCount
+	if task.Required <= 1 {
+		if mode != api.Toggle {
+			return api.TaskCompletionResponse{}, errors.New("weekly tasks with required completions of 1 only support toggle action")
+		}
+		if currentCount > 0 {
+			deletedRows, err := q.DeleteLatestTaskCompletionWeeklyEntry(ctx, dbsqlc.DeleteLatestTaskCompletionWeeklyEntryParams{
+				TaskID:    taskID,
+				WeekStart: weekStartPg,
+			})
 			if err != nil {
-				return errors.New("task not found")
+				return api.TaskCompletionResponse{}, err
 			}
-			task := taskFromGetRow(row, s.loc)
-			if task.TeamID != teamID || task.DeletedAt != nil {
-				return errors.New("task not found")
+			if deletedRows > 0 {
+				nextCount = currentCount - 1
 			}
-			today := dateOnly(time.Now().In(s.loc), s.loc)
-			targetDate := dateOnly(target.In(s.loc), s.loc)
-			if task.Type == api.Daily && !sameDate(targetDate, today) {
-				return errors.New("daily completion can only be toggled for today")
+		} else {
+			if err := s.requireChecklistCheckedLocked(ctx, q, task, targetDate); err != nil {
+				return api.TaskCompletionResponse{}, err
 			}
-			if task.Type == api.Weekly {
-				weekStart := startOfWeek(today, s.loc)
-				weekEnd := weekStart.AddDate(0, 0, 6)
-				if targetDate.Before(weekStart) || targetDate.After(weekEnd) {
-					return errors.New("weekly completion can only be toggled within current week")
-				}
+			if err := q.InsertTaskCompletionWeeklyEntry(ctx, dbsqlc.InsertTaskCompletionWeeklyEntryParams{
+				ID:                s.nextID("twce"),
+				TaskID:            taskID,
+				WeekStart:         weekStartPg,
+				CompletedByUserID: userID,
+			}); err != nil {
+				return api.TaskCompletionResponse{}, err
 			}
-
-			targetPg := toPgDate(targetDate)
-			if task.Type == api.Daily {
-				if mode != api.Toggle {
-					return errors.New("daily tasks only support toggle action")
-				}
-				exists, err := q.HasTaskCompletionDaily(txCtx, dbsqlc.HasTaskCompletionDailyParams{
-					TaskID:     taskID,
-					TargetDate: targetPg,
-				})
-				if err != nil {
-					return err
-				}
-				if exists {
-					if err := q.DeleteTaskCompletionDaily(txCtx, dbsqlc.DeleteTaskCompletionDailyParams{
-						TaskID:     taskID,
-						TargetDate: targetPg,
-					}); err != nil {
-						return err
-					}
-				} else {
-					if err := q.CreateTaskCompletionDaily(txCtx, dbsqlc.CreateTaskCompletionDailyParams{
-						TaskID:            taskID,
-						TargetDate:        targetPg,
-						CompletedByUserID: userID,
-					}); err != nil {
-						return err
-					}
-				}
-				res = api.TaskCompletionResponse{
-					TaskId:               taskID,
-					TargetDate:           toDate(targetDate),
-					Completed:            !exists,
-					WeeklyCompletedCount: 0,
-				}
-				return nil
+			nextCount = 1
+		}
+	} else {
+		switch mode {
+		case api.Toggle, api.Increment:
+			if currentCount >= int64(task.Required) {
+				nextCount = currentCount
+				break
 			}
-
-			weekStart := startOfWeek(targetDate, s.loc)
-			weekStartPg := toPgDate(weekStart)
-			currentCount, err := q.GetTaskCompletionWeeklyEntryCount(txCtx, dbsqlc.GetTaskCompletionWeeklyEntryCountParams{
+			if err := s.requireChecklistCheckedLocked(ctx, q, task, targetDate); err != nil {
+				return api.TaskCompletionResponse{}, err
+			}
+			if err := q.InsertTaskCompletionWeeklyEntry(ctx, dbsqlc.InsertTaskCompletionWeeklyEntryParams{
+				ID:                s.nextID("twce"),
+				TaskID:            taskID,
+				WeekStart:         weekStartPg,
+				CompletedByUserID: userID,
+			}); err != nil {
+				return api.TaskCompletionResponse{}, err
+			}
+			nextCount = currentCount + 1
+		case api.Decrement:
+			if currentCount <= 0 {
+				nextCount = 0
+				break
+			}
+			deletedRows, err := q.DeleteLatestTaskCompletionWeeklyEntry(ctx, dbsqlc.DeleteLatestTaskCompletionWeeklyEntryParams{
 				TaskID:    taskID,
 				WeekStart: weekStartPg,
 			})
 			if err != nil {
-				return err
+				return api.TaskCompletionResponse{}, err
 			}
-			nextCount := currentCount
-			if task.Required <= 1 {
-				if mode != api.Toggle {
-					return errors.New("weekly tasks with required completions of 1 only support toggle action")
-				}
-				if currentCount > 0 {
-					deletedRows, err := q.DeleteLatestTaskCompletionWeeklyEntry(txCtx, dbsqlc.DeleteLatestTaskCompletionWeeklyEntryParams{
-						TaskID:    taskID,
-						WeekStart: weekStartPg,
-					})
-					if err != nil {
-						return err
-					}
-					if deletedRows > 0 {
-						nextCount = currentCount - 1
-					}
-				} else {
-					if err := q.InsertTaskCompletionWeeklyEntry(txCtx, dbsqlc.InsertTaskCompletionWeeklyEntryParams{
-						ID:                s.nextID("twce"),
-						TaskID:            taskID,
-						WeekStart:         weekStartPg,
-						CompletedByUserID: userID,
-					}); err != nil {
-						return err
-					}
-					nextCount = 1
-				}
-			} else {
-				switch mode {
-				case api.Toggle, api.Increment:
-					if currentCount >= int64(task.Required) {
-						nextCount = currentCount
-						break
-					}
-					if err := q.InsertTaskCompletionWeeklyEntry(txCtx, dbsqlc.InsertTaskCompletionWeeklyEntryParams{
-						ID:                s.nextID("twce"),
-						TaskID:            taskID,
-						WeekStart:         weekStartPg,
-						CompletedByUserID: userID,
-					}); err != nil {
-						return err
-					}
-					nextCount = currentCount + 1
-				case api.Decrement:
-					if currentCount <= 0 {
-						nextCount = 0
-						break
-					}
-					deletedRows, err := q.DeleteLatestTaskCompletionWeeklyEntry(txCtx, dbsqlc.DeleteLatestTaskCompletionWeeklyEntryParams{
-						TaskID:    taskID,
-						WeekStart: weekStartPg,
-					})
-					if err != nil {
-						return err
-					}
-					if deletedRows > 0 {
-						nextCount = currentCount - 1
-					}
-				default:
-					return errors.New("invalid completion action")
-				}
+			if deletedRows > 0 {
+				nextCount = currentCount - 1
 			}
+		default:
+			return api.TaskCompletionResponse{}, errors.New("invalid completion action")
+		}
+	}
 
-			res = api.TaskCompletionResponse{
-				TaskId:               taskID,
-				TargetDate:           toDate(targetDate),
-				Completed:            nextCount > 0,
-				WeeklyCompletedCount: int(nextCount),
-			}
-			return nil
-		},
-	); err != nil {
+	res := api.TaskCompletionResponse{
+		TaskId:               taskID,
+		TargetDate:           toDate(targetDate),
+		Completed:            nextCount > 0,
+		WeeklyCompletedCount: int(nextCount),
+	}
+	recordCompletionAudit(ctx, string(mode), currentCount > 0, int(currentCount), res)
+	return res, nil
+}
+
+// toggleOneOffCompletionLocked records or clears the single completion of a
+// one_off task. A completion after the due date no longer avoids the penalty
+// once that day has been closed, but still marks the chore as done.
+func (s *Store) toggleOneOffCompletionLocked(
+	ctx context.Context,
+	q *dbsqlc.Queries,
+	userID string,
+	task taskRecord,
+	today time.Time,
+	mode api.ToggleTaskCompletionRequestAction,
+) (api.TaskCompletionResponse, error) {
+	if mode != api.Toggle {
+		return api.TaskCompletionResponse{}, errors.New("one_off tasks only support toggle action")
+	}
+	taskID := task.ID
+	_, err := q.GetTaskCompletionOneOff(ctx, taskID)
+	exists := err == nil
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
 		return api.TaskCompletionResponse{}, err
 	}
+	if exists {
+		if err := q.DeleteTaskCompletionOneOff(ctx, taskID); err != nil {
+			return api.TaskCompletionResponse{}, err
+		}
+	} else {
+		if err := s.requireChecklistCheckedLocked(ctx, q, task, today); err != nil {
+			return api.TaskCompletionResponse{}, err
+		}
+		if err := q.CreateTaskCompletionOneOff(ctx, dbsqlc.CreateTaskCompletionOneOffParams{
+			TaskID:            taskID,
+			CompletedByUserID: userID,
+			CompletedAt:       toPgTimestamptz(s.now()),
+		}); err != nil {
+			return api.TaskCompletionResponse{}, err
+		}
+	}
+	res := api.TaskCompletionResponse{
+		TaskId:               taskID,
+		TargetDate:           toDate(today),
+		Completed:            !exists,
+		WeeklyCompletedCount: 0,
+	}
+	recordCompletionAudit(ctx, string(mode), exists, 0, res)
 	return res, nil
 }
+
+// recordCompletionAudit records a completion change; the state before it is
+// the response with the completion and count the toggle started from.
+func recordCompletionAudit(ctx context.Context, action string, completed bool, count int, res api.TaskCompletionResponse) {
+	before := res
+	before.Completed = completed
+	before.WeeklyCompletedCount = count
+	recordAudit(ctx, auditChange{Entity: "task_completion", EntityID: res.TaskId, Action: action, Before: before, After: res})
+}
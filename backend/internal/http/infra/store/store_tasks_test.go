@@ -0,0 +1,160 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func TestNormalizeTaskDueOn(t *testing.T) {
+	s := &Store{loc: time.UTC}
+	now := time.Date(2026, 2, 10, 21, 0, 0, 0, time.UTC)
+	today := toDate(now)
+	yesterday := toDate(now.AddDate(0, 0, -1))
+
+	got, err := s.normalizeTaskDueOn(api.OneOff, &today, now)
+	if err != nil || got == nil || !sameDate(*got, now) {
+		t.Fatalf("expected today to be a valid due date, got %v, %v", got, err)
+	}
+	if _, err := s.normalizeTaskDueOn(api.OneOff, &yesterday, now); err == nil || err.Error() != "dueOn must not be in the past" {
+		t.Fatalf("expected a past due date to be rejected, got %v", err)
+	}
+	if _, err := s.normalizeTaskDueOn(api.Daily, &today, now); err == nil || err.Error() != "dueOn is only supported for one_off tasks" {
+		t.Fatalf("expected a due date on a daily task to be rejected, got %v", err)
+	}
+	if got, err := s.normalizeTaskDueOn(api.Weekly, nil, now); err != nil || got != nil {
+		t.Fatalf("expected no due date for a weekly task, got %v, %v", got, err)
+	}
+}
+
+func TestOneOffTaskPenalisedAtDueDateClose(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	today := time.Date(2026, 2, 3, 0, 0, 0, 0, s.loc)
+	s.SetClock(FixedClock(today.Add(9 * time.Hour)))
+
+	teamID, userID := createTeamWithMember(t, s, "one-off@example.com", today.AddDate(0, 0, -1))
+	dueToday := toDate(today)
+	dueLater := toDate(today.AddDate(0, 0, 3))
+	done, err := s.CreateTask(withLatestIfMatchForUser(t, s, ctx, userID), userID, api.CreateTaskRequest{
+		Title: "Renew passport", Type: api.OneOff, PenaltyPoints: 4, DueOn: &dueToday,
+	})
+	if err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+	missed, err := s.CreateTask(withLatestIfMatchForUser(t, s, ctx, userID), userID, api.CreateTaskRequest{
+		Title: "File taxes", Type: api.OneOff, PenaltyPoints: 5, DueOn: &dueToday,
+	})
+	if err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+	if _, err := s.CreateTask(withLatestIfMatchForUser(t, s, ctx, userID), userID, api.CreateTaskRequest{
+		Title: "Book dentist", Type: api.OneOff, PenaltyPoints: 1, DueOn: &dueLater,
+	}); err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+	if _, err := s.CreateTask(withLatestIfMatchForUser(t, s, ctx, userID), userID, api.CreateTaskRequest{
+		Title: "No date", Type: api.OneOff, PenaltyPoints: 1,
+	}); err == nil || err.Error() != "dueOn is required for one_off tasks" {
+		t.Fatalf("expected a one_off task without dueOn to be rejected, got %v", err)
+	}
+
+	if _, err := s.ToggleTaskCompletion(withLatestIfMatchForUser(t, s, ctx, userID), userID, done.Id, today, nil); err != nil {
+		t.Fatalf("ToggleTaskCompletion failed: %v", err)
+	}
+
+	overview, err := s.GetTaskOverview(ctx, userID, nil)
+	if err != nil {
+		t.Fatalf("GetTaskOverview failed: %v", err)
+	}
+	if overview.OneOffTasks == nil || len(*overview.OneOffTasks) != 3 {
+		t.Fatalf("expected 3 one-off tasks in the overview, got %+v", overview.OneOffTasks)
+	}
+	for _, item := range *overview.OneOffTasks {
+		if item.Task.Id == done.Id && (!item.Completed || item.CompletedBy == nil) {
+			t.Fatalf("expected the completed one-off task to carry its actor, got %+v", item)
+		}
+		if item.Task.Title == "Book dentist" && item.DaysUntilDue != 3 {
+			t.Fatalf("expected 3 days until due, got %d", item.DaysUntilDue)
+		}
+	}
+
+	if _, err := s.closeDayForTargetLocked(ctx, today, teamID); err != nil {
+		t.Fatalf("closeDayForTargetLocked failed: %v", err)
+	}
+	feb := getMonthSummary(t, s, teamID, "2026-02")
+	if feb.DailyPenaltyTotal != 5 {
+		t.Fatalf("expected only the missed one-off task to be penalised, got daily total=%d", feb.DailyPenaltyTotal)
+	}
+	if _, err := s.closeDayForTargetLocked(ctx, today, teamID); err != nil {
+		t.Fatalf("closeDayForTargetLocked rerun failed: %v", err)
+	}
+	if feb := getMonthSummary(t, s, teamID, "2026-02"); feb.DailyPenaltyTotal != 5 {
+		t.Fatalf("expected the rerun to be deduplicated, got daily total=%d", feb.DailyPenaltyTotal)
+	}
+
+	s.SetClock(FixedClock(today.AddDate(0, 0, 1).Add(9 * time.Hour)))
+	overview, err = s.GetTaskOverview(ctx, userID, nil)
+	if err != nil {
+		t.Fatalf("GetTaskOverview failed: %v", err)
+	}
+	for _, item := range *overview.OneOffTasks {
+		if item.Task.Id == done.Id {
+			t.Fatalf("expected the completed task to drop off the overview after its due date")
+		}
+		if item.Task.Id == missed.Id && (!item.Overdue || item.DaysUntilDue != -1) {
+			t.Fatalf("expected the missed task to be overdue by one day, got %+v", item)
+		}
+	}
+	if _, err := s.ToggleTaskCompletion(withLatestIfMatchForUser(t, s, ctx, userID), userID, missed.Id, today, nil); err == nil {
+		t.Fatalf("expected completing a one-off task for a past date to be rejected")
+	}
+	if _, err := s.PatchTask(withLatestIfMatchForUser(t, s, ctx, userID), userID, missed.Id, api.UpdateTaskRequest{DueOn: &dueLater}); err == nil ||
+		err.Error() != "dueOn cannot be changed after the task was due" {
+		t.Fatalf("expected a past due date to be frozen, got %v", err)
+	}
+	if _, err := s.ToggleTaskCompletion(withLatestIfMatchForUser(t, s, ctx, userID), userID, missed.Id, today.AddDate(0, 0, 1), nil); err != nil {
+		t.Fatalf("ToggleTaskCompletion failed: %v", err)
+	}
+	groups, err := s.buildMonthlyTaskStatusByDate(ctx, teamID, "2026-02")
+	if err != nil {
+		t.Fatalf("buildMonthlyTaskStatusByDate failed: %v", err)
+	}
+	for _, group := range groups {
+		for _, item := range group.Items {
+			if item.TaskId == done.Id && !item.Completed {
+				t.Fatalf("expected the on-time completion to show as done on %s", group.Date)
+			}
+			if item.TaskId == missed.Id && (item.Completed || item.CompletionSlots[0].Actor != nil) {
+				t.Fatalf("expected the late completion to leave %s missed, got %+v", group.Date, item)
+			}
+		}
+	}
+}
+
+func TestOneOffTaskExemptOnHoliday(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	today := time.Date(2026, 2, 11, 0, 0, 0, 0, s.loc)
+	s.SetClock(FixedClock(today.Add(9 * time.Hour)))
+
+	teamID, userID := createTeamWithMember(t, s, "one-off-holiday@example.com", today.AddDate(0, 0, -1))
+	dueToday := toDate(today)
+	if _, err := s.CreateTask(withLatestIfMatchForUser(t, s, ctx, userID), userID, api.CreateTaskRequest{
+		Title: "Renew passport", Type: api.OneOff, PenaltyPoints: 4, DueOn: &dueToday,
+	}); err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+	if _, err := s.CreateTeamHoliday(withLatestIfMatchForUser(t, s, ctx, userID), userID, api.CreateTeamHolidayRequest{Date: dueToday, Name: "National Foundation Day"}); err != nil {
+		t.Fatalf("CreateTeamHoliday failed: %v", err)
+	}
+
+	if _, err := s.closeDayForTargetLocked(ctx, today, teamID); err != nil {
+		t.Fatalf("closeDayForTargetLocked failed: %v", err)
+	}
+	if feb := getMonthSummary(t, s, teamID, "2026-02"); feb.DailyPenaltyTotal != 0 {
+		t.Fatalf("expected a one-off task due on a holiday to be exempt, got daily total=%d", feb.DailyPenaltyTotal)
+	}
+}
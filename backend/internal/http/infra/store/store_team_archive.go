@@ -0,0 +1,1266 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	dbsqlc "github.com/megu/kaji-challenge/backend/internal/db/sqlc"
+	"github.com/megu/kaji-challenge/backend/internal/tasktemplates"
+)
+
+// TeamArchiveFormat and TeamArchiveVersion identify an archive written by
+// ExportTeam. Bump the version whenever the archive layout changes; older
+// versions that are still readable stay importable from
+// teamArchiveMinVersion on.
+const (
+	TeamArchiveFormat  = "kaji-challenge.team-archive"
+	TeamArchiveVersion = 10
+
+	// Versions 1 to 5 predate one-off tasks, task active windows, task
+	// categories, checklists or task templates and import unchanged. Version
+	// 6 and older evaluations lack the charged points and category, which
+	// are taken from the task on import; version 7 ones lack the category
+	// name, which is taken from the archived category. Versions 8 and 9
+	// predate absences or the holiday calendar and import without them.
+	teamArchiveMinVersion = 1
+)
+
+const archiveDateLayout = "2006-01-02"
+
+// TeamArchive is a self-contained copy of one team. IDs are the exporting
+// environment's; ImportTeam assigns new ones. Dates are YYYY-MM-DD.
+type TeamArchive struct {
+	Format            string                    `json:"format"`
+	Version           int                       `json:"version"`
+	ExportedAt        time.Time                 `json:"exportedAt"`
+	Team              ArchiveTeam               `json:"team"`
+	Members           []ArchiveMember           `json:"members"`
+	Absences          []ArchiveAbsence          `json:"absences"`
+	Holidays          []ArchiveHoliday          `json:"holidays"`
+	TaskCategories    []ArchiveTaskCategory     `json:"taskCategories"`
+	Tasks             []ArchiveTask             `json:"tasks"`
+	ChecklistItems    []ArchiveChecklistItem    `json:"checklistItems"`
+	TaskTemplates     []ArchiveTaskTemplate     `json:"taskTemplates"`
+	DailyCompletions  []ArchiveDailyCompletion  `json:"dailyCompletions"`
+	WeeklyCompletions []ArchiveWeeklyCompletion `json:"weeklyCompletions"`
+	OneOffCompletions []ArchiveOneOffCompletion `json:"oneOffCompletions"`
+	PenaltyRules      []ArchivePenaltyRule      `json:"penaltyRules"`
+	MonthlySummaries  []ArchiveMonthlySummary   `json:"monthlySummaries"`
+	CloseRuns         []ArchiveCloseRun         `json:"closeRuns"`
+	TaskEvaluations   []ArchiveTaskEvaluation   `json:"taskEvaluations"`
+	CloseRunHistory   []ArchiveCloseRunHistory  `json:"closeRunHistory"`
+}
+
+type ArchiveTeam struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type ArchiveMember struct {
+	UserID        string    `json:"userId"`
+	Email         string    `json:"email"`
+	DisplayName   string    `json:"displayName"`
+	Nickname      *string   `json:"nickname,omitempty"`
+	ColorHex      *string   `json:"colorHex,omitempty"`
+	UserCreatedAt time.Time `json:"userCreatedAt"`
+	Role          string    `json:"role"`
+	JoinedAt      time.Time `json:"joinedAt"`
+}
+
+// ArchiveAbsence is one absence. A nil UserID means the whole team is away.
+type ArchiveAbsence struct {
+	ID              string    `json:"id"`
+	UserID          *string   `json:"userId,omitempty"`
+	StartsOn        string    `json:"startsOn"`
+	EndsOn          string    `json:"endsOn"`
+	Reason          *string   `json:"reason,omitempty"`
+	CreatedByUserID *string   `json:"createdByUserId,omitempty"`
+	CreatedAt       time.Time `json:"createdAt"`
+}
+
+type ArchiveHoliday struct {
+	Date            string    `json:"date"`
+	Name            string    `json:"name"`
+	Source          string    `json:"source"`
+	CreatedByUserID *string   `json:"createdByUserId,omitempty"`
+	CreatedAt       time.Time `json:"createdAt"`
+}
+
+type ArchiveTaskCategory struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	ColorHex  *string   `json:"colorHex,omitempty"`
+	Icon      *string   `json:"icon,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type ArchiveTask struct {
+	ID                         string     `json:"id"`
+	Title                      string     `json:"title"`
+	Notes                      *string    `json:"notes,omitempty"`
+	Type                       string     `json:"type"`
+	PenaltyPoints              int        `json:"penaltyPoints"`
+	AssigneeUserID             *string    `json:"assigneeUserId,omitempty"`
+	RequiredCompletionsPerWeek int        `json:"requiredCompletionsPerWeek"`
+	DueOn                      *string    `json:"dueOn,omitempty"`
+	StartsOn                   *string    `json:"startsOn,omitempty"`
+	EndsOn                     *string    `json:"endsOn,omitempty"`
+	CategoryID                 *string    `json:"categoryId,omitempty"`
+	CreatedAt                  time.Time  `json:"createdAt"`
+	UpdatedAt                  time.Time  `json:"updatedAt"`
+	DeletedAt                  *time.Time `json:"deletedAt,omitempty"`
+}
+
+type ArchiveChecklistItem struct {
+	ID        string    `json:"id"`
+	TaskID    string    `json:"taskId"`
+	Title     string    `json:"title"`
+	Position  int       `json:"position"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type ArchiveTaskTemplate struct {
+	ID              string                    `json:"id"`
+	Name            string                    `json:"name"`
+	Description     string                    `json:"description,omitempty"`
+	CreatedByUserID *string                   `json:"createdByUserId,omitempty"`
+	CreatedAt       time.Time                 `json:"createdAt"`
+	UpdatedAt       time.Time                 `json:"updatedAt"`
+	Items           []ArchiveTaskTemplateItem `json:"items"`
+}
+
+type ArchiveTaskTemplateItem struct {
+	Title                      string   `json:"title"`
+	Notes                      string   `json:"notes,omitempty"`
+	Type                       string   `json:"type"`
+	PenaltyPoints              int      `json:"penaltyPoints"`
+	RequiredCompletionsPerWeek int      `json:"requiredCompletionsPerWeek"`
+	DueInDays                  *int     `json:"dueInDays,omitempty"`
+	Checklist                  []string `json:"checklist"`
+}
+
+type ArchiveDailyCompletion struct {
+	TaskID            string    `json:"taskId"`
+	TargetDate        string    `json:"targetDate"`
+	CompletedByUserID *string   `json:"completedByUserId,omitempty"`
+	CreatedAt         time.Time `json:"createdAt"`
+}
+
+type ArchiveWeeklyCompletion struct {
+	TaskID            string    `json:"taskId"`
+	WeekStart         string    `json:"weekStart"`
+	CompletedByUserID *string   `json:"completedByUserId,omitempty"`
+	CreatedAt         time.Time `json:"createdAt"`
+}
+
+type ArchiveOneOffCompletion struct {
+	TaskID            string    `json:"taskId"`
+	CompletedByUserID *string   `json:"completedByUserId,omitempty"`
+	CompletedAt       time.Time `json:"completedAt"`
+}
+
+type ArchivePenaltyRule struct {
+	ID          string     `json:"id"`
+	Threshold   int        `json:"threshold"`
+	Name        string     `json:"name"`
+	Description *string    `json:"description,omitempty"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+	DeletedAt   *time.Time `json:"deletedAt,omitempty"`
+}
+
+type ArchiveMonthlySummary struct {
+	MonthStart         string                 `json:"monthStart"`
+	DailyPenaltyTotal  int                    `json:"dailyPenaltyTotal"`
+	WeeklyPenaltyTotal int                    `json:"weeklyPenaltyTotal"`
+	IsClosed           bool                   `json:"isClosed"`
+	TriggeredRules     []ArchiveTriggeredRule `json:"triggeredRules"`
+}
+
+type ArchiveTriggeredRule struct {
+	RuleID    string    `json:"ruleId"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+type ArchiveCloseRun struct {
+	Scope      string    `json:"scope"`
+	TargetDate string    `json:"targetDate"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// ArchiveTaskEvaluation is one charge made by a close. Charges outlive their
+// task and category, so TaskID and CategoryID may name ones the archive no
+// longer holds; CategoryName keeps the category's name at close.
+type ArchiveTaskEvaluation struct {
+	Scope         string    `json:"scope"`
+	TargetDate    string    `json:"targetDate"`
+	TaskID        string    `json:"taskId"`
+	PenaltyPoints *int      `json:"penaltyPoints,omitempty"`
+	CategoryID    *string   `json:"categoryId,omitempty"`
+	CategoryName  *string   `json:"categoryName,omitempty"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+type ArchiveCloseRunHistory struct {
+	Scope            string    `json:"scope"`
+	Trigger          string    `json:"trigger"`
+	AsOf             time.Time `json:"asOf"`
+	StartedAt        time.Time `json:"startedAt"`
+	FinishedAt       time.Time `json:"finishedAt"`
+	PeriodsProcessed int       `json:"periodsProcessed"`
+	PenaltyAdded     int       `json:"penaltyAdded"`
+	PenalizedTasks   int       `json:"penalizedTasks"`
+	ErrorClass       *string   `json:"errorClass,omitempty"`
+	ErrorMessage     *string   `json:"errorMessage,omitempty"`
+}
+
+// TeamImportResult reports what ImportTeam wrote, or would write on a dry run.
+type TeamImportResult struct {
+	TeamID            string `json:"teamId"`
+	DryRun            bool   `json:"dryRun"`
+	Members           int    `json:"members"`
+	Absences          int    `json:"absences"`
+	Holidays          int    `json:"holidays"`
+	TaskCategories    int    `json:"taskCategories"`
+	Tasks             int    `json:"tasks"`
+	ChecklistItems    int    `json:"checklistItems"`
+	TaskTemplates     int    `json:"taskTemplates"`
+	DailyCompletions  int    `json:"dailyCompletions"`
+	WeeklyCompletions int    `json:"weeklyCompletions"`
+	OneOffCompletions int    `json:"oneOffCompletions"`
+	PenaltyRules      int    `json:"penaltyRules"`
+	MonthlySummaries  int    `json:"monthlySummaries"`
+	CloseRuns         int    `json:"closeRuns"`
+	TaskEvaluations   int    `json:"taskEvaluations"`
+	CloseRunHistory   int    `json:"closeRunHistory"`
+}
+
+// ExportTeam reads the whole team from one repeatable-read snapshot, so an
+// archive taken while the team is in use is still consistent. Soft-deleted
+// tasks and rules are included: closed periods and summaries refer to them.
+func (s *Store) ExportTeam(ctx context.Context, teamID string) (TeamArchive, error) {
+	tx, err := s.db.BeginTx(ctx, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return TeamArchive{}, err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+	q := s.q.WithTx(tx)
+
+	team, err := q.GetArchiveTeam(ctx, teamID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return TeamArchive{}, errors.New("team not found")
+		}
+		return TeamArchive{}, err
+	}
+	archive := TeamArchive{
+		Format:            TeamArchiveFormat,
+		Version:           TeamArchiveVersion,
+		ExportedAt:        s.now(),
+		Team:              ArchiveTeam{ID: team.ID, Name: team.Name, CreatedAt: team.CreatedAt.Time.In(s.loc)},
+		Members:           []ArchiveMember{},
+		Absences:          []ArchiveAbsence{},
+		Holidays:          []ArchiveHoliday{},
+		TaskCategories:    []ArchiveTaskCategory{},
+		Tasks:             []ArchiveTask{},
+		ChecklistItems:    []ArchiveChecklistItem{},
+		TaskTemplates:     []ArchiveTaskTemplate{},
+		DailyCompletions:  []ArchiveDailyCompletion{},
+		WeeklyCompletions: []ArchiveWeeklyCompletion{},
+		OneOffCompletions: []ArchiveOneOffCompletion{},
+		PenaltyRules:      []ArchivePenaltyRule{},
+		MonthlySummaries:  []ArchiveMonthlySummary{},
+		CloseRuns:         []ArchiveCloseRun{},
+		TaskEvaluations:   []ArchiveTaskEvaluation{},
+		CloseRunHistory:   []ArchiveCloseRunHistory{},
+	}
+
+	members, err := q.ListArchiveMembersByTeamID(ctx, teamID)
+	if err != nil {
+		return TeamArchive{}, err
+	}
+	for _, row := range members {
+		archive.Members = append(archive.Members, ArchiveMember{
+			UserID:        row.UserID,
+			Email:         row.Email,
+			DisplayName:   row.DisplayName,
+			Nickname:      ptrFromText(row.Nickname),
+			ColorHex:      ptrFromText(row.ColorHex),
+			UserCreatedAt: row.UserCreatedAt.Time.In(s.loc),
+			Role:          row.Role,
+			JoinedAt:      row.JoinedAt.Time.In(s.loc),
+		})
+	}
+
+	absences, err := q.ListArchiveTeamAbsencesByTeamID(ctx, teamID)
+	if err != nil {
+		return TeamArchive{}, err
+	}
+	for _, row := range absences {
+		archive.Absences = append(archive.Absences, ArchiveAbsence{
+			ID:              row.ID,
+			UserID:          ptrFromAny(row.UserID),
+			StartsOn:        row.StartsOn.Time.Format(archiveDateLayout),
+			EndsOn:          row.EndsOn.Time.Format(archiveDateLayout),
+			Reason:          ptrFromText(row.Reason),
+			CreatedByUserID: ptrFromAny(row.CreatedByUserID),
+			CreatedAt:       row.CreatedAt.Time.In(s.loc),
+		})
+	}
+
+	holidays, err := q.ListArchiveTeamHolidaysByTeamID(ctx, teamID)
+	if err != nil {
+		return TeamArchive{}, err
+	}
+	for _, row := range holidays {
+		archive.Holidays = append(archive.Holidays, ArchiveHoliday{
+			Date:            row.HolidayDate.Time.Format(archiveDateLayout),
+			Name:            row.Name,
+			Source:          row.Source,
+			CreatedByUserID: ptrFromAny(row.CreatedByUserID),
+			CreatedAt:       row.CreatedAt.Time.In(s.loc),
+		})
+	}
+
+	categories, err := q.ListArchiveTaskCategoriesByTeamID(ctx, teamID)
+	if err != nil {
+		return TeamArchive{}, err
+	}
+	for _, row := range categories {
+		archive.TaskCategories = append(archive.TaskCategories, ArchiveTaskCategory{
+			ID:        row.ID,
+			Name:      row.Name,
+			ColorHex:  ptrFromText(row.ColorHex),
+			Icon:      ptrFromText(row.Icon),
+			CreatedAt: row.CreatedAt.Time.In(s.loc),
+			UpdatedAt: row.UpdatedAt.Time.In(s.loc),
+		})
+	}
+
+	tasks, err := q.ListArchiveTasksByTeamID(ctx, teamID)
+	if err != nil {
+		return TeamArchive{}, err
+	}
+	for _, row := range tasks {
+		archive.Tasks = append(archive.Tasks, ArchiveTask{
+			ID:                         row.ID,
+			Title:                      row.Title,
+			Notes:                      ptrFromText(row.Notes),
+			Type:                       row.Type,
+			PenaltyPoints:              int(row.PenaltyPoints),
+			AssigneeUserID:             ptrFromAny(row.AssigneeUserID),
+			RequiredCompletionsPerWeek: int(row.RequiredCompletionsPerWeek),
+			DueOn:                      archiveDatePtr(row.DueOn),
+			StartsOn:                   archiveDatePtr(row.StartsOn),
+			EndsOn:                     archiveDatePtr(row.EndsOn),
+			CategoryID:                 ptrFromAny(row.CategoryID),
+			CreatedAt:                  row.CreatedAt.Time.In(s.loc),
+			UpdatedAt:                  row.UpdatedAt.Time.In(s.loc),
+			DeletedAt:                  ptrFromTimestamptz(row.DeletedAt, s.loc),
+		})
+	}
+
+	checklistItems, err := q.ListArchiveTaskChecklistItemsByTeamID(ctx, teamID)
+	if err != nil {
+		return TeamArchive{}, err
+	}
+	for _, row := range checklistItems {
+		archive.ChecklistItems = append(archive.ChecklistItems, ArchiveChecklistItem{
+			ID:        row.ID,
+			TaskID:    row.TaskID,
+			Title:     row.Title,
+			Position:  int(row.Position),
+			CreatedAt: row.CreatedAt.Time.In(s.loc),
+			UpdatedAt: row.UpdatedAt.Time.In(s.loc),
+		})
+	}
+
+	templates, err := q.ListArchiveTaskTemplatesByTeamID(ctx, teamID)
+	if err != nil {
+		return TeamArchive{}, err
+	}
+	templateItems, err := q.ListTaskTemplateItemsByTeamID(ctx, teamID)
+	if err != nil {
+		return TeamArchive{}, err
+	}
+	itemsByTemplate := map[string][]ArchiveTaskTemplateItem{}
+	for _, row := range templateItems {
+		item := ArchiveTaskTemplateItem{
+			Title:                      row.Title,
+			Notes:                      row.Notes,
+			Type:                       row.Type,
+			PenaltyPoints:              int(row.PenaltyPoints),
+			RequiredCompletionsPerWeek: int(row.RequiredCompletionsPerWeek),
+			Checklist:                  append([]string{}, row.Checklist...),
+		}
+		if row.DueInDays.Valid {
+			dueInDays := int(row.DueInDays.Int32)
+			item.DueInDays = &dueInDays
+		}
+		itemsByTemplate[row.TemplateID] = append(itemsByTemplate[row.TemplateID], item)
+	}
+	for _, row := range templates {
+		archive.TaskTemplates = append(archive.TaskTemplates, ArchiveTaskTemplate{
+			ID:              row.ID,
+			Name:            row.Name,
+			Description:     row.Description,
+			CreatedByUserID: ptrFromAny(row.CreatedByUserID),
+			CreatedAt:       row.CreatedAt.Time.In(s.loc),
+			UpdatedAt:       row.UpdatedAt.Time.In(s.loc),
+			Items:           itemsByTemplate[row.ID],
+		})
+	}
+
+	daily, err := q.ListArchiveDailyCompletionsByTeamID(ctx, teamID)
+	if err != nil {
+		return TeamArchive{}, err
+	}
+	for _, row := range daily {
+		archive.DailyCompletions = append(archive.DailyCompletions, ArchiveDailyCompletion{
+			TaskID:            row.TaskID,
+			TargetDate:        row.TargetDate.Time.Format(archiveDateLayout),
+			CompletedByUserID: ptrFromAny(row.CompletedByUserID),
+			CreatedAt:         row.CreatedAt.Time.In(s.loc),
+		})
+	}
+
+	weekly, err := q.ListArchiveWeeklyEntriesByTeamID(ctx, teamID)
+	if err != nil {
+		return TeamArchive{}, err
+	}
+	for _, row := range weekly {
+		archive.WeeklyCompletions = append(archive.WeeklyCompletions, ArchiveWeeklyCompletion{
+			TaskID:            row.TaskID,
+			WeekStart:         row.WeekStart.Time.Format(archiveDateLayout),
+			CompletedByUserID: ptrFromAny(row.CompletedByUserID),
+			CreatedAt:         row.CreatedAt.Time.In(s.loc),
+		})
+	}
+
+	oneOff, err := q.ListArchiveOneOffCompletionsByTeamID(ctx, teamID)
+	if err != nil {
+		return TeamArchive{}, err
+	}
+	for _, row := range oneOff {
+		archive.OneOffCompletions = append(archive.OneOffCompletions, ArchiveOneOffCompletion{
+			TaskID:            row.TaskID,
+			CompletedByUserID: ptrFromAny(row.CompletedByUserID),
+			CompletedAt:       row.CompletedAt.Time.In(s.loc),
+		})
+	}
+
+	rules, err := q.ListArchivePenaltyRulesByTeamID(ctx, teamID)
+	if err != nil {
+		return TeamArchive{}, err
+	}
+	for _, row := range rules {
+		archive.PenaltyRules = append(archive.PenaltyRules, ArchivePenaltyRule{
+			ID:          row.ID,
+			Threshold:   int(row.Threshold),
+			Name:        row.Name,
+			Description: ptrFromText(row.Description),
+			CreatedAt:   row.CreatedAt.Time.In(s.loc),
+			UpdatedAt:   row.UpdatedAt.Time.In(s.loc),
+			DeletedAt:   ptrFromTimestamptz(row.DeletedAt, s.loc),
+		})
+	}
+
+	summaries, err := q.ListArchiveMonthlySummariesByTeamID(ctx, teamID)
+	if err != nil {
+		return TeamArchive{}, err
+	}
+	triggered, err := q.ListArchiveTriggeredRulesByTeamID(ctx, teamID)
+	if err != nil {
+		return TeamArchive{}, err
+	}
+	triggeredByMonth := map[string][]ArchiveTriggeredRule{}
+	for _, row := range triggered {
+		month := row.MonthStart.Time.Format(archiveDateLayout)
+		triggeredByMonth[month] = append(triggeredByMonth[month], ArchiveTriggeredRule{
+			RuleID:    row.RuleID,
+			CreatedAt: row.CreatedAt.Time.In(s.loc),
+		})
+	}
+	for _, row := range summaries {
+		month := row.MonthStart.Time.Format(archiveDateLayout)
+		rules := triggeredByMonth[month]
+		if rules == nil {
+			rules = []ArchiveTriggeredRule{}
+		}
+		archive.MonthlySummaries = append(archive.MonthlySummaries, ArchiveMonthlySummary{
+			MonthStart:         month,
+			DailyPenaltyTotal:  int(row.DailyPenaltyTotal),
+			WeeklyPenaltyTotal: int(row.WeeklyPenaltyTotal),
+			IsClosed:           row.IsClosed,
+			TriggeredRules:     rules,
+		})
+	}
+
+	closeRuns, err := q.ListArchiveCloseRunsByTeamID(ctx, teamID)
+	if err != nil {
+		return TeamArchive{}, err
+	}
+	for _, row := range closeRuns {
+		archive.CloseRuns = append(archive.CloseRuns, ArchiveCloseRun{
+			Scope:      row.Scope,
+			TargetDate: row.TargetDate.Time.Format(archiveDateLayout),
+			CreatedAt:  row.CreatedAt.Time.In(s.loc),
+		})
+	}
+
+	evaluations, err := q.ListArchiveTaskEvaluationDedupesByTeamID(ctx, teamID)
+	if err != nil {
+		return TeamArchive{}, err
+	}
+	for _, row := range evaluations {
+		penaltyPoints := int(row.PenaltyPoints)
+		archive.TaskEvaluations = append(archive.TaskEvaluations, ArchiveTaskEvaluation{
+			Scope:         row.Scope,
+			TargetDate:    row.TargetDate.Time.Format(archiveDateLayout),
+			TaskID:        row.TaskID,
+			PenaltyPoints: &penaltyPoints,
+			CategoryID:    ptrFromAny(row.CategoryID),
+			CategoryName:  ptrFromText(row.CategoryName),
+			CreatedAt:     row.CreatedAt.Time.In(s.loc),
+		})
+	}
+
+	history, err := q.ListArchiveCloseRunHistoryByTeamID(ctx, teamID)
+	if err != nil {
+		return TeamArchive{}, err
+	}
+	for _, row := range history {
+		archive.CloseRunHistory = append(archive.CloseRunHistory, ArchiveCloseRunHistory{
+			Scope:            row.Scope,
+			Trigger:          row.Trigger,
+			AsOf:             row.AsOf.Time.In(s.loc),
+			StartedAt:        row.StartedAt.Time.In(s.loc),
+			FinishedAt:       row.FinishedAt.Time.In(s.loc),
+			PeriodsProcessed: int(row.PeriodsProcessed),
+			PenaltyAdded:     int(row.PenaltyAdded),
+			PenalizedTasks:   int(row.PenalizedTasks),
+			ErrorClass:       ptrFromText(row.ErrorClass),
+			ErrorMessage:     ptrFromText(row.ErrorMessage),
+		})
+	}
+	return archive, nil
+}
+
+// ImportTeam restores an archive under freshly assigned IDs in a single
+// transaction. Members are created as new users, so the import is refused
+// when any of their emails is already registered. Completions by users who
+// are no longer members lose their actor, as they do when a member leaves.
+// With dryRun everything is written and then rolled back.
+func (s *Store) ImportTeam(ctx context.Context, archive TeamArchive, dryRun bool) (TeamImportResult, error) {
+	if err := validateTeamArchive(archive); err != nil {
+		return TeamImportResult{}, err
+	}
+	emails := make([]string, 0, len(archive.Members))
+	for _, m := range archive.Members {
+		emails = append(emails, strings.ToLower(m.Email))
+	}
+	existing, err := s.q.ListExistingUserEmails(ctx, emails)
+	if err != nil {
+		return TeamImportResult{}, err
+	}
+	if len(existing) > 0 {
+		return TeamImportResult{}, fmt.Errorf("invalid archive: users already exist: %s", strings.Join(existing, ", "))
+	}
+
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return TeamImportResult{}, err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+	result, err := s.importTeamLocked(ctx, s.q.WithTx(tx), archive)
+	if err != nil {
+		return TeamImportResult{}, err
+	}
+	result.DryRun = dryRun
+	if dryRun {
+		return result, nil
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return TeamImportResult{}, err
+	}
+	return result, nil
+}
+
+func (s *Store) importTeamLocked(ctx context.Context, q *dbsqlc.Queries, archive TeamArchive) (TeamImportResult, error) {
+	teamID := s.nextID("team")
+	result := TeamImportResult{TeamID: teamID}
+	if err := q.CreateTeam(ctx, dbsqlc.CreateTeamParams{
+		ID:        teamID,
+		Name:      archive.Team.Name,
+		CreatedAt: toPgTimestamptz(archive.Team.CreatedAt),
+	}); err != nil {
+		return result, fmt.Errorf("import team: %w", err)
+	}
+
+	userIDs := map[string]string{}
+	for _, m := range archive.Members {
+		userID := s.nextID("user")
+		userIDs[m.UserID] = userID
+		if err := q.ImportUser(ctx, dbsqlc.ImportUserParams{
+			ID:          userID,
+			Email:       m.Email,
+			DisplayName: m.DisplayName,
+			Nickname:    textFromPtr(m.Nickname),
+			ColorHex:    textFromPtr(m.ColorHex),
+			CreatedAt:   toPgTimestamptz(m.UserCreatedAt),
+		}); err != nil {
+			return result, fmt.Errorf("import member %s: %w", m.Email, err)
+		}
+		if err := q.AddTeamMember(ctx, dbsqlc.AddTeamMemberParams{
+			TeamID:    teamID,
+			UserID:    userID,
+			Role:      m.Role,
+			CreatedAt: toPgTimestamptz(m.JoinedAt),
+		}); err != nil {
+			return result, fmt.Errorf("import member %s: %w", m.Email, err)
+		}
+		result.Members++
+	}
+	// Actors who left the team are not in the archive and map to "".
+	mapUser := func(id *string) string {
+		if id == nil {
+			return ""
+		}
+		return userIDs[*id]
+	}
+
+	for _, a := range archive.Absences {
+		if err := q.ImportTeamAbsence(ctx, dbsqlc.ImportTeamAbsenceParams{
+			ID:              s.nextID("abs"),
+			TeamID:          teamID,
+			UserID:          mapUser(a.UserID),
+			StartsOn:        toPgDate(mustParseArchiveDate(a.StartsOn)),
+			EndsOn:          toPgDate(mustParseArchiveDate(a.EndsOn)),
+			Reason:          textFromPtr(a.Reason),
+			CreatedByUserID: mapUser(a.CreatedByUserID),
+			CreatedAt:       toPgTimestamptz(a.CreatedAt),
+		}); err != nil {
+			return result, fmt.Errorf("import absence %s: %w", a.ID, err)
+		}
+		result.Absences++
+	}
+	for _, h := range archive.Holidays {
+		if err := q.ImportTeamHoliday(ctx, dbsqlc.ImportTeamHolidayParams{
+			TeamID:          teamID,
+			HolidayDate:     toPgDate(mustParseArchiveDate(h.Date)),
+			Name:            h.Name,
+			Source:          h.Source,
+			CreatedByUserID: mapUser(h.CreatedByUserID),
+			CreatedAt:       toPgTimestamptz(h.CreatedAt),
+		}); err != nil {
+			return result, fmt.Errorf("import holiday %s: %w", h.Date, err)
+		}
+		result.Holidays++
+	}
+
+	categoryIDs := map[string]string{}
+	for _, c := range archive.TaskCategories {
+		categoryID := s.nextID("cat")
+		categoryIDs[c.ID] = categoryID
+		if err := q.ImportTaskCategory(ctx, dbsqlc.ImportTaskCategoryParams{
+			ID:        categoryID,
+			TeamID:    teamID,
+			Name:      c.Name,
+			ColorHex:  textFromPtr(c.ColorHex),
+			Icon:      textFromPtr(c.Icon),
+			CreatedAt: toPgTimestamptz(c.CreatedAt),
+			UpdatedAt: toPgTimestamptz(c.UpdatedAt),
+		}); err != nil {
+			return result, fmt.Errorf("import task category %s: %w", c.ID, err)
+		}
+		result.TaskCategories++
+	}
+
+	taskIDs := map[string]string{}
+	for _, t := range archive.Tasks {
+		taskID := s.nextID("task")
+		taskIDs[t.ID] = taskID
+		params := dbsqlc.ImportTaskParams{
+			ID:                         taskID,
+			TeamID:                     teamID,
+			Title:                      t.Title,
+			Notes:                      textFromPtr(t.Notes),
+			Type:                       t.Type,
+			PenaltyPoints:              int32(t.PenaltyPoints),
+			AssigneeUserID:             mapUser(t.AssigneeUserID),
+			RequiredCompletionsPerWeek: int32(t.RequiredCompletionsPerWeek),
+			CreatedAt:                  toPgTimestamptz(t.CreatedAt),
+			UpdatedAt:                  toPgTimestamptz(t.UpdatedAt),
+		}
+		if t.DeletedAt != nil {
+			params.DeletedAt = toPgTimestamptz(*t.DeletedAt)
+		}
+		params.DueOn = pgDateFromArchive(t.DueOn)
+		params.StartsOn = pgDateFromArchive(t.StartsOn)
+		params.EndsOn = pgDateFromArchive(t.EndsOn)
+		if t.CategoryID != nil {
+			params.CategoryID = categoryIDs[*t.CategoryID]
+		}
+		if err := q.ImportTask(ctx, params); err != nil {
+			return result, fmt.Errorf("import task %s: %w", t.ID, err)
+		}
+		result.Tasks++
+	}
+	for _, item := range archive.ChecklistItems {
+		if err := q.ImportTaskChecklistItem(ctx, dbsqlc.ImportTaskChecklistItemParams{
+			ID:        s.nextID("tci"),
+			TaskID:    taskIDs[item.TaskID],
+			Title:     item.Title,
+			Position:  int32(item.Position),
+			CreatedAt: toPgTimestamptz(item.CreatedAt),
+			UpdatedAt: toPgTimestamptz(item.UpdatedAt),
+		}); err != nil {
+			return result, fmt.Errorf("import checklist item %s: %w", item.ID, err)
+		}
+		result.ChecklistItems++
+	}
+	for _, tmpl := range archive.TaskTemplates {
+		templateID := s.nextID("tpl")
+		if err := q.ImportTaskTemplate(ctx, dbsqlc.ImportTaskTemplateParams{
+			ID:              templateID,
+			TeamID:          teamID,
+			Name:            tmpl.Name,
+			Description:     tmpl.Description,
+			CreatedByUserID: mapUser(tmpl.CreatedByUserID),
+			CreatedAt:       toPgTimestamptz(tmpl.CreatedAt),
+			UpdatedAt:       toPgTimestamptz(tmpl.UpdatedAt),
+		}); err != nil {
+			return result, fmt.Errorf("import task template %s: %w", tmpl.ID, err)
+		}
+		for position, item := range tmpl.Items {
+			params := dbsqlc.ImportTaskTemplateItemParams{
+				ID:                         s.nextID("tpi"),
+				TemplateID:                 templateID,
+				Position:                   int32(position),
+				Title:                      item.Title,
+				Notes:                      item.Notes,
+				Type:                       item.Type,
+				PenaltyPoints:              int32(item.PenaltyPoints),
+				RequiredCompletionsPerWeek: int32(item.RequiredCompletionsPerWeek),
+				Checklist:                  item.Checklist,
+			}
+			if item.DueInDays != nil {
+				params.DueInDays = pgtype.Int4{Int32: int32(*item.DueInDays), Valid: true}
+			}
+			if params.Checklist == nil {
+				params.Checklist = []string{}
+			}
+			if err := q.ImportTaskTemplateItem(ctx, params); err != nil {
+				return result, fmt.Errorf("import task template %s: %w", tmpl.ID, err)
+			}
+		}
+		result.TaskTemplates++
+	}
+
+	for _, c := range archive.DailyCompletions {
+		if err := q.ImportTaskCompletionDaily(ctx, dbsqlc.ImportTaskCompletionDailyParams{
+			TaskID:            taskIDs[c.TaskID],
+			TargetDate:        toPgDate(mustParseArchiveDate(c.TargetDate)),
+			CompletedByUserID: mapUser(c.CompletedByUserID),
+			CreatedAt:         toPgTimestamptz(c.CreatedAt),
+		}); err != nil {
+			return result, fmt.Errorf("import daily completion %s %s: %w", c.TaskID, c.TargetDate, err)
+		}
+		result.DailyCompletions++
+	}
+	for _, c := range archive.WeeklyCompletions {
+		if err := q.ImportTaskCompletionWeeklyEntry(ctx, dbsqlc.ImportTaskCompletionWeeklyEntryParams{
+			ID:                s.nextID("tcw"),
+			TaskID:            taskIDs[c.TaskID],
+			WeekStart:         toPgDate(mustParseArchiveDate(c.WeekStart)),
+			CompletedByUserID: mapUser(c.CompletedByUserID),
+			CreatedAt:         toPgTimestamptz(c.CreatedAt),
+		}); err != nil {
+			return result, fmt.Errorf("import weekly completion %s %s: %w", c.TaskID, c.WeekStart, err)
+		}
+		result.WeeklyCompletions++
+	}
+	for _, c := range archive.OneOffCompletions {
+		if err := q.ImportTaskCompletionOneOff(ctx, dbsqlc.ImportTaskCompletionOneOffParams{
+			TaskID:            taskIDs[c.TaskID],
+			CompletedByUserID: mapUser(c.CompletedByUserID),
+			CompletedAt:       toPgTimestamptz(c.CompletedAt),
+		}); err != nil {
+			return result, fmt.Errorf("import one-off completion %s: %w", c.TaskID, err)
+		}
+		result.OneOffCompletions++
+	}
+
+	ruleIDs := map[string]string{}
+	for _, r := range archive.PenaltyRules {
+		ruleID := s.nextID("pr")
+		ruleIDs[r.ID] = ruleID
+		params := dbsqlc.ImportPenaltyRuleParams{
+			ID:          ruleID,
+			TeamID:      teamID,
+			Threshold:   int32(r.Threshold),
+			Name:        r.Name,
+			Description: textFromPtr(r.Description),
+			CreatedAt:   toPgTimestamptz(r.CreatedAt),
+			UpdatedAt:   toPgTimestamptz(r.UpdatedAt),
+		}
+		if r.DeletedAt != nil {
+			params.DeletedAt = toPgTimestamptz(*r.DeletedAt)
+		}
+		if err := q.ImportPenaltyRule(ctx, params); err != nil {
+			return result, fmt.Errorf("import penalty rule %s: %w", r.ID, err)
+		}
+		result.PenaltyRules++
+	}
+
+	for _, m := range archive.MonthlySummaries {
+		monthStart := toPgDate(mustParseArchiveDate(m.MonthStart))
+		if err := q.ImportMonthlyPenaltySummary(ctx, dbsqlc.ImportMonthlyPenaltySummaryParams{
+			TeamID:             teamID,
+			MonthStart:         monthStart,
+			DailyPenaltyTotal:  int32(m.DailyPenaltyTotal),
+			WeeklyPenaltyTotal: int32(m.WeeklyPenaltyTotal),
+			IsClosed:           m.IsClosed,
+		}); err != nil {
+			return result, fmt.Errorf("import monthly summary %s: %w", m.MonthStart, err)
+		}
+		for _, r := range m.TriggeredRules {
+			if err := q.ImportTriggeredRule(ctx, dbsqlc.ImportTriggeredRuleParams{
+				TeamID:     teamID,
+				MonthStart: monthStart,
+				RuleID:     ruleIDs[r.RuleID],
+				CreatedAt:  toPgTimestamptz(r.CreatedAt),
+			}); err != nil {
+				return result, fmt.Errorf("import triggered rule %s %s: %w", m.MonthStart, r.RuleID, err)
+			}
+		}
+		result.MonthlySummaries++
+	}
+
+	for _, c := range archive.CloseRuns {
+		if err := q.ImportCloseRun(ctx, dbsqlc.ImportCloseRunParams{
+			TeamID:     teamID,
+			Scope:      c.Scope,
+			TargetDate: toPgDate(mustParseArchiveDate(c.TargetDate)),
+			CreatedAt:  toPgTimestamptz(c.CreatedAt),
+		}); err != nil {
+			return result, fmt.Errorf("import close run %s %s: %w", c.Scope, c.TargetDate, err)
+		}
+		result.CloseRuns++
+	}
+	archiveTasks := make(map[string]ArchiveTask, len(archive.Tasks))
+	for _, t := range archive.Tasks {
+		archiveTasks[t.ID] = t
+	}
+	categoryNames := make(map[string]string, len(archive.TaskCategories))
+	for _, c := range archive.TaskCategories {
+		categoryNames[c.ID] = c.Name
+	}
+	// Charges of purged tasks and deleted categories get fresh IDs too, one
+	// per original ID, so they still group together.
+	remap := func(ids map[string]string, id, prefix string) string {
+		if ids[id] == "" {
+			ids[id] = s.nextID(prefix)
+		}
+		return ids[id]
+	}
+	for _, e := range archive.TaskEvaluations {
+		task := archiveTasks[e.TaskID]
+		params := dbsqlc.ImportTaskEvaluationDedupeParams{
+			TeamID:        teamID,
+			Scope:         e.Scope,
+			TargetDate:    toPgDate(mustParseArchiveDate(e.TargetDate)),
+			TaskID:        remap(taskIDs, e.TaskID, "task"),
+			PenaltyPoints: int32(task.PenaltyPoints),
+			CreatedAt:     toPgTimestamptz(e.CreatedAt),
+		}
+		categoryID, categoryName := task.CategoryID, (*string)(nil)
+		if e.PenaltyPoints != nil {
+			params.PenaltyPoints = int32(*e.PenaltyPoints)
+			categoryID, categoryName = e.CategoryID, e.CategoryName
+		}
+		if categoryID != nil {
+			params.CategoryID = remap(categoryIDs, *categoryID, "cat")
+			if categoryName == nil {
+				name := categoryNames[*categoryID]
+				categoryName = &name
+			}
+			params.CategoryName = textFromPtr(categoryName)
+		}
+		if err := q.ImportTaskEvaluationDedupe(ctx, params); err != nil {
+			return result, fmt.Errorf("import task evaluation %s %s: %w", e.Scope, e.TargetDate, err)
+		}
+		result.TaskEvaluations++
+	}
+	for _, h := range archive.CloseRunHistory {
+		if err := q.InsertCloseRunHistory(ctx, dbsqlc.InsertCloseRunHistoryParams{
+			ID:               s.nextID("crh"),
+			TeamID:           teamID,
+			Scope:            h.Scope,
+			Trigger:          h.Trigger,
+			AsOf:             toPgTimestamptz(h.AsOf),
+			StartedAt:        toPgTimestamptz(h.StartedAt),
+			FinishedAt:       toPgTimestamptz(h.FinishedAt),
+			PeriodsProcessed: int32(h.PeriodsProcessed),
+			PenaltyAdded:     int32(h.PenaltyAdded),
+			PenalizedTasks:   int32(h.PenalizedTasks),
+			ErrorClass:       textFromPtr(h.ErrorClass),
+			ErrorMessage:     textFromPtr(h.ErrorMessage),
+		}); err != nil {
+			return result, fmt.Errorf("import close run history: %w", err)
+		}
+		result.CloseRunHistory++
+	}
+	return result, nil
+}
+
+// validateTeamArchive checks everything the import relies on before any row
+// is written: the format, enum values and ranges, and that every reference
+// resolves within the archive.
+func validateTeamArchive(a TeamArchive) error {
+	invalid := func(format string, args ...any) error {
+		return fmt.Errorf("invalid archive: "+format, args...)
+	}
+	if a.Format != TeamArchiveFormat {
+		return invalid("unsupported format %q", a.Format)
+	}
+	if a.Version < teamArchiveMinVersion || a.Version > TeamArchiveVersion {
+		return invalid("unsupported version %d (expected %d to %d)", a.Version, teamArchiveMinVersion, TeamArchiveVersion)
+	}
+	if strings.TrimSpace(a.Team.Name) == "" {
+		return invalid("team name is required")
+	}
+
+	members := map[string]bool{}
+	emails := map[string]bool{}
+	owners := 0
+	for _, m := range a.Members {
+		if m.UserID == "" || members[m.UserID] {
+			return invalid("member %q: missing or duplicate user id", m.UserID)
+		}
+		members[m.UserID] = true
+		email := strings.ToLower(strings.TrimSpace(m.Email))
+		if email == "" || emails[email] {
+			return invalid("member %s: missing or duplicate email", m.UserID)
+		}
+		emails[email] = true
+		if strings.TrimSpace(m.DisplayName) == "" {
+			return invalid("member %s: display name is required", m.UserID)
+		}
+		if m.ColorHex != nil {
+			if _, err := normalizeColorHex(m.ColorHex); err != nil {
+				return invalid("member %s: %v", m.UserID, err)
+			}
+		}
+		switch m.Role {
+		case "owner":
+			owners++
+		case "member":
+		default:
+			return invalid("member %s: unsupported role %q", m.UserID, m.Role)
+		}
+	}
+	if owners != 1 {
+		return invalid("expected exactly one owner, got %d", owners)
+	}
+
+	absences := map[string]bool{}
+	for _, abs := range a.Absences {
+		if abs.ID == "" || absences[abs.ID] {
+			return invalid("absence %q: missing or duplicate id", abs.ID)
+		}
+		absences[abs.ID] = true
+		if abs.UserID != nil && !members[*abs.UserID] {
+			return invalid("absence %s: user %s is not a member", abs.ID, *abs.UserID)
+		}
+		startsOn, err := parseArchiveDate(abs.StartsOn)
+		if err != nil {
+			return invalid("absence %s: %v", abs.ID, err)
+		}
+		endsOn, err := parseArchiveDate(abs.EndsOn)
+		if err != nil {
+			return invalid("absence %s: %v", abs.ID, err)
+		}
+		if endsOn.Before(startsOn) {
+			return invalid("absence %s: endsOn must not be before startsOn", abs.ID)
+		}
+		if abs.Reason != nil && utf8.RuneCountInString(*abs.Reason) > absenceReasonMaxLength {
+			return invalid("absence %s: reason must be %d characters or fewer", abs.ID, absenceReasonMaxLength)
+		}
+	}
+	holidays := map[string]bool{}
+	for _, h := range a.Holidays {
+		if _, err := parseArchiveDate(h.Date); err != nil {
+			return invalid("holiday: %v", err)
+		}
+		if holidays[h.Date] {
+			return invalid("holiday %s: duplicate date", h.Date)
+		}
+		holidays[h.Date] = true
+		if name := strings.TrimSpace(h.Name); name == "" || utf8.RuneCountInString(name) > holidayNameMaxLength {
+			return invalid("holiday %s: name is required and must be %d characters or fewer", h.Date, holidayNameMaxLength)
+		}
+		if h.Source != holidaySourceManual && h.Source != holidaySourceICalendar {
+			return invalid("holiday %s: unsupported source %q", h.Date, h.Source)
+		}
+	}
+
+	categories := map[string]bool{}
+	categoryNames := map[string]bool{}
+	for _, c := range a.TaskCategories {
+		if c.ID == "" || categories[c.ID] {
+			return invalid("task category %q: missing or duplicate id", c.ID)
+		}
+		categories[c.ID] = true
+		name := strings.ToLower(strings.TrimSpace(c.Name))
+		if name == "" || categoryNames[name] {
+			return invalid("task category %s: missing or duplicate name", c.ID)
+		}
+		categoryNames[name] = true
+		if c.ColorHex != nil {
+			if _, err := normalizeColorHex(c.ColorHex); err != nil {
+				return invalid("task category %s: %v", c.ID, err)
+			}
+		}
+	}
+
+	taskTypes := map[string]string{}
+	for _, t := range a.Tasks {
+		if t.ID == "" || taskTypes[t.ID] != "" {
+			return invalid("task %q: missing or duplicate id", t.ID)
+		}
+		if strings.TrimSpace(t.Title) == "" {
+			return invalid("task %s: title is required", t.ID)
+		}
+		if t.Type != "daily" && t.Type != "weekly" && t.Type != "one_off" {
+			return invalid("task %s: unsupported type %q", t.ID, t.Type)
+		}
+		taskTypes[t.ID] = t.Type
+		if (t.Type == "one_off") != (t.DueOn != nil) {
+			return invalid("task %s: dueOn must be set exactly for one_off tasks", t.ID)
+		}
+		for _, d := range []*string{t.DueOn, t.StartsOn, t.EndsOn} {
+			if d == nil {
+				continue
+			}
+			if _, err := parseArchiveDate(*d); err != nil {
+				return invalid("task %s: %v", t.ID, err)
+			}
+		}
+		if t.Type == "one_off" && (t.StartsOn != nil || t.EndsOn != nil) {
+			return invalid("task %s: startsOn and endsOn are not supported for one_off tasks", t.ID)
+		}
+		if t.StartsOn != nil && t.EndsOn != nil && *t.EndsOn < *t.StartsOn {
+			return invalid("task %s: endsOn must not be before startsOn", t.ID)
+		}
+		if t.PenaltyPoints < 0 || t.PenaltyPoints > 1000 {
+			return invalid("task %s: penalty points must be between 0 and 1000", t.ID)
+		}
+		if t.RequiredCompletionsPerWeek < 1 || t.RequiredCompletionsPerWeek > 7 || (t.Type != "weekly" && t.RequiredCompletionsPerWeek != 1) {
+			return invalid("task %s: invalid required completions per week %d", t.ID, t.RequiredCompletionsPerWeek)
+		}
+		if t.AssigneeUserID != nil && !members[*t.AssigneeUserID] {
+			return invalid("task %s: assignee %s is not a member", t.ID, *t.AssigneeUserID)
+		}
+		if t.CategoryID != nil && !categories[*t.CategoryID] {
+			return invalid("task %s: category %s is not in the archive", t.ID, *t.CategoryID)
+		}
+	}
+	checklistItems := map[string]bool{}
+	for _, item := range a.ChecklistItems {
+		if item.ID == "" || checklistItems[item.ID] {
+			return invalid("checklist item %q: missing or duplicate id", item.ID)
+		}
+		checklistItems[item.ID] = true
+		if taskTypes[item.TaskID] == "" {
+			return invalid("checklist item %s: unknown task %s", item.ID, item.TaskID)
+		}
+		if strings.TrimSpace(item.Title) == "" {
+			return invalid("checklist item %s: title is required", item.ID)
+		}
+		if item.Position < 0 {
+			return invalid("checklist item %s: position must not be negative", item.ID)
+		}
+	}
+	templates := map[string]bool{}
+	templateNames := map[string]bool{}
+	for _, tmpl := range a.TaskTemplates {
+		if tmpl.ID == "" || templates[tmpl.ID] {
+			return invalid("task template %q: missing or duplicate id", tmpl.ID)
+		}
+		templates[tmpl.ID] = true
+		name := strings.ToLower(strings.TrimSpace(tmpl.Name))
+		if name == "" || templateNames[name] {
+			return invalid("task template %s: missing or duplicate name", tmpl.ID)
+		}
+		templateNames[name] = true
+		if err := validateArchiveTaskTemplate(tmpl); err != nil {
+			return invalid("task template %s: %v", tmpl.ID, err)
+		}
+	}
+	for _, c := range a.DailyCompletions {
+		if taskTypes[c.TaskID] != "daily" {
+			return invalid("daily completion %s: unknown daily task", c.TaskID)
+		}
+		if _, err := parseArchiveDate(c.TargetDate); err != nil {
+			return invalid("daily completion %s: %v", c.TaskID, err)
+		}
+	}
+	for _, c := range a.WeeklyCompletions {
+		if taskTypes[c.TaskID] != "weekly" {
+			return invalid("weekly completion %s: unknown weekly task", c.TaskID)
+		}
+		d, err := parseArchiveDate(c.WeekStart)
+		if err != nil {
+			return invalid("weekly completion %s: %v", c.TaskID, err)
+		}
+		if d.Weekday() != time.Monday {
+			return invalid("weekly completion %s: week start %s is not a Monday", c.TaskID, c.WeekStart)
+		}
+	}
+
+	oneOffDone := map[string]bool{}
+	for _, c := range a.OneOffCompletions {
+		if taskTypes[c.TaskID] != "one_off" || oneOffDone[c.TaskID] {
+			return invalid("one-off completion %s: unknown one_off task or duplicate", c.TaskID)
+		}
+		oneOffDone[c.TaskID] = true
+	}
+
+	rules := map[string]bool{}
+	for _, r := range a.PenaltyRules {
+		if r.ID == "" || rules[r.ID] {
+			return invalid("penalty rule %q: missing or duplicate id", r.ID)
+		}
+		rules[r.ID] = true
+		if r.Threshold < 1 {
+			return invalid("penalty rule %s: threshold must be at least 1", r.ID)
+		}
+		if strings.TrimSpace(r.Name) == "" {
+			return invalid("penalty rule %s: name is required", r.ID)
+		}
+	}
+
+	months := map[string]bool{}
+	for _, m := range a.MonthlySummaries {
+		d, err := parseArchiveDate(m.MonthStart)
+		if err != nil {
+			return invalid("monthly summary: %v", err)
+		}
+		if d.Day() != 1 || months[m.MonthStart] {
+			return invalid("monthly summary %s: not a month start or duplicate", m.MonthStart)
+		}
+		months[m.MonthStart] = true
+		for _, r := range m.TriggeredRules {
+			if !rules[r.RuleID] {
+				return invalid("monthly summary %s: unknown triggered rule %s", m.MonthStart, r.RuleID)
+			}
+		}
+	}
+	for _, c := range a.CloseRuns {
+		if c.Scope != "close_day" && c.Scope != "close_week" && c.Scope != "close_month" {
+			return invalid("close run: unsupported scope %q", c.Scope)
+		}
+		if _, err := parseArchiveDate(c.TargetDate); err != nil {
+			return invalid("close run %s: %v", c.Scope, err)
+		}
+	}
+	for _, e := range a.TaskEvaluations {
+		if e.Scope != "penalty_day" && e.Scope != "penalty_week" {
+			return invalid("task evaluation: unsupported scope %q", e.Scope)
+		}
+		// Without its own points and category a charge is read from its
+		// task, which must then be in the archive.
+		charged := e.PenaltyPoints != nil
+		if e.TaskID == "" || (!charged && taskTypes[e.TaskID] == "") {
+			return invalid("task evaluation %s: unknown task %q", e.Scope, e.TaskID)
+		}
+		if charged && (*e.PenaltyPoints < 0 || *e.PenaltyPoints > 1000) {
+			return invalid("task evaluation %s: penalty points must be between 0 and 1000", e.Scope)
+		}
+		if e.CategoryID != nil && !categories[*e.CategoryID] && (e.CategoryName == nil || strings.TrimSpace(*e.CategoryName) == "") {
+			return invalid("task evaluation %s: category %s is not in the archive and has no name", e.Scope, *e.CategoryID)
+		}
+		if _, err := parseArchiveDate(e.TargetDate); err != nil {
+			return invalid("task evaluation %s: %v", e.Scope, err)
+		}
+	}
+	for _, h := range a.CloseRunHistory {
+		if h.Scope != "day" && h.Scope != "week" && h.Scope != "month" {
+			return invalid("close run history: unsupported scope %q", h.Scope)
+		}
+	}
+	return nil
+}
+
+// validateArchiveTaskTemplate applies the limits of templates saved through
+// the API. Items are imported as written, so they must already be in the
+// normalized form: required completions set and dueInDays only on one_off.
+func validateArchiveTaskTemplate(tmpl ArchiveTaskTemplate) error {
+	t := tasktemplates.Template{ID: tmpl.ID, Name: tmpl.Name, Description: tmpl.Description}
+	for _, item := range tmpl.Items {
+		converted := tasktemplates.Item{
+			Title:                      item.Title,
+			Notes:                      item.Notes,
+			Type:                       item.Type,
+			PenaltyPoints:              item.PenaltyPoints,
+			RequiredCompletionsPerWeek: item.RequiredCompletionsPerWeek,
+			Checklist:                  item.Checklist,
+		}
+		if (item.Type == tasktemplates.TypeOneOff) != (item.DueInDays != nil) {
+			return errors.New("dueInDays must be set exactly for one_off tasks")
+		}
+		if item.DueInDays != nil {
+			converted.DueInDays = *item.DueInDays
+		}
+		if item.RequiredCompletionsPerWeek < 1 {
+			return errors.New("required completions per week must be at least 1")
+		}
+		t.Items = append(t.Items, converted)
+	}
+	_, err := t.Normalize()
+	return err
+}
+
+func parseArchiveDate(raw string) (time.Time, error) {
+	d, err := time.Parse(archiveDateLayout, raw)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", raw)
+	}
+	return d, nil
+}
+
+// mustParseArchiveDate is only used after validateTeamArchive accepted the
+// archive.
+func mustParseArchiveDate(raw string) time.Time {
+	d, _ := parseArchiveDate(raw)
+	return d
+}
+
+func archiveDatePtr(d pgtype.Date) *string {
+	if !d.Valid {
+		return nil
+	}
+	v := d.Time.Format(archiveDateLayout)
+	return &v
+}
+
+func pgDateFromArchive(raw *string) pgtype.Date {
+	if raw == nil {
+		return pgtype.Date{}
+	}
+	return toPgDate(mustParseArchiveDate(*raw))
+}
@@ -0,0 +1,240 @@
+package store
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	dbsqlc "github.com/megu/kaji-challenge/backend/internal/db/sqlc"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func validArchive() TeamArchive {
+	owner := "user-1"
+	dueOn := "2026-01-09"
+	category := "cat-1"
+	dueInDays := 14
+	return TeamArchive{
+		Format:  TeamArchiveFormat,
+		Version: TeamArchiveVersion,
+		Team:    ArchiveTeam{ID: "team-1", Name: "Home"},
+		Members: []ArchiveMember{
+			{UserID: owner, Email: "owner@example.com", DisplayName: "Owner", Role: "owner"},
+			{UserID: "user-2", Email: "member@example.com", DisplayName: "Member", Role: "member"},
+		},
+		Absences: []ArchiveAbsence{
+			{ID: "abs-1", UserID: &owner, StartsOn: "2026-01-12", EndsOn: "2026-01-14", CreatedByUserID: &owner},
+			{ID: "abs-2", StartsOn: "2026-01-20", EndsOn: "2026-01-20"},
+		},
+		Holidays: []ArchiveHoliday{
+			{Date: "2026-01-01", Name: "New Year's Day", Source: holidaySourceICalendar},
+			{Date: "2026-01-02", Name: "Family day", Source: holidaySourceManual, CreatedByUserID: &owner},
+		},
+		TaskCategories: []ArchiveTaskCategory{{ID: category, Name: "Kitchen"}},
+		Tasks: []ArchiveTask{
+			{ID: "task-d", Title: "Dishes", Type: "daily", RequiredCompletionsPerWeek: 1, AssigneeUserID: &owner, CategoryID: &category},
+			{ID: "task-w", Title: "Laundry", Type: "weekly", RequiredCompletionsPerWeek: 2},
+			{ID: "task-o", Title: "Tax return", Type: "one_off", RequiredCompletionsPerWeek: 1, DueOn: &dueOn},
+		},
+		ChecklistItems: []ArchiveChecklistItem{{ID: "item-1", TaskID: "task-w", Title: "Scrub the tub"}},
+		TaskTemplates: []ArchiveTaskTemplate{{
+			ID:   "tpl-1",
+			Name: "Weekend",
+			Items: []ArchiveTaskTemplateItem{
+				{Title: "Sheets", Type: "weekly", RequiredCompletionsPerWeek: 1, Checklist: []string{"Strip the bed"}},
+				{Title: "Renew passport", Type: "one_off", RequiredCompletionsPerWeek: 1, DueInDays: &dueInDays},
+			},
+		}},
+		DailyCompletions:  []ArchiveDailyCompletion{{TaskID: "task-d", TargetDate: "2026-01-05"}},
+		WeeklyCompletions: []ArchiveWeeklyCompletion{{TaskID: "task-w", WeekStart: "2026-01-05"}},
+		OneOffCompletions: []ArchiveOneOffCompletion{{TaskID: "task-o"}},
+		PenaltyRules:      []ArchivePenaltyRule{{ID: "rule-1", Threshold: 3, Name: "Dinner"}},
+		MonthlySummaries: []ArchiveMonthlySummary{{
+			MonthStart:     "2026-01-01",
+			IsClosed:       true,
+			TriggeredRules: []ArchiveTriggeredRule{{RuleID: "rule-1"}},
+		}},
+		CloseRuns:       []ArchiveCloseRun{{Scope: "close_month", TargetDate: "2026-01-01"}},
+		TaskEvaluations: []ArchiveTaskEvaluation{{Scope: "penalty_day", TargetDate: "2026-01-05", TaskID: "task-d"}},
+		CloseRunHistory: []ArchiveCloseRunHistory{{Scope: "month", Trigger: CloseTriggerOps}},
+	}
+}
+
+func TestValidateTeamArchive(t *testing.T) {
+	stranger := "user-9"
+	cases := []struct {
+		name   string
+		mutate func(a *TeamArchive)
+		want   string
+	}{
+		{name: "valid", mutate: func(*TeamArchive) {}},
+		{name: "previous version", mutate: func(a *TeamArchive) { a.Version = teamArchiveMinVersion }},
+		{name: "unknown version", mutate: func(a *TeamArchive) { a.Version = TeamArchiveVersion + 1 }, want: "unsupported version"},
+		{name: "no owner", mutate: func(a *TeamArchive) { a.Members[0].Role = "member" }, want: "exactly one owner"},
+		{name: "duplicate email", mutate: func(a *TeamArchive) { a.Members[1].Email = "OWNER@example.com" }, want: "duplicate email"},
+		{name: "assignee outside team", mutate: func(a *TeamArchive) { a.Tasks[1].AssigneeUserID = &stranger }, want: "is not a member"},
+		{name: "daily task with weekly quota", mutate: func(a *TeamArchive) { a.Tasks[0].RequiredCompletionsPerWeek = 2 }, want: "required completions"},
+		{name: "completion of wrong task type", mutate: func(a *TeamArchive) { a.DailyCompletions[0].TaskID = "task-w" }, want: "unknown daily task"},
+		{name: "week start not monday", mutate: func(a *TeamArchive) { a.WeeklyCompletions[0].WeekStart = "2026-01-06" }, want: "not a Monday"},
+		{name: "one-off task without due date", mutate: func(a *TeamArchive) { a.Tasks[2].DueOn = nil }, want: "dueOn must be set"},
+		{name: "due date on recurring task", mutate: func(a *TeamArchive) { a.Tasks[0].DueOn = a.Tasks[2].DueOn }, want: "dueOn must be set"},
+		{name: "window on one-off task", mutate: func(a *TeamArchive) { a.Tasks[2].StartsOn = a.Tasks[2].DueOn }, want: "not supported for one_off"},
+		{name: "inverted window", mutate: func(a *TeamArchive) {
+			startsOn, endsOn := "2026-09-30", "2026-06-01"
+			a.Tasks[0].StartsOn, a.Tasks[0].EndsOn = &startsOn, &endsOn
+		}, want: "endsOn must not be before startsOn"},
+		{name: "absence of a non-member", mutate: func(a *TeamArchive) { a.Absences[0].UserID = &stranger }, want: "user user-9 is not a member"},
+		{name: "inverted absence", mutate: func(a *TeamArchive) { a.Absences[1].EndsOn = "2026-01-19" }, want: "endsOn must not be before startsOn"},
+		{name: "duplicate absence id", mutate: func(a *TeamArchive) { a.Absences[1].ID = "abs-1" }, want: "missing or duplicate id"},
+		{name: "duplicate holiday date", mutate: func(a *TeamArchive) { a.Holidays[1].Date = "2026-01-01" }, want: "duplicate date"},
+		{name: "holiday without name", mutate: func(a *TeamArchive) { a.Holidays[0].Name = " " }, want: "name is required"},
+		{name: "holiday with unknown source", mutate: func(a *TeamArchive) { a.Holidays[0].Source = "import" }, want: "unsupported source"},
+		{name: "duplicate category name", mutate: func(a *TeamArchive) {
+			a.TaskCategories = append(a.TaskCategories, ArchiveTaskCategory{ID: "cat-2", Name: " kitchen"})
+		}, want: "duplicate name"},
+		{name: "unknown task category", mutate: func(a *TeamArchive) { a.TaskCategories = nil }, want: "category cat-1 is not in the archive"},
+		{name: "checklist item of unknown task", mutate: func(a *TeamArchive) { a.ChecklistItems[0].TaskID = "task-9" }, want: "unknown task task-9"},
+		{name: "task template without tasks", mutate: func(a *TeamArchive) { a.TaskTemplates[0].Items = nil }, want: "must have between 1 and"},
+		{name: "task template item without quota", mutate: func(a *TeamArchive) { a.TaskTemplates[0].Items[0].RequiredCompletionsPerWeek = 0 }, want: "at least 1"},
+		{name: "task template one-off without due days", mutate: func(a *TeamArchive) { a.TaskTemplates[0].Items[1].DueInDays = nil }, want: "dueInDays must be set"},
+		{name: "duplicate task template name", mutate: func(a *TeamArchive) {
+			a.TaskTemplates = append(a.TaskTemplates, ArchiveTaskTemplate{ID: "tpl-2", Name: "weekend ", Items: a.TaskTemplates[0].Items})
+		}, want: "duplicate name"},
+		{name: "one-off completion of recurring task", mutate: func(a *TeamArchive) { a.OneOffCompletions[0].TaskID = "task-d" }, want: "unknown one_off task"},
+		{name: "unknown triggered rule", mutate: func(a *TeamArchive) { a.MonthlySummaries[0].TriggeredRules[0].RuleID = "rule-9" }, want: "unknown triggered rule"},
+		{name: "summary not on month start", mutate: func(a *TeamArchive) { a.MonthlySummaries[0].MonthStart = "2026-01-02" }, want: "not a month start"},
+		{name: "bad close run scope", mutate: func(a *TeamArchive) { a.CloseRuns[0].Scope = "day" }, want: "unsupported scope"},
+		{name: "evaluation of unknown task", mutate: func(a *TeamArchive) { a.TaskEvaluations[0].TaskID = "task-9" }, want: "unknown task"},
+		{name: "evaluation with negative points", mutate: func(a *TeamArchive) {
+			points := -1
+			a.TaskEvaluations[0].PenaltyPoints = &points
+		}, want: "penalty points must be between 0 and 1000"},
+		{name: "evaluation of unknown category", mutate: func(a *TeamArchive) {
+			points, category := 2, "cat-9"
+			a.TaskEvaluations[0].PenaltyPoints, a.TaskEvaluations[0].CategoryID = &points, &category
+		}, want: "category cat-9 is not in the archive"},
+		{name: "charge of a purged task and deleted category", mutate: func(a *TeamArchive) {
+			points, category, name := 2, "cat-9", "Garden"
+			a.TaskEvaluations[0].TaskID = "task-9"
+			a.TaskEvaluations[0].PenaltyPoints, a.TaskEvaluations[0].CategoryID, a.TaskEvaluations[0].CategoryName = &points, &category, &name
+		}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := validArchive()
+			tc.mutate(&a)
+			err := validateTeamArchive(a)
+			if tc.want == "" {
+				if err != nil {
+					t.Fatalf("expected archive to be valid, got %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tc.want) {
+				t.Fatalf("expected error containing %q, got %v", tc.want, err)
+			}
+			if !strings.HasPrefix(err.Error(), "invalid archive:") {
+				t.Fatalf("expected an invalid archive error, got %v", err)
+			}
+		})
+	}
+}
+
+func TestExportImportRoundTripRemapsIDs(t *testing.T) {
+	src := newTestStore(t)
+	ctx := context.Background()
+	base := time.Date(2026, 1, 5, 12, 0, 0, 0, src.loc)
+
+	teamID, userID := createTeamWithMember(t, src, "archive@example.com", base)
+	if err := src.q.CreateTeamAbsence(ctx, dbsqlc.CreateTeamAbsenceParams{
+		ID:              src.nextID("abs"),
+		TeamID:          teamID,
+		UserID:          userID,
+		StartsOn:        toPgDate(base.AddDate(0, 2, 0)),
+		EndsOn:          toPgDate(base.AddDate(0, 2, 6)),
+		CreatedByUserID: userID,
+		CreatedAt:       toPgTimestamptz(base),
+	}); err != nil {
+		t.Fatalf("failed to create absence: %v", err)
+	}
+	if err := src.q.UpsertTeamHoliday(ctx, dbsqlc.UpsertTeamHolidayParams{
+		TeamID:          teamID,
+		HolidayDate:     toPgDate(base.AddDate(0, 2, 10)),
+		Name:            "Spring Equinox Day",
+		Source:          holidaySourceManual,
+		CreatedByUserID: userID,
+		CreatedAt:       toPgTimestamptz(base),
+	}); err != nil {
+		t.Fatalf("failed to create holiday: %v", err)
+	}
+	dailyID := createTaskAtWithID(t, src, teamID, api.Daily, 2, 1, base)
+	deletedID := createTaskAtWithID(t, src, teamID, api.Weekly, 1, 2, base)
+	if err := src.q.DeleteTask(ctx, dbsqlc.DeleteTaskParams{ID: deletedID, DeletedAt: toPgTimestamptz(base.AddDate(0, 0, 3))}); err != nil {
+		t.Fatalf("failed to delete task: %v", err)
+	}
+	createPenaltyRuleAt(t, src, teamID, 1, "Dinner", base)
+	src.SetClock(FixedClock(time.Date(2026, 2, 10, 12, 0, 0, 0, src.loc)))
+	if _, err := src.CloseDayForTeam(ctx, teamID); err != nil {
+		t.Fatalf("CloseDayForTeam failed: %v", err)
+	}
+	if _, err := src.CloseMonthForTeam(ctx, teamID); err != nil {
+		t.Fatalf("CloseMonthForTeam failed: %v", err)
+	}
+
+	archive, err := src.ExportTeam(ctx, teamID)
+	if err != nil {
+		t.Fatalf("ExportTeam failed: %v", err)
+	}
+	if len(archive.Tasks) != 2 || len(archive.MonthlySummaries) == 0 || len(archive.CloseRuns) == 0 || len(archive.CloseRunHistory) != 2 {
+		t.Fatalf("expected soft-deleted tasks, summaries and close runs in the archive, got %+v", archive)
+	}
+
+	dst := newTestStore(t)
+	dry, err := dst.ImportTeam(ctx, archive, true)
+	if err != nil {
+		t.Fatalf("ImportTeam dry run failed: %v", err)
+	}
+	if _, err := dst.ExportTeam(ctx, dry.TeamID); err == nil {
+		t.Fatalf("expected dry run to leave nothing behind")
+	}
+
+	res, err := dst.ImportTeam(ctx, archive, false)
+	if err != nil {
+		t.Fatalf("ImportTeam failed: %v", err)
+	}
+	if res.TeamID == teamID || res.Tasks != 2 || res.Members != 1 {
+		t.Fatalf("unexpected import result: %+v", res)
+	}
+	restored, err := dst.ExportTeam(ctx, res.TeamID)
+	if err != nil {
+		t.Fatalf("ExportTeam of restored team failed: %v", err)
+	}
+	if len(restored.Tasks) != len(archive.Tasks) || len(restored.DailyCompletions) != len(archive.DailyCompletions) ||
+		len(restored.MonthlySummaries) != len(archive.MonthlySummaries) || len(restored.TaskEvaluations) != len(archive.TaskEvaluations) {
+		t.Fatalf("expected restored team to match the archive, got %+v", restored)
+	}
+	for _, task := range restored.Tasks {
+		if task.ID == dailyID || task.ID == deletedID {
+			t.Fatalf("expected task ids to be remapped, got %s", task.ID)
+		}
+	}
+	if restored.Tasks[1].DeletedAt == nil {
+		t.Fatalf("expected the soft-deleted task to stay deleted")
+	}
+	if len(restored.Absences) != 1 || restored.Absences[0].UserID == nil || *restored.Absences[0].UserID == userID ||
+		*restored.Absences[0].UserID != restored.Members[0].UserID || restored.Absences[0].StartsOn != archive.Absences[0].StartsOn {
+		t.Fatalf("expected the absence to be restored for the remapped member, got %+v", restored.Absences)
+	}
+	if len(restored.Holidays) != 1 || restored.Holidays[0].Date != archive.Holidays[0].Date || restored.Holidays[0].Name != "Spring Equinox Day" ||
+		restored.Holidays[0].CreatedByUserID == nil || *restored.Holidays[0].CreatedByUserID != restored.Members[0].UserID {
+		t.Fatalf("expected the holiday calendar to be restored, got %+v", restored.Holidays)
+	}
+	if restored.MonthlySummaries[0].DailyPenaltyTotal != archive.MonthlySummaries[0].DailyPenaltyTotal {
+		t.Fatalf("expected summary totals to survive, got %+v", restored.MonthlySummaries[0])
+	}
+
+	if _, err := dst.ImportTeam(ctx, archive, false); err == nil || !strings.Contains(err.Error(), "users already exist") {
+		t.Fatalf("expected a second import to be refused, got %v", err)
+	}
+}
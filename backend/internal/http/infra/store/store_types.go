@@ -1,10 +1,12 @@
 package store
 
 import (
+	"net/http"
 	"sync"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/megu/kaji-challenge/backend/internal/blobstore"
 	dbsqlc "github.com/megu/kaji-challenge/backend/internal/db/sqlc"
 	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
 )
@@ -18,12 +20,24 @@ const (
 type Store struct {
 	mu sync.Mutex
 
-	loc *time.Location
-	db  *pgxpool.Pool
-	q   *dbsqlc.Queries
+	loc   *time.Location
+	clock Clock
+	db    *pgxpool.Pool
+	q     *dbsqlc.Queries
 
 	eventHub *teamEventHub
 
+	outboxPollInterval time.Duration
+	outboxWake         chan struct{}
+	outboxConsumers    []teamEventConsumer
+
+	webhookCfg    webhookWorkerConfig
+	webhookClient *http.Client
+
+	closeSchedulerCfg closeSchedulerConfig
+
+	blobs blobstore.Store
+
 	users       map[string]userRecord
 	usersByMail map[string]string
 	memberships map[string][]membership
@@ -73,6 +87,10 @@ type taskRecord struct {
 	Penalty    int
 	AssigneeID *string
 	Required   int
+	DueOn      *time.Time
+	Window     taskWindow
+	CategoryID *string
+	Revision   int64
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
 	DeletedAt  *time.Time
@@ -84,6 +102,7 @@ type ruleRecord struct {
 	Threshold   int
 	Name        string
 	Description *string
+	Revision    int64
 	DeletedAt   *time.Time
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
@@ -97,4 +116,5 @@ type monthSummary struct {
 	IsClosed         bool
 	TriggeredRuleID  []string
 	TaskStatusByDate []api.MonthlyTaskStatusGroup
+	CategoryStats    []api.MonthlyCategoryStat
 }
@@ -14,7 +14,7 @@ import (
 )
 
 func (s *Store) getOrCreateUserLocked(ctx context.Context, issuer, subject, email, displayName string) (string, userRecord, error) {
-	now := time.Now().In(s.loc)
+	now := s.now()
 	issuer = strings.TrimSpace(issuer)
 	subject = strings.TrimSpace(subject)
 	if issuer == "" || subject == "" {
@@ -93,7 +93,7 @@ func (s *Store) syncUserOIDCIdentityLocked(ctx context.Context, userID, issuer,
 			ID:           userID,
 			Column2:      issuer,
 			Column3:      subject,
-			OidcLinkedAt: toPgTimestamptz(time.Now().In(s.loc)),
+			OidcLinkedAt: toPgTimestamptz(s.now()),
 		})
 	}
 	if currentIssuer == issuer && currentSubject == subject {
@@ -214,7 +214,7 @@ func (s *Store) CreateInvite(ctx context.Context, userID string, req api.CreateI
 		return api.InviteCodeResponse{}, err
 	}
 	code := strings.ToUpper(raw[:10])
-	expiresAt := time.Now().In(s.loc).Add(time.Duration(expiresInHours) * time.Hour)
+	expiresAt := s.now().Add(time.Duration(expiresInHours) * time.Hour)
 	membership, err := s.primaryMembershipLocked(ctx, userID)
 	if err != nil {
 		return api.InviteCodeResponse{}, err
@@ -235,11 +235,20 @@ func (s *Store) CreateInvite(ctx context.Context, userID string, req api.CreateI
 			if err := qtx.DeleteInviteCodesByTeamID(txCtx, m.TeamID); err != nil {
 				return err
 			}
-			return qtx.CreateInviteCode(txCtx, dbsqlc.CreateInviteCodeParams{
+			if err := qtx.CreateInviteCode(txCtx, dbsqlc.CreateInviteCodeParams{
 				Code:      code,
 				TeamID:    m.TeamID,
 				ExpiresAt: toPgTimestamptz(expiresAt),
+			}); err != nil {
+				return err
+			}
+			// The code itself is a credential, so only its expiry is logged.
+			recordAudit(txCtx, auditChange{
+				Entity: "invite",
+				Action: "create",
+				After:  map[string]time.Time{"expiresAt": expiresAt},
 			})
+			return nil
 		},
 	); err != nil {
 		return api.InviteCodeResponse{}, err
@@ -279,18 +288,42 @@ func (s *Store) PatchTeamCurrent(ctx context.Context, userID string, req api.Upd
 	if err != nil {
 		return api.TeamInfoResponse{}, err
 	}
+	var settingsRevision int64
 	if _, err := s.runWithTeamRevisionCAS(
 		ctx,
 		membership.TeamID,
 		"team_state",
 		map[string]string{"action": "rename"},
-		func(_ context.Context, qtx *dbsqlc.Queries) error {
-			return qtx.UpdateTeamName(ctx, dbsqlc.UpdateTeamNameParams{ID: membership.TeamID, Name: teamName})
+		func(txCtx context.Context, qtx *dbsqlc.Queries) error {
+			current, err := qtx.GetTeamSettings(txCtx, membership.TeamID)
+			if err != nil {
+				return err
+			}
+			if err := checkEntityIfMatch(txCtx, etagKindTeamSettings, membership.TeamID, current.SettingsRevision); err != nil {
+				return err
+			}
+			settingsRevision = nextEntityRevision(txCtx)
+			if err := qtx.UpdateTeamName(txCtx, dbsqlc.UpdateTeamNameParams{
+				ID:               membership.TeamID,
+				Name:             teamName,
+				SettingsRevision: settingsRevision,
+			}); err != nil {
+				return err
+			}
+			recordAudit(txCtx, auditChange{
+				Entity:   "team_state",
+				EntityID: membership.TeamID,
+				Action:   "rename",
+				Before:   map[string]string{"name": current.Name},
+				After:    map[string]string{"name": teamName},
+			})
+			return nil
 		},
 	); err != nil {
 		return api.TeamInfoResponse{}, err
 	}
-	return api.TeamInfoResponse{TeamId: membership.TeamID, Name: teamName}, nil
+	etag := entityETag(etagKindTeamSettings, membership.TeamID, settingsRevision)
+	return api.TeamInfoResponse{TeamId: membership.TeamID, Name: teamName, Etag: &etag}, nil
 }
 
 func (s *Store) GetTeamCurrentMembers(ctx context.Context, userID string) (api.TeamMembersResponse, error) {
@@ -333,7 +366,7 @@ func (s *Store) JoinTeam(ctx context.Context, userID, code string) (api.JoinTeam
 	if err != nil {
 		return api.JoinTeamResponse{}, errors.New("invite code not found")
 	}
-	now := time.Now().In(s.loc)
+	now := s.now()
 	if invite.ExpiresAt.Time.In(s.loc).Before(now) {
 		return api.JoinTeamResponse{}, errors.New("invite code expired")
 	}
@@ -362,35 +395,21 @@ func (s *Store) JoinTeam(ctx context.Context, userID, code string) (api.JoinTeam
 		_ = tx.Rollback(ctx)
 	}()
 	qtx := s.q.WithTx(tx)
+	actorCtx := NewActorContext(ctx, userID)
 
 	if len(memberships) > 0 {
 		current := memberships[0]
-		deletedOldTeam, err := s.detachFromCurrentTeam(ctx, qtx, userID, current.TeamID, current.Role)
-		if err != nil {
+		if err := s.leaveTeamLocked(actorCtx, qtx, userID, current.TeamID, current.Role); err != nil {
 			return api.JoinTeamResponse{}, err
 		}
-		if !deletedOldTeam {
-			if err := qtx.DeleteTeamMember(ctx, dbsqlc.DeleteTeamMemberParams{TeamID: current.TeamID, UserID: userID}); err != nil {
-				return api.JoinTeamResponse{}, err
-			}
-		}
 	}
-
-	if err := qtx.AddTeamMember(ctx, dbsqlc.AddTeamMemberParams{
-		TeamID:    invite.TeamID,
-		UserID:    userID,
-		Role:      string(api.TeamMembershipRoleMember),
-		CreatedAt: toPgTimestamptz(now),
-	}); err != nil {
+	if err := s.joinTeamLocked(actorCtx, qtx, userID, invite.TeamID, string(api.TeamMembershipRoleMember), now); err != nil {
 		return api.JoinTeamResponse{}, err
 	}
 	if err := tx.Commit(ctx); err != nil {
 		return api.JoinTeamResponse{}, err
 	}
-	if len(memberships) > 0 && memberships[0].TeamID != invite.TeamID {
-		_, _ = s.bumpTeamRevisionBestEffort(ctx, memberships[0].TeamID, "team_member", map[string]string{"action": "leave"})
-	}
-	_, _ = s.bumpTeamRevisionBestEffort(ctx, invite.TeamID, "team_member", map[string]string{"action": "join"})
+	s.notifyOutbox()
 	return api.JoinTeamResponse{TeamId: invite.TeamID}, nil
 }
 
@@ -411,7 +430,7 @@ func (s *Store) PostTeamLeave(ctx context.Context, userID string) (api.JoinTeamR
 		return api.JoinTeamResponse{}, err
 	}
 
-	now := time.Now().In(s.loc)
+	now := s.now()
 	newTeamID := s.nextID("team")
 	tx, err := s.db.Begin(ctx)
 	if err != nil {
@@ -421,16 +440,11 @@ func (s *Store) PostTeamLeave(ctx context.Context, userID string) (api.JoinTeamR
 		_ = tx.Rollback(ctx)
 	}()
 	qtx := s.q.WithTx(tx)
+	actorCtx := NewActorContext(ctx, userID)
 
-	deletedOldTeam, err := s.detachFromCurrentTeam(ctx, qtx, userID, current.TeamID, current.Role)
-	if err != nil {
+	if err := s.leaveTeamLocked(actorCtx, qtx, userID, current.TeamID, current.Role); err != nil {
 		return api.JoinTeamResponse{}, err
 	}
-	if !deletedOldTeam {
-		if err := qtx.DeleteTeamMember(ctx, dbsqlc.DeleteTeamMemberParams{TeamID: current.TeamID, UserID: userID}); err != nil {
-			return api.JoinTeamResponse{}, err
-		}
-	}
 
 	if err := qtx.CreateTeam(ctx, dbsqlc.CreateTeamParams{
 		ID:        newTeamID,
@@ -439,23 +453,129 @@ func (s *Store) PostTeamLeave(ctx context.Context, userID string) (api.JoinTeamR
 	}); err != nil {
 		return api.JoinTeamResponse{}, err
 	}
-	if err := qtx.AddTeamMember(ctx, dbsqlc.AddTeamMemberParams{
-		TeamID:    newTeamID,
-		UserID:    userID,
-		Role:      string(api.TeamMembershipRoleOwner),
-		CreatedAt: toPgTimestamptz(now),
-	}); err != nil {
+	if err := s.joinTeamLocked(actorCtx, qtx, userID, newTeamID, string(api.TeamMembershipRoleOwner), now); err != nil {
 		return api.JoinTeamResponse{}, err
 	}
 
 	if err := tx.Commit(ctx); err != nil {
 		return api.JoinTeamResponse{}, err
 	}
-	_, _ = s.bumpTeamRevisionBestEffort(ctx, current.TeamID, "team_member", map[string]string{"action": "leave"})
-	_, _ = s.bumpTeamRevisionBestEffort(ctx, newTeamID, "team_member", map[string]string{"action": "join"})
+	s.notifyOutbox()
 	return api.JoinTeamResponse{TeamId: newTeamID}, nil
 }
 
+// deletedUserDisplayName replaces the name of a deleted account, so past
+// completions still show who did them without revealing who that was.
+const deletedUserDisplayName = "Deleted user"
+
+// DeleteMe deletes the user's account. The user leaves every team the same
+// way as PostTeamLeave, handing ownership to the oldest other member, and
+// loses all sessions. The users row itself is kept as an anonymised tombstone
+// so completion attribution, and the per-member statistics built on it, stay
+// as they were.
+func (s *Store) DeleteMe(ctx context.Context, userID string) error {
+	memberships, err := s.q.ListMembershipsByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if len(memberships) > 0 {
+		if err := s.verifyIfMatchAgainstTeam(ctx, memberships[0].TeamID, false); err != nil {
+			return err
+		}
+	}
+
+	now := s.now()
+	tx, err := s.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+	qtx := s.q.WithTx(tx)
+	// The account is gone, so the leave is logged without an actor.
+	systemCtx := NewActorContext(ctx, "")
+
+	for _, m := range memberships {
+		if err := s.leaveTeamLocked(systemCtx, qtx, userID, m.TeamID, m.Role); err != nil {
+			return err
+		}
+	}
+	if err := qtx.DeleteSessionsByUserID(ctx, userID); err != nil {
+		return err
+	}
+	if err := qtx.DeleteExchangeCodesByUserID(ctx, userID); err != nil {
+		return err
+	}
+	if err := qtx.DeletePersonalDataExportsByUserID(ctx, userID); err != nil {
+		return err
+	}
+	// The placeholder email keeps users_email_lower_uq satisfied and frees
+	// the real address for a new signup.
+	anonymized, err := qtx.AnonymizeUser(ctx, dbsqlc.AnonymizeUserParams{
+		ID:          userID,
+		Email:       "deleted+" + userID + "@users.invalid",
+		DisplayName: deletedUserDisplayName,
+		DeletedAt:   toPgTimestamptz(now),
+	})
+	if err != nil {
+		return err
+	}
+	if anonymized == 0 {
+		return errors.New("user not found")
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	s.notifyOutbox()
+	return nil
+}
+
+// joinTeamLocked adds userID to teamID with role and advances the team
+// revision with the join in the audit log, inside the caller's transaction.
+func (s *Store) joinTeamLocked(ctx context.Context, qtx *dbsqlc.Queries, userID, teamID, role string, now time.Time) error {
+	if err := qtx.AddTeamMember(ctx, dbsqlc.AddTeamMemberParams{
+		TeamID:    teamID,
+		UserID:    userID,
+		Role:      role,
+		CreatedAt: toPgTimestamptz(now),
+	}); err != nil {
+		return err
+	}
+	audit := &auditRecorder{}
+	recordAudit(withAuditRecorder(ctx, audit), auditChange{
+		Entity:   "team_member",
+		EntityID: userID,
+		Action:   "join",
+		After:    map[string]string{"userId": userID, "role": role},
+	})
+	_, err := s.bumpTeamRevisionLocked(ctx, qtx, teamID, "team_member", map[string]string{"userId": userID, "action": "join"}, audit)
+	return err
+}
+
+// leaveTeamLocked removes userID from teamID inside the caller's transaction.
+// A team left empty is deleted; otherwise its revision advances with the
+// leave, and any ownership hand-over, in the audit log.
+func (s *Store) leaveTeamLocked(ctx context.Context, qtx *dbsqlc.Queries, userID, teamID, role string) error {
+	audit := &auditRecorder{}
+	recordAudit(withAuditRecorder(ctx, audit), auditChange{
+		Entity:   "team_member",
+		EntityID: userID,
+		Action:   "leave",
+		Before:   map[string]string{"userId": userID, "role": role},
+	})
+	deletedTeam, err := s.detachFromCurrentTeam(withAuditRecorder(ctx, audit), qtx, userID, teamID, role)
+	if err != nil || deletedTeam {
+		return err
+	}
+	if err := qtx.DeleteTeamMember(ctx, dbsqlc.DeleteTeamMemberParams{TeamID: teamID, UserID: userID}); err != nil {
+		return err
+	}
+	_, err = s.bumpTeamRevisionLocked(ctx, qtx, teamID, "team_member", map[string]string{"userId": userID, "action": "leave"}, audit)
+	return err
+}
+
 func (s *Store) detachFromCurrentTeam(ctx context.Context, qtx *dbsqlc.Queries, userID, teamID, role string) (bool, error) {
 	if err := qtx.ClearTaskAssigneeByTeamAndUser(ctx, dbsqlc.ClearTaskAssigneeByTeamAndUserParams{TeamID: teamID, Column2: userID}); err != nil {
 		return false, err
@@ -479,6 +599,13 @@ func (s *Store) detachFromCurrentTeam(ctx context.Context, qtx *dbsqlc.Queries,
 	if err := qtx.UpdateTeamMemberRole(ctx, dbsqlc.UpdateTeamMemberRoleParams{TeamID: teamID, UserID: oldestOtherUserID, Role: string(api.TeamMembershipRoleOwner)}); err != nil {
 		return false, err
 	}
+	recordAudit(ctx, auditChange{
+		Entity:   "team_member",
+		EntityID: oldestOtherUserID,
+		Action:   "promote",
+		Before:   map[string]string{"userId": oldestOtherUserID, "role": string(api.TeamMembershipRoleMember)},
+		After:    map[string]string{"userId": oldestOtherUserID, "role": string(api.TeamMembershipRoleOwner)},
+	})
 
 	return false, nil
 }
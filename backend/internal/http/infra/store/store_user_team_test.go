@@ -0,0 +1,79 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dbsqlc "github.com/megu/kaji-challenge/backend/internal/db/sqlc"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func TestDeleteMeHandsOverOwnershipAndAnonymizesCompletions(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	base := time.Date(2026, 3, 2, 9, 0, 0, 0, s.loc)
+	s.SetClock(FixedClock(base))
+
+	teamID, ownerID := createTeamWithMember(t, s, "leaving@example.com", base)
+	memberID := s.nextID("user")
+	if err := s.q.CreateUser(ctx, dbsqlc.CreateUserParams{
+		ID:          memberID,
+		Email:       "staying@example.com",
+		DisplayName: "Member",
+		CreatedAt:   toPgTimestamptz(base),
+	}); err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	if err := s.q.AddTeamMember(ctx, dbsqlc.AddTeamMemberParams{
+		TeamID:    teamID,
+		UserID:    memberID,
+		Role:      string(api.TeamMembershipRoleMember),
+		CreatedAt: toPgTimestamptz(base.Add(time.Minute)),
+	}); err != nil {
+		t.Fatalf("failed to add team member: %v", err)
+	}
+	taskID := createTaskAtWithID(t, s, teamID, api.Daily, 1, 1, base)
+	if err := s.q.CreateTaskCompletionDaily(ctx, dbsqlc.CreateTaskCompletionDailyParams{
+		TaskID:            taskID,
+		TargetDate:        toPgDate(base),
+		CompletedByUserID: ownerID,
+	}); err != nil {
+		t.Fatalf("failed to complete task: %v", err)
+	}
+	if err := s.q.CreateSession(ctx, dbsqlc.CreateSessionParams{Token: hashToken("session-token"), UserID: ownerID}); err != nil {
+		t.Fatalf("failed to create session: %v", err)
+	}
+
+	if err := s.DeleteMe(ctx, ownerID); err != nil {
+		t.Fatalf("DeleteMe failed: %v", err)
+	}
+
+	if _, ok := s.LookupSession(ctx, "session-token"); ok {
+		t.Fatalf("expected sessions to be revoked")
+	}
+	members, err := s.GetTeamCurrentMembers(ctx, memberID)
+	if err != nil {
+		t.Fatalf("GetTeamCurrentMembers failed: %v", err)
+	}
+	if len(members.Items) != 1 || members.Items[0].UserId != memberID || members.Items[0].Role != api.TeamMemberRoleOwner {
+		t.Fatalf("expected the remaining member to own the team, got %+v", members.Items)
+	}
+	rows, err := s.q.ListTaskCompletionDailyByTeamAndDate(ctx, dbsqlc.ListTaskCompletionDailyByTeamAndDateParams{
+		TeamID:     teamID,
+		TargetDate: toPgDate(base),
+	})
+	if err != nil {
+		t.Fatalf("failed to list completions: %v", err)
+	}
+	if len(rows) != 1 || rows[0].CompletedByUserID != ownerID || rows[0].CompletedByEffectiveName != deletedUserDisplayName {
+		t.Fatalf("expected the completion to stay attributed to the anonymised user, got %+v", rows)
+	}
+	if _, err := s.q.GetUserByEmail(ctx, "leaving@example.com"); err == nil {
+		t.Fatalf("expected the email address to be released")
+	}
+
+	if err := s.DeleteMe(ctx, ownerID); err == nil || err.Error() != "user not found" {
+		t.Fatalf("expected a second delete to report user not found, got %v", err)
+	}
+}
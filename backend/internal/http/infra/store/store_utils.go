@@ -2,13 +2,16 @@ package store
 
 import (
 	"crypto/rand"
+	"encoding/base64"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"math"
 	"strconv"
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 	openapi_types "github.com/oapi-codegen/runtime/types"
 )
@@ -21,6 +24,21 @@ func toPgDate(t time.Time) pgtype.Date {
 	return pgtype.Date{Time: t, Valid: true}
 }
 
+func pgDateFromPtr(t *time.Time) pgtype.Date {
+	if t == nil {
+		return pgtype.Date{}
+	}
+	return toPgDate(*t)
+}
+
+func ptrFromPgDate(d pgtype.Date, loc *time.Location) *time.Time {
+	if !d.Valid {
+		return nil
+	}
+	v := dateOnly(d.Time, loc)
+	return &v
+}
+
 func textFromPtr(s *string) pgtype.Text {
 	if s == nil {
 		return pgtype.Text{}
@@ -140,6 +158,14 @@ func toDate(t time.Time) openapi_types.Date {
 	return openapi_types.Date{Time: dateOnly(t, t.Location())}
 }
 
+func toDatePtr(t *time.Time) *openapi_types.Date {
+	if t == nil {
+		return nil
+	}
+	d := toDate(*t)
+	return &d
+}
+
 func monthKeyFromTime(t time.Time, loc *time.Location) string {
 	return t.In(loc).Format("2006-01")
 }
@@ -151,3 +177,29 @@ func monthStartFromKey(month string, loc *time.Location) (time.Time, error) {
 	}
 	return time.Date(parsed.Year(), parsed.Month(), 1, 0, 0, 0, 0, loc), nil
 }
+
+// encodeKeysetCursor keys a page boundary by (created_at, id), the order
+// comments and the activity feed are listed in, so equal timestamps never
+// skip a row.
+func encodeKeysetCursor(createdAt time.Time, id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(createdAt.UTC().Format(time.RFC3339Nano) + "|" + id))
+}
+
+func decodeKeysetCursor(cursor string) (time.Time, string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, "", errors.New("invalid cursor")
+	}
+	ts, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return time.Time{}, "", errors.New("invalid cursor")
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return time.Time{}, "", errors.New("invalid cursor")
+	}
+	createdAt, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return time.Time{}, "", errors.New("invalid cursor")
+	}
+	return createdAt, id, nil
+}
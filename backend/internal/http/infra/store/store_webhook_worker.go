@@ -0,0 +1,241 @@
+package store
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	dbsqlc "github.com/megu/kaji-challenge/backend/internal/db/sqlc"
+)
+
+const (
+	webhookDefaultPollInterval = 5 * time.Second
+	webhookDefaultTimeout      = 10 * time.Second
+	webhookDefaultMaxAttempts  = 8
+	webhookMaxAttemptsLimit    = 20
+	webhookClaimBatchSize      = 20
+	webhookRetryBaseDelay      = 30 * time.Second
+	webhookRetryMaxDelay       = 6 * time.Hour
+	webhookErrorMaxLength      = 500
+)
+
+type webhookWorkerConfig struct {
+	PollInterval time.Duration
+	Timeout      time.Duration
+	MaxAttempts  int32
+	// AllowPrivateNetworks lifts the public-address check, for local
+	// development against receivers on the same machine.
+	AllowPrivateNetworks bool
+}
+
+func loadWebhookWorkerConfig() (webhookWorkerConfig, error) {
+	cfg := webhookWorkerConfig{
+		PollInterval:         webhookDefaultPollInterval,
+		Timeout:              webhookDefaultTimeout,
+		MaxAttempts:          webhookDefaultMaxAttempts,
+		AllowPrivateNetworks: strings.EqualFold(strings.TrimSpace(os.Getenv("WEBHOOK_ALLOW_PRIVATE_NETWORKS")), "true"),
+	}
+	if v := strings.TrimSpace(os.Getenv("WEBHOOK_POLL_INTERVAL")); v != "" {
+		dur, err := time.ParseDuration(v)
+		if err != nil || dur <= 0 {
+			return webhookWorkerConfig{}, fmt.Errorf("WEBHOOK_POLL_INTERVAL must be a positive duration: %q", v)
+		}
+		cfg.PollInterval = dur
+	}
+	if v := strings.TrimSpace(os.Getenv("WEBHOOK_TIMEOUT")); v != "" {
+		dur, err := time.ParseDuration(v)
+		if err != nil || dur <= 0 {
+			return webhookWorkerConfig{}, fmt.Errorf("WEBHOOK_TIMEOUT must be a positive duration: %q", v)
+		}
+		cfg.Timeout = dur
+	}
+	if v := strings.TrimSpace(os.Getenv("WEBHOOK_MAX_ATTEMPTS")); v != "" {
+		maxAttempts, err := parseEnvInt32(v, "WEBHOOK_MAX_ATTEMPTS", false)
+		if err != nil {
+			return webhookWorkerConfig{}, err
+		}
+		if err := ensureInt32UpperLimit(maxAttempts, "WEBHOOK_MAX_ATTEMPTS", webhookMaxAttemptsLimit, v); err != nil {
+			return webhookWorkerConfig{}, err
+		}
+		cfg.MaxAttempts = maxAttempts
+	}
+	return cfg, nil
+}
+
+// newWebhookClient returns the client deliveries are sent with. It checks
+// the address every connection is actually made to, which also covers DNS
+// answers that changed after the hook was saved, and never follows
+// redirects, so the response to a redirect counts as a failed delivery.
+func newWebhookClient(cfg webhookWorkerConfig) *http.Client {
+	dialer := &net.Dialer{Timeout: cfg.Timeout}
+	if !cfg.AllowPrivateNetworks {
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !webhookAddrAllowed(ip) {
+				return fmt.Errorf("webhook target %s is not a public address", host)
+			}
+			return nil
+		}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// A proxy would make the dialer check the proxy instead of the target.
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   cfg.Timeout,
+		Transport: transport,
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// RunWebhookWorker delivers queued webhook events until ctx is cancelled.
+func (s *Store) RunWebhookWorker(ctx context.Context) {
+	ticker := time.NewTicker(s.webhookCfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := s.DeliverDueWebhooks(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("webhook delivery failed: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DeliverDueWebhooks sends every pending delivery whose next attempt is due and
+// returns how many were attempted. Claimed rows are leased past the request
+// timeout so concurrent workers do not send the same delivery twice.
+func (s *Store) DeliverDueWebhooks(ctx context.Context) (int, error) {
+	attempted := 0
+	for {
+		now := s.now()
+		claimed, err := s.q.ClaimDueTeamWebhookDeliveries(ctx, dbsqlc.ClaimDueTeamWebhookDeliveriesParams{
+			LeaseUntil: toPgTimestamptz(now.Add(2 * s.webhookCfg.Timeout)),
+			DueBefore:  toPgTimestamptz(now),
+			BatchSize:  webhookClaimBatchSize,
+		})
+		if err != nil {
+			return attempted, err
+		}
+		for _, d := range claimed {
+			if err := s.deliverWebhook(ctx, d); err != nil {
+				return attempted, err
+			}
+			attempted++
+		}
+		if len(claimed) < webhookClaimBatchSize {
+			return attempted, nil
+		}
+	}
+}
+
+func (s *Store) deliverWebhook(ctx context.Context, d dbsqlc.ClaimDueTeamWebhookDeliveriesRow) error {
+	attemptedAt := s.now()
+	if !d.IsActive {
+		return s.recordWebhookFailure(ctx, d, attemptedAt, pgtype.Int4{}, "webhook is inactive", true)
+	}
+	status, err := s.postWebhook(ctx, d, attemptedAt)
+	if err != nil {
+		return s.recordWebhookFailure(ctx, d, attemptedAt, pgtype.Int4{}, err.Error(), false)
+	}
+	if status < 200 || status >= 300 {
+		return s.recordWebhookFailure(ctx, d, attemptedAt, pgtype.Int4{Int32: int32(status), Valid: true}, fmt.Sprintf("unexpected status %d", status), false)
+	}
+	return s.q.MarkTeamWebhookDeliverySucceeded(ctx, dbsqlc.MarkTeamWebhookDeliverySucceededParams{
+		ID:             d.ID,
+		AttemptedAt:    toPgTimestamptz(attemptedAt),
+		ResponseStatus: pgtype.Int4{Int32: int32(status), Valid: true},
+	})
+}
+
+func (s *Store) postWebhook(ctx context.Context, d dbsqlc.ClaimDueTeamWebhookDeliveriesRow, attemptedAt time.Time) (int, error) {
+	reqCtx, cancel := context.WithTimeout(ctx, s.webhookCfg.Timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodPost, d.Url, bytes.NewReader(d.Payload))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := strconv.FormatInt(attemptedAt.Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "kaji-challenge-webhook/1")
+	req.Header.Set("X-Kaji-Event", d.EventType)
+	req.Header.Set("X-Kaji-Delivery", d.ID)
+	req.Header.Set("X-Kaji-Timestamp", timestamp)
+	req.Header.Set("X-Kaji-Signature", signWebhookPayload(d.Secret, timestamp, d.Payload))
+	res, err := s.webhookClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer res.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(res.Body, 64<<10))
+	return res.StatusCode, nil
+}
+
+func (s *Store) recordWebhookFailure(
+	ctx context.Context,
+	d dbsqlc.ClaimDueTeamWebhookDeliveriesRow,
+	attemptedAt time.Time,
+	responseStatus pgtype.Int4,
+	reason string,
+	permanent bool,
+) error {
+	attempts := d.AttemptCount + 1
+	status := "pending"
+	if permanent || attempts >= s.webhookCfg.MaxAttempts {
+		status = "failed"
+	}
+	if len(reason) > webhookErrorMaxLength {
+		reason = reason[:webhookErrorMaxLength]
+	}
+	return s.q.MarkTeamWebhookDeliveryAttemptFailed(ctx, dbsqlc.MarkTeamWebhookDeliveryAttemptFailedParams{
+		ID:             d.ID,
+		Status:         status,
+		AttemptedAt:    toPgTimestamptz(attemptedAt),
+		NextAttemptAt:  toPgTimestamptz(attemptedAt.Add(webhookRetryDelay(attempts))),
+		ResponseStatus: responseStatus,
+		LastError:      pgtype.Text{String: reason, Valid: true},
+	})
+}
+
+// signWebhookPayload signs "<timestamp>.<body>" so receivers can reject
+// replayed requests by checking the timestamp as well as the body.
+func signWebhookPayload(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookRetryDelay doubles the wait after each failed attempt, capped so a
+// long outage still gets retried a few times a day.
+func webhookRetryDelay(attempts int32) time.Duration {
+	delay := webhookRetryBaseDelay
+	for i := int32(1); i < attempts; i++ {
+		delay *= 2
+		if delay >= webhookRetryMaxDelay {
+			return webhookRetryMaxDelay
+		}
+	}
+	return delay
+}
@@ -0,0 +1,405 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	dbsqlc "github.com/megu/kaji-challenge/backend/internal/db/sqlc"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+const (
+	webhookEventMonthClosed = "month_closed"
+
+	webhookSecretMinLength = 16
+	webhookURLMaxLength    = 2048
+
+	webhookDeliveriesDefaultLimit = 50
+	webhookDeliveriesMaxLimit     = 200
+)
+
+// webhookEventTypes lists the event types a webhook can subscribe to. The
+// revision-bump entities mirror TeamEvent.Entity so the SSE stream and
+// webhooks describe the same changes.
+var webhookEventTypes = map[string]struct{}{
+	"task":                  {},
+	"task_completion":       {},
+	"penalty_rule":          {},
+	"team_member":           {},
+	"invite":                {},
+	"team_state":            {},
+	"close_run":             {},
+	"batch":                 {},
+	"absence":               {},
+	"holiday":               {},
+	"task_category":         {},
+	"task_checklist":        {},
+	"task_comment":          {},
+	"completion_reaction":   {},
+	"task_template":         {},
+	webhookEventMonthClosed: {},
+}
+
+type webhookPayload struct {
+	ID         string    `json:"id"`
+	Type       string    `json:"type"`
+	TeamID     string    `json:"teamId"`
+	OccurredAt time.Time `json:"occurredAt"`
+	Data       any       `json:"data"`
+}
+
+type monthClosedWebhookData struct {
+	Month                   string   `json:"month"`
+	DailyPenaltyTotal       int      `json:"dailyPenaltyTotal"`
+	WeeklyPenaltyTotal      int      `json:"weeklyPenaltyTotal"`
+	TotalPenalty            int      `json:"totalPenalty"`
+	TriggeredPenaltyRuleIDs []string `json:"triggeredPenaltyRuleIds"`
+}
+
+// enqueueWebhooksForTeamEvent is the outbox consumer that turns revision
+// events into webhook deliveries.
+func (s *Store) enqueueWebhooksForTeamEvent(ctx context.Context, q *dbsqlc.Queries, event TeamEvent) error {
+	return s.enqueueWebhookEvent(ctx, q, event.TeamID, event.Entity, event.ChangedAt, event)
+}
+
+// enqueueWebhookEvent stores one pending delivery per active webhook that
+// subscribes to eventType. Passing the transaction queries keeps the delivery
+// atomic with the change it describes.
+func (s *Store) enqueueWebhookEvent(ctx context.Context, q *dbsqlc.Queries, teamID, eventType string, occurredAt time.Time, data any) error {
+	hooks, err := q.ListActiveTeamWebhooksForEvent(ctx, dbsqlc.ListActiveTeamWebhooksForEventParams{
+		TeamID:    teamID,
+		EventType: eventType,
+	})
+	if err != nil {
+		return err
+	}
+	if len(hooks) == 0 {
+		return nil
+	}
+	for _, hook := range hooks {
+		deliveryID := s.nextID("whd")
+		payload, err := json.Marshal(webhookPayload{
+			ID:         deliveryID,
+			Type:       eventType,
+			TeamID:     teamID,
+			OccurredAt: occurredAt,
+			Data:       data,
+		})
+		if err != nil {
+			return err
+		}
+		if err := q.CreateTeamWebhookDelivery(ctx, dbsqlc.CreateTeamWebhookDeliveryParams{
+			ID:            deliveryID,
+			WebhookID:     hook.ID,
+			TeamID:        teamID,
+			EventType:     eventType,
+			Payload:       payload,
+			NextAttemptAt: toPgTimestamptz(occurredAt),
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Store) ListTeamWebhooks(ctx context.Context, userID string) ([]api.TeamWebhook, error) {
+	teamID, err := s.ownerTeamLocked(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := s.q.ListTeamWebhooksByTeamID(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]api.TeamWebhook, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, webhookFromDB(row, s.loc))
+	}
+	return items, nil
+}
+
+func (s *Store) CreateTeamWebhook(ctx context.Context, userID string, req api.CreateTeamWebhookRequest) (api.TeamWebhookCreatedResponse, error) {
+	teamID, err := s.ownerTeamLocked(ctx, userID)
+	if err != nil {
+		return api.TeamWebhookCreatedResponse{}, err
+	}
+	hookURL, err := normalizeWebhookURL(ctx, req.Url, s.webhookCfg.AllowPrivateNetworks)
+	if err != nil {
+		return api.TeamWebhookCreatedResponse{}, err
+	}
+	eventTypes, err := normalizeWebhookEventTypes(req.EventTypes)
+	if err != nil {
+		return api.TeamWebhookCreatedResponse{}, err
+	}
+	secret, err := webhookSecretOrGenerate(req.Secret)
+	if err != nil {
+		return api.TeamWebhookCreatedResponse{}, err
+	}
+	isActive := true
+	if req.IsActive != nil {
+		isActive = *req.IsActive
+	}
+	now := s.now()
+	row := dbsqlc.TeamWebhook{
+		ID:         s.nextID("wh"),
+		TeamID:     teamID,
+		Url:        hookURL,
+		Secret:     secret,
+		EventTypes: eventTypes,
+		IsActive:   isActive,
+		CreatedAt:  toPgTimestamptz(now),
+		UpdatedAt:  toPgTimestamptz(now),
+	}
+	if err := s.q.CreateTeamWebhook(ctx, dbsqlc.CreateTeamWebhookParams{
+		ID:         row.ID,
+		TeamID:     row.TeamID,
+		Url:        row.Url,
+		Secret:     row.Secret,
+		EventTypes: row.EventTypes,
+		IsActive:   row.IsActive,
+		CreatedAt:  row.CreatedAt,
+		UpdatedAt:  row.UpdatedAt,
+	}); err != nil {
+		return api.TeamWebhookCreatedResponse{}, err
+	}
+	return api.TeamWebhookCreatedResponse{
+		Webhook: webhookFromDB(row, s.loc),
+		Secret:  secret,
+	}, nil
+}
+
+func (s *Store) PatchTeamWebhook(ctx context.Context, userID, webhookID string, req api.UpdateTeamWebhookRequest) (api.TeamWebhook, error) {
+	teamID, err := s.ownerTeamLocked(ctx, userID)
+	if err != nil {
+		return api.TeamWebhook{}, err
+	}
+	row, err := s.teamWebhookLocked(ctx, teamID, webhookID)
+	if err != nil {
+		return api.TeamWebhook{}, err
+	}
+	if req.Url != nil {
+		hookURL, err := normalizeWebhookURL(ctx, *req.Url, s.webhookCfg.AllowPrivateNetworks)
+		if err != nil {
+			return api.TeamWebhook{}, err
+		}
+		row.Url = hookURL
+	}
+	if req.EventTypes != nil {
+		eventTypes, err := normalizeWebhookEventTypes(req.EventTypes)
+		if err != nil {
+			return api.TeamWebhook{}, err
+		}
+		row.EventTypes = eventTypes
+	}
+	if req.Secret != nil {
+		secret, err := webhookSecretOrGenerate(req.Secret)
+		if err != nil {
+			return api.TeamWebhook{}, err
+		}
+		row.Secret = secret
+	}
+	if req.IsActive != nil {
+		row.IsActive = *req.IsActive
+	}
+	row.UpdatedAt = toPgTimestamptz(s.now())
+	if err := s.q.UpdateTeamWebhook(ctx, dbsqlc.UpdateTeamWebhookParams{
+		ID:         row.ID,
+		Url:        row.Url,
+		Secret:     row.Secret,
+		EventTypes: row.EventTypes,
+		IsActive:   row.IsActive,
+		UpdatedAt:  row.UpdatedAt,
+	}); err != nil {
+		return api.TeamWebhook{}, err
+	}
+	return webhookFromDB(row, s.loc), nil
+}
+
+func (s *Store) DeleteTeamWebhook(ctx context.Context, userID, webhookID string) error {
+	teamID, err := s.ownerTeamLocked(ctx, userID)
+	if err != nil {
+		return err
+	}
+	rows, err := s.q.DeleteTeamWebhook(ctx, dbsqlc.DeleteTeamWebhookParams{ID: webhookID, TeamID: teamID})
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return errors.New("webhook not found")
+	}
+	return nil
+}
+
+func (s *Store) ListTeamWebhookDeliveries(ctx context.Context, userID, webhookID string, limit *int) ([]api.TeamWebhookDelivery, error) {
+	teamID, err := s.ownerTeamLocked(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.teamWebhookLocked(ctx, teamID, webhookID); err != nil {
+		return nil, err
+	}
+	n := webhookDeliveriesDefaultLimit
+	if limit != nil {
+		n = *limit
+	}
+	if n < 1 || n > webhookDeliveriesMaxLimit {
+		return nil, fmt.Errorf("invalid limit: must be between 1 and %d", webhookDeliveriesMaxLimit)
+	}
+	rows, err := s.q.ListTeamWebhookDeliveriesByWebhookID(ctx, dbsqlc.ListTeamWebhookDeliveriesByWebhookIDParams{
+		WebhookID: webhookID,
+		Limit:     int32(n),
+	})
+	if err != nil {
+		return nil, err
+	}
+	items := make([]api.TeamWebhookDelivery, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, webhookDeliveryFromDB(row, s.loc))
+	}
+	return items, nil
+}
+
+func (s *Store) ownerTeamLocked(ctx context.Context, userID string) (string, error) {
+	m, err := s.primaryMembershipLocked(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if m.Role != string(api.TeamMembershipRoleOwner) {
+		return "", errors.New("forbidden: owner role required")
+	}
+	return m.TeamID, nil
+}
+
+func (s *Store) teamWebhookLocked(ctx context.Context, teamID, webhookID string) (dbsqlc.TeamWebhook, error) {
+	row, err := s.q.GetTeamWebhookByID(ctx, webhookID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return dbsqlc.TeamWebhook{}, errors.New("webhook not found")
+		}
+		return dbsqlc.TeamWebhook{}, err
+	}
+	if row.TeamID != teamID {
+		return dbsqlc.TeamWebhook{}, errors.New("webhook not found")
+	}
+	return row, nil
+}
+
+// normalizeWebhookURL validates a webhook target. Unless allowPrivate is set,
+// every address the host resolves to must be public; the worker's dialer
+// checks again at send time, since DNS may change after the hook is saved.
+func normalizeWebhookURL(ctx context.Context, raw string, allowPrivate bool) (string, error) {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" {
+		return "", errors.New("webhook url is required")
+	}
+	if len(trimmed) > webhookURLMaxLength {
+		return "", fmt.Errorf("invalid webhook url: must be at most %d characters", webhookURLMaxLength)
+	}
+	u, err := url.Parse(trimmed)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return "", errors.New("invalid webhook url: must be an absolute http(s) url")
+	}
+	if !allowPrivate {
+		addrs, err := net.DefaultResolver.LookupIPAddr(ctx, u.Hostname())
+		if err != nil || len(addrs) == 0 {
+			return "", errors.New("invalid webhook url: host could not be resolved")
+		}
+		for _, addr := range addrs {
+			if !webhookAddrAllowed(addr.IP) {
+				return "", errors.New("invalid webhook url: host must not resolve to a private, loopback or link-local address")
+			}
+		}
+	}
+	return u.String(), nil
+}
+
+// webhookAddrAllowed reports whether webhooks may be sent to ip, so a team
+// cannot point the worker at the backend's own network.
+func webhookAddrAllowed(ip net.IP) bool {
+	return !ip.IsPrivate() && !ip.IsLoopback() && !ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() && !ip.IsInterfaceLocalMulticast() &&
+		!ip.IsMulticast() && !ip.IsUnspecified()
+}
+
+func normalizeWebhookEventTypes(raw *[]string) ([]string, error) {
+	if raw == nil {
+		return []string{}, nil
+	}
+	seen := map[string]struct{}{}
+	out := make([]string, 0, len(*raw))
+	for _, v := range *raw {
+		eventType := strings.TrimSpace(v)
+		if _, ok := webhookEventTypes[eventType]; !ok {
+			return nil, fmt.Errorf("invalid webhook event type %q", v)
+		}
+		if _, ok := seen[eventType]; ok {
+			continue
+		}
+		seen[eventType] = struct{}{}
+		out = append(out, eventType)
+	}
+	return out, nil
+}
+
+func webhookSecretOrGenerate(raw *string) (string, error) {
+	if raw == nil {
+		a, err := randomToken()
+		if err != nil {
+			return "", err
+		}
+		b, err := randomToken()
+		if err != nil {
+			return "", err
+		}
+		return a + b, nil
+	}
+	secret := strings.TrimSpace(*raw)
+	if len(secret) < webhookSecretMinLength {
+		return "", fmt.Errorf("invalid webhook secret: must be at least %d characters", webhookSecretMinLength)
+	}
+	return secret, nil
+}
+
+func webhookFromDB(row dbsqlc.TeamWebhook, loc *time.Location) api.TeamWebhook {
+	eventTypes := row.EventTypes
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+	return api.TeamWebhook{
+		Id:         row.ID,
+		TeamId:     row.TeamID,
+		Url:        row.Url,
+		EventTypes: eventTypes,
+		IsActive:   row.IsActive,
+		CreatedAt:  row.CreatedAt.Time.In(loc),
+		UpdatedAt:  row.UpdatedAt.Time.In(loc),
+	}
+}
+
+func webhookDeliveryFromDB(row dbsqlc.TeamWebhookDelivery, loc *time.Location) api.TeamWebhookDelivery {
+	var lastResponseStatus *int
+	if row.LastResponseStatus.Valid {
+		v := int(row.LastResponseStatus.Int32)
+		lastResponseStatus = &v
+	}
+	return api.TeamWebhookDelivery{
+		Id:                 row.ID,
+		WebhookId:          row.WebhookID,
+		EventType:          row.EventType,
+		Status:             row.Status,
+		AttemptCount:       int(row.AttemptCount),
+		NextAttemptAt:      row.NextAttemptAt.Time.In(loc),
+		LastAttemptAt:      ptrFromTimestamptz(row.LastAttemptAt, loc),
+		LastResponseStatus: lastResponseStatus,
+		LastError:          ptrFromText(row.LastError),
+		DeliveredAt:        ptrFromTimestamptz(row.DeliveredAt, loc),
+		CreatedAt:          row.CreatedAt.Time.In(loc),
+	}
+}
@@ -0,0 +1,284 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	dbsqlc "github.com/megu/kaji-challenge/backend/internal/db/sqlc"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func TestSignWebhookPayload(t *testing.T) {
+	t.Parallel()
+
+	got := signWebhookPayload("0123456789abcdef", "1700000000", []byte(`{"type":"task"}`))
+	if got != signWebhookPayload("0123456789abcdef", "1700000000", []byte(`{"type":"task"}`)) {
+		t.Fatalf("signature must be deterministic")
+	}
+	if got == signWebhookPayload("0123456789abcdef", "1700000001", []byte(`{"type":"task"}`)) {
+		t.Fatalf("signature must cover the timestamp")
+	}
+	if got == signWebhookPayload("fedcba9876543210", "1700000000", []byte(`{"type":"task"}`)) {
+		t.Fatalf("signature must depend on the secret")
+	}
+	if len(got) != len("sha256=")+64 || got[:7] != "sha256=" {
+		t.Fatalf("unexpected signature format: %q", got)
+	}
+}
+
+func TestWebhookRetryDelay(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		attempts int32
+		want     time.Duration
+	}{
+		{attempts: 1, want: 30 * time.Second},
+		{attempts: 2, want: time.Minute},
+		{attempts: 4, want: 4 * time.Minute},
+		{attempts: 20, want: webhookRetryMaxDelay},
+	}
+	for _, tt := range tests {
+		if got := webhookRetryDelay(tt.attempts); got != tt.want {
+			t.Fatalf("webhookRetryDelay(%d)=%s, want %s", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeWebhookEventTypes(t *testing.T) {
+	t.Parallel()
+
+	got, err := normalizeWebhookEventTypes(&[]string{" task_completion ", "month_closed", "task_completion"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0] != "task_completion" || got[1] != "month_closed" {
+		t.Fatalf("unexpected event types: %v", got)
+	}
+	if _, err := normalizeWebhookEventTypes(&[]string{"unknown"}); err == nil {
+		t.Fatalf("expected unknown event type to be rejected")
+	}
+	empty, err := normalizeWebhookEventTypes(nil)
+	if err != nil || len(empty) != 0 {
+		t.Fatalf("expected nil filter to subscribe to every event, got %v err=%v", empty, err)
+	}
+}
+
+func TestNormalizeWebhookURL(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	if _, err := normalizeWebhookURL(ctx, "https://93.184.216.34/hook", false); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, raw := range []string{"", "example.com/hook", "ftp://example.com/hook", "/relative"} {
+		if _, err := normalizeWebhookURL(ctx, raw, true); err == nil {
+			t.Fatalf("expected %q to be rejected", raw)
+		}
+	}
+	for _, raw := range []string{"http://127.0.0.1:8080/hook", "http://10.0.0.5/hook", "http://169.254.169.254/latest", "http://[::1]/hook", "http://0.0.0.0/hook"} {
+		if _, err := normalizeWebhookURL(ctx, raw, false); err == nil ||
+			err.Error() != "invalid webhook url: host must not resolve to a private, loopback or link-local address" {
+			t.Fatalf("expected %q to be rejected as private, got %v", raw, err)
+		}
+	}
+	if _, err := normalizeWebhookURL(ctx, "http://127.0.0.1:8080/hook", true); err != nil {
+		t.Fatalf("expected private targets when allowed, got %v", err)
+	}
+}
+
+func TestWebhookClientRefusesPrivateTargetsAndRedirects(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/redirect" {
+			http.Redirect(w, req, "/final", http.StatusFound)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	client := newWebhookClient(webhookWorkerConfig{Timeout: 5 * time.Second})
+	if _, err := client.Get(srv.URL); err == nil || !strings.Contains(err.Error(), "is not a public address") {
+		t.Fatalf("expected the dialer to refuse a loopback target, got %v", err)
+	}
+
+	client = newWebhookClient(webhookWorkerConfig{Timeout: 5 * time.Second, AllowPrivateNetworks: true})
+	res, err := client.Get(srv.URL + "/redirect")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	res.Body.Close()
+	if res.StatusCode != http.StatusFound {
+		t.Fatalf("expected the redirect not to be followed, got status %d", res.StatusCode)
+	}
+}
+
+type webhookReceiver struct {
+	mu       sync.Mutex
+	status   int
+	requests []receivedWebhook
+}
+
+type receivedWebhook struct {
+	header http.Header
+	body   []byte
+}
+
+func (r *webhookReceiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = append(r.requests, receivedWebhook{header: req.Header.Clone(), body: body})
+	w.WriteHeader(r.status)
+}
+
+func TestDeliverDueWebhooksSignsPayloadAndRecordsSuccess(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	receiver := &webhookReceiver{status: http.StatusNoContent}
+	srv := httptest.NewServer(receiver)
+	defer srv.Close()
+
+	teamID, userID := createTeamWithMember(t, s, "webhook-ok@example.com", time.Now().In(s.loc).Add(-time.Hour))
+	secret := "0123456789abcdef0123"
+	created, err := s.CreateTeamWebhook(ctx, userID, api.CreateTeamWebhookRequest{
+		Url:        srv.URL,
+		Secret:     &secret,
+		EventTypes: &[]string{"task"},
+	})
+	if err != nil {
+		t.Fatalf("CreateTeamWebhook failed: %v", err)
+	}
+	if _, err := s.CreateTask(withLatestIfMatchForUser(t, s, ctx, userID), userID, api.CreateTaskRequest{
+		Title:         "dishes",
+		Type:          api.Daily,
+		PenaltyPoints: 1,
+	}); err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+
+	if _, err := s.DispatchTeamEventOutbox(ctx); err != nil {
+		t.Fatalf("DispatchTeamEventOutbox failed: %v", err)
+	}
+	attempted, err := s.DeliverDueWebhooks(ctx)
+	if err != nil {
+		t.Fatalf("DeliverDueWebhooks failed: %v", err)
+	}
+	if attempted != 1 || len(receiver.requests) != 1 {
+		t.Fatalf("expected one delivery, attempted=%d received=%d", attempted, len(receiver.requests))
+	}
+	got := receiver.requests[0]
+	if got.header.Get("X-Kaji-Event") != "task" {
+		t.Fatalf("unexpected event header: %q", got.header.Get("X-Kaji-Event"))
+	}
+	want := signWebhookPayload(secret, got.header.Get("X-Kaji-Timestamp"), got.body)
+	if got.header.Get("X-Kaji-Signature") != want {
+		t.Fatalf("signature mismatch: got=%q want=%q", got.header.Get("X-Kaji-Signature"), want)
+	}
+	var payload webhookPayload
+	if err := json.Unmarshal(got.body, &payload); err != nil {
+		t.Fatalf("invalid payload: %v", err)
+	}
+	if payload.TeamID != teamID || payload.Type != "task" || payload.ID != got.header.Get("X-Kaji-Delivery") {
+		t.Fatalf("unexpected payload: %+v", payload)
+	}
+
+	deliveries, err := s.ListTeamWebhookDeliveries(ctx, userID, created.Webhook.Id, nil)
+	if err != nil {
+		t.Fatalf("ListTeamWebhookDeliveries failed: %v", err)
+	}
+	if len(deliveries) != 1 || deliveries[0].Status != "succeeded" || deliveries[0].DeliveredAt == nil {
+		t.Fatalf("expected succeeded delivery, got %+v", deliveries)
+	}
+}
+
+func TestDeliverDueWebhooksSchedulesRetryOnFailure(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+	receiver := &webhookReceiver{status: http.StatusServiceUnavailable}
+	srv := httptest.NewServer(receiver)
+	defer srv.Close()
+
+	_, userID := createTeamWithMember(t, s, "webhook-retry@example.com", time.Now().In(s.loc).Add(-time.Hour))
+	created, err := s.CreateTeamWebhook(ctx, userID, api.CreateTeamWebhookRequest{Url: srv.URL})
+	if err != nil {
+		t.Fatalf("CreateTeamWebhook failed: %v", err)
+	}
+	if _, err := s.CreateTask(withLatestIfMatchForUser(t, s, ctx, userID), userID, api.CreateTaskRequest{
+		Title:         "laundry",
+		Type:          api.Daily,
+		PenaltyPoints: 1,
+	}); err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+
+	if _, err := s.DispatchTeamEventOutbox(ctx); err != nil {
+		t.Fatalf("DispatchTeamEventOutbox failed: %v", err)
+	}
+	if _, err := s.DeliverDueWebhooks(ctx); err != nil {
+		t.Fatalf("DeliverDueWebhooks failed: %v", err)
+	}
+	// The retry is scheduled in the future, so a second pass sends nothing.
+	attempted, err := s.DeliverDueWebhooks(ctx)
+	if err != nil {
+		t.Fatalf("second DeliverDueWebhooks failed: %v", err)
+	}
+	if attempted != 0 || len(receiver.requests) != 1 {
+		t.Fatalf("expected no immediate retry, attempted=%d received=%d", attempted, len(receiver.requests))
+	}
+
+	deliveries, err := s.ListTeamWebhookDeliveries(ctx, userID, created.Webhook.Id, nil)
+	if err != nil {
+		t.Fatalf("ListTeamWebhookDeliveries failed: %v", err)
+	}
+	if len(deliveries) != 1 {
+		t.Fatalf("expected one delivery, got %d", len(deliveries))
+	}
+	d := deliveries[0]
+	if d.Status != "pending" || d.AttemptCount != 1 {
+		t.Fatalf("expected pending retry after first failure, got status=%s attempts=%d", d.Status, d.AttemptCount)
+	}
+	if d.LastResponseStatus == nil || *d.LastResponseStatus != http.StatusServiceUnavailable {
+		t.Fatalf("expected last response status 503, got %v", d.LastResponseStatus)
+	}
+	if !d.NextAttemptAt.After(time.Now()) {
+		t.Fatalf("expected next attempt in the future, got %s", d.NextAttemptAt)
+	}
+}
+
+func TestTeamWebhooksRequireOwner(t *testing.T) {
+	s := newTestStore(t)
+	ctx := context.Background()
+
+	teamID, _ := createTeamWithMember(t, s, "webhook-owner@example.com", time.Now().In(s.loc).Add(-time.Hour))
+	memberID := s.nextID("user")
+	if err := s.q.CreateUser(ctx, dbsqlc.CreateUserParams{
+		ID:          memberID,
+		Email:       "webhook-member@example.com",
+		DisplayName: "Member",
+		CreatedAt:   toPgTimestamptz(time.Now().In(s.loc)),
+	}); err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	if err := s.q.AddTeamMember(ctx, dbsqlc.AddTeamMemberParams{
+		TeamID:    teamID,
+		UserID:    memberID,
+		Role:      string(api.TeamMembershipRoleMember),
+		CreatedAt: toPgTimestamptz(time.Now().In(s.loc)),
+	}); err != nil {
+		t.Fatalf("failed to add team member: %v", err)
+	}
+
+	if _, err := s.ListTeamWebhooks(ctx, memberID); err == nil || err.Error() != "forbidden: owner role required" {
+		t.Fatalf("expected owner check, got %v", err)
+	}
+}
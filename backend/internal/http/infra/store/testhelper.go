@@ -12,12 +12,13 @@ func RejectMockParamsInStrictModeForTest(ctx context.Context, loc *time.Location
 	}
 	s := &Store{
 		loc:          loc,
+		clock:        systemClock{},
 		authRequests: map[string]authRequest{},
 	}
 	s.authRequests["state-1"] = authRequest{
 		Nonce:        "nonce-1",
 		CodeVerifier: "verifier-1",
-		ExpiresAt:    time.Now().In(loc).Add(10 * time.Minute),
+		ExpiresAt:    s.now().Add(10 * time.Minute),
 	}
 	_, _, err := s.CompleteGoogleAuth(ctx, "mock-code", "state-1", "owner@example.com", "Owner", "", "")
 	return err
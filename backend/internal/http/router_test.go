@@ -11,6 +11,7 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"strconv"
 	"strings"
 	"sync"
 	"testing"
@@ -1039,6 +1040,106 @@ func TestWeeklyTaskIncrementIsAtomicUnderConcurrency(t *testing.T) {
 	t.Fatalf("weekly task not found in overview")
 }
 
+func TestBatchRebasesWeeklyIncrementsOnStaleETag(t *testing.T) {
+	r := newTestRouter(t)
+	token := login(t, r)
+
+	taskRes := doRequest(t, r, http.MethodPost, "/v1/tasks", `{"title":"洗濯","type":"weekly","penaltyPoints":2,"requiredCompletionsPerWeek":3}`, token)
+	if taskRes.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", taskRes.Code, taskRes.Body.String())
+	}
+	var task api.Task
+	if err := json.Unmarshal(taskRes.Body.Bytes(), &task); err != nil {
+		t.Fatalf("failed to parse task: %v", err)
+	}
+
+	loc, _ := time.LoadLocation("Asia/Tokyo")
+	if loc == nil {
+		loc = time.FixedZone("JST", 9*60*60)
+	}
+	today := time.Now().In(loc).Format("2006-01-02")
+	staleETag := fetchLatestETag(t, r, token)
+
+	// Another device moves the team revision forward.
+	toggleRes := doRequest(t, r, http.MethodPost, "/v1/tasks/"+task.Id+"/completions/toggle", `{"targetDate":"`+today+`","action":"increment"}`, token)
+	if toggleRes.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", toggleRes.Code, toggleRes.Body.String())
+	}
+
+	body := `{"mode":"independent","operations":[` +
+		`{"id":"op-1","type":"toggleTaskCompletion","ifMatch":` + strconv.Quote(staleETag) + `,"taskId":"` + task.Id + `","toggleTaskCompletion":{"targetDate":"` + today + `","action":"increment"}},` +
+		`{"id":"op-2","type":"updateTask","ifMatch":` + strconv.Quote(staleETag) + `,"taskId":"` + task.Id + `","updateTask":{"title":"洗濯物たたみ"}},` +
+		`{"id":"op-3","type":"toggleTaskCompletion","ifMatch":` + strconv.Quote(staleETag) + `,"taskId":"` + task.Id + `","toggleTaskCompletion":{"targetDate":"` + today + `","action":"increment"}}` +
+		`]}`
+	res := doRequest(t, r, http.MethodPost, "/v1/batch", body, token)
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", res.Code, res.Body.String())
+	}
+	var batch api.BatchMutationResponse
+	if err := json.Unmarshal(res.Body.Bytes(), &batch); err != nil {
+		t.Fatalf("failed to parse batch response: %v", err)
+	}
+	if !batch.Committed {
+		t.Fatalf("expected batch to be committed")
+	}
+	if len(batch.Results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(batch.Results))
+	}
+	if batch.Results[0].Status != api.Rebased || batch.Results[2].Status != api.Rebased {
+		t.Fatalf("expected increments to be rebased, got %s and %s", batch.Results[0].Status, batch.Results[2].Status)
+	}
+	if batch.Results[2].Completion == nil || batch.Results[2].Completion.WeeklyCompletedCount != 3 {
+		t.Fatalf("expected weekly count 3 after rebased increments, got %+v", batch.Results[2].Completion)
+	}
+	if batch.Results[1].Status != api.Failed || batch.Results[1].HttpStatus == nil || *batch.Results[1].HttpStatus != http.StatusPreconditionFailed {
+		t.Fatalf("expected stale update to fail with 412, got %+v", batch.Results[1])
+	}
+	if batch.Results[1].CurrentEtag == nil || strings.TrimSpace(*batch.Results[1].CurrentEtag) == "" {
+		t.Fatalf("expected currentEtag for conflicting operation")
+	}
+	if got := res.Header().Get("ETag"); got != batch.Etag || got == staleETag {
+		t.Fatalf("expected new ETag header %q, got %q", batch.Etag, got)
+	}
+}
+
+func TestAtomicBatchRollsBackOnFailure(t *testing.T) {
+	r := newTestRouter(t)
+	token := login(t, r)
+
+	body := `{"operations":[` +
+		`{"id":"op-1","type":"createTask","createTask":{"title":"ゴミ出し","type":"daily","penaltyPoints":1}},` +
+		`{"id":"op-2","type":"deleteTask","taskId":"00000000-0000-0000-0000-000000000000"}` +
+		`]}`
+	res := doRequest(t, r, http.MethodPost, "/v1/batch", body, token)
+	if res.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d: %s", res.Code, res.Body.String())
+	}
+	var batch api.BatchMutationResponse
+	if err := json.Unmarshal(res.Body.Bytes(), &batch); err != nil {
+		t.Fatalf("failed to parse batch response: %v", err)
+	}
+	if batch.Committed {
+		t.Fatalf("expected atomic batch not to be committed")
+	}
+	if batch.Results[0].Status != api.Aborted || batch.Results[0].Task != nil {
+		t.Fatalf("expected first operation to be aborted, got %+v", batch.Results[0])
+	}
+	if batch.Results[1].Status != api.Failed || batch.Results[1].HttpStatus == nil || *batch.Results[1].HttpStatus != http.StatusNotFound {
+		t.Fatalf("expected second operation to fail with 404, got %+v", batch.Results[1])
+	}
+
+	listRes := doRequest(t, r, http.MethodGet, "/v1/tasks", "", token)
+	var list struct {
+		Items []api.Task `json:"items"`
+	}
+	if err := json.Unmarshal(listRes.Body.Bytes(), &list); err != nil {
+		t.Fatalf("failed to parse task list: %v", err)
+	}
+	if len(list.Items) != 0 {
+		t.Fatalf("expected rolled back batch to leave no tasks, got %d", len(list.Items))
+	}
+}
+
 func TestProtectedWriteRejectsInvalidOrigin(t *testing.T) {
 	r := newTestRouter(t)
 	token := login(t, r)
@@ -1068,6 +1169,58 @@ func TestProtectedGetReturnsETag(t *testing.T) {
 	}
 }
 
+func TestConditionalGetReturns304UntilTeamStateChanges(t *testing.T) {
+	r := newTestRouter(t)
+	token := login(t, r)
+
+	conditionalGet := func(path, ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.AddCookie(&http.Cookie{Name: "kaji_session", Value: token})
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		res := httptest.NewRecorder()
+		r.ServeHTTP(res, req)
+		return res
+	}
+
+	paths := []string{
+		"/v1/tasks",
+		"/v1/tasks/overview",
+		"/v1/penalty-rules",
+		"/v1/penalty-summaries/monthly",
+		"/v1/teams/current/members",
+	}
+	etags := map[string]string{}
+	for _, path := range paths {
+		res := conditionalGet(path, "")
+		if res.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d: %s", path, res.Code, res.Body.String())
+		}
+		etag := strings.TrimSpace(res.Header().Get("ETag"))
+		if etag == "" {
+			t.Fatalf("%s: expected ETag header", path)
+		}
+		etags[path] = etag
+
+		notModified := conditionalGet(path, etag)
+		if notModified.Code != http.StatusNotModified {
+			t.Fatalf("%s: expected 304, got %d", path, notModified.Code)
+		}
+	}
+
+	createRes := doRequest(t, r, http.MethodPost, "/v1/tasks", `{"title":"掃除","type":"daily","penaltyPoints":2}`, token)
+	if createRes.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", createRes.Code, createRes.Body.String())
+	}
+	for _, path := range paths {
+		res := conditionalGet(path, etags[path])
+		if res.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200 after write, got %d", path, res.Code)
+		}
+	}
+}
+
 func TestWriteRejectsIfMatchMismatch(t *testing.T) {
 	r := newTestRouter(t)
 	token := login(t, r)
@@ -1094,6 +1247,72 @@ func TestWriteRejectsIfMatchMismatch(t *testing.T) {
 	}
 }
 
+func TestUnrelatedEditsWithSameTeamETagDoNotConflict(t *testing.T) {
+	r := newTestRouter(t)
+	token := login(t, r)
+
+	createTask := func(title string) api.Task {
+		res := doRequest(t, r, http.MethodPost, "/v1/tasks", `{"title":"`+title+`","type":"daily","penaltyPoints":1}`, token)
+		if res.Code != http.StatusCreated {
+			t.Fatalf("expected 201, got %d: %s", res.Code, res.Body.String())
+		}
+		var task api.Task
+		if err := json.Unmarshal(res.Body.Bytes(), &task); err != nil {
+			t.Fatalf("failed to parse task: %v", err)
+		}
+		if task.Etag == nil || !strings.HasPrefix(*task.Etag, `W/"task:`+task.Id+`:rev:`) {
+			t.Fatalf("expected task etag, got %v", task.Etag)
+		}
+		return task
+	}
+	first := createTask("皿洗い")
+	second := createTask("掃除機")
+	staleETag := fetchLatestETag(t, r, token)
+
+	res := doRequestWithIfMatch(t, r, http.MethodPatch, "/v1/tasks/"+first.Id, `{"title":"皿洗い(夜)"}`, token, staleETag)
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected first edit 200, got %d: %s", res.Code, res.Body.String())
+	}
+	var updated api.Task
+	if err := json.Unmarshal(res.Body.Bytes(), &updated); err != nil {
+		t.Fatalf("failed to parse task: %v", err)
+	}
+	if updated.Etag == nil || *updated.Etag == *first.Etag {
+		t.Fatalf("expected task etag to change after edit")
+	}
+
+	res = doRequestWithIfMatch(t, r, http.MethodPatch, "/v1/tasks/"+second.Id, `{"title":"掃除機(2階)"}`, token, staleETag)
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected unrelated edit with stale team etag 200, got %d: %s", res.Code, res.Body.String())
+	}
+
+	res = doRequestWithIfMatch(t, r, http.MethodPatch, "/v1/tasks/"+first.Id, `{"title":"皿洗い(朝)"}`, token, staleETag)
+	if res.Code != http.StatusPreconditionFailed {
+		t.Fatalf("expected stale edit of changed task 412, got %d: %s", res.Code, res.Body.String())
+	}
+
+	res = doRequestWithIfMatch(t, r, http.MethodPatch, "/v1/tasks/"+first.Id, `{"title":"皿洗い(朝)"}`, token, *first.Etag)
+	if res.Code != http.StatusPreconditionFailed {
+		t.Fatalf("expected stale task etag 412, got %d: %s", res.Code, res.Body.String())
+	}
+	var body map[string]string
+	if err := json.Unmarshal(res.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	if body["currentEtag"] != *updated.Etag {
+		t.Fatalf("expected currentEtag %q, got %q", *updated.Etag, body["currentEtag"])
+	}
+
+	res = doRequestWithIfMatch(t, r, http.MethodPatch, "/v1/tasks/"+first.Id, `{"title":"皿洗い(朝)"}`, token, *updated.Etag)
+	if res.Code != http.StatusOK {
+		t.Fatalf("expected edit with current task etag 200, got %d: %s", res.Code, res.Body.String())
+	}
+	res = doRequestWithIfMatch(t, r, http.MethodDelete, "/v1/tasks/"+second.Id, "", token, *first.Etag)
+	if res.Code != http.StatusPreconditionFailed {
+		t.Fatalf("expected another task's etag to be rejected, got %d: %s", res.Code, res.Body.String())
+	}
+}
+
 func TestWriteRejectsMissingIfMatch(t *testing.T) {
 	r := newTestRouter(t)
 	token := login(t, r)
@@ -1318,6 +1537,20 @@ func doRequest(t *testing.T, r http.Handler, method, path, body, sessionCookie s
 	return res
 }
 
+func doRequestWithIfMatch(t *testing.T, r http.Handler, method, path, body, sessionCookie, ifMatch string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Origin", "http://localhost:5173")
+	req.Header.Set("If-Match", ifMatch)
+	req.AddCookie(&http.Cookie{Name: "kaji_session", Value: sessionCookie})
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+	return res
+}
+
 func fetchLatestETag(t *testing.T, r http.Handler, sessionCookie string) string {
 	t.Helper()
 	req := httptest.NewRequest(http.MethodGet, "/v1/me", nil)
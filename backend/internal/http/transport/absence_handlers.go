@@ -0,0 +1,53 @@
+package transport
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func (h *Handler) ListTeamAbsences(c *gin.Context, params api.ListTeamAbsencesParams) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+	includePast := params.IncludePast != nil && *params.IncludePast
+	items, err := h.services.Absence.ListTeamAbsences(c.Request.Context(), userID, includePast)
+	if err != nil {
+		writeAppError(c, err, http.StatusBadRequest)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"items": items})
+}
+
+func (h *Handler) PostTeamAbsence(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+	injectIfMatchContext(c)
+	req, ok := bindJSON[api.CreateTeamAbsenceRequest](c)
+	if !ok {
+		return
+	}
+	res, err := h.services.Absence.CreateTeamAbsence(c.Request.Context(), userID, req)
+	if err != nil {
+		writeAppError(c, err, http.StatusBadRequest)
+		return
+	}
+	c.JSON(http.StatusCreated, res)
+}
+
+func (h *Handler) DeleteTeamAbsence(c *gin.Context, absenceID string) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+	injectIfMatchContext(c)
+	if err := h.services.Absence.DeleteTeamAbsence(c.Request.Context(), userID, absenceID); err != nil {
+		writeAppError(c, err, http.StatusBadRequest)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,21 @@
+package transport
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func (h *Handler) ListTeamActivity(c *gin.Context, params api.ListTeamActivityParams) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+	res, err := h.services.Team.ListTeamActivity(c.Request.Context(), userID, params.Limit, params.Cursor)
+	if err != nil {
+		writeAppError(c, err, http.StatusBadRequest)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
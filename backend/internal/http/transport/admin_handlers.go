@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
 )
 
 func (h *Handler) PostAdminCloseDay(c *gin.Context) {
@@ -47,3 +48,16 @@ func (h *Handler) PostAdminCloseMonth(c *gin.Context) {
 	}
 	c.JSON(http.StatusOK, res)
 }
+
+func (h *Handler) ListAdminCloseRuns(c *gin.Context, params api.ListAdminCloseRunsParams) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+	res, err := h.services.Admin.ListCloseRuns(c.Request.Context(), userID, params.Limit)
+	if err != nil {
+		writeAppError(c, err, http.StatusBadRequest)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,83 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/megu/kaji-challenge/backend/internal/http/application"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func (h *Handler) PostBatch(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+	injectIfMatchContext(c)
+	req, ok := bindJSON[api.BatchMutationRequest](c)
+	if !ok {
+		return
+	}
+	res, err := h.services.Batch.ApplyBatch(c.Request.Context(), userID, req)
+	if err != nil {
+		writeAppError(c, err, http.StatusBadRequest)
+		return
+	}
+
+	body := api.BatchMutationResponse{
+		Committed: res.Committed,
+		Etag:      res.ETag,
+		Results:   make([]api.BatchOperationResult, 0, len(res.Operations)),
+	}
+	for _, outcome := range res.Operations {
+		result := outcome.Result
+		if outcome.Err != nil {
+			fillBatchOperationError(&result, outcome.Err)
+		}
+		body.Results = append(body.Results, result)
+	}
+
+	status := http.StatusOK
+	atomic := req.Mode == nil || *req.Mode == api.Atomic
+	if atomic && !res.Committed {
+		status = http.StatusConflict
+	}
+	if res.ETag != "" {
+		c.Header("ETag", res.ETag)
+	}
+	c.JSON(status, body)
+}
+
+// fillBatchOperationError reports a per-operation failure with the same status
+// and code the equivalent single request would have returned.
+func fillBatchOperationError(result *api.BatchOperationResult, err error) {
+	status := mapErrorStatus(err, http.StatusBadRequest)
+	message := err.Error()
+	var code string
+	var preconditionRequiredErr *application.PreconditionRequiredError
+	var preconditionErr *application.PreconditionError
+	var appErr *AppError
+	switch {
+	case errors.As(err, &preconditionRequiredErr):
+		status = http.StatusPreconditionRequired
+		code = "precondition_required"
+		message = preconditionRequiredErr.Error()
+	case errors.As(err, &preconditionErr):
+		status = http.StatusPreconditionFailed
+		code = "precondition_failed"
+		message = preconditionErr.Error()
+		if preconditionErr.CurrentETag != "" {
+			result.CurrentEtag = &preconditionErr.CurrentETag
+		}
+	case errors.As(err, &appErr):
+		status = appErr.Status
+		code = appErr.Code
+		message = appErr.Message
+	}
+	result.HttpStatus = &status
+	result.Message = &message
+	if code != "" {
+		result.Code = &code
+	}
+}
@@ -0,0 +1,70 @@
+package transport
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func (h *Handler) ListTaskCategories(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+	items, err := h.services.Category.ListTaskCategories(c.Request.Context(), userID)
+	if err != nil {
+		writeAppError(c, err, http.StatusBadRequest)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"items": items})
+}
+
+func (h *Handler) PostTaskCategory(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+	injectIfMatchContext(c)
+	req, ok := bindJSON[api.CreateTaskCategoryRequest](c)
+	if !ok {
+		return
+	}
+	res, err := h.services.Category.CreateTaskCategory(c.Request.Context(), userID, req)
+	if err != nil {
+		writeAppError(c, err, http.StatusBadRequest)
+		return
+	}
+	c.JSON(http.StatusCreated, res)
+}
+
+func (h *Handler) PatchTaskCategory(c *gin.Context, categoryID string) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+	injectIfMatchContext(c)
+	req, ok := bindJSON[api.UpdateTaskCategoryRequest](c)
+	if !ok {
+		return
+	}
+	res, err := h.services.Category.PatchTaskCategory(c.Request.Context(), userID, categoryID, req)
+	if err != nil {
+		writeAppError(c, err, http.StatusBadRequest)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (h *Handler) DeleteTaskCategory(c *gin.Context, categoryID string) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+	injectIfMatchContext(c)
+	if err := h.services.Category.DeleteTaskCategory(c.Request.Context(), userID, categoryID); err != nil {
+		writeAppError(c, err, http.StatusBadRequest)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,88 @@
+package transport
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func (h *Handler) ListTaskChecklistItems(c *gin.Context, taskID string) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+	items, err := h.services.Task.ListTaskChecklistItems(c.Request.Context(), userID, taskID)
+	if err != nil {
+		writeAppError(c, err, http.StatusBadRequest)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"items": items})
+}
+
+func (h *Handler) PostTaskChecklistItem(c *gin.Context, taskID string) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+	injectIfMatchContext(c)
+	req, ok := bindJSON[api.CreateTaskChecklistItemRequest](c)
+	if !ok {
+		return
+	}
+	res, err := h.services.Task.CreateTaskChecklistItem(c.Request.Context(), userID, taskID, req)
+	if err != nil {
+		writeAppError(c, err, http.StatusBadRequest)
+		return
+	}
+	c.JSON(http.StatusCreated, res)
+}
+
+func (h *Handler) PatchTaskChecklistItem(c *gin.Context, taskID, itemID string) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+	injectIfMatchContext(c)
+	req, ok := bindJSON[api.UpdateTaskChecklistItemRequest](c)
+	if !ok {
+		return
+	}
+	res, err := h.services.Task.PatchTaskChecklistItem(c.Request.Context(), userID, taskID, itemID, req)
+	if err != nil {
+		writeAppError(c, err, http.StatusBadRequest)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (h *Handler) DeleteTaskChecklistItem(c *gin.Context, taskID, itemID string) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+	injectIfMatchContext(c)
+	if err := h.services.Task.DeleteTaskChecklistItem(c.Request.Context(), userID, taskID, itemID); err != nil {
+		writeAppError(c, err, http.StatusBadRequest)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) PostTaskChecklistItemToggle(c *gin.Context, taskID, itemID string) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+	injectIfMatchContext(c)
+	req, ok := bindJSON[api.ToggleTaskChecklistItemRequest](c)
+	if !ok {
+		return
+	}
+	res, err := h.services.Task.ToggleTaskChecklistItem(c.Request.Context(), userID, taskID, itemID, req.TargetDate.Time)
+	if err != nil {
+		writeAppError(c, err, http.StatusBadRequest)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,88 @@
+package transport
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func (h *Handler) ListTaskComments(c *gin.Context, taskID string, params api.ListTaskCommentsParams) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+	res, err := h.services.Task.ListTaskComments(c.Request.Context(), userID, taskID, params.Limit, params.Cursor)
+	if err != nil {
+		writeAppError(c, err, http.StatusBadRequest)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (h *Handler) PostTaskComment(c *gin.Context, taskID string) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+	injectIfMatchContext(c)
+	req, ok := bindJSON[api.CreateTaskCommentRequest](c)
+	if !ok {
+		return
+	}
+	res, err := h.services.Task.CreateTaskComment(c.Request.Context(), userID, taskID, req)
+	if err != nil {
+		writeAppError(c, err, http.StatusBadRequest)
+		return
+	}
+	c.JSON(http.StatusCreated, res)
+}
+
+func (h *Handler) PatchTaskComment(c *gin.Context, taskID, commentID string) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+	injectIfMatchContext(c)
+	req, ok := bindJSON[api.UpdateTaskCommentRequest](c)
+	if !ok {
+		return
+	}
+	res, err := h.services.Task.PatchTaskComment(c.Request.Context(), userID, taskID, commentID, req)
+	if err != nil {
+		writeAppError(c, err, http.StatusBadRequest)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (h *Handler) DeleteTaskComment(c *gin.Context, taskID, commentID string) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+	injectIfMatchContext(c)
+	if err := h.services.Task.DeleteTaskComment(c.Request.Context(), userID, taskID, commentID); err != nil {
+		writeAppError(c, err, http.StatusBadRequest)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) PostTaskCompletionReaction(c *gin.Context, taskID string) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+	injectIfMatchContext(c)
+	req, ok := bindJSON[api.ToggleCompletionReactionRequest](c)
+	if !ok {
+		return
+	}
+	res, err := h.services.Task.ToggleCompletionReaction(c.Request.Context(), userID, taskID, req)
+	if err != nil {
+		writeAppError(c, err, http.StatusBadRequest)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,111 @@
+package transport
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/megu/kaji-challenge/backend/internal/http/application/ports"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+const (
+	// evidenceFormMaxBytes leaves room for the other fields and multipart
+	// framing on top of the 5 MiB image limit checked by the store.
+	evidenceFormMaxBytes  = 6 << 20
+	evidenceFormMaxMemory = 8 << 20
+)
+
+func (h *Handler) PostTaskCompletionEvidence(c *gin.Context, taskID string) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+	injectIfMatchContext(c)
+	upload, ok := bindCompletionEvidenceForm(c)
+	if !ok {
+		return
+	}
+	res, err := h.services.Task.SaveTaskCompletionEvidence(c.Request.Context(), userID, taskID, upload)
+	if err != nil {
+		writeAppError(c, err, http.StatusBadRequest)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (h *Handler) GetCompletionAttachment(c *gin.Context, attachmentID string, params api.GetCompletionAttachmentParams) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+	thumbnail := params.Variant != nil && *params.Variant == api.Thumbnail
+	res, err := h.services.Task.GetCompletionAttachment(c.Request.Context(), userID, attachmentID, thumbnail)
+	if err != nil {
+		writeAppError(c, err, http.StatusBadRequest)
+		return
+	}
+	defer res.Body.Close()
+	// Attachments never change once uploaded; a new photo gets a new ID.
+	c.Header("Cache-Control", "private, max-age=86400, immutable")
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.DataFromReader(http.StatusOK, -1, res.ContentType, res.Body, nil)
+}
+
+func bindCompletionEvidenceForm(c *gin.Context) (ports.CompletionEvidenceUpload, bool) {
+	invalid := func(message string) (ports.CompletionEvidenceUpload, bool) {
+		writeAppError(c, newAppError(http.StatusBadRequest, "invalid_request", message), http.StatusBadRequest)
+		return ports.CompletionEvidenceUpload{}, false
+	}
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, evidenceFormMaxBytes)
+	if err := c.Request.ParseMultipartForm(evidenceFormMaxMemory); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeAppError(c, newAppError(http.StatusRequestEntityTooLarge, "request_too_large", "request body is too large"), http.StatusRequestEntityTooLarge)
+			return ports.CompletionEvidenceUpload{}, false
+		}
+		return invalid("invalid multipart form")
+	}
+	form := c.Request.MultipartForm
+
+	var upload ports.CompletionEvidenceUpload
+	targetDate, err := time.Parse(time.DateOnly, c.Request.FormValue("targetDate"))
+	if err != nil {
+		return invalid("targetDate must be a date (YYYY-MM-DD)")
+	}
+	upload.TargetDate = targetDate
+	if v := c.Request.FormValue("slot"); v != "" {
+		slot, err := strconv.Atoi(v)
+		if err != nil {
+			return invalid("slot must be an integer")
+		}
+		upload.Slot = &slot
+	}
+	if values, ok := form.Value["note"]; ok && len(values) > 0 {
+		note := values[0]
+		upload.Note = &note
+	}
+	if v := c.Request.FormValue("removeImage"); v != "" {
+		remove, err := strconv.ParseBool(v)
+		if err != nil {
+			return invalid("removeImage must be a boolean")
+		}
+		upload.RemoveImage = remove
+	}
+	if files := form.File["image"]; len(files) > 0 {
+		f, err := files[0].Open()
+		if err != nil {
+			return invalid("image could not be read")
+		}
+		defer f.Close()
+		data, err := io.ReadAll(f)
+		if err != nil {
+			return invalid("image could not be read")
+		}
+		upload.Image = data
+	}
+	return upload, true
+}
@@ -12,6 +12,7 @@ import (
 
 type syncProvider interface {
 	TeamETagForUser(ctx context.Context, userID string) (string, error)
+	TeamDayETagForUser(ctx context.Context, userID string) (string, error)
 	TeamEventStreamForUser(ctx context.Context, userID string) (string, int64, <-chan store.TeamEvent, func(), error)
 }
 
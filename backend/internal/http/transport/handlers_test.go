@@ -12,6 +12,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/megu/kaji-challenge/backend/internal/http/application"
 	"github.com/megu/kaji-challenge/backend/internal/http/application/ports"
+	"github.com/megu/kaji-challenge/backend/internal/http/infra/store"
 	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
 )
 
@@ -29,7 +30,10 @@ func (m mockAuthService) ExchangeSession(context.Context, string) (ports.AuthSes
 func (m mockAuthService) RevokeSession(context.Context, string)                {}
 func (m mockAuthService) LookupSession(context.Context, string) (string, bool) { return "", false }
 
-type mockTeamService struct{ err error }
+type mockTeamService struct {
+	err    error
+	export ports.PersonalDataExport
+}
 
 func (m mockTeamService) GetMe(context.Context, string) (api.MeResponse, error) {
 	if m.err != nil {
@@ -43,6 +47,12 @@ func (m mockTeamService) PatchMeNickname(context.Context, string, api.UpdateNick
 func (m mockTeamService) PatchMeColor(context.Context, string, api.UpdateColorRequest) (api.UpdateColorResponse, error) {
 	return api.UpdateColorResponse{}, nil
 }
+func (m mockTeamService) GetMeExport(context.Context, string, bool) (ports.PersonalDataExport, error) {
+	return m.export, m.err
+}
+func (m mockTeamService) DeleteMe(context.Context, string) error {
+	return m.err
+}
 func (m mockTeamService) CreateInvite(context.Context, string, api.CreateInviteRequest) (api.InviteCodeResponse, error) {
 	return api.InviteCodeResponse{}, nil
 }
@@ -55,6 +65,9 @@ func (m mockTeamService) PatchTeamCurrent(context.Context, string, api.UpdateCur
 func (m mockTeamService) GetTeamCurrentMembers(context.Context, string) (api.TeamMembersResponse, error) {
 	return api.TeamMembersResponse{}, nil
 }
+func (m mockTeamService) ListTeamActivity(context.Context, string, *int, *string) (api.TeamActivityPage, error) {
+	return api.TeamActivityPage{}, nil
+}
 func (m mockTeamService) JoinTeam(context.Context, string, string) (api.JoinTeamResponse, error) {
 	return api.JoinTeamResponse{}, nil
 }
@@ -64,7 +77,7 @@ func (m mockTeamService) PostTeamLeave(context.Context, string) (api.JoinTeamRes
 
 type mockTaskService struct{}
 
-func (m mockTaskService) ListTasks(context.Context, string, *api.TaskType) ([]api.Task, error) {
+func (m mockTaskService) ListTasks(context.Context, string, *api.TaskType, *string) ([]api.Task, error) {
 	return nil, nil
 }
 func (m mockTaskService) CreateTask(context.Context, string, api.CreateTaskRequest) (api.Task, error) {
@@ -77,6 +90,42 @@ func (m mockTaskService) DeleteTask(context.Context, string, string) error { ret
 func (m mockTaskService) ToggleTaskCompletion(context.Context, string, string, time.Time, *api.ToggleTaskCompletionRequestAction) (api.TaskCompletionResponse, error) {
 	return api.TaskCompletionResponse{}, nil
 }
+func (m mockTaskService) ListTaskChecklistItems(context.Context, string, string) ([]api.TaskChecklistItem, error) {
+	return nil, nil
+}
+func (m mockTaskService) CreateTaskChecklistItem(context.Context, string, string, api.CreateTaskChecklistItemRequest) (api.TaskChecklistItem, error) {
+	return api.TaskChecklistItem{}, nil
+}
+func (m mockTaskService) PatchTaskChecklistItem(context.Context, string, string, string, api.UpdateTaskChecklistItemRequest) (api.TaskChecklistItem, error) {
+	return api.TaskChecklistItem{}, nil
+}
+func (m mockTaskService) DeleteTaskChecklistItem(context.Context, string, string, string) error {
+	return nil
+}
+func (m mockTaskService) ToggleTaskChecklistItem(context.Context, string, string, string, time.Time) (api.TaskChecklistToggleResponse, error) {
+	return api.TaskChecklistToggleResponse{}, nil
+}
+func (m mockTaskService) SaveTaskCompletionEvidence(context.Context, string, string, ports.CompletionEvidenceUpload) (api.TaskCompletionEvidenceResponse, error) {
+	return api.TaskCompletionEvidenceResponse{}, nil
+}
+func (m mockTaskService) GetCompletionAttachment(context.Context, string, string, bool) (ports.CompletionAttachmentContent, error) {
+	return ports.CompletionAttachmentContent{}, nil
+}
+func (m mockTaskService) ListTaskComments(context.Context, string, string, *int, *string) (api.TaskCommentPage, error) {
+	return api.TaskCommentPage{}, nil
+}
+func (m mockTaskService) CreateTaskComment(context.Context, string, string, api.CreateTaskCommentRequest) (api.TaskComment, error) {
+	return api.TaskComment{}, nil
+}
+func (m mockTaskService) PatchTaskComment(context.Context, string, string, string, api.UpdateTaskCommentRequest) (api.TaskComment, error) {
+	return api.TaskComment{}, nil
+}
+func (m mockTaskService) DeleteTaskComment(context.Context, string, string, string) error {
+	return nil
+}
+func (m mockTaskService) ToggleCompletionReaction(context.Context, string, string, api.ToggleCompletionReactionRequest) (api.CompletionReactionResponse, error) {
+	return api.CompletionReactionResponse{}, nil
+}
 
 type mockPenaltyService struct{}
 
@@ -93,7 +142,7 @@ func (m mockPenaltyService) DeletePenaltyRule(context.Context, string, string) e
 
 type mockTaskOverviewService struct{}
 
-func (m mockTaskOverviewService) GetTaskOverview(context.Context, string) (api.TaskOverviewResponse, error) {
+func (m mockTaskOverviewService) GetTaskOverview(context.Context, string, *string) (api.TaskOverviewResponse, error) {
 	return api.TaskOverviewResponse{}, nil
 }
 func (m mockTaskOverviewService) GetMonthlySummary(context.Context, string, *string) (api.MonthlyPenaltySummary, error) {
@@ -111,8 +160,36 @@ func (m mockAdminService) CloseWeekForUser(context.Context, string) (api.CloseRe
 func (m mockAdminService) CloseMonthForUser(context.Context, string) (api.CloseResponse, error) {
 	return api.CloseResponse{}, nil
 }
+func (m mockAdminService) ListCloseRuns(context.Context, string, *int) (api.CloseRunsResponse, error) {
+	return api.CloseRunsResponse{}, nil
+}
+
+type mockBatchService struct{ res ports.BatchResult }
+
+func (m mockBatchService) ApplyBatch(context.Context, string, api.BatchMutationRequest) (ports.BatchResult, error) {
+	return m.res, nil
+}
+
+type fakeSyncProvider struct {
+	etag    string
+	dayEtag string
+}
+
+func (f fakeSyncProvider) TeamETagForUser(context.Context, string) (string, error) {
+	return f.etag, nil
+}
+func (f fakeSyncProvider) TeamDayETagForUser(context.Context, string) (string, error) {
+	return f.dayEtag, nil
+}
+func (f fakeSyncProvider) TeamEventStreamForUser(context.Context, string) (string, int64, <-chan store.TeamEvent, func(), error) {
+	return "", 0, nil, func() {}, nil
+}
 
 func newTestHandler(teamErr error) *Handler {
+	return newTestHandlerWithBatch(teamErr, ports.BatchResult{})
+}
+
+func newTestHandlerWithBatch(teamErr error, batchRes ports.BatchResult) *Handler {
 	return NewHandler(&ports.Services{
 		Auth:         mockAuthService{},
 		Team:         mockTeamService{err: teamErr},
@@ -120,6 +197,7 @@ func newTestHandler(teamErr error) *Handler {
 		Penalty:      mockPenaltyService{},
 		TaskOverview: mockTaskOverviewService{},
 		Admin:        mockAdminService{},
+		Batch:        mockBatchService{res: batchRes},
 	}, nil)
 }
 
@@ -141,6 +219,84 @@ func TestGetMeMapsTypedNotFoundTo404(t *testing.T) {
 	}
 }
 
+func TestDeleteMeClearsSessionCookie(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := newTestHandler(nil)
+	r := gin.New()
+	r.DELETE("/v1/me", func(c *gin.Context) {
+		c.Set(AuthUserIDKey, "u1")
+		h.DeleteMe(c)
+	})
+
+	req := httptest.NewRequest(http.MethodDelete, "/v1/me", nil)
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	if res.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", res.Code)
+	}
+	cookies := res.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != SessionCookieName || cookies[0].MaxAge >= 0 {
+		t.Fatalf("expected the session cookie to be cleared, got %+v", cookies)
+	}
+}
+
+func TestGetMeExportReturnsBundleOrAcceptedStatus(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name       string
+		export     ports.PersonalDataExport
+		wantCode   int
+		wantHeader string
+		wantBody   string
+	}{
+		{
+			name: "ready",
+			export: ports.PersonalDataExport{
+				Status:  api.PersonalDataExport{Id: "e1", Status: "ready"},
+				Payload: []byte(`{"format":"kaji-challenge.personal-data"}`),
+			},
+			wantCode:   http.StatusOK,
+			wantHeader: "Content-Disposition",
+			wantBody:   `{"format":"kaji-challenge.personal-data"}`,
+		},
+		{
+			name:       "pending",
+			export:     ports.PersonalDataExport{Status: api.PersonalDataExport{Id: "e1", Status: "pending"}},
+			wantCode:   http.StatusAccepted,
+			wantHeader: "Retry-After",
+			wantBody:   `"status":"pending"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewHandler(&ports.Services{Team: mockTeamService{export: tt.export}}, nil)
+			r := gin.New()
+			r.GET("/v1/me/export", func(c *gin.Context) {
+				c.Set(AuthUserIDKey, "u1")
+				h.GetMeExport(c, api.GetMeExportParams{})
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/v1/me/export", nil)
+			res := httptest.NewRecorder()
+			r.ServeHTTP(res, req)
+
+			if res.Code != tt.wantCode {
+				t.Fatalf("expected %d, got %d", tt.wantCode, res.Code)
+			}
+			if res.Header().Get(tt.wantHeader) == "" {
+				t.Fatalf("expected %s header", tt.wantHeader)
+			}
+			if got := res.Header().Get("Cache-Control"); got != "no-store" {
+				t.Fatalf("expected no-store, got %q", got)
+			}
+			if !strings.Contains(res.Body.String(), tt.wantBody) {
+				t.Fatalf("unexpected body: %s", res.Body.String())
+			}
+		})
+	}
+}
+
 func TestPostTaskInvalidBodyReturns400(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	h := newTestHandler(nil)
@@ -168,11 +324,33 @@ func TestPostTaskInvalidBodyReturns400(t *testing.T) {
 	}
 }
 
+func TestImportTeamHolidaysRejectsOversizedBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := newTestHandler(nil)
+	r := gin.New()
+	r.POST("/v1/teams/current/holidays/import", func(c *gin.Context) {
+		c.Set(AuthUserIDKey, "u1")
+		h.ImportTeamHolidays(c)
+	})
+
+	body := `{"ics":"` + strings.Repeat("A", holidayImportMaxBytes) + `"}`
+	req := httptest.NewRequest(http.MethodPost, "/v1/teams/current/holidays/import", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	if res.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected 413, got %d", res.Code)
+	}
+}
+
 func TestGetTaskOverviewWithoutUserReturns401(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	h := newTestHandler(nil)
 	r := gin.New()
-	r.GET("/v1/tasks/overview", h.GetTaskOverview)
+	r.GET("/v1/tasks/overview", func(c *gin.Context) {
+		h.GetTaskOverview(c, api.GetTaskOverviewParams{})
+	})
 
 	req := httptest.NewRequest(http.MethodGet, "/v1/tasks/overview", nil)
 	res := httptest.NewRecorder()
@@ -182,3 +360,98 @@ func TestGetTaskOverviewWithoutUserReturns401(t *testing.T) {
 		t.Fatalf("expected 401, got %d", res.Code)
 	}
 }
+
+func TestPostBatchAbortedAtomicBatchReturns409WithItemErrors(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := newTestHandlerWithBatch(nil, ports.BatchResult{
+		Committed: false,
+		ETag:      `W/"team:t1:rev:3"`,
+		Operations: []ports.BatchOperationOutcome{
+			{Result: api.BatchOperationResult{Id: "op-1", Status: api.Aborted}},
+			{
+				Result: api.BatchOperationResult{Id: "op-2", Status: api.Failed},
+				Err: &application.PreconditionError{
+					Message:     "team state changed; refresh and retry",
+					CurrentETag: `W/"team:t1:rev:3"`,
+				},
+			},
+		},
+	})
+	r := gin.New()
+	r.POST("/v1/batch", func(c *gin.Context) {
+		c.Set(AuthUserIDKey, "u1")
+		h.PostBatch(c)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/batch", strings.NewReader(`{"operations":[{"id":"op-1","type":"deleteTask","taskId":"t"},{"id":"op-2","type":"deleteTask","taskId":"t"}]}`))
+	req.Header.Set("Content-Type", "application/json")
+	res := httptest.NewRecorder()
+	r.ServeHTTP(res, req)
+
+	if res.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d", res.Code)
+	}
+	var body api.BatchMutationResponse
+	if err := json.Unmarshal(res.Body.Bytes(), &body); err != nil {
+		t.Fatalf("invalid json response: %v", err)
+	}
+	failed := body.Results[1]
+	if failed.HttpStatus == nil || *failed.HttpStatus != http.StatusPreconditionFailed {
+		t.Fatalf("expected item status 412, got %v", failed.HttpStatus)
+	}
+	if failed.Code == nil || *failed.Code != "precondition_failed" {
+		t.Fatalf("expected precondition_failed code, got %v", failed.Code)
+	}
+	if failed.CurrentEtag == nil || *failed.CurrentEtag != `W/"team:t1:rev:3"` {
+		t.Fatalf("expected currentEtag, got %v", failed.CurrentEtag)
+	}
+	if body.Results[0].HttpStatus != nil {
+		t.Fatalf("expected aborted item without error status")
+	}
+}
+
+func TestConditionalGetReturns304WhenETagMatches(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	h := newTestHandler(nil)
+	h.syncProvider = fakeSyncProvider{
+		etag:    `W/"team:t1:rev:4"`,
+		dayEtag: `W/"team:t1:rev:4:day:2026-03-14"`,
+	}
+	r := gin.New()
+	r.GET("/v1/tasks/overview", func(c *gin.Context) {
+		c.Set(AuthUserIDKey, "u1")
+		h.GetTaskOverview(c, api.GetTaskOverviewParams{})
+	})
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		wantStatus  int
+	}{
+		{name: "no validator", ifNoneMatch: "", wantStatus: http.StatusOK},
+		{name: "same day", ifNoneMatch: `W/"team:t1:rev:4:day:2026-03-14"`, wantStatus: http.StatusNotModified},
+		{name: "one of many", ifNoneMatch: `W/"team:t1:rev:3:day:2026-03-14", W/"team:t1:rev:4:day:2026-03-14"`, wantStatus: http.StatusNotModified},
+		{name: "previous day", ifNoneMatch: `W/"team:t1:rev:4:day:2026-03-13"`, wantStatus: http.StatusOK},
+		{name: "team etag without day", ifNoneMatch: `W/"team:t1:rev:4"`, wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/v1/tasks/overview", nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			res := httptest.NewRecorder()
+			r.ServeHTTP(res, req)
+
+			if res.Code != tt.wantStatus {
+				t.Fatalf("expected %d, got %d", tt.wantStatus, res.Code)
+			}
+			if got := res.Header().Get("ETag"); got != `W/"team:t1:rev:4:day:2026-03-14"` {
+				t.Fatalf("expected day scoped ETag, got %q", got)
+			}
+			if tt.wantStatus == http.StatusNotModified && res.Body.Len() != 0 {
+				t.Fatalf("expected empty body for 304, got %q", res.Body.String())
+			}
+		})
+	}
+}
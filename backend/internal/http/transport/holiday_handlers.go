@@ -0,0 +1,91 @@
+package transport
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// holidayImportMaxBytes bounds the JSON body of a calendar import: the 1 MiB
+// document allowed by the API plus room for JSON escaping.
+const holidayImportMaxBytes = 4 << 20
+
+func (h *Handler) ListTeamHolidays(c *gin.Context, params api.ListTeamHolidaysParams) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+	var from, to *time.Time
+	if params.From != nil {
+		from = &params.From.Time
+	}
+	if params.To != nil {
+		to = &params.To.Time
+	}
+	items, err := h.services.Holiday.ListTeamHolidays(c.Request.Context(), userID, from, to)
+	if err != nil {
+		writeAppError(c, err, http.StatusBadRequest)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"items": items})
+}
+
+func (h *Handler) PostTeamHoliday(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+	injectIfMatchContext(c)
+	req, ok := bindJSON[api.CreateTeamHolidayRequest](c)
+	if !ok {
+		return
+	}
+	res, err := h.services.Holiday.CreateTeamHoliday(c.Request.Context(), userID, req)
+	if err != nil {
+		writeAppError(c, err, http.StatusBadRequest)
+		return
+	}
+	c.JSON(http.StatusCreated, res)
+}
+
+func (h *Handler) ImportTeamHolidays(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+	injectIfMatchContext(c)
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, holidayImportMaxBytes)
+	var req api.ImportTeamHolidaysRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeAppError(c, newAppError(http.StatusRequestEntityTooLarge, "request_too_large", "request body is too large"), http.StatusRequestEntityTooLarge)
+			return
+		}
+		writeAppError(c, newAppError(http.StatusBadRequest, "invalid_request", "invalid request body"), http.StatusBadRequest)
+		return
+	}
+	res, err := h.services.Holiday.ImportTeamHolidays(c.Request.Context(), userID, req)
+	if err != nil {
+		writeAppError(c, err, http.StatusBadRequest)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (h *Handler) DeleteTeamHoliday(c *gin.Context, date openapi_types.Date) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+	injectIfMatchContext(c)
+	if err := h.services.Holiday.DeleteTeamHoliday(c.Request.Context(), userID, date.Time); err != nil {
+		writeAppError(c, err, http.StatusBadRequest)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -12,6 +12,10 @@ func (h *Handler) ListPenaltyRules(c *gin.Context, params api.ListPenaltyRulesPa
 	if !ok {
 		return
 	}
+	etag, notModified := h.checkNotModified(c, userID, false)
+	if notModified {
+		return
+	}
 	includeDeleted := false
 	if params.IncludeDeleted != nil {
 		includeDeleted = *params.IncludeDeleted
@@ -21,7 +25,7 @@ func (h *Handler) ListPenaltyRules(c *gin.Context, params api.ListPenaltyRulesPa
 		writeAppError(c, err, http.StatusBadRequest)
 		return
 	}
-	h.writeTeamETag(c, userID)
+	writeCacheableETag(c, etag)
 	c.JSON(http.StatusOK, gin.H{"items": items})
 }
 
@@ -1,18 +1,25 @@
 package transport
 
 import (
+	"net/http"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/megu/kaji-challenge/backend/internal/http/infra/store"
 )
 
+// injectIfMatchContext prepares the request context for a team write: the
+// If-Match precondition and the authenticated user the audit log attributes
+// the write to.
 func injectIfMatchContext(c *gin.Context) {
-	ifMatch := strings.TrimSpace(c.GetHeader("If-Match"))
-	if ifMatch == "" {
-		return
+	ctx := c.Request.Context()
+	if userID := c.GetString(AuthUserIDKey); userID != "" {
+		ctx = store.NewActorContext(ctx, userID)
+	}
+	if ifMatch := strings.TrimSpace(c.GetHeader("If-Match")); ifMatch != "" {
+		ctx = store.NewIfMatchContext(ctx, ifMatch)
 	}
-	c.Request = c.Request.WithContext(store.NewIfMatchContext(c.Request.Context(), ifMatch))
+	c.Request = c.Request.WithContext(ctx)
 }
 
 func (h *Handler) writeTeamETag(c *gin.Context, userID string) {
@@ -25,3 +32,56 @@ func (h *Handler) writeTeamETag(c *gin.Context, userID string) {
 	}
 	c.Header("ETag", etag)
 }
+
+// checkNotModified computes the team ETag before the payload is loaded, so the
+// ETag never claims a newer state than the body it is sent with. It answers 304
+// when If-None-Match already holds that ETag.
+func (h *Handler) checkNotModified(c *gin.Context, userID string, dayScoped bool) (string, bool) {
+	if h.syncProvider == nil || userID == "" {
+		return "", false
+	}
+	var (
+		etag string
+		err  error
+	)
+	if dayScoped {
+		etag, err = h.syncProvider.TeamDayETagForUser(c.Request.Context(), userID)
+	} else {
+		etag, err = h.syncProvider.TeamETagForUser(c.Request.Context(), userID)
+	}
+	if err != nil || etag == "" {
+		return "", false
+	}
+	if !ifNoneMatchHas(c.GetHeader("If-None-Match"), etag) {
+		return etag, false
+	}
+	writeCacheableETag(c, etag)
+	c.Status(http.StatusNotModified)
+	return etag, true
+}
+
+func writeCacheableETag(c *gin.Context, etag string) {
+	if etag == "" {
+		return
+	}
+	c.Header("ETag", etag)
+	c.Header("Cache-Control", "private, no-cache")
+}
+
+// ifNoneMatchHas uses the weak comparison required for If-None-Match.
+func ifNoneMatchHas(header, etag string) bool {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return false
+	}
+	if header == "*" {
+		return true
+	}
+	want := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == want {
+			return true
+		}
+	}
+	return false
+}
@@ -12,12 +12,16 @@ func (h *Handler) ListTasks(c *gin.Context, params api.ListTasksParams) {
 	if !ok {
 		return
 	}
-	items, err := h.services.Task.ListTasks(c.Request.Context(), userID, params.Type)
+	etag, notModified := h.checkNotModified(c, userID, false)
+	if notModified {
+		return
+	}
+	items, err := h.services.Task.ListTasks(c.Request.Context(), userID, params.Type, params.CategoryId)
 	if err != nil {
 		writeAppError(c, err, http.StatusBadRequest)
 		return
 	}
-	h.writeTeamETag(c, userID)
+	writeCacheableETag(c, etag)
 	c.JSON(http.StatusOK, gin.H{"items": items})
 }
 
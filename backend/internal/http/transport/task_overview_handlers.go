@@ -7,17 +7,21 @@ import (
 	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
 )
 
-func (h *Handler) GetTaskOverview(c *gin.Context) {
+func (h *Handler) GetTaskOverview(c *gin.Context, params api.GetTaskOverviewParams) {
 	userID, ok := mustUserID(c)
 	if !ok {
 		return
 	}
-	home, err := h.services.TaskOverview.GetTaskOverview(c.Request.Context(), userID)
+	etag, notModified := h.checkNotModified(c, userID, true)
+	if notModified {
+		return
+	}
+	home, err := h.services.TaskOverview.GetTaskOverview(c.Request.Context(), userID, params.CategoryId)
 	if err != nil {
 		writeAppError(c, err, http.StatusBadRequest)
 		return
 	}
-	h.writeTeamETag(c, userID)
+	writeCacheableETag(c, etag)
 	c.JSON(http.StatusOK, home)
 }
 
@@ -26,11 +30,15 @@ func (h *Handler) GetPenaltySummaryMonthly(c *gin.Context, params api.GetPenalty
 	if !ok {
 		return
 	}
+	etag, notModified := h.checkNotModified(c, userID, true)
+	if notModified {
+		return
+	}
 	summary, err := h.services.TaskOverview.GetMonthlySummary(c.Request.Context(), userID, params.Month)
 	if err != nil {
 		writeAppError(c, err, http.StatusBadRequest)
 		return
 	}
-	h.writeTeamETag(c, userID)
+	writeCacheableETag(c, etag)
 	c.JSON(http.StatusOK, summary)
 }
@@ -57,6 +57,45 @@ func (h *Handler) PatchMeColor(c *gin.Context) {
 	c.JSON(http.StatusOK, res)
 }
 
+func (h *Handler) DeleteMe(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+	injectIfMatchContext(c)
+	if err := h.services.Team.DeleteMe(c.Request.Context(), userID); err != nil {
+		writeAppError(c, err, http.StatusBadRequest)
+		return
+	}
+	clearSessionCookie(c.Writer, shouldUseSecureCookie(c.Request))
+	c.Status(http.StatusNoContent)
+}
+
+// personalExportRetryAfterSeconds is how long clients are asked to wait before
+// polling a queued export again.
+const personalExportRetryAfterSeconds = "30"
+
+func (h *Handler) GetMeExport(c *gin.Context, params api.GetMeExportParams) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+	refresh := params.Refresh != nil && *params.Refresh
+	res, err := h.services.Team.GetMeExport(c.Request.Context(), userID, refresh)
+	if err != nil {
+		writeAppError(c, err, http.StatusBadRequest)
+		return
+	}
+	c.Header("Cache-Control", "no-store")
+	if res.Payload == nil {
+		c.Header("Retry-After", personalExportRetryAfterSeconds)
+		c.JSON(http.StatusAccepted, res.Status)
+		return
+	}
+	c.Header("Content-Disposition", `attachment; filename="kaji-challenge-personal-data.json"`)
+	c.Data(http.StatusOK, "application/json", res.Payload)
+}
+
 func (h *Handler) PostTeamInvite(c *gin.Context) {
 	userID, ok := mustUserID(c)
 	if !ok {
@@ -116,12 +155,16 @@ func (h *Handler) GetTeamCurrentMembers(c *gin.Context) {
 	if !ok {
 		return
 	}
+	etag, notModified := h.checkNotModified(c, userID, false)
+	if notModified {
+		return
+	}
 	res, err := h.services.Team.GetTeamCurrentMembers(c.Request.Context(), userID)
 	if err != nil {
 		writeAppError(c, err, http.StatusBadRequest)
 		return
 	}
-	h.writeTeamETag(c, userID)
+	writeCacheableETag(c, etag)
 	c.JSON(http.StatusOK, res)
 }
 
@@ -0,0 +1,74 @@
+package transport
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func (h *Handler) ListTaskTemplates(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+	items, err := h.services.Template.ListTaskTemplates(c.Request.Context(), userID)
+	if err != nil {
+		writeAppError(c, err, http.StatusBadRequest)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"items": items})
+}
+
+func (h *Handler) PostTaskTemplate(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+	injectIfMatchContext(c)
+	req, ok := bindJSON[api.CreateTaskTemplateRequest](c)
+	if !ok {
+		return
+	}
+	res, err := h.services.Template.CreateTaskTemplate(c.Request.Context(), userID, req)
+	if err != nil {
+		writeAppError(c, err, http.StatusBadRequest)
+		return
+	}
+	c.JSON(http.StatusCreated, res)
+}
+
+func (h *Handler) DeleteTaskTemplate(c *gin.Context, templateID string) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+	injectIfMatchContext(c)
+	if err := h.services.Template.DeleteTaskTemplate(c.Request.Context(), userID, templateID); err != nil {
+		writeAppError(c, err, http.StatusBadRequest)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) PostTaskTemplateInstantiate(c *gin.Context, templateID string) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+	injectIfMatchContext(c)
+	var req api.InstantiateTaskTemplateRequest
+	if c.Request.ContentLength > 0 {
+		v, ok := bindJSON[api.InstantiateTaskTemplateRequest](c)
+		if !ok {
+			return
+		}
+		req = v
+	}
+	tasks, err := h.services.Template.InstantiateTaskTemplate(c.Request.Context(), userID, templateID, req)
+	if err != nil {
+		writeAppError(c, err, http.StatusBadRequest)
+		return
+	}
+	c.JSON(http.StatusCreated, api.InstantiateTaskTemplateResponse{Tasks: tasks})
+}
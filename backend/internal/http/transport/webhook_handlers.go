@@ -0,0 +1,80 @@
+package transport
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	api "github.com/megu/kaji-challenge/backend/internal/openapi/generated"
+)
+
+func (h *Handler) ListTeamWebhooks(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+	items, err := h.services.Webhook.ListTeamWebhooks(c.Request.Context(), userID)
+	if err != nil {
+		writeAppError(c, err, http.StatusBadRequest)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"items": items})
+}
+
+func (h *Handler) PostTeamWebhook(c *gin.Context) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+	req, ok := bindJSON[api.CreateTeamWebhookRequest](c)
+	if !ok {
+		return
+	}
+	res, err := h.services.Webhook.CreateTeamWebhook(c.Request.Context(), userID, req)
+	if err != nil {
+		writeAppError(c, err, http.StatusBadRequest)
+		return
+	}
+	c.JSON(http.StatusCreated, res)
+}
+
+func (h *Handler) PatchTeamWebhook(c *gin.Context, webhookID string) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+	req, ok := bindJSON[api.UpdateTeamWebhookRequest](c)
+	if !ok {
+		return
+	}
+	res, err := h.services.Webhook.PatchTeamWebhook(c.Request.Context(), userID, webhookID, req)
+	if err != nil {
+		writeAppError(c, err, http.StatusBadRequest)
+		return
+	}
+	c.JSON(http.StatusOK, res)
+}
+
+func (h *Handler) DeleteTeamWebhook(c *gin.Context, webhookID string) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+	if err := h.services.Webhook.DeleteTeamWebhook(c.Request.Context(), userID, webhookID); err != nil {
+		writeAppError(c, err, http.StatusBadRequest)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) ListTeamWebhookDeliveries(c *gin.Context, webhookID string, params api.ListTeamWebhookDeliveriesParams) {
+	userID, ok := mustUserID(c)
+	if !ok {
+		return
+	}
+	items, err := h.services.Webhook.ListTeamWebhookDeliveries(c.Request.Context(), userID, webhookID, params.Limit)
+	if err != nil {
+		writeAppError(c, err, http.StatusBadRequest)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"items": items})
+}
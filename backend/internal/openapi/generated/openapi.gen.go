@@ -17,12 +17,56 @@ const (
 	CookieAuthScopes = "cookieAuth.Scopes"
 )
 
+// Defines values for BatchMutationRequestMode.
+const (
+	Atomic      BatchMutationRequestMode = "atomic"
+	Independent BatchMutationRequestMode = "independent"
+)
+
+// Defines values for BatchOperationResultStatus.
+const (
+	Aborted BatchOperationResultStatus = "aborted"
+	Applied BatchOperationResultStatus = "applied"
+	Failed  BatchOperationResultStatus = "failed"
+	Rebased BatchOperationResultStatus = "rebased"
+)
+
+// Defines values for BatchOperationType.
+const (
+	CreatePenaltyRule    BatchOperationType = "createPenaltyRule"
+	CreateTask           BatchOperationType = "createTask"
+	DeletePenaltyRule    BatchOperationType = "deletePenaltyRule"
+	DeleteTask           BatchOperationType = "deleteTask"
+	ToggleTaskCompletion BatchOperationType = "toggleTaskCompletion"
+	UpdatePenaltyRule    BatchOperationType = "updatePenaltyRule"
+	UpdateTask           BatchOperationType = "updateTask"
+)
+
+// Defines values for CompletionAttachmentContentType.
+const (
+	Imagejpeg CompletionAttachmentContentType = "image/jpeg"
+	Imagepng  CompletionAttachmentContentType = "image/png"
+)
+
+// Defines values for TaskTemplateSource.
+const (
+	Builtin TaskTemplateSource = "builtin"
+	Team    TaskTemplateSource = "team"
+)
+
 // Defines values for TaskType.
 const (
 	Daily  TaskType = "daily"
+	OneOff TaskType = "one_off"
 	Weekly TaskType = "weekly"
 )
 
+// Defines values for TeamHolidaySource.
+const (
+	Ical   TeamHolidaySource = "ical"
+	Manual TeamHolidaySource = "manual"
+)
+
 // Defines values for TeamMemberRole.
 const (
 	TeamMemberRoleMember TeamMemberRole = "member"
@@ -42,6 +86,12 @@ const (
 	Toggle    ToggleTaskCompletionRequestAction = "toggle"
 )
 
+// Defines values for GetCompletionAttachmentParamsVariant.
+const (
+	Original  GetCompletionAttachmentParamsVariant = "original"
+	Thumbnail GetCompletionAttachmentParamsVariant = "thumbnail"
+)
+
 // AuthCallbackResponse defines model for AuthCallbackResponse.
 type AuthCallbackResponse struct {
 	ExchangeCode string `json:"exchangeCode"`
@@ -62,12 +112,147 @@ type AuthStartResponse struct {
 	AuthorizationUrl string `json:"authorizationUrl"`
 }
 
+// BatchMutationRequest defines model for BatchMutationRequest.
+type BatchMutationRequest struct {
+	Mode       *BatchMutationRequestMode `json:"mode,omitempty"`
+	Operations []BatchOperation          `json:"operations"`
+}
+
+// BatchMutationRequestMode defines model for BatchMutationRequest.Mode.
+type BatchMutationRequestMode string
+
+// BatchMutationResponse defines model for BatchMutationResponse.
+type BatchMutationResponse struct {
+	Committed bool                   `json:"committed"`
+	Etag      string                 `json:"etag"`
+	Results   []BatchOperationResult `json:"results"`
+}
+
+// BatchOperation defines model for BatchOperation.
+type BatchOperation struct {
+	CreatePenaltyRule    *CreatePenaltyRuleRequest    `json:"createPenaltyRule,omitempty"`
+	CreateTask           *CreateTaskRequest           `json:"createTask,omitempty"`
+	Id                   string                       `json:"id"`
+	IfMatch              *string                      `json:"ifMatch,omitempty"`
+	RuleId               *string                      `json:"ruleId,omitempty"`
+	TaskId               *string                      `json:"taskId,omitempty"`
+	ToggleTaskCompletion *ToggleTaskCompletionRequest `json:"toggleTaskCompletion,omitempty"`
+	Type                 BatchOperationType           `json:"type"`
+	UpdatePenaltyRule    *UpdatePenaltyRuleRequest    `json:"updatePenaltyRule,omitempty"`
+	UpdateTask           *UpdateTaskRequest           `json:"updateTask,omitempty"`
+}
+
+// BatchOperationResult defines model for BatchOperationResult.
+type BatchOperationResult struct {
+	Code        *string                    `json:"code,omitempty"`
+	Completion  *TaskCompletionResponse    `json:"completion,omitempty"`
+	CurrentEtag *string                    `json:"currentEtag,omitempty"`
+	HttpStatus  *int                       `json:"httpStatus,omitempty"`
+	Id          string                     `json:"id"`
+	Message     *string                    `json:"message,omitempty"`
+	PenaltyRule *PenaltyRule               `json:"penaltyRule,omitempty"`
+	Status      BatchOperationResultStatus `json:"status"`
+	Task        *Task                      `json:"task,omitempty"`
+}
+
+// BatchOperationResultStatus defines model for BatchOperationResult.Status.
+type BatchOperationResultStatus string
+
+// BatchOperationType defines model for BatchOperationType.
+type BatchOperationType string
+
 // CloseResponse defines model for CloseResponse.
 type CloseResponse struct {
 	ClosedAt time.Time `json:"closedAt"`
 	Month    string    `json:"month"`
 }
 
+// CloseRun defines model for CloseRun.
+type CloseRun struct {
+	// AsOf The instant the run treated as now
+	AsOf time.Time `json:"asOf"`
+
+	// ErrorClass timeout, canceled, month_closed, database or internal
+	ErrorClass       *string   `json:"errorClass"`
+	ErrorMessage     *string   `json:"errorMessage"`
+	FinishedAt       time.Time `json:"finishedAt"`
+	Id               string    `json:"id"`
+	PenalizedTasks   int       `json:"penalizedTasks"`
+	PenaltyAdded     int       `json:"penaltyAdded"`
+	PeriodsProcessed int       `json:"periodsProcessed"`
+
+	// Scope day, week or month
+	Scope     string    `json:"scope"`
+	StartedAt time.Time `json:"startedAt"`
+
+	// Trigger ops, admin or scheduler
+	Trigger string `json:"trigger"`
+}
+
+// CloseRunsResponse defines model for CloseRunsResponse.
+type CloseRunsResponse struct {
+	Items  []CloseRun         `json:"items"`
+	Status []CloseScopeStatus `json:"status"`
+}
+
+// CloseScopeStatus defines model for CloseScopeStatus.
+type CloseScopeStatus struct {
+	// ExpectedTargetDate Latest period that should be closed by now
+	ExpectedTargetDate openapi_types.Date `json:"expectedTargetDate"`
+
+	// Lagging True when a period up to expectedTargetDate is still unclosed
+	Lagging bool      `json:"lagging"`
+	LastRun *CloseRun `json:"lastRun,omitempty"`
+
+	// LatestTargetDate Latest closed day, week start or month start
+	LatestTargetDate *openapi_types.Date `json:"latestTargetDate"`
+
+	// Scope day, week or month
+	Scope string `json:"scope"`
+}
+
+// CompletionAttachment defines model for CompletionAttachment.
+type CompletionAttachment struct {
+	ContentType CompletionAttachmentContentType `json:"contentType"`
+	CreatedAt   time.Time                       `json:"createdAt"`
+	Height      int                             `json:"height"`
+
+	// Id Download with GET /v1/completion-attachments/{id}.
+	Id        string `json:"id"`
+	SizeBytes int    `json:"sizeBytes"`
+	Width     int    `json:"width"`
+}
+
+// CompletionAttachmentContentType defines model for CompletionAttachment.ContentType.
+type CompletionAttachmentContentType string
+
+// CompletionEvidence defines model for CompletionEvidence.
+type CompletionEvidence struct {
+	Attachment *CompletionAttachment `json:"attachment,omitempty"`
+	Note       *string               `json:"note,omitempty"`
+}
+
+// CompletionReaction defines model for CompletionReaction.
+type CompletionReaction struct {
+	Count int    `json:"count"`
+	Emoji string `json:"emoji"`
+
+	// UserIds Members who reacted, in the order they reacted.
+	UserIds []string `json:"userIds"`
+}
+
+// CompletionReactionResponse defines model for CompletionReactionResponse.
+type CompletionReactionResponse struct {
+	// Reacted True when the reaction was added, false when it was removed.
+	Reacted   bool                 `json:"reacted"`
+	Reactions []CompletionReaction `json:"reactions"`
+
+	// Slot Completion slot the reaction belongs to; always 1 for daily and one_off tasks.
+	Slot       int                `json:"slot"`
+	TargetDate openapi_types.Date `json:"targetDate"`
+	TaskId     string             `json:"taskId"`
+}
+
 // CreateInviteRequest defines model for CreateInviteRequest.
 type CreateInviteRequest struct {
 	ExpiresInHours *int `json:"expiresInHours,omitempty"`
@@ -80,14 +265,86 @@ type CreatePenaltyRuleRequest struct {
 	Threshold   int     `json:"threshold"`
 }
 
+// CreateTaskCategoryRequest defines model for CreateTaskCategoryRequest.
+type CreateTaskCategoryRequest struct {
+	// ColorHex #RRGGBB
+	ColorHex *string `json:"colorHex,omitempty"`
+	Icon     *string `json:"icon,omitempty"`
+	Name     string  `json:"name"`
+}
+
+// CreateTaskChecklistItemRequest defines model for CreateTaskChecklistItemRequest.
+type CreateTaskChecklistItemRequest struct {
+	// Position Zero-based position. Appended to the end when omitted.
+	Position *int   `json:"position,omitempty"`
+	Title    string `json:"title"`
+}
+
+// CreateTaskCommentRequest defines model for CreateTaskCommentRequest.
+type CreateTaskCommentRequest struct {
+	Body string `json:"body"`
+}
+
 // CreateTaskRequest defines model for CreateTaskRequest.
 type CreateTaskRequest struct {
-	AssigneeUserId             *string  `json:"assigneeUserId,omitempty"`
-	Notes                      *string  `json:"notes,omitempty"`
-	PenaltyPoints              int      `json:"penaltyPoints"`
-	RequiredCompletionsPerWeek *int     `json:"requiredCompletionsPerWeek,omitempty"`
-	Title                      string   `json:"title"`
-	Type                       TaskType `json:"type"`
+	AssigneeUserId *string `json:"assigneeUserId,omitempty"`
+
+	// CategoryId Category in the same team.
+	CategoryId *string `json:"categoryId,omitempty"`
+
+	// DueOn Required for one_off tasks, which are penalised at the close of this date unless completed. Must not be in the past.
+	DueOn *openapi_types.Date `json:"dueOn,omitempty"`
+
+	// EndsOn Last day a daily or weekly task is active. Must not be in the past or before startsOn.
+	EndsOn                     *openapi_types.Date `json:"endsOn,omitempty"`
+	Notes                      *string             `json:"notes,omitempty"`
+	PenaltyPoints              int                 `json:"penaltyPoints"`
+	RequiredCompletionsPerWeek *int                `json:"requiredCompletionsPerWeek,omitempty"`
+
+	// StartsOn First day a daily or weekly task is active. Must not be in the past.
+	StartsOn *openapi_types.Date `json:"startsOn,omitempty"`
+	Title    string              `json:"title"`
+	Type     TaskType            `json:"type"`
+}
+
+// CreateTaskTemplateRequest defines model for CreateTaskTemplateRequest.
+type CreateTaskTemplateRequest struct {
+	Description *string             `json:"description,omitempty"`
+	Items       *[]TaskTemplateItem `json:"items,omitempty"`
+	Name        string              `json:"name"`
+
+	// TaskIds Tasks of the team to copy, including their checklists. Use either items or taskIds.
+	TaskIds *[]string `json:"taskIds,omitempty"`
+}
+
+// CreateTeamAbsenceRequest defines model for CreateTeamAbsenceRequest.
+type CreateTeamAbsenceRequest struct {
+	EndsOn   openapi_types.Date `json:"endsOn"`
+	Reason   *string            `json:"reason,omitempty"`
+	StartsOn openapi_types.Date `json:"startsOn"`
+	TeamWide *bool              `json:"teamWide,omitempty"`
+
+	// UserId Absent member. Defaults to the caller; ignored when teamWide is set.
+	UserId *string `json:"userId,omitempty"`
+}
+
+// CreateTeamHolidayRequest defines model for CreateTeamHolidayRequest.
+type CreateTeamHolidayRequest struct {
+	Date openapi_types.Date `json:"date"`
+	Name string             `json:"name"`
+}
+
+// CreateTeamWebhookRequest defines model for CreateTeamWebhookRequest.
+type CreateTeamWebhookRequest struct {
+	// EventTypes task, task_category, task_checklist, task_comment, task_template, task_completion, completion_reaction, penalty_rule, team_member, invite, team_state, close_run, batch, absence, holiday, month_closed
+	EventTypes *[]string `json:"eventTypes,omitempty"`
+	IsActive   *bool     `json:"isActive,omitempty"`
+
+	// Secret HMAC signing secret. Generated when omitted.
+	Secret *string `json:"secret,omitempty"`
+
+	// Url Absolute http(s) URL whose host resolves to public addresses only. Redirects are not followed.
+	Url string `json:"url"`
 }
 
 // HealthResponse defines model for HealthResponse.
@@ -95,6 +352,33 @@ type HealthResponse struct {
 	Status string `json:"status"`
 }
 
+// ImportTeamHolidaysRequest defines model for ImportTeamHolidaysRequest.
+type ImportTeamHolidaysRequest struct {
+	// Ics iCalendar (RFC 5545) document.
+	Ics string `json:"ics"`
+}
+
+// ImportTeamHolidaysResponse defines model for ImportTeamHolidaysResponse.
+type ImportTeamHolidaysResponse struct {
+	// Imported Number of dates saved as holidays.
+	Imported int `json:"imported"`
+
+	// Skipped Number of events ignored (recurring, undated or out of range).
+	Skipped int `json:"skipped"`
+}
+
+// InstantiateTaskTemplateRequest defines model for InstantiateTaskTemplateRequest.
+type InstantiateTaskTemplateRequest struct {
+	// CategoryId Category for every created task.
+	CategoryId *string `json:"categoryId,omitempty"`
+}
+
+// InstantiateTaskTemplateResponse defines model for InstantiateTaskTemplateResponse.
+type InstantiateTaskTemplateResponse struct {
+	// Tasks Created tasks, in template order.
+	Tasks []Task `json:"tasks"`
+}
+
 // InviteCodeResponse defines model for InviteCodeResponse.
 type InviteCodeResponse struct {
 	Code      string    `json:"code"`
@@ -118,8 +402,26 @@ type MeResponse struct {
 	User        User             `json:"user"`
 }
 
+// MonthlyCategoryStat defines model for MonthlyCategoryStat.
+type MonthlyCategoryStat struct {
+	// CategoryId Absent for uncategorised tasks.
+	CategoryId     *string `json:"categoryId,omitempty"`
+	CompletedCount int     `json:"completedCount"`
+
+	// MissedCount Number of penalties in the category, one per task and closed day or week.
+	MissedCount int `json:"missedCount"`
+
+	// Name Absent for uncategorised tasks. For a deleted category, its name when the penalties were charged.
+	Name *string `json:"name,omitempty"`
+
+	// PenaltyTotal Penalty points of the month's missed closes, at the points charged at close.
+	PenaltyTotal int `json:"penaltyTotal"`
+}
+
 // MonthlyPenaltySummary defines model for MonthlyPenaltySummary.
 type MonthlyPenaltySummary struct {
+	// CategoryStats Penalties and completions of the month per category, including an uncategorised entry when it has any. Penalties keep the category and points charged at close, even after the task or category is deleted; a deleted category follows the live ones under the name it had when charged.
+	CategoryStats           *[]MonthlyCategoryStat   `json:"categoryStats,omitempty"`
 	DailyPenaltyTotal       int                      `json:"dailyPenaltyTotal"`
 	IsClosed                bool                     `json:"isClosed"`
 	Month                   string                   `json:"month"`
@@ -132,8 +434,12 @@ type MonthlyPenaltySummary struct {
 
 // MonthlyTaskStatusGroup defines model for MonthlyTaskStatusGroup.
 type MonthlyTaskStatusGroup struct {
-	Date  openapi_types.Date      `json:"date"`
-	Items []MonthlyTaskStatusItem `json:"items"`
+	Date openapi_types.Date `json:"date"`
+
+	// Exempt True on team holidays. No task is penalised on an exempt day.
+	Exempt      *bool                   `json:"exempt,omitempty"`
+	HolidayName *string                 `json:"holidayName"`
+	Items       []MonthlyTaskStatusItem `json:"items"`
 }
 
 // MonthlyTaskStatusItem defines model for MonthlyTaskStatusItem.
@@ -153,25 +459,123 @@ type PenaltyRule struct {
 	CreatedAt   time.Time  `json:"createdAt"`
 	DeletedAt   *time.Time `json:"deletedAt"`
 	Description *string    `json:"description,omitempty"`
-	Id          string     `json:"id"`
-	Name        string     `json:"name"`
-	TeamId      string     `json:"teamId"`
-	Threshold   int        `json:"threshold"`
-	UpdatedAt   time.Time  `json:"updatedAt"`
+
+	// Etag Penalty rule ETag (`W/"penalty_rule:<id>:rev:<n>"`) usable as If-Match for writes to this rule only.
+	Etag      *string   `json:"etag,omitempty"`
+	Id        string    `json:"id"`
+	Name      string    `json:"name"`
+	TeamId    string    `json:"teamId"`
+	Threshold int       `json:"threshold"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// PersonalDataExport defines model for PersonalDataExport.
+type PersonalDataExport struct {
+	AttemptCount int        `json:"attemptCount"`
+	CompletedAt  *time.Time `json:"completedAt"`
+	ExpiresAt    *time.Time `json:"expiresAt"`
+	Id           string     `json:"id"`
+	LastError    *string    `json:"lastError"`
+	RequestedAt  time.Time  `json:"requestedAt"`
+
+	// Status pending, ready or failed
+	Status string `json:"status"`
 }
 
 // Task defines model for Task.
 type Task struct {
-	AssigneeUserId             *string   `json:"assigneeUserId,omitempty"`
-	CreatedAt                  time.Time `json:"createdAt"`
-	Id                         string    `json:"id"`
-	Notes                      *string   `json:"notes,omitempty"`
-	PenaltyPoints              int       `json:"penaltyPoints"`
-	RequiredCompletionsPerWeek int       `json:"requiredCompletionsPerWeek"`
-	TeamId                     string    `json:"teamId"`
-	Title                      string    `json:"title"`
-	Type                       TaskType  `json:"type"`
-	UpdatedAt                  time.Time `json:"updatedAt"`
+	AssigneeUserId *string `json:"assigneeUserId,omitempty"`
+
+	// CategoryId Category of the task. Absent when uncategorised.
+	CategoryId *string   `json:"categoryId,omitempty"`
+	CreatedAt  time.Time `json:"createdAt"`
+
+	// DueOn Due date of a one_off task. Absent for recurring tasks.
+	DueOn *openapi_types.Date `json:"dueOn,omitempty"`
+
+	// EndsOn Last day a recurring task is active. Absent when it never expires.
+	EndsOn *openapi_types.Date `json:"endsOn,omitempty"`
+
+	// Etag Task ETag (`W/"task:<id>:rev:<n>"`) usable as If-Match for writes to this task only.
+	Etag                       *string `json:"etag,omitempty"`
+	Id                         string  `json:"id"`
+	Notes                      *string `json:"notes,omitempty"`
+	PenaltyPoints              int     `json:"penaltyPoints"`
+	RequiredCompletionsPerWeek int     `json:"requiredCompletionsPerWeek"`
+
+	// StartsOn First day a recurring task is active. Absent when it is active from creation.
+	StartsOn  *openapi_types.Date `json:"startsOn,omitempty"`
+	TeamId    string              `json:"teamId"`
+	Title     string              `json:"title"`
+	Type      TaskType            `json:"type"`
+	UpdatedAt time.Time           `json:"updatedAt"`
+}
+
+// TaskCategory defines model for TaskCategory.
+type TaskCategory struct {
+	ColorHex  *string   `json:"colorHex"`
+	CreatedAt time.Time `json:"createdAt"`
+
+	// Etag Category ETag (`W/"task_category:<id>:rev:<n>"`) usable as If-Match for writes to this category only.
+	Etag *string `json:"etag,omitempty"`
+
+	// Icon Short icon key or emoji chosen by the client.
+	Icon      *string   `json:"icon"`
+	Id        string    `json:"id"`
+	Name      string    `json:"name"`
+	TeamId    string    `json:"teamId"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// TaskChecklistItem defines model for TaskChecklistItem.
+type TaskChecklistItem struct {
+	CreatedAt time.Time `json:"createdAt"`
+	Id        string    `json:"id"`
+	Position  int       `json:"position"`
+	TaskId    string    `json:"taskId"`
+	Title     string    `json:"title"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// TaskChecklistItemState defines model for TaskChecklistItemState.
+type TaskChecklistItemState struct {
+	// Checked Checked in the task's current period (today, this week, or ever for one_off tasks).
+	Checked   bool                 `json:"checked"`
+	CheckedBy *TaskCompletionActor `json:"checkedBy,omitempty"`
+	Id        string               `json:"id"`
+	Position  int                  `json:"position"`
+	Title     string               `json:"title"`
+}
+
+// TaskChecklistToggleResponse defines model for TaskChecklistToggleResponse.
+type TaskChecklistToggleResponse struct {
+	Checked        bool                    `json:"checked"`
+	ItemId         string                  `json:"itemId"`
+	TargetDate     openapi_types.Date      `json:"targetDate"`
+	TaskCompletion *TaskCompletionResponse `json:"taskCompletion,omitempty"`
+	TaskId         string                  `json:"taskId"`
+}
+
+// TaskComment defines model for TaskComment.
+type TaskComment struct {
+	Author    *TaskCompletionActor `json:"author,omitempty"`
+	Body      string               `json:"body"`
+	CreatedAt time.Time            `json:"createdAt"`
+	EditedAt  *time.Time           `json:"editedAt"`
+
+	// Etag Comment ETag (`W/"task_comment:<id>:rev:<n>"`) usable as If-Match for writes to this comment only.
+	Etag   *string `json:"etag,omitempty"`
+	Id     string  `json:"id"`
+	TaskId string  `json:"taskId"`
+}
+
+// TaskCommentPage defines model for TaskCommentPage.
+type TaskCommentPage struct {
+	// Items Comments, newest first.
+	Items []TaskComment `json:"items"`
+
+	// NextCursor Pass as cursor to fetch older comments. Absent on the last page.
+	NextCursor *string `json:"nextCursor"`
 }
 
 // TaskCompletionActor defines model for TaskCompletionActor.
@@ -181,6 +585,31 @@ type TaskCompletionActor struct {
 	UserId        string  `json:"userId"`
 }
 
+// TaskCompletionEvidenceResponse defines model for TaskCompletionEvidenceResponse.
+type TaskCompletionEvidenceResponse struct {
+	Evidence CompletionEvidence `json:"evidence"`
+
+	// Slot Completion slot the evidence belongs to; always 1 for daily and one_off tasks.
+	Slot       int                `json:"slot"`
+	TargetDate openapi_types.Date `json:"targetDate"`
+	TaskId     string             `json:"taskId"`
+}
+
+// TaskCompletionEvidenceUpload defines model for TaskCompletionEvidenceUpload.
+type TaskCompletionEvidenceUpload struct {
+	Image *openapi_types.File `json:"image,omitempty"`
+	Note  *string             `json:"note,omitempty"`
+
+	// RemoveImage Detach the current photo. Ignored when image is sent.
+	RemoveImage *bool `json:"removeImage,omitempty"`
+
+	// Slot Weekly completion slot to annotate. Defaults to the latest completion of the week.
+	Slot *int `json:"slot,omitempty"`
+
+	// TargetDate Same rules as ToggleTaskCompletionRequest.targetDate.
+	TargetDate openapi_types.Date `json:"targetDate"`
+}
+
 // TaskCompletionResponse defines model for TaskCompletionResponse.
 type TaskCompletionResponse struct {
 	Completed            bool               `json:"completed"`
@@ -191,42 +620,185 @@ type TaskCompletionResponse struct {
 
 // TaskCompletionSlot defines model for TaskCompletionSlot.
 type TaskCompletionSlot struct {
-	Actor *TaskCompletionActor `json:"actor,omitempty"`
-	Slot  int                  `json:"slot"`
+	Actor    *TaskCompletionActor `json:"actor,omitempty"`
+	Evidence *CompletionEvidence  `json:"evidence,omitempty"`
+
+	// Reactions Emoji reactions on the completion. Only returned by the task overview.
+	Reactions *[]CompletionReaction `json:"reactions,omitempty"`
+	Slot      int                   `json:"slot"`
 }
 
 // TaskOverviewDailyTask defines model for TaskOverviewDailyTask.
 type TaskOverviewDailyTask struct {
-	CompletedBy    *TaskCompletionActor `json:"completedBy,omitempty"`
-	CompletedToday bool                 `json:"completedToday"`
-	Task           Task                 `json:"task"`
+	// Checklist Checklist items in order with their state in the current period. Absent when the task has none.
+	Checklist      *[]TaskChecklistItemState `json:"checklist,omitempty"`
+	CompletedBy    *TaskCompletionActor      `json:"completedBy,omitempty"`
+	CompletedToday bool                      `json:"completedToday"`
+	Evidence       *CompletionEvidence       `json:"evidence,omitempty"`
+
+	// Paused True when an absence covers today, so the task is not penalised.
+	Paused *bool `json:"paused,omitempty"`
+
+	// Reactions Emoji reactions on the completion. Absent when there are none.
+	Reactions *[]CompletionReaction `json:"reactions,omitempty"`
+	Task      Task                  `json:"task"`
+}
+
+// TaskOverviewOneOffTask defines model for TaskOverviewOneOffTask.
+type TaskOverviewOneOffTask struct {
+	// Checklist Checklist items in order with their state in the current period. Absent when the task has none.
+	Checklist   *[]TaskChecklistItemState `json:"checklist,omitempty"`
+	Completed   bool                      `json:"completed"`
+	CompletedBy *TaskCompletionActor      `json:"completedBy,omitempty"`
+
+	// DaysUntilDue Days from today to the due date; negative when overdue.
+	DaysUntilDue int                 `json:"daysUntilDue"`
+	Evidence     *CompletionEvidence `json:"evidence,omitempty"`
+
+	// Overdue True once the due date has passed without a completion.
+	Overdue bool `json:"overdue"`
+
+	// Reactions Emoji reactions on the completion. Absent when there are none.
+	Reactions *[]CompletionReaction `json:"reactions,omitempty"`
+	Task      Task                  `json:"task"`
 }
 
 // TaskOverviewResponse defines model for TaskOverviewResponse.
 type TaskOverviewResponse struct {
-	DailyTasks          []TaskOverviewDailyTask  `json:"dailyTasks"`
-	ElapsedDaysInWeek   int                      `json:"elapsedDaysInWeek"`
-	Month               string                   `json:"month"`
-	MonthlyPenaltyTotal int                      `json:"monthlyPenaltyTotal"`
-	Today               openapi_types.Date       `json:"today"`
-	WeeklyTasks         []TaskOverviewWeeklyTask `json:"weeklyTasks"`
+	DailyTasks          []TaskOverviewDailyTask `json:"dailyTasks"`
+	ElapsedDaysInWeek   int                     `json:"elapsedDaysInWeek"`
+	Month               string                  `json:"month"`
+	MonthlyPenaltyTotal int                     `json:"monthlyPenaltyTotal"`
+
+	// OneOffTasks Open one_off tasks ordered by due date, including overdue ones, plus those completed but not yet due.
+	OneOffTasks *[]TaskOverviewOneOffTask `json:"oneOffTasks,omitempty"`
+	Today       openapi_types.Date        `json:"today"`
+	WeeklyTasks []TaskOverviewWeeklyTask  `json:"weeklyTasks"`
 }
 
 // TaskOverviewWeeklyTask defines model for TaskOverviewWeeklyTask.
 type TaskOverviewWeeklyTask struct {
-	CompletionSlots            []TaskCompletionSlot `json:"completionSlots"`
-	RequiredCompletionsPerWeek int                  `json:"requiredCompletionsPerWeek"`
-	Task                       Task                 `json:"task"`
-	WeekCompletedCount         int                  `json:"weekCompletedCount"`
+	// Checklist Checklist items in order with their state in the current period. Absent when the task has none.
+	Checklist       *[]TaskChecklistItemState `json:"checklist,omitempty"`
+	CompletionSlots []TaskCompletionSlot      `json:"completionSlots"`
+
+	// PausedDays Days of this week covered by an absence or holiday, or outside the task's startsOn/endsOn window.
+	PausedDays                 *int `json:"pausedDays,omitempty"`
+	RequiredCompletionsPerWeek int  `json:"requiredCompletionsPerWeek"`
+
+	// RequiredCompletionsThisWeek requiredCompletionsPerWeek prorated by the days not counted in pausedDays (rounded up).
+	RequiredCompletionsThisWeek *int `json:"requiredCompletionsThisWeek,omitempty"`
+	Task                        Task `json:"task"`
+	WeekCompletedCount          int  `json:"weekCompletedCount"`
+}
+
+// TaskTemplate defines model for TaskTemplate.
+type TaskTemplate struct {
+	// CreatedAt Team templates only.
+	CreatedAt   *time.Time `json:"createdAt,omitempty"`
+	Description *string    `json:"description,omitempty"`
+
+	// Id Built-in templates have stable IDs starting with builtin-.
+	Id     string             `json:"id"`
+	Items  []TaskTemplateItem `json:"items"`
+	Name   string             `json:"name"`
+	Source TaskTemplateSource `json:"source"`
+}
+
+// TaskTemplateItem defines model for TaskTemplateItem.
+type TaskTemplateItem struct {
+	// Checklist Checklist item titles, in order.
+	Checklist []string `json:"checklist"`
+
+	// DueInDays One_off tasks only. The task is due this many days after the template is used.
+	DueInDays     *int    `json:"dueInDays,omitempty"`
+	Notes         *string `json:"notes,omitempty"`
+	PenaltyPoints int     `json:"penaltyPoints"`
+
+	// RequiredCompletionsPerWeek Weekly tasks only; 1 for other types.
+	RequiredCompletionsPerWeek int      `json:"requiredCompletionsPerWeek"`
+	Title                      string   `json:"title"`
+	Type                       TaskType `json:"type"`
 }
 
+// TaskTemplateSource defines model for TaskTemplateSource.
+type TaskTemplateSource string
+
 // TaskType defines model for TaskType.
 type TaskType string
 
+// TeamAbsence defines model for TeamAbsence.
+type TeamAbsence struct {
+	CreatedAt       time.Time `json:"createdAt"`
+	CreatedByUserId *string   `json:"createdByUserId"`
+
+	// EndsOn Last day of the absence (inclusive).
+	EndsOn openapi_types.Date `json:"endsOn"`
+
+	// Etag Absence ETag (`W/"absence:<id>:rev:<n>"`) usable as If-Match for writes to this absence only.
+	Etag     *string            `json:"etag,omitempty"`
+	Id       string             `json:"id"`
+	Reason   *string            `json:"reason"`
+	StartsOn openapi_types.Date `json:"startsOn"`
+	TeamId   string             `json:"teamId"`
+
+	// UserId Absent member. Null for a team-wide absence.
+	UserId *string `json:"userId"`
+}
+
+// TeamActivityEntry defines model for TeamActivityEntry.
+type TeamActivityEntry struct {
+	// Action create, update, delete, toggle, increment, decrement, rename, join, leave, ...
+	Action string               `json:"action"`
+	Actor  *TaskCompletionActor `json:"actor,omitempty"`
+
+	// After Entity state after the change. Absent for deletions.
+	After *map[string]interface{} `json:"after"`
+
+	// Before Entity state before the change, for tasks, penalty rules, completions, team renames and invites.
+	Before    *map[string]interface{} `json:"before"`
+	CreatedAt time.Time               `json:"createdAt"`
+
+	// Entity Same values as the event types of team webhooks (task, penalty_rule, task_completion, invite, team_state, ...).
+	Entity   string  `json:"entity"`
+	EntityId *string `json:"entityId"`
+	Id       string  `json:"id"`
+
+	// Revision Team revision the change was committed as. Changes of one batch share it.
+	Revision int64 `json:"revision"`
+}
+
+// TeamActivityPage defines model for TeamActivityPage.
+type TeamActivityPage struct {
+	// Items Audit entries, newest first.
+	Items []TeamActivityEntry `json:"items"`
+
+	// NextCursor Pass as cursor to fetch older entries. Absent on the last page.
+	NextCursor *string `json:"nextCursor"`
+}
+
+// TeamHoliday defines model for TeamHoliday.
+type TeamHoliday struct {
+	CreatedAt       time.Time          `json:"createdAt"`
+	CreatedByUserId *string            `json:"createdByUserId"`
+	Date            openapi_types.Date `json:"date"`
+
+	// Etag Holiday ETag (`W/"holiday:<date>:rev:<n>"`) usable as If-Match for writes to this date only.
+	Etag   *string           `json:"etag,omitempty"`
+	Name   string            `json:"name"`
+	Source TeamHolidaySource `json:"source"`
+	TeamId string            `json:"teamId"`
+}
+
+// TeamHolidaySource defines model for TeamHoliday.Source.
+type TeamHolidaySource string
+
 // TeamInfoResponse defines model for TeamInfoResponse.
 type TeamInfoResponse struct {
-	Name   string `json:"name"`
-	TeamId string `json:"teamId"`
+	// Etag Team settings ETag (`W/"team_settings:<teamId>:rev:<n>"`) usable as If-Match for PATCH /v1/teams/current.
+	Etag   *string `json:"etag,omitempty"`
+	Name   string  `json:"name"`
+	TeamId string  `json:"teamId"`
 }
 
 // TeamMember defines model for TeamMember.
@@ -258,10 +830,66 @@ type TeamMembership struct {
 // TeamMembershipRole defines model for TeamMembership.Role.
 type TeamMembershipRole string
 
+// TeamWebhook defines model for TeamWebhook.
+type TeamWebhook struct {
+	CreatedAt time.Time `json:"createdAt"`
+
+	// EventTypes Subscribed event types. Empty means every event.
+	EventTypes []string  `json:"eventTypes"`
+	Id         string    `json:"id"`
+	IsActive   bool      `json:"isActive"`
+	TeamId     string    `json:"teamId"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+	Url        string    `json:"url"`
+}
+
+// TeamWebhookCreatedResponse defines model for TeamWebhookCreatedResponse.
+type TeamWebhookCreatedResponse struct {
+	Secret  string      `json:"secret"`
+	Webhook TeamWebhook `json:"webhook"`
+}
+
+// TeamWebhookDelivery defines model for TeamWebhookDelivery.
+type TeamWebhookDelivery struct {
+	AttemptCount       int        `json:"attemptCount"`
+	CreatedAt          time.Time  `json:"createdAt"`
+	DeliveredAt        *time.Time `json:"deliveredAt"`
+	EventType          string     `json:"eventType"`
+	Id                 string     `json:"id"`
+	LastAttemptAt      *time.Time `json:"lastAttemptAt"`
+	LastError          *string    `json:"lastError"`
+	LastResponseStatus *int       `json:"lastResponseStatus"`
+	NextAttemptAt      time.Time  `json:"nextAttemptAt"`
+
+	// Status pending, succeeded or failed
+	Status    string `json:"status"`
+	WebhookId string `json:"webhookId"`
+}
+
+// ToggleCompletionReactionRequest defines model for ToggleCompletionReactionRequest.
+type ToggleCompletionReactionRequest struct {
+	// Emoji A single emoji, optionally with skin tone or ZWJ sequence.
+	Emoji string `json:"emoji"`
+
+	// Slot Weekly completion slot to react to. Defaults to the latest completion of the week.
+	Slot *int `json:"slot,omitempty"`
+
+	// TargetDate Same rules as ToggleTaskCompletionRequest.targetDate.
+	TargetDate openapi_types.Date `json:"targetDate"`
+}
+
+// ToggleTaskChecklistItemRequest defines model for ToggleTaskChecklistItemRequest.
+type ToggleTaskChecklistItemRequest struct {
+	// TargetDate Same rules as ToggleTaskCompletionRequest.targetDate.
+	TargetDate openapi_types.Date `json:"targetDate"`
+}
+
 // ToggleTaskCompletionRequest defines model for ToggleTaskCompletionRequest.
 type ToggleTaskCompletionRequest struct {
-	Action     *ToggleTaskCompletionRequestAction `json:"action,omitempty"`
-	TargetDate openapi_types.Date                 `json:"targetDate"`
+	Action *ToggleTaskCompletionRequestAction `json:"action,omitempty"`
+
+	// TargetDate Today for daily and one_off tasks, a day of the current week for weekly tasks.
+	TargetDate openapi_types.Date `json:"targetDate"`
 }
 
 // ToggleTaskCompletionRequestAction defines model for ToggleTaskCompletionRequest.Action.
@@ -300,13 +928,63 @@ type UpdatePenaltyRuleRequest struct {
 	Threshold   *int    `json:"threshold,omitempty"`
 }
 
+// UpdateTaskCategoryRequest defines model for UpdateTaskCategoryRequest.
+type UpdateTaskCategoryRequest struct {
+	// ColorHex #RRGGBB
+	ColorHex *string `json:"colorHex,omitempty"`
+	Icon     *string `json:"icon,omitempty"`
+	Name     *string `json:"name,omitempty"`
+}
+
+// UpdateTaskChecklistItemRequest defines model for UpdateTaskChecklistItemRequest.
+type UpdateTaskChecklistItemRequest struct {
+	Position *int    `json:"position,omitempty"`
+	Title    *string `json:"title,omitempty"`
+}
+
+// UpdateTaskCommentRequest defines model for UpdateTaskCommentRequest.
+type UpdateTaskCommentRequest struct {
+	Body string `json:"body"`
+}
+
 // UpdateTaskRequest defines model for UpdateTaskRequest.
 type UpdateTaskRequest struct {
-	AssigneeUserId             *string `json:"assigneeUserId,omitempty"`
-	Notes                      *string `json:"notes,omitempty"`
-	PenaltyPoints              *int    `json:"penaltyPoints,omitempty"`
-	RequiredCompletionsPerWeek *int    `json:"requiredCompletionsPerWeek,omitempty"`
-	Title                      *string `json:"title,omitempty"`
+	AssigneeUserId *string `json:"assigneeUserId,omitempty"`
+
+	// CategoryId New category in the same team.
+	CategoryId *string `json:"categoryId,omitempty"`
+
+	// ClearCategoryId Make the task uncategorised. Ignored when categoryId is set.
+	ClearCategoryId *bool `json:"clearCategoryId,omitempty"`
+
+	// ClearEndsOn Remove endsOn so the task never expires. Ignored when endsOn is set.
+	ClearEndsOn *bool `json:"clearEndsOn,omitempty"`
+
+	// ClearStartsOn Remove startsOn so the task is active from creation. Ignored when startsOn is set.
+	ClearStartsOn *bool `json:"clearStartsOn,omitempty"`
+
+	// DueOn New due date of a one_off task. Must not be in the past, and cannot be changed once the current due date has passed.
+	DueOn *openapi_types.Date `json:"dueOn,omitempty"`
+
+	// EndsOn New last active day. Must not be in the past, and cannot change once the task has ended.
+	EndsOn                     *openapi_types.Date `json:"endsOn,omitempty"`
+	Notes                      *string             `json:"notes,omitempty"`
+	PenaltyPoints              *int                `json:"penaltyPoints,omitempty"`
+	RequiredCompletionsPerWeek *int                `json:"requiredCompletionsPerWeek,omitempty"`
+
+	// StartsOn New first active day. Must not be in the past, and cannot change once the task has started.
+	StartsOn *openapi_types.Date `json:"startsOn,omitempty"`
+	Title    *string             `json:"title,omitempty"`
+}
+
+// UpdateTeamWebhookRequest defines model for UpdateTeamWebhookRequest.
+type UpdateTeamWebhookRequest struct {
+	EventTypes *[]string `json:"eventTypes,omitempty"`
+	IsActive   *bool     `json:"isActive,omitempty"`
+	Secret     *string   `json:"secret,omitempty"`
+
+	// Url Absolute http(s) URL whose host resolves to public addresses only. Redirects are not followed.
+	Url *string `json:"url,omitempty"`
 }
 
 // User defines model for User.
@@ -318,12 +996,30 @@ type User struct {
 	Id          string    `json:"id"`
 }
 
+// ListAdminCloseRunsParams defines parameters for ListAdminCloseRuns.
+type ListAdminCloseRunsParams struct {
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
 // GetAuthGoogleCallbackParams defines parameters for GetAuthGoogleCallback.
 type GetAuthGoogleCallbackParams struct {
 	Code  string `form:"code" json:"code"`
 	State string `form:"state" json:"state"`
 }
 
+// GetCompletionAttachmentParams defines parameters for GetCompletionAttachment.
+type GetCompletionAttachmentParams struct {
+	Variant *GetCompletionAttachmentParamsVariant `form:"variant,omitempty" json:"variant,omitempty"`
+}
+
+// GetCompletionAttachmentParamsVariant defines parameters for GetCompletionAttachment.
+type GetCompletionAttachmentParamsVariant string
+
+// GetMeExportParams defines parameters for GetMeExport.
+type GetMeExportParams struct {
+	Refresh *bool `form:"refresh,omitempty" json:"refresh,omitempty"`
+}
+
 // ListPenaltyRulesParams defines parameters for ListPenaltyRules.
 type ListPenaltyRulesParams struct {
 	IncludeDeleted *bool `form:"includeDeleted,omitempty" json:"includeDeleted,omitempty"`
@@ -337,11 +1033,58 @@ type GetPenaltySummaryMonthlyParams struct {
 // ListTasksParams defines parameters for ListTasks.
 type ListTasksParams struct {
 	Type *TaskType `form:"type,omitempty" json:"type,omitempty"`
+
+	// CategoryId Only tasks in this category, or `none` for uncategorised tasks.
+	CategoryId *string `form:"categoryId,omitempty" json:"categoryId,omitempty"`
+}
+
+// GetTaskOverviewParams defines parameters for GetTaskOverview.
+type GetTaskOverviewParams struct {
+	// CategoryId Only tasks in this category, or `none` for uncategorised tasks.
+	CategoryId *string `form:"categoryId,omitempty" json:"categoryId,omitempty"`
+}
+
+// ListTaskCommentsParams defines parameters for ListTaskComments.
+type ListTaskCommentsParams struct {
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor nextCursor of the previous page, to continue with older comments.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// ListTeamAbsencesParams defines parameters for ListTeamAbsences.
+type ListTeamAbsencesParams struct {
+	IncludePast *bool `form:"includePast,omitempty" json:"includePast,omitempty"`
+}
+
+// ListTeamActivityParams defines parameters for ListTeamActivity.
+type ListTeamActivityParams struct {
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor nextCursor of the previous page, to continue with older entries.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// ListTeamHolidaysParams defines parameters for ListTeamHolidays.
+type ListTeamHolidaysParams struct {
+	// From First date to list (inclusive). Defaults to today.
+	From *openapi_types.Date `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Last date to list (inclusive). Defaults to one year after from.
+	To *openapi_types.Date `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// ListTeamWebhookDeliveriesParams defines parameters for ListTeamWebhookDeliveries.
+type ListTeamWebhookDeliveriesParams struct {
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
 }
 
 // PostAuthSessionsExchangeJSONRequestBody defines body for PostAuthSessionsExchange for application/json ContentType.
 type PostAuthSessionsExchangeJSONRequestBody = AuthSessionExchangeRequest
 
+// PostBatchJSONRequestBody defines body for PostBatch for application/json ContentType.
+type PostBatchJSONRequestBody = BatchMutationRequest
+
 // PatchMeColorJSONRequestBody defines body for PatchMeColor for application/json ContentType.
 type PatchMeColorJSONRequestBody = UpdateColorRequest
 
@@ -354,18 +1097,66 @@ type PostPenaltyRuleJSONRequestBody = CreatePenaltyRuleRequest
 // PatchPenaltyRuleJSONRequestBody defines body for PatchPenaltyRule for application/json ContentType.
 type PatchPenaltyRuleJSONRequestBody = UpdatePenaltyRuleRequest
 
+// PostTaskTemplateJSONRequestBody defines body for PostTaskTemplate for application/json ContentType.
+type PostTaskTemplateJSONRequestBody = CreateTaskTemplateRequest
+
+// PostTaskTemplateInstantiateJSONRequestBody defines body for PostTaskTemplateInstantiate for application/json ContentType.
+type PostTaskTemplateInstantiateJSONRequestBody = InstantiateTaskTemplateRequest
+
 // PostTaskJSONRequestBody defines body for PostTask for application/json ContentType.
 type PostTaskJSONRequestBody = CreateTaskRequest
 
 // PatchTaskJSONRequestBody defines body for PatchTask for application/json ContentType.
 type PatchTaskJSONRequestBody = UpdateTaskRequest
 
+// PostTaskChecklistItemJSONRequestBody defines body for PostTaskChecklistItem for application/json ContentType.
+type PostTaskChecklistItemJSONRequestBody = CreateTaskChecklistItemRequest
+
+// PatchTaskChecklistItemJSONRequestBody defines body for PatchTaskChecklistItem for application/json ContentType.
+type PatchTaskChecklistItemJSONRequestBody = UpdateTaskChecklistItemRequest
+
+// PostTaskChecklistItemToggleJSONRequestBody defines body for PostTaskChecklistItemToggle for application/json ContentType.
+type PostTaskChecklistItemToggleJSONRequestBody = ToggleTaskChecklistItemRequest
+
+// PostTaskCommentJSONRequestBody defines body for PostTaskComment for application/json ContentType.
+type PostTaskCommentJSONRequestBody = CreateTaskCommentRequest
+
+// PatchTaskCommentJSONRequestBody defines body for PatchTaskComment for application/json ContentType.
+type PatchTaskCommentJSONRequestBody = UpdateTaskCommentRequest
+
+// PostTaskCompletionEvidenceMultipartRequestBody defines body for PostTaskCompletionEvidence for multipart/form-data ContentType.
+type PostTaskCompletionEvidenceMultipartRequestBody = TaskCompletionEvidenceUpload
+
+// PostTaskCompletionReactionJSONRequestBody defines body for PostTaskCompletionReaction for application/json ContentType.
+type PostTaskCompletionReactionJSONRequestBody = ToggleCompletionReactionRequest
+
 // PostTaskCompletionToggleJSONRequestBody defines body for PostTaskCompletionToggle for application/json ContentType.
 type PostTaskCompletionToggleJSONRequestBody = ToggleTaskCompletionRequest
 
 // PatchTeamCurrentJSONRequestBody defines body for PatchTeamCurrent for application/json ContentType.
 type PatchTeamCurrentJSONRequestBody = UpdateCurrentTeamRequest
 
+// PostTeamAbsenceJSONRequestBody defines body for PostTeamAbsence for application/json ContentType.
+type PostTeamAbsenceJSONRequestBody = CreateTeamAbsenceRequest
+
+// PostTaskCategoryJSONRequestBody defines body for PostTaskCategory for application/json ContentType.
+type PostTaskCategoryJSONRequestBody = CreateTaskCategoryRequest
+
+// PatchTaskCategoryJSONRequestBody defines body for PatchTaskCategory for application/json ContentType.
+type PatchTaskCategoryJSONRequestBody = UpdateTaskCategoryRequest
+
+// PostTeamHolidayJSONRequestBody defines body for PostTeamHoliday for application/json ContentType.
+type PostTeamHolidayJSONRequestBody = CreateTeamHolidayRequest
+
+// ImportTeamHolidaysJSONRequestBody defines body for ImportTeamHolidays for application/json ContentType.
+type ImportTeamHolidaysJSONRequestBody = ImportTeamHolidaysRequest
+
+// PostTeamWebhookJSONRequestBody defines body for PostTeamWebhook for application/json ContentType.
+type PostTeamWebhookJSONRequestBody = CreateTeamWebhookRequest
+
+// PatchTeamWebhookJSONRequestBody defines body for PatchTeamWebhook for application/json ContentType.
+type PatchTeamWebhookJSONRequestBody = UpdateTeamWebhookRequest
+
 // PostTeamInviteJSONRequestBody defines body for PostTeamInvite for application/json ContentType.
 type PostTeamInviteJSONRequestBody = CreateInviteRequest
 
@@ -383,6 +1174,9 @@ type ServerInterface interface {
 	// Run month close now
 	// (POST /v1/admin/close-month)
 	PostAdminCloseMonth(c *gin.Context)
+	// List close run history and per-scope close status of the current team
+	// (GET /v1/admin/close-runs)
+	ListAdminCloseRuns(c *gin.Context, params ListAdminCloseRunsParams)
 	// Run week close now
 	// (POST /v1/admin/close-week)
 	PostAdminCloseWeek(c *gin.Context)
@@ -398,12 +1192,24 @@ type ServerInterface interface {
 	// Exchange one-time code for app session token
 	// (POST /v1/auth/sessions/exchange)
 	PostAuthSessionsExchange(c *gin.Context)
+	// Apply queued mutations in one request
+	// (POST /v1/batch)
+	PostBatch(c *gin.Context)
+	// Download a completion photo of current team
+	// (GET /v1/completion-attachments/{attachmentId})
+	GetCompletionAttachment(c *gin.Context, attachmentId string, params GetCompletionAttachmentParams)
+	// Delete the current user's account
+	// (DELETE /v1/me)
+	DeleteMe(c *gin.Context)
 	// Current user
 	// (GET /v1/me)
 	GetMe(c *gin.Context)
 	// Update current user color
 	// (PATCH /v1/me/color)
 	PatchMeColor(c *gin.Context)
+	// Download a copy of the personal data held about the current user
+	// (GET /v1/me/export)
+	GetMeExport(c *gin.Context, params GetMeExportParams)
 	// Update current user nickname
 	// (PATCH /v1/me/nickname)
 	PatchMeNickname(c *gin.Context)
@@ -422,6 +1228,18 @@ type ServerInterface interface {
 	// Monthly penalty summary
 	// (GET /v1/penalty-summaries/monthly)
 	GetPenaltySummaryMonthly(c *gin.Context, params GetPenaltySummaryMonthlyParams)
+	// List built-in templates and templates saved by current team
+	// (GET /v1/task-templates)
+	ListTaskTemplates(c *gin.Context)
+	// Save a template in current team
+	// (POST /v1/task-templates)
+	PostTaskTemplate(c *gin.Context)
+	// Delete a template saved by current team
+	// (DELETE /v1/task-templates/{templateId})
+	DeleteTaskTemplate(c *gin.Context, templateId string)
+	// Create the tasks of a template in current team
+	// (POST /v1/task-templates/{templateId}/instantiate)
+	PostTaskTemplateInstantiate(c *gin.Context, templateId string)
 	// List tasks in current team
 	// (GET /v1/tasks)
 	ListTasks(c *gin.Context, params ListTasksParams)
@@ -430,22 +1248,106 @@ type ServerInterface interface {
 	PostTask(c *gin.Context)
 	// Task overview payload
 	// (GET /v1/tasks/overview)
-	GetTaskOverview(c *gin.Context)
+	GetTaskOverview(c *gin.Context, params GetTaskOverviewParams)
 	// Delete task
 	// (DELETE /v1/tasks/{taskId})
 	DeleteTask(c *gin.Context, taskId string)
 	// Update task
 	// (PATCH /v1/tasks/{taskId})
 	PatchTask(c *gin.Context, taskId string)
+	// List checklist items of a task in order
+	// (GET /v1/tasks/{taskId}/checklist)
+	ListTaskChecklistItems(c *gin.Context, taskId string)
+	// Add a checklist item to a task
+	// (POST /v1/tasks/{taskId}/checklist)
+	PostTaskChecklistItem(c *gin.Context, taskId string)
+	// Delete a checklist item
+	// (DELETE /v1/tasks/{taskId}/checklist/{itemId})
+	DeleteTaskChecklistItem(c *gin.Context, taskId string, itemId string)
+	// Rename or move a checklist item
+	// (PATCH /v1/tasks/{taskId}/checklist/{itemId})
+	PatchTaskChecklistItem(c *gin.Context, taskId string, itemId string)
+	// Check or uncheck a checklist item in the target period
+	// (POST /v1/tasks/{taskId}/checklist/{itemId}/toggle)
+	PostTaskChecklistItemToggle(c *gin.Context, taskId string, itemId string)
+	// List comments of a task, newest first
+	// (GET /v1/tasks/{taskId}/comments)
+	ListTaskComments(c *gin.Context, taskId string, params ListTaskCommentsParams)
+	// Comment on a task
+	// (POST /v1/tasks/{taskId}/comments)
+	PostTaskComment(c *gin.Context, taskId string)
+	// Delete a comment (author only)
+	// (DELETE /v1/tasks/{taskId}/comments/{commentId})
+	DeleteTaskComment(c *gin.Context, taskId string, commentId string)
+	// Edit a comment (author only)
+	// (PATCH /v1/tasks/{taskId}/comments/{commentId})
+	PatchTaskComment(c *gin.Context, taskId string, commentId string)
+	// Attach a note and photo to a recorded completion
+	// (POST /v1/tasks/{taskId}/completions/evidence)
+	PostTaskCompletionEvidence(c *gin.Context, taskId string)
+	// Add or remove the current user's emoji reaction on a recorded completion
+	// (POST /v1/tasks/{taskId}/completions/reactions)
+	PostTaskCompletionReaction(c *gin.Context, taskId string)
 	// Update task completion in target period
 	// (POST /v1/tasks/{taskId}/completions/toggle)
 	PostTaskCompletionToggle(c *gin.Context, taskId string)
 	// Update current team name
 	// (PATCH /v1/teams/current)
 	PatchTeamCurrent(c *gin.Context)
+	// List absences of current team
+	// (GET /v1/teams/current/absences)
+	ListTeamAbsences(c *gin.Context, params ListTeamAbsencesParams)
+	// Register an absence for a member or the whole team
+	// (POST /v1/teams/current/absences)
+	PostTeamAbsence(c *gin.Context)
+	// Delete an absence (owner, or the absent member)
+	// (DELETE /v1/teams/current/absences/{absenceId})
+	DeleteTeamAbsence(c *gin.Context, absenceId string)
+	// List the current team's audit log, newest first
+	// (GET /v1/teams/current/activity)
+	ListTeamActivity(c *gin.Context, params ListTeamActivityParams)
+	// List task categories of current team
+	// (GET /v1/teams/current/categories)
+	ListTaskCategories(c *gin.Context)
+	// Create a task category in current team
+	// (POST /v1/teams/current/categories)
+	PostTaskCategory(c *gin.Context)
+	// Delete a task category
+	// (DELETE /v1/teams/current/categories/{categoryId})
+	DeleteTaskCategory(c *gin.Context, categoryId string)
+	// Update a task category
+	// (PATCH /v1/teams/current/categories/{categoryId})
+	PatchTaskCategory(c *gin.Context, categoryId string)
+	// List penalty-free holidays of current team
+	// (GET /v1/teams/current/holidays)
+	ListTeamHolidays(c *gin.Context, params ListTeamHolidaysParams)
+	// Add a penalty-free holiday to current team (owner only)
+	// (POST /v1/teams/current/holidays)
+	PostTeamHoliday(c *gin.Context)
+	// Import holidays from an iCalendar file (owner only)
+	// (POST /v1/teams/current/holidays/import)
+	ImportTeamHolidays(c *gin.Context)
+	// Remove a holiday from current team (owner only)
+	// (DELETE /v1/teams/current/holidays/{date})
+	DeleteTeamHoliday(c *gin.Context, date openapi_types.Date)
 	// List current team members by joined date
 	// (GET /v1/teams/current/members)
 	GetTeamCurrentMembers(c *gin.Context)
+	// List outbound webhooks of current team (owner only)
+	// (GET /v1/teams/current/webhooks)
+	ListTeamWebhooks(c *gin.Context)
+	// Register outbound webhook for current team (owner only)
+	// (POST /v1/teams/current/webhooks)
+	PostTeamWebhook(c *gin.Context)
+	// Delete outbound webhook (owner only)
+	// (DELETE /v1/teams/current/webhooks/{webhookId})
+	DeleteTeamWebhook(c *gin.Context, webhookId string)
+	// Update outbound webhook (owner only)
+	// (PATCH /v1/teams/current/webhooks/{webhookId})
+	PatchTeamWebhook(c *gin.Context, webhookId string)
+	// List recent deliveries of an outbound webhook (owner only)
+	// (GET /v1/teams/current/webhooks/{webhookId}/deliveries)
+	ListTeamWebhookDeliveries(c *gin.Context, webhookId string, params ListTeamWebhookDeliveriesParams)
 	// Create invite code
 	// (POST /v1/teams/invites)
 	PostTeamInvite(c *gin.Context)
@@ -512,17 +1414,45 @@ func (siw *ServerInterfaceWrapper) PostAdminCloseMonth(c *gin.Context) {
 	siw.Handler.PostAdminCloseMonth(c)
 }
 
-// PostAdminCloseWeek operation middleware
-func (siw *ServerInterfaceWrapper) PostAdminCloseWeek(c *gin.Context) {
+// ListAdminCloseRuns operation middleware
+func (siw *ServerInterfaceWrapper) ListAdminCloseRuns(c *gin.Context) {
+
+	var err error
 
 	c.Set(CookieAuthScopes, []string{})
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		middleware(c)
-		if c.IsAborted() {
-			return
-		}
-	}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListAdminCloseRunsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListAdminCloseRuns(c, params)
+}
+
+// PostAdminCloseWeek operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminCloseWeek(c *gin.Context) {
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
 
 	siw.Handler.PostAdminCloseWeek(c)
 }
@@ -616,6 +1546,73 @@ func (siw *ServerInterfaceWrapper) PostAuthSessionsExchange(c *gin.Context) {
 	siw.Handler.PostAuthSessionsExchange(c)
 }
 
+// PostBatch operation middleware
+func (siw *ServerInterfaceWrapper) PostBatch(c *gin.Context) {
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostBatch(c)
+}
+
+// GetCompletionAttachment operation middleware
+func (siw *ServerInterfaceWrapper) GetCompletionAttachment(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "attachmentId" -------------
+	var attachmentId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "attachmentId", c.Param("attachmentId"), &attachmentId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter attachmentId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetCompletionAttachmentParams
+
+	// ------------- Optional query parameter "variant" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "variant", c.Request.URL.Query(), &params.Variant)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter variant: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetCompletionAttachment(c, attachmentId, params)
+}
+
+// DeleteMe operation middleware
+func (siw *ServerInterfaceWrapper) DeleteMe(c *gin.Context) {
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteMe(c)
+}
+
 // GetMe operation middleware
 func (siw *ServerInterfaceWrapper) GetMe(c *gin.Context) {
 
@@ -646,6 +1643,34 @@ func (siw *ServerInterfaceWrapper) PatchMeColor(c *gin.Context) {
 	siw.Handler.PatchMeColor(c)
 }
 
+// GetMeExport operation middleware
+func (siw *ServerInterfaceWrapper) GetMeExport(c *gin.Context) {
+
+	var err error
+
+	c.Set(CookieAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetMeExportParams
+
+	// ------------- Optional query parameter "refresh" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "refresh", c.Request.URL.Query(), &params.Refresh)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter refresh: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetMeExport(c, params)
+}
+
 // PatchMeNickname operation middleware
 func (siw *ServerInterfaceWrapper) PatchMeNickname(c *gin.Context) {
 
@@ -784,6 +1809,88 @@ func (siw *ServerInterfaceWrapper) GetPenaltySummaryMonthly(c *gin.Context) {
 	siw.Handler.GetPenaltySummaryMonthly(c, params)
 }
 
+// ListTaskTemplates operation middleware
+func (siw *ServerInterfaceWrapper) ListTaskTemplates(c *gin.Context) {
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListTaskTemplates(c)
+}
+
+// PostTaskTemplate operation middleware
+func (siw *ServerInterfaceWrapper) PostTaskTemplate(c *gin.Context) {
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostTaskTemplate(c)
+}
+
+// DeleteTaskTemplate operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTaskTemplate(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "templateId" -------------
+	var templateId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "templateId", c.Param("templateId"), &templateId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter templateId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteTaskTemplate(c, templateId)
+}
+
+// PostTaskTemplateInstantiate operation middleware
+func (siw *ServerInterfaceWrapper) PostTaskTemplateInstantiate(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "templateId" -------------
+	var templateId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "templateId", c.Param("templateId"), &templateId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter templateId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostTaskTemplateInstantiate(c, templateId)
+}
+
 // ListTasks operation middleware
 func (siw *ServerInterfaceWrapper) ListTasks(c *gin.Context) {
 
@@ -802,6 +1909,14 @@ func (siw *ServerInterfaceWrapper) ListTasks(c *gin.Context) {
 		return
 	}
 
+	// ------------- Optional query parameter "categoryId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "categoryId", c.Request.URL.Query(), &params.CategoryId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter categoryId: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -830,8 +1945,21 @@ func (siw *ServerInterfaceWrapper) PostTask(c *gin.Context) {
 // GetTaskOverview operation middleware
 func (siw *ServerInterfaceWrapper) GetTaskOverview(c *gin.Context) {
 
+	var err error
+
 	c.Set(CookieAuthScopes, []string{})
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTaskOverviewParams
+
+	// ------------- Optional query parameter "categoryId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "categoryId", c.Request.URL.Query(), &params.CategoryId)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter categoryId: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -839,7 +1967,7 @@ func (siw *ServerInterfaceWrapper) GetTaskOverview(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetTaskOverview(c)
+	siw.Handler.GetTaskOverview(c, params)
 }
 
 // DeleteTask operation middleware
@@ -894,8 +2022,8 @@ func (siw *ServerInterfaceWrapper) PatchTask(c *gin.Context) {
 	siw.Handler.PatchTask(c, taskId)
 }
 
-// PostTaskCompletionToggle operation middleware
-func (siw *ServerInterfaceWrapper) PostTaskCompletionToggle(c *gin.Context) {
+// ListTaskChecklistItems operation middleware
+func (siw *ServerInterfaceWrapper) ListTaskChecklistItems(c *gin.Context) {
 
 	var err error
 
@@ -917,11 +2045,22 @@ func (siw *ServerInterfaceWrapper) PostTaskCompletionToggle(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PostTaskCompletionToggle(c, taskId)
+	siw.Handler.ListTaskChecklistItems(c, taskId)
 }
 
-// PatchTeamCurrent operation middleware
-func (siw *ServerInterfaceWrapper) PatchTeamCurrent(c *gin.Context) {
+// PostTaskChecklistItem operation middleware
+func (siw *ServerInterfaceWrapper) PostTaskChecklistItem(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "taskId" -------------
+	var taskId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "taskId", c.Param("taskId"), &taskId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter taskId: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	c.Set(CookieAuthScopes, []string{})
 
@@ -932,11 +2071,31 @@ func (siw *ServerInterfaceWrapper) PatchTeamCurrent(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.PatchTeamCurrent(c)
+	siw.Handler.PostTaskChecklistItem(c, taskId)
 }
 
-// GetTeamCurrentMembers operation middleware
-func (siw *ServerInterfaceWrapper) GetTeamCurrentMembers(c *gin.Context) {
+// DeleteTaskChecklistItem operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTaskChecklistItem(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "taskId" -------------
+	var taskId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "taskId", c.Param("taskId"), &taskId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter taskId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "itemId" -------------
+	var itemId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "itemId", c.Param("itemId"), &itemId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter itemId: %w", err), http.StatusBadRequest)
+		return
+	}
 
 	c.Set(CookieAuthScopes, []string{})
 
@@ -947,7 +2106,724 @@ func (siw *ServerInterfaceWrapper) GetTeamCurrentMembers(c *gin.Context) {
 		}
 	}
 
-	siw.Handler.GetTeamCurrentMembers(c)
+	siw.Handler.DeleteTaskChecklistItem(c, taskId, itemId)
+}
+
+// PatchTaskChecklistItem operation middleware
+func (siw *ServerInterfaceWrapper) PatchTaskChecklistItem(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "taskId" -------------
+	var taskId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "taskId", c.Param("taskId"), &taskId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter taskId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "itemId" -------------
+	var itemId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "itemId", c.Param("itemId"), &itemId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter itemId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PatchTaskChecklistItem(c, taskId, itemId)
+}
+
+// PostTaskChecklistItemToggle operation middleware
+func (siw *ServerInterfaceWrapper) PostTaskChecklistItemToggle(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "taskId" -------------
+	var taskId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "taskId", c.Param("taskId"), &taskId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter taskId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "itemId" -------------
+	var itemId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "itemId", c.Param("itemId"), &itemId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter itemId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostTaskChecklistItemToggle(c, taskId, itemId)
+}
+
+// ListTaskComments operation middleware
+func (siw *ServerInterfaceWrapper) ListTaskComments(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "taskId" -------------
+	var taskId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "taskId", c.Param("taskId"), &taskId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter taskId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListTaskCommentsParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListTaskComments(c, taskId, params)
+}
+
+// PostTaskComment operation middleware
+func (siw *ServerInterfaceWrapper) PostTaskComment(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "taskId" -------------
+	var taskId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "taskId", c.Param("taskId"), &taskId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter taskId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostTaskComment(c, taskId)
+}
+
+// DeleteTaskComment operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTaskComment(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "taskId" -------------
+	var taskId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "taskId", c.Param("taskId"), &taskId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter taskId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "commentId" -------------
+	var commentId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "commentId", c.Param("commentId"), &commentId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter commentId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteTaskComment(c, taskId, commentId)
+}
+
+// PatchTaskComment operation middleware
+func (siw *ServerInterfaceWrapper) PatchTaskComment(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "taskId" -------------
+	var taskId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "taskId", c.Param("taskId"), &taskId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter taskId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Path parameter "commentId" -------------
+	var commentId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "commentId", c.Param("commentId"), &commentId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter commentId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PatchTaskComment(c, taskId, commentId)
+}
+
+// PostTaskCompletionEvidence operation middleware
+func (siw *ServerInterfaceWrapper) PostTaskCompletionEvidence(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "taskId" -------------
+	var taskId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "taskId", c.Param("taskId"), &taskId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter taskId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostTaskCompletionEvidence(c, taskId)
+}
+
+// PostTaskCompletionReaction operation middleware
+func (siw *ServerInterfaceWrapper) PostTaskCompletionReaction(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "taskId" -------------
+	var taskId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "taskId", c.Param("taskId"), &taskId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter taskId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostTaskCompletionReaction(c, taskId)
+}
+
+// PostTaskCompletionToggle operation middleware
+func (siw *ServerInterfaceWrapper) PostTaskCompletionToggle(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "taskId" -------------
+	var taskId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "taskId", c.Param("taskId"), &taskId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter taskId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostTaskCompletionToggle(c, taskId)
+}
+
+// PatchTeamCurrent operation middleware
+func (siw *ServerInterfaceWrapper) PatchTeamCurrent(c *gin.Context) {
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PatchTeamCurrent(c)
+}
+
+// ListTeamAbsences operation middleware
+func (siw *ServerInterfaceWrapper) ListTeamAbsences(c *gin.Context) {
+
+	var err error
+
+	c.Set(CookieAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListTeamAbsencesParams
+
+	// ------------- Optional query parameter "includePast" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "includePast", c.Request.URL.Query(), &params.IncludePast)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter includePast: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListTeamAbsences(c, params)
+}
+
+// PostTeamAbsence operation middleware
+func (siw *ServerInterfaceWrapper) PostTeamAbsence(c *gin.Context) {
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostTeamAbsence(c)
+}
+
+// DeleteTeamAbsence operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTeamAbsence(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "absenceId" -------------
+	var absenceId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "absenceId", c.Param("absenceId"), &absenceId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter absenceId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteTeamAbsence(c, absenceId)
+}
+
+// ListTeamActivity operation middleware
+func (siw *ServerInterfaceWrapper) ListTeamActivity(c *gin.Context) {
+
+	var err error
+
+	c.Set(CookieAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListTeamActivityParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListTeamActivity(c, params)
+}
+
+// ListTaskCategories operation middleware
+func (siw *ServerInterfaceWrapper) ListTaskCategories(c *gin.Context) {
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListTaskCategories(c)
+}
+
+// PostTaskCategory operation middleware
+func (siw *ServerInterfaceWrapper) PostTaskCategory(c *gin.Context) {
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostTaskCategory(c)
+}
+
+// DeleteTaskCategory operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTaskCategory(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "categoryId" -------------
+	var categoryId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "categoryId", c.Param("categoryId"), &categoryId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter categoryId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteTaskCategory(c, categoryId)
+}
+
+// PatchTaskCategory operation middleware
+func (siw *ServerInterfaceWrapper) PatchTaskCategory(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "categoryId" -------------
+	var categoryId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "categoryId", c.Param("categoryId"), &categoryId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter categoryId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PatchTaskCategory(c, categoryId)
+}
+
+// ListTeamHolidays operation middleware
+func (siw *ServerInterfaceWrapper) ListTeamHolidays(c *gin.Context) {
+
+	var err error
+
+	c.Set(CookieAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListTeamHolidaysParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", c.Request.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter from: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", c.Request.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter to: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListTeamHolidays(c, params)
+}
+
+// PostTeamHoliday operation middleware
+func (siw *ServerInterfaceWrapper) PostTeamHoliday(c *gin.Context) {
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostTeamHoliday(c)
+}
+
+// ImportTeamHolidays operation middleware
+func (siw *ServerInterfaceWrapper) ImportTeamHolidays(c *gin.Context) {
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ImportTeamHolidays(c)
+}
+
+// DeleteTeamHoliday operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTeamHoliday(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "date" -------------
+	var date openapi_types.Date
+
+	err = runtime.BindStyledParameterWithOptions("simple", "date", c.Param("date"), &date, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter date: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteTeamHoliday(c, date)
+}
+
+// GetTeamCurrentMembers operation middleware
+func (siw *ServerInterfaceWrapper) GetTeamCurrentMembers(c *gin.Context) {
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetTeamCurrentMembers(c)
+}
+
+// ListTeamWebhooks operation middleware
+func (siw *ServerInterfaceWrapper) ListTeamWebhooks(c *gin.Context) {
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListTeamWebhooks(c)
+}
+
+// PostTeamWebhook operation middleware
+func (siw *ServerInterfaceWrapper) PostTeamWebhook(c *gin.Context) {
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PostTeamWebhook(c)
+}
+
+// DeleteTeamWebhook operation middleware
+func (siw *ServerInterfaceWrapper) DeleteTeamWebhook(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "webhookId" -------------
+	var webhookId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "webhookId", c.Param("webhookId"), &webhookId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter webhookId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.DeleteTeamWebhook(c, webhookId)
+}
+
+// PatchTeamWebhook operation middleware
+func (siw *ServerInterfaceWrapper) PatchTeamWebhook(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "webhookId" -------------
+	var webhookId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "webhookId", c.Param("webhookId"), &webhookId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter webhookId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.PatchTeamWebhook(c, webhookId)
+}
+
+// ListTeamWebhookDeliveries operation middleware
+func (siw *ServerInterfaceWrapper) ListTeamWebhookDeliveries(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "webhookId" -------------
+	var webhookId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "webhookId", c.Param("webhookId"), &webhookId, runtime.BindStyledParameterOptions{Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter webhookId: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	c.Set(CookieAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListTeamWebhookDeliveriesParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListTeamWebhookDeliveries(c, webhookId, params)
 }
 
 // PostTeamInvite operation middleware
@@ -1040,27 +2916,64 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 	router.GET(options.BaseURL+"/health", wrapper.Health)
 	router.POST(options.BaseURL+"/v1/admin/close-day", wrapper.PostAdminCloseDay)
 	router.POST(options.BaseURL+"/v1/admin/close-month", wrapper.PostAdminCloseMonth)
+	router.GET(options.BaseURL+"/v1/admin/close-runs", wrapper.ListAdminCloseRuns)
 	router.POST(options.BaseURL+"/v1/admin/close-week", wrapper.PostAdminCloseWeek)
 	router.GET(options.BaseURL+"/v1/auth/google/callback", wrapper.GetAuthGoogleCallback)
 	router.GET(options.BaseURL+"/v1/auth/google/start", wrapper.GetAuthGoogleStart)
 	router.POST(options.BaseURL+"/v1/auth/logout", wrapper.PostAuthLogout)
 	router.POST(options.BaseURL+"/v1/auth/sessions/exchange", wrapper.PostAuthSessionsExchange)
+	router.POST(options.BaseURL+"/v1/batch", wrapper.PostBatch)
+	router.GET(options.BaseURL+"/v1/completion-attachments/:attachmentId", wrapper.GetCompletionAttachment)
+	router.DELETE(options.BaseURL+"/v1/me", wrapper.DeleteMe)
 	router.GET(options.BaseURL+"/v1/me", wrapper.GetMe)
 	router.PATCH(options.BaseURL+"/v1/me/color", wrapper.PatchMeColor)
+	router.GET(options.BaseURL+"/v1/me/export", wrapper.GetMeExport)
 	router.PATCH(options.BaseURL+"/v1/me/nickname", wrapper.PatchMeNickname)
 	router.GET(options.BaseURL+"/v1/penalty-rules", wrapper.ListPenaltyRules)
 	router.POST(options.BaseURL+"/v1/penalty-rules", wrapper.PostPenaltyRule)
 	router.DELETE(options.BaseURL+"/v1/penalty-rules/:ruleId", wrapper.DeletePenaltyRule)
 	router.PATCH(options.BaseURL+"/v1/penalty-rules/:ruleId", wrapper.PatchPenaltyRule)
 	router.GET(options.BaseURL+"/v1/penalty-summaries/monthly", wrapper.GetPenaltySummaryMonthly)
+	router.GET(options.BaseURL+"/v1/task-templates", wrapper.ListTaskTemplates)
+	router.POST(options.BaseURL+"/v1/task-templates", wrapper.PostTaskTemplate)
+	router.DELETE(options.BaseURL+"/v1/task-templates/:templateId", wrapper.DeleteTaskTemplate)
+	router.POST(options.BaseURL+"/v1/task-templates/:templateId/instantiate", wrapper.PostTaskTemplateInstantiate)
 	router.GET(options.BaseURL+"/v1/tasks", wrapper.ListTasks)
 	router.POST(options.BaseURL+"/v1/tasks", wrapper.PostTask)
 	router.GET(options.BaseURL+"/v1/tasks/overview", wrapper.GetTaskOverview)
 	router.DELETE(options.BaseURL+"/v1/tasks/:taskId", wrapper.DeleteTask)
 	router.PATCH(options.BaseURL+"/v1/tasks/:taskId", wrapper.PatchTask)
+	router.GET(options.BaseURL+"/v1/tasks/:taskId/checklist", wrapper.ListTaskChecklistItems)
+	router.POST(options.BaseURL+"/v1/tasks/:taskId/checklist", wrapper.PostTaskChecklistItem)
+	router.DELETE(options.BaseURL+"/v1/tasks/:taskId/checklist/:itemId", wrapper.DeleteTaskChecklistItem)
+	router.PATCH(options.BaseURL+"/v1/tasks/:taskId/checklist/:itemId", wrapper.PatchTaskChecklistItem)
+	router.POST(options.BaseURL+"/v1/tasks/:taskId/checklist/:itemId/toggle", wrapper.PostTaskChecklistItemToggle)
+	router.GET(options.BaseURL+"/v1/tasks/:taskId/comments", wrapper.ListTaskComments)
+	router.POST(options.BaseURL+"/v1/tasks/:taskId/comments", wrapper.PostTaskComment)
+	router.DELETE(options.BaseURL+"/v1/tasks/:taskId/comments/:commentId", wrapper.DeleteTaskComment)
+	router.PATCH(options.BaseURL+"/v1/tasks/:taskId/comments/:commentId", wrapper.PatchTaskComment)
+	router.POST(options.BaseURL+"/v1/tasks/:taskId/completions/evidence", wrapper.PostTaskCompletionEvidence)
+	router.POST(options.BaseURL+"/v1/tasks/:taskId/completions/reactions", wrapper.PostTaskCompletionReaction)
 	router.POST(options.BaseURL+"/v1/tasks/:taskId/completions/toggle", wrapper.PostTaskCompletionToggle)
 	router.PATCH(options.BaseURL+"/v1/teams/current", wrapper.PatchTeamCurrent)
+	router.GET(options.BaseURL+"/v1/teams/current/absences", wrapper.ListTeamAbsences)
+	router.POST(options.BaseURL+"/v1/teams/current/absences", wrapper.PostTeamAbsence)
+	router.DELETE(options.BaseURL+"/v1/teams/current/absences/:absenceId", wrapper.DeleteTeamAbsence)
+	router.GET(options.BaseURL+"/v1/teams/current/activity", wrapper.ListTeamActivity)
+	router.GET(options.BaseURL+"/v1/teams/current/categories", wrapper.ListTaskCategories)
+	router.POST(options.BaseURL+"/v1/teams/current/categories", wrapper.PostTaskCategory)
+	router.DELETE(options.BaseURL+"/v1/teams/current/categories/:categoryId", wrapper.DeleteTaskCategory)
+	router.PATCH(options.BaseURL+"/v1/teams/current/categories/:categoryId", wrapper.PatchTaskCategory)
+	router.GET(options.BaseURL+"/v1/teams/current/holidays", wrapper.ListTeamHolidays)
+	router.POST(options.BaseURL+"/v1/teams/current/holidays", wrapper.PostTeamHoliday)
+	router.POST(options.BaseURL+"/v1/teams/current/holidays/import", wrapper.ImportTeamHolidays)
+	router.DELETE(options.BaseURL+"/v1/teams/current/holidays/:date", wrapper.DeleteTeamHoliday)
 	router.GET(options.BaseURL+"/v1/teams/current/members", wrapper.GetTeamCurrentMembers)
+	router.GET(options.BaseURL+"/v1/teams/current/webhooks", wrapper.ListTeamWebhooks)
+	router.POST(options.BaseURL+"/v1/teams/current/webhooks", wrapper.PostTeamWebhook)
+	router.DELETE(options.BaseURL+"/v1/teams/current/webhooks/:webhookId", wrapper.DeleteTeamWebhook)
+	router.PATCH(options.BaseURL+"/v1/teams/current/webhooks/:webhookId", wrapper.PatchTeamWebhook)
+	router.GET(options.BaseURL+"/v1/teams/current/webhooks/:webhookId/deliveries", wrapper.ListTeamWebhookDeliveries)
 	router.POST(options.BaseURL+"/v1/teams/invites", wrapper.PostTeamInvite)
 	router.GET(options.BaseURL+"/v1/teams/invites/current", wrapper.GetTeamCurrentInvite)
 	router.POST(options.BaseURL+"/v1/teams/join", wrapper.PostTeamJoin)
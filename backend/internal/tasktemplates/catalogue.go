@@ -0,0 +1,77 @@
+package tasktemplates
+
+// builtin is the catalogue shared by every team. IDs are part of the API and
+// the seeder's --pack flag, so keep them stable when editing entries.
+var builtin = []Template{
+	{
+		ID:          "builtin-dishes",
+		Name:        "食器洗い",
+		Description: "毎日の食器洗い",
+		Items: []Item{
+			{Title: "食器洗い", Type: TypeDaily, PenaltyPoints: 1, Notes: "シンクを空にするまで"},
+		},
+	},
+	{
+		ID:          "builtin-trash",
+		Name:        "ゴミ出し",
+		Description: "週2回のゴミ出し",
+		Items: []Item{
+			{Title: "ゴミ出し", Type: TypeWeekly, PenaltyPoints: 2, RequiredCompletionsPerWeek: 2},
+		},
+	},
+	{
+		ID:          "builtin-laundry",
+		Name:        "洗濯",
+		Description: "週3回の洗濯",
+		Items: []Item{
+			{Title: "洗濯", Type: TypeWeekly, PenaltyPoints: 2, RequiredCompletionsPerWeek: 3, Notes: "干して取り込むまで"},
+		},
+	},
+	{
+		ID:          "builtin-bath",
+		Name:        "お風呂掃除",
+		Description: "週1回のお風呂掃除",
+		Items: []Item{
+			{
+				Title: "お風呂掃除", Type: TypeWeekly, PenaltyPoints: 3, RequiredCompletionsPerWeek: 1,
+				Checklist: []string{"浴槽を洗う", "床と壁を洗う", "排水口の髪の毛を取る"},
+			},
+		},
+	},
+	{
+		ID:          "builtin-vacuum",
+		Name:        "掃除機がけ",
+		Description: "週2回の掃除機がけ",
+		Items: []Item{
+			{Title: "掃除機がけ", Type: TypeWeekly, PenaltyPoints: 2, RequiredCompletionsPerWeek: 2},
+		},
+	},
+	{
+		ID:          "builtin-new-apartment-basics",
+		Name:        "新生活の基本セット",
+		Description: "引っ越し直後に揃えておきたい家事と手続き",
+		Items: []Item{
+			{Title: "食器洗い", Type: TypeDaily, PenaltyPoints: 1},
+			{Title: "ゴミ出し", Type: TypeWeekly, PenaltyPoints: 2, RequiredCompletionsPerWeek: 2},
+			{Title: "洗濯", Type: TypeWeekly, PenaltyPoints: 2, RequiredCompletionsPerWeek: 3},
+			{
+				Title: "お風呂掃除", Type: TypeWeekly, PenaltyPoints: 3, RequiredCompletionsPerWeek: 1,
+				Checklist: []string{"浴槽を洗う", "床と壁を洗う", "排水口の髪の毛を取る"},
+			},
+			{
+				Title: "住所変更の手続き", Type: TypeOneOff, PenaltyPoints: 5, DueInDays: 14,
+				Checklist: []string{"転入届を出す", "運転免許証の住所を変える", "銀行とカードの住所を変える", "郵便の転送を届け出る"},
+			},
+		},
+	},
+	{
+		ID:          "builtin-weekend-reset",
+		Name:        "週末リセット",
+		Description: "週に一度まとめて片付ける家事",
+		Items: []Item{
+			{Title: "シーツの洗濯", Type: TypeWeekly, PenaltyPoints: 2, RequiredCompletionsPerWeek: 1},
+			{Title: "冷蔵庫の整理", Type: TypeWeekly, PenaltyPoints: 1, RequiredCompletionsPerWeek: 1, Notes: "期限切れの食品を捨てる"},
+			{Title: "トイレ掃除", Type: TypeWeekly, PenaltyPoints: 2, RequiredCompletionsPerWeek: 1},
+		},
+	},
+}
@@ -0,0 +1,178 @@
+// Package tasktemplates defines task templates and the built-in catalogue
+// offered to every team. A template holds one task (a single template) or
+// several (a starter pack such as "new apartment basics"); teams can save
+// their own templates next to the built-in ones. The seeder reuses the same
+// catalogue so sample data matches what users can pick in the app.
+package tasktemplates
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	TypeDaily  = "daily"
+	TypeWeekly = "weekly"
+	TypeOneOff = "one_off"
+
+	// BuiltinIDPrefix marks the IDs of built-in templates. Team templates use
+	// UUIDs, so the two never collide.
+	BuiltinIDPrefix = "builtin-"
+
+	NameMaxLength           = 50
+	DescriptionMaxLength    = 200
+	MaxItems                = 30
+	TitleMaxLength          = 100
+	NotesMaxLength          = 500
+	PenaltyPointsMax        = 1000
+	RequiredCompletionsMax  = 7
+	DueInDaysMax            = 365
+	ChecklistMaxItems       = 20
+	ChecklistTitleMaxLength = 100
+)
+
+// Item is one task a template creates. RequiredCompletionsPerWeek applies to
+// weekly tasks only and DueInDays to one_off tasks only: the task is due that
+// many days after the template is used.
+type Item struct {
+	Title                      string
+	Notes                      string
+	Type                       string
+	PenaltyPoints              int
+	RequiredCompletionsPerWeek int
+	DueInDays                  int
+	Checklist                  []string
+}
+
+type Template struct {
+	ID          string
+	Name        string
+	Description string
+	Items       []Item
+}
+
+// IsBuiltinID reports whether id names a built-in template, whether or not
+// one with that ID exists.
+func IsBuiltinID(id string) bool {
+	return strings.HasPrefix(id, BuiltinIDPrefix)
+}
+
+// Builtin returns a copy of the built-in catalogue, single templates first.
+func Builtin() []Template {
+	out := make([]Template, 0, len(builtin))
+	for _, t := range builtin {
+		out = append(out, t.clone())
+	}
+	return out
+}
+
+// BuiltinByID returns a copy of the built-in template with the given ID.
+func BuiltinByID(id string) (Template, bool) {
+	for _, t := range builtin {
+		if t.ID == id {
+			return t.clone(), true
+		}
+	}
+	return Template{}, false
+}
+
+func (t Template) clone() Template {
+	items := make([]Item, len(t.Items))
+	for i, item := range t.Items {
+		item.Checklist = append([]string(nil), item.Checklist...)
+		items[i] = item
+	}
+	t.Items = items
+	return t
+}
+
+// Normalize trims the template's text fields and checks them against the
+// limits tasks and checklists have, so every template can be instantiated.
+func (t Template) Normalize() (Template, error) {
+	t.Name = strings.TrimSpace(t.Name)
+	t.Description = strings.TrimSpace(t.Description)
+	if t.Name == "" {
+		return Template{}, errors.New("invalid template: name is required")
+	}
+	if utf8.RuneCountInString(t.Name) > NameMaxLength {
+		return Template{}, fmt.Errorf("invalid template: name must be at most %d characters", NameMaxLength)
+	}
+	if utf8.RuneCountInString(t.Description) > DescriptionMaxLength {
+		return Template{}, fmt.Errorf("invalid template: description must be at most %d characters", DescriptionMaxLength)
+	}
+	if len(t.Items) == 0 || len(t.Items) > MaxItems {
+		return Template{}, fmt.Errorf("invalid template: must have between 1 and %d tasks", MaxItems)
+	}
+	items := make([]Item, 0, len(t.Items))
+	for i, item := range t.Items {
+		normalized, err := item.Normalize()
+		if err != nil {
+			return Template{}, fmt.Errorf("%w (task %d)", err, i+1)
+		}
+		items = append(items, normalized)
+	}
+	t.Items = items
+	return t, nil
+}
+
+// Normalize trims the item's text fields, fills in the defaults for
+// RequiredCompletionsPerWeek and checks the ranges per task type.
+func (i Item) Normalize() (Item, error) {
+	i.Title = strings.TrimSpace(i.Title)
+	i.Notes = strings.TrimSpace(i.Notes)
+	if i.Title == "" {
+		return Item{}, errors.New("invalid template item: title is required")
+	}
+	if utf8.RuneCountInString(i.Title) > TitleMaxLength {
+		return Item{}, fmt.Errorf("invalid template item: title must be at most %d characters", TitleMaxLength)
+	}
+	if utf8.RuneCountInString(i.Notes) > NotesMaxLength {
+		return Item{}, fmt.Errorf("invalid template item: notes must be at most %d characters", NotesMaxLength)
+	}
+	if i.PenaltyPoints < 0 || i.PenaltyPoints > PenaltyPointsMax {
+		return Item{}, fmt.Errorf("invalid template item: penalty points must be between 0 and %d", PenaltyPointsMax)
+	}
+	switch i.Type {
+	case TypeWeekly:
+		if i.RequiredCompletionsPerWeek == 0 {
+			i.RequiredCompletionsPerWeek = 1
+		}
+		if i.RequiredCompletionsPerWeek < 1 || i.RequiredCompletionsPerWeek > RequiredCompletionsMax {
+			return Item{}, fmt.Errorf("invalid template item: required completions per week must be between 1 and %d", RequiredCompletionsMax)
+		}
+		if i.DueInDays != 0 {
+			return Item{}, errors.New("invalid template item: dueInDays is only supported for one_off tasks")
+		}
+	case TypeDaily, TypeOneOff:
+		if i.RequiredCompletionsPerWeek > 1 {
+			return Item{}, errors.New("invalid template item: required completions per week is only supported for weekly tasks")
+		}
+		i.RequiredCompletionsPerWeek = 1
+		if i.Type == TypeDaily && i.DueInDays != 0 {
+			return Item{}, errors.New("invalid template item: dueInDays is only supported for one_off tasks")
+		}
+		if i.DueInDays < 0 || i.DueInDays > DueInDaysMax {
+			return Item{}, fmt.Errorf("invalid template item: dueInDays must be between 0 and %d", DueInDaysMax)
+		}
+	default:
+		return Item{}, fmt.Errorf("invalid template item: unsupported type %q", i.Type)
+	}
+	if len(i.Checklist) > ChecklistMaxItems {
+		return Item{}, fmt.Errorf("invalid template item: at most %d checklist items", ChecklistMaxItems)
+	}
+	checklist := make([]string, 0, len(i.Checklist))
+	for _, raw := range i.Checklist {
+		title := strings.TrimSpace(raw)
+		if title == "" {
+			return Item{}, errors.New("invalid template item: checklist item title is required")
+		}
+		if utf8.RuneCountInString(title) > ChecklistTitleMaxLength {
+			return Item{}, fmt.Errorf("invalid template item: checklist item title must be at most %d characters", ChecklistTitleMaxLength)
+		}
+		checklist = append(checklist, title)
+	}
+	i.Checklist = checklist
+	return i, nil
+}
@@ -0,0 +1,60 @@
+package tasktemplates
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuiltinCatalogueIsValid(t *testing.T) {
+	seen := map[string]bool{}
+	for _, tmpl := range Builtin() {
+		if !IsBuiltinID(tmpl.ID) {
+			t.Fatalf("expected %q to use the built-in prefix", tmpl.ID)
+		}
+		if seen[tmpl.ID] {
+			t.Fatalf("duplicate built-in template %q", tmpl.ID)
+		}
+		seen[tmpl.ID] = true
+		if _, err := tmpl.Normalize(); err != nil {
+			t.Fatalf("built-in template %q is invalid: %v", tmpl.ID, err)
+		}
+	}
+	if _, ok := BuiltinByID("builtin-new-apartment-basics"); !ok {
+		t.Fatalf("expected the seeder's default pack to exist")
+	}
+}
+
+func TestBuiltinReturnsCopies(t *testing.T) {
+	tmpl, _ := BuiltinByID("builtin-bath")
+	tmpl.Items[0].Checklist[0] = "changed"
+	again, _ := BuiltinByID("builtin-bath")
+	if again.Items[0].Checklist[0] == "changed" {
+		t.Fatalf("expected callers not to share the catalogue's slices")
+	}
+}
+
+func TestItemNormalize(t *testing.T) {
+	item, err := Item{Title: "  Trash ", Type: TypeWeekly, Checklist: []string{" bag "}}.Normalize()
+	if err != nil || item.Title != "Trash" || item.RequiredCompletionsPerWeek != 1 || item.Checklist[0] != "bag" {
+		t.Fatalf("expected trimming and weekly defaults, got %+v, %v", item, err)
+	}
+	for name, invalid := range map[string]Item{
+		"blank title":        {Title: " ", Type: TypeDaily},
+		"unknown type":       {Title: "x", Type: "monthly"},
+		"negative penalty":   {Title: "x", Type: TypeDaily, PenaltyPoints: -1},
+		"daily due":          {Title: "x", Type: TypeDaily, DueInDays: 3},
+		"weekly count":       {Title: "x", Type: TypeWeekly, RequiredCompletionsPerWeek: 8},
+		"one_off count":      {Title: "x", Type: TypeOneOff, RequiredCompletionsPerWeek: 2},
+		"far due":            {Title: "x", Type: TypeOneOff, DueInDays: DueInDaysMax + 1},
+		"long title":         {Title: strings.Repeat("あ", TitleMaxLength+1), Type: TypeDaily},
+		"blank checklist":    {Title: "x", Type: TypeDaily, Checklist: []string{" "}},
+		"too many checklist": {Title: "x", Type: TypeDaily, Checklist: make([]string, ChecklistMaxItems+1)},
+	} {
+		if _, err := invalid.Normalize(); err == nil || !strings.HasPrefix(err.Error(), "invalid") {
+			t.Fatalf("%s: expected an invalid error, got %v", name, err)
+		}
+	}
+	if _, err := (Template{Name: "empty"}).Normalize(); err == nil {
+		t.Fatalf("expected a template without tasks to be rejected")
+	}
+}
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS team_webhook_deliveries;
+DROP TABLE IF EXISTS team_webhooks;
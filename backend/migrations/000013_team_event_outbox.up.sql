@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS team_event_outbox (
+  id BIGSERIAL PRIMARY KEY,
+  team_id UUID NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+  entity TEXT NOT NULL,
+  revision BIGINT NOT NULL,
+  hints JSONB NOT NULL DEFAULT '{}'::jsonb,
+  changed_at TIMESTAMPTZ NOT NULL,
+  attempt_count INTEGER NOT NULL DEFAULT 0 CHECK (attempt_count >= 0),
+  last_error TEXT,
+  dispatched_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX IF NOT EXISTS idx_team_event_outbox_pending
+  ON team_event_outbox (id)
+  WHERE dispatched_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_team_event_outbox_dispatched_at
+  ON team_event_outbox (dispatched_at)
+  WHERE dispatched_at IS NOT NULL;
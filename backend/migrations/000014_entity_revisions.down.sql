@@ -0,0 +1,3 @@
+ALTER TABLE teams DROP COLUMN IF EXISTS settings_revision;
+ALTER TABLE penalty_rules DROP COLUMN IF EXISTS revision;
+ALTER TABLE tasks DROP COLUMN IF EXISTS revision;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS close_run_history;
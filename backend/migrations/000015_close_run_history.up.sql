@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS close_run_history (
+  id UUID PRIMARY KEY,
+  team_id UUID NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+  scope TEXT NOT NULL CHECK (scope IN ('day', 'week', 'month')),
+  trigger TEXT NOT NULL CHECK (trigger IN ('ops', 'admin', 'scheduler')),
+  as_of TIMESTAMPTZ NOT NULL,
+  started_at TIMESTAMPTZ NOT NULL,
+  finished_at TIMESTAMPTZ NOT NULL,
+  periods_processed INTEGER NOT NULL DEFAULT 0 CHECK (periods_processed >= 0),
+  penalty_added INTEGER NOT NULL DEFAULT 0,
+  penalized_tasks INTEGER NOT NULL DEFAULT 0 CHECK (penalized_tasks >= 0),
+  error_class TEXT,
+  error_message TEXT
+);
+CREATE INDEX IF NOT EXISTS idx_close_run_history_team_started
+  ON close_run_history (team_id, started_at DESC);
+CREATE INDEX IF NOT EXISTS idx_close_run_history_team_scope_started
+  ON close_run_history (team_id, scope, started_at DESC);
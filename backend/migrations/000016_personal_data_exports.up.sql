@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS personal_data_exports (
+  id UUID PRIMARY KEY,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'ready', 'failed')),
+  payload BYTEA,
+  attempt_count INTEGER NOT NULL DEFAULT 0 CHECK (attempt_count >= 0),
+  next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  last_error TEXT,
+  requested_at TIMESTAMPTZ NOT NULL,
+  completed_at TIMESTAMPTZ,
+  expires_at TIMESTAMPTZ,
+  CHECK (status <> 'ready' OR (payload IS NOT NULL AND expires_at IS NOT NULL))
+);
+CREATE INDEX IF NOT EXISTS idx_personal_data_exports_user_requested
+  ON personal_data_exports (user_id, requested_at DESC);
+CREATE INDEX IF NOT EXISTS idx_personal_data_exports_pending
+  ON personal_data_exports (next_attempt_at)
+  WHERE status = 'pending';
+CREATE UNIQUE INDEX IF NOT EXISTS uq_personal_data_exports_user_pending
+  ON personal_data_exports (user_id)
+  WHERE status = 'pending';
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS team_absences (
+  id UUID PRIMARY KEY,
+  team_id UUID NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+  user_id UUID,
+  starts_on DATE NOT NULL,
+  ends_on DATE NOT NULL,
+  reason TEXT,
+  created_by_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+  created_at TIMESTAMPTZ NOT NULL,
+  CHECK (ends_on >= starts_on),
+  FOREIGN KEY (team_id, user_id)
+    REFERENCES team_members(team_id, user_id)
+    ON DELETE CASCADE
+);
+CREATE INDEX IF NOT EXISTS idx_team_absences_team_ends_on ON team_absences (team_id, ends_on);
@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS team_holidays (
+  team_id UUID NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+  holiday_date DATE NOT NULL,
+  name TEXT NOT NULL,
+  source TEXT NOT NULL CHECK (source IN ('manual', 'ical')),
+  created_by_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+  created_at TIMESTAMPTZ NOT NULL,
+  PRIMARY KEY (team_id, holiday_date)
+);
@@ -0,0 +1,26 @@
+DROP TABLE IF EXISTS task_completion_one_off;
+
+DELETE FROM tasks
+WHERE type = 'one_off';
+
+DROP INDEX IF EXISTS idx_tasks_team_due_on;
+
+ALTER TABLE tasks
+  DROP CONSTRAINT IF EXISTS tasks_due_on_check;
+
+ALTER TABLE tasks
+  DROP CONSTRAINT IF EXISTS tasks_check;
+
+ALTER TABLE tasks
+  ADD CONSTRAINT tasks_check
+  CHECK ((type = 'daily' AND required_completions_per_week = 1) OR type = 'weekly');
+
+ALTER TABLE tasks
+  DROP CONSTRAINT IF EXISTS tasks_type_check;
+
+ALTER TABLE tasks
+  ADD CONSTRAINT tasks_type_check
+  CHECK (type IN ('daily', 'weekly'));
+
+ALTER TABLE tasks
+  DROP COLUMN IF EXISTS due_on;
@@ -0,0 +1,33 @@
+ALTER TABLE tasks
+  ADD COLUMN IF NOT EXISTS due_on DATE;
+
+ALTER TABLE tasks
+  DROP CONSTRAINT IF EXISTS tasks_type_check;
+
+ALTER TABLE tasks
+  ADD CONSTRAINT tasks_type_check
+  CHECK (type IN ('daily', 'weekly', 'one_off'));
+
+ALTER TABLE tasks
+  DROP CONSTRAINT IF EXISTS tasks_check;
+
+ALTER TABLE tasks
+  ADD CONSTRAINT tasks_check
+  CHECK ((type IN ('daily', 'one_off') AND required_completions_per_week = 1) OR type = 'weekly');
+
+ALTER TABLE tasks
+  ADD CONSTRAINT tasks_due_on_check
+  CHECK ((type = 'one_off') = (due_on IS NOT NULL));
+
+CREATE INDEX IF NOT EXISTS idx_tasks_team_due_on
+  ON tasks (team_id, due_on)
+  WHERE type = 'one_off';
+
+CREATE TABLE IF NOT EXISTS task_completion_one_off (
+  task_id UUID PRIMARY KEY REFERENCES tasks(id) ON DELETE CASCADE,
+  completed_by_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+  completed_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_completion_one_off_user
+  ON task_completion_one_off (completed_by_user_id);
@@ -0,0 +1,9 @@
+ALTER TABLE tasks
+  DROP CONSTRAINT IF EXISTS tasks_active_window_type_check;
+
+ALTER TABLE tasks
+  DROP CONSTRAINT IF EXISTS tasks_active_window_check;
+
+ALTER TABLE tasks
+  DROP COLUMN IF EXISTS ends_on,
+  DROP COLUMN IF EXISTS starts_on;
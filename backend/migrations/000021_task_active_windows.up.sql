@@ -0,0 +1,11 @@
+ALTER TABLE tasks
+  ADD COLUMN IF NOT EXISTS starts_on DATE,
+  ADD COLUMN IF NOT EXISTS ends_on DATE;
+
+ALTER TABLE tasks
+  ADD CONSTRAINT tasks_active_window_check
+  CHECK (starts_on IS NULL OR ends_on IS NULL OR starts_on <= ends_on);
+
+ALTER TABLE tasks
+  ADD CONSTRAINT tasks_active_window_type_check
+  CHECK (type <> 'one_off' OR (starts_on IS NULL AND ends_on IS NULL));
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_tasks_category_id;
+
+ALTER TABLE tasks
+  DROP COLUMN IF EXISTS category_id;
+
+DROP TABLE IF EXISTS task_categories;
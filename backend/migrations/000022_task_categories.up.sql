@@ -0,0 +1,19 @@
+CREATE TABLE IF NOT EXISTS task_categories (
+  id UUID PRIMARY KEY,
+  team_id UUID NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+  name TEXT NOT NULL,
+  color_hex TEXT CHECK (color_hex IS NULL OR color_hex ~ '^#[0-9A-F]{6}$'),
+  icon TEXT,
+  created_at TIMESTAMPTZ NOT NULL,
+  updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_task_categories_team_name
+  ON task_categories (team_id, lower(name));
+
+ALTER TABLE tasks
+  ADD COLUMN IF NOT EXISTS category_id UUID REFERENCES task_categories(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_category_id
+  ON tasks (category_id)
+  WHERE category_id IS NOT NULL;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS task_checklist_checks;
+
+DROP TABLE IF EXISTS task_checklist_items;
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS task_checklist_items (
+  id UUID PRIMARY KEY,
+  task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+  title TEXT NOT NULL,
+  position INTEGER NOT NULL CHECK (position >= 0),
+  created_at TIMESTAMPTZ NOT NULL,
+  updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_checklist_items_task_position
+  ON task_checklist_items (task_id, position);
+
+CREATE TABLE IF NOT EXISTS task_checklist_checks (
+  item_id UUID NOT NULL REFERENCES task_checklist_items(id) ON DELETE CASCADE,
+  period_start DATE NOT NULL,
+  checked_by_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+  checked_at TIMESTAMPTZ NOT NULL,
+  PRIMARY KEY (item_id, period_start)
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_checklist_checks_user
+  ON task_checklist_checks (checked_by_user_id);
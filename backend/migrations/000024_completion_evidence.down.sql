@@ -0,0 +1,13 @@
+ALTER TABLE task_completion_one_off
+  DROP COLUMN IF EXISTS attachment_id,
+  DROP COLUMN IF EXISTS note;
+
+ALTER TABLE task_completion_weekly_entries
+  DROP COLUMN IF EXISTS attachment_id,
+  DROP COLUMN IF EXISTS note;
+
+ALTER TABLE task_completion_daily
+  DROP COLUMN IF EXISTS attachment_id,
+  DROP COLUMN IF EXISTS note;
+
+DROP TABLE IF EXISTS completion_attachments;
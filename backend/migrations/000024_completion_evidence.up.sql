@@ -0,0 +1,40 @@
+CREATE TABLE IF NOT EXISTS completion_attachments (
+  id UUID PRIMARY KEY,
+  team_id UUID REFERENCES teams(id) ON DELETE SET NULL,
+  task_id UUID REFERENCES tasks(id) ON DELETE SET NULL,
+  blob_key TEXT NOT NULL,
+  thumbnail_key TEXT NOT NULL,
+  content_type TEXT NOT NULL,
+  size_bytes INTEGER NOT NULL CHECK (size_bytes > 0),
+  width INTEGER NOT NULL CHECK (width > 0),
+  height INTEGER NOT NULL CHECK (height > 0),
+  uploaded_by_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+  created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_completion_attachments_team
+  ON completion_attachments (team_id);
+
+CREATE INDEX IF NOT EXISTS idx_completion_attachments_created_at
+  ON completion_attachments (created_at);
+
+ALTER TABLE task_completion_daily
+  ADD COLUMN IF NOT EXISTS note TEXT,
+  ADD COLUMN IF NOT EXISTS attachment_id UUID REFERENCES completion_attachments(id) ON DELETE SET NULL;
+
+ALTER TABLE task_completion_weekly_entries
+  ADD COLUMN IF NOT EXISTS note TEXT,
+  ADD COLUMN IF NOT EXISTS attachment_id UUID REFERENCES completion_attachments(id) ON DELETE SET NULL;
+
+ALTER TABLE task_completion_one_off
+  ADD COLUMN IF NOT EXISTS note TEXT,
+  ADD COLUMN IF NOT EXISTS attachment_id UUID REFERENCES completion_attachments(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_task_completion_daily_attachment
+  ON task_completion_daily (attachment_id);
+
+CREATE INDEX IF NOT EXISTS idx_task_completion_weekly_entries_attachment
+  ON task_completion_weekly_entries (attachment_id);
+
+CREATE INDEX IF NOT EXISTS idx_task_completion_one_off_attachment
+  ON task_completion_one_off (attachment_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS completion_reactions;
+
+DROP TABLE IF EXISTS task_comments;
@@ -0,0 +1,42 @@
+CREATE TABLE IF NOT EXISTS task_comments (
+  id UUID PRIMARY KEY,
+  task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+  author_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+  body TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL,
+  edited_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_comments_task_created
+  ON task_comments (task_id, created_at DESC, id DESC);
+
+CREATE INDEX IF NOT EXISTS idx_task_comments_author
+  ON task_comments (author_user_id);
+
+CREATE TABLE IF NOT EXISTS completion_reactions (
+  id UUID PRIMARY KEY,
+  task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+  target_date DATE,
+  weekly_entry_id UUID REFERENCES task_completion_weekly_entries(id) ON DELETE CASCADE,
+  one_off_task_id UUID REFERENCES task_completion_one_off(task_id) ON DELETE CASCADE,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  emoji TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL,
+  FOREIGN KEY (task_id, target_date) REFERENCES task_completion_daily(task_id, target_date) ON DELETE CASCADE,
+  CHECK (num_nonnulls(target_date, weekly_entry_id, one_off_task_id) = 1)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_completion_reactions_daily
+  ON completion_reactions (task_id, target_date, user_id, emoji)
+  WHERE target_date IS NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_completion_reactions_weekly
+  ON completion_reactions (weekly_entry_id, user_id, emoji)
+  WHERE weekly_entry_id IS NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS uq_completion_reactions_one_off
+  ON completion_reactions (one_off_task_id, user_id, emoji)
+  WHERE one_off_task_id IS NOT NULL;
+
+CREATE INDEX IF NOT EXISTS idx_completion_reactions_user
+  ON completion_reactions (user_id);
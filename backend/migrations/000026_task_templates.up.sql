@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS task_templates (
+  id UUID PRIMARY KEY,
+  team_id UUID NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+  name TEXT NOT NULL,
+  description TEXT NOT NULL DEFAULT '',
+  created_by_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+  created_at TIMESTAMPTZ NOT NULL,
+  updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_task_templates_team_name
+  ON task_templates (team_id, lower(name));
+
+CREATE INDEX IF NOT EXISTS idx_task_templates_created_by
+  ON task_templates (created_by_user_id);
+
+CREATE TABLE IF NOT EXISTS task_template_items (
+  id UUID PRIMARY KEY,
+  template_id UUID NOT NULL REFERENCES task_templates(id) ON DELETE CASCADE,
+  position INTEGER NOT NULL CHECK (position >= 0),
+  title TEXT NOT NULL,
+  notes TEXT NOT NULL DEFAULT '',
+  type TEXT NOT NULL CHECK (type IN ('daily', 'weekly', 'one_off')),
+  penalty_points INTEGER NOT NULL CHECK (penalty_points >= 0),
+  required_completions_per_week INTEGER NOT NULL CHECK (required_completions_per_week BETWEEN 1 AND 7),
+  due_in_days INTEGER CHECK (due_in_days IS NULL OR due_in_days >= 0),
+  checklist TEXT[] NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_template_items_template_position
+  ON task_template_items (template_id, position);
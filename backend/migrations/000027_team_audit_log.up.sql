@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS team_audit_log (
+  id UUID PRIMARY KEY,
+  team_id UUID NOT NULL REFERENCES teams(id) ON DELETE CASCADE,
+  actor_user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+  entity TEXT NOT NULL,
+  entity_id TEXT,
+  action TEXT NOT NULL,
+  before JSONB,
+  after JSONB,
+  revision BIGINT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_team_audit_log_team_created
+  ON team_audit_log (team_id, created_at DESC, id DESC);
+
+CREATE INDEX IF NOT EXISTS idx_team_audit_log_actor
+  ON team_audit_log (actor_user_id);
+
+CREATE INDEX IF NOT EXISTS idx_team_audit_log_created
+  ON team_audit_log (created_at);
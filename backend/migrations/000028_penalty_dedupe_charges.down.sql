@@ -0,0 +1,3 @@
+ALTER TABLE task_evaluation_dedupes
+  DROP COLUMN IF EXISTS category_id,
+  DROP COLUMN IF EXISTS penalty_points;
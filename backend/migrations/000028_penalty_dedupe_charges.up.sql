@@ -0,0 +1,14 @@
+ALTER TABLE task_evaluation_dedupes
+  ADD COLUMN IF NOT EXISTS penalty_points INTEGER,
+  ADD COLUMN IF NOT EXISTS category_id UUID REFERENCES task_categories(id) ON DELETE SET NULL;
+
+UPDATE task_evaluation_dedupes e
+SET penalty_points = t.penalty_points,
+    category_id = t.category_id
+FROM tasks t
+WHERE t.id = e.task_id
+  AND e.penalty_points IS NULL;
+
+ALTER TABLE task_evaluation_dedupes
+  ALTER COLUMN penalty_points SET DEFAULT 0,
+  ALTER COLUMN penalty_points SET NOT NULL;
@@ -0,0 +1,4 @@
+ALTER TABLE task_comments DROP COLUMN IF EXISTS revision;
+ALTER TABLE team_holidays DROP COLUMN IF EXISTS revision;
+ALTER TABLE team_absences DROP COLUMN IF EXISTS revision;
+ALTER TABLE task_categories DROP COLUMN IF EXISTS revision;
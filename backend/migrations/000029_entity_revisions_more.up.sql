@@ -0,0 +1,4 @@
+ALTER TABLE task_categories ADD COLUMN IF NOT EXISTS revision BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE team_absences ADD COLUMN IF NOT EXISTS revision BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE team_holidays ADD COLUMN IF NOT EXISTS revision BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE task_comments ADD COLUMN IF NOT EXISTS revision BIGINT NOT NULL DEFAULT 0;
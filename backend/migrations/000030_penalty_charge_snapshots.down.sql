@@ -0,0 +1,12 @@
+DELETE FROM task_evaluation_dedupes e
+WHERE NOT EXISTS (SELECT 1 FROM tasks t WHERE t.id = e.task_id);
+
+UPDATE task_evaluation_dedupes e
+SET category_id = NULL
+WHERE e.category_id IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM task_categories c WHERE c.id = e.category_id);
+
+ALTER TABLE task_evaluation_dedupes
+  DROP COLUMN IF EXISTS category_name,
+  ADD CONSTRAINT task_evaluation_dedupes_task_id_fkey FOREIGN KEY (task_id) REFERENCES tasks(id) ON DELETE CASCADE,
+  ADD CONSTRAINT task_evaluation_dedupes_category_id_fkey FOREIGN KEY (category_id) REFERENCES task_categories(id) ON DELETE SET NULL;
@@ -0,0 +1,10 @@
+ALTER TABLE task_evaluation_dedupes
+  DROP CONSTRAINT IF EXISTS task_evaluation_dedupes_task_id_fkey,
+  DROP CONSTRAINT IF EXISTS task_evaluation_dedupes_category_id_fkey,
+  ADD COLUMN IF NOT EXISTS category_name TEXT;
+
+UPDATE task_evaluation_dedupes e
+SET category_name = c.name
+FROM task_categories c
+WHERE c.id = e.category_id
+  AND e.category_name IS NULL;